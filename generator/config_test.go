@@ -0,0 +1,526 @@
+// Copyright 2023 Google Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package main
+
+import (
+	"flag"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/openconfig/gnmi/errdiff"
+	"github.com/openconfig/ygot/genutil"
+	"github.com/openconfig/ygot/gogen"
+	"github.com/openconfig/ygot/ygen"
+)
+
+func TestParseConfig(t *testing.T) {
+	tests := []struct {
+		name             string
+		in               string
+		want             *generatorConfig
+		wantErrSubstring string
+	}{{
+		name: "yaml with all job kinds",
+		in: `
+version: 1
+jobs:
+- name: structs
+  kind: go_structs
+  modules: [a.yang]
+  paths: [yang]
+  package_name: oc
+  output_dir: oc
+  split_files_count: 2
+  compress_paths: true
+  go_structs:
+    generate_getters: true
+    include_schema: false
+- name: paths
+  kind: path_structs
+  modules: [a.yang]
+  package_name: ocpath
+  output_file: ocpath.go
+  compress_paths: true
+  path_structs:
+    schema_struct_path: example.com/oc
+- name: proto
+  kind: proto
+  modules: [a.yang]
+  package_name: openconfig
+  output_dir: proto
+`,
+		want: &generatorConfig{
+			Version: 1,
+			Jobs: []*jobConfig{{
+				Name:            "structs",
+				Kind:            goStructsJob,
+				Modules:         []string{"a.yang"},
+				Paths:           []string{"yang"},
+				PackageName:     "oc",
+				OutputDir:       "oc",
+				SplitFilesCount: 2,
+				CompressPaths:   true,
+				GoStructs: &goStructsConfig{
+					GenerateGetters: true,
+					IncludeSchema:   new(bool),
+				},
+			}, {
+				Name:          "paths",
+				Kind:          pathStructsJob,
+				Modules:       []string{"a.yang"},
+				PackageName:   "ocpath",
+				OutputFile:    "ocpath.go",
+				CompressPaths: true,
+				PathStructs: &pathStructsConfig{
+					SchemaStructPath: "example.com/oc",
+				},
+			}, {
+				Name:        "proto",
+				Kind:        protoJob,
+				Modules:     []string{"a.yang"},
+				PackageName: "openconfig",
+				OutputDir:   "proto",
+			}},
+		},
+	}, {
+		name: "json",
+		in:   `{"version": 1, "jobs": [{"name": "structs", "kind": "go_structs", "modules": ["a.yang"], "package_name": "oc", "output_file": "-"}]}`,
+		want: &generatorConfig{
+			Version: 1,
+			Jobs: []*jobConfig{{
+				Name:        "structs",
+				Kind:        goStructsJob,
+				Modules:     []string{"a.yang"},
+				PackageName: "oc",
+				OutputFile:  "-",
+			}},
+		},
+	}, {
+		name: "path structs in the same package as structs",
+		in: `
+version: 1
+jobs:
+- {name: structs, kind: go_structs, modules: [a.yang], package_name: oc, output_file: oc.go, compress_paths: true}
+- {name: paths, kind: path_structs, modules: [a.yang], package_name: oc, output_file: paths.go, compress_paths: true}
+`,
+		want: &generatorConfig{
+			Version: 1,
+			Jobs: []*jobConfig{{
+				Name:          "structs",
+				Kind:          goStructsJob,
+				Modules:       []string{"a.yang"},
+				PackageName:   "oc",
+				OutputFile:    "oc.go",
+				CompressPaths: true,
+			}, {
+				Name:          "paths",
+				Kind:          pathStructsJob,
+				Modules:       []string{"a.yang"},
+				PackageName:   "oc",
+				OutputFile:    "paths.go",
+				CompressPaths: true,
+			}},
+		},
+	}, {
+		name:             "empty",
+		in:               "",
+		wantErrSubstring: "configuration is empty",
+	}, {
+		name:             "unknown field",
+		in:               "version: 1\njobs:\n- name: a\n  kind: go_structs\n  generate_structs: true\n",
+		wantErrSubstring: "field generate_structs not found",
+	}, {
+		name:             "unsupported version",
+		in:               "version: 2\n",
+		wantErrSubstring: "unsupported version 2",
+	}, {
+		name:             "no jobs",
+		in:               "version: 1\n",
+		wantErrSubstring: "no jobs specified",
+	}, {
+		name:             "job without name",
+		in:               "version: 1\njobs:\n- kind: go_structs\n",
+		wantErrSubstring: "job 0 does not have a name",
+	}, {
+		name: "duplicate job names",
+		in: `
+version: 1
+jobs:
+- {name: a, kind: go_structs, modules: [a.yang], package_name: oc, output_file: a.go}
+- {name: a, kind: go_structs, modules: [a.yang], package_name: oc, output_file: b.go}
+`,
+		wantErrSubstring: `duplicate job name "a"`,
+	}, {
+		name:             "invalid kind",
+		in:               "version: 1\njobs:\n- {name: a, kind: structs}\n",
+		wantErrSubstring: `invalid kind "structs"`,
+	}, {
+		name:             "options for another kind",
+		in:               "version: 1\njobs:\n- {name: a, kind: go_structs, path_structs: {}}\n",
+		wantErrSubstring: "path_structs options cannot be specified for a go_structs job",
+	}, {
+		name:             "name lock for proto",
+		in:               "version: 1\njobs:\n- {name: a, kind: proto, modules: [a.yang], package_name: oc, output_dir: oc, name_lock_file: names.lock}\n",
+		wantErrSubstring: "name_lock_file cannot be specified for a proto job",
+	}, {
+		name:             "name overrides for proto",
+		in:               "version: 1\njobs:\n- {name: a, kind: proto, modules: [a.yang], package_name: oc, output_dir: oc, name_overrides_file: names.json}\n",
+		wantErrSubstring: "name_overrides_file cannot be specified for a proto job",
+	}, {
+		name:             "no modules",
+		in:               "version: 1\njobs:\n- {name: a, kind: go_structs, package_name: oc, output_file: a.go}\n",
+		wantErrSubstring: `job "a": no input modules specified`,
+	}, {
+		name:             "no package name",
+		in:               "version: 1\njobs:\n- {name: a, kind: go_structs, modules: [a.yang], output_file: a.go}\n",
+		wantErrSubstring: "package_name must be specified",
+	}, {
+		name:             "prefer operational state without compression",
+		in:               "version: 1\njobs:\n- {name: a, kind: go_structs, modules: [a.yang], package_name: oc, output_file: a.go, prefer_operational_state: true}\n",
+		wantErrSubstring: "preferOperationalState is only compatible",
+	}, {
+		name:             "trim enum prefix without compression",
+		in:               "version: 1\njobs:\n- {name: a, kind: go_structs, modules: [a.yang], package_name: oc, output_file: a.go, trim_enum_openconfig_prefix: true}\n",
+		wantErrSubstring: "trim_enum_openconfig_prefix requires compress_paths",
+	}, {
+		name:             "enum suffix without typedef enum names",
+		in:               "version: 1\njobs:\n- {name: a, kind: go_structs, modules: [a.yang], package_name: oc, output_file: a.go, enum_suffix_for_simple_union_enums: true}\n",
+		wantErrSubstring: "enum_suffix_for_simple_union_enums requires typedef_enum_with_defmod",
+	}, {
+		name:             "structs with both outputs",
+		in:               "version: 1\njobs:\n- {name: a, kind: go_structs, modules: [a.yang], package_name: oc, output_file: a.go, output_dir: oc}\n",
+		wantErrSubstring: "cannot specify both output_file (a.go) and output_dir (oc)",
+	}, {
+		name:             "structs without output",
+		in:               "version: 1\njobs:\n- {name: a, kind: go_structs, modules: [a.yang], package_name: oc}\n",
+		wantErrSubstring: "an output_file or output_dir must be specified",
+	}, {
+		name:             "structs output directory without file count",
+		in:               "version: 1\njobs:\n- {name: a, kind: go_structs, modules: [a.yang], package_name: oc, output_dir: oc}\n",
+		wantErrSubstring: "split_files_count must be specified with output_dir",
+	}, {
+		name:             "structs split by module without base import path",
+		in:               "version: 1\njobs:\n- {name: a, kind: go_structs, modules: [a.yang], package_name: oc, output_dir: oc, go_structs: {split_structs_by_module: true}}\n",
+		wantErrSubstring: "split_structs_by_module requires output_dir and base_import_path",
+	}, {
+		name:             "structs split by module with file count",
+		in:               "version: 1\njobs:\n- {name: a, kind: go_structs, modules: [a.yang], package_name: oc, output_dir: oc, split_files_count: 2, go_structs: {split_structs_by_module: true, base_import_path: example.com/oc}}\n",
+		wantErrSubstring: "split_files_count cannot be specified with split_structs_by_module",
+	}, {
+		name:             "structs package prefix without split by module",
+		in:               "version: 1\njobs:\n- {name: a, kind: go_structs, modules: [a.yang], package_name: oc, output_file: a.go, go_structs: {trim_structs_package_prefix: openconfig-}}\n",
+		wantErrSubstring: "require split_structs_by_module",
+	}, {
+		name:             "structs shared package is the root package",
+		in:               "version: 1\njobs:\n- {name: a, kind: go_structs, modules: [a.yang], package_name: oc, output_dir: oc, go_structs: {split_structs_by_module: true, base_import_path: example.com/oc, structs_shared_package_name: oc}}\n",
+		wantErrSubstring: "structs_shared_package_name cannot be the same as package_name",
+	}, {
+		name:             "ignore shadow paths without compression",
+		in:               "version: 1\njobs:\n- {name: a, kind: go_structs, modules: [a.yang], package_name: oc, output_file: a.go, go_structs: {ignore_shadow_schema_paths: true}}\n",
+		wantErrSubstring: "ignore_shadow_schema_paths requires compress_paths",
+	}, {
+		name:             "annotation prefix without annotations",
+		in:               "version: 1\njobs:\n- {name: a, kind: go_structs, modules: [a.yang], package_name: oc, output_file: a.go, go_structs: {annotation_prefix: X}}\n",
+		wantErrSubstring: "annotation_prefix requires annotations",
+	}, {
+		name:             "path structs without compression",
+		in:               "version: 1\njobs:\n- {name: a, kind: path_structs, modules: [a.yang], package_name: oc, output_file: a.go}\n",
+		wantErrSubstring: "not supported for uncompressed paths",
+	}, {
+		name:             "path structs without schema struct path",
+		in:               "version: 1\njobs:\n- {name: a, kind: path_structs, modules: [a.yang], package_name: oc, output_file: a.go, compress_paths: true}\n",
+		wantErrSubstring: "schema_struct_path must be specified",
+	}, {
+		name: "path structs with schema struct path in structs package",
+		in: `
+version: 1
+jobs:
+- {name: structs, kind: go_structs, modules: [a.yang], package_name: oc, output_file: oc.go}
+- {name: paths, kind: path_structs, modules: [a.yang], package_name: oc, output_file: paths.go, compress_paths: true, path_structs: {schema_struct_path: example.com/oc}}
+`,
+		wantErrSubstring: "schema_struct_path cannot be specified when package oc is generated by a go_structs job",
+	}, {
+		name:             "path structs split by module without base import path",
+		in:               "version: 1\njobs:\n- {name: a, kind: path_structs, modules: [a.yang], package_name: oc, output_file: a.go, output_dir: oc, compress_paths: true, path_structs: {schema_struct_path: p, split_pathstructs_by_module: true}}\n",
+		wantErrSubstring: "base_import_path must be specified",
+	}, {
+		name:             "path structs split by module without output directory",
+		in:               "version: 1\njobs:\n- {name: a, kind: path_structs, modules: [a.yang], package_name: oc, output_file: a.go, compress_paths: true, path_structs: {schema_struct_path: p, split_pathstructs_by_module: true, base_import_path: b}}\n",
+		wantErrSubstring: "both output_file and output_dir must be specified",
+	}, {
+		name:             "path structs package suffix without split by module",
+		in:               "version: 1\njobs:\n- {name: a, kind: path_structs, modules: [a.yang], package_name: oc, output_file: a.go, compress_paths: true, path_structs: {schema_struct_path: p, path_struct_package_suffix: s}}\n",
+		wantErrSubstring: "require split_pathstructs_by_module",
+	}, {
+		name:             "path structs list builder without wildcards",
+		in:               "version: 1\njobs:\n- {name: a, kind: path_structs, modules: [a.yang], package_name: oc, output_file: a.go, compress_paths: true, path_structs: {schema_struct_path: p, generate_wildcard_paths: false, list_builder_key_threshold: 2}}\n",
+		wantErrSubstring: "require generate_wildcard_paths",
+	}, {
+		name:             "path structs with fake root",
+		in:               "version: 1\njobs:\n- {name: a, kind: path_structs, modules: [a.yang], package_name: oc, output_file: a.go, compress_paths: true, generate_fakeroot: true, path_structs: {schema_struct_path: p}}\n",
+		wantErrSubstring: "generate_fakeroot cannot be specified",
+	}, {
+		name:             "path structs with operations",
+		in:               "version: 1\njobs:\n- {name: a, kind: path_structs, modules: [a.yang], package_name: oc, output_file: a.go, compress_paths: true, generate_operations: true, path_structs: {schema_struct_path: p}}\n",
+		wantErrSubstring: "generate_operations cannot be specified",
+	}, {
+		name:             "proto without output directory",
+		in:               "version: 1\njobs:\n- {name: a, kind: proto, modules: [a.yang], package_name: oc}\n",
+		wantErrSubstring: "an output_dir must be specified",
+	}, {
+		name:             "proto with output file",
+		in:               "version: 1\njobs:\n- {name: a, kind: proto, modules: [a.yang], package_name: oc, output_dir: p, output_file: a.proto}\n",
+		wantErrSubstring: "output_file cannot be specified",
+	}, {
+		name:             "proto with unsupported enum option",
+		in:               "version: 1\njobs:\n- {name: a, kind: proto, modules: [a.yang], package_name: oc, output_dir: p, shorten_enum_leaf_names: true}\n",
+		wantErrSubstring: "are not supported",
+	}, {
+		name:             "ir without output file",
+		in:               "version: 1\njobs:\n- {name: a, kind: ir, modules: [a.yang]}\n",
+		wantErrSubstring: "an output_file must be specified",
+	}, {
+		name:             "ir with package name",
+		in:               "version: 1\njobs:\n- {name: a, kind: ir, modules: [a.yang], package_name: oc, output_file: ir.json}\n",
+		wantErrSubstring: "package_name and ygot_path cannot be specified",
+	}}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := parseConfig([]byte(tt.in))
+			if diff := errdiff.Substring(err, tt.wantErrSubstring); diff != "" {
+				t.Fatalf("parseConfig: %s", diff)
+			}
+			if diff := cmp.Diff(tt.want, got); diff != "" {
+				t.Errorf("parseConfig: did not get expected config, diff(-want, +got):\n%s", diff)
+			}
+		})
+	}
+}
+
+func TestJobOptions(t *testing.T) {
+	j := &jobConfig{
+		Name:                     "structs",
+		Kind:                     goStructsJob,
+		ExcludeModules:           []string{"excluded"},
+		PathAllowlist:            []string{"/interfaces"},
+		PackageName:              "oc",
+		IgnoreCircDeps:           true,
+		CompressPaths:            true,
+		PreferOperationalState:   true,
+		GenerateFakeRoot:         true,
+		FakeRootName:             "Root",
+		GenerateOperations:       true,
+		TrimEnumOpenConfigPrefix: true,
+		GoStructs:                &goStructsConfig{GenerateGetters: true},
+	}
+
+	gotIR := j.irOptions()
+	if got, want := gotIR.TransformationOptions.CompressBehaviour, genutil.PreferOperationalState; got != want {
+		t.Errorf("irOptions: got CompressBehaviour %v, want %v", got, want)
+	}
+	wantTransform := ygen.TransformationOpts{
+		CompressBehaviour:          genutil.PreferOperationalState,
+		GenerateFakeRoot:           true,
+		FakeRootName:               "Root",
+		EnumOrgPrefixesToTrim:      []string{"openconfig"},
+		EnumerationsUseUnderscores: true,
+		PathAllowlist:              []string{"/interfaces"},
+		GenerateOperations:         true,
+	}
+	if diff := cmp.Diff(wantTransform, gotIR.TransformationOptions); diff != "" {
+		t.Errorf("irOptions: did not get expected TransformationOpts, diff(-want, +got):\n%s", diff)
+	}
+	if !gotIR.ParseOptions.YANGParseOptions.IgnoreSubmoduleCircularDependencies {
+		t.Errorf("irOptions: IgnoreSubmoduleCircularDependencies not set")
+	}
+
+	wantGo := gogen.GoOpts{
+		PackageName:          "oc",
+		GenerateJSONSchema:   true,
+		YgotImportPath:       genutil.GoDefaultYgotImportPath,
+		YtypesImportPath:     genutil.GoDefaultYtypesImportPath,
+		GoyangImportPath:     genutil.GoDefaultGoyangImportPath,
+		AnnotationPrefix:     gogen.DefaultAnnotationPrefix,
+		GenerateGetters:      true,
+		ValidateFunctionName: "Validate",
+	}
+	if diff := cmp.Diff(wantGo, j.goOpts()); diff != "" {
+		t.Errorf("goOpts: did not get expected options, diff(-want, +got):\n%s", diff)
+	}
+
+	j.Kind = pathStructsJob
+	j.GoStructs = nil
+	pcg := j.pathGenConfig()
+	if !pcg.GenerateWildcardPaths || pcg.PathStructSuffix != "Path" || pcg.PackageSuffix != "path" || !pcg.PreferOperationalState {
+		t.Errorf("pathGenConfig: did not get expected defaults, got %+v", pcg)
+	}
+	if diff := cmp.Diff([]string{"/interfaces"}, pcg.PathAllowlist); diff != "" {
+		t.Errorf("pathGenConfig: did not get expected PathAllowlist, diff(-want, +got):\n%s", diff)
+	}
+
+	j.Kind = protoJob
+	if got := j.irOptions().TransformationOptions.EnumerationsUseUnderscores; got {
+		t.Errorf("irOptions: got EnumerationsUseUnderscores %v for proto job, want false", got)
+	}
+	po := j.protoOpts()
+	if !po.AnnotateSchemaPaths || !po.AnnotateEnumNames || !po.NestedMessages || po.EnumPackageName != "enums" {
+		t.Errorf("protoOpts: did not get expected defaults, got %+v", po)
+	}
+}
+
+func TestLoadConfigAndRun(t *testing.T) {
+	dir := t.TempDir()
+	modules, err := filepath.Abs(filepath.Join("..", "testdata", "modules"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	cfg := `
+version: 1
+jobs:
+- name: structs
+  kind: go_structs
+  modules: [` + filepath.Join(modules, "openconfig-simple.yang") + `]
+  package_name: oc
+  output_dir: oc
+  split_files_count: 1
+  compress_paths: true
+  generate_fakeroot: true
+  name_lock_file: names.lock
+- name: paths
+  kind: path_structs
+  modules: [` + filepath.Join(modules, "openconfig-simple.yang") + `]
+  package_name: oc
+  output_file: oc/paths.go
+  compress_paths: true
+- name: proto
+  kind: proto
+  modules: [` + filepath.Join(modules, "openconfig-simple.yang") + `]
+  package_name: openconfig
+  output_dir: proto
+  compress_paths: true
+- name: ir
+  kind: ir
+  modules: [` + filepath.Join(modules, "openconfig-simple.yang") + `]
+  output_file: ir.json
+  compress_paths: true
+  generate_fakeroot: true
+`
+	cfgFile := filepath.Join(dir, "config.yaml")
+	if err := os.WriteFile(cfgFile, []byte(cfg), 0644); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.MkdirAll(filepath.Join(dir, "oc"), 0755); err != nil {
+		t.Fatal(err)
+	}
+
+	c, err := loadConfig(cfgFile)
+	if err != nil {
+		t.Fatalf("loadConfig: %v", err)
+	}
+	if got, want := c.Jobs[0].OutputDir, filepath.Join(dir, "oc"); got != want {
+		t.Errorf("loadConfig: got output directory %s, want %s relative to configuration file", got, want)
+	}
+	for _, j := range c.Jobs {
+		if err := j.run(); err != nil {
+			t.Fatalf("job %s: %v", j.Name, err)
+		}
+	}
+
+	for _, f := range []string{"oc/structs-0.go", "oc/schema.go", "oc/paths.go", "proto/openconfig/openconfig.proto", "ir.json", "names.lock"} {
+		if _, err := os.Stat(filepath.Join(dir, f)); err != nil {
+			t.Errorf("did not find generated file %s: %v", f, err)
+		}
+	}
+
+	b, err := os.ReadFile(filepath.Join(dir, "ir.json"))
+	if err != nil {
+		t.Fatalf("cannot read serialised IR: %v", err)
+	}
+	ir, err := ygen.UnmarshalIR(b)
+	if err != nil {
+		t.Fatalf("UnmarshalIR: %v", err)
+	}
+	if _, ok := ir.Directories["/device"]; !ok {
+		t.Errorf("serialised IR does not contain the fake root, got directories %v", ir.OrderedDirectoryPaths())
+	}
+	if _, err := ir.SchemaTree(false); err != nil {
+		t.Errorf("serialised IR does not contain the schema tree: %v", err)
+	}
+
+	lock, err := readNameLock(filepath.Join(dir, "names.lock"))
+	if err != nil {
+		t.Fatalf("readNameLock: %v", err)
+	}
+	if got, want := lock.Directories["/device"], "Device"; got != want {
+		t.Errorf("name lock: got name %q for the fake root, want %q", got, want)
+	}
+	// Regenerating with the lock must assign the same names.
+	if err := c.Jobs[0].run(); err != nil {
+		t.Errorf("job %s with name lock: %v", c.Jobs[0].Name, err)
+	}
+}
+
+func TestLoadConfigErrors(t *testing.T) {
+	dir := t.TempDir()
+	if _, err := loadConfig(filepath.Join(dir, "missing.yaml")); err == nil {
+		t.Errorf("loadConfig: did not get expected error for missing file")
+	}
+	bad := filepath.Join(dir, "bad.yaml")
+	if err := os.WriteFile(bad, []byte("version: 3\n"), 0644); err != nil {
+		t.Fatal(err)
+	}
+	_, err := loadConfig(bad)
+	if diff := errdiff.Substring(err, "invalid configuration file "+bad); diff != "" {
+		t.Errorf("loadConfig: %s", diff)
+	}
+}
+
+func TestConfigFileConflicts(t *testing.T) {
+	tests := []struct {
+		name string
+		in   []string
+		want []string
+	}{{
+		name: "only config_file",
+		in:   []string{"-config_file=gen.yaml"},
+	}, {
+		name: "config_file with other flags",
+		in:   []string{"-config_file=gen.yaml", "-compress_paths=false", "-package_name=oc"},
+		want: []string{"compress_paths", "package_name"},
+	}, {
+		name: "flag set to its default value",
+		in:   []string{"-config_file", "gen.yaml", "-output_file="},
+		want: []string{"output_file"},
+	}}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			fs := flag.NewFlagSet("generator", flag.ContinueOnError)
+			fs.String("config_file", "", "")
+			fs.Bool("compress_paths", false, "")
+			fs.String("package_name", "ocstructs", "")
+			fs.String("output_file", "", "")
+			if err := fs.Parse(tt.in); err != nil {
+				t.Fatalf("cannot parse flags %v: %v", tt.in, err)
+			}
+			if diff := cmp.Diff(tt.want, configFileConflicts(fs)); diff != "" {
+				t.Errorf("configFileConflicts(%v): did not get expected flags, diff(-want, +got):\n%s", tt.in, diff)
+			}
+		})
+	}
+}
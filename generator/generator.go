@@ -18,9 +18,12 @@
 package main
 
 import (
+	"bytes"
+	"errors"
 	"flag"
 	"fmt"
 	"io"
+	"io/fs"
 	"math"
 	"os"
 	"path/filepath"
@@ -30,8 +33,10 @@ import (
 	"github.com/openconfig/goyang/pkg/yang"
 	"github.com/openconfig/ygot/genutil"
 	"github.com/openconfig/ygot/gogen"
+	"github.com/openconfig/ygot/protogen"
 	"github.com/openconfig/ygot/ygen"
 	"github.com/openconfig/ygot/ypathgen"
+	"gopkg.in/yaml.v3"
 )
 
 const (
@@ -52,6 +57,7 @@ const (
 )
 
 var (
+	configFile              = flag.String("config_file", "", "A YAML or JSON file describing the generation jobs to be run. If specified, the input modules and all options are read from the file rather than from the command line.")
 	generateGoStructs       = flag.Bool("generate_structs", true, "If true, then Go code for YANG path construction (schema/Go structs) will be generated.")
 	generatePathStructs     = flag.Bool("generate_path_structs", false, "If true, then Go code for YANG path construction (path structs) will be generated.")
 	ocStructsOutputFile     = flag.String("output_file", "", "The file that the generated Go code for manipulating YANG data (schema/Go structs) should be written to. Specify \"-\" for stdout.")
@@ -59,6 +65,8 @@ var (
 	ocPathStructsOutputFile = flag.String("path_structs_output_file", "", "The file that the generated Go code for YANG path construction (path structs) will be generated. If split_pathstructs_by_module=true, this file contains the fake root path struct. Specify \"-\" for stdout.")
 	pathStructsFileN        = flag.Int("path_structs_split_files_count", 0, "The number of files to split the generated path structs into when output_dir or split_pathstructs_by_module is specified for generating path structs")
 	outputDir               = flag.String("output_dir", "", "The directory that the generated Go code should be written to. This is common between schema structs and path structs. For path struct generation, if split_pathstructs_by_module=true, this directory is the base of the generated module packages.")
+	irOutputFile            = flag.String("ir_output_file", "", "If specified, the intermediate representation (IR) of the input modules, using Go naming conventions, is serialised as JSON and written to this file, such that it can be consumed by code generators that are not part of ygot. The schema tree is included if include_schema=true. Specify \"-\" for stdout. Set generate_structs=false to output only the IR.")
+	treeOutputFile          = flag.String("tree_output_file", "", "If specified, an RFC 8340 tree diagram of the schema, annotated with the names of the generated Go structs and fields, is written to this file. The tree reflects the compress_paths setting. Specify \"-\" for stdout. Set generate_structs=false to output only the tree diagram.")
 	compressPaths           = flag.Bool("compress_paths", false, "If set to true, the schema's paths are compressed, according to OpenConfig YANG module conventions. Path structs generation currently only supports compressed paths.")
 
 	// Common flags used for GoStruct and PathStruct generation.
@@ -80,6 +88,11 @@ var (
 	enumOrgPrefixesToTrim                []string
 	ignoreUnsupportedStatements          = flag.Bool("ignore_unsupported", false, "If set to true, unsupported YANG statements are ignored.")
 	ignoreDeviateNotsupported            = flag.Bool("ignore_deviate_notsupported", false, "If set to true, 'deviate not-supported' YANG statements are ignored, thus target nodes are retained in the generated code.")
+	pathAllowlist                        = flag.String("path_allowlist", "", "Comma separated set of uncompressed schema paths (e.g. /interfaces/interface/config/mtu) to which code generation is restricted. Path elements may contain wildcards, such as *. Matched nodes are retained along with their descendants, and the list keys and leafref targets that they require.")
+	nameLockFile                         = flag.String("name_lock_file", "", "File that records the names assigned to generated structs, enumerated types and unions by schema path. If the file exists, the names that it records are reused, and a name clash is reported as an error rather than being resolved by renaming. The file is written with the names of the generated code after generation.")
+	nameOverridesFile                    = flag.String("name_overrides_file", "", "File that specifies names to be used in place of the generated names of structs, fields, enumerated types and their values, and unions, keyed by schema path. The names used for serialisation are unchanged.")
+	generateOperations                   = flag.Bool("generate_operations", false, "If set to true, code is generated for the inputs and outputs of YANG rpc and action statements, and the bodies of notification statements. By default, rpc and action statements are ignored, and notification statements are treated as unsupported.")
+	pathAllowlistFile                    = flag.String("path_allowlist_file", "", "File containing schema paths to which code generation is restricted, one per line, in addition to those specified by path_allowlist. Empty lines and lines beginning with # are ignored.")
 
 	// Flags used for GoStruct generation only.
 	generateFakeRoot        = flag.Bool("generate_fakeroot", false, "If set to true, a fake element at the root of the data tree is generated. By default the fake root entity is named Device, its name can be controlled with the fakeroot_name flag.")
@@ -100,6 +113,12 @@ var (
 	generatePopulateDefault = flag.Bool("generate_populate_defaults", false, "If set to true, a PopulateDefault method will be generated for all GoStructs which recursively populates default values.")
 	generateValidateFnName  = flag.String("validate_fn_name", "Validate", "The Name of the proxy function for the Validate functionality.")
 	generateOrderedMaps     = flag.Bool("generate_ordered_maps", true, "If set to true, ordered map structures satisfying the interface ygot.GoOrderedMap will be generated for `ordered-by user` lists instead of Go built-in maps.")
+	generateChoiceSumTypes  = flag.Bool("generate_choice_sum_types", false, "If set to true, each YANG choice is generated as a field of an interface type that is implemented by a struct for each of its cases, such that only one case can be selected, rather than as fields for the contents of all of its cases.")
+	generateRFC7951Methods  = flag.Bool("generate_rfc7951_methods", false, "If set to true, MarshalRFC7951 and UnmarshalRFC7951 methods are generated for each GoStruct, which are used to marshal and unmarshal RFC7951 JSON without reflecting over the fields of the struct.")
+	generateEqualCopyDiff   = flag.Bool("generate_equal_copy_diff", false, "If set to true, Equal and Copy methods are generated for each GoStruct, along with methods that allow ygot.DeepCopy and ygot.Diff to copy and compare GoStructs without reflecting over their fields.")
+	splitStructsByModule    = flag.Bool("split_structs_by_module", false, "If set to true, the generated schema structs are split into a Go package per YANG module within output_dir. The fake root and the Schema function are written to the package named by package_name in output_dir, and enumerated types and the schema tree to a shared package.")
+	trimStructsPrefix       = flag.String("trim_structs_package_prefix", "", "Module prefix to trim from generated schema struct package names (e.g. 'openconfig-'), when split_structs_by_module=true.")
+	sharedPackageName       = flag.String("structs_shared_package_name", "common", "The name of the package that enumerated types and the schema tree are written to, when split_structs_by_module=true.")
 
 	// Flags used for PathStruct generation only.
 	schemaStructPath        = flag.String("schema_struct_path", "", "The Go import path for the schema structs package. This should be specified if and only if schema structs are not being generated at the same time as path structs.")
@@ -109,7 +128,7 @@ var (
 	pathStructSuffix        = flag.String("path_struct_suffix", "Path", "The suffix string appended to each generated path struct in order to differentiate their names from their corresponding schema struct names.")
 	splitByModule           = flag.Bool("split_pathstructs_by_module", false, "Whether to split path struct generation by module.")
 	trimPathPackagePrefix   = flag.String("trim_path_package_prefix", "", "Module prefix to trim from generated path struct package names (e.g. 'openconfig-'), when split_pathstructs_by_module=true.")
-	baseImportPath          = flag.String("base_import_path", "", "Base import path used to concatenate with module package relative paths for path struct imports when split_pathstructs_by_module=true.")
+	baseImportPath          = flag.String("base_import_path", "", "Base import path used to concatenate with module package relative paths for path struct imports when split_pathstructs_by_module=true, and for schema struct imports when split_structs_by_module=true.")
 	packageSuffix           = flag.String("path_struct_package_suffix", "path", "Suffix to append to generated Go package names, when split_pathstructs_by_module=true.")
 )
 
@@ -143,6 +162,10 @@ func writeGoCodeSingleFile(w io.Writer, goCode *gogen.GeneratedCode) error {
 		fmt.Fprintln(w, goCode.EnumTypeMap)
 	}
 
+	if len(goCode.OperationMap) > 0 {
+		fmt.Fprintln(w, goCode.OperationMap)
+	}
+
 	return nil
 }
 
@@ -215,6 +238,10 @@ func splitCodeByFileN(goCode *gogen.GeneratedCode, fileN int) (map[string]string
 		code.WriteString("\n")
 	}
 	code.WriteString(goCode.EnumTypeMap)
+	if goCode.OperationMap != "" {
+		code.WriteString("\n")
+		code.WriteString(goCode.OperationMap)
+	}
 
 	out[enumMapFn] = code.String()
 	out[interfaceFn] = interfaceCode.String()
@@ -266,6 +293,24 @@ func processFlags() {
 // to the specified file.
 func main() {
 	flag.Parse()
+	if *configFile != "" {
+		if flag.NArg() != 0 {
+			log.Exitf("Error: input modules must be specified within the config_file, got %v", flag.Args())
+		}
+		if fs := configFileConflicts(flag.CommandLine); len(fs) != 0 {
+			log.Exitf("Error: flags %v cannot be used with config_file, options must be specified within the file", fs)
+		}
+		cfg, err := loadConfig(*configFile)
+		if err != nil {
+			log.Exitf("Error: %v", err)
+		}
+		for _, j := range cfg.Jobs {
+			if err := j.run(); err != nil {
+				log.Exitf("ERROR running job %q: %v", j.Name, err)
+			}
+		}
+		return
+	}
 	processFlags()
 	// Extract the set of modules that code is to be generated for,
 	// throwing an error if the set is empty.
@@ -274,8 +319,8 @@ func main() {
 		log.Exitln("Error: no input modules specified")
 	}
 
-	if !*generateGoStructs && !*generatePathStructs {
-		log.Exitf("Error: Neither schema structs nor path structs generation is enabled.")
+	if !*generateGoStructs && !*generatePathStructs && *irOutputFile == "" && *treeOutputFile == "" {
+		log.Exitf("Error: Neither schema structs, path structs, IR nor tree diagram generation is enabled.")
 	}
 
 	if *generatePathStructs {
@@ -313,6 +358,71 @@ func main() {
 		}
 	}
 
+	allowlist, err := readPathAllowlist(*pathAllowlist, *pathAllowlistFile)
+	if err != nil {
+		log.Exitf("ERROR Generating Code: %v\n", err)
+	}
+	nameLock, err := readNameLock(*nameLockFile)
+	if err != nil {
+		log.Exitf("ERROR Generating Code: %v\n", err)
+	}
+	nameOverrides, err := readNameOverrides(*nameOverridesFile)
+	if err != nil {
+		log.Exitf("ERROR Generating Code: %v\n", err)
+	}
+
+	var irOpts ygen.IROptions
+	if *generateGoStructs || *irOutputFile != "" || *treeOutputFile != "" {
+		compressBehaviour, err := genutil.TranslateToCompressBehaviour(*compressPaths, *excludeState, *preferOperationalState)
+		if err != nil {
+			log.Exitf("ERROR Generating Code: %v\n", err)
+		}
+		irOpts = ygen.IROptions{
+			ParseOptions: ygen.ParseOpts{
+				IgnoreUnsupportedStatements: *ignoreUnsupportedStatements,
+				ExcludeModules:              modsExcluded,
+				YANGParseOptions: yang.Options{
+					IgnoreSubmoduleCircularDependencies: *ignoreCircDeps,
+					DeviateOptions: yang.DeviateOptions{
+						IgnoreDeviateNotSupported: *ignoreDeviateNotsupported,
+					},
+				},
+			},
+			TransformationOptions: ygen.TransformationOpts{
+				CompressBehaviour:                    compressBehaviour,
+				GenerateFakeRoot:                     *generateFakeRoot,
+				FakeRootName:                         *fakeRootName,
+				SkipEnumDeduplication:                *skipEnumDedup,
+				ShortenEnumLeafNames:                 *shortenEnumLeafNames,
+				EnumOrgPrefixesToTrim:                enumOrgPrefixesToTrim,
+				UseDefiningModuleForTypedefEnumNames: *useDefiningModuleForTypedefEnumNames,
+				EnumerationsUseUnderscores:           true,
+				PathAllowlist:                        allowlist,
+				GenerateOperations:                   *generateOperations,
+			},
+			AppendEnumSuffixForSimpleUnionEnums: *appendEnumSuffixForSimpleUnionEnums,
+			NameLock:                            nameLock,
+			NameOverrides:                       nameOverrides,
+		}
+	}
+
+	if *irOutputFile != "" || *treeOutputFile != "" {
+		ir, err := ygen.GenerateIR(generateModules, includePaths, gogen.NewGoLangMapper(*generateSimpleUnions), irOpts)
+		if err != nil {
+			log.Exitf("ERROR Generating IR: %v\n", err)
+		}
+		if *irOutputFile != "" {
+			if err := writeIR(ir, ygen.MarshalIROpts{IncludeSchemaTree: *generateSchema, IncludeDescriptions: *includeDescriptions}, *irOutputFile); err != nil {
+				log.Exitf("ERROR writing IR: %v\n", err)
+			}
+		}
+		if *treeOutputFile != "" {
+			if err := writeTreeDiagram(ir, *treeOutputFile); err != nil {
+				log.Exitf("ERROR writing tree diagram: %v\n", err)
+			}
+		}
+	}
+
 	if *generateGoStructs {
 		generateGoStructsSingleFile := *ocStructsOutputFile != ""
 		generateGoStructsMultipleFiles := *outputDir != ""
@@ -322,37 +432,14 @@ func main() {
 		if !generateGoStructsSingleFile && !generateGoStructsMultipleFiles {
 			log.Exitf("Error: Go struct generation requires a specified output file or output directory.")
 		}
-
-		compressBehaviour, err := genutil.TranslateToCompressBehaviour(*compressPaths, *excludeState, *preferOperationalState)
-		if err != nil {
-			log.Exitf("ERROR Generating Code: %v\n", err)
+		if *splitStructsByModule && (!generateGoStructsMultipleFiles || *baseImportPath == "") {
+			log.Exitf("Error: when splitting schema structs by module, both output_dir and base_import_path need to be set.")
 		}
 
 		// Perform the code generation.
 		cg := gogen.New(
 			"",
-			ygen.IROptions{
-				ParseOptions: ygen.ParseOpts{
-					IgnoreUnsupportedStatements: *ignoreUnsupportedStatements,
-					ExcludeModules:              modsExcluded,
-					YANGParseOptions: yang.Options{
-						IgnoreSubmoduleCircularDependencies: *ignoreCircDeps,
-						DeviateOptions: yang.DeviateOptions{
-							IgnoreDeviateNotSupported: *ignoreDeviateNotsupported,
-						},
-					},
-				},
-				TransformationOptions: ygen.TransformationOpts{
-					CompressBehaviour:                    compressBehaviour,
-					GenerateFakeRoot:                     *generateFakeRoot,
-					FakeRootName:                         *fakeRootName,
-					SkipEnumDeduplication:                *skipEnumDedup,
-					ShortenEnumLeafNames:                 *shortenEnumLeafNames,
-					EnumOrgPrefixesToTrim:                enumOrgPrefixesToTrim,
-					UseDefiningModuleForTypedefEnumNames: *useDefiningModuleForTypedefEnumNames,
-					EnumerationsUseUnderscores:           true,
-				},
-			},
+			irOpts,
 			gogen.GoOpts{
 				PackageName:                         *packageName,
 				GenerateJSONSchema:                  *generateSchema,
@@ -376,6 +463,13 @@ func main() {
 				AppendEnumSuffixForSimpleUnionEnums: *appendEnumSuffixForSimpleUnionEnums,
 				IgnoreShadowSchemaPaths:             *ignoreShadowSchemaPaths,
 				GenerateOrderedListsAsUnorderedMaps: !*generateOrderedMaps,
+				GenerateChoiceSumTypes:              *generateChoiceSumTypes,
+				GenerateRFC7951Methods:              *generateRFC7951Methods,
+				GenerateEqualCopyDiff:               *generateEqualCopyDiff,
+				SplitByModule:                       *splitStructsByModule,
+				TrimPackagePrefix:                   *trimStructsPrefix,
+				BaseImportPath:                      *baseImportPath,
+				SharedPackageName:                   *sharedPackageName,
 			},
 		)
 
@@ -384,32 +478,17 @@ func main() {
 			log.Exitf("ERROR Generating GoStruct Code: %v\n", errs)
 		}
 
-		switch {
-		case generateGoStructsSingleFile:
-			var outfh *os.File
-			switch *ocStructsOutputFile {
-			case "-":
-				// If "-" is the output file name, we output to os.Stdout, otherwise
-				// we write to the specified file.
-				outfh = os.Stdout
-			default:
-				// Assign the newly created filehandle to the outfh, and ensure
-				// that it is synced and closed before exit of main.
-				outfh = genutil.OpenFile(*ocStructsOutputFile)
-				defer genutil.SyncFile(outfh)
-			}
+		if err := writeGoCode(generatedGoCode, *ocStructsOutputFile, *outputDir, *structsFileN); err != nil {
+			log.Exitf("ERROR writing GoStruct Code: %v\n", err)
+		}
 
-			if err := writeGoCodeSingleFile(outfh, generatedGoCode); err != nil {
-				log.Exitf("ERROR writing GoStruct Code to single file: %v\n", err)
-			}
-		case generateGoStructsMultipleFiles:
-			// Write the Go code to a series of output files.
-			out, err := splitCodeByFileN(generatedGoCode, *structsFileN)
+		if *nameLockFile != "" {
+			ir, err := ygen.GenerateIR(generateModules, includePaths, gogen.NewGoLangMapper(*generateSimpleUnions), irOpts)
 			if err != nil {
-				log.Exitf("ERROR writing split GoStruct Code: %v\n", err)
+				log.Exitf("ERROR Generating IR for name lock: %v\n", err)
 			}
-			if err := writeFiles(*outputDir, out); err != nil {
-				log.Exitf("Error while writing schema struct files: %v", err)
+			if err := writeNameLock(ir, *nameLockFile); err != nil {
+				log.Exitf("ERROR writing name lock: %v\n", err)
 			}
 		}
 	}
@@ -451,6 +530,9 @@ func main() {
 		FakeRootName:                         *fakeRootName,
 		PathStructSuffix:                     *pathStructSuffix,
 		ExcludeModules:                       modsExcluded,
+		PathAllowlist:                        allowlist,
+		NameLock:                             nameLock,
+		NameOverrides:                        nameOverrides,
 		IgnoreUnsupportedStatements:          *ignoreUnsupportedStatements,
 		YANGParseOptions: yang.Options{
 			IgnoreSubmoduleCircularDependencies: *ignoreCircDeps,
@@ -470,58 +552,217 @@ func main() {
 		log.Exitf("ERROR Generating PathStruct Code: %s\n", errs)
 	}
 
+	if err := writePathCode(pathCode, pcg, *ocPathStructsOutputFile, *outputDir, *pathStructsFileN); err != nil {
+		log.Exit(err)
+	}
+
+	// The names of path structs are those of the schema structs, so the
+	// lock is written here only if it was not written for schema structs.
+	if *nameLockFile != "" && !*generateGoStructs {
+		ir, err := pcg.GenerateIR(generateModules, includePaths)
+		if err != nil {
+			log.Exitf("ERROR Generating IR for name lock: %v\n", err)
+		}
+		if err := writeNameLock(ir, *nameLockFile); err != nil {
+			log.Exitf("ERROR writing name lock: %v\n", err)
+		}
+	}
+}
+
+// writeGoCode writes the generated GoStruct code to outputFile, or, if it is
+// empty, splits it into fileN files within outputDir. If the code was split
+// by module, each package is instead written to its own directory within
+// outputDir.
+func writeGoCode(goCode *gogen.GeneratedCode, outputFile, outputDir string, fileN int) error {
+	if goCode.Packages != nil {
+		for name, pkg := range goCode.Packages {
+			dir := filepath.Join(outputDir, pkg.Path)
+			if err := os.MkdirAll(dir, 0755); err != nil {
+				return fmt.Errorf("failed to create directory for package %q: %v", name, err)
+			}
+			if err := os.WriteFile(filepath.Join(dir, fmt.Sprintf("%s.go", name)), []byte(pkg.Code), 0644); err != nil {
+				return fmt.Errorf("error while writing schema struct package %q: %v", name, err)
+			}
+		}
+		return nil
+	}
+	if outputFile == "" {
+		// Write the Go code to a series of output files.
+		out, err := splitCodeByFileN(goCode, fileN)
+		if err != nil {
+			return err
+		}
+		if err := writeFiles(outputDir, out); err != nil {
+			return fmt.Errorf("error while writing schema struct files: %v", err)
+		}
+		return nil
+	}
+
+	var outfh *os.File
+	switch outputFile {
+	case "-":
+		// If "-" is the output file name, we output to os.Stdout, otherwise
+		// we write to the specified file.
+		outfh = os.Stdout
+	default:
+		// Assign the newly created filehandle to the outfh, and ensure
+		// that it is synced and closed before returning.
+		outfh = genutil.OpenFile(outputFile)
+		defer genutil.SyncFile(outfh)
+	}
+	return writeGoCodeSingleFile(outfh, goCode)
+}
+
+// writeIR serialises ir using opts and writes it to outputFile, or to stdout
+// if outputFile is "-".
+func writeIR(ir *ygen.IR, opts ygen.MarshalIROpts, outputFile string) error {
+	b, err := ygen.MarshalIR(ir, opts)
+	if err != nil {
+		return err
+	}
+	if outputFile == "-" {
+		_, err := os.Stdout.Write(b)
+		return err
+	}
+	return os.WriteFile(outputFile, b, 0644)
+}
+
+// readPathAllowlist returns the schema paths to which code generation is
+// restricted, given the comma separated list of paths, list, and the file
+// containing paths one per line, file. Either may be empty.
+func readPathAllowlist(list, file string) ([]string, error) {
+	var paths []string
+	if list != "" {
+		paths = strings.Split(list, ",")
+	}
+	if file == "" {
+		return paths, nil
+	}
+	b, err := os.ReadFile(file)
+	if err != nil {
+		return nil, fmt.Errorf("cannot read path allowlist file: %v", err)
+	}
+	for _, l := range strings.Split(string(b), "\n") {
+		l = strings.TrimSpace(l)
+		if l == "" || strings.HasPrefix(l, "#") {
+			continue
+		}
+		paths = append(paths, l)
+	}
+	return paths, nil
+}
+
+// readNameLock returns the name lock stored in file. It returns nil, such that
+// names are not locked, if file is empty or does not exist.
+func readNameLock(file string) (*ygen.NameLock, error) {
+	if file == "" {
+		return nil, nil
+	}
+	b, err := os.ReadFile(file)
 	switch {
-	case *splitByModule:
+	case errors.Is(err, fs.ErrNotExist):
+		return nil, nil
+	case err != nil:
+		return nil, fmt.Errorf("cannot read name lock file: %v", err)
+	}
+	return ygen.UnmarshalNameLock(b)
+}
+
+// readNameOverrides returns the name overrides stored in file, or nil if file
+// is empty.
+func readNameOverrides(file string) (*ygen.NameOverrides, error) {
+	if file == "" {
+		return nil, nil
+	}
+	b, err := os.ReadFile(file)
+	if err != nil {
+		return nil, fmt.Errorf("cannot read name overrides file: %v", err)
+	}
+	return ygen.UnmarshalNameOverrides(b)
+}
+
+// writeNameLock writes the names assigned to the directories, enumerated types
+// and unions of ir to file.
+func writeNameLock(ir *ygen.IR, file string) error {
+	b, err := ygen.MarshalNameLock(ygen.NewNameLock(ir))
+	if err != nil {
+		return err
+	}
+	return os.WriteFile(file, b, 0644)
+}
+
+// writeTreeDiagram writes the RFC 8340 tree diagram of the schema represented
+// by ir to outputFile, or to stdout if outputFile is "-".
+func writeTreeDiagram(ir *ygen.IR, outputFile string) error {
+	tree, err := ir.TreeDiagram()
+	if err != nil {
+		return err
+	}
+	if outputFile == "-" {
+		_, err := os.Stdout.WriteString(tree)
+		return err
+	}
+	return os.WriteFile(outputFile, []byte(tree), 0644)
+}
+
+// writePathCode writes the path struct code generated using pcg. When the path
+// structs are split by module, the fake root package is written to outputFile,
+// and every other package to a directory of the same name within outputDir.
+// Otherwise, the code is written to outputFile if it is set, or split into
+// fileN files within outputDir.
+func writePathCode(pathCode map[string]*ypathgen.GeneratedPathCode, pcg *ypathgen.GenConfig, outputFile, outputDir string, fileN int) error {
+	switch {
+	case pcg.SplitByModule:
 		for packageName, code := range pathCode {
-			// The fake root package is written to ocPathStructsOutputFile.
-			// All other packages are written to outdir/<package>.
-			path := *ocPathStructsOutputFile
+			// The fake root package is written to outputFile.
+			// All other packages are written to outputDir/<package>.
+			path := outputFile
 			if packageName != pcg.PackageName {
-				if err := os.MkdirAll(filepath.Join(*outputDir, packageName), 0755); err != nil {
-					log.Exitf("failed to create directory for package %q: %v", packageName, err)
+				if err := os.MkdirAll(filepath.Join(outputDir, packageName), 0755); err != nil {
+					return fmt.Errorf("failed to create directory for package %q: %v", packageName, err)
 				}
-				path = filepath.Join(*outputDir, packageName, fmt.Sprintf("%s.go", packageName))
+				path = filepath.Join(outputDir, packageName, fmt.Sprintf("%s.go", packageName))
 			}
-			if *pathStructsFileN <= 1 || packageName == pcg.PackageName {
+			if fileN <= 1 || packageName == pcg.PackageName {
 				outfh := genutil.OpenFile(path)
 				defer genutil.SyncFile(outfh)
-				err := writeGoPathCodeSingleFile(outfh, code)
-				if err != nil {
-					log.Exitf("Error while writing path struct file: %v", err)
+				if err := writeGoPathCodeSingleFile(outfh, code); err != nil {
+					return fmt.Errorf("Error while writing path struct file: %v", err)
 				}
 			} else {
-				if err := writePathPackage(pathCode, packageName, filepath.Join(*outputDir, packageName)); err != nil {
+				if err := writePathPackage(pathCode, packageName, filepath.Join(outputDir, packageName), fileN); err != nil {
 					log.Errorln(err)
 				}
 			}
 		}
-	case generatePathStructsSingleFile:
+	case outputFile != "":
 		var outfh *os.File
-		switch *ocPathStructsOutputFile {
+		switch outputFile {
 		case "-":
 			// If "-" is the output file name, we output to os.Stdout, otherwise
 			// we write to the specified file.
 			outfh = os.Stdout
 		default:
 			// Assign the newly created filehandle to the outfh, and ensure
-			// that it is synced and closed before exit of main.
-			outfh = genutil.OpenFile(*ocPathStructsOutputFile)
+			// that it is synced and closed before returning.
+			outfh = genutil.OpenFile(outputFile)
 			defer genutil.SyncFile(outfh)
 		}
 		writeGoPathCodeSingleFile(outfh, pathCode[pcg.PackageName])
-	case generatePathStructsMultipleFiles:
-		if err := writePathPackage(pathCode, pcg.PackageName, *outputDir); err != nil {
-			log.Exit(err)
-		}
+	default:
+		return writePathPackage(pathCode, pcg.PackageName, outputDir, fileN)
 	}
+	return nil
 }
 
-func writePathPackage(pathCode map[string]*ypathgen.GeneratedPathCode, pkgName, dir string) error {
+// writePathPackage splits the path struct code for the package pkgName into
+// fileN files, which are written to dir.
+func writePathPackage(pathCode map[string]*ypathgen.GeneratedPathCode, pkgName, dir string, fileN int) error {
 	out := map[string]string{}
 	// Split the path struct code into files.
-	files, err := pathCode[pkgName].SplitFiles(*pathStructsFileN)
+	files, err := pathCode[pkgName].SplitFiles(fileN)
 	if err != nil {
-		return fmt.Errorf("error while splitting path structs code into %d files: %w", pathStructsFileN, err)
+		return fmt.Errorf("error while splitting path structs code into %d files: %w", fileN, err)
 	}
 	for i, file := range files {
 		out[fmt.Sprintf(pathStructsFileFmt, i)] = file
@@ -531,3 +772,735 @@ func writePathPackage(pathCode map[string]*ypathgen.GeneratedPathCode, pkgName,
 	}
 	return nil
 }
+
+// writeProtoCode writes each generated protobuf package to a file within dir,
+// whose path within dir is specified by the package.
+func writeProtoCode(protoCode *protogen.GeneratedCode, dir string) error {
+	for _, p := range protoCode.Packages {
+		fp := filepath.Join(append([]string{dir}, p.FilePath[:len(p.FilePath)-1]...)...)
+		if err := os.MkdirAll(fp, 0755); err != nil {
+			return fmt.Errorf("could not create directory %v, got error: %v", fp, err)
+		}
+
+		f, err := os.Create(filepath.Join(fp, p.FilePath[len(p.FilePath)-1]))
+		if err != nil {
+			return fmt.Errorf("could not create file %v, got error: %v", fp, err)
+		}
+		defer f.Close()
+
+		f.WriteString(p.Header)
+		for _, m := range p.Messages {
+			f.WriteString(fmt.Sprintf("%s\n", m))
+		}
+		for _, e := range p.Enums {
+			f.WriteString(e)
+		}
+		if err := f.Sync(); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// configVersion is the only version of the configuration file format that is
+// currently supported.
+const configVersion = 1
+
+// jobKind is the type of code that is output by a generation job.
+type jobKind string
+
+const (
+	// goStructsJob generates GoStructs for manipulating YANG data.
+	goStructsJob jobKind = "go_structs"
+	// pathStructsJob generates path structs for constructing YANG paths.
+	pathStructsJob jobKind = "path_structs"
+	// protoJob generates protobuf messages corresponding to the schema.
+	protoJob jobKind = "proto"
+	// irJob writes the serialised IR of the schema, using Go naming
+	// conventions, for consumption by external code generators.
+	irJob jobKind = "ir"
+)
+
+// generatorConfig is the contents of a configuration file supplied to the
+// generator using the config_file flag. It is written in YAML, or in JSON,
+// which is parsed as YAML, for example:
+//
+//	version: 1
+//	jobs:
+//	- name: structs
+//	  kind: go_structs
+//	  modules: [yang/openconfig-interfaces.yang]
+//	  paths: [yang]
+//	  package_name: oc
+//	  output_dir: oc
+//	  split_files_count: 2
+//	  compress_paths: true
+//	  go_structs:
+//	    generate_getters: true
+//	- name: paths
+//	  kind: path_structs
+//	  modules: [yang/openconfig-interfaces.yang]
+//	  paths: [yang]
+//	  package_name: ocpath
+//	  output_file: ocpath/ocpath.go
+//	  compress_paths: true
+//	  path_structs:
+//	    schema_struct_path: example.com/oc
+//
+// Relative paths within the file are resolved against the directory that
+// contains it.
+type generatorConfig struct {
+	// Version is the version of the configuration file format.
+	Version int `yaml:"version"`
+	// Jobs are the generation jobs that are run, in order.
+	Jobs []*jobConfig `yaml:"jobs"`
+}
+
+// jobConfig describes a single generation job. The names of its fields
+// match the generator's flags where an equivalent flag exists.
+type jobConfig struct {
+	// Name uniquely identifies the job within the configuration file.
+	Name string `yaml:"name"`
+	// Kind is the type of code that the job generates.
+	Kind jobKind `yaml:"kind"`
+	// Modules are the YANG files that code is generated for.
+	Modules []string `yaml:"modules"`
+	// Paths are the directories that are recursively searched for included
+	// modules and submodules.
+	Paths []string `yaml:"paths"`
+	// ExcludeModules are the names of modules that are excluded from code
+	// generation.
+	ExcludeModules []string `yaml:"exclude_modules"`
+	// PathAllowlist are the uncompressed schema paths to which code
+	// generation is restricted.
+	PathAllowlist []string `yaml:"path_allowlist"`
+	// NameLockFile is the file that records the names assigned to generated
+	// types, such that they are kept stable when the modules change.
+	NameLockFile string `yaml:"name_lock_file"`
+	// NameOverridesFile is the file that specifies names to be used in
+	// place of the generated names of types, fields and enumerated values.
+	NameOverridesFile string `yaml:"name_overrides_file"`
+	// PackageName is the name of the generated Go or protobuf package.
+	PackageName string `yaml:"package_name"`
+	// OutputFile is the file that generated code is written to, "-"
+	// specifies stdout.
+	OutputFile string `yaml:"output_file"`
+	// OutputDir is the directory that generated code is written to.
+	OutputDir string `yaml:"output_dir"`
+	// SplitFilesCount is the number of files that generated structs are
+	// split into when OutputDir is set.
+	SplitFilesCount int `yaml:"split_files_count"`
+
+	IgnoreCircDeps                       bool   `yaml:"ignore_circdeps"`
+	IgnoreUnsupportedStatements          bool   `yaml:"ignore_unsupported"`
+	IgnoreDeviateNotSupported            bool   `yaml:"ignore_deviate_notsupported"`
+	CompressPaths                        bool   `yaml:"compress_paths"`
+	ExcludeState                         bool   `yaml:"exclude_state"`
+	PreferOperationalState               bool   `yaml:"prefer_operational_state"`
+	GenerateFakeRoot                     bool   `yaml:"generate_fakeroot"`
+	FakeRootName                         string `yaml:"fakeroot_name"`
+	GenerateOperations                   bool   `yaml:"generate_operations"`
+	SkipEnumDeduplication                bool   `yaml:"skip_enum_deduplication"`
+	ShortenEnumLeafNames                 bool   `yaml:"shorten_enum_leaf_names"`
+	UseDefiningModuleForTypedefEnumNames bool   `yaml:"typedef_enum_with_defmod"`
+	AppendEnumSuffixForSimpleUnionEnums  bool   `yaml:"enum_suffix_for_simple_union_enums"`
+	TrimEnumOpenConfigPrefix             bool   `yaml:"trim_enum_openconfig_prefix"`
+	YgotImportPath                       string `yaml:"ygot_path"`
+
+	// GoStructs holds the options for go_structs jobs.
+	GoStructs *goStructsConfig `yaml:"go_structs"`
+	// PathStructs holds the options for path_structs jobs.
+	PathStructs *pathStructsConfig `yaml:"path_structs"`
+	// Proto holds the options for proto jobs.
+	Proto *protoConfig `yaml:"proto"`
+	// IR holds the options for ir jobs.
+	IR *irConfig `yaml:"ir"`
+}
+
+// goStructsConfig holds the options that are specific to go_structs jobs.
+// Options whose flag defaults to true are pointers, such that they can be
+// distinguished from an unset value.
+type goStructsConfig struct {
+	IncludeSchema           *bool  `yaml:"include_schema"`
+	IncludeDescriptions     bool   `yaml:"include_descriptions"`
+	YtypesImportPath        string `yaml:"ytypes_path"`
+	GoyangImportPath        string `yaml:"goyang_path"`
+	GenerateRename          bool   `yaml:"generate_rename"`
+	Annotations             bool   `yaml:"annotations"`
+	AnnotationPrefix        string `yaml:"annotation_prefix"`
+	YANGPresence            bool   `yaml:"yangpresence"`
+	GenerateAppend          bool   `yaml:"generate_append"`
+	GenerateGetters         bool   `yaml:"generate_getters"`
+	GenerateDelete          bool   `yaml:"generate_delete"`
+	GenerateLeafGetters     bool   `yaml:"generate_leaf_getters"`
+	GenerateLeafSetters     bool   `yaml:"generate_leaf_setters"`
+	GenerateSimpleUnions    bool   `yaml:"generate_simple_unions"`
+	IncludeModelData        bool   `yaml:"include_model_data"`
+	GeneratePopulateDefault bool   `yaml:"generate_populate_defaults"`
+	ValidateFnName          string `yaml:"validate_fn_name"`
+	GenerateOrderedMaps     *bool  `yaml:"generate_ordered_maps"`
+	GenerateChoiceSumTypes  bool   `yaml:"generate_choice_sum_types"`
+	GenerateRFC7951Methods  bool   `yaml:"generate_rfc7951_methods"`
+	GenerateEqualCopyDiff   bool   `yaml:"generate_equal_copy_diff"`
+	IgnoreShadowSchemaPaths bool   `yaml:"ignore_shadow_schema_paths"`
+	SplitByModule           bool   `yaml:"split_structs_by_module"`
+	TrimPackagePrefix       string `yaml:"trim_structs_package_prefix"`
+	BaseImportPath          string `yaml:"base_import_path"`
+	SharedPackageName       string `yaml:"structs_shared_package_name"`
+}
+
+// pathStructsConfig holds the options that are specific to path_structs
+// jobs.
+type pathStructsConfig struct {
+	// SchemaStructPath is the import path of the GoStructs package. It
+	// must be empty if and only if a go_structs job in the same file
+	// generates a package with the same name, which is assumed to be the
+	// package that the path structs are output to.
+	SchemaStructPath        string `yaml:"schema_struct_path"`
+	GenerateWildcardPaths   *bool  `yaml:"generate_wildcard_paths"`
+	SimplifyWildcardPaths   bool   `yaml:"simplify_wildcard_paths"`
+	ListBuilderKeyThreshold uint   `yaml:"list_builder_key_threshold"`
+	PathStructSuffix        string `yaml:"path_struct_suffix"`
+	SplitByModule           bool   `yaml:"split_pathstructs_by_module"`
+	TrimPackagePrefix       string `yaml:"trim_path_package_prefix"`
+	BaseImportPath          string `yaml:"base_import_path"`
+	PackageSuffix           string `yaml:"path_struct_package_suffix"`
+}
+
+// protoConfig holds the options that are specific to proto jobs.
+type protoConfig struct {
+	EnumPackageName  string `yaml:"enum_package_name"`
+	BaseImportPath   string `yaml:"base_import_path"`
+	YwrapperPath     string `yaml:"ywrapper_path"`
+	YextPath         string `yaml:"yext_path"`
+	AddSchemaPaths   *bool  `yaml:"add_schemapaths"`
+	AddEnumNames     *bool  `yaml:"add_enumnames"`
+	PackageHierarchy bool   `yaml:"package_hierarchy"`
+	GoPackageBase    string `yaml:"go_package_base"`
+}
+
+// irConfig holds the options that are specific to ir jobs.
+type irConfig struct {
+	IncludeSchema        *bool `yaml:"include_schema"`
+	IncludeDescriptions  bool  `yaml:"include_descriptions"`
+	GenerateSimpleUnions bool  `yaml:"generate_simple_unions"`
+}
+
+// boolOr returns the value of b, or def if b is nil.
+func boolOr(b *bool, def bool) bool {
+	if b == nil {
+		return def
+	}
+	return *b
+}
+
+// stringOr returns s, or def if s is empty.
+func stringOr(s, def string) string {
+	if s == "" {
+		return def
+	}
+	return s
+}
+
+// configFileConflicts returns the names of the flags that were explicitly
+// set within fs, other than config_file. These flags would otherwise be
+// silently ignored, since all options are read from the configuration file.
+func configFileConflicts(fs *flag.FlagSet) []string {
+	var names []string
+	fs.Visit(func(f *flag.Flag) {
+		if f.Name != "config_file" {
+			names = append(names, f.Name)
+		}
+	})
+	return names
+}
+
+// loadConfig reads the generator configuration file at path, resolving the
+// relative paths within it against the directory containing the file, and
+// checks that it is valid.
+func loadConfig(path string) (*generatorConfig, error) {
+	b, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("cannot read configuration file: %v", err)
+	}
+	cfg, err := parseConfig(b)
+	if err != nil {
+		return nil, fmt.Errorf("invalid configuration file %s: %v", path, err)
+	}
+	cfg.resolvePaths(filepath.Dir(path))
+	return cfg, nil
+}
+
+// parseConfig parses the YAML or JSON configuration b, returning an error if
+// it contains unknown fields or is not valid.
+func parseConfig(b []byte) (*generatorConfig, error) {
+	dec := yaml.NewDecoder(bytes.NewReader(b))
+	dec.KnownFields(true)
+	cfg := &generatorConfig{}
+	if err := dec.Decode(cfg); err != nil {
+		if errors.Is(err, io.EOF) {
+			return nil, fmt.Errorf("configuration is empty")
+		}
+		return nil, err
+	}
+	if err := cfg.validate(); err != nil {
+		return nil, err
+	}
+	return cfg, nil
+}
+
+// resolvePaths makes the relative file paths within the configuration
+// relative to dir.
+func (c *generatorConfig) resolvePaths(dir string) {
+	resolve := func(p string) string {
+		if p == "" || p == "-" || filepath.IsAbs(p) {
+			return p
+		}
+		return filepath.Join(dir, p)
+	}
+	for _, j := range c.Jobs {
+		for i, m := range j.Modules {
+			j.Modules[i] = resolve(m)
+		}
+		for i, p := range j.Paths {
+			j.Paths[i] = resolve(p)
+		}
+		j.OutputFile = resolve(j.OutputFile)
+		j.OutputDir = resolve(j.OutputDir)
+		j.NameLockFile = resolve(j.NameLockFile)
+		j.NameOverridesFile = resolve(j.NameOverridesFile)
+	}
+}
+
+// validate checks the configuration for missing options and combinations
+// of options that cannot be used together.
+func (c *generatorConfig) validate() error {
+	if c.Version != configVersion {
+		return fmt.Errorf("unsupported version %d, must be %d", c.Version, configVersion)
+	}
+	if len(c.Jobs) == 0 {
+		return fmt.Errorf("no jobs specified")
+	}
+	names := map[string]bool{}
+	structPkgs := map[string]bool{}
+	for i, j := range c.Jobs {
+		switch {
+		case j == nil:
+			return fmt.Errorf("job %d is empty", i)
+		case j.Name == "":
+			return fmt.Errorf("job %d does not have a name", i)
+		case names[j.Name]:
+			return fmt.Errorf("duplicate job name %q", j.Name)
+		}
+		names[j.Name] = true
+		if j.Kind == goStructsJob {
+			structPkgs[j.PackageName] = true
+		}
+	}
+	for _, j := range c.Jobs {
+		if err := j.validate(structPkgs); err != nil {
+			return fmt.Errorf("job %q: %v", j.Name, err)
+		}
+	}
+	return nil
+}
+
+// validate checks the options of the job. structPkgs is the set of package
+// names that are generated by go_structs jobs within the same configuration.
+func (j *jobConfig) validate(structPkgs map[string]bool) error {
+	sections := map[jobKind]bool{
+		goStructsJob:   j.GoStructs != nil,
+		pathStructsJob: j.PathStructs != nil,
+		protoJob:       j.Proto != nil,
+		irJob:          j.IR != nil,
+	}
+	if _, ok := sections[j.Kind]; !ok {
+		return fmt.Errorf("invalid kind %q, must be one of %s, %s, %s or %s", j.Kind, goStructsJob, pathStructsJob, protoJob, irJob)
+	}
+	for k, set := range sections {
+		if set && k != j.Kind {
+			return fmt.Errorf("%s options cannot be specified for a %s job", k, j.Kind)
+		}
+	}
+	if len(j.Modules) == 0 {
+		return fmt.Errorf("no input modules specified")
+	}
+	if j.PackageName == "" && j.Kind != irJob {
+		return fmt.Errorf("package_name must be specified")
+	}
+	if _, err := genutil.TranslateToCompressBehaviour(j.CompressPaths, j.ExcludeState, j.PreferOperationalState); err != nil {
+		return err
+	}
+	if j.TrimEnumOpenConfigPrefix && !j.CompressPaths {
+		return fmt.Errorf("trim_enum_openconfig_prefix requires compress_paths")
+	}
+	if j.AppendEnumSuffixForSimpleUnionEnums && !j.UseDefiningModuleForTypedefEnumNames {
+		return fmt.Errorf("enum_suffix_for_simple_union_enums requires typedef_enum_with_defmod")
+	}
+	if j.SplitFilesCount < 0 {
+		return fmt.Errorf("invalid split_files_count %d", j.SplitFilesCount)
+	}
+	if j.NameLockFile != "" && j.Kind == protoJob {
+		return fmt.Errorf("name_lock_file cannot be specified for a %s job", protoJob)
+	}
+	if j.NameOverridesFile != "" && j.Kind == protoJob {
+		return fmt.Errorf("name_overrides_file cannot be specified for a %s job", protoJob)
+	}
+
+	switch j.Kind {
+	case goStructsJob:
+		return j.validateGoStructs()
+	case pathStructsJob:
+		return j.validatePathStructs(structPkgs)
+	case irJob:
+		return j.validateIR()
+	default:
+		return j.validateProto()
+	}
+}
+
+// validateGoStructs checks the options that apply to a go_structs job.
+func (j *jobConfig) validateGoStructs() error {
+	o := j.GoStructs
+	if o == nil {
+		o = &goStructsConfig{}
+	}
+	switch {
+	case j.OutputFile != "" && j.OutputDir != "":
+		return fmt.Errorf("cannot specify both output_file (%s) and output_dir (%s)", j.OutputFile, j.OutputDir)
+	case j.OutputFile == "" && j.OutputDir == "":
+		return fmt.Errorf("an output_file or output_dir must be specified")
+	case o.SplitByModule && (j.OutputDir == "" || o.BaseImportPath == ""):
+		return fmt.Errorf("split_structs_by_module requires output_dir and base_import_path")
+	case o.SplitByModule && j.SplitFilesCount != 0:
+		return fmt.Errorf("split_files_count cannot be specified with split_structs_by_module")
+	case !o.SplitByModule && (o.TrimPackagePrefix != "" || o.BaseImportPath != "" || o.SharedPackageName != ""):
+		return fmt.Errorf("trim_structs_package_prefix, base_import_path and structs_shared_package_name require split_structs_by_module")
+	case o.SplitByModule && o.SharedPackageName == j.PackageName:
+		return fmt.Errorf("structs_shared_package_name cannot be the same as package_name")
+	case j.OutputDir != "" && j.SplitFilesCount == 0 && !o.SplitByModule:
+		return fmt.Errorf("split_files_count must be specified with output_dir")
+	case j.OutputFile != "" && j.SplitFilesCount != 0:
+		return fmt.Errorf("split_files_count cannot be specified with output_file")
+	}
+	if o.IgnoreShadowSchemaPaths && !j.CompressPaths {
+		return fmt.Errorf("ignore_shadow_schema_paths requires compress_paths")
+	}
+	if o.AnnotationPrefix != "" && !o.Annotations {
+		return fmt.Errorf("annotation_prefix requires annotations")
+	}
+	return nil
+}
+
+// validatePathStructs checks the options that apply to a path_structs job.
+func (j *jobConfig) validatePathStructs(structPkgs map[string]bool) error {
+	if !j.CompressPaths {
+		return fmt.Errorf("path struct generation is not supported for uncompressed paths")
+	}
+	if j.GenerateFakeRoot {
+		return fmt.Errorf("generate_fakeroot cannot be specified, a fake root is always generated for path structs")
+	}
+	if j.GenerateOperations {
+		return fmt.Errorf("generate_operations cannot be specified, path structs are not generated for operations")
+	}
+	o := j.PathStructs
+	if o == nil {
+		o = &pathStructsConfig{}
+	}
+	switch {
+	case o.SchemaStructPath != "" && structPkgs[j.PackageName]:
+		return fmt.Errorf("schema_struct_path cannot be specified when package %s is generated by a %s job", j.PackageName, goStructsJob)
+	case o.SchemaStructPath == "" && !structPkgs[j.PackageName]:
+		return fmt.Errorf("schema_struct_path must be specified when package %s is not generated by a %s job", j.PackageName, goStructsJob)
+	}
+	if o.SplitByModule {
+		switch {
+		case j.OutputFile == "" || j.OutputDir == "":
+			return fmt.Errorf("both output_file and output_dir must be specified with split_pathstructs_by_module")
+		case o.BaseImportPath == "":
+			return fmt.Errorf("base_import_path must be specified with split_pathstructs_by_module")
+		}
+	} else {
+		switch {
+		case j.OutputFile != "" && j.OutputDir != "":
+			return fmt.Errorf("cannot specify both output_file (%s) and output_dir (%s)", j.OutputFile, j.OutputDir)
+		case j.OutputFile == "" && j.OutputDir == "":
+			return fmt.Errorf("an output_file or output_dir must be specified")
+		case j.OutputDir != "" && j.SplitFilesCount == 0:
+			return fmt.Errorf("split_files_count must be specified with output_dir")
+		case j.OutputFile != "" && j.SplitFilesCount != 0:
+			return fmt.Errorf("split_files_count cannot be specified with output_file")
+		case o.TrimPackagePrefix != "" || o.BaseImportPath != "" || o.PackageSuffix != "":
+			return fmt.Errorf("trim_path_package_prefix, base_import_path and path_struct_package_suffix require split_pathstructs_by_module")
+		}
+	}
+	if !boolOr(o.GenerateWildcardPaths, true) && (o.SimplifyWildcardPaths || o.ListBuilderKeyThreshold != 0) {
+		return fmt.Errorf("simplify_wildcard_paths and list_builder_key_threshold require generate_wildcard_paths")
+	}
+	return nil
+}
+
+// validateProto checks the options that apply to a proto job.
+func (j *jobConfig) validateProto() error {
+	switch {
+	case j.OutputDir == "":
+		return fmt.Errorf("an output_dir must be specified")
+	case j.OutputFile != "":
+		return fmt.Errorf("output_file cannot be specified, protobufs are written to a hierarchy within output_dir")
+	case j.SplitFilesCount != 0:
+		return fmt.Errorf("split_files_count cannot be specified")
+	case j.ShortenEnumLeafNames, j.UseDefiningModuleForTypedefEnumNames, j.TrimEnumOpenConfigPrefix:
+		return fmt.Errorf("shorten_enum_leaf_names, typedef_enum_with_defmod and trim_enum_openconfig_prefix are not supported")
+	case j.YgotImportPath != "":
+		return fmt.Errorf("ygot_path cannot be specified")
+	}
+	return nil
+}
+
+// validateIR checks the options that apply to an ir job.
+func (j *jobConfig) validateIR() error {
+	switch {
+	case j.OutputFile == "":
+		return fmt.Errorf("an output_file must be specified")
+	case j.OutputDir != "" || j.SplitFilesCount != 0:
+		return fmt.Errorf("output_dir and split_files_count cannot be specified, the IR is written to output_file")
+	case j.PackageName != "" || j.YgotImportPath != "":
+		return fmt.Errorf("package_name and ygot_path cannot be specified")
+	}
+	return nil
+}
+
+// includePaths returns the paths that are searched for included modules,
+// such that each of the job's paths is searched recursively.
+func (j *jobConfig) includePaths() []string {
+	var paths []string
+	for _, p := range j.Paths {
+		paths = append(paths, filepath.Join(p, "..."))
+	}
+	return paths
+}
+
+// enumOrgPrefixesToTrim returns the organisation prefixes that are trimmed
+// from the names of enumerated types.
+func (j *jobConfig) enumOrgPrefixesToTrim() []string {
+	if j.TrimEnumOpenConfigPrefix {
+		return []string{"openconfig"}
+	}
+	return nil
+}
+
+// irOptions returns the options used to generate the IR for the job, which
+// must have been validated.
+func (j *jobConfig) irOptions() ygen.IROptions {
+	// The error is checked during validation.
+	compressBehaviour, _ := genutil.TranslateToCompressBehaviour(j.CompressPaths, j.ExcludeState, j.PreferOperationalState)
+	return ygen.IROptions{
+		ParseOptions: ygen.ParseOpts{
+			IgnoreUnsupportedStatements: j.IgnoreUnsupportedStatements,
+			ExcludeModules:              j.ExcludeModules,
+			YANGParseOptions: yang.Options{
+				IgnoreSubmoduleCircularDependencies: j.IgnoreCircDeps,
+				DeviateOptions: yang.DeviateOptions{
+					IgnoreDeviateNotSupported: j.IgnoreDeviateNotSupported,
+				},
+			},
+		},
+		TransformationOptions: ygen.TransformationOpts{
+			CompressBehaviour:                    compressBehaviour,
+			GenerateFakeRoot:                     j.GenerateFakeRoot,
+			FakeRootName:                         j.FakeRootName,
+			SkipEnumDeduplication:                j.SkipEnumDeduplication,
+			ShortenEnumLeafNames:                 j.ShortenEnumLeafNames,
+			EnumOrgPrefixesToTrim:                j.enumOrgPrefixesToTrim(),
+			UseDefiningModuleForTypedefEnumNames: j.UseDefiningModuleForTypedefEnumNames,
+			EnumerationsUseUnderscores:           j.Kind == goStructsJob || j.Kind == irJob,
+			PathAllowlist:                        j.PathAllowlist,
+			GenerateOperations:                   j.GenerateOperations,
+		},
+		AppendEnumSuffixForSimpleUnionEnums: j.AppendEnumSuffixForSimpleUnionEnums,
+	}
+}
+
+// marshalIROpts returns the options used to serialise the IR for the job.
+func (j *jobConfig) marshalIROpts() ygen.MarshalIROpts {
+	o := j.IR
+	if o == nil {
+		o = &irConfig{}
+	}
+	return ygen.MarshalIROpts{
+		IncludeSchemaTree:   boolOr(o.IncludeSchema, true),
+		IncludeDescriptions: o.IncludeDescriptions,
+	}
+}
+
+// goOpts returns the options used to generate GoStructs for the job.
+func (j *jobConfig) goOpts() gogen.GoOpts {
+	o := j.GoStructs
+	if o == nil {
+		o = &goStructsConfig{}
+	}
+	return gogen.GoOpts{
+		PackageName:                         j.PackageName,
+		GenerateJSONSchema:                  boolOr(o.IncludeSchema, true),
+		IncludeDescriptions:                 o.IncludeDescriptions,
+		YgotImportPath:                      stringOr(j.YgotImportPath, genutil.GoDefaultYgotImportPath),
+		YtypesImportPath:                    stringOr(o.YtypesImportPath, genutil.GoDefaultYtypesImportPath),
+		GoyangImportPath:                    stringOr(o.GoyangImportPath, genutil.GoDefaultGoyangImportPath),
+		GenerateRenameMethod:                o.GenerateRename,
+		AddAnnotationFields:                 o.Annotations,
+		AnnotationPrefix:                    stringOr(o.AnnotationPrefix, gogen.DefaultAnnotationPrefix),
+		AddYangPresence:                     o.YANGPresence,
+		GenerateGetters:                     o.GenerateGetters,
+		GenerateDeleteMethod:                o.GenerateDelete,
+		GenerateAppendMethod:                o.GenerateAppend,
+		GenerateLeafGetters:                 o.GenerateLeafGetters,
+		GenerateLeafSetters:                 o.GenerateLeafSetters,
+		GeneratePopulateDefault:             o.GeneratePopulateDefault,
+		ValidateFunctionName:                stringOr(o.ValidateFnName, "Validate"),
+		GenerateSimpleUnions:                o.GenerateSimpleUnions,
+		IncludeModelData:                    o.IncludeModelData,
+		AppendEnumSuffixForSimpleUnionEnums: j.AppendEnumSuffixForSimpleUnionEnums,
+		IgnoreShadowSchemaPaths:             o.IgnoreShadowSchemaPaths,
+		GenerateOrderedListsAsUnorderedMaps: !boolOr(o.GenerateOrderedMaps, true),
+		GenerateChoiceSumTypes:              o.GenerateChoiceSumTypes,
+		GenerateRFC7951Methods:              o.GenerateRFC7951Methods,
+		GenerateEqualCopyDiff:               o.GenerateEqualCopyDiff,
+		SplitByModule:                       o.SplitByModule,
+		TrimPackagePrefix:                   o.TrimPackagePrefix,
+		BaseImportPath:                      o.BaseImportPath,
+		SharedPackageName:                   o.SharedPackageName,
+	}
+}
+
+// pathGenConfig returns the configuration used to generate path structs for
+// the job.
+func (j *jobConfig) pathGenConfig() *ypathgen.GenConfig {
+	o := j.PathStructs
+	if o == nil {
+		o = &pathStructsConfig{}
+	}
+	return &ypathgen.GenConfig{
+		PackageName: j.PackageName,
+		GoImports: ypathgen.GoImports{
+			SchemaStructPkgPath: o.SchemaStructPath,
+			YgotImportPath:      stringOr(j.YgotImportPath, genutil.GoDefaultYgotImportPath),
+		},
+		PreferOperationalState:               j.PreferOperationalState,
+		ExcludeState:                         j.ExcludeState,
+		SkipEnumDeduplication:                j.SkipEnumDeduplication,
+		ShortenEnumLeafNames:                 j.ShortenEnumLeafNames,
+		EnumOrgPrefixesToTrim:                j.enumOrgPrefixesToTrim(),
+		UseDefiningModuleForTypedefEnumNames: j.UseDefiningModuleForTypedefEnumNames,
+		AppendEnumSuffixForSimpleUnionEnums:  j.AppendEnumSuffixForSimpleUnionEnums,
+		FakeRootName:                         j.FakeRootName,
+		PathStructSuffix:                     stringOr(o.PathStructSuffix, "Path"),
+		ExcludeModules:                       j.ExcludeModules,
+		PathAllowlist:                        j.PathAllowlist,
+		IgnoreUnsupportedStatements:          j.IgnoreUnsupportedStatements,
+		YANGParseOptions: yang.Options{
+			IgnoreSubmoduleCircularDependencies: j.IgnoreCircDeps,
+			DeviateOptions: yang.DeviateOptions{
+				IgnoreDeviateNotSupported: j.IgnoreDeviateNotSupported,
+			},
+		},
+		GeneratingBinary:        genutil.CallerName(),
+		ListBuilderKeyThreshold: o.ListBuilderKeyThreshold,
+		GenerateWildcardPaths:   boolOr(o.GenerateWildcardPaths, true),
+		SimplifyWildcardPaths:   o.SimplifyWildcardPaths,
+		TrimPackagePrefix:       o.TrimPackagePrefix,
+		SplitByModule:           o.SplitByModule,
+		BaseImportPath:          o.BaseImportPath,
+		PackageSuffix:           stringOr(o.PackageSuffix, "path"),
+	}
+}
+
+// protoOpts returns the options used to generate protobufs for the job.
+func (j *jobConfig) protoOpts() protogen.ProtoOpts {
+	o := j.Proto
+	if o == nil {
+		o = &protoConfig{}
+	}
+	return protogen.ProtoOpts{
+		PackageName:         j.PackageName,
+		BaseImportPath:      o.BaseImportPath,
+		YwrapperPath:        o.YwrapperPath,
+		YextPath:            o.YextPath,
+		AnnotateSchemaPaths: boolOr(o.AddSchemaPaths, true),
+		AnnotateEnumNames:   boolOr(o.AddEnumNames, true),
+		NestedMessages:      !o.PackageHierarchy,
+		EnumPackageName:     stringOr(o.EnumPackageName, "enums"),
+		GoPackageBase:       o.GoPackageBase,
+	}
+}
+
+// run generates the code for the job and writes it to its output.
+func (j *jobConfig) run() error {
+	lock, err := readNameLock(j.NameLockFile)
+	if err != nil {
+		return err
+	}
+	overrides, err := readNameOverrides(j.NameOverridesFile)
+	if err != nil {
+		return err
+	}
+	opts := j.irOptions()
+	opts.NameLock = lock
+	opts.NameOverrides = overrides
+
+	switch j.Kind {
+	case goStructsJob:
+		code, errs := gogen.New("", opts, j.goOpts()).Generate(j.Modules, j.includePaths())
+		if errs != nil {
+			return fmt.Errorf("error generating GoStruct code: %v", errs)
+		}
+		if err := writeGoCode(code, j.OutputFile, j.OutputDir, j.SplitFilesCount); err != nil {
+			return err
+		}
+		if j.NameLockFile == "" {
+			return nil
+		}
+		ir, err := ygen.GenerateIR(j.Modules, j.includePaths(), gogen.NewGoLangMapper(j.GoStructs != nil && j.GoStructs.GenerateSimpleUnions), opts)
+		if err != nil {
+			return fmt.Errorf("error generating IR for name lock: %v", err)
+		}
+		return writeNameLock(ir, j.NameLockFile)
+	case pathStructsJob:
+		pcg := j.pathGenConfig()
+		pcg.NameLock = lock
+		pcg.NameOverrides = overrides
+		code, _, errs := pcg.GeneratePathCode(j.Modules, j.includePaths())
+		if errs != nil {
+			return fmt.Errorf("error generating PathStruct code: %v", errs)
+		}
+		if err := writePathCode(code, pcg, j.OutputFile, j.OutputDir, j.SplitFilesCount); err != nil {
+			return err
+		}
+		if j.NameLockFile == "" {
+			return nil
+		}
+		ir, err := pcg.GenerateIR(j.Modules, j.includePaths())
+		if err != nil {
+			return fmt.Errorf("error generating IR for name lock: %v", err)
+		}
+		return writeNameLock(ir, j.NameLockFile)
+	case protoJob:
+		code, errs := protogen.New("generator", j.irOptions(), j.protoOpts()).Generate(j.Modules, j.includePaths())
+		if errs != nil {
+			return fmt.Errorf("error generating proto code: %v", errs)
+		}
+		return writeProtoCode(code, j.OutputDir)
+	case irJob:
+		ir, err := ygen.GenerateIR(j.Modules, j.includePaths(), gogen.NewGoLangMapper(j.IR != nil && j.IR.GenerateSimpleUnions), opts)
+		if err != nil {
+			return fmt.Errorf("error generating IR: %v", err)
+		}
+		if err := writeIR(ir, j.marshalIROpts(), j.OutputFile); err != nil {
+			return err
+		}
+		if j.NameLockFile == "" {
+			return nil
+		}
+		return writeNameLock(ir, j.NameLockFile)
+	}
+	return fmt.Errorf("invalid kind %q", j.Kind)
+}
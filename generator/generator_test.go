@@ -16,13 +16,17 @@ package main
 
 import (
 	"fmt"
+	"os"
+	"path/filepath"
 	"strings"
 	"testing"
 
 	"github.com/google/go-cmp/cmp"
+	"github.com/google/go-cmp/cmp/cmpopts"
 	"github.com/kylelemons/godebug/pretty"
 	"github.com/openconfig/gnmi/errdiff"
 	"github.com/openconfig/ygot/gogen"
+	"github.com/openconfig/ygot/ygen"
 	"github.com/openconfig/ygot/ypathgen"
 )
 
@@ -76,6 +80,15 @@ foo
 		},
 		wantCode: `
 map
+`,
+	}, {
+		name: "operation map",
+		inGoCode: &gogen.GeneratedCode{
+			EnumMap:      "ΛMap",
+			OperationMap: "ops",
+		},
+		wantCode: `ΛMap
+ops
 `,
 	}}
 
@@ -120,6 +133,30 @@ func TestSplitCodeByFileN(t *testing.T) {
 			interfaceFn:                    "common_header\ninterfaces\n",
 			fmt.Sprintf(structsFileFmt, 0): "common_header\noneoff_header\ndef\nname_key\nmethods\n",
 		},
+	}, {
+		name: "struct with operation map",
+		in: &gogen.GeneratedCode{
+			CommonHeader: "common_header\n",
+			OneOffHeader: "oneoff_header\n",
+			Structs: []gogen.GoStructCodeSnippet{{
+				StructName: "name",
+				StructDef:  "def\n",
+				ListKeys:   "name_key",
+				Methods:    "methods",
+				Interfaces: "interfaces",
+			}},
+			EnumMap:      "enummap",
+			EnumTypeMap:  "enumtypemap",
+			OperationMap: "ops",
+		},
+		inFileN: 1,
+		want: map[string]string{
+			enumMapFn:                      "common_header\nenummap\nenumtypemap\nops",
+			enumFn:                         "common_header\n",
+			schemaFn:                       "common_header\n",
+			interfaceFn:                    "common_header\ninterfaces\n",
+			fmt.Sprintf(structsFileFmt, 0): "common_header\noneoff_header\ndef\nname_key\nmethods\n",
+		},
 	}, {
 		name: "less than 1 file requested for splitting",
 		in: &gogen.GeneratedCode{
@@ -384,3 +421,134 @@ ChildConstructor
 		})
 	}
 }
+
+func TestReadPathAllowlist(t *testing.T) {
+	file := filepath.Join(t.TempDir(), "allowlist")
+	if err := os.WriteFile(file, []byte("# Interfaces.\n/interfaces/interface/config/mtu\n\n  /system  \n"), 0644); err != nil {
+		t.Fatalf("cannot write allowlist file: %v", err)
+	}
+
+	tests := []struct {
+		name             string
+		inList           string
+		inFile           string
+		want             []string
+		wantErrSubstring string
+	}{{
+		name: "empty",
+	}, {
+		name:   "list only",
+		inList: "/a,/b/*",
+		want:   []string{"/a", "/b/*"},
+	}, {
+		name:   "list and file",
+		inList: "/a",
+		inFile: file,
+		want:   []string{"/a", "/interfaces/interface/config/mtu", "/system"},
+	}, {
+		name:             "missing file",
+		inFile:           filepath.Join(t.TempDir(), "does-not-exist"),
+		wantErrSubstring: "cannot read path allowlist file",
+	}}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := readPathAllowlist(tt.inList, tt.inFile)
+			if diff := errdiff.Substring(err, tt.wantErrSubstring); diff != "" {
+				t.Fatalf("readPathAllowlist(%q, %q): did not get expected error, %s", tt.inList, tt.inFile, diff)
+			}
+			if diff := cmp.Diff(tt.want, got); diff != "" {
+				t.Errorf("readPathAllowlist(%q, %q): did not get expected paths, diff(-want, +got):\n%s", tt.inList, tt.inFile, diff)
+			}
+		})
+	}
+}
+
+func TestReadNameLock(t *testing.T) {
+	dir := t.TempDir()
+	valid := filepath.Join(dir, "valid.lock")
+	if err := os.WriteFile(valid, []byte(`{"format_version": 1, "directories": {"/a/b": "B"}}`), 0644); err != nil {
+		t.Fatalf("cannot write name lock file: %v", err)
+	}
+	invalid := filepath.Join(dir, "invalid.lock")
+	if err := os.WriteFile(invalid, []byte(`{"format_version": 42}`), 0644); err != nil {
+		t.Fatalf("cannot write name lock file: %v", err)
+	}
+
+	tests := []struct {
+		name             string
+		inFile           string
+		want             *ygen.NameLock
+		wantErrSubstring string
+	}{{
+		name: "no file",
+	}, {
+		name:   "missing file",
+		inFile: filepath.Join(dir, "does-not-exist"),
+	}, {
+		name:   "valid file",
+		inFile: valid,
+		want:   &ygen.NameLock{Directories: map[string]string{"/a/b": "B"}},
+	}, {
+		name:             "invalid file",
+		inFile:           invalid,
+		wantErrSubstring: "unsupported name lock format version 42",
+	}}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := readNameLock(tt.inFile)
+			if diff := errdiff.Substring(err, tt.wantErrSubstring); diff != "" {
+				t.Fatalf("readNameLock(%q): did not get expected error, %s", tt.inFile, diff)
+			}
+			if diff := cmp.Diff(tt.want, got, cmpopts.IgnoreUnexported(ygen.NameLock{})); diff != "" {
+				t.Errorf("readNameLock(%q): did not get expected lock, diff(-want, +got):\n%s", tt.inFile, diff)
+			}
+		})
+	}
+}
+
+func TestReadNameOverrides(t *testing.T) {
+	dir := t.TempDir()
+	valid := filepath.Join(dir, "valid.json")
+	if err := os.WriteFile(valid, []byte(`{"format_version": 1, "nodes": {"/a/b": {"type": "B"}}}`), 0644); err != nil {
+		t.Fatalf("cannot write name overrides file: %v", err)
+	}
+	invalid := filepath.Join(dir, "invalid.json")
+	if err := os.WriteFile(invalid, []byte(`{"format_version": 1, "nodes": {"/a/b": {"field": "b-c"}}}`), 0644); err != nil {
+		t.Fatalf("cannot write name overrides file: %v", err)
+	}
+
+	tests := []struct {
+		name             string
+		inFile           string
+		want             *ygen.NameOverrides
+		wantErrSubstring string
+	}{{
+		name: "no file",
+	}, {
+		name:             "missing file",
+		inFile:           filepath.Join(dir, "does-not-exist"),
+		wantErrSubstring: "cannot read name overrides file",
+	}, {
+		name:   "valid file",
+		inFile: valid,
+		want:   &ygen.NameOverrides{Nodes: map[string]*ygen.NameOverride{"/a/b": {Type: "B"}}},
+	}, {
+		name:             "invalid file",
+		inFile:           invalid,
+		wantErrSubstring: `"b-c" is not a valid identifier`,
+	}}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := readNameOverrides(tt.inFile)
+			if diff := errdiff.Substring(err, tt.wantErrSubstring); diff != "" {
+				t.Fatalf("readNameOverrides(%q): did not get expected error, %s", tt.inFile, diff)
+			}
+			if diff := cmp.Diff(tt.want, got); diff != "" {
+				t.Errorf("readNameOverrides(%q): did not get expected overrides, diff(-want, +got):\n%s", tt.inFile, diff)
+			}
+		})
+	}
+}
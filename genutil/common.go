@@ -514,7 +514,7 @@ func TransformEntry(e *yang.Entry, compressBehaviour CompressBehaviour) util.Err
 		case ch.IsContainer(), ch.IsList(), util.IsChoiceOrCase(ch):
 			// Recurse down the tree.
 			errs = util.AppendErrs(errs, TransformEntry(ch, compressBehaviour))
-		case ch.Kind == yang.AnyDataEntry:
+		case util.IsAnydataOrAnyxml(ch):
 			continue
 		default:
 			errs = util.AppendErr(errs, fmt.Errorf("unknown type of entry %v in TransformEntry for %s", e.Kind, e.Path()))
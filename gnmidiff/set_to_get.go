@@ -4,8 +4,9 @@ import (
 	"fmt"
 	"reflect"
 
-	"github.com/derekparker/trie"
 	gpb "github.com/openconfig/gnmi/proto/gnmi"
+	"github.com/openconfig/ygot/util"
+	"github.com/openconfig/ygot/ygot"
 	"github.com/openconfig/ygot/ytypes"
 )
 
@@ -82,14 +83,27 @@ func DiffSetRequestToNotifications(setreq *gpb.SetRequest, notifs []*gpb.Notific
 		delete(updates, pathA)
 	}
 
-	t := trie.New()
-	for pathB, vB := range updates {
-		t.Add(pathB, vB)
+	t := util.NewPathTrie[string]()
+	for pathB := range updates {
+		p, err := ygot.StringToStructuredPath(pathB)
+		if err != nil {
+			return SetToNotifsDiff{}, fmt.Errorf("gnmidiff: cannot parse update path %q: %v", pathB, err)
+		}
+		t.Insert(p, pathB)
 	}
 	for delPath := range setIntent.Deletes {
-		// TODO: handle wildcards in delete paths (if applicable).
-		for _, extraPath := range t.PrefixSearch(delPath + "/") {
-			diff.ExtraUpdates[extraPath] = updates[extraPath]
+		delQuery, err := ygot.StringToStructuredPath(delPath)
+		if err != nil {
+			return SetToNotifsDiff{}, fmt.Errorf("gnmidiff: cannot parse delete path %q: %v", delPath, err)
+		}
+		for _, e := range t.MatchQuery(delQuery) {
+			// Deletes are applied before updates, hence a delete
+			// path only conflicts with the updates strictly beneath
+			// the nodes that it deletes.
+			if !util.PathIsBeneathQuery(e.Path, delQuery) {
+				continue
+			}
+			diff.ExtraUpdates[e.Value] = updates[e.Value]
 		}
 	}
 
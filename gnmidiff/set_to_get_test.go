@@ -820,6 +820,72 @@ func TestSetRequestToNotifications(t *testing.T) {
 				},
 			},
 		},
+	}, {
+		desc: "wildcard key in delete path",
+		inSetRequest: &gpb.SetRequest{
+			Delete: []*gpb.Path{
+				ygot.MustStringToPath("/interfaces/interface[name=*]/config"),
+			},
+			Replace: []*gpb.Update{{
+				Path: ygot.MustStringToPath("/interfaces/interface[name=eth0]"),
+				Val:  must7951(&exampleoc.Interface{Name: ygot.String("eth0")}),
+			}},
+		},
+		inNotifications: []*gpb.Notification{{
+			Update: []*gpb.Update{{
+				Path: ygot.MustStringToPath("/interfaces/interface[name=eth0]"),
+				Val:  must7951(&exampleoc.Interface{Name: ygot.String("eth0"), Description: ygot.String("d0")}),
+			}, {
+				Path: ygot.MustStringToPath("/interfaces/interface[name=eth1]"),
+				Val:  must7951(&exampleoc.Interface{Name: ygot.String("eth1"), Description: ygot.String("d1")}),
+			}},
+		}},
+		wantSetToNotifsDiff: SetToNotifsDiff{
+			MissingUpdates: map[string]interface{}{},
+			ExtraUpdates: map[string]interface{}{
+				"/interfaces/interface[name=eth0]/config/description": "d0",
+				"/interfaces/interface[name=eth1]/config/description": "d1",
+				"/interfaces/interface[name=eth1]/config/name":        "eth1",
+			},
+			CommonUpdates: map[string]interface{}{
+				"/interfaces/interface[name=eth0]/name":        "eth0",
+				"/interfaces/interface[name=eth0]/config/name": "eth0",
+			},
+			MismatchedUpdates: map[string]MismatchedUpdate{},
+		},
+	}, {
+		desc: "multi-level wildcard in delete path",
+		inSetRequest: &gpb.SetRequest{
+			Delete: []*gpb.Path{
+				ygot.MustStringToPath("/interfaces/.../config"),
+			},
+			Replace: []*gpb.Update{{
+				Path: ygot.MustStringToPath("/interfaces/interface[name=eth0]"),
+				Val:  must7951(&exampleoc.Interface{Name: ygot.String("eth0")}),
+			}},
+		},
+		inNotifications: []*gpb.Notification{{
+			Update: []*gpb.Update{{
+				Path: ygot.MustStringToPath("/interfaces/interface[name=eth0]"),
+				Val:  must7951(&exampleoc.Interface{Name: ygot.String("eth0"), Description: ygot.String("d0")}),
+			}, {
+				Path: ygot.MustStringToPath("/interfaces/interface[name=eth1]"),
+				Val:  must7951(&exampleoc.Interface{Name: ygot.String("eth1"), Description: ygot.String("d1")}),
+			}},
+		}},
+		wantSetToNotifsDiff: SetToNotifsDiff{
+			MissingUpdates: map[string]interface{}{},
+			ExtraUpdates: map[string]interface{}{
+				"/interfaces/interface[name=eth0]/config/description": "d0",
+				"/interfaces/interface[name=eth1]/config/description": "d1",
+				"/interfaces/interface[name=eth1]/config/name":        "eth1",
+			},
+			CommonUpdates: map[string]interface{}{
+				"/interfaces/interface[name=eth0]/name":        "eth0",
+				"/interfaces/interface[name=eth0]/config/name": "eth0",
+			},
+			MismatchedUpdates: map[string]MismatchedUpdate{},
+		},
 	}}
 
 	for _, tt := range tests {
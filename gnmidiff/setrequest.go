@@ -20,7 +20,7 @@ import (
 	"reflect"
 	"strings"
 
-	"github.com/derekparker/trie"
+	"github.com/openconfig/ygot/util"
 	"github.com/openconfig/ygot/ygot"
 	"github.com/openconfig/ygot/ytypes"
 
@@ -122,8 +122,6 @@ func minimalSetRequestIntent(req *gpb.SetRequest, schema *ytypes.Schema) (setReq
 		Deletes: map[string]struct{}{},
 		Updates: map[string]interface{}{},
 	}
-	// NOTE: This simple trie will not work if we intend to check conflicts with wildcard deletion paths.
-	t := trie.New()
 	for _, gPath := range req.Delete {
 		path, err := fullPathStr(prefix, gPath)
 		if err != nil {
@@ -133,7 +131,6 @@ func minimalSetRequestIntent(req *gpb.SetRequest, schema *ytypes.Schema) (setReq
 			return setRequestIntent{}, fmt.Errorf("gnmidiff: conflicting replaces in SetRequest: %v", path)
 		}
 		intent.Deletes[path] = struct{}{}
-		t.Add(path, nil)
 	}
 	for _, upd := range req.Replace {
 		path, err := fullPathStr(prefix, upd.Path)
@@ -144,7 +141,6 @@ func minimalSetRequestIntent(req *gpb.SetRequest, schema *ytypes.Schema) (setReq
 			return setRequestIntent{}, fmt.Errorf("gnmidiff: conflicting replaces in SetRequest: %v", path)
 		}
 		intent.Deletes[path] = struct{}{}
-		t.Add(path, nil)
 
 		if err := intent.populateUpdate(path, upd.GetVal(), schema, true); err != nil {
 			return setRequestIntent{}, err
@@ -152,10 +148,10 @@ func minimalSetRequestIntent(req *gpb.SetRequest, schema *ytypes.Schema) (setReq
 	}
 
 	// Do prefix match to check for conflicting replace paths.
-	for _, path := range t.Keys() {
-		if matches := t.PrefixSearch(path + "/"); len(matches) >= 1 {
-			return setRequestIntent{}, fmt.Errorf("gnmidiff: conflicting replaces in SetRequest: %v, %v", path, matches)
-		}
+	if path, matches, err := prefixConflict(intent.Deletes); err != nil {
+		return setRequestIntent{}, err
+	} else if len(matches) != 0 {
+		return setRequestIntent{}, fmt.Errorf("gnmidiff: conflicting replaces in SetRequest: %v, %v", path, matches)
 	}
 
 	for _, upd := range req.Update {
@@ -169,19 +165,43 @@ func minimalSetRequestIntent(req *gpb.SetRequest, schema *ytypes.Schema) (setReq
 		}
 	}
 
-	t = trie.New()
-	for path := range intent.Updates {
-		t.Add(path, nil)
+	// Do prefix match to check for conflicting update paths.
+	if path, matches, err := prefixConflict(intent.Updates); err != nil {
+		return setRequestIntent{}, err
+	} else if len(matches) != 0 {
+		return setRequestIntent{}, fmt.Errorf("gnmidiff: bad SetRequest, there are leaf updates that have a prefix match: %v, %v", path, matches)
 	}
 
-	// Do prefix match to check for conflicting update paths.
-	for _, path := range t.Keys() {
-		if matches := t.PrefixSearch(path + "/"); len(matches) >= 1 {
-			return setRequestIntent{}, fmt.Errorf("gnmidiff: bad SetRequest, there are leaf updates that have a prefix match: %v, %v", path, matches)
+	return intent, nil
+}
+
+// prefixConflict returns a path within the keys of paths that is a prefix of
+// other paths within the keys, along with those paths. If no path is a prefix
+// of another, matches is empty.
+//
+// NOTE: Wildcards within the paths are compared literally, hence conflicts
+// with wildcard deletion paths are not found.
+func prefixConflict[V any](paths map[string]V) (path string, matches []string, err error) {
+	t := util.NewPathTrie[string]()
+	for p := range paths {
+		sp, err := ygot.StringToStructuredPath(p)
+		if err != nil {
+			return "", nil, fmt.Errorf("gnmidiff: %v", err)
 		}
+		t.Insert(sp, p)
 	}
 
-	return intent, nil
+	// Entries are returned such that each precedes those beneath it, so
+	// the first entry of each subtree is the prefix itself.
+	for _, e := range t.Subtree(&gpb.Path{}) {
+		if sub := t.Subtree(e.Path); len(sub) > 1 {
+			for _, m := range sub[1:] {
+				matches = append(matches, m.Value)
+			}
+			return e.Value, matches, nil
+		}
+	}
+	return "", nil, nil
 }
 
 // prefixStr returns the path version of a prefix path, handling corner cases.
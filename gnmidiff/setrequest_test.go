@@ -795,6 +795,30 @@ func TestMinimalSetRequestIntent(t *testing.T) {
 			},
 		},
 		wantErr: true,
+	}, {
+		desc: "conflicting deletes with key containing a slash",
+		inSetRequest: &gpb.SetRequest{
+			Delete: []*gpb.Path{
+				ygot.MustStringToPath("/interfaces/interface[name=eth0/1]"),
+				ygot.MustStringToPath("/interfaces/interface[name=eth0/1]/config/description"),
+			},
+		},
+		wantErr: true,
+	}, {
+		desc: "deletes with keys that share a prefix",
+		inSetRequest: &gpb.SetRequest{
+			Delete: []*gpb.Path{
+				ygot.MustStringToPath("/interfaces/interface[name=eth0]"),
+				ygot.MustStringToPath("/interfaces/interface[name=eth0/1]/config/description"),
+			},
+		},
+		wantIntent: setRequestIntent{
+			Deletes: map[string]struct{}{
+				"/interfaces/interface[name=eth0]":                      {},
+				"/interfaces/interface[name=eth0/1]/config/description": {},
+			},
+			Updates: map[string]interface{}{},
+		},
 	}, {
 		desc: "conflicting delete with replace",
 		inSetRequest: &gpb.SetRequest{
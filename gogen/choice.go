@@ -0,0 +1,275 @@
+// Copyright 2023 Google Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package gogen
+
+import (
+	"bytes"
+	"fmt"
+
+	"github.com/openconfig/goyang/pkg/yang"
+	"github.com/openconfig/ygot/genutil"
+	"github.com/openconfig/ygot/ygen"
+)
+
+// goChoice describes a YANG choice that is generated as an interface type,
+// which is implemented by a struct generated for each of its cases.
+type goChoice struct {
+	// Name is the name of the interface type generated for the choice.
+	Name string
+	// YANGName is the name of the choice in the YANG schema.
+	YANGName string
+	// FieldName is the name of the field that stores the choice within
+	// the struct containing it.
+	FieldName string
+	// YANGPath is the path of the YANG schema element that contains the
+	// choice.
+	YANGPath string
+	// Cases are the cases of the choice, in the order in which they are
+	// first encountered.
+	Cases []*goChoiceCase
+}
+
+// goChoiceCase describes a case of a YANG choice that is generated as a
+// struct.
+type goChoiceCase struct {
+	// generatedGoStruct describes the struct generated for the case, the
+	// fields of which are the contents of the case.
+	generatedGoStruct
+	// YANGName is the name of the case in the YANG schema.
+	YANGName string
+	// Choice is the choice that the case belongs to.
+	Choice *goChoice
+	// Choices are the choices that are directly within the case.
+	Choices []*goChoice
+}
+
+// goChoiceCaseTypes is the input to the template that generates the
+// ΛChoiceCaseTypes method of a struct.
+type goChoiceCaseTypes struct {
+	// Receiver is the name of the struct that contains the choices.
+	Receiver string
+	// Choices are the choices that are directly within Receiver.
+	Choices []*goChoice
+}
+
+// goChoiceSet stores the YANG choices within a struct that are generated as
+// sum types.
+type goChoiceSet struct {
+	// structDef is the struct that contains the choices.
+	structDef *generatedGoStruct
+	// fieldNames is the set of names of fields within structDef and any of
+	// its cases, which is used to ensure that the names of the fields that
+	// store the choices are unique.
+	fieldNames map[string]bool
+	// choices are the choices that are directly within structDef.
+	choices []*goChoice
+}
+
+// newGoChoiceSet returns a goChoiceSet for the choices within the struct
+// described by structDef, whose fields have the names in fieldNames.
+func newGoChoiceSet(structDef *generatedGoStruct, fieldNames map[string]string) *goChoiceSet {
+	s := &goChoiceSet{
+		structDef:  structDef,
+		fieldNames: map[string]bool{},
+	}
+	for _, n := range fieldNames {
+		s.fieldNames[n] = true
+	}
+	return s
+}
+
+// caseFor returns the case that stores a field that is within the supplied
+// choices and cases. The choices and cases are created if they do not already
+// exist, with the field storing each choice being added to its containing
+// struct or case.
+func (s *goChoiceSet) caseFor(choiceCases []*ygen.YANGChoiceCase) *goChoiceCase {
+	choices, fields := &s.choices, &s.structDef.Fields
+	var c *goChoiceCase
+	for _, cc := range choiceCases {
+		var ch *goChoice
+		for _, e := range *choices {
+			if e.YANGName == cc.Choice {
+				ch = e
+				break
+			}
+		}
+		if ch == nil {
+			ch = &goChoice{
+				Name:      fmt.Sprintf("%s_%s_Choice", s.structDef.StructName, yang.CamelCase(cc.Choice)),
+				YANGName:  cc.Choice,
+				FieldName: genutil.MakeNameUnique(yang.CamelCase(cc.Choice), s.fieldNames),
+				YANGPath:  s.structDef.YANGPath,
+			}
+			*choices = append(*choices, ch)
+			*fields = append(*fields, &goStructField{
+				Name: ch.FieldName,
+				Type: ch.Name,
+				Tags: fmt.Sprintf(`choice:"%s"`, cc.Choice),
+			})
+		}
+
+		c = nil
+		for _, e := range ch.Cases {
+			if e.YANGName == cc.Case {
+				c = e
+				break
+			}
+		}
+		if c == nil {
+			c = &goChoiceCase{
+				generatedGoStruct: generatedGoStruct{
+					StructName:      fmt.Sprintf("%s_%s", ch.Name, yang.CamelCase(cc.Case)),
+					YANGPath:        s.structDef.YANGPath,
+					BelongingModule: s.structDef.BelongingModule,
+				},
+				YANGName: cc.Case,
+				Choice:   ch,
+			}
+			ch.Cases = append(ch.Cases, c)
+		}
+		choices, fields = &c.Choices, &c.Fields
+	}
+	return c
+}
+
+// cases returns all of the cases of the choices within the set, including
+// those of choices nested within cases.
+func (s *goChoiceSet) cases() []*goChoiceCase {
+	var cs []*goChoiceCase
+	var addCases func([]*goChoice)
+	addCases = func(choices []*goChoice) {
+		for _, ch := range choices {
+			for _, c := range ch.Cases {
+				cs = append(cs, c)
+				addCases(c.Choices)
+			}
+		}
+	}
+	addCases(s.choices)
+	return cs
+}
+
+var (
+	// goChoiceTemplate takes an input goChoice, and generates the interface
+	// that represents the choice, along with the struct that represents
+	// each of its cases.
+	goChoiceTemplate = mustMakeTemplate("choice", `
+// {{ .Name }} is an interface that is implemented by the structs
+// representing the cases of the choice {{ .YANGName }} within the
+// {{ .YANGPath }} YANG schema element.
+type {{ .Name }} interface {
+	ygot.GoChoiceCase
+	Is_{{ .Name }}()
+}
+{{ $intfName := .Name }}
+{{- $choiceName := .YANGName }}
+{{- range $case := .Cases }}
+// {{ $case.StructName }} represents the case {{ $case.YANGName }} of
+// the choice {{ $choiceName }} within the {{ $case.YANGPath }} YANG schema element.
+type {{ $case.StructName }} struct {
+{{- range $idx, $field := $case.Fields }}
+	{{- if $field.IsScalarField }}
+	{{ $field.Name }}	*{{ $field.Type }}	`+"`"+`{{ $field.Tags }}`+"`"+`
+	{{- else }}
+	{{ $field.Name }}	{{ $field.Type }}	`+"`"+`{{ $field.Tags }}`+"`"+`
+	{{- end }}
+{{- end }}
+}
+
+// IsYANGChoiceCase ensures that {{ $case.StructName }} implements the
+// ygot.GoChoiceCase interface.
+func (*{{ $case.StructName }}) IsYANGChoiceCase() {}
+
+// Is_{{ $intfName }} ensures that {{ $case.StructName }}
+// implements the {{ $intfName }} interface.
+func (*{{ $case.StructName }}) Is_{{ $intfName }}() {}
+{{ end -}}
+`)
+
+	// goChoiceCaseTypesTemplate takes an input goChoiceCaseTypes, and
+	// generates the method that returns the types of the cases of each
+	// choice within a struct, such that the case that is present can be
+	// determined when unmarshalling.
+	goChoiceCaseTypesTemplate = mustMakeTemplate("choiceCaseTypes", `
+// ΛChoiceCaseTypes returns the types of the structs representing the cases
+// of each choice within {{ .Receiver }}, keyed by the name of the field
+// that stores the choice.
+func (*{{ .Receiver }}) ΛChoiceCaseTypes() map[string][]reflect.Type {
+	return map[string][]reflect.Type{
+		{{- range $choice := .Choices }}
+		"{{ $choice.FieldName }}": {
+			{{- range $case := $choice.Cases }}
+			reflect.TypeOf((*{{ $case.StructName }})(nil)),
+			{{- end }}
+		},
+		{{- end }}
+	}
+}
+`)
+)
+
+// generateChoices generates the interfaces and structs representing the
+// choices within the supplied set, including those nested within cases, and
+// writes them to buf.
+func generateChoices(buf *bytes.Buffer, s *goChoiceSet) error {
+	var write func([]*goChoice) error
+	write = func(choices []*goChoice) error {
+		for _, ch := range choices {
+			if err := goChoiceTemplate.Execute(buf, ch); err != nil {
+				return err
+			}
+			for _, c := range ch.Cases {
+				if err := write(c.Choices); err != nil {
+					return err
+				}
+			}
+		}
+		return nil
+	}
+	return write(s.choices)
+}
+
+// generateChoiceMethods generates the ΛChoiceCaseTypes methods for the struct
+// containing the choices within the supplied set, and for each of their cases
+// that contains a choice. If getters is set, the getter methods for the
+// container fields of each case are also generated.
+func generateChoiceMethods(buf *bytes.Buffer, s *goChoiceSet, getters bool) error {
+	if err := goChoiceCaseTypesTemplate.Execute(buf, goChoiceCaseTypes{
+		Receiver: s.structDef.StructName,
+		Choices:  s.choices,
+	}); err != nil {
+		return err
+	}
+
+	for _, c := range s.cases() {
+		if len(c.Choices) != 0 {
+			if err := goChoiceCaseTypesTemplate.Execute(buf, goChoiceCaseTypes{
+				Receiver: c.StructName,
+				Choices:  c.Choices,
+			}); err != nil {
+				return err
+			}
+		}
+		if getters {
+			if err := generateGetOrCreateStruct(buf, c.generatedGoStruct); err != nil {
+				return err
+			}
+			if err := generateContainerGetters(buf, c.generatedGoStruct); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
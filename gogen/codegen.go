@@ -5,6 +5,7 @@ import (
 	"bytes"
 	"fmt"
 	"sort"
+	"strings"
 
 	"github.com/openconfig/ygot/internal/igenutil"
 	"github.com/openconfig/ygot/util"
@@ -123,6 +124,46 @@ type GoOpts struct {
 	// marked `ordered-by user` will be represented using built-in Go maps
 	// instead of an ordered map Go structure.
 	GenerateOrderedListsAsUnorderedMaps bool
+	// GenerateChoiceSumTypes specifies whether each YANG choice is
+	// represented by a field of an interface type, implemented by a
+	// struct generated for each of its cases, such that only one case
+	// of the choice can be selected. When unset, the contents of all
+	// cases of a choice are generated as fields of the struct containing
+	// the choice.
+	GenerateChoiceSumTypes bool
+	// GenerateRFC7951Methods specifies whether MarshalRFC7951 and
+	// UnmarshalRFC7951 methods are generated for each struct, which
+	// marshal the struct to and unmarshal it from RFC7951 JSON using its
+	// known layout rather than reflection. The methods are used by the
+	// ygot and ytypes JSON functions when they are present.
+	GenerateRFC7951Methods bool
+	// GenerateEqualCopyDiff specifies whether Equal and Copy methods are
+	// generated for each struct, along with methods that implement the
+	// ygot.GoStructCopier and ygot.GoStructDiffer interfaces, such that
+	// ygot.DeepCopy and ygot.Diff do not need to use reflection to walk
+	// the struct.
+	GenerateEqualCopyDiff bool
+	// SplitByModule specifies whether the generated code should be output
+	// as a Go package per YANG module, in addition to a single package.
+	// Each struct is output to the package of the module in which the
+	// root of its YANG tree is instantiated. The enumerated types and the
+	// schema are output to a shared package, and the fake root, along
+	// with the Schema function, is output to the package named
+	// PackageName, which imports the other packages. The packages are
+	// returned in the Packages field of GeneratedCode.
+	SplitByModule bool
+	// TrimPackagePrefix is a prefix that is removed from module names
+	// when they are used as the names of packages when SplitByModule is
+	// set.
+	TrimPackagePrefix string
+	// BaseImportPath is the import path of the package named PackageName
+	// when SplitByModule is set. The other packages are imported from
+	// subdirectories of this path.
+	BaseImportPath string
+	// SharedPackageName is the name of the package that contains the
+	// enumerated types and schema when SplitByModule is set. If it is
+	// unset, the package is named "common".
+	SharedPackageName string
 }
 
 // GeneratedCode contains generated code snippets that can be processed by the calling
@@ -159,6 +200,22 @@ type GeneratedCode struct {
 	RawJSONSchema []byte
 	// EnumTypeMap is a Go map that allows YANG schemapaths to be mapped to reflect.Type values.
 	EnumTypeMap string
+	// OperationMap is a Go map, keyed by YANG module name, of the RPCs, actions and notifications
+	// for which structs were generated. It is empty if the input YANG models define no such operations.
+	OperationMap string
+	// Packages contains the generated code split into a Go package per YANG module, keyed by the
+	// name of the package. It is populated only when the SplitByModule option is set.
+	Packages map[string]*GeneratedPackage
+}
+
+// GeneratedPackage stores the code for a single generated Go package when the
+// generated code is split by module.
+type GeneratedPackage struct {
+	// Path is the directory of the package relative to the directory of the
+	// package named GoOpts.PackageName, which is empty for that package.
+	Path string
+	// Code is the complete, formatted source code of the package.
+	Code string
 }
 
 // New returns a new instance of the CodeGenerator
@@ -171,6 +228,120 @@ func New(callerName string, opts ygen.IROptions, goOpts GoOpts) *CodeGenerator {
 	}
 }
 
+// checkFieldNameOverrides returns an error if a field name that is overridden
+// within o clashes with the name of a method that is generated for the struct
+// that contains the field, such as the getter of another field, in which case
+// the generated code would not compile.
+func checkFieldNameOverrides(ir *ygen.IR, o *ygen.NameOverrides, goOpts GoOpts) error {
+	if o == nil {
+		return nil
+	}
+	var errs []string
+	for _, dp := range ir.OrderedDirectoryPathsByName() {
+		dir := ir.Directories[dp]
+		var methods map[string]string
+		for _, fn := range dir.OrderedFieldNames() {
+			f := dir.Fields[fn]
+			if no := o.Nodes[f.YANGDetails.SchemaPath]; no == nil || no.Field == "" {
+				continue
+			}
+			if methods == nil {
+				methods = structMethodNames(dir, ir.Directories, goOpts)
+			}
+			if m, ok := methods[f.Name]; ok {
+				errs = append(errs, fmt.Sprintf("field name %q given to %s clashes with the %s of %s", f.Name, f.YANGDetails.SchemaPath, m, dir.Name))
+			}
+		}
+	}
+	if errs == nil {
+		return nil
+	}
+	return fmt.Errorf("name overrides: %s", strings.Join(errs, "; "))
+}
+
+// structMethodNames returns the names of the methods that are generated for
+// the struct representing dir, keyed by name, with a description of each
+// method as the value. dirs is the set of directories within the IR.
+func structMethodNames(dir *ygen.ParsedDirectory, dirs map[string]*ygen.ParsedDirectory, goOpts GoOpts) map[string]string {
+	methods := map[string]string{
+		"IsYANGGoStruct":   "IsYANGGoStruct method",
+		"ΛBelongingModule": "ΛBelongingModule method",
+	}
+	add := func(desc string, names ...string) {
+		for _, n := range names {
+			methods[n] = desc
+		}
+	}
+	if dir.ListKeys != nil {
+		add("list key method", "ΛListKeyMap")
+	}
+	if goOpts.GenerateJSONSchema {
+		add("validation method", "ΛValidate", "ΛEnumTypeMap")
+		if goOpts.ValidateFunctionName != "" {
+			add("validation method", goOpts.ValidateFunctionName)
+		}
+	}
+	if goOpts.GeneratePopulateDefault {
+		add("PopulateDefaults method", "PopulateDefaults")
+	}
+	if goOpts.GenerateRFC7951Methods {
+		add("RFC7951 method", "MarshalRFC7951", "UnmarshalRFC7951")
+	}
+	if goOpts.GenerateEqualCopyDiff {
+		add("Equal, Copy or Diff method", "Equal", "Copy", "ΛDeepCopy", "ΛDiff")
+	}
+
+	for _, fn := range dir.OrderedFieldNames() {
+		f := dir.Fields[fn]
+		if goOpts.GenerateChoiceSumTypes && len(f.YANGDetails.Choices) != 0 {
+			// The methods of fields within a choice are generated
+			// for the struct that represents their case.
+			continue
+		}
+		desc := fmt.Sprintf("method generated for field %s", f.Name)
+		switch f.Type {
+		case ygen.ContainerNode:
+			if goOpts.GenerateGetters {
+				add(desc, "Get"+f.Name, "GetOrCreate"+f.Name)
+			}
+		case ygen.ListNode:
+			if child, ok := dirs[f.YANGDetails.Path]; !ok || len(child.ListKeys) == 0 {
+				// Keyless lists are represented by slices, for
+				// which no methods are generated.
+				continue
+			}
+			if f.YANGDetails.OrderedByUser && !goOpts.GenerateOrderedListsAsUnorderedMaps {
+				add(desc, "GetOrCreate"+f.Name+"Map", "AppendNew"+f.Name, "Append"+f.Name, "Get"+f.Name, "Delete"+f.Name)
+				continue
+			}
+			add(desc, "New"+f.Name)
+			if goOpts.GenerateRenameMethod {
+				add(desc, "Rename"+f.Name)
+			}
+			if goOpts.GenerateGetters {
+				add(desc, "Get"+f.Name, "GetOrCreate"+f.Name)
+			}
+			if goOpts.GenerateDeleteMethod {
+				add(desc, "Delete"+f.Name)
+			}
+			if goOpts.GenerateAppendMethod {
+				add(desc, "Append"+f.Name)
+			}
+		case ygen.LeafNode, ygen.LeafListNode:
+			if goOpts.GenerateLeafGetters {
+				add(desc, "Get"+f.Name)
+			}
+			if goOpts.GenerateLeafSetters {
+				add(desc, "Set"+f.Name)
+			}
+			if f.LangType != nil && len(f.LangType.UnionTypes) > 1 {
+				add(desc, "To_"+f.LangType.NativeType)
+			}
+		}
+	}
+	return methods
+}
+
 // checkForBinaryKeys returns a non-empty list of errors if the input directory
 // has one or more binary types (including union types containing binary types)
 // as a list key.
@@ -210,6 +381,8 @@ func (cg *CodeGenerator) Generate(yangFiles, includePaths []string) (*GeneratedC
 		NestedDirectories:                   false,
 		AbsoluteMapPaths:                    false,
 		AppendEnumSuffixForSimpleUnionEnums: cg.GoOptions.AppendEnumSuffixForSimpleUnionEnums,
+		NameLock:                            cg.IROptions.NameLock,
+		NameOverrides:                       cg.IROptions.NameOverrides,
 	}
 
 	var codegenErr util.Errors
@@ -218,6 +391,10 @@ func (cg *CodeGenerator) Generate(yangFiles, includePaths []string) (*GeneratedC
 		return nil, util.AppendErr(codegenErr, err)
 	}
 
+	if err := checkFieldNameOverrides(ir, cg.IROptions.NameOverrides, cg.GoOptions); err != nil {
+		return nil, util.AppendErr(codegenErr, err)
+	}
+
 	var rootName string
 	if cg.IROptions.TransformationOptions.GenerateFakeRoot {
 		rootName = cg.IROptions.TransformationOptions.FakeRootName
@@ -263,6 +440,7 @@ func (cg *CodeGenerator) Generate(yangFiles, includePaths []string) (*GeneratedC
 			codegenErr = util.AppendErrs(codegenErr, errs)
 			continue
 		}
+		structOut.Package = goPackageName(dir, cg.GoOptions)
 		structSnippets = append(structSnippets, structOut)
 
 		// Record down all the enum types we encounter in each field.
@@ -341,12 +519,17 @@ func (cg *CodeGenerator) Generate(yangFiles, includePaths []string) (*GeneratedC
 		}
 	}
 
+	operationMapCode, err := generateOperationMap(ir)
+	if err != nil {
+		codegenErr = util.AppendErr(codegenErr, err)
+	}
+
 	// Return any errors that were encountered during code generation.
 	if len(codegenErr) != 0 {
 		return nil, codegenErr
 	}
 
-	return &GeneratedCode{
+	code := &GeneratedCode{
 		CommonHeader:   commonHeader,
 		OneOffHeader:   oneoffHeader,
 		Structs:        structSnippets,
@@ -355,7 +538,90 @@ func (cg *CodeGenerator) Generate(yangFiles, includePaths []string) (*GeneratedC
 		JSONSchemaCode: jsonSchema,
 		RawJSONSchema:  rawSchema,
 		EnumTypeMap:    enumTypeMapCode,
-	}, nil
+		OperationMap:   operationMapCode,
+	}
+	if cg.GoOptions.SplitByModule {
+		if code.Packages, err = splitByModule(code, cg.GoOptions); err != nil {
+			return nil, util.NewErrs(err)
+		}
+	}
+	return code, nil
+}
+
+// goOperation is the input to the operation map template describing a
+// single operation.
+type goOperation struct {
+	// Name is the YANG identifier of the operation.
+	Name string
+	// Type is the name of the ygot.OperationType constant for the
+	// operation.
+	Type string
+	// Path is the schema path of the operation.
+	Path string
+	// Input, Output and Notification are the names of the generated
+	// structs for the operation, which are empty if not applicable.
+	Input, Output, Notification string
+}
+
+// generateOperationMap outputs a map using the operationMap template that
+// allows the RPCs, actions and notifications in the IR to be looked up by the
+// module in which they are defined. It returns an empty string if the IR
+// contains no operations.
+func generateOperationMap(ir *ygen.IR) (string, error) {
+	if len(ir.Operations) == 0 {
+		return "", nil
+	}
+
+	dirName := func(p string) (string, error) {
+		if p == "" {
+			return "", nil
+		}
+		d, ok := ir.Directories[p]
+		if !ok {
+			return "", fmt.Errorf("cannot find directory %s for operation", p)
+		}
+		return d.Name, nil
+	}
+
+	ops := map[string][]*goOperation{}
+	for _, p := range ir.OrderedOperationPaths() {
+		op := ir.Operations[p]
+		goOp := &goOperation{
+			Name: op.Name,
+			Path: op.SchemaPath,
+		}
+		switch op.Type {
+		case ygot.RPCOperation:
+			goOp.Type = "RPCOperation"
+		case ygot.ActionOperation:
+			goOp.Type = "ActionOperation"
+		case ygot.NotificationOperation:
+			goOp.Type = "NotificationOperation"
+		default:
+			return "", fmt.Errorf("unknown type %v for operation %s", op.Type, op.Path)
+		}
+
+		var err error
+		if op.Type == ygot.NotificationOperation {
+			if goOp.Notification, err = dirName(op.Path); err != nil {
+				return "", err
+			}
+		} else {
+			if goOp.Input, err = dirName(op.InputPath); err != nil {
+				return "", err
+			}
+			if goOp.Output, err = dirName(op.OutputPath); err != nil {
+				return "", err
+			}
+		}
+		ops[op.BelongingModule] = append(ops[op.BelongingModule], goOp)
+	}
+
+	var buf bytes.Buffer
+	if err := goOperationMapTemplate.Execute(&buf, ops); err != nil {
+		return "", err
+	}
+	return buf.String(), nil
 }
 
 // generateEnumTypeMap outputs a map using the enumTypeMap template. It takes an
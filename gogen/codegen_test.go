@@ -7,6 +7,8 @@ import (
 	"fmt"
 	"os"
 	"path/filepath"
+	"sort"
+	"strings"
 	"testing"
 
 	"github.com/google/go-cmp/cmp"
@@ -189,6 +191,25 @@ func TestSimpleStructs(t *testing.T) {
 			},
 		},
 		wantStructsCodeFile: filepath.Join(TestRoot, "testdata/structs/openconfig-simple-with-unsupported.formatted-txt"),
+	}, {
+		name:    "rpc, action and notification test, with no compression",
+		inFiles: []string{filepath.Join(datapath, "operations.yang")},
+		inConfig: CodeGenerator{
+			IROptions: ygen.IROptions{
+				TransformationOptions: ygen.TransformationOpts{
+					CompressBehaviour:                    genutil.Uncompressed,
+					GenerateFakeRoot:                     true,
+					ShortenEnumLeafNames:                 true,
+					UseDefiningModuleForTypedefEnumNames: true,
+					EnumerationsUseUnderscores:           true,
+					GenerateOperations:                   true,
+				},
+			},
+			GoOptions: GoOpts{
+				GenerateSimpleUnions: true,
+			},
+		},
+		wantStructsCodeFile: filepath.Join(TestRoot, "testdata/structs/operations.formatted-txt"),
 	}, {
 		name:    "simple openconfig test with deviate not-supported",
 		inFiles: []string{filepath.Join(datapath, "deviate-not-supported.yang")},
@@ -563,6 +584,57 @@ func TestSimpleStructs(t *testing.T) {
 		name:                "structs test with choices and cases",
 		inFiles:             []string{filepath.Join(datapath, "choice-case-example.yang")},
 		wantStructsCodeFile: filepath.Join(TestRoot, "testdata/structs/choice-case-example.formatted-txt"),
+	}, {
+		name:    "structs test with choices generated as sum types",
+		inFiles: []string{filepath.Join(datapath, "choice-sum-types.yang")},
+		inConfig: CodeGenerator{
+			GoOptions: GoOpts{
+				GenerateChoiceSumTypes:  true,
+				GenerateGetters:         true,
+				GenerateLeafGetters:     true,
+				GeneratePopulateDefault: true,
+			},
+		},
+		wantStructsCodeFile: filepath.Join(TestRoot, "testdata/structs/choice-sum-types.formatted-txt"),
+	}, {
+		name: "structs test with RFC7951 marshalling methods",
+		inFiles: []string{
+			filepath.Join(datapath, "rfc7951-methods.yang"),
+			filepath.Join(datapath, "rfc7951-methods-augment.yang"),
+		},
+		inConfig: CodeGenerator{
+			IROptions: ygen.IROptions{
+				TransformationOptions: ygen.TransformationOpts{
+					GenerateFakeRoot: true,
+				},
+			},
+			GoOptions: GoOpts{
+				GenerateSimpleUnions:   true,
+				GenerateRFC7951Methods: true,
+				AddAnnotationFields:    true,
+				AnnotationPrefix:       "Λ",
+				AddYangPresence:        true,
+			},
+		},
+		wantStructsCodeFile: filepath.Join(TestRoot, "testdata/structs/rfc7951-methods.formatted-txt"),
+	}, {
+		name:    "structs test with Equal, Copy and Diff methods",
+		inFiles: []string{filepath.Join(datapath, "equal-copy-diff.yang")},
+		inConfig: CodeGenerator{
+			IROptions: ygen.IROptions{
+				TransformationOptions: ygen.TransformationOpts{
+					GenerateFakeRoot: true,
+				},
+			},
+			GoOptions: GoOpts{
+				GenerateSimpleUnions:   true,
+				GenerateChoiceSumTypes: true,
+				GenerateEqualCopyDiff:  true,
+				AddAnnotationFields:    true,
+				AnnotationPrefix:       "Λ",
+			},
+		},
+		wantStructsCodeFile: filepath.Join(TestRoot, "testdata/structs/equal-copy-diff.formatted-txt"),
 	}, {
 		name: "module with augments",
 		inFiles: []string{
@@ -1165,6 +1237,7 @@ func TestSimpleStructs(t *testing.T) {
 
 				// Write generated enumeration map out.
 				fmt.Fprint(&gotCode, gotGeneratedCode.EnumMap)
+				fmt.Fprint(&gotCode, gotGeneratedCode.OperationMap)
 
 				var gotJSON map[string]interface{}
 				if tt.inConfig.GoOptions.GenerateJSONSchema {
@@ -1239,3 +1312,209 @@ func TestSimpleStructs(t *testing.T) {
 		})
 	}
 }
+
+// TestGenerateNameLock tests that the names within a name lock are used when
+// generating Go code.
+func TestGenerateNameLock(t *testing.T) {
+	opts := ygen.IROptions{
+		TransformationOptions: ygen.TransformationOpts{
+			CompressBehaviour:          genutil.PreferIntendedConfig,
+			EnumerationsUseUnderscores: true,
+		},
+		NameLock: &ygen.NameLock{
+			Directories: map[string]string{"/name-lock/interfaces/interface": "Intf"},
+		},
+	}
+	code, errs := New("", opts, GoOpts{}).Generate([]string{filepath.Join(datapath, "namelock", "v1", "name-lock.yang")}, nil)
+	if errs != nil {
+		t.Fatalf("Generate: got unexpected errors: %v", errs)
+	}
+	var got []string
+	for _, s := range code.Structs {
+		got = append(got, s.StructName)
+	}
+	if want := []string{"FooBar", "Intf"}; !cmp.Equal(got, want) {
+		t.Errorf("Generate: did not get expected structs, got %v, want %v", got, want)
+	}
+}
+
+// TestGenerateNameOverrides tests that overridden names are used in generated
+// Go code, and that the paths and YANG names used for serialisation are
+// unchanged.
+func TestGenerateNameOverrides(t *testing.T) {
+	opts := ygen.IROptions{
+		TransformationOptions: ygen.TransformationOpts{
+			CompressBehaviour:          genutil.PreferIntendedConfig,
+			EnumerationsUseUnderscores: true,
+		},
+		NameOverrides: &ygen.NameOverrides{
+			Nodes: map[string]*ygen.NameOverride{
+				"/interfaces/interface/config/description": {Field: "Desc"},
+				"/interfaces/interface/config/admin-status": {
+					Type:   "AdminStatus",
+					Values: map[string]string{"TESTING-MODE": "Testing"},
+				},
+			},
+		},
+	}
+	code, errs := New("", opts, GoOpts{GeneratePopulateDefault: true}).Generate([]string{filepath.Join(datapath, "name-overrides.yang")}, nil)
+	if errs != nil {
+		t.Fatalf("Generate: got unexpected errors: %v", errs)
+	}
+	var structs strings.Builder
+	for _, s := range code.Structs {
+		structs.WriteString(s.StructDef)
+		structs.WriteString(s.Methods)
+	}
+	enums := strings.Join(code.Enums, "")
+
+	for _, c := range []struct {
+		desc, code, want string
+	}{
+		{"overridden field", structs.String(), "Desc\t*string\t`path:\"config/description\" module:\"name-overrides/name-overrides\"`"},
+		{"enumerated field", structs.String(), "AdminStatus\tE_AdminStatus\t`path:\"config/admin-status\" module:\"name-overrides/name-overrides\"`"},
+		{"enumerated default", structs.String(), "AdminStatus_Testing"},
+		{"overridden value", enums, "AdminStatus_Testing E_AdminStatus = 3"},
+		{"value", enums, "AdminStatus_UP E_AdminStatus = 1"},
+		{"YANG value name", code.EnumMap, `3: {Name: "TESTING-MODE"}`},
+	} {
+		if !strings.Contains(c.code, c.want) {
+			t.Errorf("Generate: %s: did not find %q in generated code", c.desc, c.want)
+		}
+	}
+}
+
+// TestGenerateFieldNameOverrideClashes tests that field names given by name
+// overrides are rejected when they clash with the name of a method generated
+// for the parent struct.
+func TestGenerateFieldNameOverrideClashes(t *testing.T) {
+	tests := []struct {
+		name             string
+		inPath           string
+		inField          string
+		inGoOpts         GoOpts
+		wantErrSubstring string
+	}{{
+		name:    "no clash",
+		inPath:  "/interfaces/interface/config/description",
+		inField: "Desc",
+		inGoOpts: GoOpts{
+			GenerateJSONSchema:   true,
+			ValidateFunctionName: "Validate",
+			GenerateLeafGetters:  true,
+		},
+	}, {
+		name:             "clash with leaf getter",
+		inPath:           "/interfaces/interface/config/description",
+		inField:          "GetName",
+		inGoOpts:         GoOpts{GenerateLeafGetters: true},
+		wantErrSubstring: `field name "GetName" given to /interfaces/interface/config/description clashes with the method generated for field Name`,
+	}, {
+		name:    "leaf getter not generated",
+		inPath:  "/interfaces/interface/config/description",
+		inField: "GetName",
+	}, {
+		name:             "clash with validation method",
+		inPath:           "/interfaces/interface/config/description",
+		inField:          "Validate",
+		inGoOpts:         GoOpts{GenerateJSONSchema: true, ValidateFunctionName: "Validate"},
+		wantErrSubstring: "clashes with the validation method",
+	}, {
+		name:             "clash with list key method",
+		inPath:           "/interfaces/interface/config/description",
+		inField:          "ΛListKeyMap",
+		wantErrSubstring: "clashes with the list key method",
+	}, {
+		name:             "clash with list method",
+		inPath:           "/interfaces/interface/config/description",
+		inField:          "NewVrrpGroup",
+		wantErrSubstring: "clashes with the method generated for field VrrpGroup",
+	}, {
+		name:             "clash with union conversion method",
+		inPath:           "/interfaces/interface/config/description",
+		inField:          "To_Interface_Value_Union",
+		wantErrSubstring: "clashes with the method generated for field Value",
+	}, {
+		name:             "clash with Equal",
+		inPath:           "/interfaces/interface/config/description",
+		inField:          "Equal",
+		inGoOpts:         GoOpts{GenerateEqualCopyDiff: true},
+		wantErrSubstring: "clashes with the Equal, Copy or Diff method",
+	}, {
+		name:             "clash with RFC7951 method",
+		inPath:           "/interfaces/interface/config/description",
+		inField:          "MarshalRFC7951",
+		inGoOpts:         GoOpts{GenerateRFC7951Methods: true},
+		wantErrSubstring: "clashes with the RFC7951 method",
+	}}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			opts := ygen.IROptions{
+				TransformationOptions: ygen.TransformationOpts{
+					CompressBehaviour: genutil.PreferIntendedConfig,
+				},
+				NameOverrides: &ygen.NameOverrides{
+					Nodes: map[string]*ygen.NameOverride{
+						tt.inPath: {Field: tt.inField},
+					},
+				},
+			}
+			_, errs := New("", opts, tt.inGoOpts).Generate([]string{filepath.Join(datapath, "name-overrides.yang")}, nil)
+			var err error
+			if errs != nil {
+				err = errs
+			}
+			if diff := errdiff.Substring(err, tt.wantErrSubstring); diff != "" {
+				t.Fatalf("Generate: %s", diff)
+			}
+		})
+	}
+}
+
+// TestGenerateOperationsDisabled tests that, when operations are not
+// generated, RPCs that do not follow the OpenConfig conventions do not
+// prevent code generation with compressed paths, and that the schema of the
+// fake root does not include them.
+func TestGenerateOperationsDisabled(t *testing.T) {
+	inFiles := []string{filepath.Join(datapath, "openconfig-operations.yang")}
+	opts := ygen.IROptions{
+		ParseOptions: ygen.ParseOpts{
+			IgnoreUnsupportedStatements: true,
+		},
+		TransformationOptions: ygen.TransformationOpts{
+			CompressBehaviour: genutil.PreferIntendedConfig,
+			GenerateFakeRoot:  true,
+		},
+	}
+
+	got, errs := New("", opts, GoOpts{GenerateJSONSchema: true}).Generate(inFiles, nil)
+	if errs != nil {
+		t.Fatalf("Generate: got unexpected errors: %v", errs)
+	}
+	if got.OperationMap != "" {
+		t.Errorf("Generate: got unexpected operation map:\n%s", got.OperationMap)
+	}
+	for _, s := range got.Structs {
+		if strings.Contains(s.StructDef, "GetStuff") {
+			t.Errorf("Generate: got unexpected struct for RPC:\n%s", s.StructDef)
+		}
+	}
+
+	var schema struct {
+		Dir map[string]any
+	}
+	if err := json.Unmarshal(got.RawJSONSchema, &schema); err != nil {
+		t.Fatalf("json.Unmarshal: cannot unmarshal schema: %v", err)
+	}
+	var gotDir []string
+	for n := range schema.Dir {
+		gotDir = append(gotDir, n)
+	}
+	sort.Strings(gotDir)
+	// The notification is retained within the schema, as it was before
+	// operations could be generated, but the RPC is not.
+	if diff := cmp.Diff([]string{"event", "top"}, gotDir); diff != "" {
+		t.Errorf("Generate: did not get expected fake root schema children, diff(-want, +got):\n%s", diff)
+	}
+}
@@ -0,0 +1,437 @@
+// Copyright 2023 Google Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package gogen
+
+import (
+	"bytes"
+	"fmt"
+	"strings"
+
+	"github.com/openconfig/ygot/ygen"
+	"github.com/openconfig/ygot/ygot"
+)
+
+// The kinds of field for which code is generated within the Equal, Copy and
+// diff methods of a struct.
+const (
+	// equalCopyPtr is a leaf that is stored as a pointer to a comparable
+	// value.
+	equalCopyPtr = "ptr"
+	// equalCopyValue is a leaf whose value is comparable, such as an
+	// enumerated or empty leaf.
+	equalCopyValue = "value"
+	// equalCopySlice is a leaf-list of comparable values, or a binary
+	// leaf.
+	equalCopySlice = "slice"
+	// equalCopyContainer is a container.
+	equalCopyContainer = "container"
+	// equalCopyMap is a keyed list stored in a map.
+	equalCopyMap = "map"
+	// equalCopyOrderedMap is a keyed list that is ordered by the user.
+	equalCopyOrderedMap = "orderedmap"
+	// equalCopyStructSlice is an unkeyed list.
+	equalCopyStructSlice = "structslice"
+	// equalCopyChoice is a choice that is generated as a sum type.
+	equalCopyChoice = "choice"
+	// equalCopyReflect is a field that is compared and copied using
+	// reflection, such as a union, anydata or annotation field.
+	equalCopyReflect = "reflect"
+)
+
+// goEqualCopyField describes the code that is generated to compare, copy and
+// diff a field of a struct.
+type goEqualCopyField struct {
+	// Name is the name of the field.
+	Name string
+	// Kind is the kind of the field, which is one of the equalCopy*
+	// constants.
+	Kind string
+	// Paths is a Go expression for the data tree paths of the field.
+	Paths string
+	// ShadowPaths is a Go expression for the shadow data tree paths of the
+	// field.
+	ShadowPaths string
+	// IsLeaf indicates that a field of kind equalCopyReflect is a leaf or
+	// leaf-list.
+	IsLeaf bool
+	// IsAnnotation indicates that the field is an annotation, which is not
+	// included in the diff of the struct.
+	IsAnnotation bool
+	// Cases are the names of the structs of the cases of a choice field.
+	Cases []string
+}
+
+// goEqualCopyMethods is the input to the templates that generate the Equal,
+// Copy and diff methods of a struct.
+type goEqualCopyMethods struct {
+	// Receiver is the name of the struct.
+	Receiver string
+	// IsChoiceCase indicates that the struct is the case of a choice,
+	// which is not a GoStruct.
+	IsChoiceCase bool
+	// Fields are the fields of the struct.
+	Fields []*goEqualCopyField
+}
+
+// CopyCanFail reports whether copying any of the fields of the struct can
+// return an error.
+func (m goEqualCopyMethods) CopyCanFail() bool {
+	for _, f := range m.Fields {
+		switch f.Kind {
+		case equalCopyPtr, equalCopyValue, equalCopySlice:
+		default:
+			return true
+		}
+	}
+	return false
+}
+
+// newGoEqualCopyField returns the description of the code that is generated
+// to compare, copy and diff fieldDef, which is the field that represents
+// field. isOrderedMap indicates that field is a list that is stored using an
+// ordered map.
+func newGoEqualCopyField(fieldDef *goStructField, field *ygen.NodeDetails, isOrderedMap bool, goOpts GoOpts) *goEqualCopyField {
+	f := &goEqualCopyField{
+		Name:        fieldDef.Name,
+		Kind:        equalCopyReflect,
+		Paths:       goPathsLiteral(field.MappedPaths),
+		ShadowPaths: "nil",
+	}
+	// The shadow paths are only used by the reflection-based diff when they
+	// are stored in the struct tags.
+	if goOpts.IgnoreShadowSchemaPaths {
+		f.ShadowPaths = goPathsLiteral(field.ShadowMappedPaths)
+	}
+
+	switch field.Type {
+	case ygen.ListNode:
+		switch {
+		case isOrderedMap:
+			f.Kind = equalCopyOrderedMap
+		case strings.HasPrefix(fieldDef.Type, "map["):
+			f.Kind = equalCopyMap
+		default:
+			f.Kind = equalCopyStructSlice
+		}
+	case ygen.ContainerNode:
+		f.Kind = equalCopyContainer
+	case ygen.LeafNode:
+		f.IsLeaf = true
+		t := field.LangType.NativeType
+		switch {
+		case len(field.LangType.UnionTypes) > 1:
+		case field.LangType.IsEnumeratedValue && !fieldDef.IsScalarField, t == ygot.EmptyTypeName:
+			f.Kind = equalCopyValue
+		case t == ygot.BinaryTypeName:
+			f.Kind = equalCopySlice
+		case fieldDef.IsScalarField && isComparableScalar(t):
+			f.Kind = equalCopyPtr
+		}
+	case ygen.LeafListNode:
+		f.IsLeaf = true
+		t := field.LangType.NativeType
+		switch {
+		case len(field.LangType.UnionTypes) > 1:
+		case field.LangType.IsEnumeratedValue, isComparableScalar(t):
+			f.Kind = equalCopySlice
+		}
+	}
+	return f
+}
+
+// isComparableScalar reports whether t is the name of a Go builtin type used
+// for YANG leaves whose values can be compared using ==.
+func isComparableScalar(t string) bool {
+	_, ok := rfc7951Scalars[t]
+	return ok && t != ygot.BinaryTypeName
+}
+
+var (
+	// goEqualCopyTemplate takes an input goEqualCopyMethods, and generates
+	// the Equal and Copy methods of the struct, along with the methods
+	// that implement the ygot.GoStructCopier and ygot.GoStructDiffer
+	// interfaces for GoStructs.
+	goEqualCopyTemplate = mustMakeTemplate("equalCopy", `
+// Equal reports whether {{ .Receiver }} is equal to o. Nil values are only
+// equal to other nil values.
+func (t *{{ .Receiver }}) Equal(o *{{ .Receiver }}) bool {
+	if t == nil || o == nil {
+		return t == o
+	}
+{{- range $f := .Fields }}
+	{{- if eq $f.Kind "choice" }}
+	switch c := t.{{ $f.Name }}.(type) {
+	{{- range $c := $f.Cases }}
+	case *{{ $c }}:
+		if oc, ok := o.{{ $f.Name }}.(*{{ $c }}); !ok || !c.Equal(oc) {
+			return false
+		}
+	{{- end }}
+	default:
+		if !reflect.DeepEqual(t.{{ $f.Name }}, o.{{ $f.Name }}) {
+			return false
+		}
+	}
+	{{- else }}
+	if {{ template "equalCopyDiffers" $f }} {
+		return false
+	}
+	{{- end }}
+{{- end }}
+	return true
+}
+
+// Copy returns a deep copy of {{ .Receiver }}. As with ygot.DeepCopy, empty
+// maps and slices are not retained in the copy.
+func (t *{{ .Receiver }}) Copy() (*{{ .Receiver }}, error) {
+	if t == nil {
+		return nil, nil
+	}
+	n := &{{ .Receiver }}{}
+	{{- if .CopyCanFail }}
+	var err error
+	{{- end }}
+{{- range $f := .Fields }}
+	{{- if eq $f.Kind "ptr" }}
+	if t.{{ $f.Name }} != nil {
+		v := *t.{{ $f.Name }}
+		n.{{ $f.Name }} = &v
+	}
+	{{- else if eq $f.Kind "value" }}
+	n.{{ $f.Name }} = t.{{ $f.Name }}
+	{{- else if eq $f.Kind "slice" }}
+	n.{{ $f.Name }} = ygot.CopySlice(t.{{ $f.Name }})
+	{{- else if eq $f.Kind "container" }}
+	if n.{{ $f.Name }}, err = t.{{ $f.Name }}.Copy(); err != nil {
+		return nil, err
+	}
+	{{- else if eq $f.Kind "orderedmap" }}
+	if t.{{ $f.Name }}.Len() != 0 {
+		if n.{{ $f.Name }}, err = t.{{ $f.Name }}.Copy(); err != nil {
+			return nil, err
+		}
+	}
+	{{- else if eq $f.Kind "map" }}
+	if n.{{ $f.Name }}, err = ygot.CopyMap(t.{{ $f.Name }}); err != nil {
+		return nil, err
+	}
+	{{- else if eq $f.Kind "structslice" }}
+	if n.{{ $f.Name }}, err = ygot.CopyStructSlice(t.{{ $f.Name }}); err != nil {
+		return nil, err
+	}
+	{{- else if eq $f.Kind "choice" }}
+	switch c := t.{{ $f.Name }}.(type) {
+	{{- range $c := $f.Cases }}
+	case *{{ $c }}:
+		if n.{{ $f.Name }}, err = c.Copy(); err != nil {
+			return nil, err
+		}
+	{{- end }}
+	default:
+		if n.{{ $f.Name }}, err = ygot.CopyField(t.{{ $f.Name }}); err != nil {
+			return nil, err
+		}
+	}
+	{{- else }}
+	if n.{{ $f.Name }}, err = ygot.CopyField(t.{{ $f.Name }}); err != nil {
+		return nil, err
+	}
+	{{- end }}
+{{- end }}
+	return n, nil
+}
+{{- if not .IsChoiceCase }}
+
+// ΛDeepCopy returns a deep copy of {{ .Receiver }}. It implements the
+// ygot.GoStructCopier interface.
+func (t *{{ .Receiver }}) ΛDeepCopy() (ygot.GoStruct, error) {
+	return t.Copy()
+}
+
+// ΛDiff reports the fields of {{ .Receiver }} that differ from those of other
+// to d. It implements the ygot.GoStructDiffer interface.
+func (t *{{ .Receiver }}) ΛDiff(d *ygot.DiffWalker, other ygot.GoStruct) error {
+	o, _ := other.(*{{ .Receiver }})
+	return t.diff(d, o)
+}
+{{- end }}
+
+// diff reports the fields of {{ .Receiver }} that differ from those of o to d.
+func (t *{{ .Receiver }}) diff(d *ygot.DiffWalker, o *{{ .Receiver }}) error {
+	if t == nil {
+		t = &{{ .Receiver }}{}
+	}
+	if o == nil {
+		o = &{{ .Receiver }}{}
+	}
+{{- range $f := .Fields }}
+	{{- if $f.IsAnnotation }}
+	{{- else if eq $f.Kind "choice" }}
+	switch c := t.{{ $f.Name }}.(type) {
+	{{- range $c := $f.Cases }}
+	case *{{ $c }}:
+		oc, _ := o.{{ $f.Name }}.(*{{ $c }})
+		if err := c.diff(d, oc); err != nil {
+			return err
+		}
+	{{- end }}
+	}
+	switch c := o.{{ $f.Name }}.(type) {
+	{{- range $c := $f.Cases }}
+	case *{{ $c }}:
+		if _, ok := t.{{ $f.Name }}.(*{{ $c }}); !ok {
+			if err := (*{{ $c }})(nil).diff(d, c); err != nil {
+				return err
+			}
+		}
+	{{- end }}
+	}
+	{{- else }}
+	if {{ template "equalCopyDiffers" $f }} {
+		{{- if or $f.IsLeaf (eq $f.Kind "ptr") (eq $f.Kind "value") (eq $f.Kind "slice") }}
+		if err := d.Leaf({{ $f.Paths }}, {{ $f.ShadowPaths }}, t.{{ $f.Name }}, o.{{ $f.Name }}); err != nil {
+			return err
+		}
+		{{- else if eq $f.Kind "container" }}
+		if err := d.Struct({{ $f.Paths }}, {{ $f.ShadowPaths }}, t.{{ $f.Name }}, o.{{ $f.Name }}); err != nil {
+			return err
+		}
+		{{- else if eq $f.Kind "map" }}
+		if err := ygot.DiffMaps(d, {{ $f.Paths }}, {{ $f.ShadowPaths }}, t.{{ $f.Name }}, o.{{ $f.Name }}); err != nil {
+			return err
+		}
+		{{- else }}
+		if err := d.Field(t, o, "{{ $f.Name }}"); err != nil {
+			return err
+		}
+		{{- end }}
+	}
+	{{- end }}
+{{- end }}
+	return nil
+}
+{{- define "equalCopyDiffers" }}
+	{{- if eq .Kind "ptr" -}}
+	!ygot.EqualPtrs(t.{{ .Name }}, o.{{ .Name }})
+	{{- else if eq .Kind "value" -}}
+	t.{{ .Name }} != o.{{ .Name }}
+	{{- else if eq .Kind "slice" -}}
+	!ygot.EqualSlices(t.{{ .Name }}, o.{{ .Name }})
+	{{- else if or (eq .Kind "container") (eq .Kind "orderedmap") -}}
+	!t.{{ .Name }}.Equal(o.{{ .Name }})
+	{{- else if eq .Kind "map" -}}
+	!ygot.EqualMaps(t.{{ .Name }}, o.{{ .Name }})
+	{{- else if eq .Kind "structslice" -}}
+	!ygot.EqualStructSlices(t.{{ .Name }}, o.{{ .Name }})
+	{{- else -}}
+	!reflect.DeepEqual(t.{{ .Name }}, o.{{ .Name }})
+	{{- end -}}
+{{- end }}
+`)
+
+	// goOrderedMapEqualCopyTemplate takes an input generatedOrderedMapStruct,
+	// and generates the Equal and Copy methods of the ordered map.
+	goOrderedMapEqualCopyTemplate = mustMakeTemplate("orderedMapEqualCopy", `
+// Equal reports whether {{ .StructName }} is equal to p, such that it has
+// the same keys in the same order, and equal values for each key.
+func (o *{{ .StructName }}) Equal(p *{{ .StructName }}) bool {
+	if o == nil || p == nil {
+		return o == p
+	}
+	if len(o.keys) != len(p.keys) {
+		return false
+	}
+	for i, key := range o.keys {
+		if p.keys[i] != key || !o.valueMap[key].Equal(p.valueMap[key]) {
+			return false
+		}
+	}
+	return true
+}
+
+// Copy returns a deep copy of {{ .StructName }}.
+func (o *{{ .StructName }}) Copy() (*{{ .StructName }}, error) {
+	if o == nil {
+		return nil, nil
+	}
+	n := &{{ .StructName }}{}
+	for _, key := range o.keys {
+		v, err := o.valueMap[key].Copy()
+		if err != nil {
+			return nil, err
+		}
+		n.keys = append(n.keys, key)
+		n.init()
+		n.valueMap[key] = v
+	}
+	return n, nil
+}
+`)
+)
+
+// generateEqualCopyDiffMethods generates the Equal, Copy and diff methods for
+// the struct described by structDef, and for the cases of the supplied
+// choices within it, writing them to buf. fields describes the schema node
+// that is represented by each field.
+func generateEqualCopyDiffMethods(buf *bytes.Buffer, structDef generatedGoStruct, choices *goChoiceSet, fields map[*goStructField]*goFieldDetails, goOpts GoOpts) error {
+	type choiceStruct struct {
+		generatedGoStruct
+		isCase  bool
+		choices []*goChoice
+	}
+	structs := []choiceStruct{{generatedGoStruct: structDef}}
+	if choices != nil {
+		structs[0].choices = choices.choices
+		for _, c := range choices.cases() {
+			structs = append(structs, choiceStruct{generatedGoStruct: c.generatedGoStruct, isCase: true, choices: c.Choices})
+		}
+	}
+
+	for _, s := range structs {
+		m := goEqualCopyMethods{Receiver: s.StructName, IsChoiceCase: s.isCase}
+		for _, fd := range s.Fields {
+			var f *goEqualCopyField
+			switch d, ok := fields[fd]; {
+			case ok && d.Node != nil:
+				f = newGoEqualCopyField(fd, d.Node, d.IsOrderedMap, goOpts)
+			case ok:
+				f = &goEqualCopyField{Name: fd.Name, Kind: equalCopyReflect, IsAnnotation: true}
+			case strings.HasPrefix(fd.Tags, "choice:"):
+				f = &goEqualCopyField{Name: fd.Name, Kind: equalCopyChoice}
+				for _, ch := range s.choices {
+					if ch.FieldName != fd.Name {
+						continue
+					}
+					for _, c := range ch.Cases {
+						f.Cases = append(f.Cases, c.StructName)
+					}
+				}
+			default:
+				return fmt.Errorf("cannot generate Equal, Copy and diff methods for field %s of %s", fd.Name, s.StructName)
+			}
+			m.Fields = append(m.Fields, f)
+		}
+		if err := goEqualCopyTemplate.Execute(buf, m); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// generateOrderedMapEqualCopy generates the Equal and Copy methods of the
+// ordered map described by s, writing them to buf.
+func generateOrderedMapEqualCopy(buf *bytes.Buffer, s *generatedOrderedMapStruct) error {
+	return goOrderedMapEqualCopyTemplate.Execute(buf, s)
+}
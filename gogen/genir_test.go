@@ -1,7 +1,10 @@
 package gogen
 
 import (
+	"fmt"
+	"os"
 	"path/filepath"
+	"strings"
 	"testing"
 
 	"github.com/google/go-cmp/cmp"
@@ -10,6 +13,7 @@ import (
 	gpb "github.com/openconfig/gnmi/proto/gnmi"
 	"github.com/openconfig/goyang/pkg/yang"
 	"github.com/openconfig/ygot/genutil"
+	"github.com/openconfig/ygot/testutil"
 	"github.com/openconfig/ygot/ygen"
 	"github.com/openconfig/ygot/ygot"
 	"google.golang.org/protobuf/testing/protocmp"
@@ -1972,3 +1976,579 @@ func TestGenerateIR(t *testing.T) {
 		})
 	}
 }
+
+func TestGenerateIROperations(t *testing.T) {
+	got, err := ygen.GenerateIR([]string{filepath.Join(datapath, "operations.yang")}, nil, NewGoLangMapper(true), ygen.IROptions{
+		TransformationOptions: ygen.TransformationOpts{
+			CompressBehaviour:  genutil.Uncompressed,
+			GenerateFakeRoot:   true,
+			GenerateOperations: true,
+		},
+	})
+	if err != nil {
+		t.Fatalf("GenerateIR: got unexpected error: %v", err)
+	}
+
+	wantOps := map[string]*ygen.ParsedOperation{
+		"/operations/link-down": {
+			Name:            "link-down",
+			Type:            ygot.NotificationOperation,
+			Path:            "/operations/link-down",
+			SchemaPath:      "/link-down",
+			BelongingModule: "operations",
+		},
+		"/operations/ping": {
+			Name:            "ping",
+			Type:            ygot.RPCOperation,
+			Path:            "/operations/ping",
+			SchemaPath:      "/ping",
+			BelongingModule: "operations",
+			InputPath:       "/operations/ping/input",
+			OutputPath:      "/operations/ping/output",
+		},
+		"/operations/reboot": {
+			Name:            "reboot",
+			Type:            ygot.RPCOperation,
+			Path:            "/operations/reboot",
+			SchemaPath:      "/reboot",
+			BelongingModule: "operations",
+		},
+		"/operations/system/server/restart": {
+			Name:            "restart",
+			Type:            ygot.ActionOperation,
+			Path:            "/operations/system/server/restart",
+			SchemaPath:      "/system/server/restart",
+			BelongingModule: "operations",
+			InputPath:       "/operations/system/server/restart/input",
+			OutputPath:      "/operations/system/server/restart/output",
+		},
+	}
+	if diff := cmp.Diff(wantOps, got.Operations); diff != "" {
+		t.Errorf("GenerateIR: did not get expected operations, diff(-want,+got):\n%s", diff)
+	}
+
+	wantInOperation := map[string]bool{
+		"/device":                   false,
+		"/operations/system":        false,
+		"/operations/system/server": false,
+		"/operations/system/server/restart/input":  true,
+		"/operations/system/server/restart/output": true,
+		"/operations/ping/input":                   true,
+		"/operations/ping/output":                  true,
+		"/operations/ping/output/result":           true,
+		"/operations/link-down":                    true,
+		"/operations/link-down/details":            true,
+	}
+	gotInOperation := map[string]bool{}
+	for p, d := range got.Directories {
+		gotInOperation[p] = d.InOperation
+	}
+	if diff := cmp.Diff(wantInOperation, gotInOperation); diff != "" {
+		t.Errorf("GenerateIR: did not get expected InOperation values for directories, diff(-want,+got):\n%s", diff)
+	}
+}
+
+func TestMarshalIRRoundTrip(t *testing.T) {
+	tests := []struct {
+		desc        string
+		inYANGFiles []string
+		inOpts      ygen.IROptions
+	}{{
+		desc: "compressed schema with augments",
+		inYANGFiles: []string{
+			filepath.Join(datapath, "openconfig-simple.yang"),
+			filepath.Join(datapath, "openconfig-simple-augment2.yang"),
+		},
+		inOpts: ygen.IROptions{
+			TransformationOptions: ygen.TransformationOpts{
+				CompressBehaviour: genutil.PreferIntendedConfig,
+				GenerateFakeRoot:  true,
+			},
+		},
+	}, {
+		desc: "uncompressed schema with operations and choices",
+		inYANGFiles: []string{
+			filepath.Join(datapath, "operations.yang"),
+			filepath.Join(datapath, "choice-case-example.yang"),
+		},
+		inOpts: ygen.IROptions{
+			TransformationOptions: ygen.TransformationOpts{
+				GenerateFakeRoot:   true,
+				GenerateOperations: true,
+			},
+		},
+	}}
+
+	for _, tt := range tests {
+		t.Run(tt.desc, func(t *testing.T) {
+			ir, err := ygen.GenerateIR(tt.inYANGFiles, nil, goLangMapper{GoLangMapper: NewGoLangMapper(true)}, tt.inOpts)
+			if err != nil {
+				t.Fatalf("GenerateIR: got unexpected error: %v", err)
+			}
+
+			b, err := ygen.MarshalIR(ir, ygen.MarshalIROpts{IncludeSchemaTree: true})
+			if err != nil {
+				t.Fatalf("MarshalIR: got unexpected error: %v", err)
+			}
+			got, err := ygen.UnmarshalIR(b)
+			if err != nil {
+				t.Fatalf("UnmarshalIR: got unexpected error: %v", err)
+			}
+			if diff := cmp.Diff(ir, got, cmpopts.IgnoreUnexported(ygen.IR{}), cmpopts.EquateEmpty(), protocmp.Transform()); diff != "" {
+				t.Errorf("UnmarshalIR: did not get expected IR, diff(-want,+got):\n%s", diff)
+			}
+
+			wantTree, err := ir.SchemaTree(false)
+			if err != nil {
+				t.Fatalf("SchemaTree: got unexpected error for generated IR: %v", err)
+			}
+			gotTree, err := got.SchemaTree(false)
+			if err != nil {
+				t.Fatalf("SchemaTree: got unexpected error for loaded IR: %v", err)
+			}
+			if diff := cmp.Diff(string(wantTree), string(gotTree)); diff != "" {
+				t.Errorf("SchemaTree: did not get expected tree for loaded IR, diff(-want,+got):\n%s", diff)
+			}
+		})
+	}
+}
+
+func TestIRTreeDiagram(t *testing.T) {
+	tests := []struct {
+		desc        string
+		inYANGFiles []string
+		inOpts      ygen.IROptions
+		// wantFile is the path to the file containing the tree diagram
+		// that is expected to be rendered.
+		wantFile string
+	}{{
+		desc: "uncompressed schema with augments",
+		inYANGFiles: []string{
+			filepath.Join(datapath, "openconfig-simple.yang"),
+			filepath.Join(datapath, "openconfig-simple-augment2.yang"),
+		},
+		inOpts: ygen.IROptions{
+			TransformationOptions: ygen.TransformationOpts{
+				GenerateFakeRoot: true,
+			},
+		},
+		wantFile: filepath.Join("testdata", "treediagram", "openconfig-simple-uncompressed.formatted-txt"),
+	}, {
+		desc: "compressed schema with augments",
+		inYANGFiles: []string{
+			filepath.Join(datapath, "openconfig-simple.yang"),
+			filepath.Join(datapath, "openconfig-simple-augment2.yang"),
+		},
+		inOpts: ygen.IROptions{
+			TransformationOptions: ygen.TransformationOpts{
+				CompressBehaviour: genutil.PreferIntendedConfig,
+				GenerateFakeRoot:  true,
+			},
+		},
+		wantFile: filepath.Join("testdata", "treediagram", "openconfig-simple-compressed.formatted-txt"),
+	}, {
+		desc: "operations and choices without fake root",
+		inYANGFiles: []string{
+			filepath.Join(datapath, "operations.yang"),
+			filepath.Join(datapath, "choice-case-example.yang"),
+		},
+		inOpts: ygen.IROptions{
+			TransformationOptions: ygen.TransformationOpts{
+				GenerateOperations: true,
+			},
+		},
+		wantFile: filepath.Join("testdata", "treediagram", "operations-choices.formatted-txt"),
+	}}
+
+	for _, tt := range tests {
+		t.Run(tt.desc, func(t *testing.T) {
+			ir, err := ygen.GenerateIR(tt.inYANGFiles, nil, NewGoLangMapper(true), tt.inOpts)
+			if err != nil {
+				t.Fatalf("GenerateIR: got unexpected error: %v", err)
+			}
+
+			got, err := ir.TreeDiagram()
+			if err != nil {
+				t.Fatalf("TreeDiagram: got unexpected error: %v", err)
+			}
+
+			want, err := os.ReadFile(tt.wantFile)
+			if err != nil {
+				t.Fatalf("cannot read want file %s: %v", tt.wantFile, err)
+			}
+			if got != string(want) {
+				diff, _ := testutil.GenerateUnifiedDiff(string(want), got)
+				t.Errorf("TreeDiagram: did not get expected tree diagram (file: %s), diff(-want, +got):\n%s", tt.wantFile, diff)
+			}
+
+			b, err := ygen.MarshalIR(ir, ygen.MarshalIROpts{})
+			if err != nil {
+				t.Fatalf("MarshalIR: got unexpected error: %v", err)
+			}
+			loaded, err := ygen.UnmarshalIR(b)
+			if err != nil {
+				t.Fatalf("UnmarshalIR: got unexpected error: %v", err)
+			}
+			if _, err := loaded.TreeDiagram(); err == nil {
+				t.Errorf("TreeDiagram: did not get expected error for loaded IR")
+			}
+		})
+	}
+}
+
+func TestGenerateIRPathAllowlist(t *testing.T) {
+	tests := []struct {
+		desc            string
+		inPathAllowlist []string
+		inCompress      genutil.CompressBehaviour
+		// wantFile is the path to the file containing the tree diagram
+		// of the pruned schema that is expected to be rendered.
+		wantFile string
+		// wantEnums are the names of the enumerated types that are
+		// expected to be generated for the pruned schema.
+		wantEnums        []string
+		wantErrSubstring string
+	}{{
+		desc:            "uncompressed schema with leafref and list key dependencies",
+		inPathAllowlist: []string{"/interfaces/interface/config/vrf", "/pa:system/hostname", "/ping"},
+		wantFile:        filepath.Join("testdata", "treediagram", "path-allowlist-uncompressed.formatted-txt"),
+		wantEnums:       []string{"PathAllowlist_Interfaces_Interface_Config_Vrf"},
+	}, {
+		desc:            "compressed schema with wildcards",
+		inPathAllowlist: []string{"/interfaces/interface/*/mtu", "/network-instances"},
+		inCompress:      genutil.PreferIntendedConfig,
+		wantFile:        filepath.Join("testdata", "treediagram", "path-allowlist-compressed.formatted-txt"),
+		wantEnums:       []string{"PathAllowlist_NetworkInstance_Type"},
+	}, {
+		desc:             "path that does not match any node",
+		inPathAllowlist:  []string{"/interfaces/interface/config/speed"},
+		wantErrSubstring: "does not match any schema node",
+	}, {
+		desc:             "relative path",
+		inPathAllowlist:  []string{"interfaces/interface"},
+		wantErrSubstring: "is not an absolute schema path",
+	}, {
+		desc:             "invalid wildcard",
+		inPathAllowlist:  []string{"/interfaces/[interface"},
+		wantErrSubstring: "has invalid element",
+	}}
+
+	for _, tt := range tests {
+		t.Run(tt.desc, func(t *testing.T) {
+			ir, err := ygen.GenerateIR([]string{filepath.Join(datapath, "path-allowlist.yang")}, nil, NewGoLangMapper(true), ygen.IROptions{
+				TransformationOptions: ygen.TransformationOpts{
+					CompressBehaviour:          tt.inCompress,
+					GenerateFakeRoot:           true,
+					EnumerationsUseUnderscores: true,
+					PathAllowlist:              tt.inPathAllowlist,
+					GenerateOperations:         true,
+				},
+			})
+			if diff := errdiff.Substring(err, tt.wantErrSubstring); diff != "" {
+				t.Fatalf("GenerateIR: did not get expected error, %s", diff)
+			}
+			if err != nil {
+				return
+			}
+
+			got, err := ir.TreeDiagram()
+			if err != nil {
+				t.Fatalf("TreeDiagram: got unexpected error: %v", err)
+			}
+			want, err := os.ReadFile(tt.wantFile)
+			if err != nil {
+				t.Fatalf("cannot read want file %s: %v", tt.wantFile, err)
+			}
+			if got != string(want) {
+				diff, _ := testutil.GenerateUnifiedDiff(string(want), got)
+				t.Errorf("TreeDiagram: did not get expected tree diagram (file: %s), diff(-want, +got):\n%s", tt.wantFile, diff)
+			}
+
+			var gotEnums []string
+			for _, e := range ir.Enums {
+				gotEnums = append(gotEnums, e.Name)
+			}
+			if diff := cmp.Diff(tt.wantEnums, gotEnums, cmpopts.SortSlices(func(a, b string) bool { return a < b })); diff != "" {
+				t.Errorf("GenerateIR: did not get expected enumerated types, diff(-want, +got):\n%s", diff)
+			}
+		})
+	}
+}
+
+func TestGenerateIRNameLock(t *testing.T) {
+	v1 := []string{filepath.Join(datapath, "namelock", "v1", "name-lock.yang")}
+	v2 := []string{filepath.Join(datapath, "namelock", "v2", "name-lock.yang")}
+	opts := ygen.IROptions{
+		TransformationOptions: ygen.TransformationOpts{
+			CompressBehaviour:          genutil.PreferIntendedConfig,
+			EnumerationsUseUnderscores: true,
+		},
+	}
+
+	tests := []struct {
+		desc string
+		// inLock specifies whether the lock of the original version of
+		// the module is used when generating the updated version.
+		inLock bool
+		// inLockedDirectories, inLockedEnums and inLockedUnions are names
+		// that are added to the lock of the original version of the
+		// module.
+		inLockedDirectories map[string]string
+		inLockedEnums       map[string]string
+		inLockedUnions      map[string]string
+		want                *ygen.NameLock
+		wantErrSubstring    string
+	}{{
+		desc: "without lock, existing entities are renamed",
+		want: &ygen.NameLock{
+			Directories: map[string]string{
+				"/name-lock/foo-bar":              "FooBar",
+				"/name-lock/foo_bar":              "FooBar_",
+				"/name-lock/interfaces/interface": "Interface",
+				"/name-lock/tunnels/interface":    "Interface_",
+			},
+			Enums: map[string]string{
+				"/name-lock/interface-config/mode":         "NameLock_Interfaces_Interface_Mode",
+				"/name-lock/tunnels/interface/config/mode": "NameLock_Tunnels_Interface_Mode",
+			},
+			Unions: map[string]string{
+				"/name-lock/interfaces/interface/config/value": "Interface_Value_Union",
+				"/name-lock/tunnels/interface/config/value":    "Interface_Value_Union",
+			},
+		},
+	}, {
+		desc:             "new enumerated type clashes with locked name",
+		inLock:           true,
+		wantErrSubstring: `enumerated type name "NameLock_Interface_Mode" for /name-lock/tunnels/interface/config/mode is locked to /name-lock/interface-config/mode`,
+	}, {
+		desc:   "new directory clashes with locked name",
+		inLock: true,
+		inLockedEnums: map[string]string{
+			"/name-lock/tunnels/interface/config/mode": "NameLock_Tunnel_Mode",
+		},
+		wantErrSubstring: `directory name "FooBar" for /name-lock/foo-bar is locked to /name-lock/foo_bar`,
+	}, {
+		desc:   "new union clashes with existing name",
+		inLock: true,
+		inLockedDirectories: map[string]string{
+			"/name-lock/foo-bar":           "FooBar2",
+			"/name-lock/tunnels/interface": "Tunnel",
+		},
+		inLockedEnums: map[string]string{
+			"/name-lock/tunnels/interface/config/mode": "NameLock_Tunnel_Mode",
+		},
+		wantErrSubstring: `union name "Interface_Value_Union" is assigned to both /name-lock/interfaces/interface/config/value and /name-lock/tunnels/interface/config/value`,
+	}, {
+		desc:   "new entities are named in lock",
+		inLock: true,
+		inLockedDirectories: map[string]string{
+			"/name-lock/foo-bar":           "FooBar2",
+			"/name-lock/tunnels/interface": "Tunnel",
+		},
+		inLockedEnums: map[string]string{
+			"/name-lock/tunnels/interface/config/mode": "NameLock_Tunnel_Mode",
+		},
+		inLockedUnions: map[string]string{
+			"/name-lock/tunnels/interface/config/value": "Tunnel_Value_Union",
+		},
+		want: &ygen.NameLock{
+			Directories: map[string]string{
+				"/name-lock/foo-bar":              "FooBar2",
+				"/name-lock/foo_bar":              "FooBar",
+				"/name-lock/interfaces/interface": "Interface",
+				"/name-lock/tunnels/interface":    "Tunnel",
+			},
+			Enums: map[string]string{
+				"/name-lock/interface-config/mode":         "NameLock_Interface_Mode",
+				"/name-lock/tunnels/interface/config/mode": "NameLock_Tunnel_Mode",
+			},
+			Unions: map[string]string{
+				"/name-lock/interfaces/interface/config/value": "Interface_Value_Union",
+				"/name-lock/tunnels/interface/config/value":    "Tunnel_Value_Union",
+			},
+		},
+	}}
+
+	for _, tt := range tests {
+		t.Run(tt.desc, func(t *testing.T) {
+			opts := opts
+			if tt.inLock {
+				ir, err := ygen.GenerateIR(v1, nil, NewGoLangMapper(true), opts)
+				if err != nil {
+					t.Fatalf("GenerateIR: got unexpected error for original module: %v", err)
+				}
+				// Round trip the lock through its serialised form, as
+				// it would be stored between runs.
+				b, err := ygen.MarshalNameLock(ygen.NewNameLock(ir))
+				if err != nil {
+					t.Fatalf("MarshalNameLock: got unexpected error: %v", err)
+				}
+				if opts.NameLock, err = ygen.UnmarshalNameLock(b); err != nil {
+					t.Fatalf("UnmarshalNameLock: got unexpected error: %v", err)
+				}
+				for p, n := range tt.inLockedDirectories {
+					opts.NameLock.Directories[p] = n
+				}
+				for p, n := range tt.inLockedEnums {
+					opts.NameLock.Enums[p] = n
+				}
+				for p, n := range tt.inLockedUnions {
+					opts.NameLock.Unions[p] = n
+				}
+			}
+
+			ir, err := ygen.GenerateIR(v2, nil, NewGoLangMapper(true), opts)
+			if diff := errdiff.Substring(err, tt.wantErrSubstring); diff != "" {
+				t.Fatalf("GenerateIR: did not get expected error, %s", diff)
+			}
+			if err != nil {
+				return
+			}
+			if diff := cmp.Diff(tt.want, ygen.NewNameLock(ir), cmpopts.IgnoreUnexported(ygen.NameLock{})); diff != "" {
+				t.Errorf("GenerateIR: did not get expected names, diff(-want, +got):\n%s", diff)
+			}
+		})
+	}
+}
+
+func TestGenerateIRNameOverrides(t *testing.T) {
+	in := []string{filepath.Join(datapath, "name-overrides.yang")}
+	overrides := func() map[string]*ygen.NameOverride {
+		return map[string]*ygen.NameOverride{
+			"/interfaces/interface/vrrp/vrrp-group/interface-tracking": {Type: "VrrpTracking"},
+			"/interfaces/interface/config/description":                 {Field: "Desc"},
+			"/interfaces/interface/config/admin-status": {
+				Type:   "AdminStatus",
+				Values: map[string]string{"TESTING-MODE": "Testing"},
+			},
+			"/interfaces/interface/config/value": {Type: "InterfaceValue"},
+			"/protocols/config/protocol":         {Type: "Protocol", Values: map[string]string{"BGP": "Bgp"}},
+		}
+	}
+
+	tests := []struct {
+		desc string
+		// inOverrides modifies the default overrides.
+		inOverrides      func(map[string]*ygen.NameOverride)
+		wantErrSubstring string
+	}{{
+		desc: "overrides",
+	}, {
+		desc: "directory name clashes",
+		inOverrides: func(o map[string]*ygen.NameOverride) {
+			o["/interfaces/interface/vrrp/vrrp-group/interface-tracking"].Type = "Protocols"
+		},
+		wantErrSubstring: "name overrides: directory name",
+	}, {
+		desc: "union name clashes with a struct",
+		inOverrides: func(o map[string]*ygen.NameOverride) {
+			o["/interfaces/interface/config/value"].Type = "Protocols"
+		},
+		wantErrSubstring: `name overrides: union name "Protocols" given to /name-overrides/interfaces/interface/config/value is already assigned to /name-overrides/protocols`,
+	}, {
+		desc: "union name clashes with an overridden struct",
+		inOverrides: func(o map[string]*ygen.NameOverride) {
+			o["/interfaces/interface/config/value"].Type = "VrrpTracking"
+		},
+		wantErrSubstring: `name overrides: union name "VrrpTracking" given to /name-overrides/interfaces/interface/config/value is already assigned to`,
+	}, {
+		desc: "union name clashes with an enumerated type",
+		inOverrides: func(o map[string]*ygen.NameOverride) {
+			o["/interfaces/interface/config/value"].Type = "E_AdminStatus"
+		},
+		wantErrSubstring: `name overrides: union name "E_AdminStatus" given to /name-overrides/interfaces/interface/config/value is the name of an enumerated type`,
+	}, {
+		desc: "union name is reserved",
+		inOverrides: func(o map[string]*ygen.NameOverride) {
+			o["/interfaces/interface/config/value"].Type = "Binary"
+		},
+		wantErrSubstring: `name overrides: union name "Binary" given to /name-overrides/interfaces/interface/config/value is reserved`,
+	}, {
+		desc: "field name clashes",
+		inOverrides: func(o map[string]*ygen.NameOverride) {
+			o["/interfaces/interface/config/description"].Field = "Name"
+		},
+		wantErrSubstring: `name overrides: fields /interfaces/interface/config/description and /interfaces/interface/config/name of /interfaces/interface have the same name "Name"`,
+	}, {
+		desc: "enumerated type name clashes",
+		inOverrides: func(o map[string]*ygen.NameOverride) {
+			o["/protocols/config/protocol"].Type = "AdminStatus"
+		},
+		wantErrSubstring: `enumerated type name "AdminStatus" is assigned to more than one enumerated type`,
+	}, {
+		desc: "compressed container",
+		inOverrides: func(o map[string]*ygen.NameOverride) {
+			o["/interfaces/interface/config"] = &ygen.NameOverride{Type: "InterfaceConfig"}
+		},
+		wantErrSubstring: "type name for /interfaces/interface/config does not correspond to a generated struct",
+	}, {
+		desc: "undefined value",
+		inOverrides: func(o map[string]*ygen.NameOverride) {
+			o["/protocols/config/protocol"].Values["OSPF"] = "Ospf"
+		},
+		wantErrSubstring: "value OSPF of /protocols/config/protocol is not a value of its enumerated type",
+	}, {
+		desc: "value names for non-enumerated leaf",
+		inOverrides: func(o map[string]*ygen.NameOverride) {
+			o["/interfaces/interface/config/description"].Values = map[string]string{"UP": "Up"}
+		},
+		wantErrSubstring: "value names for /interfaces/interface/config/description do not correspond to a leaf of enumerated type",
+	}}
+
+	for _, tt := range tests {
+		for _, simpleUnions := range []bool{true, false} {
+			t.Run(fmt.Sprintf("%s (simple unions: %v)", tt.desc, simpleUnions), func(t *testing.T) {
+				o := overrides()
+				if tt.inOverrides != nil {
+					tt.inOverrides(o)
+				}
+				opts := ygen.IROptions{
+					TransformationOptions: ygen.TransformationOpts{
+						CompressBehaviour:          genutil.PreferIntendedConfig,
+						EnumerationsUseUnderscores: true,
+						GenerateFakeRoot:           true,
+					},
+					NameOverrides: &ygen.NameOverrides{Nodes: o},
+				}
+				ir, err := ygen.GenerateIR(in, nil, NewGoLangMapper(simpleUnions), opts)
+				if diff := errdiff.Substring(err, tt.wantErrSubstring); diff != "" {
+					t.Fatalf("GenerateIR: did not get expected error, %s", diff)
+				}
+				if err != nil {
+					return
+				}
+
+				intf := ir.Directories["/name-overrides/interfaces/interface"]
+				for _, c := range []struct {
+					desc, got, want string
+				}{
+					{"struct name", ir.Directories["/name-overrides/interfaces/interface/vrrp/vrrp-group/interface-tracking"].Name, "VrrpTracking"},
+					{"field name", intf.Fields["description"].Name, "Desc"},
+					{"field path", strings.Join(intf.Fields["description"].MappedPaths[0], "/"), "config/description"},
+					{"enumerated type", intf.Fields["admin-status"].LangType.NativeType, "E_AdminStatus"},
+					{"enumerated default", *intf.Fields["admin-status"].LangType.DefaultValue, "AdminStatus_Testing"},
+					{"union", intf.Fields["value"].LangType.NativeType, "InterfaceValue"},
+					{"identity", ir.Directories["/name-overrides/protocols"].Fields["protocol"].LangType.NativeType, "E_Protocol"},
+				} {
+					if c.got != c.want {
+						t.Errorf("GenerateIR: got %s %q, want %q", c.desc, c.got, c.want)
+					}
+				}
+
+				valueNames := map[string]map[string]string{}
+				for _, e := range ir.Enums {
+					if e.ValueNames != nil {
+						valueNames[e.Name] = e.ValueNames
+					}
+				}
+				wantValueNames := map[string]map[string]string{
+					"AdminStatus": {"TESTING-MODE": "Testing"},
+					"Protocol":    {"BGP": "Bgp"},
+				}
+				if diff := cmp.Diff(wantValueNames, valueNames); diff != "" {
+					t.Errorf("GenerateIR: did not get expected value names, diff(-want, +got):\n%s", diff)
+				}
+			})
+		}
+	}
+}
@@ -147,6 +147,21 @@ type GoLangMapper struct {
 	// entry, how to refer to it when generating code.
 	uniqueDirectoryNames map[string]string
 
+	// overriddenDirectoryPaths is a map, keyed by the name of a directory
+	// whose name is overridden, of the path of the directory, such that
+	// clashes with the overridden names can be reported.
+	overriddenDirectoryPaths map[string]string
+
+	// unionPaths is a map, keyed by the name of a union type, of the path
+	// of the leaf that the union was first generated for.
+	unionPaths map[string]string
+
+	// overriddenUnionPaths is a map, keyed by the name of a union type
+	// whose name is overridden, of the path of the leaf that the union was
+	// generated for, such that clashes with the overridden names can be
+	// reported.
+	overriddenUnionPaths map[string]string
+
 	// simpleUnions specifies whether simple typedefs are used to represent
 	// union subtypes in the generated code instead of using wrapper types.
 	// NOTE: This flag will be removed as part of ygot's v1 release.
@@ -167,8 +182,11 @@ func NewGoLangMapper(simpleUnions bool) *GoLangMapper {
 			ygot.BinaryTypeName: true,
 			ygot.EmptyTypeName:  true,
 		},
-		uniqueDirectoryNames: map[string]string{},
-		simpleUnions:         simpleUnions,
+		uniqueDirectoryNames:     map[string]string{},
+		overriddenDirectoryPaths: map[string]string{},
+		unionPaths:               map[string]string{},
+		overriddenUnionPaths:     map[string]string{},
+		simpleUnions:             simpleUnions,
 	}
 }
 
@@ -234,6 +252,20 @@ func pathToCamelCaseName(e *yang.Entry, compressOCPaths bool) string {
 // Although name conversion is lossy, name uniquification occurs at this stage
 // since all generated struct names reside in the package namespace.
 func (s *GoLangMapper) DirectoryName(e *yang.Entry, compressBehaviour genutil.CompressBehaviour) (string, error) {
+	if o := s.NameOverrides().Lookup(e); o != nil && o.Type != "" {
+		return s.overriddenDirectoryName(e, o.Type)
+	}
+	name := pathToCamelCaseName(e, compressBehaviour.CompressEnabled())
+	if p, ok := s.overriddenDirectoryPaths[name]; ok {
+		return "", fmt.Errorf("name overrides: directory name for %s clashes with the name given to %s", e.Path(), p)
+	}
+	if p, ok := s.overriddenUnionPaths[name]; ok {
+		return "", fmt.Errorf("name overrides: directory name for %s clashes with the union name given to %s", e.Path(), p)
+	}
+	if lock := s.NameLock(); lock != nil {
+		return s.lockedDirectoryName(e, compressBehaviour, lock)
+	}
+
 	// TODO(wenbli): Do not uniquify at this step -- rather do this in a
 	// later pass to avoid non-idempotent behaviour in GoLangMapper.
 	uniqName := genutil.MakeNameUnique(pathToCamelCaseName(e, compressBehaviour.CompressEnabled()), s.definedGlobals)
@@ -245,10 +277,65 @@ func (s *GoLangMapper) DirectoryName(e *yang.Entry, compressBehaviour genutil.Co
 	return uniqName, nil
 }
 
+// lockedDirectoryName returns the name of the directory entry e when names
+// are locked by lock. The locked name of the directory is used if there is
+// one, and otherwise its default name. Rather than making the name unique,
+// an error is returned if it is already in use, or is locked to another
+// directory.
+func (s *GoLangMapper) lockedDirectoryName(e *yang.Entry, compressBehaviour genutil.CompressBehaviour, lock *ygen.NameLock) (string, error) {
+	path := e.Path()
+	name, ok := lock.Directories[path]
+	if !ok {
+		name = pathToCamelCaseName(e, compressBehaviour.CompressEnabled())
+	}
+	if s.definedGlobals[name] {
+		for p, n := range s.uniqueDirectoryNames {
+			if n == name {
+				return "", fmt.Errorf("name lock: directory name %q for %s is already assigned to %s; assign a unique name to %s in the name lock", name, path, p, path)
+			}
+		}
+		return "", fmt.Errorf("name lock: directory name %q for %s is reserved; assign a unique name to %s in the name lock", name, path, path)
+	}
+	if p, ok := lock.DirectoryPath(name); ok && p != path {
+		return "", fmt.Errorf("name lock: directory name %q for %s is locked to %s; assign a unique name to %s in the name lock", name, path, p, path)
+	}
+	s.definedGlobals[name] = true
+	s.uniqueDirectoryNames[e.Path()] = name
+	return name, nil
+}
+
+// overriddenDirectoryName returns the name of the directory entry e, whose
+// name is overridden to be name. An error is returned if the name is already
+// in use.
+func (s *GoLangMapper) overriddenDirectoryName(e *yang.Entry, name string) (string, error) {
+	path := e.Path()
+	if s.definedGlobals[name] {
+		for p, n := range s.uniqueDirectoryNames {
+			if n == name {
+				return "", fmt.Errorf("name overrides: directory name %q given to %s is already assigned to %s", name, path, p)
+			}
+		}
+		if p, ok := s.overriddenUnionPaths[name]; ok {
+			return "", fmt.Errorf("name overrides: directory name %q given to %s is already assigned to the union of %s", name, path, p)
+		}
+		return "", fmt.Errorf("name overrides: directory name %q given to %s is reserved", name, path)
+	}
+	if s.isEnumeratedTypeName(name) {
+		return "", fmt.Errorf("name overrides: directory name %q given to %s is the name of an enumerated type", name, path)
+	}
+	s.definedGlobals[name] = true
+	s.uniqueDirectoryNames[path] = name
+	s.overriddenDirectoryPaths[name] = path
+	return name, nil
+}
+
 // FieldName maps the input entry's name to what the Go name of the field would be.
 // Since this conversion is lossy, a later step should resolve any naming
 // conflicts between different fields.
 func (s *GoLangMapper) FieldName(e *yang.Entry) (string, error) {
+	if o := s.NameOverrides().Lookup(e); o != nil && o.Field != "" {
+		return o.Field, nil
+	}
 	return genutil.EntryCamelCaseName(e), nil
 }
 
@@ -459,8 +546,26 @@ func (s *GoLangMapper) goUnionType(args resolveTypeArgs, compressOCPaths, skipEn
 		return nil, fmt.Errorf("errors mapping element: %v", errs)
 	}
 
+	unionName := fmt.Sprintf("%s_Union", pathToCamelCaseName(args.contextEntry, compressOCPaths))
+	if lock := s.NameLock(); lock != nil {
+		if n, ok := lock.Unions[args.contextEntry.Path()]; ok {
+			unionName = n
+		}
+	}
+	o := s.NameOverrides().Lookup(args.contextEntry)
+	overridden := o != nil && o.Type != ""
+	if overridden {
+		unionName = o.Type
+	}
+	// A type is only generated for a union with more than one subtype.
+	if len(unionMappedTypes) > 1 {
+		if err := s.addUnionName(unionName, args.contextEntry.Path(), overridden); err != nil {
+			return nil, err
+		}
+	}
+
 	resolvedType := &ygen.MappedType{
-		NativeType: fmt.Sprintf("%s_Union", pathToCamelCaseName(args.contextEntry, compressOCPaths)),
+		NativeType: unionName,
 		// Zero value is set to nil, other than in cases where there is
 		// a single type in the union.
 		ZeroValue:    "nil",
@@ -476,6 +581,46 @@ func (s *GoLangMapper) goUnionType(args resolveTypeArgs, compressOCPaths, skipEn
 	return resolvedType, nil
 }
 
+// addUnionName records that the union type with the supplied name is
+// generated for the leaf at path, where overridden specifies whether the name
+// is overridden. It returns an error if the name is overridden and clashes
+// with the name of a struct, enumerated type, reserved name or another union,
+// or if the name clashes with that of a union whose name is overridden.
+func (s *GoLangMapper) addUnionName(name, path string, overridden bool) error {
+	if p, ok := s.unionPaths[name]; ok {
+		// The union of a leaf is mapped more than once, e.g., where the
+		// leaf is also a list key, and the unions of different leaves
+		// may share a default name.
+		if p != path && (overridden || s.overriddenUnionPaths[name] != "") {
+			return fmt.Errorf("name overrides: union name %q of %s clashes with the union of %s", name, path, p)
+		}
+		return nil
+	}
+	if overridden {
+		if s.definedGlobals[name] {
+			for p, n := range s.uniqueDirectoryNames {
+				if n == name {
+					return fmt.Errorf("name overrides: union name %q given to %s is already assigned to %s", name, path, p)
+				}
+			}
+			return fmt.Errorf("name overrides: union name %q given to %s is reserved", name, path)
+		}
+		if s.isEnumeratedTypeName(name) {
+			return fmt.Errorf("name overrides: union name %q given to %s is the name of an enumerated type", name, path)
+		}
+		s.definedGlobals[name] = true
+		s.overriddenUnionPaths[name] = path
+	}
+	s.unionPaths[name] = path
+	return nil
+}
+
+// isEnumeratedTypeName returns true if name is the name of a generated Go
+// enumerated type.
+func (s *GoLangMapper) isEnumeratedTypeName(name string) bool {
+	return strings.HasPrefix(name, goEnumPrefix) && s.IsEnumeratedTypeName(strings.TrimPrefix(name, goEnumPrefix))
+}
+
 // goUnionSubTypes extracts all the possible subtypes of a YANG union leaf,
 // returning any errors that occur. In case of nested unions, the entire union
 // is flattened, and identical types are de-duped. currentTypes keeps track of
@@ -560,7 +705,7 @@ func generateGoDefaultValue(field *yang.Entry, mtype *ygen.MappedType, gogen *Go
 	// support the wrapper union generated code, so this if
 	// block would be obsolete.
 	if !simpleUnions {
-		defaultValues = goLeafDefaults(field, mtype)
+		defaultValues = goLeafDefaults(field, mtype, gogen.leafEnumValueNames(field, compressPaths, skipEnumDedup, shortenEnumLeafNames, useDefiningModuleForTypedefEnumNames, enumOrgPrefixesToTrim))
 		if len(defaultValues) != 0 && len(mtype.UnionTypes) > 1 {
 			// If the default value is applied to a union type, we will generate
 			// non-compilable code when generating wrapper unions, so error out and inform
@@ -585,6 +730,31 @@ func generateGoDefaultValue(field *yang.Entry, mtype *ygen.MappedType, gogen *Go
 	return defaultValue, nil
 }
 
+// leafEnumValueNames returns the overridden names of the values of the
+// enumerated type of the leaf e, keyed by the YANG name of each value, or nil
+// if e is not of enumerated type or no value names are overridden.
+func (s *GoLangMapper) leafEnumValueNames(e *yang.Entry, compressOCPaths, skipEnumDedup, shortenEnumLeafNames, useDefiningModuleForTypedefEnumNames bool, enumOrgPrefixesToTrim []string) map[string]string {
+	if s.NameOverrides() == nil {
+		return nil
+	}
+	// Errors are not returned since they are returned when the type of
+	// the leaf is mapped.
+	if _, key, isTypedef, err := s.EnumeratedTypedefTypeName(e.Type, e, goEnumPrefix, false, useDefiningModuleForTypedefEnumNames); err == nil && isTypedef {
+		return s.EnumValueNames(key)
+	}
+	switch e.Type.Kind {
+	case yang.Yenum:
+		if _, key, err := s.EnumName(e, compressOCPaths, false, skipEnumDedup, shortenEnumLeafNames, false, enumOrgPrefixesToTrim); err == nil {
+			return s.EnumValueNames(key)
+		}
+	case yang.Yidentityref:
+		if _, key, err := s.IdentityrefBaseTypeFromIdentity(e.Type.IdentityBase); err == nil {
+			return s.EnumValueNames(key)
+		}
+	}
+	return nil
+}
+
 // yangDefaultValueToGo takes a default value, and its associated type, schema
 // entry, whether it is a union with a single type, and other generation flags,
 // and maps it to a Go snippet reference that would represent the value in the
@@ -608,7 +778,7 @@ func generateGoDefaultValue(field *yang.Entry, mtype *ygen.MappedType, gogen *Go
 // type for each leaf is created.
 func (s *GoLangMapper) yangDefaultValueToGo(value string, args resolveTypeArgs, isSingletonUnion, compressOCPaths, skipEnumDedup, shortenEnumLeafNames, useDefiningModuleForTypedefEnumNames bool, enumOrgPrefixesToTrim []string) (string, yang.TypeKind, error) {
 	// Handle the case of a typedef which is actually an enumeration.
-	typedefName, _, isTypedef, err := s.EnumeratedTypedefTypeName(args.yangType, args.contextEntry, goEnumPrefix, false, useDefiningModuleForTypedefEnumNames)
+	typedefName, typedefKey, isTypedef, err := s.EnumeratedTypedefTypeName(args.yangType, args.contextEntry, goEnumPrefix, false, useDefiningModuleForTypedefEnumNames)
 	if err != nil {
 		// err is non nil when this was a typedef which included
 		// an invalid enumerated type.
@@ -628,7 +798,7 @@ func (s *GoLangMapper) yangDefaultValueToGo(value string, args resolveTypeArgs,
 				return "", yang.Ynone, fmt.Errorf("default value conversion: typedef identity value %q not found in enum with type name %q", value, args.yangType.Name)
 			}
 		}
-		return enumDefaultValue(typedefName, value, goEnumPrefix), args.yangType.Kind, nil
+		return enumDefaultValue(typedefName, value, goEnumPrefix, s.EnumValueNames(typedefKey)), args.yangType.Kind, nil
 	}
 
 	signed := false
@@ -730,11 +900,11 @@ func (s *GoLangMapper) yangDefaultValueToGo(value string, args resolveTypeArgs,
 		if !args.yangType.Enum.IsDefined(value) {
 			return "", yang.Ynone, fmt.Errorf("default value conversion: enum value %q not found in enum with type name %q", value, args.yangType.Name)
 		}
-		n, _, err := s.EnumName(args.contextEntry, compressOCPaths, false, skipEnumDedup, shortenEnumLeafNames, false, enumOrgPrefixesToTrim)
+		n, key, err := s.EnumName(args.contextEntry, compressOCPaths, false, skipEnumDedup, shortenEnumLeafNames, false, enumOrgPrefixesToTrim)
 		if err != nil {
 			return "", yang.Ynone, err
 		}
-		return enumDefaultValue(n, value, ""), ykind, nil
+		return enumDefaultValue(n, value, "", s.EnumValueNames(key)), ykind, nil
 	case yang.Yidentityref:
 		// Identityref leaves are mapped according to the base identity that they
 		// refer to - this is stored in the IdentityBase field of the context leaf
@@ -748,11 +918,11 @@ func (s *GoLangMapper) yangDefaultValueToGo(value string, args resolveTypeArgs,
 		if !args.yangType.IdentityBase.IsDefined(value) {
 			return "", yang.Ynone, fmt.Errorf("default value conversion: identity value %q not found in enum with type name %q", value, args.yangType.Name)
 		}
-		n, _, err := s.IdentityrefBaseTypeFromIdentity(args.yangType.IdentityBase)
+		n, key, err := s.IdentityrefBaseTypeFromIdentity(args.yangType.IdentityBase)
 		if err != nil {
 			return "", yang.Ynone, err
 		}
-		return enumDefaultValue(n, value, ""), ykind, nil
+		return enumDefaultValue(n, value, "", s.EnumValueNames(key)), ykind, nil
 	case yang.Yleafref:
 		// This is a leafref, so we check what the type of the leaf that it
 		// references is by looking it up.
@@ -785,9 +955,11 @@ func (s *GoLangMapper) yangDefaultValueToGo(value string, args resolveTypeArgs,
 
 // goLeafDefaults returns the default value(s) of the leaf e if specified. If it
 // is unspecified, the value specified by the type is returned if it is not nil,
-// otherwise nil is returned to indicate no default was specified.
+// otherwise nil is returned to indicate no default was specified. valueNames
+// are the overridden names of the values of the leaf's enumerated type, if
+// any.
 // TODO(wenbli): This doesn't handle unions. Deprecate this for v1 release.
-func goLeafDefaults(e *yang.Entry, t *ygen.MappedType) []string {
+func goLeafDefaults(e *yang.Entry, t *ygen.MappedType, valueNames map[string]string) []string {
 	defaultValues := e.DefaultValues()
 	if len(defaultValues) == 0 && t.DefaultValue != nil {
 		defaultValues = []string{*t.DefaultValue}
@@ -795,7 +967,7 @@ func goLeafDefaults(e *yang.Entry, t *ygen.MappedType) []string {
 
 	for i, defVal := range defaultValues {
 		if t.IsEnumeratedValue {
-			defaultValues[i] = enumDefaultValue(t.NativeType, defVal, goEnumPrefix)
+			defaultValues[i] = enumDefaultValue(t.NativeType, defVal, goEnumPrefix, valueNames)
 		} else {
 			defaultValues[i] = quoteDefault(defVal, t.NativeType)
 		}
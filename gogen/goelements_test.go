@@ -22,6 +22,7 @@ import (
 	"github.com/google/go-cmp/cmp"
 	"github.com/google/go-cmp/cmp/cmpopts"
 	"github.com/kylelemons/godebug/pretty"
+	"github.com/openconfig/gnmi/errdiff"
 	"github.com/openconfig/goyang/pkg/yang"
 	"github.com/openconfig/ygot/genutil"
 	"github.com/openconfig/ygot/internal/igenutil"
@@ -3257,10 +3258,70 @@ func TestGoLeafDefaults(t *testing.T) {
 
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
-			got := goLeafDefaults(tt.inLeaf, tt.inType)
+			got := goLeafDefaults(tt.inLeaf, tt.inType, nil)
 			if diff := cmp.Diff(tt.want, got); diff != "" {
 				t.Fatalf("did not get expected default, (-want, +got):\n%s", diff)
 			}
 		})
 	}
 }
+
+func TestAddUnionName(t *testing.T) {
+	type union struct {
+		name, path string
+		overridden bool
+	}
+	tests := []struct {
+		desc             string
+		in               []union
+		wantErrSubstring string
+	}{{
+		desc: "union mapped more than once",
+		in: []union{
+			{name: "Foo", path: "/a/foo", overridden: true},
+			{name: "Foo", path: "/a/foo", overridden: true},
+		},
+	}, {
+		desc: "default names shared",
+		in: []union{
+			{name: "A_Foo_Union", path: "/a/foo"},
+			{name: "A_Foo_Union", path: "/b/a/foo"},
+		},
+	}, {
+		desc: "overridden names clash",
+		in: []union{
+			{name: "Foo", path: "/a/foo", overridden: true},
+			{name: "Foo", path: "/a/bar", overridden: true},
+		},
+		wantErrSubstring: `name overrides: union name "Foo" of /a/bar clashes with the union of /a/foo`,
+	}, {
+		desc: "default name clashes with overridden name",
+		in: []union{
+			{name: "A_Foo_Union", path: "/a/bar", overridden: true},
+			{name: "A_Foo_Union", path: "/a/foo"},
+		},
+		wantErrSubstring: `name overrides: union name "A_Foo_Union" of /a/foo clashes with the union of /a/bar`,
+	}, {
+		desc: "overridden name clashes with default name",
+		in: []union{
+			{name: "A_Foo_Union", path: "/a/foo"},
+			{name: "A_Foo_Union", path: "/a/bar", overridden: true},
+		},
+		wantErrSubstring: `name overrides: union name "A_Foo_Union" of /a/bar clashes with the union of /a/foo`,
+	}}
+
+	for _, tt := range tests {
+		t.Run(tt.desc, func(t *testing.T) {
+			s := NewGoLangMapper(false)
+			var err error
+			for _, u := range tt.in {
+				if err = s.addUnionName(u.name, u.path, u.overridden); err != nil {
+					break
+				}
+			}
+			if diff := errdiff.Substring(err, tt.wantErrSubstring); diff != "" {
+				t.Fatalf("addUnionName: did not get expected error, %s", diff)
+			}
+		})
+	}
+}
@@ -39,7 +39,10 @@ type enumGeneratedCode struct {
 }
 
 // genGoEnumeratedTypes converts the input map of EnumeratedYANGType objects to
-// another intermediate representation suitable for Go code generation.
+// another intermediate representation suitable for Go code generation. The
+// overridden names of values are used where they are specified, and an error
+// is returned if the name of a value then clashes with that of another value
+// of the same type.
 func genGoEnumeratedTypes(enums map[string]*ygen.EnumeratedYANGType) (map[string]*goEnumeratedType, error) {
 	et := map[string]*goEnumeratedType{}
 	for _, e := range enums {
@@ -59,8 +62,17 @@ func genGoEnumeratedTypes(enums map[string]*ygen.EnumeratedYANGType) (map[string
 
 		switch e.Kind {
 		case ygen.IdentityType, ygen.SimpleEnumerationType, ygen.DerivedEnumerationType, ygen.UnionEnumerationType, ygen.DerivedUnionEnumerationType:
+			names := map[string]string{"UNSET": ""}
 			for _, v := range e.ValToYANGDetails {
-				values[int64(v.Value)+1] = safeGoEnumeratedValueName(v.Name)
+				n, ok := e.ValueNames[v.Name]
+				if !ok {
+					n = safeGoEnumeratedValueName(v.Name)
+				}
+				if prev, ok := names[n]; ok && (e.ValueNames[v.Name] != "" || e.ValueNames[prev] != "") {
+					return nil, fmt.Errorf("name overrides: name %q of value %s of %s clashes with another value", n, v.Name, e.Name)
+				}
+				names[n] = v.Name
+				values[int64(v.Value)+1] = n
 				origValues[int64(v.Value)+1] = v
 			}
 		default:
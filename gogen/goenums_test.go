@@ -19,6 +19,7 @@ import (
 	"testing"
 
 	"github.com/google/go-cmp/cmp"
+	"github.com/openconfig/gnmi/errdiff"
 	"github.com/openconfig/goyang/pkg/yang"
 	"github.com/openconfig/ygot/testutil"
 	"github.com/openconfig/ygot/ygen"
@@ -46,9 +47,10 @@ func TestGenGoEnumeratedTypes(t *testing.T) {
 	}
 
 	tests := []struct {
-		name string
-		in   map[string]*ygen.EnumeratedYANGType
-		want map[string]*goEnumeratedType
+		name             string
+		in               map[string]*ygen.EnumeratedYANGType
+		want             map[string]*goEnumeratedType
+		wantErrSubstring string
 	}{{
 		name: "enum",
 		in: map[string]*ygen.EnumeratedYANGType{
@@ -103,14 +105,73 @@ func TestGenGoEnumeratedTypes(t *testing.T) {
 				},
 			},
 		},
+	}, {
+		name: "enum with overridden value names",
+		in: map[string]*ygen.EnumeratedYANGType{
+			"foo": {
+				Name:     "EnumeratedValue",
+				Kind:     ygen.SimpleEnumerationType,
+				TypeName: "enumerated-value",
+				ValToYANGDetails: []ygot.EnumDefinition{
+					{Name: "VALUE-A", Value: 0},
+					{Name: "VALUE-B", Value: 1},
+				},
+				ValueNames: map[string]string{"VALUE-A": "A"},
+			},
+		},
+		want: map[string]*goEnumeratedType{
+			"EnumeratedValue": {
+				Name: "EnumeratedValue",
+				CodeValues: map[int64]string{
+					0: "UNSET",
+					1: "A",
+					2: "VALUE_B",
+				},
+				YANGValues: map[int64]ygot.EnumDefinition{
+					1: {Name: "VALUE-A", Value: 0},
+					2: {Name: "VALUE-B", Value: 1},
+				},
+			},
+		},
+	}, {
+		name: "overridden value name clashes with another value",
+		in: map[string]*ygen.EnumeratedYANGType{
+			"foo": {
+				Name:     "EnumeratedValue",
+				Kind:     ygen.SimpleEnumerationType,
+				TypeName: "enumerated-value",
+				ValToYANGDetails: []ygot.EnumDefinition{
+					{Name: "VALUE-A", Value: 0},
+					{Name: "VALUE-B", Value: 1},
+				},
+				ValueNames: map[string]string{"VALUE-B": "VALUE_A"},
+			},
+		},
+		wantErrSubstring: `name overrides: name "VALUE_A" of value VALUE-B of EnumeratedValue clashes with another value`,
+	}, {
+		name: "overridden value name clashes with unset value",
+		in: map[string]*ygen.EnumeratedYANGType{
+			"foo": {
+				Name:     "EnumeratedValue",
+				Kind:     ygen.SimpleEnumerationType,
+				TypeName: "enumerated-value",
+				ValToYANGDetails: []ygot.EnumDefinition{
+					{Name: "VALUE-A", Value: 0},
+				},
+				ValueNames: map[string]string{"VALUE-A": "UNSET"},
+			},
+		},
+		wantErrSubstring: `name overrides: name "UNSET" of value VALUE-A of EnumeratedValue clashes with another value`,
 	}}
 
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
 			got, err := genGoEnumeratedTypes(tt.in)
+			if diff := errdiff.Substring(err, tt.wantErrSubstring); diff != "" {
+				t.Fatalf("%s: genGoEnumeratedTypes(%v): did not get expected error, %s",
+					tt.name, tt.in, diff)
+			}
 			if err != nil {
-				t.Errorf("%s: genGoEnumeratedTypes(%v): got unexpected error: %v",
-					tt.name, tt.in, err)
 				return
 			}
 
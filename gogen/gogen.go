@@ -154,6 +154,9 @@ type GoStructCodeSnippet struct {
 	// used within the generated struct. Used when there are interfaces that
 	// represent multi-type unions generated.
 	Interfaces string
+	// Package is the name of the package that the struct is output to when
+	// the generated code is split by module. It is empty otherwise.
+	Package string
 }
 
 // String returns the contents of the receiver GoStructCodeSnippet as a string.
@@ -190,6 +193,19 @@ type goStructField struct {
 	IsYANGList bool
 }
 
+// goFieldDetails describes the schema node that is represented by a field of
+// a generated struct.
+type goFieldDetails struct {
+	// Node describes the schema node that the field represents. It is nil
+	// for annotation fields.
+	Node *ygen.NodeDetails
+	// IsOrderedMap indicates that the field is a list that is stored
+	// using an ordered map.
+	IsOrderedMap bool
+	// AnnotationPaths are the data tree paths of an annotation field.
+	AnnotationPaths [][]string
+}
+
 // goUnionInterface contains a definition of an interface that should
 // be generated for a multi-type union in YANG.
 type goUnionInterface struct {
@@ -325,6 +341,8 @@ import (
 
 {{- if .GenerateSchema }}
 	"{{ .GoOptions.GoyangImportPath }}"
+{{- end }}
+{{- if or .GenerateSchema .GoOptions.GenerateRFC7951Methods }}
 	"{{ .GoOptions.YtypesImportPath }}"
 {{- end }}
 {{- if .GoOptions.IncludeModelData }}
@@ -695,6 +713,39 @@ func initΛEnumTypes(){
 	{{- end }}
   }
 }
+`)
+
+	// goOperationMapTemplate provides a template to output a map, keyed by
+	// the name of a YANG module, of the RPCs, actions and notifications that
+	// are defined by the module.
+	goOperationMapTemplate = mustMakeTemplate("operationMap", `
+// ΛOperations is a map, keyed by the name of a YANG module, of the RPCs, actions
+// and notifications defined by the module for which GoStructs are included in
+// the generated code. The naming of the map ensures that there are no clashes
+// with valid YANG identifiers.
+var ΛOperations = map[string][]*ygot.Operation{
+{{- range $module, $ops := . }}
+	"{{ $module }}": {
+		{{- range $op := $ops }}
+		{
+			Name:   "{{ $op.Name }}",
+			Module: "{{ $module }}",
+			Type:   ygot.{{ $op.Type }},
+			Path:   "{{ $op.Path }}",
+			{{- if $op.Input }}
+			Input:  reflect.TypeOf((*{{ $op.Input }})(nil)),
+			{{- end }}
+			{{- if $op.Output }}
+			Output: reflect.TypeOf((*{{ $op.Output }})(nil)),
+			{{- end }}
+			{{- if $op.Notification }}
+			Notification: reflect.TypeOf((*{{ $op.Notification }})(nil)),
+			{{- end }}
+		},
+		{{- end }}
+	},
+{{- end }}
+}
 `)
 
 	// goEnumTypeMapAccessTemplate provides a template to output an accessor
@@ -1018,16 +1069,31 @@ func writeGoStruct(targetStruct *ygen.ParsedDirectory, goStructElements map[stri
 		annotationPrefix = DefaultAnnotationPrefix
 	}
 
+	// fieldDetails stores the details of the schema node that each field
+	// of the struct represents, which are used to generate the methods
+	// that access the fields without reflection.
+	fieldDetails := map[*goStructField]*goFieldDetails{}
+
 	if goOpts.AddAnnotationFields {
 		// Add the top-level struct metadata field.
-		structDef.Fields = append(structDef.Fields, &goStructField{
+		metadataField := &goStructField{
 			Name: fmt.Sprintf("%sMetadata", annotationPrefix),
 			Type: annotationFieldType,
 			Tags: `path:"@" ygotAnnotation:"true"`,
-		})
+		}
+		structDef.Fields = append(structDef.Fields, metadataField)
+		fieldDetails[metadataField] = &goFieldDetails{AnnotationPaths: [][]string{{"@"}}}
 	}
 
 	goFieldNameMap := ygen.GoFieldNameMap(targetStruct)
+
+	// choices stores the YANG choices within the struct when they are
+	// generated as sum types rather than being flattened into the struct.
+	var choices *goChoiceSet
+	if goOpts.GenerateChoiceSumTypes {
+		choices = newGoChoiceSet(&structDef, goFieldNameMap)
+	}
+
 	// Alphabetically order fields to produce deterministic output.
 	for _, fName := range targetStruct.OrderedFieldNames() {
 		// Iterate through the fields of the struct that we are generating code for.
@@ -1035,11 +1101,23 @@ func writeGoStruct(targetStruct *ygen.ParsedDirectory, goStructElements map[stri
 		// the corresponding type. fieldDef is used to store the definition of the field (name
 		// and type) that are calculated.
 		var fieldDef *goStructField
+		// isOrderedMap indicates that the field is a list that is stored
+		// using an ordered map.
+		var isOrderedMap bool
 
 		field := targetStruct.Fields[fName]
 		fieldName := goFieldNameMap[fName]
 		definedNameMap[fName] = &yangFieldMap{YANGName: fName, GoName: fieldName}
 
+		// fields is the set of fields to which the field is added, and
+		// receiver is the name of the struct that contains them. Fields
+		// within a choice are stored in the struct for their case.
+		fields, receiver := &structDef.Fields, targetStruct.Name
+		if choices != nil && len(field.YANGDetails.Choices) != 0 {
+			c := choices.caseFor(field.YANGDetails.Choices)
+			fields, receiver = &c.Fields, c.StructName
+		}
+
 		switch field.Type {
 		case ygen.ListNode:
 			// If the field within the struct is a list, then generate code for this list. This
@@ -1057,13 +1135,20 @@ func writeGoStruct(targetStruct *ygen.ParsedDirectory, goStructElements map[stri
 			}
 
 			if listMethods != nil {
+				listMethods.Receiver = receiver
 				associatedListMethods = append(associatedListMethods, listMethods)
 			}
 
-			if orderedMapSpec != nil {
+			isOrderedMap = orderedMapSpec != nil
+
+			switch {
+			case orderedMapSpec != nil:
+				orderedMapSpec.ParentStructName = receiver
 				associatedOrderedMapStructs = append(associatedOrderedMapStructs, orderedMapSpec)
-				associatedDefaultMethod.ChildOrderedListNames = append(associatedDefaultMethod.ChildOrderedListNames, fieldName)
-			} else {
+				if receiver == targetStruct.Name {
+					associatedDefaultMethod.ChildOrderedListNames = append(associatedDefaultMethod.ChildOrderedListNames, fieldName)
+				}
+			case receiver == targetStruct.Name:
 				associatedDefaultMethod.ChildUnorderedListNames = append(associatedDefaultMethod.ChildUnorderedListNames, fieldName)
 			}
 
@@ -1088,7 +1173,17 @@ func writeGoStruct(targetStruct *ygen.ParsedDirectory, goStructElements map[stri
 				Type:            fmt.Sprintf("*%s", dir.Name),
 				IsYANGContainer: true,
 			}
-			associatedDefaultMethod.ChildContainerNames = append(associatedDefaultMethod.ChildContainerNames, fieldName)
+			if receiver == targetStruct.Name {
+				associatedDefaultMethod.ChildContainerNames = append(associatedDefaultMethod.ChildContainerNames, fieldName)
+			}
+		case ygen.AnyDataNode:
+			// The contents of anydata and anyxml nodes are not described by
+			// the schema, so they are stored using the generic ygot type that
+			// can hold either RFC7951 JSON or a GoStruct.
+			fieldDef = &goStructField{
+				Name: fieldName,
+				Type: "*ygot.AnyData",
+			}
 		case ygen.LeafNode, ygen.LeafListNode:
 			// Only if this union has more than one subtype do we generate the union;
 			// otherwise, we use that subtype directly.
@@ -1172,7 +1267,7 @@ func writeGoStruct(targetStruct *ygen.ParsedDirectory, goStructElements map[stri
 				Type:     fType,
 				Zero:     zeroValue,
 				IsPtr:    scalarField,
-				Receiver: targetStruct.Name,
+				Receiver: receiver,
 				Default:  field.LangType.DefaultValue,
 			})
 
@@ -1183,7 +1278,7 @@ func writeGoStruct(targetStruct *ygen.ParsedDirectory, goStructElements map[stri
 				Name:     fieldName,
 				Type:     fType,
 				IsPtr:    scalarField,
-				Receiver: targetStruct.Name,
+				Receiver: receiver,
 			})
 
 			fieldDef = &goStructField{
@@ -1256,16 +1351,25 @@ func writeGoStruct(targetStruct *ygen.ParsedDirectory, goStructElements map[stri
 		fieldDef.Tags = tagBuf.String()
 
 		// Append the generated field definition to the set of fields of the struct.
-		structDef.Fields = append(structDef.Fields, fieldDef)
+		*fields = append(*fields, fieldDef)
+		fieldDetails[fieldDef] = &goFieldDetails{Node: field, IsOrderedMap: isOrderedMap}
 
 		if goOpts.AddAnnotationFields {
 			// Append the definition of the field annotation to the set of fields in the
 			// struct.
-			structDef.Fields = append(structDef.Fields, &goStructField{
+			annotationField := &goStructField{
 				Name: fmt.Sprintf("%s%s", annotationPrefix, fieldDef.Name),
 				Type: annotationFieldType,
 				Tags: metadataTagBuf.String(),
-			})
+			}
+			*fields = append(*fields, annotationField)
+			var paths [][]string
+			for _, p := range field.MappedPaths {
+				p = append([]string{}, p...)
+				p[len(p)-1] = fmt.Sprintf("@%s", p[len(p)-1])
+				paths = append(paths, p)
+			}
+			fieldDetails[annotationField] = &goFieldDetails{AnnotationPaths: paths}
 		}
 	}
 
@@ -1330,6 +1434,12 @@ func writeGoStruct(targetStruct *ygen.ParsedDirectory, goStructElements map[stri
 		}
 	}
 
+	if choices != nil && len(choices.choices) != 0 {
+		if err := generateChoiceMethods(&methodBuf, choices, goOpts.GenerateGetters); err != nil {
+			errs = append(errs, err)
+		}
+	}
+
 	if goOpts.GenerateLeafGetters {
 		if err := generateLeafGetters(&methodBuf, associatedLeafGetters); err != nil {
 			errs = append(errs, err)
@@ -1349,10 +1459,22 @@ func writeGoStruct(targetStruct *ygen.ParsedDirectory, goStructElements map[stri
 		if err := generateOrderedMapStruct(&methodBuf, s); err != nil {
 			errs = append(errs, err)
 		}
+		if goOpts.GenerateEqualCopyDiff {
+			if err := generateOrderedMapEqualCopy(&methodBuf, s); err != nil {
+				errs = append(errs, err)
+			}
+		}
 	}
 
 	if goOpts.GeneratePopulateDefault {
-		associatedDefaultMethod.Leaves = associatedLeafGetters
+		// Defaults are only populated for the leaves that are directly
+		// within the struct, since no case of a choice is selected by
+		// default.
+		for _, l := range associatedLeafGetters {
+			if l.Receiver == targetStruct.Name {
+				associatedDefaultMethod.Leaves = append(associatedDefaultMethod.Leaves, l)
+			}
+		}
 		if err := goDefaultMethodTemplate.Execute(&methodBuf, associatedDefaultMethod); err != nil {
 			errs = append(errs, err)
 		}
@@ -1389,6 +1511,12 @@ func writeGoStruct(targetStruct *ygen.ParsedDirectory, goStructElements map[stri
 		}
 	}
 
+	if choices != nil {
+		if err := generateChoices(&interfaceBuf, choices); err != nil {
+			errs = append(errs, err)
+		}
+	}
+
 	if goOpts.GenerateJSONSchema {
 		if err := generateValidator(&methodBuf, structDef, goOpts.ValidateFunctionName); err != nil {
 			errs = append(errs, err)
@@ -1399,6 +1527,18 @@ func writeGoStruct(targetStruct *ygen.ParsedDirectory, goStructElements map[stri
 		}
 	}
 
+	if goOpts.GenerateRFC7951Methods {
+		if err := generateRFC7951Methods(&methodBuf, structDef, choices, fieldDetails, goOpts); err != nil {
+			errs = append(errs, err)
+		}
+	}
+
+	if goOpts.GenerateEqualCopyDiff {
+		if err := generateEqualCopyDiffMethods(&methodBuf, structDef, choices, fieldDetails, goOpts); err != nil {
+			errs = append(errs, err)
+		}
+	}
+
 	if err := generateBelongingModuleFunction(&methodBuf, structDef); err != nil {
 		errs = append(errs, err)
 	}
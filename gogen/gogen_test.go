@@ -1084,18 +1084,45 @@ func (*Tstruct) ΛBelongingModule() string {
 		},
 		want: wantGoStructOut{wantErr: true},
 	}, {
-		name: "unknown kind",
+		name: "anydata field",
 		inStructToMap: &ygen.ParsedDirectory{
 			Name: "AStruct",
 			Fields: map[string]*ygen.NodeDetails{
 				"anydata": {
-					Name: "anydata",
-					Type: ygen.AnyDataNode,
+					Name: "Anydata",
+					YANGDetails: ygen.YANGNodeDetails{
+						Name:              "anydata",
+						RootElementModule: "exmod",
+						Path:              "/root-module/a-struct/anydata",
+					},
+					Type:              ygen.AnyDataNode,
+					MappedPaths:       [][]string{{"anydata"}},
+					MappedPathModules: [][]string{{"exmod"}},
 				},
 			},
+			Path:            "/root-module/a-struct",
 			BelongingModule: "exmod",
 		},
-		want: wantGoStructOut{wantErr: true},
+		want: wantGoStructOut{
+			structs: `
+// AStruct represents the /root-module/a-struct YANG schema element.
+type AStruct struct {
+	Anydata	*ygot.AnyData	` + "`" + `path:"anydata" module:"exmod"` + "`" + `
+}
+
+// IsYANGGoStruct ensures that AStruct implements the yang.GoStruct
+// interface. This allows functions that need to handle this struct to
+// identify it as being generated by ygen.
+func (*AStruct) IsYANGGoStruct() {}
+`,
+			methods: `
+// ΛBelongingModule returns the name of the module that defines the namespace
+// of AStruct.
+func (*AStruct) ΛBelongingModule() string {
+	return "exmod"
+}
+`,
+		},
 	}, {
 		name: "unknown field type",
 		inStructToMap: &ygen.ParsedDirectory{
@@ -41,8 +41,10 @@ func safeGoEnumeratedValueName(name string) string {
 // is used as the generated enumeration name stripping any prefix specified,
 // (allowing removal of the enumeration type prefix if required). The default
 // value in the form <sanitised_baseName>_<sanitised_defVal> is returned as
-// a pointer.
-func enumDefaultValue(baseName, defVal, prefix string) string {
+// a pointer. If the name of the value is overridden within valueNames, which
+// is keyed by the YANG name of the value, the overridden name is used rather
+// than the sanitised value.
+func enumDefaultValue(baseName, defVal, prefix string, valueNames map[string]string) string {
 	if strings.Contains(defVal, ":") {
 		defVal = strings.Split(defVal, ":")[1]
 	}
@@ -51,7 +53,11 @@ func enumDefaultValue(baseName, defVal, prefix string) string {
 		baseName = strings.TrimPrefix(baseName, prefix)
 	}
 
-	defVal = safeGoEnumeratedValueName(defVal)
+	if n, ok := valueNames[defVal]; ok {
+		defVal = n
+	} else {
+		defVal = safeGoEnumeratedValueName(defVal)
+	}
 
 	return fmt.Sprintf("%s_%s", baseName, defVal)
 }
@@ -0,0 +1,543 @@
+// Copyright 2023 Google Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package gogen
+
+import (
+	"bytes"
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/openconfig/goyang/pkg/yang"
+	"github.com/openconfig/ygot/ygen"
+	"github.com/openconfig/ygot/ygot"
+)
+
+// The kinds of field for which code is generated within the RFC7951 marshal
+// and unmarshal methods of a struct.
+const (
+	// rfc7951Value is a leaf whose value can be written directly.
+	rfc7951Value = "value"
+	// rfc7951Enum is a leaf of an enumerated type.
+	rfc7951Enum = "enum"
+	// rfc7951LeafList is a leaf-list whose elements can be written
+	// directly, or are of an enumerated type.
+	rfc7951LeafList = "leaflist"
+	// rfc7951Union is a leaf of a union type with more than one subtype.
+	rfc7951Union = "union"
+	// rfc7951Container is a container.
+	rfc7951Container = "container"
+	// rfc7951Map is a keyed list stored in a map.
+	rfc7951Map = "map"
+	// rfc7951OrderedMap is a keyed list that is ordered by the user.
+	rfc7951OrderedMap = "orderedmap"
+	// rfc7951Slice is an unkeyed list.
+	rfc7951Slice = "slice"
+	// rfc7951Choice is a choice that is generated as a sum type.
+	rfc7951Choice = "choice"
+	// rfc7951Reflect is a field that is marshalled and unmarshalled using
+	// reflection, such as an anydata, annotation or unsupported field.
+	rfc7951Reflect = "reflect"
+)
+
+// rfc7951Scalar describes how a value of a Go type used for a YANG leaf is
+// written to and read from RFC7951 JSON.
+type rfc7951Scalar struct {
+	// Marshal is the format of the expression that converts a value of
+	// the type to its JSON representation, given the value.
+	Marshal string
+	// Decoder is the name of the ytypes.RFC7951Decoder method that reads
+	// a value of the type.
+	Decoder string
+	// Builtin is the Go builtin type that the value must be converted to
+	// when it is stored in a named type, such as a simple union type.
+	Builtin string
+}
+
+// rfc7951Scalars describes the Go types used for YANG leaves that can be
+// written to and read from RFC7951 JSON without the use of reflection, keyed
+// by the name of the type.
+var rfc7951Scalars = map[string]rfc7951Scalar{
+	"string":            {Marshal: "ygot.RFC7951String(%s)", Decoder: "String", Builtin: "string"},
+	"bool":              {Marshal: "%s", Decoder: "Bool", Builtin: "bool"},
+	"int8":              {Marshal: "float64(%s)", Decoder: "Int8", Builtin: "int8"},
+	"int16":             {Marshal: "float64(%s)", Decoder: "Int16", Builtin: "int16"},
+	"int32":             {Marshal: "float64(%s)", Decoder: "Int32", Builtin: "int32"},
+	"int64":             {Marshal: "ygot.RFC7951Int64(%s)", Decoder: "Int64", Builtin: "int64"},
+	"uint8":             {Marshal: "float64(%s)", Decoder: "Uint8", Builtin: "uint8"},
+	"uint16":            {Marshal: "float64(%s)", Decoder: "Uint16", Builtin: "uint16"},
+	"uint32":            {Marshal: "float64(%s)", Decoder: "Uint32", Builtin: "uint32"},
+	"uint64":            {Marshal: "ygot.RFC7951Uint64(%s)", Decoder: "Uint64", Builtin: "uint64"},
+	"float64":           {Marshal: "ygot.RFC7951Decimal64(%s)", Decoder: "Decimal64", Builtin: "float64"},
+	ygot.BinaryTypeName: {Marshal: "ygot.RFC7951Binary(%s)", Decoder: "Binary"},
+}
+
+// goRFC7951Field describes the code that is generated to marshal a field of
+// a struct to, and unmarshal it from, RFC7951 JSON.
+type goRFC7951Field struct {
+	// Name is the name of the field.
+	Name string
+	// YANGName is the name of the schema node that the field represents.
+	YANGName string
+	// Kind is the kind of the field, which is one of the rfc7951*
+	// constants.
+	Kind string
+	// Paths is a Go expression for the data tree paths of the field.
+	Paths string
+	// Modules is a Go expression for the modules of the elements of the
+	// data tree paths of the field.
+	Modules string
+	// ShadowPaths is a Go expression for the shadow data tree paths of the
+	// field, which are accepted when unmarshalling.
+	ShadowPaths string
+	// IsPtr indicates that the field is a pointer to its value.
+	IsPtr bool
+	// Cond is the condition that the field is set, for value and
+	// annotation fields.
+	Cond string
+	// Value is the expression that returns the JSON representation of the
+	// field, for value fields, or of the element v of a leaf-list.
+	Value string
+	// EnumType is the name of the enumerated type of the field, or of the
+	// elements of the leaf-list.
+	EnumType string
+	// Decode is the call to the ytypes.RFC7951Decoder method that reads
+	// the JSON value v of the field, or of an element of the leaf-list.
+	Decode string
+	// Convert is the expression that converts the value x read by Decode
+	// to the type of the field, or of an element of the leaf-list.
+	Convert string
+	// Presence indicates that the field is a presence container.
+	Presence bool
+	// UnionCases are the cases of the type switch that is used to marshal
+	// a union field.
+	UnionCases []*goRFC7951UnionCase
+}
+
+// goRFC7951UnionCase describes a case of the type switch that is generated to
+// marshal a union field, which matches one of its subtypes.
+type goRFC7951UnionCase struct {
+	// Type is the type matched by the case.
+	Type string
+	// Expr is the expression that returns the value of the subtype, given
+	// the union value u.
+	Expr string
+	// EnumType is the name of the subtype if it is an enumerated type.
+	EnumType string
+	// Cond is the condition that the value is set.
+	Cond string
+	// Value is the expression that returns the JSON representation of the
+	// value.
+	Value string
+}
+
+// goRFC7951Methods is the input to the templates that generate the RFC7951
+// marshal and unmarshal methods of a struct.
+type goRFC7951Methods struct {
+	// Receiver is the name of the struct.
+	Receiver string
+	// Fields are the fields of the struct.
+	Fields []*goRFC7951Field
+}
+
+// newGoRFC7951AnnotationField returns the description of the code that is
+// generated to marshal and unmarshal the annotation field with the supplied
+// name, whose data tree paths are paths, to and from RFC7951 JSON.
+func newGoRFC7951AnnotationField(name string, paths [][]string) *goRFC7951Field {
+	return &goRFC7951Field{
+		Name:    name,
+		Kind:    rfc7951Reflect,
+		Paths:   goPathsLiteral(paths),
+		Modules: "nil",
+		Cond:    fmt.Sprintf("t.%s != nil", name),
+	}
+}
+
+// goPathsLiteral returns a Go expression for the supplied paths.
+func goPathsLiteral(paths [][]string) string {
+	if len(paths) == 0 {
+		return "nil"
+	}
+	var b strings.Builder
+	b.WriteString("[][]string{")
+	for i, p := range paths {
+		if i != 0 {
+			b.WriteString(", ")
+		}
+		b.WriteString("{")
+		for j, e := range p {
+			if j != 0 {
+				b.WriteString(", ")
+			}
+			fmt.Fprintf(&b, "%q", e)
+		}
+		b.WriteString("}")
+	}
+	b.WriteString("}")
+	return b.String()
+}
+
+// newGoRFC7951Field returns the description of the code that is generated to
+// marshal and unmarshal fieldDef, which is the field that represents field,
+// to and from RFC7951 JSON. isOrderedMap indicates that field is a list that
+// is stored using an ordered map.
+func newGoRFC7951Field(fieldDef *goStructField, field *ygen.NodeDetails, isOrderedMap bool, goOpts GoOpts) *goRFC7951Field {
+	f := &goRFC7951Field{
+		Name:    fieldDef.Name,
+		Kind:    rfc7951Reflect,
+		Paths:   goPathsLiteral(field.MappedPaths),
+		Modules: goPathsLiteral(field.MappedPathModules),
+		// The shadow paths are only accepted by the reflection-based
+		// unmarshalling when they are stored in the struct tags.
+		ShadowPaths: "nil",
+	}
+	if goOpts.IgnoreShadowSchemaPaths {
+		f.ShadowPaths = goPathsLiteral(field.ShadowMappedPaths)
+	}
+	if len(field.MappedPaths) != 0 {
+		p := field.MappedPaths[0]
+		f.YANGName = p[len(p)-1]
+	}
+
+	switch field.Type {
+	case ygen.ListNode:
+		switch {
+		case isOrderedMap:
+			f.Kind = rfc7951OrderedMap
+		case strings.HasPrefix(fieldDef.Type, "map["):
+			f.Kind = rfc7951Map
+		default:
+			f.Kind = rfc7951Slice
+		}
+	case ygen.ContainerNode:
+		f.Kind = rfc7951Container
+		f.Presence = goOpts.AddYangPresence && field.YANGDetails.PresenceStatement != nil
+	case ygen.LeafNode:
+		t := field.LangType.NativeType
+		switch s, ok := rfc7951Scalars[t]; {
+		case len(field.LangType.UnionTypes) > 1:
+			f.Kind = rfc7951Union
+			f.UnionCases = rfc7951UnionCases(field, goOpts.GenerateSimpleUnions)
+		case field.LangType.IsEnumeratedValue && !fieldDef.IsScalarField:
+			f.Kind = rfc7951Enum
+			f.EnumType = t
+			f.Decode = fmt.Sprintf("d.Enum(%q, %q, %s(0), %q, v)", f.YANGName, f.Name, t, t)
+			f.Convert = fmt.Sprintf("%s(x)", t)
+		case t == ygot.EmptyTypeName:
+			f.Kind = rfc7951Value
+			f.Cond = fmt.Sprintf("t.%s", f.Name)
+			f.Value = "[]interface{}{nil}"
+			f.Decode = fmt.Sprintf("d.Empty(%q, v)", f.YANGName)
+			f.Convert = fmt.Sprintf("%s(x)", t)
+		case t == ygot.BinaryTypeName:
+			f.Kind = rfc7951Value
+			f.Cond = fmt.Sprintf("t.%s != nil", f.Name)
+			f.Value = fmt.Sprintf(s.Marshal, "t."+f.Name)
+			f.Decode = fmt.Sprintf("d.%s(%q, v)", s.Decoder, f.YANGName)
+			f.Convert = fmt.Sprintf("%s(x)", t)
+		case ok && fieldDef.IsScalarField:
+			f.Kind = rfc7951Value
+			f.IsPtr = true
+			f.Cond = fmt.Sprintf("t.%s != nil", f.Name)
+			f.Value = fmt.Sprintf(s.Marshal, "*t."+f.Name)
+			f.Decode = fmt.Sprintf("d.%s(%q, v)", s.Decoder, f.YANGName)
+			f.Convert = "x"
+		}
+	case ygen.LeafListNode:
+		t := field.LangType.NativeType
+		switch s, ok := rfc7951Scalars[t]; {
+		case len(field.LangType.UnionTypes) > 1:
+		case field.LangType.IsEnumeratedValue:
+			f.Kind = rfc7951LeafList
+			f.EnumType = t
+			f.Decode = fmt.Sprintf("d.Enum(%q, %q, %s(0), %q, v)", f.YANGName, f.Name, t, t)
+			f.Convert = fmt.Sprintf("%s(x)", t)
+		case ok:
+			f.Kind = rfc7951LeafList
+			f.Value = fmt.Sprintf(s.Marshal, "v")
+			f.Decode = fmt.Sprintf("d.%s(%q, v)", s.Decoder, f.YANGName)
+			f.Convert = "x"
+			if t == ygot.BinaryTypeName {
+				f.Convert = fmt.Sprintf("%s(x)", t)
+			}
+		}
+	}
+	return f
+}
+
+// rfc7951UnionCases returns the cases of the type switch that is generated
+// to marshal the union field, whose subtypes are represented using simple
+// union types if simpleUnions is set, or wrapper structs otherwise. Subtypes
+// that cannot be marshalled without reflection are not included, such that
+// they are handled by the default case of the type switch.
+func rfc7951UnionCases(field *ygen.NodeDetails, simpleUnions bool) []*goRFC7951UnionCase {
+	var types []string
+	for t := range field.LangType.UnionTypes {
+		types = append(types, t)
+	}
+	sort.Strings(types)
+
+	var cases []*goRFC7951UnionCase
+	for _, t := range types {
+		// The type and value of the subtype are determined in the same
+		// way as the union types that are generated by writeGoStruct.
+		c := &goRFC7951UnionCase{}
+		switch tn := yang.CamelCase(t); {
+		case t == "interface{}":
+			continue
+		case simpleUnions:
+			c.Type, c.Expr = t, "u"
+			if simpleName, ok := ygot.SimpleUnionBuiltinGoTypes[t]; ok {
+				c.Type = simpleName
+			}
+		default:
+			c.Type, c.Expr = fmt.Sprintf("*%s_%s", field.LangType.NativeType, tn), "u."+tn
+		}
+
+		switch s, ok := rfc7951Scalars[t]; {
+		case strings.HasPrefix(t, goEnumPrefix):
+			c.EnumType = t
+		case t == ygot.EmptyTypeName && simpleUnions:
+			c.Cond = c.Expr
+			c.Value = "[]interface{}{nil}"
+		case t == ygot.EmptyTypeName:
+			// A YANGEmpty value that is stored in a wrapper struct is
+			// output as a boolean.
+			c.Value = fmt.Sprintf("bool(%s)", c.Expr)
+		case ok && s.Builtin != "" && simpleUnions:
+			c.Value = fmt.Sprintf(s.Marshal, fmt.Sprintf("%s(%s)", s.Builtin, c.Expr))
+		case ok:
+			c.Value = fmt.Sprintf(s.Marshal, c.Expr)
+		default:
+			continue
+		}
+		cases = append(cases, c)
+	}
+	return cases
+}
+
+var (
+	// goRFC7951MarshalTemplate takes an input goRFC7951Methods, and
+	// generates the method that marshals the struct to RFC7951 JSON.
+	goRFC7951MarshalTemplate = mustMakeTemplate("rfc7951Marshal", `
+// MarshalRFC7951 writes the contents of {{ .Receiver }} to the supplied
+// encoder as RFC7951 JSON. It implements the ygot.RFC7951Marshaler interface.
+func (t *{{ .Receiver }}) MarshalRFC7951(e *ygot.RFC7951Encoder) error {
+{{- range $f := .Fields }}
+	{{- if eq $f.Kind "value" }}
+	if {{ $f.Cond }} {
+		if err := e.Set({{ $f.Paths }}, {{ $f.Modules }}, {{ $f.Value }}); err != nil {
+			return err
+		}
+	}
+	{{- else if eq $f.Kind "enum" }}
+	if t.{{ $f.Name }} != 0 {
+		v, err := e.Enum(t.{{ $f.Name }}, "{{ $f.EnumType }}", int64(t.{{ $f.Name }}))
+		if err != nil {
+			return err
+		}
+		if err := e.Set({{ $f.Paths }}, {{ $f.Modules }}, v); err != nil {
+			return err
+		}
+	}
+	{{- else if eq $f.Kind "leaflist" }}
+	if t.{{ $f.Name }} != nil {
+		l := make([]interface{}, 0, len(t.{{ $f.Name }}))
+		for _, v := range t.{{ $f.Name }} {
+			{{- if $f.EnumType }}
+			s, err := e.Enum(v, "{{ $f.EnumType }}", int64(v))
+			if err != nil {
+				return err
+			}
+			l = append(l, s)
+			{{- else }}
+			l = append(l, {{ $f.Value }})
+			{{- end }}
+		}
+		if err := e.Set({{ $f.Paths }}, {{ $f.Modules }}, l); err != nil {
+			return err
+		}
+	}
+	{{- else if eq $f.Kind "union" }}
+	switch u := t.{{ $f.Name }}.(type) {
+	case nil:
+	{{- range $c := $f.UnionCases }}
+	case {{ $c.Type }}:
+		{{- if $c.EnumType }}
+		if {{ $c.Expr }} != 0 {
+			v, err := e.Enum({{ $c.Expr }}, "{{ $c.EnumType }}", int64({{ $c.Expr }}))
+			if err != nil {
+				return err
+			}
+			if err := e.Set({{ $f.Paths }}, {{ $f.Modules }}, v); err != nil {
+				return err
+			}
+		}
+		{{- else if $c.Cond }}
+		if {{ $c.Cond }} {
+			if err := e.Set({{ $f.Paths }}, {{ $f.Modules }}, {{ $c.Value }}); err != nil {
+				return err
+			}
+		}
+		{{- else }}
+		if err := e.Set({{ $f.Paths }}, {{ $f.Modules }}, {{ $c.Value }}); err != nil {
+			return err
+		}
+		{{- end }}
+	{{- end }}
+	default:
+		if err := e.Field({{ $f.Paths }}, {{ $f.Modules }}, &t.{{ $f.Name }}); err != nil {
+			return err
+		}
+	}
+	{{- else if eq $f.Kind "container" }}
+	if t.{{ $f.Name }} != nil {
+		if err := e.Struct({{ $f.Paths }}, {{ $f.Modules }}, t.{{ $f.Name }}, {{ $f.Presence }}); err != nil {
+			return err
+		}
+	}
+	{{- else if eq $f.Kind "map" }}
+	if t.{{ $f.Name }} != nil {
+		l := make([]ygot.RFC7951ListElement, 0, len(t.{{ $f.Name }}))
+		for k, v := range t.{{ $f.Name }} {
+			l = append(l, ygot.RFC7951ListElement{Key: k, Value: v})
+		}
+		if err := e.List({{ $f.Paths }}, {{ $f.Modules }}, l); err != nil {
+			return err
+		}
+	}
+	{{- else if or (eq $f.Kind "orderedmap") (eq $f.Kind "slice") }}
+	if t.{{ $f.Name }} != nil {
+		l := []ygot.GoStruct{}
+		for _, v := range t.{{ $f.Name }}{{ if eq $f.Kind "orderedmap" }}.Values(){{ end }} {
+			l = append(l, v)
+		}
+		if err := e.OrderedList({{ $f.Paths }}, {{ $f.Modules }}, l); err != nil {
+			return err
+		}
+	}
+	{{- else if eq $f.Kind "choice" }}
+	if t.{{ $f.Name }} != nil {
+		if err := e.Choice(t.{{ $f.Name }}); err != nil {
+			return err
+		}
+	}
+	{{- else if $f.Cond }}
+	if {{ $f.Cond }} {
+		if err := e.Field({{ $f.Paths }}, {{ $f.Modules }}, &t.{{ $f.Name }}); err != nil {
+			return err
+		}
+	}
+	{{- else }}
+	if err := e.Field({{ $f.Paths }}, {{ $f.Modules }}, &t.{{ $f.Name }}); err != nil {
+		return err
+	}
+	{{- end }}
+{{- end }}
+	return nil
+}
+`)
+
+	// goRFC7951UnmarshalTemplate takes an input goRFC7951Methods, and
+	// generates the method that unmarshals RFC7951 JSON into the struct.
+	// Fields whose schema is required to unmarshal them, such as
+	// containers and lists, are unmarshalled by the decoder.
+	goRFC7951UnmarshalTemplate = mustMakeTemplate("rfc7951Unmarshal", `
+// UnmarshalRFC7951 unmarshals the supplied RFC7951 JSON object into
+// {{ .Receiver }}. It implements the ytypes.RFC7951Unmarshaler interface.
+func (t *{{ .Receiver }}) UnmarshalRFC7951(d *ytypes.RFC7951Decoder, jsonTree map[string]interface{}) error {
+{{- range $f := .Fields }}
+	{{- if or (eq $f.Kind "value") (eq $f.Kind "enum") }}
+	if v, err := d.Value(jsonTree, {{ $f.Paths }}, {{ $f.ShadowPaths }}); err != nil {
+		return err
+	} else if v != nil {
+		x, err := {{ $f.Decode }}
+		if err != nil {
+			return err
+		}
+		{{- if $f.IsPtr }}
+		t.{{ $f.Name }} = &x
+		{{- else }}
+		t.{{ $f.Name }} = {{ $f.Convert }}
+		{{- end }}
+	}
+	{{- else if eq $f.Kind "leaflist" }}
+	if v, err := d.Value(jsonTree, {{ $f.Paths }}, {{ $f.ShadowPaths }}); err != nil {
+		return err
+	} else if v != nil {
+		l, err := d.LeafList("{{ $f.YANGName }}", v)
+		if err != nil {
+			return err
+		}
+		t.{{ $f.Name }} = nil
+		for _, v := range l {
+			if v == nil {
+				continue
+			}
+			x, err := {{ $f.Decode }}
+			if err != nil {
+				return err
+			}
+			t.{{ $f.Name }} = append(t.{{ $f.Name }}, {{ $f.Convert }})
+		}
+	}
+	{{- else }}
+	if err := d.Field(t, "{{ $f.Name }}", jsonTree); err != nil {
+		return err
+	}
+	{{- end }}
+{{- end }}
+	return nil
+}
+`)
+)
+
+// generateRFC7951Methods generates the RFC7951 marshal and unmarshal methods
+// for the struct described by structDef, and for the cases of the supplied
+// choices within it, writing them to buf. fields describes the schema node
+// that is represented by each field.
+func generateRFC7951Methods(buf *bytes.Buffer, structDef generatedGoStruct, choices *goChoiceSet, fields map[*goStructField]*goFieldDetails, goOpts GoOpts) error {
+	structs := []generatedGoStruct{structDef}
+	if choices != nil {
+		for _, c := range choices.cases() {
+			structs = append(structs, c.generatedGoStruct)
+		}
+	}
+
+	for _, s := range structs {
+		m := goRFC7951Methods{Receiver: s.StructName}
+		for _, fd := range s.Fields {
+			var f *goRFC7951Field
+			switch d, ok := fields[fd]; {
+			case ok && d.Node != nil:
+				f = newGoRFC7951Field(fd, d.Node, d.IsOrderedMap, goOpts)
+			case ok:
+				f = newGoRFC7951AnnotationField(fd.Name, d.AnnotationPaths)
+			case strings.HasPrefix(fd.Tags, "choice:"):
+				f = &goRFC7951Field{Name: fd.Name, Kind: rfc7951Choice}
+			default:
+				return fmt.Errorf("cannot generate RFC7951 methods for field %s of %s", fd.Name, s.StructName)
+			}
+			m.Fields = append(m.Fields, f)
+		}
+		if err := goRFC7951MarshalTemplate.Execute(buf, m); err != nil {
+			return err
+		}
+		if err := goRFC7951UnmarshalTemplate.Execute(buf, m); err != nil {
+			return err
+		}
+	}
+	return nil
+}
@@ -0,0 +1,468 @@
+// Copyright 2023 Google Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package gogen
+
+import (
+	"fmt"
+	"go/ast"
+	"go/format"
+	"go/parser"
+	"go/token"
+	"path"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/openconfig/ygot/ygen"
+)
+
+const (
+	// defaultSharedPackageName is the name of the package containing the
+	// enumerated types and schema when the generated code is split by
+	// module, and no name is specified.
+	defaultSharedPackageName = "common"
+)
+
+// packageNameReplacePattern matches the characters that are allowed in YANG
+// module names, but not in Go package names.
+var packageNameReplacePattern = regexp.MustCompile("[._-]")
+
+// goPackageName returns the name of the package that the struct generated for
+// the directory dir is output to when the generated code is split by module.
+// It is the name of the module in which the root of the directory's YANG tree
+// is instantiated, such that augmentations are output alongside the tree
+// that they augment. The fake root is output to the package named by the
+// PackageName option. An empty string is returned if the code is not split.
+func goPackageName(dir *ygen.ParsedDirectory, opts GoOpts) string {
+	switch {
+	case !opts.SplitByModule:
+		return ""
+	case dir.IsFakeRoot:
+		return opts.PackageName
+	}
+	name := strings.TrimPrefix(dir.RootElementModule, opts.TrimPackagePrefix)
+	return strings.ToLower(packageNameReplacePattern.ReplaceAllString(name, ""))
+}
+
+// goDecl is a top-level declaration within the generated code.
+type goDecl struct {
+	// src is the source of the declaration, including its doc comment.
+	src string
+	// offset is the offset of src within the parsed file.
+	offset int
+	// names are the identifiers declared, which is empty for methods.
+	names []string
+	// recv is the name of the receiver type of a method.
+	recv string
+	// pkg is the name of the package the declaration is output to. It is
+	// ignored for methods, which are output to the package of their
+	// receiver type.
+	pkg string
+	// refs are the identifiers within the declaration that refer to other
+	// top-level declarations.
+	refs []*ast.Ident
+	// lits maps the references that are the type of a composite literal
+	// with unkeyed elements to the literal.
+	lits map[*ast.Ident]*ast.CompositeLit
+	// fields are the names of the fields of a struct type declaration.
+	fields []string
+	// unresolved are the names of identifiers within the declaration that
+	// are not declared in the generated code, such as imported packages.
+	unresolved map[string]bool
+}
+
+// codeSplitter splits the generated code into a package per module.
+type codeSplitter struct {
+	// decls are the declarations in the order they were generated.
+	decls []*goDecl
+	// byName maps each declared identifier to its declaration.
+	byName map[string]*goDecl
+	// root and shared are the names of the package containing the fake
+	// root and of the package containing the enumerated types and schema.
+	root, shared string
+}
+
+// pkgOf returns the package that d is output to.
+func (s *codeSplitter) pkgOf(d *goDecl) string {
+	if d.recv != "" {
+		if r, ok := s.byName[d.recv]; ok {
+			return r.pkg
+		}
+	}
+	return d.pkg
+}
+
+// isModulePkg returns true if pkg is a package generated for a module.
+func (s *codeSplitter) isModulePkg(pkg string) bool {
+	return pkg != s.root && pkg != s.shared
+}
+
+// splitByModule splits the generated code into a Go package per YANG module,
+// returning the packages keyed by name. The declarations of the generated
+// code are assigned to packages as follows:
+//   - the code generated for each struct is output to the package of the
+//     struct, which is stored in its snippet,
+//   - the Schema function and operation map are output to the root package,
+//     which is named by the PackageName option,
+//   - all other code, such as the enumerated types and the schema tree, is
+//     output to the shared package.
+//
+// Declarations from one module's package that are referenced by another
+// module's package, such as a union type used by leaves in both modules, are
+// moved to the shared package such that module packages do not import each
+// other. References to identifiers in another package are qualified with the
+// name of that package.
+func splitByModule(code *GeneratedCode, opts GoOpts) (map[string]*GeneratedPackage, error) {
+	if opts.BaseImportPath == "" {
+		return nil, fmt.Errorf("a base import path must be specified when splitting generated code by module")
+	}
+	s := &codeSplitter{
+		byName: map[string]*goDecl{},
+		root:   opts.PackageName,
+		shared: opts.SharedPackageName,
+	}
+	if s.shared == "" {
+		s.shared = defaultSharedPackageName
+	}
+	if s.shared == s.root {
+		return nil, fmt.Errorf("shared package name %s is the same as the package name", s.shared)
+	}
+
+	// The code is parsed as a single file such that references between
+	// declarations are resolved by the parser.
+	type chunk struct {
+		src, pkg string
+	}
+	chunks := []chunk{{code.OneOffHeader, s.shared}}
+	for _, st := range code.Structs {
+		chunks = append(chunks, chunk{st.String(), st.Package})
+	}
+	for _, c := range append(append([]string{}, code.Enums...), code.EnumMap, code.JSONSchemaCode, code.EnumTypeMap) {
+		chunks = append(chunks, chunk{c, s.shared})
+	}
+	chunks = append(chunks, chunk{code.OperationMap, s.root})
+
+	var src strings.Builder
+	src.WriteString("package " + s.root + "\n")
+	type chunkEnd struct {
+		end int
+		pkg string
+	}
+	var ends []chunkEnd
+	for _, c := range chunks {
+		src.WriteString(c.src)
+		src.WriteString("\n")
+		ends = append(ends, chunkEnd{src.Len(), c.pkg})
+	}
+
+	fset := token.NewFileSet()
+	f, err := parser.ParseFile(fset, "", src.String(), parser.ParseComments)
+	if err != nil {
+		return nil, fmt.Errorf("cannot parse generated code: %v", err)
+	}
+	tf := fset.File(f.Pos())
+
+	unresolved := map[*ast.Ident]bool{}
+	for _, id := range f.Unresolved {
+		unresolved[id] = true
+	}
+
+	for _, decl := range f.Decls {
+		start := decl.Pos()
+		d := &goDecl{unresolved: map[string]bool{}}
+		switch decl := decl.(type) {
+		case *ast.FuncDecl:
+			if decl.Doc != nil {
+				start = decl.Doc.Pos()
+			}
+			if decl.Recv == nil {
+				if decl.Name.Name != "init" {
+					d.names = append(d.names, decl.Name.Name)
+				}
+			} else {
+				d.recv = receiverTypeName(decl.Recv.List[0].Type)
+			}
+		case *ast.GenDecl:
+			if decl.Doc != nil {
+				start = decl.Doc.Pos()
+			}
+			for _, spec := range decl.Specs {
+				switch spec := spec.(type) {
+				case *ast.TypeSpec:
+					d.names = append(d.names, spec.Name.Name)
+					if st, ok := spec.Type.(*ast.StructType); ok && len(decl.Specs) == 1 {
+						for _, fd := range st.Fields.List {
+							for _, n := range fd.Names {
+								d.fields = append(d.fields, n.Name)
+							}
+						}
+					}
+				case *ast.ValueSpec:
+					for _, n := range spec.Names {
+						d.names = append(d.names, n.Name)
+					}
+				}
+			}
+		}
+		d.offset = tf.Offset(start)
+		d.src = src.String()[d.offset:tf.Offset(decl.End())]
+		for _, e := range ends {
+			if d.offset < e.end {
+				d.pkg = e.pkg
+				break
+			}
+		}
+		// The Schema function refers to the fake root, and hence is
+		// output to the root package, unlike the rest of the header.
+		if fd, ok := decl.(*ast.FuncDecl); ok && d.offset < ends[0].end && fd.Recv == nil && fd.Name.Name == "Schema" {
+			d.pkg = s.root
+		}
+		for _, n := range d.names {
+			s.byName[n] = d
+		}
+		d.refs, d.lits = s.findRefs(f, decl, unresolved, d.unresolved)
+		s.decls = append(s.decls, d)
+	}
+
+	if err := s.moveSharedDecls(); err != nil {
+		return nil, err
+	}
+	return s.writePackages(fset, code.CommonHeader, opts.BaseImportPath)
+}
+
+// receiverTypeName returns the name of the type of a method's receiver.
+func receiverTypeName(e ast.Expr) string {
+	if st, ok := e.(*ast.StarExpr); ok {
+		e = st.X
+	}
+	if id, ok := e.(*ast.Ident); ok {
+		return id.Name
+	}
+	return ""
+}
+
+// findRefs returns the identifiers within decl that refer to a top-level
+// declaration of the file f, along with the composite literals with unkeyed
+// elements whose type is one of the identifiers. The names of the identifiers
+// within decl that are in unresolved are added to names.
+func (s *codeSplitter) findRefs(f *ast.File, decl ast.Decl, unresolved map[*ast.Ident]bool, names map[string]bool) ([]*ast.Ident, map[*ast.Ident]*ast.CompositeLit) {
+	var refs []*ast.Ident
+	lits := map[*ast.Ident]*ast.CompositeLit{}
+	// fields are the keys of composite literals that are not maps, which
+	// are the names of struct fields, but are resolved by the parser if a
+	// top-level declaration has the same name.
+	fields := map[*ast.Ident]bool{}
+	ast.Inspect(decl, func(n ast.Node) bool {
+		switch n := n.(type) {
+		case *ast.CompositeLit:
+			if _, ok := n.Type.(*ast.MapType); ok {
+				return true
+			}
+			if id, ok := n.Type.(*ast.Ident); ok && len(n.Elts) != 0 {
+				if _, keyed := n.Elts[0].(*ast.KeyValueExpr); !keyed {
+					lits[id] = n
+				}
+			}
+			for _, e := range n.Elts {
+				if kv, ok := e.(*ast.KeyValueExpr); ok {
+					if id, ok := kv.Key.(*ast.Ident); ok {
+						fields[id] = true
+					}
+				}
+			}
+		case *ast.Ident:
+			if unresolved[n] {
+				names[n.Name] = true
+			}
+			if n.Obj == nil || f.Scope.Objects[n.Name] != n.Obj || fields[n] {
+				return true
+			}
+			refs = append(refs, n)
+		}
+		return true
+	})
+	return refs, lits
+}
+
+// moveSharedDecls moves declarations that are output to a module's package,
+// but referenced by the package of another module, or by the shared package,
+// to the shared package. It returns an error if a declaration in a module's
+// package or the shared package refers to the root package.
+func (s *codeSplitter) moveSharedDecls() error {
+	for moved := true; moved; {
+		moved = false
+		for _, d := range s.decls {
+			pkg := s.pkgOf(d)
+			if pkg == s.root {
+				continue
+			}
+			for _, r := range d.refs {
+				t := s.byName[r.Name]
+				tpkg := s.pkgOf(t)
+				switch {
+				case tpkg == s.root:
+					return fmt.Errorf("%s in package %s refers to %s in package %s", d.declName(), pkg, r.Name, s.root)
+				case s.isModulePkg(tpkg) && tpkg != pkg:
+					t.pkg = s.shared
+					moved = true
+				}
+			}
+		}
+	}
+	return nil
+}
+
+// declName returns a name that identifies d in error messages.
+func (d *goDecl) declName() string {
+	if d.recv != "" {
+		return "method of " + d.recv
+	}
+	return strings.Join(d.names, ", ")
+}
+
+// writePackages returns the source code of each generated package, which
+// imports the packages within baseImportPath that it refers to, along with
+// the packages imported by the commonHeader that it uses.
+func (s *codeSplitter) writePackages(fset *token.FileSet, commonHeader, baseImportPath string) (map[string]*GeneratedPackage, error) {
+	hf, err := parser.ParseFile(fset, "", commonHeader, parser.ParseComments|parser.ImportsOnly)
+	if err != nil {
+		return nil, fmt.Errorf("cannot parse generated header: %v", err)
+	}
+	headerImports := map[string]string{}
+	for _, is := range hf.Imports {
+		p, err := strconv.Unquote(is.Path.Value)
+		if err != nil {
+			return nil, err
+		}
+		name := path.Base(p)
+		if is.Name != nil {
+			name = is.Name.Name
+		}
+		headerImports[name] = is.Path.Value
+	}
+	doc := commonHeader[:strings.Index(commonHeader, "package "+s.root)]
+
+	type pkgCode struct {
+		body    strings.Builder
+		imports map[string]string
+	}
+	pkgs := map[string]*pkgCode{}
+	importPath := func(pkg string) string {
+		if pkg == s.root {
+			return strconv.Quote(baseImportPath)
+		}
+		return strconv.Quote(baseImportPath + "/" + pkg)
+	}
+
+	for _, d := range s.decls {
+		pkg := s.pkgOf(d)
+		if _, ok := headerImports[pkg]; ok && pkg != s.root {
+			return nil, fmt.Errorf("package name %s conflicts with an imported package", pkg)
+		}
+		pc, ok := pkgs[pkg]
+		if !ok {
+			pc = &pkgCode{imports: map[string]string{}}
+			pkgs[pkg] = pc
+		}
+		for n := range d.unresolved {
+			if p, ok := headerImports[n]; ok {
+				pc.imports[n] = p
+			}
+		}
+
+		// Qualify the references to identifiers in other packages. Keys
+		// are added to unkeyed literals of struct types in other
+		// packages, which go vet reports otherwise.
+		type edit struct {
+			off  int
+			text string
+		}
+		var edits []edit
+		for _, r := range d.refs {
+			tpkg := s.pkgOf(s.byName[r.Name])
+			if tpkg == pkg {
+				continue
+			}
+			if !ast.IsExported(r.Name) {
+				return nil, fmt.Errorf("%s in package %s refers to unexported identifier %s in package %s", d.declName(), pkg, r.Name, tpkg)
+			}
+			pc.imports[tpkg] = importPath(tpkg)
+			edits = append(edits, edit{fset.Position(r.Pos()).Offset - d.offset, tpkg + "."})
+			if cl, ok := d.lits[r]; ok {
+				if fields := s.byName[r.Name].fields; len(fields) == len(cl.Elts) {
+					for i, e := range cl.Elts {
+						edits = append(edits, edit{fset.Position(e.Pos()).Offset - d.offset, fields[i] + ": "})
+					}
+				}
+			}
+		}
+		sort.SliceStable(edits, func(i, j int) bool { return edits[i].off < edits[j].off })
+		var last int
+		for _, e := range edits {
+			pc.body.WriteString(d.src[last:e.off])
+			pc.body.WriteString(e.text)
+			last = e.off
+		}
+		pc.body.WriteString(d.src[last:])
+		pc.body.WriteString("\n\n")
+	}
+
+	out := map[string]*GeneratedPackage{}
+	for name, pc := range pkgs {
+		var b strings.Builder
+		b.WriteString(strings.Replace(doc, "Package "+s.root+" ", "Package "+name+" ", 1))
+		fmt.Fprintf(&b, "package %s\n\nimport (\n", name)
+		// Standard library packages are imported in a separate group
+		// to other packages.
+		var std, other []string
+		for n, p := range pc.imports {
+			ip, _ := strconv.Unquote(p)
+			i := p
+			if path.Base(ip) != n {
+				i = n + " " + p
+			}
+			if strings.Contains(strings.Split(ip, "/")[0], ".") {
+				other = append(other, i)
+			} else {
+				std = append(std, i)
+			}
+		}
+		sort.Strings(std)
+		sort.Strings(other)
+		for _, i := range std {
+			fmt.Fprintf(&b, "\t%s\n", i)
+		}
+		if len(std) != 0 && len(other) != 0 {
+			b.WriteString("\n")
+		}
+		for _, i := range other {
+			fmt.Fprintf(&b, "\t%s\n", i)
+		}
+		b.WriteString(")\n\n")
+		b.WriteString(pc.body.String())
+
+		src, err := format.Source([]byte(b.String()))
+		if err != nil {
+			return nil, fmt.Errorf("cannot format generated package %s: %v", name, err)
+		}
+		gp := &GeneratedPackage{Code: string(src)}
+		if name != s.root {
+			gp.Path = name
+		}
+		out[name] = gp
+	}
+	return out, nil
+}
@@ -0,0 +1,194 @@
+// Copyright 2023 Google Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package gogen
+
+import (
+	"fmt"
+	"go/parser"
+	"go/token"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/google/go-cmp/cmp/cmpopts"
+	"github.com/openconfig/gnmi/errdiff"
+	"github.com/openconfig/ygot/genutil"
+	"github.com/openconfig/ygot/testutil"
+	"github.com/openconfig/ygot/ygen"
+)
+
+func TestGoPackageName(t *testing.T) {
+	tests := []struct {
+		desc   string
+		inDir  *ygen.ParsedDirectory
+		inOpts GoOpts
+		want   string
+	}{{
+		desc:   "not split",
+		inDir:  &ygen.ParsedDirectory{RootElementModule: "openconfig-interfaces"},
+		inOpts: GoOpts{PackageName: "oc"},
+		want:   "",
+	}, {
+		desc:   "fake root",
+		inDir:  &ygen.ParsedDirectory{IsFakeRoot: true},
+		inOpts: GoOpts{PackageName: "oc", SplitByModule: true},
+		want:   "oc",
+	}, {
+		desc:   "module name",
+		inDir:  &ygen.ParsedDirectory{RootElementModule: "openconfig-if-ip"},
+		inOpts: GoOpts{PackageName: "oc", SplitByModule: true},
+		want:   "openconfigifip",
+	}, {
+		desc:   "module name with trimmed prefix",
+		inDir:  &ygen.ParsedDirectory{RootElementModule: "openconfig-if-ip"},
+		inOpts: GoOpts{PackageName: "oc", SplitByModule: true, TrimPackagePrefix: "openconfig-"},
+		want:   "ifip",
+	}, {
+		desc:   "module name with upper case characters and dots",
+		inDir:  &ygen.ParsedDirectory{RootElementModule: "Vendor.Module_A"},
+		inOpts: GoOpts{PackageName: "oc", SplitByModule: true},
+		want:   "vendormodulea",
+	}}
+
+	for _, tt := range tests {
+		t.Run(tt.desc, func(t *testing.T) {
+			if got := goPackageName(tt.inDir, tt.inOpts); got != tt.want {
+				t.Errorf("goPackageName(%v, %v): did not get expected name, got: %q, want: %q", tt.inDir, tt.inOpts, got, tt.want)
+			}
+		})
+	}
+}
+
+// TestSplitByModule tests generating code that is split into a package per
+// YANG module. Each package that is generated is compared to the golden file
+// named after the package within the wantDir directory.
+func TestSplitByModule(t *testing.T) {
+	inFiles := []string{
+		filepath.Join(datapath, "split-a.yang"),
+		filepath.Join(datapath, "split-b.yang"),
+	}
+
+	tests := []struct {
+		name             string
+		inGoOpts         GoOpts
+		wantDir          string
+		wantErrSubstring string
+	}{{
+		name: "simple unions with getters",
+		inGoOpts: GoOpts{
+			PackageName:          "oc",
+			SplitByModule:        true,
+			TrimPackagePrefix:    "split-",
+			BaseImportPath:       "example.com/oc",
+			GenerateSimpleUnions: true,
+			GenerateLeafGetters:  true,
+			GenerateGetters:      true,
+			GenerateJSONSchema:   true,
+		},
+		wantDir: filepath.Join(TestRoot, "testdata/split/simple-unions"),
+	}, {
+		name: "wrapper unions with shared package name",
+		inGoOpts: GoOpts{
+			PackageName:        "device",
+			SplitByModule:      true,
+			BaseImportPath:     "example.com/device",
+			SharedPackageName:  "types",
+			GenerateJSONSchema: true,
+		},
+		wantDir: filepath.Join(TestRoot, "testdata/split/wrapper-unions"),
+	}, {
+		name: "no base import path",
+		inGoOpts: GoOpts{
+			PackageName:   "oc",
+			SplitByModule: true,
+		},
+		wantErrSubstring: "a base import path must be specified",
+	}, {
+		name: "shared package name is the package name",
+		inGoOpts: GoOpts{
+			PackageName:       "oc",
+			SplitByModule:     true,
+			BaseImportPath:    "example.com/oc",
+			SharedPackageName: "oc",
+		},
+		wantErrSubstring: "is the same as the package name",
+	}}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cg := New("codegen-tests", ygen.IROptions{
+				TransformationOptions: ygen.TransformationOpts{
+					CompressBehaviour:                    genutil.Uncompressed,
+					GenerateFakeRoot:                     true,
+					ShortenEnumLeafNames:                 true,
+					UseDefiningModuleForTypedefEnumNames: true,
+					EnumerationsUseUnderscores:           true,
+					GenerateOperations:                   true,
+				},
+			}, tt.inGoOpts)
+
+			got, errs := cg.Generate(inFiles, []string{datapath})
+			var err error
+			if len(errs) > 0 {
+				err = fmt.Errorf("%w", errs)
+			}
+			if diff := errdiff.Substring(err, tt.wantErrSubstring); diff != "" {
+				t.Fatalf("Generate(%v): did not get expected error, %s", inFiles, diff)
+			}
+			if err != nil {
+				return
+			}
+
+			var gotFiles []string
+			for name, pkg := range got.Packages {
+				if _, err := parser.ParseFile(token.NewFileSet(), "", pkg.Code, parser.AllErrors); err != nil {
+					t.Errorf("Generate(%v): package %s is not valid Go: %v", inFiles, name, err)
+				}
+				gotFiles = append(gotFiles, name+".formatted-txt")
+
+				wantFile := filepath.Join(tt.wantDir, name+".formatted-txt")
+				if *updateGolden {
+					if err := os.MkdirAll(tt.wantDir, 0755); err != nil {
+						t.Fatal(err)
+					}
+					if err := os.WriteFile(wantFile, []byte(pkg.Code), 0644); err != nil {
+						t.Fatal(err)
+					}
+				}
+				want, err := os.ReadFile(wantFile)
+				if err != nil {
+					t.Fatalf("os.ReadFile(%q) error: %v", wantFile, err)
+				}
+				if pkg.Code != string(want) {
+					diff, _ := testutil.GenerateUnifiedDiff(string(want), pkg.Code)
+					t.Errorf("Generate(%v): did not get expected code for package %s (path: %q, file: %v), diff:\n%s", inFiles, name, pkg.Path, wantFile, diff)
+				}
+			}
+
+			entries, err := os.ReadDir(tt.wantDir)
+			if err != nil {
+				t.Fatal(err)
+			}
+			var wantFiles []string
+			for _, e := range entries {
+				wantFiles = append(wantFiles, e.Name())
+			}
+			if diff := cmp.Diff(wantFiles, gotFiles, cmpopts.SortSlices(func(a, b string) bool { return a < b })); diff != "" {
+				t.Errorf("Generate(%v): did not get expected set of packages, (-want, +got):\n%s", inFiles, diff)
+			}
+		})
+	}
+}
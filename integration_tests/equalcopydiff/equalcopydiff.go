@@ -0,0 +1,1906 @@
+/*
+Package equalcopydiff is a generated package which contains definitions
+of structs which represent a YANG schema. The generated schema can be
+compressed by a series of transformations (compression was false
+in this case).
+
+This package was generated by /root/module/genutil/names.go
+using the following YANG input files:
+  - ../../testdata/modules/equal-copy-diff.yang
+
+Imported modules were sourced from:
+  - ...
+*/
+package equalcopydiff
+
+import (
+	"encoding/json"
+	"fmt"
+	"reflect"
+
+	"github.com/openconfig/goyang/pkg/yang"
+	"github.com/openconfig/ygot/ygot"
+	"github.com/openconfig/ygot/ytypes"
+)
+
+// Binary is a type that is used for fields that have a YANG type of
+// binary. It is used such that binary fields can be distinguished from
+// leaf-lists of uint8s (which are mapped to []uint8, equivalent to
+// []byte in reflection).
+type Binary []byte
+
+// YANGEmpty is a type that is used for fields that have a YANG type of
+// empty. It is used such that empty fields can be distinguished from boolean fields
+// in the generated code.
+type YANGEmpty bool
+
+// UnionInt8 is an int8 type assignable to unions of which it is a subtype.
+type UnionInt8 int8
+
+// UnionInt16 is an int16 type assignable to unions of which it is a subtype.
+type UnionInt16 int16
+
+// UnionInt32 is an int32 type assignable to unions of which it is a subtype.
+type UnionInt32 int32
+
+// UnionInt64 is an int64 type assignable to unions of which it is a subtype.
+type UnionInt64 int64
+
+// UnionUint8 is a uint8 type assignable to unions of which it is a subtype.
+type UnionUint8 uint8
+
+// UnionUint16 is a uint16 type assignable to unions of which it is a subtype.
+type UnionUint16 uint16
+
+// UnionUint32 is a uint32 type assignable to unions of which it is a subtype.
+type UnionUint32 uint32
+
+// UnionUint64 is a uint64 type assignable to unions of which it is a subtype.
+type UnionUint64 uint64
+
+// UnionFloat64 is a float64 type assignable to unions of which it is a subtype.
+type UnionFloat64 float64
+
+// UnionString is a string type assignable to unions of which it is a subtype.
+type UnionString string
+
+// UnionBool is a bool type assignable to unions of which it is a subtype.
+type UnionBool bool
+
+// UnionUnsupported is an interface{} wrapper type for unsupported types. It is
+// assignable to unions of which it is a subtype.
+type UnionUnsupported struct {
+	Value interface{}
+}
+
+var (
+	SchemaTree map[string]*yang.Entry
+	ΛEnumTypes map[string][]reflect.Type
+)
+
+func init() {
+	var err error
+	initΛEnumTypes()
+	if SchemaTree, err = UnzipSchema(); err != nil {
+		panic("schema error: " + err.Error())
+	}
+}
+
+// Schema returns the details of the generated schema.
+func Schema() (*ytypes.Schema, error) {
+	uzp, err := UnzipSchema()
+	if err != nil {
+		return nil, fmt.Errorf("cannot unzip schema, %v", err)
+	}
+
+	return &ytypes.Schema{
+		Root:       &Device{},
+		SchemaTree: uzp,
+		Unmarshal:  Unmarshal,
+	}, nil
+}
+
+// UnzipSchema unzips the zipped schema and returns a map of yang.Entry nodes,
+// keyed by the name of the struct that the yang.Entry describes the schema for.
+func UnzipSchema() (map[string]*yang.Entry, error) {
+	var schemaTree map[string]*yang.Entry
+	var err error
+	if schemaTree, err = ygot.GzipToSchema(ySchema); err != nil {
+		return nil, fmt.Errorf("could not unzip the schema; %v", err)
+	}
+	return schemaTree, nil
+}
+
+// Unmarshal unmarshals data, which must be RFC7951 JSON format, into
+// destStruct, which must be non-nil and the correct GoStruct type. It returns
+// an error if the destStruct is not found in the schema or the data cannot be
+// unmarshaled. The supplied options (opts) are used to control the behaviour
+// of the unmarshal function - for example, determining whether errors are
+// thrown for unknown fields in the input JSON.
+func Unmarshal(data []byte, destStruct ygot.GoStruct, opts ...ytypes.UnmarshalOpt) error {
+	tn := reflect.TypeOf(destStruct).Elem().Name()
+	schema, ok := SchemaTree[tn]
+	if !ok {
+		return fmt.Errorf("could not find schema for type %s", tn)
+	}
+	var jsonTree interface{}
+	if err := json.Unmarshal([]byte(data), &jsonTree); err != nil {
+		return err
+	}
+	return ytypes.Unmarshal(schema, destStruct, jsonTree, opts...)
+}
+
+// Device represents the /device YANG schema element.
+type Device struct {
+	Top *EqualCopyDiff_Top `path:"top" module:"equal-copy-diff"`
+}
+
+// IsYANGGoStruct ensures that Device implements the yang.GoStruct
+// interface. This allows functions that need to handle this struct to
+// identify it as being generated by ygen.
+func (*Device) IsYANGGoStruct() {}
+
+// GetOrCreateTop retrieves the value of the Top field
+// or returns the existing field if it already exists.
+func (t *Device) GetOrCreateTop() *EqualCopyDiff_Top {
+	if t.Top != nil {
+		return t.Top
+	}
+	t.Top = &EqualCopyDiff_Top{}
+	return t.Top
+}
+
+// GetTop returns the value of the Top struct pointer
+// from Device. If the receiver or the field Top is nil, nil
+// is returned such that the Get* methods can be safely chained.
+func (t *Device) GetTop() *EqualCopyDiff_Top {
+	if t != nil && t.Top != nil {
+		return t.Top
+	}
+	return nil
+}
+
+// Validate validates s against the YANG schema corresponding to its type.
+func (t *Device) ΛValidate(opts ...ygot.ValidationOption) error {
+	if err := ytypes.Validate(SchemaTree["Device"], t, opts...); err != nil {
+		return err
+	}
+	return nil
+}
+
+// Validate validates s against the YANG schema corresponding to its type.
+func (t *Device) Validate(opts ...ygot.ValidationOption) error {
+	return t.ΛValidate(opts...)
+}
+
+// ΛEnumTypeMap returns a map, keyed by YANG schema path, of the enumerated types
+// that are included in the generated code.
+func (t *Device) ΛEnumTypeMap() map[string][]reflect.Type { return ΛEnumTypes }
+
+// Equal reports whether Device is equal to o. Nil values are only
+// equal to other nil values.
+func (t *Device) Equal(o *Device) bool {
+	if t == nil || o == nil {
+		return t == o
+	}
+	if !t.Top.Equal(o.Top) {
+		return false
+	}
+	return true
+}
+
+// Copy returns a deep copy of Device. As with ygot.DeepCopy, empty
+// maps and slices are not retained in the copy.
+func (t *Device) Copy() (*Device, error) {
+	if t == nil {
+		return nil, nil
+	}
+	n := &Device{}
+	var err error
+	if n.Top, err = t.Top.Copy(); err != nil {
+		return nil, err
+	}
+	return n, nil
+}
+
+// ΛDeepCopy returns a deep copy of Device. It implements the
+// ygot.GoStructCopier interface.
+func (t *Device) ΛDeepCopy() (ygot.GoStruct, error) {
+	return t.Copy()
+}
+
+// ΛDiff reports the fields of Device that differ from those of other
+// to d. It implements the ygot.GoStructDiffer interface.
+func (t *Device) ΛDiff(d *ygot.DiffWalker, other ygot.GoStruct) error {
+	o, _ := other.(*Device)
+	return t.diff(d, o)
+}
+
+// diff reports the fields of Device that differ from those of o to d.
+func (t *Device) diff(d *ygot.DiffWalker, o *Device) error {
+	if t == nil {
+		t = &Device{}
+	}
+	if o == nil {
+		o = &Device{}
+	}
+	if !t.Top.Equal(o.Top) {
+		if err := d.Struct([][]string{{"top"}}, nil, t.Top, o.Top); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// ΛBelongingModule returns the name of the module that defines the namespace
+// of Device.
+func (*Device) ΛBelongingModule() string {
+	return ""
+}
+
+// EqualCopyDiff_Top represents the /equal-copy-diff/top YANG schema element.
+type EqualCopyDiff_Top struct {
+	Bin       Binary                              `path:"bin" module:"equal-copy-diff"`
+	Blobs     []Binary                            `path:"blobs" module:"equal-copy-diff"`
+	Child     *EqualCopyDiff_Top_Child            `path:"child" module:"equal-copy-diff"`
+	Color     E_EqualCopyDiff_Top_Color           `path:"color" module:"equal-copy-diff"`
+	Data      *ygot.AnyData                       `path:"data" module:"equal-copy-diff"`
+	Entry     map[string]*EqualCopyDiff_Top_Entry `path:"entry" module:"equal-copy-diff"`
+	Kind      E_EqualCopyDiff_BASE                `path:"kind" module:"equal-copy-diff"`
+	Kinds     []E_EqualCopyDiff_BASE              `path:"kinds" module:"equal-copy-diff"`
+	Mixed     EqualCopyDiff_Top_Mixed_Union       `path:"mixed" module:"equal-copy-diff"`
+	Transport EqualCopyDiff_Top_Transport_Choice  `choice:"transport"`
+	Names     []string                            `path:"names" module:"equal-copy-diff"`
+	On        YANGEmpty                           `path:"on" module:"equal-copy-diff"`
+	Sample    []*EqualCopyDiff_Top_Sample         `path:"sample" module:"equal-copy-diff"`
+	Step      *EqualCopyDiff_Top_Step_OrderedMap  `path:"step" module:"equal-copy-diff"`
+	Str       *string                             `path:"str" module:"equal-copy-diff"`
+}
+
+// IsYANGGoStruct ensures that EqualCopyDiff_Top implements the yang.GoStruct
+// interface. This allows functions that need to handle this struct to
+// identify it as being generated by ygen.
+func (*EqualCopyDiff_Top) IsYANGGoStruct() {}
+
+// NewEntry creates a new entry in the Entry list of the
+// EqualCopyDiff_Top struct. The keys of the list are populated from the input
+// arguments.
+func (t *EqualCopyDiff_Top) NewEntry(Name string) (*EqualCopyDiff_Top_Entry, error) {
+
+	// Initialise the list within the receiver struct if it has not already been
+	// created.
+	if t.Entry == nil {
+		t.Entry = make(map[string]*EqualCopyDiff_Top_Entry)
+	}
+
+	key := Name
+
+	// Ensure that this key has not already been used in the
+	// list. Keyed YANG lists do not allow duplicate keys to
+	// be created.
+	if _, ok := t.Entry[key]; ok {
+		return nil, fmt.Errorf("duplicate key %v for list Entry", key)
+	}
+
+	t.Entry[key] = &EqualCopyDiff_Top_Entry{
+		Name: &Name,
+	}
+
+	return t.Entry[key], nil
+}
+
+// GetOrCreateEntryMap returns the list (map) from EqualCopyDiff_Top.
+//
+// It initializes the field if not already initialized.
+func (t *EqualCopyDiff_Top) GetOrCreateEntryMap() map[string]*EqualCopyDiff_Top_Entry {
+	if t.Entry == nil {
+		t.Entry = make(map[string]*EqualCopyDiff_Top_Entry)
+	}
+	return t.Entry
+}
+
+// GetOrCreateEntry retrieves the value with the specified keys from
+// the receiver EqualCopyDiff_Top. If the entry does not exist, then it is created.
+// It returns the existing or new list member.
+func (t *EqualCopyDiff_Top) GetOrCreateEntry(Name string) *EqualCopyDiff_Top_Entry {
+
+	key := Name
+
+	if v, ok := t.Entry[key]; ok {
+		return v
+	}
+	// Panic if we receive an error, since we should have retrieved an existing
+	// list member. This allows chaining of GetOrCreate methods.
+	v, err := t.NewEntry(Name)
+	if err != nil {
+		panic(fmt.Sprintf("GetOrCreateEntry got unexpected error: %v", err))
+	}
+	return v
+}
+
+// GetEntry retrieves the value with the specified key from
+// the Entry map field of EqualCopyDiff_Top. If the receiver is nil, or
+// the specified key is not present in the list, nil is returned such that Get*
+// methods may be safely chained.
+func (t *EqualCopyDiff_Top) GetEntry(Name string) *EqualCopyDiff_Top_Entry {
+
+	if t == nil {
+		return nil
+	}
+
+	key := Name
+
+	if lm, ok := t.Entry[key]; ok {
+		return lm
+	}
+	return nil
+}
+
+// AppendEntry appends the supplied EqualCopyDiff_Top_Entry struct to the
+// list Entry of EqualCopyDiff_Top. If the key value(s) specified in
+// the supplied EqualCopyDiff_Top_Entry already exist in the list, an error is
+// returned.
+func (t *EqualCopyDiff_Top) AppendEntry(v *EqualCopyDiff_Top_Entry) error {
+	if v.Name == nil {
+		return fmt.Errorf("invalid nil key received for Name")
+	}
+
+	key := *v.Name
+
+	// Initialise the list within the receiver struct if it has not already been
+	// created.
+	if t.Entry == nil {
+		t.Entry = make(map[string]*EqualCopyDiff_Top_Entry)
+	}
+
+	if _, ok := t.Entry[key]; ok {
+		return fmt.Errorf("duplicate key for list Entry %v", key)
+	}
+
+	t.Entry[key] = v
+	return nil
+}
+
+// GetOrCreateChild retrieves the value of the Child field
+// or returns the existing field if it already exists.
+func (t *EqualCopyDiff_Top) GetOrCreateChild() *EqualCopyDiff_Top_Child {
+	if t.Child != nil {
+		return t.Child
+	}
+	t.Child = &EqualCopyDiff_Top_Child{}
+	return t.Child
+}
+
+// GetChild returns the value of the Child struct pointer
+// from EqualCopyDiff_Top. If the receiver or the field Child is nil, nil
+// is returned such that the Get* methods can be safely chained.
+func (t *EqualCopyDiff_Top) GetChild() *EqualCopyDiff_Top_Child {
+	if t != nil && t.Child != nil {
+		return t.Child
+	}
+	return nil
+}
+
+// ΛChoiceCaseTypes returns the types of the structs representing the cases
+// of each choice within EqualCopyDiff_Top, keyed by the name of the field
+// that stores the choice.
+func (*EqualCopyDiff_Top) ΛChoiceCaseTypes() map[string][]reflect.Type {
+	return map[string][]reflect.Type{
+		"Transport": {
+			reflect.TypeOf((*EqualCopyDiff_Top_Transport_Choice_Udp)(nil)),
+			reflect.TypeOf((*EqualCopyDiff_Top_Transport_Choice_Tcp)(nil)),
+		},
+	}
+}
+
+// ΛChoiceCaseTypes returns the types of the structs representing the cases
+// of each choice within EqualCopyDiff_Top_Transport_Choice_Udp, keyed by the name of the field
+// that stores the choice.
+func (*EqualCopyDiff_Top_Transport_Choice_Udp) ΛChoiceCaseTypes() map[string][]reflect.Type {
+	return map[string][]reflect.Type{
+		"Mode": {
+			reflect.TypeOf((*EqualCopyDiff_Top_Mode_Choice_Multicast)(nil)),
+			reflect.TypeOf((*EqualCopyDiff_Top_Mode_Choice_Unicast)(nil)),
+		},
+	}
+}
+
+// GetOrCreateOptions retrieves the value of the Options field
+// or returns the existing field if it already exists.
+func (t *EqualCopyDiff_Top_Transport_Choice_Tcp) GetOrCreateOptions() *EqualCopyDiff_Top_Options {
+	if t.Options != nil {
+		return t.Options
+	}
+	t.Options = &EqualCopyDiff_Top_Options{}
+	return t.Options
+}
+
+// GetOptions returns the value of the Options struct pointer
+// from EqualCopyDiff_Top_Transport_Choice_Tcp. If the receiver or the field Options is nil, nil
+// is returned such that the Get* methods can be safely chained.
+func (t *EqualCopyDiff_Top_Transport_Choice_Tcp) GetOptions() *EqualCopyDiff_Top_Options {
+	if t != nil && t.Options != nil {
+		return t.Options
+	}
+	return nil
+}
+
+// GetOrCreateStepMap returns the ordered map field
+// Step from EqualCopyDiff_Top.
+//
+// It initializes the field if not already initialized.
+func (s *EqualCopyDiff_Top) GetOrCreateStepMap() *EqualCopyDiff_Top_Step_OrderedMap {
+	if s.Step == nil {
+		s.Step = &EqualCopyDiff_Top_Step_OrderedMap{}
+	}
+	return s.Step
+}
+
+// AppendNewStep creates a new entry in the Step
+// ordered map of the EqualCopyDiff_Top struct. The keys of the list are
+// populated from the input arguments.
+func (s *EqualCopyDiff_Top) AppendNewStep(Id uint32) (*EqualCopyDiff_Top_Step, error) {
+	if s.Step == nil {
+		s.Step = &EqualCopyDiff_Top_Step_OrderedMap{}
+	}
+	return s.Step.AppendNew(Id)
+}
+
+// AppendStep appends the supplied EqualCopyDiff_Top_Step struct
+// to the list Step of EqualCopyDiff_Top. If the key value(s)
+// specified in the supplied EqualCopyDiff_Top_Step already exist in the list, an
+// error is returned.
+func (s *EqualCopyDiff_Top) AppendStep(v *EqualCopyDiff_Top_Step) error {
+	if s.Step == nil {
+		s.Step = &EqualCopyDiff_Top_Step_OrderedMap{}
+	}
+	return s.Step.Append(v)
+}
+
+// GetStep retrieves the value with the specified key from the
+// Step map field of EqualCopyDiff_Top. If the receiver
+// is nil, or the specified key is not present in the list, nil is returned
+// such that Get* methods may be safely chained.
+func (s *EqualCopyDiff_Top) GetStep(Id uint32) *EqualCopyDiff_Top_Step {
+	if s == nil {
+		return nil
+	}
+	key := Id
+	return s.Step.Get(key)
+}
+
+// DeleteStep deletes the value with the specified keys from
+// the receiver EqualCopyDiff_Top. If there is no such element, the
+// function is a no-op.
+func (s *EqualCopyDiff_Top) DeleteStep(Id uint32) bool {
+	key := Id
+	return s.Step.Delete(key)
+}
+
+// EqualCopyDiff_Top_Step_OrderedMap is an ordered map that represents the "ordered-by user"
+// list elements at /equal-copy-diff/top/step.
+type EqualCopyDiff_Top_Step_OrderedMap struct {
+	keys     []uint32
+	valueMap map[uint32]*EqualCopyDiff_Top_Step
+}
+
+// IsYANGOrderedList ensures that EqualCopyDiff_Top_Step_OrderedMap implements the
+// ygot.GoOrderedMap interface.
+func (*EqualCopyDiff_Top_Step_OrderedMap) IsYANGOrderedList() {}
+
+// init initializes any uninitialized values.
+func (o *EqualCopyDiff_Top_Step_OrderedMap) init() {
+	if o == nil {
+		return
+	}
+	if o.valueMap == nil {
+		o.valueMap = map[uint32]*EqualCopyDiff_Top_Step{}
+	}
+}
+
+// Keys returns a copy of the list's keys.
+func (o *EqualCopyDiff_Top_Step_OrderedMap) Keys() []uint32 {
+	if o == nil {
+		return nil
+	}
+	return append([]uint32{}, o.keys...)
+}
+
+// Values returns the current set of the list's values in order.
+func (o *EqualCopyDiff_Top_Step_OrderedMap) Values() []*EqualCopyDiff_Top_Step {
+	if o == nil {
+		return nil
+	}
+	var values []*EqualCopyDiff_Top_Step
+	for _, key := range o.keys {
+		values = append(values, o.valueMap[key])
+	}
+	return values
+}
+
+// Len returns a size of EqualCopyDiff_Top_Step_OrderedMap
+func (o *EqualCopyDiff_Top_Step_OrderedMap) Len() int {
+	if o == nil {
+		return 0
+	}
+	return len(o.keys)
+}
+
+// Get returns the value corresponding to the key. If the key is not found, nil
+// is returned.
+func (o *EqualCopyDiff_Top_Step_OrderedMap) Get(key uint32) *EqualCopyDiff_Top_Step {
+	if o == nil {
+		return nil
+	}
+	val, _ := o.valueMap[key]
+	return val
+}
+
+// Delete deletes an element.
+func (o *EqualCopyDiff_Top_Step_OrderedMap) Delete(key uint32) bool {
+	if o == nil {
+		return false
+	}
+	if _, ok := o.valueMap[key]; !ok {
+		return false
+	}
+	for i, k := range o.keys {
+		if k == key {
+			o.keys = append(o.keys[:i], o.keys[i+1:]...)
+			delete(o.valueMap, key)
+			return true
+		}
+	}
+	return false
+}
+
+// Append appends a EqualCopyDiff_Top_Step, returning an error if the key
+// already exists in the ordered list or if the key is unspecified.
+func (o *EqualCopyDiff_Top_Step_OrderedMap) Append(v *EqualCopyDiff_Top_Step) error {
+	if o == nil {
+		return fmt.Errorf("nil ordered map, cannot append EqualCopyDiff_Top_Step")
+	}
+	if v == nil {
+		return fmt.Errorf("nil EqualCopyDiff_Top_Step")
+	}
+	if v.Id == nil {
+		return fmt.Errorf("invalid nil key received for Id")
+	}
+
+	key := *v.Id
+
+	if _, ok := o.valueMap[key]; ok {
+		return fmt.Errorf("duplicate key for list Statement %v", key)
+	}
+	o.keys = append(o.keys, key)
+	o.init()
+	o.valueMap[key] = v
+	return nil
+}
+
+// AppendNew creates and appends a new EqualCopyDiff_Top_Step, returning the
+// newly-initialized v. It returns an error if the v already exists.
+func (o *EqualCopyDiff_Top_Step_OrderedMap) AppendNew(Id uint32) (*EqualCopyDiff_Top_Step, error) {
+	if o == nil {
+		return nil, fmt.Errorf("nil ordered map, cannot append EqualCopyDiff_Top_Step")
+	}
+	key := Id
+
+	if _, ok := o.valueMap[key]; ok {
+		return nil, fmt.Errorf("duplicate key for list Statement %v", key)
+	}
+	o.keys = append(o.keys, key)
+	newElement := &EqualCopyDiff_Top_Step{
+		Id: &Id,
+	}
+	o.init()
+	o.valueMap[key] = newElement
+	return newElement, nil
+}
+
+// Equal reports whether EqualCopyDiff_Top_Step_OrderedMap is equal to p, such that it has
+// the same keys in the same order, and equal values for each key.
+func (o *EqualCopyDiff_Top_Step_OrderedMap) Equal(p *EqualCopyDiff_Top_Step_OrderedMap) bool {
+	if o == nil || p == nil {
+		return o == p
+	}
+	if len(o.keys) != len(p.keys) {
+		return false
+	}
+	for i, key := range o.keys {
+		if p.keys[i] != key || !o.valueMap[key].Equal(p.valueMap[key]) {
+			return false
+		}
+	}
+	return true
+}
+
+// Copy returns a deep copy of EqualCopyDiff_Top_Step_OrderedMap.
+func (o *EqualCopyDiff_Top_Step_OrderedMap) Copy() (*EqualCopyDiff_Top_Step_OrderedMap, error) {
+	if o == nil {
+		return nil, nil
+	}
+	n := &EqualCopyDiff_Top_Step_OrderedMap{}
+	for _, key := range o.keys {
+		v, err := o.valueMap[key].Copy()
+		if err != nil {
+			return nil, err
+		}
+		n.keys = append(n.keys, key)
+		n.init()
+		n.valueMap[key] = v
+	}
+	return n, nil
+}
+
+// Validate validates s against the YANG schema corresponding to its type.
+func (t *EqualCopyDiff_Top) ΛValidate(opts ...ygot.ValidationOption) error {
+	if err := ytypes.Validate(SchemaTree["EqualCopyDiff_Top"], t, opts...); err != nil {
+		return err
+	}
+	return nil
+}
+
+// Validate validates s against the YANG schema corresponding to its type.
+func (t *EqualCopyDiff_Top) Validate(opts ...ygot.ValidationOption) error {
+	return t.ΛValidate(opts...)
+}
+
+// ΛEnumTypeMap returns a map, keyed by YANG schema path, of the enumerated types
+// that are included in the generated code.
+func (t *EqualCopyDiff_Top) ΛEnumTypeMap() map[string][]reflect.Type { return ΛEnumTypes }
+
+// Equal reports whether EqualCopyDiff_Top is equal to o. Nil values are only
+// equal to other nil values.
+func (t *EqualCopyDiff_Top) Equal(o *EqualCopyDiff_Top) bool {
+	if t == nil || o == nil {
+		return t == o
+	}
+	if !ygot.EqualSlices(t.Bin, o.Bin) {
+		return false
+	}
+	if !reflect.DeepEqual(t.Blobs, o.Blobs) {
+		return false
+	}
+	if !t.Child.Equal(o.Child) {
+		return false
+	}
+	if t.Color != o.Color {
+		return false
+	}
+	if !reflect.DeepEqual(t.Data, o.Data) {
+		return false
+	}
+	if !ygot.EqualMaps(t.Entry, o.Entry) {
+		return false
+	}
+	if t.Kind != o.Kind {
+		return false
+	}
+	if !ygot.EqualSlices(t.Kinds, o.Kinds) {
+		return false
+	}
+	if !reflect.DeepEqual(t.Mixed, o.Mixed) {
+		return false
+	}
+	switch c := t.Transport.(type) {
+	case *EqualCopyDiff_Top_Transport_Choice_Udp:
+		if oc, ok := o.Transport.(*EqualCopyDiff_Top_Transport_Choice_Udp); !ok || !c.Equal(oc) {
+			return false
+		}
+	case *EqualCopyDiff_Top_Transport_Choice_Tcp:
+		if oc, ok := o.Transport.(*EqualCopyDiff_Top_Transport_Choice_Tcp); !ok || !c.Equal(oc) {
+			return false
+		}
+	default:
+		if !reflect.DeepEqual(t.Transport, o.Transport) {
+			return false
+		}
+	}
+	if !ygot.EqualSlices(t.Names, o.Names) {
+		return false
+	}
+	if t.On != o.On {
+		return false
+	}
+	if !ygot.EqualStructSlices(t.Sample, o.Sample) {
+		return false
+	}
+	if !t.Step.Equal(o.Step) {
+		return false
+	}
+	if !ygot.EqualPtrs(t.Str, o.Str) {
+		return false
+	}
+	return true
+}
+
+// Copy returns a deep copy of EqualCopyDiff_Top. As with ygot.DeepCopy, empty
+// maps and slices are not retained in the copy.
+func (t *EqualCopyDiff_Top) Copy() (*EqualCopyDiff_Top, error) {
+	if t == nil {
+		return nil, nil
+	}
+	n := &EqualCopyDiff_Top{}
+	var err error
+	n.Bin = ygot.CopySlice(t.Bin)
+	if n.Blobs, err = ygot.CopyField(t.Blobs); err != nil {
+		return nil, err
+	}
+	if n.Child, err = t.Child.Copy(); err != nil {
+		return nil, err
+	}
+	n.Color = t.Color
+	if n.Data, err = ygot.CopyField(t.Data); err != nil {
+		return nil, err
+	}
+	if n.Entry, err = ygot.CopyMap(t.Entry); err != nil {
+		return nil, err
+	}
+	n.Kind = t.Kind
+	n.Kinds = ygot.CopySlice(t.Kinds)
+	if n.Mixed, err = ygot.CopyField(t.Mixed); err != nil {
+		return nil, err
+	}
+	switch c := t.Transport.(type) {
+	case *EqualCopyDiff_Top_Transport_Choice_Udp:
+		if n.Transport, err = c.Copy(); err != nil {
+			return nil, err
+		}
+	case *EqualCopyDiff_Top_Transport_Choice_Tcp:
+		if n.Transport, err = c.Copy(); err != nil {
+			return nil, err
+		}
+	default:
+		if n.Transport, err = ygot.CopyField(t.Transport); err != nil {
+			return nil, err
+		}
+	}
+	n.Names = ygot.CopySlice(t.Names)
+	n.On = t.On
+	if n.Sample, err = ygot.CopyStructSlice(t.Sample); err != nil {
+		return nil, err
+	}
+	if t.Step.Len() != 0 {
+		if n.Step, err = t.Step.Copy(); err != nil {
+			return nil, err
+		}
+	}
+	if t.Str != nil {
+		v := *t.Str
+		n.Str = &v
+	}
+	return n, nil
+}
+
+// ΛDeepCopy returns a deep copy of EqualCopyDiff_Top. It implements the
+// ygot.GoStructCopier interface.
+func (t *EqualCopyDiff_Top) ΛDeepCopy() (ygot.GoStruct, error) {
+	return t.Copy()
+}
+
+// ΛDiff reports the fields of EqualCopyDiff_Top that differ from those of other
+// to d. It implements the ygot.GoStructDiffer interface.
+func (t *EqualCopyDiff_Top) ΛDiff(d *ygot.DiffWalker, other ygot.GoStruct) error {
+	o, _ := other.(*EqualCopyDiff_Top)
+	return t.diff(d, o)
+}
+
+// diff reports the fields of EqualCopyDiff_Top that differ from those of o to d.
+func (t *EqualCopyDiff_Top) diff(d *ygot.DiffWalker, o *EqualCopyDiff_Top) error {
+	if t == nil {
+		t = &EqualCopyDiff_Top{}
+	}
+	if o == nil {
+		o = &EqualCopyDiff_Top{}
+	}
+	if !ygot.EqualSlices(t.Bin, o.Bin) {
+		if err := d.Leaf([][]string{{"bin"}}, nil, t.Bin, o.Bin); err != nil {
+			return err
+		}
+	}
+	if !reflect.DeepEqual(t.Blobs, o.Blobs) {
+		if err := d.Leaf([][]string{{"blobs"}}, nil, t.Blobs, o.Blobs); err != nil {
+			return err
+		}
+	}
+	if !t.Child.Equal(o.Child) {
+		if err := d.Struct([][]string{{"child"}}, nil, t.Child, o.Child); err != nil {
+			return err
+		}
+	}
+	if t.Color != o.Color {
+		if err := d.Leaf([][]string{{"color"}}, nil, t.Color, o.Color); err != nil {
+			return err
+		}
+	}
+	if !reflect.DeepEqual(t.Data, o.Data) {
+		if err := d.Field(t, o, "Data"); err != nil {
+			return err
+		}
+	}
+	if !ygot.EqualMaps(t.Entry, o.Entry) {
+		if err := ygot.DiffMaps(d, [][]string{{"entry"}}, nil, t.Entry, o.Entry); err != nil {
+			return err
+		}
+	}
+	if t.Kind != o.Kind {
+		if err := d.Leaf([][]string{{"kind"}}, nil, t.Kind, o.Kind); err != nil {
+			return err
+		}
+	}
+	if !ygot.EqualSlices(t.Kinds, o.Kinds) {
+		if err := d.Leaf([][]string{{"kinds"}}, nil, t.Kinds, o.Kinds); err != nil {
+			return err
+		}
+	}
+	if !reflect.DeepEqual(t.Mixed, o.Mixed) {
+		if err := d.Leaf([][]string{{"mixed"}}, nil, t.Mixed, o.Mixed); err != nil {
+			return err
+		}
+	}
+	switch c := t.Transport.(type) {
+	case *EqualCopyDiff_Top_Transport_Choice_Udp:
+		oc, _ := o.Transport.(*EqualCopyDiff_Top_Transport_Choice_Udp)
+		if err := c.diff(d, oc); err != nil {
+			return err
+		}
+	case *EqualCopyDiff_Top_Transport_Choice_Tcp:
+		oc, _ := o.Transport.(*EqualCopyDiff_Top_Transport_Choice_Tcp)
+		if err := c.diff(d, oc); err != nil {
+			return err
+		}
+	}
+	switch c := o.Transport.(type) {
+	case *EqualCopyDiff_Top_Transport_Choice_Udp:
+		if _, ok := t.Transport.(*EqualCopyDiff_Top_Transport_Choice_Udp); !ok {
+			if err := (*EqualCopyDiff_Top_Transport_Choice_Udp)(nil).diff(d, c); err != nil {
+				return err
+			}
+		}
+	case *EqualCopyDiff_Top_Transport_Choice_Tcp:
+		if _, ok := t.Transport.(*EqualCopyDiff_Top_Transport_Choice_Tcp); !ok {
+			if err := (*EqualCopyDiff_Top_Transport_Choice_Tcp)(nil).diff(d, c); err != nil {
+				return err
+			}
+		}
+	}
+	if !ygot.EqualSlices(t.Names, o.Names) {
+		if err := d.Leaf([][]string{{"names"}}, nil, t.Names, o.Names); err != nil {
+			return err
+		}
+	}
+	if t.On != o.On {
+		if err := d.Leaf([][]string{{"on"}}, nil, t.On, o.On); err != nil {
+			return err
+		}
+	}
+	if !ygot.EqualStructSlices(t.Sample, o.Sample) {
+		if err := d.Field(t, o, "Sample"); err != nil {
+			return err
+		}
+	}
+	if !t.Step.Equal(o.Step) {
+		if err := d.Field(t, o, "Step"); err != nil {
+			return err
+		}
+	}
+	if !ygot.EqualPtrs(t.Str, o.Str) {
+		if err := d.Leaf([][]string{{"str"}}, nil, t.Str, o.Str); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// Equal reports whether EqualCopyDiff_Top_Transport_Choice_Udp is equal to o. Nil values are only
+// equal to other nil values.
+func (t *EqualCopyDiff_Top_Transport_Choice_Udp) Equal(o *EqualCopyDiff_Top_Transport_Choice_Udp) bool {
+	if t == nil || o == nil {
+		return t == o
+	}
+	switch c := t.Mode.(type) {
+	case *EqualCopyDiff_Top_Mode_Choice_Multicast:
+		if oc, ok := o.Mode.(*EqualCopyDiff_Top_Mode_Choice_Multicast); !ok || !c.Equal(oc) {
+			return false
+		}
+	case *EqualCopyDiff_Top_Mode_Choice_Unicast:
+		if oc, ok := o.Mode.(*EqualCopyDiff_Top_Mode_Choice_Unicast); !ok || !c.Equal(oc) {
+			return false
+		}
+	default:
+		if !reflect.DeepEqual(t.Mode, o.Mode) {
+			return false
+		}
+	}
+	return true
+}
+
+// Copy returns a deep copy of EqualCopyDiff_Top_Transport_Choice_Udp. As with ygot.DeepCopy, empty
+// maps and slices are not retained in the copy.
+func (t *EqualCopyDiff_Top_Transport_Choice_Udp) Copy() (*EqualCopyDiff_Top_Transport_Choice_Udp, error) {
+	if t == nil {
+		return nil, nil
+	}
+	n := &EqualCopyDiff_Top_Transport_Choice_Udp{}
+	var err error
+	switch c := t.Mode.(type) {
+	case *EqualCopyDiff_Top_Mode_Choice_Multicast:
+		if n.Mode, err = c.Copy(); err != nil {
+			return nil, err
+		}
+	case *EqualCopyDiff_Top_Mode_Choice_Unicast:
+		if n.Mode, err = c.Copy(); err != nil {
+			return nil, err
+		}
+	default:
+		if n.Mode, err = ygot.CopyField(t.Mode); err != nil {
+			return nil, err
+		}
+	}
+	return n, nil
+}
+
+// diff reports the fields of EqualCopyDiff_Top_Transport_Choice_Udp that differ from those of o to d.
+func (t *EqualCopyDiff_Top_Transport_Choice_Udp) diff(d *ygot.DiffWalker, o *EqualCopyDiff_Top_Transport_Choice_Udp) error {
+	if t == nil {
+		t = &EqualCopyDiff_Top_Transport_Choice_Udp{}
+	}
+	if o == nil {
+		o = &EqualCopyDiff_Top_Transport_Choice_Udp{}
+	}
+	switch c := t.Mode.(type) {
+	case *EqualCopyDiff_Top_Mode_Choice_Multicast:
+		oc, _ := o.Mode.(*EqualCopyDiff_Top_Mode_Choice_Multicast)
+		if err := c.diff(d, oc); err != nil {
+			return err
+		}
+	case *EqualCopyDiff_Top_Mode_Choice_Unicast:
+		oc, _ := o.Mode.(*EqualCopyDiff_Top_Mode_Choice_Unicast)
+		if err := c.diff(d, oc); err != nil {
+			return err
+		}
+	}
+	switch c := o.Mode.(type) {
+	case *EqualCopyDiff_Top_Mode_Choice_Multicast:
+		if _, ok := t.Mode.(*EqualCopyDiff_Top_Mode_Choice_Multicast); !ok {
+			if err := (*EqualCopyDiff_Top_Mode_Choice_Multicast)(nil).diff(d, c); err != nil {
+				return err
+			}
+		}
+	case *EqualCopyDiff_Top_Mode_Choice_Unicast:
+		if _, ok := t.Mode.(*EqualCopyDiff_Top_Mode_Choice_Unicast); !ok {
+			if err := (*EqualCopyDiff_Top_Mode_Choice_Unicast)(nil).diff(d, c); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+// Equal reports whether EqualCopyDiff_Top_Mode_Choice_Multicast is equal to o. Nil values are only
+// equal to other nil values.
+func (t *EqualCopyDiff_Top_Mode_Choice_Multicast) Equal(o *EqualCopyDiff_Top_Mode_Choice_Multicast) bool {
+	if t == nil || o == nil {
+		return t == o
+	}
+	if t.Multicast != o.Multicast {
+		return false
+	}
+	return true
+}
+
+// Copy returns a deep copy of EqualCopyDiff_Top_Mode_Choice_Multicast. As with ygot.DeepCopy, empty
+// maps and slices are not retained in the copy.
+func (t *EqualCopyDiff_Top_Mode_Choice_Multicast) Copy() (*EqualCopyDiff_Top_Mode_Choice_Multicast, error) {
+	if t == nil {
+		return nil, nil
+	}
+	n := &EqualCopyDiff_Top_Mode_Choice_Multicast{}
+	n.Multicast = t.Multicast
+	return n, nil
+}
+
+// diff reports the fields of EqualCopyDiff_Top_Mode_Choice_Multicast that differ from those of o to d.
+func (t *EqualCopyDiff_Top_Mode_Choice_Multicast) diff(d *ygot.DiffWalker, o *EqualCopyDiff_Top_Mode_Choice_Multicast) error {
+	if t == nil {
+		t = &EqualCopyDiff_Top_Mode_Choice_Multicast{}
+	}
+	if o == nil {
+		o = &EqualCopyDiff_Top_Mode_Choice_Multicast{}
+	}
+	if t.Multicast != o.Multicast {
+		if err := d.Leaf([][]string{{"multicast"}}, nil, t.Multicast, o.Multicast); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// Equal reports whether EqualCopyDiff_Top_Mode_Choice_Unicast is equal to o. Nil values are only
+// equal to other nil values.
+func (t *EqualCopyDiff_Top_Mode_Choice_Unicast) Equal(o *EqualCopyDiff_Top_Mode_Choice_Unicast) bool {
+	if t == nil || o == nil {
+		return t == o
+	}
+	if !ygot.EqualPtrs(t.Unicast, o.Unicast) {
+		return false
+	}
+	return true
+}
+
+// Copy returns a deep copy of EqualCopyDiff_Top_Mode_Choice_Unicast. As with ygot.DeepCopy, empty
+// maps and slices are not retained in the copy.
+func (t *EqualCopyDiff_Top_Mode_Choice_Unicast) Copy() (*EqualCopyDiff_Top_Mode_Choice_Unicast, error) {
+	if t == nil {
+		return nil, nil
+	}
+	n := &EqualCopyDiff_Top_Mode_Choice_Unicast{}
+	if t.Unicast != nil {
+		v := *t.Unicast
+		n.Unicast = &v
+	}
+	return n, nil
+}
+
+// diff reports the fields of EqualCopyDiff_Top_Mode_Choice_Unicast that differ from those of o to d.
+func (t *EqualCopyDiff_Top_Mode_Choice_Unicast) diff(d *ygot.DiffWalker, o *EqualCopyDiff_Top_Mode_Choice_Unicast) error {
+	if t == nil {
+		t = &EqualCopyDiff_Top_Mode_Choice_Unicast{}
+	}
+	if o == nil {
+		o = &EqualCopyDiff_Top_Mode_Choice_Unicast{}
+	}
+	if !ygot.EqualPtrs(t.Unicast, o.Unicast) {
+		if err := d.Leaf([][]string{{"unicast"}}, nil, t.Unicast, o.Unicast); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// Equal reports whether EqualCopyDiff_Top_Transport_Choice_Tcp is equal to o. Nil values are only
+// equal to other nil values.
+func (t *EqualCopyDiff_Top_Transport_Choice_Tcp) Equal(o *EqualCopyDiff_Top_Transport_Choice_Tcp) bool {
+	if t == nil || o == nil {
+		return t == o
+	}
+	if !t.Options.Equal(o.Options) {
+		return false
+	}
+	if !ygot.EqualPtrs(t.Port, o.Port) {
+		return false
+	}
+	return true
+}
+
+// Copy returns a deep copy of EqualCopyDiff_Top_Transport_Choice_Tcp. As with ygot.DeepCopy, empty
+// maps and slices are not retained in the copy.
+func (t *EqualCopyDiff_Top_Transport_Choice_Tcp) Copy() (*EqualCopyDiff_Top_Transport_Choice_Tcp, error) {
+	if t == nil {
+		return nil, nil
+	}
+	n := &EqualCopyDiff_Top_Transport_Choice_Tcp{}
+	var err error
+	if n.Options, err = t.Options.Copy(); err != nil {
+		return nil, err
+	}
+	if t.Port != nil {
+		v := *t.Port
+		n.Port = &v
+	}
+	return n, nil
+}
+
+// diff reports the fields of EqualCopyDiff_Top_Transport_Choice_Tcp that differ from those of o to d.
+func (t *EqualCopyDiff_Top_Transport_Choice_Tcp) diff(d *ygot.DiffWalker, o *EqualCopyDiff_Top_Transport_Choice_Tcp) error {
+	if t == nil {
+		t = &EqualCopyDiff_Top_Transport_Choice_Tcp{}
+	}
+	if o == nil {
+		o = &EqualCopyDiff_Top_Transport_Choice_Tcp{}
+	}
+	if !t.Options.Equal(o.Options) {
+		if err := d.Struct([][]string{{"options"}}, nil, t.Options, o.Options); err != nil {
+			return err
+		}
+	}
+	if !ygot.EqualPtrs(t.Port, o.Port) {
+		if err := d.Leaf([][]string{{"port"}}, nil, t.Port, o.Port); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// ΛBelongingModule returns the name of the module that defines the namespace
+// of EqualCopyDiff_Top.
+func (*EqualCopyDiff_Top) ΛBelongingModule() string {
+	return "equal-copy-diff"
+}
+
+// EqualCopyDiff_Top_Mixed_Union is an interface that is implemented by valid types for the union
+// for the leaf /equal-copy-diff/top/mixed within the YANG schema.
+// Union type can be one of [UnionInt32, UnionString].
+type EqualCopyDiff_Top_Mixed_Union interface {
+	// Union type can be one of [UnionInt32, UnionString]
+	Documentation_for_EqualCopyDiff_Top_Mixed_Union()
+}
+
+// Documentation_for_EqualCopyDiff_Top_Mixed_Union ensures that UnionInt32
+// implements the EqualCopyDiff_Top_Mixed_Union interface.
+func (UnionInt32) Documentation_for_EqualCopyDiff_Top_Mixed_Union() {}
+
+// Documentation_for_EqualCopyDiff_Top_Mixed_Union ensures that UnionString
+// implements the EqualCopyDiff_Top_Mixed_Union interface.
+func (UnionString) Documentation_for_EqualCopyDiff_Top_Mixed_Union() {}
+
+// To_EqualCopyDiff_Top_Mixed_Union takes an input interface{} and attempts to convert it to a struct
+// which implements the EqualCopyDiff_Top_Mixed_Union union. It returns an error if the interface{} supplied
+// cannot be converted to a type within the union.
+func (t *EqualCopyDiff_Top) To_EqualCopyDiff_Top_Mixed_Union(i interface{}) (EqualCopyDiff_Top_Mixed_Union, error) {
+	if v, ok := i.(EqualCopyDiff_Top_Mixed_Union); ok {
+		return v, nil
+	}
+	switch v := i.(type) {
+	case int32:
+		return UnionInt32(v), nil
+	case string:
+		return UnionString(v), nil
+	}
+	return nil, fmt.Errorf("cannot convert %v to EqualCopyDiff_Top_Mixed_Union, unknown union type, got: %T, want any of [int32, string]", i, i)
+}
+
+// EqualCopyDiff_Top_Transport_Choice is an interface that is implemented by the structs
+// representing the cases of the choice transport within the
+// /equal-copy-diff/top YANG schema element.
+type EqualCopyDiff_Top_Transport_Choice interface {
+	ygot.GoChoiceCase
+	Is_EqualCopyDiff_Top_Transport_Choice()
+}
+
+// EqualCopyDiff_Top_Transport_Choice_Udp represents the case udp of
+// the choice transport within the /equal-copy-diff/top YANG schema element.
+type EqualCopyDiff_Top_Transport_Choice_Udp struct {
+	Mode EqualCopyDiff_Top_Mode_Choice `choice:"mode"`
+}
+
+// IsYANGChoiceCase ensures that EqualCopyDiff_Top_Transport_Choice_Udp implements the
+// ygot.GoChoiceCase interface.
+func (*EqualCopyDiff_Top_Transport_Choice_Udp) IsYANGChoiceCase() {}
+
+// Is_EqualCopyDiff_Top_Transport_Choice ensures that EqualCopyDiff_Top_Transport_Choice_Udp
+// implements the EqualCopyDiff_Top_Transport_Choice interface.
+func (*EqualCopyDiff_Top_Transport_Choice_Udp) Is_EqualCopyDiff_Top_Transport_Choice() {}
+
+// EqualCopyDiff_Top_Transport_Choice_Tcp represents the case tcp of
+// the choice transport within the /equal-copy-diff/top YANG schema element.
+type EqualCopyDiff_Top_Transport_Choice_Tcp struct {
+	Options *EqualCopyDiff_Top_Options `path:"options" module:"equal-copy-diff"`
+	Port    *uint16                    `path:"port" module:"equal-copy-diff"`
+}
+
+// IsYANGChoiceCase ensures that EqualCopyDiff_Top_Transport_Choice_Tcp implements the
+// ygot.GoChoiceCase interface.
+func (*EqualCopyDiff_Top_Transport_Choice_Tcp) IsYANGChoiceCase() {}
+
+// Is_EqualCopyDiff_Top_Transport_Choice ensures that EqualCopyDiff_Top_Transport_Choice_Tcp
+// implements the EqualCopyDiff_Top_Transport_Choice interface.
+func (*EqualCopyDiff_Top_Transport_Choice_Tcp) Is_EqualCopyDiff_Top_Transport_Choice() {}
+
+// EqualCopyDiff_Top_Mode_Choice is an interface that is implemented by the structs
+// representing the cases of the choice mode within the
+// /equal-copy-diff/top YANG schema element.
+type EqualCopyDiff_Top_Mode_Choice interface {
+	ygot.GoChoiceCase
+	Is_EqualCopyDiff_Top_Mode_Choice()
+}
+
+// EqualCopyDiff_Top_Mode_Choice_Multicast represents the case multicast of
+// the choice mode within the /equal-copy-diff/top YANG schema element.
+type EqualCopyDiff_Top_Mode_Choice_Multicast struct {
+	Multicast YANGEmpty `path:"multicast" module:"equal-copy-diff"`
+}
+
+// IsYANGChoiceCase ensures that EqualCopyDiff_Top_Mode_Choice_Multicast implements the
+// ygot.GoChoiceCase interface.
+func (*EqualCopyDiff_Top_Mode_Choice_Multicast) IsYANGChoiceCase() {}
+
+// Is_EqualCopyDiff_Top_Mode_Choice ensures that EqualCopyDiff_Top_Mode_Choice_Multicast
+// implements the EqualCopyDiff_Top_Mode_Choice interface.
+func (*EqualCopyDiff_Top_Mode_Choice_Multicast) Is_EqualCopyDiff_Top_Mode_Choice() {}
+
+// EqualCopyDiff_Top_Mode_Choice_Unicast represents the case unicast of
+// the choice mode within the /equal-copy-diff/top YANG schema element.
+type EqualCopyDiff_Top_Mode_Choice_Unicast struct {
+	Unicast *string `path:"unicast" module:"equal-copy-diff"`
+}
+
+// IsYANGChoiceCase ensures that EqualCopyDiff_Top_Mode_Choice_Unicast implements the
+// ygot.GoChoiceCase interface.
+func (*EqualCopyDiff_Top_Mode_Choice_Unicast) IsYANGChoiceCase() {}
+
+// Is_EqualCopyDiff_Top_Mode_Choice ensures that EqualCopyDiff_Top_Mode_Choice_Unicast
+// implements the EqualCopyDiff_Top_Mode_Choice interface.
+func (*EqualCopyDiff_Top_Mode_Choice_Unicast) Is_EqualCopyDiff_Top_Mode_Choice() {}
+
+// EqualCopyDiff_Top_Child represents the /equal-copy-diff/top/child YANG schema element.
+type EqualCopyDiff_Top_Child struct {
+	Value *string `path:"value" module:"equal-copy-diff"`
+}
+
+// IsYANGGoStruct ensures that EqualCopyDiff_Top_Child implements the yang.GoStruct
+// interface. This allows functions that need to handle this struct to
+// identify it as being generated by ygen.
+func (*EqualCopyDiff_Top_Child) IsYANGGoStruct() {}
+
+// Validate validates s against the YANG schema corresponding to its type.
+func (t *EqualCopyDiff_Top_Child) ΛValidate(opts ...ygot.ValidationOption) error {
+	if err := ytypes.Validate(SchemaTree["EqualCopyDiff_Top_Child"], t, opts...); err != nil {
+		return err
+	}
+	return nil
+}
+
+// Validate validates s against the YANG schema corresponding to its type.
+func (t *EqualCopyDiff_Top_Child) Validate(opts ...ygot.ValidationOption) error {
+	return t.ΛValidate(opts...)
+}
+
+// ΛEnumTypeMap returns a map, keyed by YANG schema path, of the enumerated types
+// that are included in the generated code.
+func (t *EqualCopyDiff_Top_Child) ΛEnumTypeMap() map[string][]reflect.Type { return ΛEnumTypes }
+
+// Equal reports whether EqualCopyDiff_Top_Child is equal to o. Nil values are only
+// equal to other nil values.
+func (t *EqualCopyDiff_Top_Child) Equal(o *EqualCopyDiff_Top_Child) bool {
+	if t == nil || o == nil {
+		return t == o
+	}
+	if !ygot.EqualPtrs(t.Value, o.Value) {
+		return false
+	}
+	return true
+}
+
+// Copy returns a deep copy of EqualCopyDiff_Top_Child. As with ygot.DeepCopy, empty
+// maps and slices are not retained in the copy.
+func (t *EqualCopyDiff_Top_Child) Copy() (*EqualCopyDiff_Top_Child, error) {
+	if t == nil {
+		return nil, nil
+	}
+	n := &EqualCopyDiff_Top_Child{}
+	if t.Value != nil {
+		v := *t.Value
+		n.Value = &v
+	}
+	return n, nil
+}
+
+// ΛDeepCopy returns a deep copy of EqualCopyDiff_Top_Child. It implements the
+// ygot.GoStructCopier interface.
+func (t *EqualCopyDiff_Top_Child) ΛDeepCopy() (ygot.GoStruct, error) {
+	return t.Copy()
+}
+
+// ΛDiff reports the fields of EqualCopyDiff_Top_Child that differ from those of other
+// to d. It implements the ygot.GoStructDiffer interface.
+func (t *EqualCopyDiff_Top_Child) ΛDiff(d *ygot.DiffWalker, other ygot.GoStruct) error {
+	o, _ := other.(*EqualCopyDiff_Top_Child)
+	return t.diff(d, o)
+}
+
+// diff reports the fields of EqualCopyDiff_Top_Child that differ from those of o to d.
+func (t *EqualCopyDiff_Top_Child) diff(d *ygot.DiffWalker, o *EqualCopyDiff_Top_Child) error {
+	if t == nil {
+		t = &EqualCopyDiff_Top_Child{}
+	}
+	if o == nil {
+		o = &EqualCopyDiff_Top_Child{}
+	}
+	if !ygot.EqualPtrs(t.Value, o.Value) {
+		if err := d.Leaf([][]string{{"value"}}, nil, t.Value, o.Value); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// ΛBelongingModule returns the name of the module that defines the namespace
+// of EqualCopyDiff_Top_Child.
+func (*EqualCopyDiff_Top_Child) ΛBelongingModule() string {
+	return "equal-copy-diff"
+}
+
+// EqualCopyDiff_Top_Entry represents the /equal-copy-diff/top/entry YANG schema element.
+type EqualCopyDiff_Top_Entry struct {
+	Name  *string `path:"name" module:"equal-copy-diff"`
+	Value *uint8  `path:"value" module:"equal-copy-diff"`
+}
+
+// IsYANGGoStruct ensures that EqualCopyDiff_Top_Entry implements the yang.GoStruct
+// interface. This allows functions that need to handle this struct to
+// identify it as being generated by ygen.
+func (*EqualCopyDiff_Top_Entry) IsYANGGoStruct() {}
+
+// ΛListKeyMap returns the keys of the EqualCopyDiff_Top_Entry struct, which is a YANG list entry.
+func (t *EqualCopyDiff_Top_Entry) ΛListKeyMap() (map[string]interface{}, error) {
+	if t.Name == nil {
+		return nil, fmt.Errorf("nil value for key Name")
+	}
+
+	return map[string]interface{}{
+		"name": *t.Name,
+	}, nil
+}
+
+// Validate validates s against the YANG schema corresponding to its type.
+func (t *EqualCopyDiff_Top_Entry) ΛValidate(opts ...ygot.ValidationOption) error {
+	if err := ytypes.Validate(SchemaTree["EqualCopyDiff_Top_Entry"], t, opts...); err != nil {
+		return err
+	}
+	return nil
+}
+
+// Validate validates s against the YANG schema corresponding to its type.
+func (t *EqualCopyDiff_Top_Entry) Validate(opts ...ygot.ValidationOption) error {
+	return t.ΛValidate(opts...)
+}
+
+// ΛEnumTypeMap returns a map, keyed by YANG schema path, of the enumerated types
+// that are included in the generated code.
+func (t *EqualCopyDiff_Top_Entry) ΛEnumTypeMap() map[string][]reflect.Type { return ΛEnumTypes }
+
+// Equal reports whether EqualCopyDiff_Top_Entry is equal to o. Nil values are only
+// equal to other nil values.
+func (t *EqualCopyDiff_Top_Entry) Equal(o *EqualCopyDiff_Top_Entry) bool {
+	if t == nil || o == nil {
+		return t == o
+	}
+	if !ygot.EqualPtrs(t.Name, o.Name) {
+		return false
+	}
+	if !ygot.EqualPtrs(t.Value, o.Value) {
+		return false
+	}
+	return true
+}
+
+// Copy returns a deep copy of EqualCopyDiff_Top_Entry. As with ygot.DeepCopy, empty
+// maps and slices are not retained in the copy.
+func (t *EqualCopyDiff_Top_Entry) Copy() (*EqualCopyDiff_Top_Entry, error) {
+	if t == nil {
+		return nil, nil
+	}
+	n := &EqualCopyDiff_Top_Entry{}
+	if t.Name != nil {
+		v := *t.Name
+		n.Name = &v
+	}
+	if t.Value != nil {
+		v := *t.Value
+		n.Value = &v
+	}
+	return n, nil
+}
+
+// ΛDeepCopy returns a deep copy of EqualCopyDiff_Top_Entry. It implements the
+// ygot.GoStructCopier interface.
+func (t *EqualCopyDiff_Top_Entry) ΛDeepCopy() (ygot.GoStruct, error) {
+	return t.Copy()
+}
+
+// ΛDiff reports the fields of EqualCopyDiff_Top_Entry that differ from those of other
+// to d. It implements the ygot.GoStructDiffer interface.
+func (t *EqualCopyDiff_Top_Entry) ΛDiff(d *ygot.DiffWalker, other ygot.GoStruct) error {
+	o, _ := other.(*EqualCopyDiff_Top_Entry)
+	return t.diff(d, o)
+}
+
+// diff reports the fields of EqualCopyDiff_Top_Entry that differ from those of o to d.
+func (t *EqualCopyDiff_Top_Entry) diff(d *ygot.DiffWalker, o *EqualCopyDiff_Top_Entry) error {
+	if t == nil {
+		t = &EqualCopyDiff_Top_Entry{}
+	}
+	if o == nil {
+		o = &EqualCopyDiff_Top_Entry{}
+	}
+	if !ygot.EqualPtrs(t.Name, o.Name) {
+		if err := d.Leaf([][]string{{"name"}}, nil, t.Name, o.Name); err != nil {
+			return err
+		}
+	}
+	if !ygot.EqualPtrs(t.Value, o.Value) {
+		if err := d.Leaf([][]string{{"value"}}, nil, t.Value, o.Value); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// ΛBelongingModule returns the name of the module that defines the namespace
+// of EqualCopyDiff_Top_Entry.
+func (*EqualCopyDiff_Top_Entry) ΛBelongingModule() string {
+	return "equal-copy-diff"
+}
+
+// EqualCopyDiff_Top_Options represents the /equal-copy-diff/top/options YANG schema element.
+type EqualCopyDiff_Top_Options struct {
+	Nodelay *bool `path:"nodelay" module:"equal-copy-diff"`
+}
+
+// IsYANGGoStruct ensures that EqualCopyDiff_Top_Options implements the yang.GoStruct
+// interface. This allows functions that need to handle this struct to
+// identify it as being generated by ygen.
+func (*EqualCopyDiff_Top_Options) IsYANGGoStruct() {}
+
+// Validate validates s against the YANG schema corresponding to its type.
+func (t *EqualCopyDiff_Top_Options) ΛValidate(opts ...ygot.ValidationOption) error {
+	if err := ytypes.Validate(SchemaTree["EqualCopyDiff_Top_Options"], t, opts...); err != nil {
+		return err
+	}
+	return nil
+}
+
+// Validate validates s against the YANG schema corresponding to its type.
+func (t *EqualCopyDiff_Top_Options) Validate(opts ...ygot.ValidationOption) error {
+	return t.ΛValidate(opts...)
+}
+
+// ΛEnumTypeMap returns a map, keyed by YANG schema path, of the enumerated types
+// that are included in the generated code.
+func (t *EqualCopyDiff_Top_Options) ΛEnumTypeMap() map[string][]reflect.Type { return ΛEnumTypes }
+
+// Equal reports whether EqualCopyDiff_Top_Options is equal to o. Nil values are only
+// equal to other nil values.
+func (t *EqualCopyDiff_Top_Options) Equal(o *EqualCopyDiff_Top_Options) bool {
+	if t == nil || o == nil {
+		return t == o
+	}
+	if !ygot.EqualPtrs(t.Nodelay, o.Nodelay) {
+		return false
+	}
+	return true
+}
+
+// Copy returns a deep copy of EqualCopyDiff_Top_Options. As with ygot.DeepCopy, empty
+// maps and slices are not retained in the copy.
+func (t *EqualCopyDiff_Top_Options) Copy() (*EqualCopyDiff_Top_Options, error) {
+	if t == nil {
+		return nil, nil
+	}
+	n := &EqualCopyDiff_Top_Options{}
+	if t.Nodelay != nil {
+		v := *t.Nodelay
+		n.Nodelay = &v
+	}
+	return n, nil
+}
+
+// ΛDeepCopy returns a deep copy of EqualCopyDiff_Top_Options. It implements the
+// ygot.GoStructCopier interface.
+func (t *EqualCopyDiff_Top_Options) ΛDeepCopy() (ygot.GoStruct, error) {
+	return t.Copy()
+}
+
+// ΛDiff reports the fields of EqualCopyDiff_Top_Options that differ from those of other
+// to d. It implements the ygot.GoStructDiffer interface.
+func (t *EqualCopyDiff_Top_Options) ΛDiff(d *ygot.DiffWalker, other ygot.GoStruct) error {
+	o, _ := other.(*EqualCopyDiff_Top_Options)
+	return t.diff(d, o)
+}
+
+// diff reports the fields of EqualCopyDiff_Top_Options that differ from those of o to d.
+func (t *EqualCopyDiff_Top_Options) diff(d *ygot.DiffWalker, o *EqualCopyDiff_Top_Options) error {
+	if t == nil {
+		t = &EqualCopyDiff_Top_Options{}
+	}
+	if o == nil {
+		o = &EqualCopyDiff_Top_Options{}
+	}
+	if !ygot.EqualPtrs(t.Nodelay, o.Nodelay) {
+		if err := d.Leaf([][]string{{"nodelay"}}, nil, t.Nodelay, o.Nodelay); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// ΛBelongingModule returns the name of the module that defines the namespace
+// of EqualCopyDiff_Top_Options.
+func (*EqualCopyDiff_Top_Options) ΛBelongingModule() string {
+	return "equal-copy-diff"
+}
+
+// EqualCopyDiff_Top_Sample represents the /equal-copy-diff/top/sample YANG schema element.
+type EqualCopyDiff_Top_Sample struct {
+	Value *int32 `path:"value" module:"equal-copy-diff"`
+}
+
+// IsYANGGoStruct ensures that EqualCopyDiff_Top_Sample implements the yang.GoStruct
+// interface. This allows functions that need to handle this struct to
+// identify it as being generated by ygen.
+func (*EqualCopyDiff_Top_Sample) IsYANGGoStruct() {}
+
+// Validate validates s against the YANG schema corresponding to its type.
+func (t *EqualCopyDiff_Top_Sample) ΛValidate(opts ...ygot.ValidationOption) error {
+	if err := ytypes.Validate(SchemaTree["EqualCopyDiff_Top_Sample"], t, opts...); err != nil {
+		return err
+	}
+	return nil
+}
+
+// Validate validates s against the YANG schema corresponding to its type.
+func (t *EqualCopyDiff_Top_Sample) Validate(opts ...ygot.ValidationOption) error {
+	return t.ΛValidate(opts...)
+}
+
+// ΛEnumTypeMap returns a map, keyed by YANG schema path, of the enumerated types
+// that are included in the generated code.
+func (t *EqualCopyDiff_Top_Sample) ΛEnumTypeMap() map[string][]reflect.Type { return ΛEnumTypes }
+
+// Equal reports whether EqualCopyDiff_Top_Sample is equal to o. Nil values are only
+// equal to other nil values.
+func (t *EqualCopyDiff_Top_Sample) Equal(o *EqualCopyDiff_Top_Sample) bool {
+	if t == nil || o == nil {
+		return t == o
+	}
+	if !ygot.EqualPtrs(t.Value, o.Value) {
+		return false
+	}
+	return true
+}
+
+// Copy returns a deep copy of EqualCopyDiff_Top_Sample. As with ygot.DeepCopy, empty
+// maps and slices are not retained in the copy.
+func (t *EqualCopyDiff_Top_Sample) Copy() (*EqualCopyDiff_Top_Sample, error) {
+	if t == nil {
+		return nil, nil
+	}
+	n := &EqualCopyDiff_Top_Sample{}
+	if t.Value != nil {
+		v := *t.Value
+		n.Value = &v
+	}
+	return n, nil
+}
+
+// ΛDeepCopy returns a deep copy of EqualCopyDiff_Top_Sample. It implements the
+// ygot.GoStructCopier interface.
+func (t *EqualCopyDiff_Top_Sample) ΛDeepCopy() (ygot.GoStruct, error) {
+	return t.Copy()
+}
+
+// ΛDiff reports the fields of EqualCopyDiff_Top_Sample that differ from those of other
+// to d. It implements the ygot.GoStructDiffer interface.
+func (t *EqualCopyDiff_Top_Sample) ΛDiff(d *ygot.DiffWalker, other ygot.GoStruct) error {
+	o, _ := other.(*EqualCopyDiff_Top_Sample)
+	return t.diff(d, o)
+}
+
+// diff reports the fields of EqualCopyDiff_Top_Sample that differ from those of o to d.
+func (t *EqualCopyDiff_Top_Sample) diff(d *ygot.DiffWalker, o *EqualCopyDiff_Top_Sample) error {
+	if t == nil {
+		t = &EqualCopyDiff_Top_Sample{}
+	}
+	if o == nil {
+		o = &EqualCopyDiff_Top_Sample{}
+	}
+	if !ygot.EqualPtrs(t.Value, o.Value) {
+		if err := d.Leaf([][]string{{"value"}}, nil, t.Value, o.Value); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// ΛBelongingModule returns the name of the module that defines the namespace
+// of EqualCopyDiff_Top_Sample.
+func (*EqualCopyDiff_Top_Sample) ΛBelongingModule() string {
+	return "equal-copy-diff"
+}
+
+// EqualCopyDiff_Top_Step represents the /equal-copy-diff/top/step YANG schema element.
+type EqualCopyDiff_Top_Step struct {
+	Id *uint32 `path:"id" module:"equal-copy-diff"`
+}
+
+// IsYANGGoStruct ensures that EqualCopyDiff_Top_Step implements the yang.GoStruct
+// interface. This allows functions that need to handle this struct to
+// identify it as being generated by ygen.
+func (*EqualCopyDiff_Top_Step) IsYANGGoStruct() {}
+
+// ΛListKeyMap returns the keys of the EqualCopyDiff_Top_Step struct, which is a YANG list entry.
+func (t *EqualCopyDiff_Top_Step) ΛListKeyMap() (map[string]interface{}, error) {
+	if t.Id == nil {
+		return nil, fmt.Errorf("nil value for key Id")
+	}
+
+	return map[string]interface{}{
+		"id": *t.Id,
+	}, nil
+}
+
+// Validate validates s against the YANG schema corresponding to its type.
+func (t *EqualCopyDiff_Top_Step) ΛValidate(opts ...ygot.ValidationOption) error {
+	if err := ytypes.Validate(SchemaTree["EqualCopyDiff_Top_Step"], t, opts...); err != nil {
+		return err
+	}
+	return nil
+}
+
+// Validate validates s against the YANG schema corresponding to its type.
+func (t *EqualCopyDiff_Top_Step) Validate(opts ...ygot.ValidationOption) error {
+	return t.ΛValidate(opts...)
+}
+
+// ΛEnumTypeMap returns a map, keyed by YANG schema path, of the enumerated types
+// that are included in the generated code.
+func (t *EqualCopyDiff_Top_Step) ΛEnumTypeMap() map[string][]reflect.Type { return ΛEnumTypes }
+
+// Equal reports whether EqualCopyDiff_Top_Step is equal to o. Nil values are only
+// equal to other nil values.
+func (t *EqualCopyDiff_Top_Step) Equal(o *EqualCopyDiff_Top_Step) bool {
+	if t == nil || o == nil {
+		return t == o
+	}
+	if !ygot.EqualPtrs(t.Id, o.Id) {
+		return false
+	}
+	return true
+}
+
+// Copy returns a deep copy of EqualCopyDiff_Top_Step. As with ygot.DeepCopy, empty
+// maps and slices are not retained in the copy.
+func (t *EqualCopyDiff_Top_Step) Copy() (*EqualCopyDiff_Top_Step, error) {
+	if t == nil {
+		return nil, nil
+	}
+	n := &EqualCopyDiff_Top_Step{}
+	if t.Id != nil {
+		v := *t.Id
+		n.Id = &v
+	}
+	return n, nil
+}
+
+// ΛDeepCopy returns a deep copy of EqualCopyDiff_Top_Step. It implements the
+// ygot.GoStructCopier interface.
+func (t *EqualCopyDiff_Top_Step) ΛDeepCopy() (ygot.GoStruct, error) {
+	return t.Copy()
+}
+
+// ΛDiff reports the fields of EqualCopyDiff_Top_Step that differ from those of other
+// to d. It implements the ygot.GoStructDiffer interface.
+func (t *EqualCopyDiff_Top_Step) ΛDiff(d *ygot.DiffWalker, other ygot.GoStruct) error {
+	o, _ := other.(*EqualCopyDiff_Top_Step)
+	return t.diff(d, o)
+}
+
+// diff reports the fields of EqualCopyDiff_Top_Step that differ from those of o to d.
+func (t *EqualCopyDiff_Top_Step) diff(d *ygot.DiffWalker, o *EqualCopyDiff_Top_Step) error {
+	if t == nil {
+		t = &EqualCopyDiff_Top_Step{}
+	}
+	if o == nil {
+		o = &EqualCopyDiff_Top_Step{}
+	}
+	if !ygot.EqualPtrs(t.Id, o.Id) {
+		if err := d.Leaf([][]string{{"id"}}, nil, t.Id, o.Id); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// ΛBelongingModule returns the name of the module that defines the namespace
+// of EqualCopyDiff_Top_Step.
+func (*EqualCopyDiff_Top_Step) ΛBelongingModule() string {
+	return "equal-copy-diff"
+}
+
+// E_EqualCopyDiff_BASE is a derived int64 type which is used to represent
+// the enumerated node EqualCopyDiff_BASE. An additional value named
+// EqualCopyDiff_BASE_UNSET is added to the enumeration which is used as
+// the nil value, indicating that the enumeration was not explicitly set by
+// the program importing the generated structures.
+type E_EqualCopyDiff_BASE int64
+
+// IsYANGGoEnum ensures that EqualCopyDiff_BASE implements the yang.GoEnum
+// interface. This ensures that EqualCopyDiff_BASE can be identified as a
+// mapped type for a YANG enumeration.
+func (E_EqualCopyDiff_BASE) IsYANGGoEnum() {}
+
+// ΛMap returns the value lookup map associated with  EqualCopyDiff_BASE.
+func (E_EqualCopyDiff_BASE) ΛMap() map[string]map[int64]ygot.EnumDefinition { return ΛEnum }
+
+// String returns a logging-friendly string for E_EqualCopyDiff_BASE.
+func (e E_EqualCopyDiff_BASE) String() string {
+	return ygot.EnumLogString(e, int64(e), "E_EqualCopyDiff_BASE")
+}
+
+const (
+	// EqualCopyDiff_BASE_UNSET corresponds to the value UNSET of EqualCopyDiff_BASE
+	EqualCopyDiff_BASE_UNSET E_EqualCopyDiff_BASE = 0
+	// EqualCopyDiff_BASE_DERIVED corresponds to the value DERIVED of EqualCopyDiff_BASE
+	EqualCopyDiff_BASE_DERIVED E_EqualCopyDiff_BASE = 1
+)
+
+// E_EqualCopyDiff_Top_Color is a derived int64 type which is used to represent
+// the enumerated node EqualCopyDiff_Top_Color. An additional value named
+// EqualCopyDiff_Top_Color_UNSET is added to the enumeration which is used as
+// the nil value, indicating that the enumeration was not explicitly set by
+// the program importing the generated structures.
+type E_EqualCopyDiff_Top_Color int64
+
+// IsYANGGoEnum ensures that EqualCopyDiff_Top_Color implements the yang.GoEnum
+// interface. This ensures that EqualCopyDiff_Top_Color can be identified as a
+// mapped type for a YANG enumeration.
+func (E_EqualCopyDiff_Top_Color) IsYANGGoEnum() {}
+
+// ΛMap returns the value lookup map associated with  EqualCopyDiff_Top_Color.
+func (E_EqualCopyDiff_Top_Color) ΛMap() map[string]map[int64]ygot.EnumDefinition { return ΛEnum }
+
+// String returns a logging-friendly string for E_EqualCopyDiff_Top_Color.
+func (e E_EqualCopyDiff_Top_Color) String() string {
+	return ygot.EnumLogString(e, int64(e), "E_EqualCopyDiff_Top_Color")
+}
+
+const (
+	// EqualCopyDiff_Top_Color_UNSET corresponds to the value UNSET of EqualCopyDiff_Top_Color
+	EqualCopyDiff_Top_Color_UNSET E_EqualCopyDiff_Top_Color = 0
+	// EqualCopyDiff_Top_Color_RED corresponds to the value RED of EqualCopyDiff_Top_Color
+	EqualCopyDiff_Top_Color_RED E_EqualCopyDiff_Top_Color = 1
+	// EqualCopyDiff_Top_Color_BLUE corresponds to the value BLUE of EqualCopyDiff_Top_Color
+	EqualCopyDiff_Top_Color_BLUE E_EqualCopyDiff_Top_Color = 2
+)
+
+// ΛEnum is a map, keyed by the name of the type defined for each enum in the
+// generated Go code, which provides a mapping between the constant int64 value
+// of each value of the enumeration, and the string that is used to represent it
+// in the YANG schema. The map is named ΛEnum in order to avoid clash with any
+// valid YANG identifier.
+var ΛEnum = map[string]map[int64]ygot.EnumDefinition{
+	"E_EqualCopyDiff_BASE": {
+		1: {Name: "DERIVED", DefiningModule: "equal-copy-diff"},
+	},
+	"E_EqualCopyDiff_Top_Color": {
+		1: {Name: "RED"},
+		2: {Name: "BLUE"},
+	},
+}
+
+var (
+	// ySchema is a byte slice contain a gzip compressed representation of the
+	// YANG schema from which the Go code was generated. When uncompressed the
+	// contents of the byte slice is a JSON document containing an object, keyed
+	// on the name of the generated struct, and containing the JSON marshalled
+	// contents of a goyang yang.Entry struct, which defines the schema for the
+	// fields within the struct.
+	ySchema = []byte{
+		0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0xec, 0x5d, 0xdb, 0x6e, 0xdb, 0x38,
+		0x13, 0xbe, 0xf7, 0x53, 0x10, 0xbc, 0x76, 0xe0, 0x43, 0x64, 0x3b, 0xf1, 0x5d, 0x52, 0xbb, 0xf8,
+		0x8b, 0xf6, 0xdf, 0x2e, 0x9a, 0xb6, 0x37, 0x8b, 0xa0, 0xa0, 0x2d, 0x3a, 0x25, 0x2a, 0x93, 0x5a,
+		0x89, 0xea, 0xc6, 0x58, 0xe4, 0xdd, 0x17, 0x3a, 0xb9, 0xf1, 0x41, 0xd2, 0xf0, 0xa0, 0xd4, 0x6e,
+		0xa9, 0xbb, 0xda, 0x23, 0x6a, 0x34, 0xf3, 0xcd, 0xf0, 0x9b, 0x19, 0xa6, 0xfe, 0xb7, 0x83, 0x10,
+		0x42, 0xf8, 0x0f, 0xb2, 0xa6, 0x78, 0x8a, 0xb0, 0x4f, 0xbf, 0xb3, 0x25, 0xc5, 0xdd, 0xfc, 0xd3,
+		0xb7, 0x8c, 0xfb, 0x78, 0x8a, 0x06, 0xc5, 0x3f, 0x5f, 0x09, 0xbe, 0x62, 0x0f, 0x78, 0x8a, 0xfa,
+		0xc5, 0x07, 0x33, 0x16, 0xe1, 0x29, 0xca, 0x97, 0x40, 0x08, 0x21, 0x2c, 0x45, 0xb8, 0xf3, 0xc1,
+		0xce, 0xda, 0xe9, 0x97, 0xdd, 0xdd, 0xaf, 0x76, 0x1f, 0xb0, 0xfd, 0x78, 0xff, 0x41, 0xdb, 0x2f,
+		0xfe, 0x8c, 0xe8, 0x8a, 0x3d, 0x1e, 0x3c, 0x62, 0xe7, 0x31, 0x74, 0xe9, 0xe3, 0xee, 0xe1, 0xd7,
+		0x77, 0x22, 0x89, 0x96, 0xf4, 0xe8, 0xad, 0xb9, 0x2a, 0x74, 0xf3, 0x8f, 0x88, 0x52, 0x6d, 0x70,
+		0x98, 0x3f, 0xa5, 0x7b, 0x5c, 0xf0, 0x7f, 0x24, 0xbe, 0x89, 0x1e, 0x92, 0x35, 0xe5, 0x12, 0x4f,
+		0x91, 0x8c, 0x12, 0x5a, 0x21, 0xf8, 0x4c, 0x2a, 0x53, 0xea, 0x40, 0xea, 0x69, 0xe7, 0x93, 0xa7,
+		0xbd, 0x77, 0xdd, 0x37, 0xee, 0xf6, 0x8b, 0x05, 0xe3, 0xd5, 0xaf, 0x51, 0x5a, 0x21, 0x15, 0xaa,
+		0xd0, 0xab, 0x30, 0x7a, 0xbf, 0xe2, 0xeb, 0x2a, 0xe3, 0x43, 0x9c, 0x00, 0x74, 0x06, 0xd4, 0x29,
+		0xca, 0xce, 0x51, 0x76, 0x12, 0xdc, 0x59, 0xc7, 0x9d, 0x56, 0xe1, 0xbc, 0xf2, 0xc2, 0x1f, 0x37,
+		0x21, 0x85, 0x59, 0x6a, 0xc1, 0x38, 0x89, 0x36, 0x75, 0xc6, 0x2a, 0xfc, 0x76, 0xdd, 0x81, 0xa9,
+		0x75, 0x44, 0x25, 0xbc, 0x08, 0xc4, 0x22, 0x06, 0x80, 0x27, 0x13, 0x73, 0xf0, 0xf9, 0x8d, 0xe0,
+		0x53, 0xa1, 0xc0, 0x3b, 0x16, 0xcb, 0x1b, 0x29, 0xa3, 0x7a, 0x25, 0xfe, 0xcf, 0xf8, 0x3c, 0xa0,
+		0xe9, 0xfb, 0xc7, 0xd5, 0x8e, 0xcf, 0x25, 0xc9, 0xe3, 0x33, 0xc9, 0xc1, 0x95, 0xe7, 0x8d, 0x27,
+		0x9e, 0xd7, 0x9f, 0x5c, 0x4e, 0xfa, 0xd7, 0xa3, 0xd1, 0x60, 0x3c, 0x18, 0xd5, 0xdc, 0xfc, 0x3e,
+		0xf2, 0x69, 0x44, 0xfd, 0xdb, 0x0d, 0x9e, 0x22, 0x9e, 0x04, 0x01, 0x44, 0xf4, 0x53, 0x4c, 0x53,
+		0xe5, 0x57, 0x24, 0x88, 0xa9, 0x41, 0xe0, 0x2c, 0xbf, 0xb2, 0xc0, 0x6f, 0x0e, 0x9c, 0x5c, 0xac,
+		0x3e, 0x70, 0x06, 0x2e, 0x70, 0xda, 0x0f, 0x9c, 0xaa, 0xcd, 0xb3, 0xbc, 0xf0, 0x77, 0x12, 0x24,
+		0x00, 0x03, 0x94, 0xf6, 0xcc, 0xc5, 0x1b, 0xde, 0xa5, 0x3e, 0x33, 0x82, 0x1d, 0xad, 0xe2, 0x70,
+		0x45, 0xc7, 0xab, 0x02, 0x40, 0x1b, 0x08, 0xda, 0x80, 0x50, 0x07, 0x46, 0x3d, 0x40, 0x1a, 0x80,
+		0x02, 0xcf, 0xb4, 0x07, 0x96, 0x8e, 0x65, 0xc4, 0xf8, 0x03, 0xc4, 0xd8, 0x65, 0xe0, 0x5f, 0x75,
+		0xf4, 0xf4, 0x57, 0x83, 0xfe, 0x0d, 0xe7, 0x42, 0x12, 0xc9, 0x04, 0xaf, 0x8f, 0x80, 0x78, 0xf9,
+		0x95, 0xae, 0x49, 0x48, 0xe4, 0xd7, 0xf4, 0x6d, 0x7a, 0xf4, 0xef, 0x84, 0x04, 0x17, 0x4b, 0x11,
+		0x6e, 0x2e, 0x7c, 0xb6, 0x5a, 0xf5, 0xa4, 0x08, 0x7b, 0x75, 0xe9, 0x2c, 0x5f, 0x43, 0x46, 0xc9,
+		0x52, 0xf2, 0xc2, 0x22, 0xf3, 0x74, 0x89, 0x57, 0x22, 0xdc, 0xcc, 0xd8, 0x6a, 0xf5, 0xe5, 0xa3,
+		0x08, 0xbf, 0xbc, 0xca, 0x16, 0x30, 0xc9, 0xbb, 0x22, 0x10, 0x11, 0x20, 0xef, 0x66, 0x62, 0x8e,
+		0xb0, 0x9c, 0x11, 0x61, 0xa1, 0x3c, 0x59, 0xd3, 0x28, 0xc7, 0x69, 0x33, 0x6b, 0x19, 0x78, 0x35,
+		0x32, 0x73, 0x9e, 0xac, 0x9b, 0x6d, 0xfa, 0x51, 0xdc, 0xe5, 0x11, 0x0b, 0x8a, 0xf2, 0x7e, 0xaa,
+		0xe3, 0x87, 0xf9, 0x0c, 0x12, 0xdf, 0x83, 0x54, 0xf6, 0xf6, 0xdd, 0xa7, 0x39, 0x36, 0x4b, 0x41,
+		0xe2, 0x0d, 0x97, 0x30, 0xed, 0xb2, 0x87, 0x55, 0x52, 0x89, 0xe7, 0x57, 0xf6, 0x0e, 0x53, 0xd4,
+		0xb7, 0x9b, 0x7a, 0x40, 0xb1, 0xeb, 0x13, 0x49, 0x9a, 0x43, 0x37, 0x93, 0xaa, 0x8f, 0xdc, 0xa1,
+		0x8b, 0xdc, 0xf6, 0x23, 0xd7, 0xe2, 0xb6, 0x91, 0xb9, 0xd4, 0x00, 0x38, 0x94, 0xcb, 0x68, 0xd3,
+		0x8c, 0x9c, 0x5c, 0xcc, 0x91, 0xed, 0xd3, 0x27, 0xdb, 0x05, 0x41, 0x00, 0x72, 0xed, 0x4c, 0xda,
+		0x51, 0x6d, 0x47, 0xb5, 0x7f, 0x06, 0xd5, 0xee, 0xba, 0x92, 0xd1, 0xe1, 0xb8, 0x2d, 0x1c, 0x27,
+		0x8c, 0xcb, 0x2b, 0x05, 0x18, 0x8f, 0x20, 0xfc, 0x8e, 0xf0, 0x87, 0x74, 0xf1, 0xbf, 0x1a, 0x45,
+		0x11, 0x42, 0x40, 0xdf, 0xa1, 0xa2, 0xa9, 0x87, 0xa7, 0x0a, 0x37, 0x20, 0x84, 0x10, 0xfe, 0x5c,
+		0xc4, 0x48, 0xbf, 0xab, 0x76, 0xdf, 0xeb, 0x88, 0x2c, 0x53, 0xee, 0x31, 0x63, 0x0f, 0xac, 0xa9,
+		0x89, 0x78, 0xdc, 0xc4, 0xf4, 0x81, 0x48, 0xf6, 0x9d, 0xd6, 0xf6, 0xfa, 0x34, 0xbc, 0xbc, 0x6b,
+		0x12, 0xf2, 0xa8, 0x6f, 0x92, 0xe1, 0x68, 0x74, 0x3e, 0x46, 0xe9, 0xd8, 0x91, 0xba, 0x7f, 0x91,
+		0x8e, 0xc7, 0x5b, 0xba, 0x69, 0xe0, 0x0d, 0xbf, 0x43, 0x1f, 0xbb, 0x7d, 0x5e, 0x5f, 0x47, 0xb8,
+		0x11, 0xa4, 0x1d, 0x34, 0xcf, 0x16, 0x30, 0xa8, 0x0c, 0xbe, 0xe5, 0x79, 0xb1, 0xa1, 0x30, 0xc8,
+		0xa4, 0x5c, 0x33, 0xe8, 0x8c, 0x9a, 0x41, 0xcc, 0xa7, 0x5c, 0x32, 0xb9, 0x89, 0xe8, 0x0a, 0xd2,
+		0x0c, 0xaa, 0x0b, 0xae, 0x37, 0xc5, 0x52, 0xb7, 0x24, 0x56, 0x20, 0x6b, 0xb7, 0x37, 0x77, 0xf3,
+		0x26, 0xb3, 0x66, 0x89, 0x3c, 0x06, 0x6d, 0xb5, 0x40, 0x8a, 0x54, 0x3e, 0x7d, 0x36, 0xff, 0xf0,
+		0xe6, 0xf3, 0x7c, 0x66, 0x4a, 0x58, 0xee, 0x5b, 0x69, 0xe3, 0xa4, 0xd1, 0x14, 0xc3, 0x82, 0xce,
+		0xcd, 0x8c, 0x5d, 0xd4, 0xb9, 0xa8, 0xd3, 0xf4, 0x9b, 0x1b, 0xb5, 0x23, 0x84, 0x10, 0xc2, 0x6b,
+		0xf6, 0x48, 0x01, 0x9b, 0x7c, 0x2e, 0xe6, 0xf2, 0xcd, 0x19, 0xe5, 0x9b, 0x84, 0x03, 0x87, 0x3d,
+		0xd7, 0x35, 0x32, 0xc5, 0xe3, 0xea, 0xb3, 0xc1, 0xcf, 0xec, 0x2d, 0x75, 0x6d, 0x69, 0xc6, 0xb8,
+		0xbc, 0x1c, 0x2a, 0x28, 0x76, 0x79, 0xb6, 0xdd, 0x82, 0xe1, 0xc0, 0x9b, 0x78, 0x57, 0x97, 0x63,
+		0xef, 0xea, 0x27, 0x56, 0xc8, 0x69, 0x04, 0x9d, 0x60, 0xd7, 0xa0, 0x34, 0xcd, 0xc4, 0x35, 0x0f,
+		0x80, 0xf7, 0xdf, 0x1b, 0x6c, 0x3e, 0x69, 0xed, 0x0a, 0x20, 0xbb, 0xb9, 0x98, 0xdb, 0x7c, 0xce,
+		0x68, 0xf3, 0x69, 0xcc, 0xf3, 0x0d, 0xf9, 0xdd, 0xd1, 0xb6, 0xfa, 0xc8, 0x11, 0x80, 0x43, 0xe9,
+		0xc2, 0x9d, 0x49, 0x3f, 0xaf, 0x33, 0x3a, 0xeb, 0x50, 0x42, 0xce, 0x14, 0x0f, 0x2e, 0x0d, 0x80,
+		0x13, 0x93, 0x75, 0x18, 0xd0, 0x66, 0xf0, 0x14, 0x72, 0x86, 0xf3, 0xfe, 0xa1, 0x03, 0x50, 0xfb,
+		0xf3, 0x7e, 0x37, 0x29, 0x75, 0x93, 0x52, 0xa5, 0x8c, 0x83, 0x90, 0xab, 0x7d, 0x5c, 0xed, 0x83,
+		0x90, 0xab, 0x7d, 0x10, 0x42, 0xe8, 0xc5, 0x06, 0xa7, 0x6e, 0x2a, 0x6a, 0x61, 0x2a, 0x5a, 0xcb,
+		0x4b, 0x10, 0x64, 0x2c, 0x7a, 0x97, 0xaf, 0x60, 0xc2, 0xa1, 0x24, 0x0d, 0x01, 0x0c, 0x2a, 0x95,
+		0x72, 0xe7, 0x25, 0x4f, 0x9f, 0x3f, 0x31, 0x1f, 0x4e, 0x9e, 0x98, 0xef, 0x98, 0x93, 0x63, 0x4e,
+		0xbb, 0x67, 0xcc, 0x94, 0xa8, 0xd3, 0xc4, 0x1d, 0x32, 0x6b, 0x9f, 0x16, 0xbc, 0x14, 0x65, 0xf2,
+		0x86, 0xd7, 0xde, 0xf5, 0x78, 0x32, 0xbc, 0x76, 0x67, 0xcd, 0xa0, 0xf7, 0x6b, 0x9d, 0x35, 0xab,
+		0xcc, 0xba, 0xa7, 0xcb, 0xa9, 0x80, 0xfb, 0x49, 0x92, 0x52, 0xaa, 0x6e, 0xc7, 0x56, 0x9a, 0x7e,
+		0x9e, 0xa2, 0x45, 0xae, 0xcd, 0xc5, 0x62, 0x03, 0x49, 0x4f, 0x3a, 0x29, 0x7a, 0x27, 0x3d, 0x67,
+		0x6f, 0xd2, 0xc2, 0x39, 0xf0, 0x7d, 0xf6, 0x59, 0x59, 0x3c, 0x55, 0x61, 0x88, 0x3e, 0xca, 0x88,
+		0x5c, 0x24, 0x3c, 0x96, 0x64, 0x11, 0xd4, 0x9b, 0xf1, 0xb9, 0xcd, 0x2c, 0x0e, 0x3d, 0x01, 0x4e,
+		0x46, 0x86, 0xfb, 0xb1, 0x92, 0xb3, 0x91, 0xb5, 0x3d, 0xb9, 0xd9, 0xe9, 0xcd, 0xd9, 0x44, 0x79,
+		0xb4, 0xf4, 0x02, 0x45, 0x46, 0x35, 0x75, 0x47, 0xa0, 0x12, 0x23, 0xbd, 0xdf, 0xa8, 0xc0, 0x88,
+		0x20, 0xf5, 0x85, 0xfb, 0x1b, 0xdc, 0xdf, 0x6b, 0x26, 0x06, 0xc2, 0x8e, 0x8c, 0x08, 0x8f, 0x43,
+		0x11, 0xc9, 0x66, 0x04, 0xfd, 0x10, 0xad, 0xc7, 0xd1, 0xc8, 0xe1, 0xe8, 0x04, 0xca, 0x54, 0xb9,
+		0x0c, 0xe1, 0xbc, 0x22, 0x15, 0x86, 0x15, 0xaa, 0x9e, 0x2b, 0x54, 0xf5, 0xc1, 0xa0, 0x0e, 0x8a,
+		0xe6, 0x5d, 0x0f, 0x01, 0x0a, 0xd5, 0x26, 0xb0, 0x94, 0x17, 0x16, 0x61, 0xba, 0x21, 0xc6, 0x70,
+		0xd3, 0x6d, 0x87, 0xc7, 0xc5, 0x8d, 0xc0, 0xf7, 0xaf, 0x6f, 0x68, 0x69, 0x83, 0x4a, 0x07, 0x5c,
+		0x9a, 0x20, 0xd3, 0x05, 0x9b, 0x31, 0xe8, 0x8c, 0xc1, 0xa7, 0x0f, 0x42, 0x18, 0x18, 0x81, 0xa0,
+		0x54, 0x06, 0x67, 0x79, 0x61, 0x2e, 0x7c, 0x1a, 0x90, 0x8d, 0xba, 0xc9, 0xb7, 0x07, 0x84, 0x8a,
+		0x05, 0x14, 0xed, 0x05, 0x6b, 0xd5, 0x19, 0x83, 0xd7, 0x04, 0xc4, 0x86, 0x60, 0x36, 0x05, 0xb5,
+		0x35, 0x70, 0x5b, 0x03, 0xb9, 0x39, 0xd8, 0xd5, 0x40, 0xaf, 0x08, 0x7e, 0x38, 0x2d, 0x6c, 0xf4,
+		0xf4, 0x42, 0x88, 0x80, 0x12, 0xae, 0xe3, 0xed, 0x32, 0x1b, 0x0f, 0x3a, 0xed, 0x18, 0xc4, 0x6e,
+		0xbe, 0x00, 0x16, 0x6e, 0x3a, 0x85, 0xdc, 0x96, 0xe1, 0xf6, 0xe4, 0x32, 0xec, 0xa9, 0xed, 0x69,
+		0x08, 0x52, 0xe9, 0xbd, 0x2f, 0x96, 0xb4, 0xd4, 0x55, 0x03, 0x98, 0x0c, 0xd7, 0x72, 0xfb, 0x4a,
+		0x34, 0xd5, 0xd0, 0x7c, 0xc3, 0xbc, 0xe8, 0x36, 0xf3, 0x36, 0xf2, 0xdc, 0xa9, 0x6c, 0xe6, 0xca,
+		0x79, 0x6c, 0x67, 0x34, 0x32, 0x18, 0xab, 0x38, 0xab, 0xc0, 0xdd, 0x58, 0xe1, 0x16, 0xb5, 0x51,
+		0x49, 0x79, 0x69, 0x64, 0x65, 0x9d, 0xd1, 0xc9, 0xc1, 0xbc, 0xa0, 0xaf, 0xb9, 0xd7, 0x99, 0x8e,
+		0x0b, 0xcc, 0xc7, 0x06, 0x8a, 0xb0, 0x31, 0x1e, 0xb1, 0x1c, 0x98, 0x6e, 0x3c, 0x1a, 0x5d, 0x8e,
+		0xce, 0xdf, 0x7c, 0x2d, 0x6d, 0xc8, 0xf7, 0xb6, 0xf6, 0x1f, 0xa3, 0xaa, 0x54, 0x71, 0x23, 0x57,
+		0xde, 0xc0, 0xdb, 0x18, 0x2f, 0x24, 0xbe, 0x42, 0x57, 0x25, 0x15, 0x76, 0x5d, 0x15, 0xd7, 0x55,
+		0x59, 0x0b, 0x9f, 0xaa, 0x53, 0xb0, 0xec, 0x2e, 0x35, 0x0a, 0x36, 0x72, 0x14, 0xcc, 0x51, 0x30,
+		0xe5, 0x7e, 0xca, 0x3a, 0x09, 0x24, 0x5b, 0x92, 0x58, 0xea, 0x77, 0x54, 0x7e, 0x2c, 0xa1, 0xd7,
+		0x53, 0xf1, 0x5c, 0x4f, 0xa5, 0x75, 0x80, 0x5b, 0x03, 0xba, 0x39, 0xe0, 0xd5, 0x80, 0xaf, 0x18,
+		0x00, 0xda, 0x81, 0x60, 0x21, 0x20, 0xac, 0x05, 0xc6, 0x7e, 0x80, 0xe8, 0x52, 0x50, 0xdd, 0x40,
+		0xb1, 0x11, 0x30, 0x96, 0x02, 0xc7, 0x56, 0x00, 0x59, 0x0f, 0x24, 0xeb, 0x01, 0x65, 0x2f, 0xb0,
+		0xf4, 0x02, 0x4c, 0x33, 0xd0, 0xf4, 0x8b, 0xff, 0x6a, 0xa4, 0x34, 0xfc, 0x6d, 0x1b, 0x34, 0x6c,
+		0x2a, 0xfe, 0xf6, 0xcd, 0xbe, 0xd9, 0xda, 0xcd, 0x64, 0x9a, 0x2d, 0x4f, 0xbd, 0xca, 0x29, 0xf1,
+		0xc3, 0x5e, 0xca, 0x3d, 0x7b, 0x3f, 0x72, 0x57, 0x5b, 0x7d, 0x61, 0x05, 0x5a, 0x98, 0x70, 0x43,
+		0x8a, 0x52, 0x2e, 0xe0, 0x08, 0x4a, 0x4b, 0xf9, 0xd5, 0x11, 0x94, 0x17, 0x23, 0x28, 0xba, 0xc1,
+		0x60, 0x29, 0x28, 0xf6, 0x83, 0xc3, 0x91, 0x13, 0x47, 0x4e, 0x34, 0xae, 0x73, 0x25, 0x27, 0xe0,
+		0xff, 0x94, 0x48, 0xf3, 0xe0, 0x9e, 0x7d, 0xbb, 0xfd, 0x8a, 0xec, 0xa4, 0x4c, 0x5d, 0x6e, 0x66,
+		0xbd, 0x6f, 0x1a, 0xfc, 0xeb, 0x77, 0xf7, 0xd3, 0xce, 0xfa, 0xb9, 0xfd, 0x5e, 0xd3, 0x56, 0x7b,
+		0xf0, 0x51, 0xef, 0xda, 0x5f, 0x20, 0x6d, 0xd0, 0x0c, 0xa0, 0x11, 0xee, 0x76, 0x54, 0x0f, 0x30,
+		0xe0, 0xce, 0x71, 0x05, 0x9f, 0x3a, 0xcf, 0x54, 0xac, 0x52, 0x0d, 0xb3, 0xf8, 0x35, 0xf9, 0x46,
+		0x3f, 0x08, 0x71, 0xb8, 0x13, 0xed, 0xab, 0x8b, 0xbb, 0x9d, 0x0a, 0x9d, 0x66, 0xf9, 0x8f, 0xe0,
+		0xe6, 0x0f, 0xec, 0x3c, 0xfd, 0x07, 0x00, 0x00, 0xff, 0xff, 0x03, 0x00, 0x00, 0xc9, 0xf2, 0x00,
+		0x23, 0x77, 0x00, 0x00,
+	}
+)
+
+// ΛEnumTypes is a map, keyed by a YANG schema path, of the enumerated types that
+// correspond with the leaf. The type is represented as a reflect.Type. The naming
+// of the map ensures that there are no clashes with valid YANG identifiers.
+func initΛEnumTypes() {
+	ΛEnumTypes = map[string][]reflect.Type{
+		"/top/color": {
+			reflect.TypeOf((E_EqualCopyDiff_Top_Color)(0)),
+		},
+		"/top/kind": {
+			reflect.TypeOf((E_EqualCopyDiff_BASE)(0)),
+		},
+		"/top/kinds": {
+			reflect.TypeOf((E_EqualCopyDiff_BASE)(0)),
+		},
+	}
+}
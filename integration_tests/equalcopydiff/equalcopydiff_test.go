@@ -0,0 +1,263 @@
+// Copyright 2026 Google Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package equalcopydiff
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/openconfig/gnmi/errdiff"
+	"github.com/openconfig/ygot/integration_tests/equalcopydiff/plain"
+	"github.com/openconfig/ygot/testutil"
+	"github.com/openconfig/ygot/ygot"
+
+	gnmipb "github.com/openconfig/gnmi/proto/gnmi"
+)
+
+// The generated structs must implement the interfaces through which
+// ygot.DeepCopy and ygot.Diff call the generated methods, whereas the structs
+// of the plain package must not.
+var (
+	_ ygot.GoStructCopier = (*Device)(nil)
+	_ ygot.GoStructDiffer = (*Device)(nil)
+	_ ygot.GoStructCopier = (*EqualCopyDiff_Top)(nil)
+	_ ygot.GoStructDiffer = (*EqualCopyDiff_Top)(nil)
+)
+
+// populatedDevice returns a Device in which maps, unions, an ordered list,
+// leaf-lists and choices are set.
+func populatedDevice(t *testing.T) *Device {
+	t.Helper()
+	d := &Device{}
+	top := d.GetOrCreateTop()
+	top.Str = ygot.String("a")
+	top.Bin = Binary("bin")
+	top.On = true
+	top.Color = EqualCopyDiff_Top_Color_RED
+	top.Kind = EqualCopyDiff_BASE_DERIVED
+	top.Mixed = UnionString("mixed")
+	top.Names = []string{"one", "two"}
+	top.Blobs = []Binary{Binary("b1"), Binary("b2")}
+	top.Kinds = []E_EqualCopyDiff_BASE{EqualCopyDiff_BASE_DERIVED}
+	top.GetOrCreateChild().Value = ygot.String("child")
+	top.GetOrCreateEntry("e1").Value = ygot.Uint8(1)
+	top.GetOrCreateEntry("e2").Value = ygot.Uint8(2)
+	for _, id := range []uint32{3, 1, 2} {
+		if _, err := top.AppendNewStep(id); err != nil {
+			t.Fatalf("AppendNewStep(%d): %v", id, err)
+		}
+	}
+	top.Sample = []*EqualCopyDiff_Top_Sample{{Value: ygot.Int32(-1)}, {Value: ygot.Int32(1)}}
+	top.Transport = &EqualCopyDiff_Top_Transport_Choice_Tcp{
+		Port:    ygot.Uint16(80),
+		Options: &EqualCopyDiff_Top_Options{Nodelay: ygot.Bool(true)},
+	}
+	return d
+}
+
+// mustCopy returns a copy of d made using the generated Copy method.
+func mustCopy(t *testing.T, d *Device) *Device {
+	t.Helper()
+	c, err := d.Copy()
+	if err != nil {
+		t.Fatalf("Copy(): %v", err)
+	}
+	return c
+}
+
+// mutations are the changes that are made to the populated device to check
+// Equal and Diff.
+var mutations = []struct {
+	desc string
+	in   func(*testing.T, *Device)
+}{{
+	desc: "leaf changed",
+	in:   func(_ *testing.T, d *Device) { d.Top.Str = ygot.String("b") },
+}, {
+	desc: "binary changed",
+	in:   func(_ *testing.T, d *Device) { d.Top.Bin = Binary("other") },
+}, {
+	desc: "empty leaf unset",
+	in:   func(_ *testing.T, d *Device) { d.Top.On = false },
+}, {
+	desc: "enumeration changed",
+	in:   func(_ *testing.T, d *Device) { d.Top.Color = EqualCopyDiff_Top_Color_BLUE },
+}, {
+	desc: "identityref unset",
+	in:   func(_ *testing.T, d *Device) { d.Top.Kind = EqualCopyDiff_BASE_UNSET },
+}, {
+	desc: "union changed to another type",
+	in:   func(_ *testing.T, d *Device) { d.Top.Mixed = UnionInt32(42) },
+}, {
+	desc: "union unset",
+	in:   func(_ *testing.T, d *Device) { d.Top.Mixed = nil },
+}, {
+	desc: "leaf-list reordered",
+	in:   func(_ *testing.T, d *Device) { d.Top.Names = []string{"two", "one"} },
+}, {
+	desc: "binary leaf-list element changed",
+	in:   func(_ *testing.T, d *Device) { d.Top.Blobs[1] = Binary("b3") },
+}, {
+	desc: "container leaf changed",
+	in:   func(_ *testing.T, d *Device) { d.Top.Child.Value = ygot.String("other") },
+}, {
+	desc: "map entry modified",
+	in:   func(_ *testing.T, d *Device) { d.Top.Entry["e1"].Value = ygot.Uint8(10) },
+}, {
+	desc: "map entry removed and added",
+	in: func(_ *testing.T, d *Device) {
+		delete(d.Top.Entry, "e2")
+		d.Top.GetOrCreateEntry("e3").Value = ygot.Uint8(3)
+	},
+}, {
+	desc: "ordered list reordered",
+	in: func(t *testing.T, d *Device) {
+		d.Top.DeleteStep(3)
+		if _, err := d.Top.AppendNewStep(3); err != nil {
+			t.Fatalf("AppendNewStep(3): %v", err)
+		}
+	},
+}, {
+	desc: "ordered list entry removed",
+	in:   func(_ *testing.T, d *Device) { d.Top.DeleteStep(1) },
+}, {
+	desc: "choice case changed",
+	in: func(_ *testing.T, d *Device) {
+		d.Top.Transport = &EqualCopyDiff_Top_Transport_Choice_Udp{
+			Mode: &EqualCopyDiff_Top_Mode_Choice_Unicast{Unicast: ygot.String("u")},
+		}
+	},
+}, {
+	desc: "leaf within choice case changed",
+	in: func(_ *testing.T, d *Device) {
+		d.Top.Transport.(*EqualCopyDiff_Top_Transport_Choice_Tcp).Options.Nodelay = ygot.Bool(false)
+	},
+}}
+
+// TestCopy checks that the generated Copy method, which ygot.DeepCopy calls,
+// returns the same struct as the reflection based copy made by
+// ygot.MergeStructInto, and that the copy shares no state with the original.
+func TestCopy(t *testing.T) {
+	d := populatedDevice(t)
+	opt := cmp.AllowUnexported(EqualCopyDiff_Top_Step_OrderedMap{})
+
+	want := &Device{}
+	if err := ygot.MergeStructInto(want, d); err != nil {
+		t.Fatalf("MergeStructInto(): %v", err)
+	}
+	got, err := ygot.DeepCopy(d)
+	if err != nil {
+		t.Fatalf("DeepCopy(): %v", err)
+	}
+	if diff := cmp.Diff(want, got, opt); diff != "" {
+		t.Errorf("DeepCopy(): generated methods did not produce the same struct as reflection, diff(-reflection, +methods):\n%s", diff)
+	}
+	if diff := cmp.Diff(d, got, opt); diff != "" {
+		t.Errorf("DeepCopy(): did not get a copy of the input, diff(-want, +got):\n%s", diff)
+	}
+
+	for _, m := range mutations {
+		t.Run(m.desc, func(t *testing.T) {
+			c := mustCopy(t, d)
+			m.in(t, c)
+			if diff := cmp.Diff(want, d, opt); diff != "" {
+				t.Errorf("mutating the copy changed the original, diff(-want, +got):\n%s", diff)
+			}
+		})
+	}
+}
+
+// TestEqual checks that the generated Equal method agrees with
+// reflect.DeepEqual for both equal and differing structs.
+func TestEqual(t *testing.T) {
+	d := populatedDevice(t)
+	if c := mustCopy(t, d); !d.Equal(c) {
+		t.Errorf("Equal(): got false for a copy of the struct, want true")
+	}
+	for _, m := range mutations {
+		t.Run(m.desc, func(t *testing.T) {
+			c := mustCopy(t, d)
+			m.in(t, c)
+			if got, want := d.Equal(c), reflect.DeepEqual(d, c); got != want {
+				t.Errorf("Equal(): got %v, reflect.DeepEqual: %v", got, want)
+			}
+			if got, want := c.Equal(d), d.Equal(c); got != want {
+				t.Errorf("Equal(): not symmetric, got %v, reverse: %v", got, want)
+			}
+		})
+	}
+}
+
+// toPlain returns the struct of the plain package, which does not have the
+// generated methods, that holds the same data as d.
+func toPlain(t *testing.T, d *Device) *plain.Device {
+	t.Helper()
+	js, err := ygot.Marshal7951(d)
+	if err != nil {
+		t.Fatalf("Marshal7951(): %v", err)
+	}
+	p := &plain.Device{}
+	if err := plain.Unmarshal(js, p); err != nil {
+		t.Fatalf("plain.Unmarshal(): %v", err)
+	}
+	return p
+}
+
+// TestDiff checks that the generated Diff methods, which ygot.Diff and
+// ygot.DiffWithAtomic call, return the same notifications as the reflection
+// based diff of the same data held in structs without the methods.
+func TestDiff(t *testing.T) {
+	d := populatedDevice(t)
+	// The state list cannot be unmarshalled into the plain structs since it
+	// has no keys, and is hence compared only when it is the same in both.
+	d.Top.Sample = nil
+	pd := toPlain(t, d)
+
+	diffFuncs := []struct {
+		desc string
+		in   func(a, b ygot.GoStruct) ([]*gnmipb.Notification, error)
+	}{{
+		desc: "Diff",
+		in: func(a, b ygot.GoStruct) ([]*gnmipb.Notification, error) {
+			n, err := ygot.Diff(a, b)
+			return []*gnmipb.Notification{n}, err
+		},
+	}, {
+		desc: "DiffWithAtomic",
+		in: func(a, b ygot.GoStruct) ([]*gnmipb.Notification, error) {
+			return ygot.DiffWithAtomic(a, b)
+		},
+	}}
+
+	for _, m := range mutations {
+		for _, f := range diffFuncs {
+			t.Run(f.desc+" "+m.desc, func(t *testing.T) {
+				c := mustCopy(t, d)
+				m.in(t, c)
+				pc := toPlain(t, c)
+
+				want, wantErr := f.in(pd, pc)
+				got, err := f.in(d, c)
+				if diff := errdiff.Check(err, wantErr); diff != "" {
+					t.Fatalf("%s(): generated methods did not return the same error as reflection: %s", f.desc, diff)
+				}
+				if !testutil.NotificationSetEqual(got, want) {
+					t.Errorf("%s(): generated methods did not return the same notifications as reflection, diff(-reflection, +methods):\n%s", f.desc, cmp.Diff(want, got, testutil.NotificationComparer()))
+				}
+			})
+		}
+	}
+}
@@ -0,0 +1,3 @@
+package equalcopydiff
+
+//go:generate ./update.sh
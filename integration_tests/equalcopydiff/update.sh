@@ -0,0 +1,22 @@
+#!/bin/bash
+
+# The structs in the plain package are generated from the same module without
+# the Equal, Copy and Diff methods, such that the results of the generated
+# methods can be compared with those of the reflection-based functions.
+for pkg in equalcopydiff plain; do
+  out=equalcopydiff.go
+  extra=-generate_equal_copy_diff
+  if [ "${pkg}" = "plain" ]; then
+    out=plain/plain.go
+    extra=""
+  fi
+  go run ../../generator/generator.go -path="." -output_file="${out}" \
+    -package_name="${pkg}" -generate_fakeroot -fakeroot_name=device \
+    -generate_simple_unions \
+    -generate_choice_sum_types \
+    -generate_getters \
+    -generate_append \
+    ${extra} \
+    ../../testdata/modules/equal-copy-diff.yang
+  gofmt -w -s "${out}"
+done
@@ -0,0 +1,3 @@
+package nameoverrides
+
+//go:generate ./update.sh
@@ -0,0 +1,1580 @@
+/*
+Package nameoverrides is a generated package which contains definitions
+of structs which represent a YANG schema. The generated schema can be
+compressed by a series of transformations (compression was true
+in this case).
+
+This package was generated by /root/module/genutil/names.go
+using the following YANG input files:
+  - ../../testdata/modules/name-overrides.yang
+
+Imported modules were sourced from:
+  - ...
+*/
+package nameoverrides
+
+import (
+	"encoding/json"
+	"fmt"
+	"reflect"
+
+	"github.com/openconfig/goyang/pkg/yang"
+	"github.com/openconfig/ygot/ygot"
+	"github.com/openconfig/ygot/ytypes"
+)
+
+// Binary is a type that is used for fields that have a YANG type of
+// binary. It is used such that binary fields can be distinguished from
+// leaf-lists of uint8s (which are mapped to []uint8, equivalent to
+// []byte in reflection).
+type Binary []byte
+
+// YANGEmpty is a type that is used for fields that have a YANG type of
+// empty. It is used such that empty fields can be distinguished from boolean fields
+// in the generated code.
+type YANGEmpty bool
+
+var (
+	SchemaTree map[string]*yang.Entry
+	ΛEnumTypes map[string][]reflect.Type
+)
+
+func init() {
+	var err error
+	initΛEnumTypes()
+	if SchemaTree, err = UnzipSchema(); err != nil {
+		panic("schema error: " + err.Error())
+	}
+}
+
+// Schema returns the details of the generated schema.
+func Schema() (*ytypes.Schema, error) {
+	uzp, err := UnzipSchema()
+	if err != nil {
+		return nil, fmt.Errorf("cannot unzip schema, %v", err)
+	}
+
+	return &ytypes.Schema{
+		Root:       &Device{},
+		SchemaTree: uzp,
+		Unmarshal:  Unmarshal,
+	}, nil
+}
+
+// UnzipSchema unzips the zipped schema and returns a map of yang.Entry nodes,
+// keyed by the name of the struct that the yang.Entry describes the schema for.
+func UnzipSchema() (map[string]*yang.Entry, error) {
+	var schemaTree map[string]*yang.Entry
+	var err error
+	if schemaTree, err = ygot.GzipToSchema(ySchema); err != nil {
+		return nil, fmt.Errorf("could not unzip the schema; %v", err)
+	}
+	return schemaTree, nil
+}
+
+// Unmarshal unmarshals data, which must be RFC7951 JSON format, into
+// destStruct, which must be non-nil and the correct GoStruct type. It returns
+// an error if the destStruct is not found in the schema or the data cannot be
+// unmarshaled. The supplied options (opts) are used to control the behaviour
+// of the unmarshal function - for example, determining whether errors are
+// thrown for unknown fields in the input JSON.
+func Unmarshal(data []byte, destStruct ygot.GoStruct, opts ...ytypes.UnmarshalOpt) error {
+	tn := reflect.TypeOf(destStruct).Elem().Name()
+	schema, ok := SchemaTree[tn]
+	if !ok {
+		return fmt.Errorf("could not find schema for type %s", tn)
+	}
+	var jsonTree interface{}
+	if err := json.Unmarshal([]byte(data), &jsonTree); err != nil {
+		return err
+	}
+	return ytypes.Unmarshal(schema, destStruct, jsonTree, opts...)
+}
+
+// Device represents the /device YANG schema element.
+type Device struct {
+	Interface map[string]*Intf `path:"interfaces/interface" module:"name-overrides/name-overrides"`
+	Protocols *Protocols       `path:"protocols" module:"name-overrides"`
+}
+
+// IsYANGGoStruct ensures that Device implements the yang.GoStruct
+// interface. This allows functions that need to handle this struct to
+// identify it as being generated by ygen.
+func (*Device) IsYANGGoStruct() {}
+
+// NewInterface creates a new entry in the Interface list of the
+// Device struct. The keys of the list are populated from the input
+// arguments.
+func (t *Device) NewInterface(Name string) (*Intf, error) {
+
+	// Initialise the list within the receiver struct if it has not already been
+	// created.
+	if t.Interface == nil {
+		t.Interface = make(map[string]*Intf)
+	}
+
+	key := Name
+
+	// Ensure that this key has not already been used in the
+	// list. Keyed YANG lists do not allow duplicate keys to
+	// be created.
+	if _, ok := t.Interface[key]; ok {
+		return nil, fmt.Errorf("duplicate key %v for list Interface", key)
+	}
+
+	t.Interface[key] = &Intf{
+		Name: &Name,
+	}
+
+	return t.Interface[key], nil
+}
+
+// RenameInterface renames an entry in the list Interface within
+// the Device struct. The entry with key oldK is renamed to newK updating
+// the key within the value.
+func (t *Device) RenameInterface(oldK, newK string) error {
+	if _, ok := t.Interface[newK]; ok {
+		return fmt.Errorf("key %v already exists in Interface", newK)
+	}
+
+	e, ok := t.Interface[oldK]
+	if !ok {
+		return fmt.Errorf("key %v not found in Interface", oldK)
+	}
+	e.Name = &newK
+
+	t.Interface[newK] = e
+	delete(t.Interface, oldK)
+	return nil
+}
+
+// GetOrCreateInterfaceMap returns the list (map) from Device.
+//
+// It initializes the field if not already initialized.
+func (t *Device) GetOrCreateInterfaceMap() map[string]*Intf {
+	if t.Interface == nil {
+		t.Interface = make(map[string]*Intf)
+	}
+	return t.Interface
+}
+
+// GetOrCreateInterface retrieves the value with the specified keys from
+// the receiver Device. If the entry does not exist, then it is created.
+// It returns the existing or new list member.
+func (t *Device) GetOrCreateInterface(Name string) *Intf {
+
+	key := Name
+
+	if v, ok := t.Interface[key]; ok {
+		return v
+	}
+	// Panic if we receive an error, since we should have retrieved an existing
+	// list member. This allows chaining of GetOrCreate methods.
+	v, err := t.NewInterface(Name)
+	if err != nil {
+		panic(fmt.Sprintf("GetOrCreateInterface got unexpected error: %v", err))
+	}
+	return v
+}
+
+// GetInterface retrieves the value with the specified key from
+// the Interface map field of Device. If the receiver is nil, or
+// the specified key is not present in the list, nil is returned such that Get*
+// methods may be safely chained.
+func (t *Device) GetInterface(Name string) *Intf {
+
+	if t == nil {
+		return nil
+	}
+
+	key := Name
+
+	if lm, ok := t.Interface[key]; ok {
+		return lm
+	}
+	return nil
+}
+
+// DeleteInterface deletes the value with the specified keys from
+// the receiver Device. If there is no such element, the function
+// is a no-op.
+func (t *Device) DeleteInterface(Name string) {
+	key := Name
+
+	delete(t.Interface, key)
+}
+
+// AppendInterface appends the supplied Intf struct to the
+// list Interface of Device. If the key value(s) specified in
+// the supplied Intf already exist in the list, an error is
+// returned.
+func (t *Device) AppendInterface(v *Intf) error {
+	if v.Name == nil {
+		return fmt.Errorf("invalid nil key received for Name")
+	}
+
+	key := *v.Name
+
+	// Initialise the list within the receiver struct if it has not already been
+	// created.
+	if t.Interface == nil {
+		t.Interface = make(map[string]*Intf)
+	}
+
+	if _, ok := t.Interface[key]; ok {
+		return fmt.Errorf("duplicate key for list Interface %v", key)
+	}
+
+	t.Interface[key] = v
+	return nil
+}
+
+// GetOrCreateProtocols retrieves the value of the Protocols field
+// or returns the existing field if it already exists.
+func (t *Device) GetOrCreateProtocols() *Protocols {
+	if t.Protocols != nil {
+		return t.Protocols
+	}
+	t.Protocols = &Protocols{}
+	return t.Protocols
+}
+
+// GetProtocols returns the value of the Protocols struct pointer
+// from Device. If the receiver or the field Protocols is nil, nil
+// is returned such that the Get* methods can be safely chained.
+func (t *Device) GetProtocols() *Protocols {
+	if t != nil && t.Protocols != nil {
+		return t.Protocols
+	}
+	return nil
+}
+
+// PopulateDefaults recursively populates unset leaf fields in the Device
+// with default values as specified in the YANG schema, instantiating any nil
+// container fields.
+func (t *Device) PopulateDefaults() {
+	if t == nil {
+		return
+	}
+	ygot.BuildEmptyTree(t)
+	t.Protocols.PopulateDefaults()
+	for _, e := range t.Interface {
+		e.PopulateDefaults()
+	}
+}
+
+// Validate validates s against the YANG schema corresponding to its type.
+func (t *Device) ΛValidate(opts ...ygot.ValidationOption) error {
+	if err := ytypes.Validate(SchemaTree["Device"], t, opts...); err != nil {
+		return err
+	}
+	return nil
+}
+
+// Validate validates s against the YANG schema corresponding to its type.
+func (t *Device) Validate(opts ...ygot.ValidationOption) error {
+	return t.ΛValidate(opts...)
+}
+
+// ΛEnumTypeMap returns a map, keyed by YANG schema path, of the enumerated types
+// that are included in the generated code.
+func (t *Device) ΛEnumTypeMap() map[string][]reflect.Type { return ΛEnumTypes }
+
+// MarshalRFC7951 writes the contents of Device to the supplied
+// encoder as RFC7951 JSON. It implements the ygot.RFC7951Marshaler interface.
+func (t *Device) MarshalRFC7951(e *ygot.RFC7951Encoder) error {
+	if t.Interface != nil {
+		l := make([]ygot.RFC7951ListElement, 0, len(t.Interface))
+		for k, v := range t.Interface {
+			l = append(l, ygot.RFC7951ListElement{Key: k, Value: v})
+		}
+		if err := e.List([][]string{{"interfaces", "interface"}}, [][]string{{"name-overrides", "name-overrides"}}, l); err != nil {
+			return err
+		}
+	}
+	if t.Protocols != nil {
+		if err := e.Struct([][]string{{"protocols"}}, [][]string{{"name-overrides"}}, t.Protocols, false); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// UnmarshalRFC7951 unmarshals the supplied RFC7951 JSON object into
+// Device. It implements the ytypes.RFC7951Unmarshaler interface.
+func (t *Device) UnmarshalRFC7951(d *ytypes.RFC7951Decoder, jsonTree map[string]interface{}) error {
+	if err := d.Field(t, "Interface", jsonTree); err != nil {
+		return err
+	}
+	if err := d.Field(t, "Protocols", jsonTree); err != nil {
+		return err
+	}
+	return nil
+}
+
+// Equal reports whether Device is equal to o. Nil values are only
+// equal to other nil values.
+func (t *Device) Equal(o *Device) bool {
+	if t == nil || o == nil {
+		return t == o
+	}
+	if !ygot.EqualMaps(t.Interface, o.Interface) {
+		return false
+	}
+	if !t.Protocols.Equal(o.Protocols) {
+		return false
+	}
+	return true
+}
+
+// Copy returns a deep copy of Device. As with ygot.DeepCopy, empty
+// maps and slices are not retained in the copy.
+func (t *Device) Copy() (*Device, error) {
+	if t == nil {
+		return nil, nil
+	}
+	n := &Device{}
+	var err error
+	if n.Interface, err = ygot.CopyMap(t.Interface); err != nil {
+		return nil, err
+	}
+	if n.Protocols, err = t.Protocols.Copy(); err != nil {
+		return nil, err
+	}
+	return n, nil
+}
+
+// ΛDeepCopy returns a deep copy of Device. It implements the
+// ygot.GoStructCopier interface.
+func (t *Device) ΛDeepCopy() (ygot.GoStruct, error) {
+	return t.Copy()
+}
+
+// ΛDiff reports the fields of Device that differ from those of other
+// to d. It implements the ygot.GoStructDiffer interface.
+func (t *Device) ΛDiff(d *ygot.DiffWalker, other ygot.GoStruct) error {
+	o, _ := other.(*Device)
+	return t.diff(d, o)
+}
+
+// diff reports the fields of Device that differ from those of o to d.
+func (t *Device) diff(d *ygot.DiffWalker, o *Device) error {
+	if t == nil {
+		t = &Device{}
+	}
+	if o == nil {
+		o = &Device{}
+	}
+	if !ygot.EqualMaps(t.Interface, o.Interface) {
+		if err := ygot.DiffMaps(d, [][]string{{"interfaces", "interface"}}, nil, t.Interface, o.Interface); err != nil {
+			return err
+		}
+	}
+	if !t.Protocols.Equal(o.Protocols) {
+		if err := d.Struct([][]string{{"protocols"}}, nil, t.Protocols, o.Protocols); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// ΛBelongingModule returns the name of the module that defines the namespace
+// of Device.
+func (*Device) ΛBelongingModule() string {
+	return ""
+}
+
+// Interface_VrrpGroup represents the /name-overrides/interfaces/interface/vrrp/vrrp-group YANG schema element.
+type Interface_VrrpGroup struct {
+	Id                *uint8        `path:"config/id|id" module:"name-overrides/name-overrides|name-overrides"`
+	InterfaceTracking *VrrpTracking `path:"interface-tracking" module:"name-overrides"`
+}
+
+// IsYANGGoStruct ensures that Interface_VrrpGroup implements the yang.GoStruct
+// interface. This allows functions that need to handle this struct to
+// identify it as being generated by ygen.
+func (*Interface_VrrpGroup) IsYANGGoStruct() {}
+
+// GetOrCreateInterfaceTracking retrieves the value of the InterfaceTracking field
+// or returns the existing field if it already exists.
+func (t *Interface_VrrpGroup) GetOrCreateInterfaceTracking() *VrrpTracking {
+	if t.InterfaceTracking != nil {
+		return t.InterfaceTracking
+	}
+	t.InterfaceTracking = &VrrpTracking{}
+	return t.InterfaceTracking
+}
+
+// GetInterfaceTracking returns the value of the InterfaceTracking struct pointer
+// from Interface_VrrpGroup. If the receiver or the field InterfaceTracking is nil, nil
+// is returned such that the Get* methods can be safely chained.
+func (t *Interface_VrrpGroup) GetInterfaceTracking() *VrrpTracking {
+	if t != nil && t.InterfaceTracking != nil {
+		return t.InterfaceTracking
+	}
+	return nil
+}
+
+// GetId retrieves the value of the leaf Id from the Interface_VrrpGroup
+// struct. If the field is unset but has a default value in the YANG schema,
+// then the default value will be returned.
+// Caution should be exercised whilst using this method since when without a
+// default value, it will return the Go zero value if the field is explicitly
+// unset. If the caller explicitly does not care if Id is set, it can
+// safely use t.GetId() to retrieve the value. In the case that the
+// caller has different actions based on whether the leaf is set or unset, it
+// should use 'if t.Id == nil' before retrieving the leaf's value.
+func (t *Interface_VrrpGroup) GetId() uint8 {
+	if t == nil || t.Id == nil {
+		return 0
+	}
+	return *t.Id
+}
+
+// SetId sets the value of the leaf Id in the Interface_VrrpGroup
+// struct.
+func (t *Interface_VrrpGroup) SetId(v uint8) {
+	t.Id = &v
+}
+
+// PopulateDefaults recursively populates unset leaf fields in the Interface_VrrpGroup
+// with default values as specified in the YANG schema, instantiating any nil
+// container fields.
+func (t *Interface_VrrpGroup) PopulateDefaults() {
+	if t == nil {
+		return
+	}
+	ygot.BuildEmptyTree(t)
+	t.InterfaceTracking.PopulateDefaults()
+}
+
+// ΛListKeyMap returns the keys of the Interface_VrrpGroup struct, which is a YANG list entry.
+func (t *Interface_VrrpGroup) ΛListKeyMap() (map[string]interface{}, error) {
+	if t.Id == nil {
+		return nil, fmt.Errorf("nil value for key Id")
+	}
+
+	return map[string]interface{}{
+		"id": *t.Id,
+	}, nil
+}
+
+// Validate validates s against the YANG schema corresponding to its type.
+func (t *Interface_VrrpGroup) ΛValidate(opts ...ygot.ValidationOption) error {
+	if err := ytypes.Validate(SchemaTree["Interface_VrrpGroup"], t, opts...); err != nil {
+		return err
+	}
+	return nil
+}
+
+// Validate validates s against the YANG schema corresponding to its type.
+func (t *Interface_VrrpGroup) Validate(opts ...ygot.ValidationOption) error {
+	return t.ΛValidate(opts...)
+}
+
+// ΛEnumTypeMap returns a map, keyed by YANG schema path, of the enumerated types
+// that are included in the generated code.
+func (t *Interface_VrrpGroup) ΛEnumTypeMap() map[string][]reflect.Type { return ΛEnumTypes }
+
+// MarshalRFC7951 writes the contents of Interface_VrrpGroup to the supplied
+// encoder as RFC7951 JSON. It implements the ygot.RFC7951Marshaler interface.
+func (t *Interface_VrrpGroup) MarshalRFC7951(e *ygot.RFC7951Encoder) error {
+	if t.Id != nil {
+		if err := e.Set([][]string{{"config", "id"}, {"id"}}, [][]string{{"name-overrides", "name-overrides"}, {"name-overrides"}}, float64(*t.Id)); err != nil {
+			return err
+		}
+	}
+	if t.InterfaceTracking != nil {
+		if err := e.Struct([][]string{{"interface-tracking"}}, [][]string{{"name-overrides"}}, t.InterfaceTracking, false); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// UnmarshalRFC7951 unmarshals the supplied RFC7951 JSON object into
+// Interface_VrrpGroup. It implements the ytypes.RFC7951Unmarshaler interface.
+func (t *Interface_VrrpGroup) UnmarshalRFC7951(d *ytypes.RFC7951Decoder, jsonTree map[string]interface{}) error {
+	if v, err := d.Value(jsonTree, [][]string{{"config", "id"}, {"id"}}, nil); err != nil {
+		return err
+	} else if v != nil {
+		x, err := d.Uint8("id", v)
+		if err != nil {
+			return err
+		}
+		t.Id = &x
+	}
+	if err := d.Field(t, "InterfaceTracking", jsonTree); err != nil {
+		return err
+	}
+	return nil
+}
+
+// Equal reports whether Interface_VrrpGroup is equal to o. Nil values are only
+// equal to other nil values.
+func (t *Interface_VrrpGroup) Equal(o *Interface_VrrpGroup) bool {
+	if t == nil || o == nil {
+		return t == o
+	}
+	if !ygot.EqualPtrs(t.Id, o.Id) {
+		return false
+	}
+	if !t.InterfaceTracking.Equal(o.InterfaceTracking) {
+		return false
+	}
+	return true
+}
+
+// Copy returns a deep copy of Interface_VrrpGroup. As with ygot.DeepCopy, empty
+// maps and slices are not retained in the copy.
+func (t *Interface_VrrpGroup) Copy() (*Interface_VrrpGroup, error) {
+	if t == nil {
+		return nil, nil
+	}
+	n := &Interface_VrrpGroup{}
+	var err error
+	if t.Id != nil {
+		v := *t.Id
+		n.Id = &v
+	}
+	if n.InterfaceTracking, err = t.InterfaceTracking.Copy(); err != nil {
+		return nil, err
+	}
+	return n, nil
+}
+
+// ΛDeepCopy returns a deep copy of Interface_VrrpGroup. It implements the
+// ygot.GoStructCopier interface.
+func (t *Interface_VrrpGroup) ΛDeepCopy() (ygot.GoStruct, error) {
+	return t.Copy()
+}
+
+// ΛDiff reports the fields of Interface_VrrpGroup that differ from those of other
+// to d. It implements the ygot.GoStructDiffer interface.
+func (t *Interface_VrrpGroup) ΛDiff(d *ygot.DiffWalker, other ygot.GoStruct) error {
+	o, _ := other.(*Interface_VrrpGroup)
+	return t.diff(d, o)
+}
+
+// diff reports the fields of Interface_VrrpGroup that differ from those of o to d.
+func (t *Interface_VrrpGroup) diff(d *ygot.DiffWalker, o *Interface_VrrpGroup) error {
+	if t == nil {
+		t = &Interface_VrrpGroup{}
+	}
+	if o == nil {
+		o = &Interface_VrrpGroup{}
+	}
+	if !ygot.EqualPtrs(t.Id, o.Id) {
+		if err := d.Leaf([][]string{{"config", "id"}, {"id"}}, nil, t.Id, o.Id); err != nil {
+			return err
+		}
+	}
+	if !t.InterfaceTracking.Equal(o.InterfaceTracking) {
+		if err := d.Struct([][]string{{"interface-tracking"}}, nil, t.InterfaceTracking, o.InterfaceTracking); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// ΛBelongingModule returns the name of the module that defines the namespace
+// of Interface_VrrpGroup.
+func (*Interface_VrrpGroup) ΛBelongingModule() string {
+	return "name-overrides"
+}
+
+// Intf represents the /name-overrides/interfaces/interface YANG schema element.
+type Intf struct {
+	AdminStatus E_AdminStatus                  `path:"config/admin-status" module:"name-overrides/name-overrides"`
+	Desc        *string                        `path:"config/description" module:"name-overrides/name-overrides"`
+	Name        *string                        `path:"config/name|name" module:"name-overrides/name-overrides|name-overrides"`
+	Value       InterfaceValue                 `path:"config/value" module:"name-overrides/name-overrides"`
+	Group       map[uint8]*Interface_VrrpGroup `path:"vrrp/vrrp-group" module:"name-overrides/name-overrides"`
+}
+
+// IsYANGGoStruct ensures that Intf implements the yang.GoStruct
+// interface. This allows functions that need to handle this struct to
+// identify it as being generated by ygen.
+func (*Intf) IsYANGGoStruct() {}
+
+// NewGroup creates a new entry in the Group list of the
+// Intf struct. The keys of the list are populated from the input
+// arguments.
+func (t *Intf) NewGroup(Id uint8) (*Interface_VrrpGroup, error) {
+
+	// Initialise the list within the receiver struct if it has not already been
+	// created.
+	if t.Group == nil {
+		t.Group = make(map[uint8]*Interface_VrrpGroup)
+	}
+
+	key := Id
+
+	// Ensure that this key has not already been used in the
+	// list. Keyed YANG lists do not allow duplicate keys to
+	// be created.
+	if _, ok := t.Group[key]; ok {
+		return nil, fmt.Errorf("duplicate key %v for list Group", key)
+	}
+
+	t.Group[key] = &Interface_VrrpGroup{
+		Id: &Id,
+	}
+
+	return t.Group[key], nil
+}
+
+// RenameGroup renames an entry in the list Group within
+// the Intf struct. The entry with key oldK is renamed to newK updating
+// the key within the value.
+func (t *Intf) RenameGroup(oldK, newK uint8) error {
+	if _, ok := t.Group[newK]; ok {
+		return fmt.Errorf("key %v already exists in Group", newK)
+	}
+
+	e, ok := t.Group[oldK]
+	if !ok {
+		return fmt.Errorf("key %v not found in Group", oldK)
+	}
+	e.Id = &newK
+
+	t.Group[newK] = e
+	delete(t.Group, oldK)
+	return nil
+}
+
+// GetOrCreateGroupMap returns the list (map) from Intf.
+//
+// It initializes the field if not already initialized.
+func (t *Intf) GetOrCreateGroupMap() map[uint8]*Interface_VrrpGroup {
+	if t.Group == nil {
+		t.Group = make(map[uint8]*Interface_VrrpGroup)
+	}
+	return t.Group
+}
+
+// GetOrCreateGroup retrieves the value with the specified keys from
+// the receiver Intf. If the entry does not exist, then it is created.
+// It returns the existing or new list member.
+func (t *Intf) GetOrCreateGroup(Id uint8) *Interface_VrrpGroup {
+
+	key := Id
+
+	if v, ok := t.Group[key]; ok {
+		return v
+	}
+	// Panic if we receive an error, since we should have retrieved an existing
+	// list member. This allows chaining of GetOrCreate methods.
+	v, err := t.NewGroup(Id)
+	if err != nil {
+		panic(fmt.Sprintf("GetOrCreateGroup got unexpected error: %v", err))
+	}
+	return v
+}
+
+// GetGroup retrieves the value with the specified key from
+// the Group map field of Intf. If the receiver is nil, or
+// the specified key is not present in the list, nil is returned such that Get*
+// methods may be safely chained.
+func (t *Intf) GetGroup(Id uint8) *Interface_VrrpGroup {
+
+	if t == nil {
+		return nil
+	}
+
+	key := Id
+
+	if lm, ok := t.Group[key]; ok {
+		return lm
+	}
+	return nil
+}
+
+// DeleteGroup deletes the value with the specified keys from
+// the receiver Intf. If there is no such element, the function
+// is a no-op.
+func (t *Intf) DeleteGroup(Id uint8) {
+	key := Id
+
+	delete(t.Group, key)
+}
+
+// AppendGroup appends the supplied Interface_VrrpGroup struct to the
+// list Group of Intf. If the key value(s) specified in
+// the supplied Interface_VrrpGroup already exist in the list, an error is
+// returned.
+func (t *Intf) AppendGroup(v *Interface_VrrpGroup) error {
+	if v.Id == nil {
+		return fmt.Errorf("invalid nil key received for Id")
+	}
+
+	key := *v.Id
+
+	// Initialise the list within the receiver struct if it has not already been
+	// created.
+	if t.Group == nil {
+		t.Group = make(map[uint8]*Interface_VrrpGroup)
+	}
+
+	if _, ok := t.Group[key]; ok {
+		return fmt.Errorf("duplicate key for list Group %v", key)
+	}
+
+	t.Group[key] = v
+	return nil
+}
+
+// GetAdminStatus retrieves the value of the leaf AdminStatus from the Intf
+// struct. If the field is unset but has a default value in the YANG schema,
+// then the default value will be returned.
+// Caution should be exercised whilst using this method since when without a
+// default value, it will return the Go zero value if the field is explicitly
+// unset. If the caller explicitly does not care if AdminStatus is set, it can
+// safely use t.GetAdminStatus() to retrieve the value. In the case that the
+// caller has different actions based on whether the leaf is set or unset, it
+// should use 'if t.AdminStatus == nil' before retrieving the leaf's value.
+func (t *Intf) GetAdminStatus() E_AdminStatus {
+	if t == nil || t.AdminStatus == 0 {
+		return AdminStatus_Testing
+	}
+	return t.AdminStatus
+}
+
+// GetDesc retrieves the value of the leaf Desc from the Intf
+// struct. If the field is unset but has a default value in the YANG schema,
+// then the default value will be returned.
+// Caution should be exercised whilst using this method since when without a
+// default value, it will return the Go zero value if the field is explicitly
+// unset. If the caller explicitly does not care if Desc is set, it can
+// safely use t.GetDesc() to retrieve the value. In the case that the
+// caller has different actions based on whether the leaf is set or unset, it
+// should use 'if t.Desc == nil' before retrieving the leaf's value.
+func (t *Intf) GetDesc() string {
+	if t == nil || t.Desc == nil {
+		return ""
+	}
+	return *t.Desc
+}
+
+// GetName retrieves the value of the leaf Name from the Intf
+// struct. If the field is unset but has a default value in the YANG schema,
+// then the default value will be returned.
+// Caution should be exercised whilst using this method since when without a
+// default value, it will return the Go zero value if the field is explicitly
+// unset. If the caller explicitly does not care if Name is set, it can
+// safely use t.GetName() to retrieve the value. In the case that the
+// caller has different actions based on whether the leaf is set or unset, it
+// should use 'if t.Name == nil' before retrieving the leaf's value.
+func (t *Intf) GetName() string {
+	if t == nil || t.Name == nil {
+		return ""
+	}
+	return *t.Name
+}
+
+// GetValue retrieves the value of the leaf Value from the Intf
+// struct. If the field is unset but has a default value in the YANG schema,
+// then the default value will be returned.
+// Caution should be exercised whilst using this method since when without a
+// default value, it will return the Go zero value if the field is explicitly
+// unset. If the caller explicitly does not care if Value is set, it can
+// safely use t.GetValue() to retrieve the value. In the case that the
+// caller has different actions based on whether the leaf is set or unset, it
+// should use 'if t.Value == nil' before retrieving the leaf's value.
+func (t *Intf) GetValue() InterfaceValue {
+	if t == nil || t.Value == nil {
+		return nil
+	}
+	return t.Value
+}
+
+// SetAdminStatus sets the value of the leaf AdminStatus in the Intf
+// struct.
+func (t *Intf) SetAdminStatus(v E_AdminStatus) {
+	t.AdminStatus = v
+}
+
+// SetDesc sets the value of the leaf Desc in the Intf
+// struct.
+func (t *Intf) SetDesc(v string) {
+	t.Desc = &v
+}
+
+// SetName sets the value of the leaf Name in the Intf
+// struct.
+func (t *Intf) SetName(v string) {
+	t.Name = &v
+}
+
+// SetValue sets the value of the leaf Value in the Intf
+// struct.
+func (t *Intf) SetValue(v InterfaceValue) {
+	t.Value = v
+}
+
+// PopulateDefaults recursively populates unset leaf fields in the Intf
+// with default values as specified in the YANG schema, instantiating any nil
+// container fields.
+func (t *Intf) PopulateDefaults() {
+	if t == nil {
+		return
+	}
+	ygot.BuildEmptyTree(t)
+	if t.AdminStatus == 0 {
+		t.AdminStatus = AdminStatus_Testing
+	}
+	for _, e := range t.Group {
+		e.PopulateDefaults()
+	}
+}
+
+// ΛListKeyMap returns the keys of the Intf struct, which is a YANG list entry.
+func (t *Intf) ΛListKeyMap() (map[string]interface{}, error) {
+	if t.Name == nil {
+		return nil, fmt.Errorf("nil value for key Name")
+	}
+
+	return map[string]interface{}{
+		"name": *t.Name,
+	}, nil
+}
+
+// Validate validates s against the YANG schema corresponding to its type.
+func (t *Intf) ΛValidate(opts ...ygot.ValidationOption) error {
+	if err := ytypes.Validate(SchemaTree["Intf"], t, opts...); err != nil {
+		return err
+	}
+	return nil
+}
+
+// Validate validates s against the YANG schema corresponding to its type.
+func (t *Intf) Validate(opts ...ygot.ValidationOption) error {
+	return t.ΛValidate(opts...)
+}
+
+// ΛEnumTypeMap returns a map, keyed by YANG schema path, of the enumerated types
+// that are included in the generated code.
+func (t *Intf) ΛEnumTypeMap() map[string][]reflect.Type { return ΛEnumTypes }
+
+// MarshalRFC7951 writes the contents of Intf to the supplied
+// encoder as RFC7951 JSON. It implements the ygot.RFC7951Marshaler interface.
+func (t *Intf) MarshalRFC7951(e *ygot.RFC7951Encoder) error {
+	if t.AdminStatus != 0 {
+		v, err := e.Enum(t.AdminStatus, "E_AdminStatus", int64(t.AdminStatus))
+		if err != nil {
+			return err
+		}
+		if err := e.Set([][]string{{"config", "admin-status"}}, [][]string{{"name-overrides", "name-overrides"}}, v); err != nil {
+			return err
+		}
+	}
+	if t.Desc != nil {
+		if err := e.Set([][]string{{"config", "description"}}, [][]string{{"name-overrides", "name-overrides"}}, ygot.RFC7951String(*t.Desc)); err != nil {
+			return err
+		}
+	}
+	if t.Name != nil {
+		if err := e.Set([][]string{{"config", "name"}, {"name"}}, [][]string{{"name-overrides", "name-overrides"}, {"name-overrides"}}, ygot.RFC7951String(*t.Name)); err != nil {
+			return err
+		}
+	}
+	switch u := t.Value.(type) {
+	case nil:
+	case *InterfaceValue_String:
+		if err := e.Set([][]string{{"config", "value"}}, [][]string{{"name-overrides", "name-overrides"}}, ygot.RFC7951String(u.String)); err != nil {
+			return err
+		}
+	case *InterfaceValue_Uint32:
+		if err := e.Set([][]string{{"config", "value"}}, [][]string{{"name-overrides", "name-overrides"}}, float64(u.Uint32)); err != nil {
+			return err
+		}
+	default:
+		if err := e.Field([][]string{{"config", "value"}}, [][]string{{"name-overrides", "name-overrides"}}, &t.Value); err != nil {
+			return err
+		}
+	}
+	if t.Group != nil {
+		l := make([]ygot.RFC7951ListElement, 0, len(t.Group))
+		for k, v := range t.Group {
+			l = append(l, ygot.RFC7951ListElement{Key: k, Value: v})
+		}
+		if err := e.List([][]string{{"vrrp", "vrrp-group"}}, [][]string{{"name-overrides", "name-overrides"}}, l); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// UnmarshalRFC7951 unmarshals the supplied RFC7951 JSON object into
+// Intf. It implements the ytypes.RFC7951Unmarshaler interface.
+func (t *Intf) UnmarshalRFC7951(d *ytypes.RFC7951Decoder, jsonTree map[string]interface{}) error {
+	if v, err := d.Value(jsonTree, [][]string{{"config", "admin-status"}}, nil); err != nil {
+		return err
+	} else if v != nil {
+		x, err := d.Enum("admin-status", "AdminStatus", E_AdminStatus(0), "E_AdminStatus", v)
+		if err != nil {
+			return err
+		}
+		t.AdminStatus = E_AdminStatus(x)
+	}
+	if v, err := d.Value(jsonTree, [][]string{{"config", "description"}}, nil); err != nil {
+		return err
+	} else if v != nil {
+		x, err := d.String("description", v)
+		if err != nil {
+			return err
+		}
+		t.Desc = &x
+	}
+	if v, err := d.Value(jsonTree, [][]string{{"config", "name"}, {"name"}}, nil); err != nil {
+		return err
+	} else if v != nil {
+		x, err := d.String("name", v)
+		if err != nil {
+			return err
+		}
+		t.Name = &x
+	}
+	if err := d.Field(t, "Value", jsonTree); err != nil {
+		return err
+	}
+	if err := d.Field(t, "Group", jsonTree); err != nil {
+		return err
+	}
+	return nil
+}
+
+// Equal reports whether Intf is equal to o. Nil values are only
+// equal to other nil values.
+func (t *Intf) Equal(o *Intf) bool {
+	if t == nil || o == nil {
+		return t == o
+	}
+	if t.AdminStatus != o.AdminStatus {
+		return false
+	}
+	if !ygot.EqualPtrs(t.Desc, o.Desc) {
+		return false
+	}
+	if !ygot.EqualPtrs(t.Name, o.Name) {
+		return false
+	}
+	if !reflect.DeepEqual(t.Value, o.Value) {
+		return false
+	}
+	if !ygot.EqualMaps(t.Group, o.Group) {
+		return false
+	}
+	return true
+}
+
+// Copy returns a deep copy of Intf. As with ygot.DeepCopy, empty
+// maps and slices are not retained in the copy.
+func (t *Intf) Copy() (*Intf, error) {
+	if t == nil {
+		return nil, nil
+	}
+	n := &Intf{}
+	var err error
+	n.AdminStatus = t.AdminStatus
+	if t.Desc != nil {
+		v := *t.Desc
+		n.Desc = &v
+	}
+	if t.Name != nil {
+		v := *t.Name
+		n.Name = &v
+	}
+	if n.Value, err = ygot.CopyField(t.Value); err != nil {
+		return nil, err
+	}
+	if n.Group, err = ygot.CopyMap(t.Group); err != nil {
+		return nil, err
+	}
+	return n, nil
+}
+
+// ΛDeepCopy returns a deep copy of Intf. It implements the
+// ygot.GoStructCopier interface.
+func (t *Intf) ΛDeepCopy() (ygot.GoStruct, error) {
+	return t.Copy()
+}
+
+// ΛDiff reports the fields of Intf that differ from those of other
+// to d. It implements the ygot.GoStructDiffer interface.
+func (t *Intf) ΛDiff(d *ygot.DiffWalker, other ygot.GoStruct) error {
+	o, _ := other.(*Intf)
+	return t.diff(d, o)
+}
+
+// diff reports the fields of Intf that differ from those of o to d.
+func (t *Intf) diff(d *ygot.DiffWalker, o *Intf) error {
+	if t == nil {
+		t = &Intf{}
+	}
+	if o == nil {
+		o = &Intf{}
+	}
+	if t.AdminStatus != o.AdminStatus {
+		if err := d.Leaf([][]string{{"config", "admin-status"}}, nil, t.AdminStatus, o.AdminStatus); err != nil {
+			return err
+		}
+	}
+	if !ygot.EqualPtrs(t.Desc, o.Desc) {
+		if err := d.Leaf([][]string{{"config", "description"}}, nil, t.Desc, o.Desc); err != nil {
+			return err
+		}
+	}
+	if !ygot.EqualPtrs(t.Name, o.Name) {
+		if err := d.Leaf([][]string{{"config", "name"}, {"name"}}, nil, t.Name, o.Name); err != nil {
+			return err
+		}
+	}
+	if !reflect.DeepEqual(t.Value, o.Value) {
+		if err := d.Leaf([][]string{{"config", "value"}}, nil, t.Value, o.Value); err != nil {
+			return err
+		}
+	}
+	if !ygot.EqualMaps(t.Group, o.Group) {
+		if err := ygot.DiffMaps(d, [][]string{{"vrrp", "vrrp-group"}}, nil, t.Group, o.Group); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// ΛBelongingModule returns the name of the module that defines the namespace
+// of Intf.
+func (*Intf) ΛBelongingModule() string {
+	return "name-overrides"
+}
+
+// InterfaceValue is an interface that is implemented by valid types for the union
+// for the leaf /name-overrides/interfaces/interface/config/value within the YANG schema.
+type InterfaceValue interface {
+	Is_InterfaceValue()
+}
+
+// InterfaceValue_String is used when /name-overrides/interfaces/interface/config/value
+// is to be set to a string value.
+type InterfaceValue_String struct {
+	String string
+}
+
+// Is_InterfaceValue ensures that InterfaceValue_String
+// implements the InterfaceValue interface.
+func (*InterfaceValue_String) Is_InterfaceValue() {}
+
+// InterfaceValue_Uint32 is used when /name-overrides/interfaces/interface/config/value
+// is to be set to a uint32 value.
+type InterfaceValue_Uint32 struct {
+	Uint32 uint32
+}
+
+// Is_InterfaceValue ensures that InterfaceValue_Uint32
+// implements the InterfaceValue interface.
+func (*InterfaceValue_Uint32) Is_InterfaceValue() {}
+
+// To_InterfaceValue takes an input interface{} and attempts to convert it to a struct
+// which implements the InterfaceValue union. It returns an error if the interface{} supplied
+// cannot be converted to a type within the union.
+func (t *Intf) To_InterfaceValue(i interface{}) (InterfaceValue, error) {
+	switch v := i.(type) {
+	case string:
+		return &InterfaceValue_String{v}, nil
+	case uint32:
+		return &InterfaceValue_Uint32{v}, nil
+	default:
+		return nil, fmt.Errorf("cannot convert %v to InterfaceValue, unknown union type, got: %T, want any of [string, uint32]", i, i)
+	}
+}
+
+// Protocols represents the /name-overrides/protocols YANG schema element.
+type Protocols struct {
+	Protocol E_Protocol `path:"config/protocol" module:"name-overrides/name-overrides"`
+}
+
+// IsYANGGoStruct ensures that Protocols implements the yang.GoStruct
+// interface. This allows functions that need to handle this struct to
+// identify it as being generated by ygen.
+func (*Protocols) IsYANGGoStruct() {}
+
+// GetProtocol retrieves the value of the leaf Protocol from the Protocols
+// struct. If the field is unset but has a default value in the YANG schema,
+// then the default value will be returned.
+// Caution should be exercised whilst using this method since when without a
+// default value, it will return the Go zero value if the field is explicitly
+// unset. If the caller explicitly does not care if Protocol is set, it can
+// safely use t.GetProtocol() to retrieve the value. In the case that the
+// caller has different actions based on whether the leaf is set or unset, it
+// should use 'if t.Protocol == nil' before retrieving the leaf's value.
+func (t *Protocols) GetProtocol() E_Protocol {
+	if t == nil || t.Protocol == 0 {
+		return 0
+	}
+	return t.Protocol
+}
+
+// SetProtocol sets the value of the leaf Protocol in the Protocols
+// struct.
+func (t *Protocols) SetProtocol(v E_Protocol) {
+	t.Protocol = v
+}
+
+// PopulateDefaults recursively populates unset leaf fields in the Protocols
+// with default values as specified in the YANG schema, instantiating any nil
+// container fields.
+func (t *Protocols) PopulateDefaults() {
+	if t == nil {
+		return
+	}
+	ygot.BuildEmptyTree(t)
+}
+
+// Validate validates s against the YANG schema corresponding to its type.
+func (t *Protocols) ΛValidate(opts ...ygot.ValidationOption) error {
+	if err := ytypes.Validate(SchemaTree["Protocols"], t, opts...); err != nil {
+		return err
+	}
+	return nil
+}
+
+// Validate validates s against the YANG schema corresponding to its type.
+func (t *Protocols) Validate(opts ...ygot.ValidationOption) error {
+	return t.ΛValidate(opts...)
+}
+
+// ΛEnumTypeMap returns a map, keyed by YANG schema path, of the enumerated types
+// that are included in the generated code.
+func (t *Protocols) ΛEnumTypeMap() map[string][]reflect.Type { return ΛEnumTypes }
+
+// MarshalRFC7951 writes the contents of Protocols to the supplied
+// encoder as RFC7951 JSON. It implements the ygot.RFC7951Marshaler interface.
+func (t *Protocols) MarshalRFC7951(e *ygot.RFC7951Encoder) error {
+	if t.Protocol != 0 {
+		v, err := e.Enum(t.Protocol, "E_Protocol", int64(t.Protocol))
+		if err != nil {
+			return err
+		}
+		if err := e.Set([][]string{{"config", "protocol"}}, [][]string{{"name-overrides", "name-overrides"}}, v); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// UnmarshalRFC7951 unmarshals the supplied RFC7951 JSON object into
+// Protocols. It implements the ytypes.RFC7951Unmarshaler interface.
+func (t *Protocols) UnmarshalRFC7951(d *ytypes.RFC7951Decoder, jsonTree map[string]interface{}) error {
+	if v, err := d.Value(jsonTree, [][]string{{"config", "protocol"}}, nil); err != nil {
+		return err
+	} else if v != nil {
+		x, err := d.Enum("protocol", "Protocol", E_Protocol(0), "E_Protocol", v)
+		if err != nil {
+			return err
+		}
+		t.Protocol = E_Protocol(x)
+	}
+	return nil
+}
+
+// Equal reports whether Protocols is equal to o. Nil values are only
+// equal to other nil values.
+func (t *Protocols) Equal(o *Protocols) bool {
+	if t == nil || o == nil {
+		return t == o
+	}
+	if t.Protocol != o.Protocol {
+		return false
+	}
+	return true
+}
+
+// Copy returns a deep copy of Protocols. As with ygot.DeepCopy, empty
+// maps and slices are not retained in the copy.
+func (t *Protocols) Copy() (*Protocols, error) {
+	if t == nil {
+		return nil, nil
+	}
+	n := &Protocols{}
+	n.Protocol = t.Protocol
+	return n, nil
+}
+
+// ΛDeepCopy returns a deep copy of Protocols. It implements the
+// ygot.GoStructCopier interface.
+func (t *Protocols) ΛDeepCopy() (ygot.GoStruct, error) {
+	return t.Copy()
+}
+
+// ΛDiff reports the fields of Protocols that differ from those of other
+// to d. It implements the ygot.GoStructDiffer interface.
+func (t *Protocols) ΛDiff(d *ygot.DiffWalker, other ygot.GoStruct) error {
+	o, _ := other.(*Protocols)
+	return t.diff(d, o)
+}
+
+// diff reports the fields of Protocols that differ from those of o to d.
+func (t *Protocols) diff(d *ygot.DiffWalker, o *Protocols) error {
+	if t == nil {
+		t = &Protocols{}
+	}
+	if o == nil {
+		o = &Protocols{}
+	}
+	if t.Protocol != o.Protocol {
+		if err := d.Leaf([][]string{{"config", "protocol"}}, nil, t.Protocol, o.Protocol); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// ΛBelongingModule returns the name of the module that defines the namespace
+// of Protocols.
+func (*Protocols) ΛBelongingModule() string {
+	return "name-overrides"
+}
+
+// VrrpTracking represents the /name-overrides/interfaces/interface/vrrp/vrrp-group/interface-tracking YANG schema element.
+type VrrpTracking struct {
+	PriorityDecrement *uint8 `path:"config/priority-decrement" module:"name-overrides/name-overrides"`
+}
+
+// IsYANGGoStruct ensures that VrrpTracking implements the yang.GoStruct
+// interface. This allows functions that need to handle this struct to
+// identify it as being generated by ygen.
+func (*VrrpTracking) IsYANGGoStruct() {}
+
+// GetPriorityDecrement retrieves the value of the leaf PriorityDecrement from the VrrpTracking
+// struct. If the field is unset but has a default value in the YANG schema,
+// then the default value will be returned.
+// Caution should be exercised whilst using this method since when without a
+// default value, it will return the Go zero value if the field is explicitly
+// unset. If the caller explicitly does not care if PriorityDecrement is set, it can
+// safely use t.GetPriorityDecrement() to retrieve the value. In the case that the
+// caller has different actions based on whether the leaf is set or unset, it
+// should use 'if t.PriorityDecrement == nil' before retrieving the leaf's value.
+func (t *VrrpTracking) GetPriorityDecrement() uint8 {
+	if t == nil || t.PriorityDecrement == nil {
+		return 0
+	}
+	return *t.PriorityDecrement
+}
+
+// SetPriorityDecrement sets the value of the leaf PriorityDecrement in the VrrpTracking
+// struct.
+func (t *VrrpTracking) SetPriorityDecrement(v uint8) {
+	t.PriorityDecrement = &v
+}
+
+// PopulateDefaults recursively populates unset leaf fields in the VrrpTracking
+// with default values as specified in the YANG schema, instantiating any nil
+// container fields.
+func (t *VrrpTracking) PopulateDefaults() {
+	if t == nil {
+		return
+	}
+	ygot.BuildEmptyTree(t)
+}
+
+// Validate validates s against the YANG schema corresponding to its type.
+func (t *VrrpTracking) ΛValidate(opts ...ygot.ValidationOption) error {
+	if err := ytypes.Validate(SchemaTree["VrrpTracking"], t, opts...); err != nil {
+		return err
+	}
+	return nil
+}
+
+// Validate validates s against the YANG schema corresponding to its type.
+func (t *VrrpTracking) Validate(opts ...ygot.ValidationOption) error {
+	return t.ΛValidate(opts...)
+}
+
+// ΛEnumTypeMap returns a map, keyed by YANG schema path, of the enumerated types
+// that are included in the generated code.
+func (t *VrrpTracking) ΛEnumTypeMap() map[string][]reflect.Type { return ΛEnumTypes }
+
+// MarshalRFC7951 writes the contents of VrrpTracking to the supplied
+// encoder as RFC7951 JSON. It implements the ygot.RFC7951Marshaler interface.
+func (t *VrrpTracking) MarshalRFC7951(e *ygot.RFC7951Encoder) error {
+	if t.PriorityDecrement != nil {
+		if err := e.Set([][]string{{"config", "priority-decrement"}}, [][]string{{"name-overrides", "name-overrides"}}, float64(*t.PriorityDecrement)); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// UnmarshalRFC7951 unmarshals the supplied RFC7951 JSON object into
+// VrrpTracking. It implements the ytypes.RFC7951Unmarshaler interface.
+func (t *VrrpTracking) UnmarshalRFC7951(d *ytypes.RFC7951Decoder, jsonTree map[string]interface{}) error {
+	if v, err := d.Value(jsonTree, [][]string{{"config", "priority-decrement"}}, nil); err != nil {
+		return err
+	} else if v != nil {
+		x, err := d.Uint8("priority-decrement", v)
+		if err != nil {
+			return err
+		}
+		t.PriorityDecrement = &x
+	}
+	return nil
+}
+
+// Equal reports whether VrrpTracking is equal to o. Nil values are only
+// equal to other nil values.
+func (t *VrrpTracking) Equal(o *VrrpTracking) bool {
+	if t == nil || o == nil {
+		return t == o
+	}
+	if !ygot.EqualPtrs(t.PriorityDecrement, o.PriorityDecrement) {
+		return false
+	}
+	return true
+}
+
+// Copy returns a deep copy of VrrpTracking. As with ygot.DeepCopy, empty
+// maps and slices are not retained in the copy.
+func (t *VrrpTracking) Copy() (*VrrpTracking, error) {
+	if t == nil {
+		return nil, nil
+	}
+	n := &VrrpTracking{}
+	if t.PriorityDecrement != nil {
+		v := *t.PriorityDecrement
+		n.PriorityDecrement = &v
+	}
+	return n, nil
+}
+
+// ΛDeepCopy returns a deep copy of VrrpTracking. It implements the
+// ygot.GoStructCopier interface.
+func (t *VrrpTracking) ΛDeepCopy() (ygot.GoStruct, error) {
+	return t.Copy()
+}
+
+// ΛDiff reports the fields of VrrpTracking that differ from those of other
+// to d. It implements the ygot.GoStructDiffer interface.
+func (t *VrrpTracking) ΛDiff(d *ygot.DiffWalker, other ygot.GoStruct) error {
+	o, _ := other.(*VrrpTracking)
+	return t.diff(d, o)
+}
+
+// diff reports the fields of VrrpTracking that differ from those of o to d.
+func (t *VrrpTracking) diff(d *ygot.DiffWalker, o *VrrpTracking) error {
+	if t == nil {
+		t = &VrrpTracking{}
+	}
+	if o == nil {
+		o = &VrrpTracking{}
+	}
+	if !ygot.EqualPtrs(t.PriorityDecrement, o.PriorityDecrement) {
+		if err := d.Leaf([][]string{{"config", "priority-decrement"}}, nil, t.PriorityDecrement, o.PriorityDecrement); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// ΛBelongingModule returns the name of the module that defines the namespace
+// of VrrpTracking.
+func (*VrrpTracking) ΛBelongingModule() string {
+	return "name-overrides"
+}
+
+// E_AdminStatus is a derived int64 type which is used to represent
+// the enumerated node AdminStatus. An additional value named
+// AdminStatus_UNSET is added to the enumeration which is used as
+// the nil value, indicating that the enumeration was not explicitly set by
+// the program importing the generated structures.
+type E_AdminStatus int64
+
+// IsYANGGoEnum ensures that AdminStatus implements the yang.GoEnum
+// interface. This ensures that AdminStatus can be identified as a
+// mapped type for a YANG enumeration.
+func (E_AdminStatus) IsYANGGoEnum() {}
+
+// ΛMap returns the value lookup map associated with  AdminStatus.
+func (E_AdminStatus) ΛMap() map[string]map[int64]ygot.EnumDefinition { return ΛEnum }
+
+// String returns a logging-friendly string for E_AdminStatus.
+func (e E_AdminStatus) String() string {
+	return ygot.EnumLogString(e, int64(e), "E_AdminStatus")
+}
+
+const (
+	// AdminStatus_UNSET corresponds to the value UNSET of AdminStatus
+	AdminStatus_UNSET E_AdminStatus = 0
+	// AdminStatus_UP corresponds to the value UP of AdminStatus
+	AdminStatus_UP E_AdminStatus = 1
+	// AdminStatus_DOWN corresponds to the value DOWN of AdminStatus
+	AdminStatus_DOWN E_AdminStatus = 2
+	// AdminStatus_Testing corresponds to the value Testing of AdminStatus
+	AdminStatus_Testing E_AdminStatus = 3
+)
+
+// E_Protocol is a derived int64 type which is used to represent
+// the enumerated node Protocol. An additional value named
+// Protocol_UNSET is added to the enumeration which is used as
+// the nil value, indicating that the enumeration was not explicitly set by
+// the program importing the generated structures.
+type E_Protocol int64
+
+// IsYANGGoEnum ensures that Protocol implements the yang.GoEnum
+// interface. This ensures that Protocol can be identified as a
+// mapped type for a YANG enumeration.
+func (E_Protocol) IsYANGGoEnum() {}
+
+// ΛMap returns the value lookup map associated with  Protocol.
+func (E_Protocol) ΛMap() map[string]map[int64]ygot.EnumDefinition { return ΛEnum }
+
+// String returns a logging-friendly string for E_Protocol.
+func (e E_Protocol) String() string {
+	return ygot.EnumLogString(e, int64(e), "E_Protocol")
+}
+
+const (
+	// Protocol_UNSET corresponds to the value UNSET of Protocol
+	Protocol_UNSET E_Protocol = 0
+	// Protocol_Bgp corresponds to the value Bgp of Protocol
+	Protocol_Bgp E_Protocol = 1
+	// Protocol_STATIC corresponds to the value STATIC of Protocol
+	Protocol_STATIC E_Protocol = 2
+)
+
+// ΛEnum is a map, keyed by the name of the type defined for each enum in the
+// generated Go code, which provides a mapping between the constant int64 value
+// of each value of the enumeration, and the string that is used to represent it
+// in the YANG schema. The map is named ΛEnum in order to avoid clash with any
+// valid YANG identifier.
+var ΛEnum = map[string]map[int64]ygot.EnumDefinition{
+	"E_AdminStatus": {
+		1: {Name: "UP"},
+		2: {Name: "DOWN"},
+		3: {Name: "TESTING-MODE"},
+	},
+	"E_Protocol": {
+		1: {Name: "BGP", DefiningModule: "name-overrides"},
+		2: {Name: "STATIC", DefiningModule: "name-overrides"},
+	},
+}
+
+var (
+	// ySchema is a byte slice contain a gzip compressed representation of the
+	// YANG schema from which the Go code was generated. When uncompressed the
+	// contents of the byte slice is a JSON document containing an object, keyed
+	// on the name of the generated struct, and containing the JSON marshalled
+	// contents of a goyang yang.Entry struct, which defines the schema for the
+	// fields within the struct.
+	ySchema = []byte{
+		0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0xec, 0x5c, 0xdf, 0x6f, 0xe2, 0x38,
+		0x10, 0x7e, 0xe7, 0xaf, 0xb0, 0xfc, 0x0c, 0x5b, 0x60, 0xe9, 0x2f, 0xde, 0xda, 0xd2, 0xed, 0x55,
+		0xb7, 0x2d, 0xa8, 0xa5, 0x7b, 0x0f, 0x2b, 0x74, 0xb2, 0x12, 0xc3, 0x5a, 0x0b, 0x0e, 0x72, 0x9c,
+		0xde, 0x56, 0xa7, 0xfe, 0xef, 0xa7, 0x40, 0xc2, 0xaf, 0x10, 0xb0, 0xc7, 0x13, 0xba, 0xd7, 0xc6,
+		0x2f, 0x77, 0x4b, 0x3d, 0x8e, 0x3d, 0xf3, 0x7d, 0x93, 0xf1, 0x17, 0x27, 0xff, 0x56, 0x08, 0x21,
+		0x84, 0xde, 0xb3, 0x09, 0xa7, 0x6d, 0x42, 0x7d, 0xfe, 0x2c, 0x3c, 0x4e, 0xab, 0xf3, 0x5f, 0xff,
+		0x14, 0xd2, 0xa7, 0x6d, 0xd2, 0x48, 0xfe, 0x79, 0x15, 0xc8, 0xa1, 0x18, 0xd1, 0x36, 0xa9, 0x27,
+		0x3f, 0x74, 0x84, 0xa2, 0x6d, 0x32, 0x1f, 0x82, 0x10, 0x42, 0xa8, 0x90, 0x9a, 0xab, 0x21, 0xf3,
+		0x78, 0xb8, 0xf6, 0xfb, 0xda, 0x25, 0x56, 0xfa, 0x54, 0xd7, 0x7b, 0xac, 0x5f, 0x6e, 0xf1, 0xf3,
+		0xe6, 0x65, 0x17, 0x7f, 0xe8, 0x29, 0x3e, 0x14, 0xbf, 0x32, 0x57, 0x5a, 0xbb, 0x9a, 0x0c, 0x68,
+		0x35, 0xfb, 0xd7, 0xc7, 0x20, 0x52, 0x1e, 0xdf, 0x6a, 0x39, 0x9f, 0x09, 0x7f, 0xf9, 0x27, 0x50,
+		0xf1, 0x64, 0xe8, 0x74, 0x7e, 0x91, 0xea, 0xf6, 0x8e, 0x7f, 0xb0, 0xf0, 0x42, 0x8d, 0xa2, 0x09,
+		0x97, 0x9a, 0xb6, 0x89, 0x56, 0x11, 0xcf, 0xe9, 0xb8, 0xd2, 0x2b, 0x9e, 0x53, 0xa6, 0xd3, 0xeb,
+		0xda, 0x2f, 0xaf, 0x1b, 0x2b, 0xdd, 0x74, 0x74, 0xd6, 0xe1, 0xf9, 0x6b, 0xc9, 0xf8, 0x3d, 0x6f,
+		0x2d, 0xdb, 0xdd, 0xbf, 0x37, 0x0c, 0x26, 0xe1, 0x30, 0x0b, 0x8b, 0x69, 0x78, 0xac, 0xc3, 0x64,
+		0x1d, 0x2e, 0xe3, 0xb0, 0x6d, 0x0f, 0x5f, 0x4e, 0x18, 0xf7, 0x86, 0x33, 0x6d, 0xd4, 0x4b, 0x7d,
+		0xbd, 0xc7, 0x01, 0xa9, 0x3b, 0x93, 0xfe, 0x7b, 0x16, 0xb3, 0x3b, 0xc0, 0xc6, 0x81, 0xb6, 0x09,
+		0xb8, 0x5d, 0xe0, 0x6d, 0x01, 0x00, 0x06, 0x02, 0x18, 0x10, 0xd6, 0xc0, 0xd8, 0x0d, 0x90, 0x3d,
+		0x40, 0x31, 0x06, 0x4c, 0xda, 0x28, 0xf3, 0x27, 0x42, 0xd6, 0x42, 0xcd, 0x74, 0x14, 0x9a, 0xbb,
+		0x2f, 0x0d, 0xce, 0x9a, 0xb5, 0xa1, 0x23, 0x3a, 0x7c, 0xc8, 0xa2, 0x71, 0xec, 0x87, 0xef, 0x46,
+		0x06, 0x84, 0x10, 0x42, 0xfb, 0xd7, 0x8f, 0xfd, 0xdb, 0xfb, 0x9b, 0xda, 0x5d, 0xb7, 0x73, 0x4d,
+		0x8d, 0xcc, 0x06, 0x86, 0xd3, 0x49, 0x30, 0x5e, 0x37, 0xec, 0x6e, 0x8a, 0x75, 0x08, 0xe6, 0x61,
+		0xd8, 0x87, 0x72, 0xc0, 0x99, 0x0b, 0xce, 0x9c, 0x00, 0x73, 0xc3, 0x8c, 0x23, 0x86, 0x5c, 0x59,
+		0x62, 0xec, 0x65, 0xca, 0x61, 0x71, 0xe2, 0x32, 0x9a, 0x70, 0xc5, 0xb4, 0x08, 0xa4, 0x4d, 0xc0,
+		0xd2, 0xf4, 0xda, 0xb2, 0xb0, 0xb9, 0x96, 0xd1, 0xc4, 0x3e, 0xc4, 0xfd, 0xe0, 0x51, 0x2b, 0x21,
+		0x47, 0xd6, 0x96, 0x84, 0x10, 0x42, 0xeb, 0xf1, 0x1a, 0x9f, 0x7a, 0xb4, 0x6a, 0x6f, 0xda, 0x88,
+		0x4d, 0x3b, 0xdd, 0xbf, 0xee, 0x21, 0xc6, 0x4d, 0xda, 0x86, 0x30, 0xdf, 0x32, 0xee, 0x2b, 0x4e,
+		0xba, 0x95, 0x1a, 0xe6, 0xa1, 0xd9, 0x0a, 0xf7, 0xde, 0x28, 0xf7, 0x67, 0xb6, 0x36, 0x69, 0x02,
+		0x86, 0x78, 0xea, 0xc5, 0xf9, 0xc8, 0xce, 0x37, 0xd8, 0x1c, 0xab, 0x20, 0x44, 0x83, 0xfa, 0x3c,
+		0xf4, 0x94, 0x98, 0xce, 0x78, 0x64, 0x7d, 0x2f, 0x5a, 0x35, 0x2e, 0x73, 0x3f, 0x21, 0x65, 0xee,
+		0x3f, 0x48, 0xee, 0x0f, 0xe7, 0x89, 0x15, 0x90, 0xf6, 0xcf, 0x0e, 0xc8, 0x2c, 0xc9, 0x26, 0xe6,
+		0xeb, 0x5b, 0x62, 0x30, 0xfe, 0x6f, 0xc9, 0xa5, 0x92, 0x4b, 0x25, 0x97, 0x96, 0x8d, 0x3e, 0xb3,
+		0x71, 0x04, 0x20, 0xd3, 0xdc, 0xac, 0x64, 0x53, 0xc9, 0xa6, 0x03, 0xb1, 0x29, 0x92, 0xc0, 0xfd,
+		0xc8, 0xb9, 0x85, 0x4d, 0x32, 0xbd, 0xef, 0x56, 0xae, 0x05, 0x54, 0xd8, 0xe0, 0x14, 0x01, 0x4c,
+		0x15, 0x96, 0x61, 0x42, 0x58, 0x59, 0x24, 0xa4, 0xfe, 0xdc, 0x74, 0x58, 0xd9, 0x29, 0xc0, 0xf4,
+		0x81, 0xc9, 0x91, 0x7d, 0xfc, 0xe0, 0xab, 0x4d, 0x1b, 0xbd, 0x13, 0x12, 0xb4, 0xd5, 0x5a, 0x6d,
+		0xf4, 0x5b, 0x92, 0x89, 0xeb, 0x55, 0xb7, 0x71, 0xbe, 0x28, 0xe6, 0xc5, 0xfb, 0x86, 0x8e, 0x18,
+		0x09, 0x1d, 0x22, 0x0c, 0x78, 0xcf, 0x47, 0x4c, 0x8b, 0xe7, 0x78, 0x6e, 0x43, 0x36, 0x0e, 0x39,
+		0x78, 0xb4, 0xd7, 0xaa, 0x83, 0x8b, 0xd9, 0x2f, 0x3c, 0x17, 0xb7, 0x9a, 0xe7, 0xad, 0xf3, 0x93,
+		0xd3, 0xe6, 0xf9, 0xf1, 0xfb, 0xf5, 0x75, 0xe5, 0x30, 0x56, 0x83, 0x82, 0x76, 0xea, 0x03, 0xac,
+		0x1a, 0xc8, 0x49, 0x79, 0xbe, 0x90, 0x32, 0xd0, 0xcc, 0x78, 0x0b, 0x4f, 0x43, 0xef, 0x07, 0x9f,
+		0xb0, 0x29, 0xd3, 0x3f, 0x68, 0x9b, 0xd0, 0xa3, 0x78, 0xcb, 0x51, 0x0b, 0x9e, 0xb9, 0x52, 0xc2,
+		0xe7, 0xe1, 0xd1, 0xf2, 0xd9, 0xdf, 0xf2, 0x7f, 0x8f, 0x92, 0x87, 0x17, 0x15, 0xd8, 0x22, 0x76,
+		0x2c, 0xc0, 0x6c, 0x9b, 0x64, 0xb3, 0x3d, 0x32, 0x2c, 0xe4, 0xca, 0x47, 0x28, 0x45, 0x14, 0x64,
+		0x6e, 0x40, 0x36, 0x2e, 0xbc, 0x16, 0x7e, 0x1e, 0x73, 0x36, 0x54, 0x7c, 0x68, 0xe2, 0xec, 0xb4,
+		0x18, 0x31, 0xb8, 0x67, 0xd3, 0x5e, 0xc2, 0x8d, 0x4f, 0x9f, 0x12, 0xe4, 0xcf, 0x48, 0x52, 0x04,
+		0xfc, 0x9f, 0x95, 0x9a, 0x9a, 0xc3, 0x7f, 0xd6, 0xbb, 0x7c, 0x82, 0xf8, 0xd1, 0x9f, 0x20, 0xc6,
+		0x30, 0xa8, 0x8d, 0x54, 0x10, 0x4d, 0x01, 0x7b, 0xe2, 0xa5, 0xad, 0xdd, 0xc6, 0xb8, 0x51, 0x6e,
+		0x8c, 0x3f, 0xfa, 0xc6, 0xd8, 0x14, 0xa0, 0x69, 0x33, 0x3d, 0x23, 0x91, 0x1b, 0x5e, 0xa3, 0x33,
+		0x13, 0x8e, 0x80, 0x05, 0x03, 0xd7, 0x05, 0xc0, 0x6e, 0x40, 0x76, 0x05, 0x34, 0x1a, 0xb0, 0xd1,
+		0x00, 0xee, 0x0c, 0x74, 0x3b, 0xc0, 0x5b, 0x02, 0x1f, 0x4c, 0x80, 0xb4, 0x51, 0xe1, 0xc3, 0x83,
+		0xb4, 0x38, 0x1c, 0xe6, 0x43, 0xa3, 0x63, 0x27, 0x6d, 0xa2, 0x11, 0x03, 0x83, 0x20, 0x38, 0x44,
+		0xc1, 0x22, 0x0c, 0x3a, 0x71, 0xd0, 0x09, 0x84, 0x46, 0x24, 0x18, 0xa1, 0x80, 0xc4, 0xb2, 0xdf,
+		0x01, 0x18, 0x69, 0x7b, 0x67, 0x2e, 0x50, 0x49, 0x48, 0xe3, 0x20, 0xbe, 0x38, 0x4a, 0x7d, 0x69,
+		0x73, 0x83, 0x2a, 0xc1, 0x92, 0xfe, 0x32, 0xfa, 0x94, 0xa3, 0x8a, 0x54, 0x98, 0x3c, 0x85, 0x2f,
+		0x53, 0x39, 0xa2, 0x1a, 0x5d, 0x22, 0xcc, 0x84, 0xa2, 0x79, 0x7c, 0xfc, 0xf1, 0x82, 0x51, 0x79,
+		0x1b, 0xeb, 0xc1, 0x81, 0x34, 0xcb, 0x62, 0x2b, 0x19, 0x4b, 0xcd, 0x10, 0x43, 0x43, 0x8c, 0x77,
+		0x9e, 0x47, 0xcb, 0xed, 0xa7, 0x91, 0xa6, 0x08, 0x77, 0x8a, 0x85, 0x43, 0x20, 0xd5, 0x19, 0xbc,
+		0x2a, 0x03, 0x56, 0x63, 0xe5, 0xf6, 0xa4, 0xdc, 0x9e, 0x14, 0x56, 0x3d, 0x01, 0xf4, 0x54, 0x17,
+		0x7d, 0x35, 0x0b, 0xd1, 0x8c, 0xde, 0x2a, 0xfc, 0xdf, 0x22, 0x31, 0xa4, 0xc9, 0xab, 0xa6, 0x15,
+		0xf3, 0x7e, 0x42, 0x8e, 0xf3, 0x66, 0xdf, 0xed, 0x59, 0x8e, 0x55, 0xea, 0x1a, 0x65, 0xe2, 0xf8,
+		0x7f, 0xeb, 0x1a, 0x40, 0x81, 0x2f, 0x03, 0x13, 0x90, 0xd0, 0xe7, 0x48, 0x0c, 0x67, 0x82, 0x60,
+		0x10, 0x05, 0x87, 0x30, 0x58, 0xc4, 0x41, 0x27, 0x10, 0x3a, 0x91, 0xd0, 0x08, 0x05, 0x23, 0x16,
+		0x90, 0x60, 0xce, 0x44, 0x4b, 0x1b, 0x9d, 0x2a, 0x11, 0x28, 0xa1, 0x5f, 0x6a, 0x3e, 0xf7, 0x14,
+		0x4f, 0x1c, 0xe0, 0x18, 0xec, 0x14, 0x7a, 0x5b, 0xc6, 0x76, 0x0c, 0x92, 0x9b, 0xf0, 0x88, 0x46,
+		0x50, 0x4c, 0xa2, 0xe2, 0x12, 0x16, 0x9b, 0xb8, 0x85, 0x11, 0xb8, 0x30, 0x22, 0xa3, 0x13, 0xda,
+		0x8d, 0xd8, 0x8e, 0x04, 0x77, 0x2f, 0xc5, 0x0b, 0x13, 0x34, 0x37, 0x49, 0x89, 0xa0, 0x18, 0x21,
+		0x09, 0x9c, 0x69, 0xc3, 0x81, 0x3e, 0xc1, 0x16, 0x3c, 0x33, 0x6a, 0x1b, 0x92, 0x24, 0x56, 0xb8,
+		0xe6, 0x56, 0x9c, 0xf6, 0x86, 0xc4, 0x92, 0xc2, 0x84, 0xd1, 0xe2, 0x04, 0xd2, 0xf7, 0x10, 0xb4,
+		0xca, 0xef, 0x31, 0xca, 0xe0, 0x8d, 0x04, 0xdc, 0xc3, 0x56, 0x7c, 0x8e, 0x42, 0x2b, 0xa6, 0xe0,
+		0x9a, 0x55, 0x20, 0x40, 0x1a, 0x2c, 0xdc, 0x8f, 0xef, 0x5e, 0xd2, 0x76, 0xd6, 0x78, 0xe6, 0x13,
+		0xd1, 0x2a, 0xf2, 0x74, 0x72, 0x30, 0x96, 0x7e, 0x53, 0x6a, 0xda, 0x4f, 0x47, 0x7b, 0xdb, 0x37,
+		0x8e, 0xab, 0xc6, 0xe7, 0xf4, 0xac, 0x94, 0x71, 0xfa, 0x55, 0x84, 0xfa, 0x42, 0x6b, 0xcb, 0xc3,
+		0x45, 0x77, 0x42, 0x5e, 0x8f, 0x67, 0xdb, 0x14, 0xcb, 0xf4, 0x1b, 0xdf, 0x6a, 0x56, 0x2c, 0x1b,
+		0x67, 0xad, 0xd6, 0xc9, 0x69, 0xab, 0x55, 0x3f, 0xfd, 0x7c, 0x5a, 0x3f, 0x3f, 0x3e, 0x6e, 0x9c,
+		0x34, 0x2c, 0x6e, 0x16, 0xb4, 0xab, 0x7c, 0xae, 0xb8, 0x7f, 0x19, 0xaf, 0x59, 0x46, 0xe3, 0x31,
+		0xc4, 0xf4, 0x29, 0xe4, 0xca, 0x2a, 0xcf, 0x9b, 0x86, 0x02, 0xc8, 0x09, 0x04, 0x2e, 0x58, 0x00,
+		0x7f, 0x03, 0xf0, 0xb7, 0xe9, 0x90, 0x7f, 0xc7, 0xd0, 0xbf, 0x99, 0x0d, 0xf6, 0x51, 0xce, 0xd9,
+		0xc7, 0xfe, 0x03, 0x1f, 0x33, 0xb6, 0xfa, 0xbe, 0x51, 0x42, 0xd2, 0x1d, 0x07, 0xea, 0xcd, 0x68,
+		0x69, 0x4e, 0x43, 0x27, 0xda, 0x59, 0xd0, 0xcc, 0x82, 0x56, 0x79, 0xce, 0x31, 0x8c, 0x34, 0x20,
+		0xc2, 0x3b, 0x68, 0x91, 0xa5, 0xc1, 0x90, 0x56, 0xcc, 0x02, 0xbd, 0xfb, 0xcb, 0x64, 0x7b, 0x56,
+		0x63, 0xbc, 0x0a, 0x5a, 0xd9, 0x7e, 0xcd, 0x95, 0xeb, 0xd1, 0xa9, 0x0a, 0x74, 0xe0, 0x05, 0xe3,
+		0x1d, 0x1f, 0x97, 0x5b, 0x76, 0x29, 0xbf, 0x2d, 0x67, 0x18, 0xc1, 0xdc, 0x6f, 0xcb, 0xed, 0xd1,
+		0xdf, 0xcd, 0xf4, 0xf5, 0xf2, 0xab, 0x72, 0x80, 0x80, 0xc1, 0xb2, 0xee, 0xde, 0xaf, 0xca, 0xa5,
+		0xe4, 0x30, 0x7f, 0x27, 0x64, 0x61, 0x51, 0xbe, 0x16, 0x55, 0xbe, 0x16, 0xb5, 0x38, 0x94, 0xc2,
+		0xa5, 0x16, 0xfa, 0xc5, 0xf2, 0xd5, 0x28, 0x83, 0xb2, 0x97, 0xde, 0x26, 0x43, 0x5f, 0xb2, 0x10,
+		0xf0, 0x45, 0x86, 0xde, 0x43, 0xb7, 0xdf, 0xbd, 0xea, 0x7e, 0x35, 0x8d, 0xd0, 0x4c, 0xbf, 0x09,
+		0xad, 0x14, 0x46, 0xe0, 0xe3, 0xf9, 0xcb, 0x9b, 0x1e, 0x2d, 0xe2, 0x0c, 0x01, 0x70, 0x3a, 0x8f,
+		0xfd, 0x8b, 0xfe, 0xed, 0x15, 0xf6, 0x8b, 0x1e, 0x03, 0x57, 0xa4, 0x22, 0xa5, 0x41, 0x9c, 0xfa,
+		0x6a, 0x51, 0x48, 0xec, 0x94, 0x31, 0x0e, 0x5b, 0x2d, 0xe5, 0x15, 0x37, 0x24, 0x5b, 0xe0, 0xf5,
+		0x16, 0x5d, 0xf3, 0xea, 0xaa, 0xca, 0xca, 0xfc, 0xf2, 0xe6, 0x45, 0x45, 0x78, 0x15, 0x4c, 0xa6,
+		0x8a, 0x87, 0x21, 0xf7, 0x1f, 0x67, 0x73, 0xcb, 0xe4, 0x32, 0x2a, 0xc2, 0x2f, 0xec, 0x27, 0x7f,
+		0x08, 0x82, 0x6c, 0x9e, 0xdb, 0x5c, 0x0f, 0xad, 0x56, 0x72, 0xe6, 0xdb, 0x99, 0x7f, 0x77, 0x78,
+		0x3e, 0xa9, 0xca, 0xeb, 0x7f, 0x00, 0x00, 0x00, 0xff, 0xff, 0x03, 0x00, 0xd8, 0xce, 0x39, 0xaa,
+		0x96, 0x58, 0x00, 0x00,
+	}
+)
+
+// ΛEnumTypes is a map, keyed by a YANG schema path, of the enumerated types that
+// correspond with the leaf. The type is represented as a reflect.Type. The naming
+// of the map ensures that there are no clashes with valid YANG identifiers.
+func initΛEnumTypes() {
+	ΛEnumTypes = map[string][]reflect.Type{
+		"/interfaces/interface/config/admin-status": {
+			reflect.TypeOf((E_AdminStatus)(0)),
+		},
+		"/protocols/config/protocol": {
+			reflect.TypeOf((E_Protocol)(0)),
+		},
+	}
+}
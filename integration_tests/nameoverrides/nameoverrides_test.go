@@ -0,0 +1,62 @@
+// Copyright 2026 Google Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package nameoverrides
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/openconfig/ygot/ygot"
+)
+
+// TestOverriddenNames checks that the overridden Go names are usable and
+// that they do not change the data tree that is serialised or unmarshalled.
+func TestOverriddenNames(t *testing.T) {
+	d := &Device{}
+	intf := d.GetOrCreateInterface("eth0")
+	intf.Desc = ygot.String("uplink")
+	intf.AdminStatus = AdminStatus_Testing
+	intf.GetOrCreateGroup(1)
+	d.GetOrCreateProtocols().Protocol = Protocol_Bgp
+
+	if err := d.Validate(); err != nil {
+		t.Fatalf("Validate(): got unexpected error: %v", err)
+	}
+
+	got, err := ygot.EmitJSON(d, &ygot.EmitJSONConfig{
+		Format: ygot.RFC7951,
+		RFC7951Config: &ygot.RFC7951JSONConfig{
+			AppendModuleName: true,
+		},
+	})
+	if err != nil {
+		t.Fatalf("EmitJSON(): got unexpected error: %v", err)
+	}
+
+	for _, want := range []string{`"description": "uplink"`, `"TESTING-MODE"`, `"vrrp-group"`, `"name-overrides:BGP"`} {
+		if !strings.Contains(got, want) {
+			t.Errorf("EmitJSON(): did not find %s in output:\n%s", want, got)
+		}
+	}
+
+	rt := &Device{}
+	if err := Unmarshal([]byte(got), rt); err != nil {
+		t.Fatalf("Unmarshal(): got unexpected error: %v", err)
+	}
+	if diff := cmp.Diff(d, rt); diff != "" {
+		t.Errorf("Unmarshal(): did not get expected round-tripped struct, diff(-want, +got):\n%s", diff)
+	}
+}
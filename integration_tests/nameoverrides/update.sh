@@ -0,0 +1,17 @@
+#!/bin/bash
+
+go run ../../generator/generator.go -path="." -output_file=nameoverrides.go \
+  -package_name=nameoverrides -generate_fakeroot -fakeroot_name=device \
+  -compress_paths \
+  -name_overrides_file=overrides.json \
+  -generate_append \
+  -generate_delete \
+  -generate_getters \
+  -generate_leaf_getters \
+  -generate_leaf_setters \
+  -generate_populate_defaults \
+  -generate_rename \
+  -generate_rfc7951_methods \
+  -generate_equal_copy_diff \
+  ../../testdata/modules/name-overrides.yang
+gofmt -w -s nameoverrides.go
@@ -0,0 +1,3 @@
+package rfc7951methods
+
+//go:generate ./update.sh
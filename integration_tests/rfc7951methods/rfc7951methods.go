@@ -0,0 +1,1867 @@
+/*
+Package rfc7951methods is a generated package which contains definitions
+of structs which represent a YANG schema. The generated schema can be
+compressed by a series of transformations (compression was false
+in this case).
+
+This package was generated by /root/module/genutil/names.go
+using the following YANG input files:
+  - ../../testdata/modules/rfc7951-methods.yang
+  - ../../testdata/modules/rfc7951-methods-augment.yang
+
+Imported modules were sourced from:
+  - ...
+*/
+package rfc7951methods
+
+import (
+	"encoding/json"
+	"fmt"
+	"reflect"
+
+	"github.com/openconfig/goyang/pkg/yang"
+	"github.com/openconfig/ygot/ygot"
+	"github.com/openconfig/ygot/ytypes"
+)
+
+// Binary is a type that is used for fields that have a YANG type of
+// binary. It is used such that binary fields can be distinguished from
+// leaf-lists of uint8s (which are mapped to []uint8, equivalent to
+// []byte in reflection).
+type Binary []byte
+
+// YANGEmpty is a type that is used for fields that have a YANG type of
+// empty. It is used such that empty fields can be distinguished from boolean fields
+// in the generated code.
+type YANGEmpty bool
+
+// UnionInt8 is an int8 type assignable to unions of which it is a subtype.
+type UnionInt8 int8
+
+// UnionInt16 is an int16 type assignable to unions of which it is a subtype.
+type UnionInt16 int16
+
+// UnionInt32 is an int32 type assignable to unions of which it is a subtype.
+type UnionInt32 int32
+
+// UnionInt64 is an int64 type assignable to unions of which it is a subtype.
+type UnionInt64 int64
+
+// UnionUint8 is a uint8 type assignable to unions of which it is a subtype.
+type UnionUint8 uint8
+
+// UnionUint16 is a uint16 type assignable to unions of which it is a subtype.
+type UnionUint16 uint16
+
+// UnionUint32 is a uint32 type assignable to unions of which it is a subtype.
+type UnionUint32 uint32
+
+// UnionUint64 is a uint64 type assignable to unions of which it is a subtype.
+type UnionUint64 uint64
+
+// UnionFloat64 is a float64 type assignable to unions of which it is a subtype.
+type UnionFloat64 float64
+
+// UnionString is a string type assignable to unions of which it is a subtype.
+type UnionString string
+
+// UnionBool is a bool type assignable to unions of which it is a subtype.
+type UnionBool bool
+
+// UnionUnsupported is an interface{} wrapper type for unsupported types. It is
+// assignable to unions of which it is a subtype.
+type UnionUnsupported struct {
+	Value interface{}
+}
+
+var (
+	SchemaTree map[string]*yang.Entry
+	ΛEnumTypes map[string][]reflect.Type
+)
+
+func init() {
+	var err error
+	initΛEnumTypes()
+	if SchemaTree, err = UnzipSchema(); err != nil {
+		panic("schema error: " + err.Error())
+	}
+}
+
+// Schema returns the details of the generated schema.
+func Schema() (*ytypes.Schema, error) {
+	uzp, err := UnzipSchema()
+	if err != nil {
+		return nil, fmt.Errorf("cannot unzip schema, %v", err)
+	}
+
+	return &ytypes.Schema{
+		Root:       &Device{},
+		SchemaTree: uzp,
+		Unmarshal:  Unmarshal,
+	}, nil
+}
+
+// UnzipSchema unzips the zipped schema and returns a map of yang.Entry nodes,
+// keyed by the name of the struct that the yang.Entry describes the schema for.
+func UnzipSchema() (map[string]*yang.Entry, error) {
+	var schemaTree map[string]*yang.Entry
+	var err error
+	if schemaTree, err = ygot.GzipToSchema(ySchema); err != nil {
+		return nil, fmt.Errorf("could not unzip the schema; %v", err)
+	}
+	return schemaTree, nil
+}
+
+// Unmarshal unmarshals data, which must be RFC7951 JSON format, into
+// destStruct, which must be non-nil and the correct GoStruct type. It returns
+// an error if the destStruct is not found in the schema or the data cannot be
+// unmarshaled. The supplied options (opts) are used to control the behaviour
+// of the unmarshal function - for example, determining whether errors are
+// thrown for unknown fields in the input JSON.
+func Unmarshal(data []byte, destStruct ygot.GoStruct, opts ...ytypes.UnmarshalOpt) error {
+	tn := reflect.TypeOf(destStruct).Elem().Name()
+	schema, ok := SchemaTree[tn]
+	if !ok {
+		return fmt.Errorf("could not find schema for type %s", tn)
+	}
+	var jsonTree interface{}
+	if err := json.Unmarshal([]byte(data), &jsonTree); err != nil {
+		return err
+	}
+	return ytypes.Unmarshal(schema, destStruct, jsonTree, opts...)
+}
+
+// Device represents the /device YANG schema element.
+type Device struct {
+	Top *Rfc7951Methods_Top `path:"top" module:"rfc7951-methods"`
+}
+
+// IsYANGGoStruct ensures that Device implements the yang.GoStruct
+// interface. This allows functions that need to handle this struct to
+// identify it as being generated by ygen.
+func (*Device) IsYANGGoStruct() {}
+
+// GetOrCreateTop retrieves the value of the Top field
+// or returns the existing field if it already exists.
+func (t *Device) GetOrCreateTop() *Rfc7951Methods_Top {
+	if t.Top != nil {
+		return t.Top
+	}
+	t.Top = &Rfc7951Methods_Top{}
+	return t.Top
+}
+
+// GetTop returns the value of the Top struct pointer
+// from Device. If the receiver or the field Top is nil, nil
+// is returned such that the Get* methods can be safely chained.
+func (t *Device) GetTop() *Rfc7951Methods_Top {
+	if t != nil && t.Top != nil {
+		return t.Top
+	}
+	return nil
+}
+
+// Validate validates s against the YANG schema corresponding to its type.
+func (t *Device) ΛValidate(opts ...ygot.ValidationOption) error {
+	if err := ytypes.Validate(SchemaTree["Device"], t, opts...); err != nil {
+		return err
+	}
+	return nil
+}
+
+// Validate validates s against the YANG schema corresponding to its type.
+func (t *Device) Validate(opts ...ygot.ValidationOption) error {
+	return t.ΛValidate(opts...)
+}
+
+// ΛEnumTypeMap returns a map, keyed by YANG schema path, of the enumerated types
+// that are included in the generated code.
+func (t *Device) ΛEnumTypeMap() map[string][]reflect.Type { return ΛEnumTypes }
+
+// MarshalRFC7951 writes the contents of Device to the supplied
+// encoder as RFC7951 JSON. It implements the ygot.RFC7951Marshaler interface.
+func (t *Device) MarshalRFC7951(e *ygot.RFC7951Encoder) error {
+	if t.Top != nil {
+		if err := e.Struct([][]string{{"top"}}, [][]string{{"rfc7951-methods"}}, t.Top, false); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// UnmarshalRFC7951 unmarshals the supplied RFC7951 JSON object into
+// Device. It implements the ytypes.RFC7951Unmarshaler interface.
+func (t *Device) UnmarshalRFC7951(d *ytypes.RFC7951Decoder, jsonTree map[string]interface{}) error {
+	if err := d.Field(t, "Top", jsonTree); err != nil {
+		return err
+	}
+	return nil
+}
+
+// ΛBelongingModule returns the name of the module that defines the namespace
+// of Device.
+func (*Device) ΛBelongingModule() string {
+	return ""
+}
+
+// Rfc7951Methods_Top represents the /rfc7951-methods/top YANG schema element.
+type Rfc7951Methods_Top struct {
+	Bin    Binary                                                   `path:"bin" module:"rfc7951-methods"`
+	Child  *Rfc7951Methods_Top_Child                                `path:"child" module:"rfc7951-methods" yangPresence:"true"`
+	Color  E_Rfc7951Methods_Top_Color                               `path:"color" module:"rfc7951-methods"`
+	Counts []uint16                                                 `path:"counts" module:"rfc7951-methods"`
+	Data   *ygot.AnyData                                            `path:"data" module:"rfc7951-methods"`
+	Dec    *float64                                                 `path:"dec" module:"rfc7951-methods"`
+	Entry  map[string]*Rfc7951Methods_Top_Entry                     `path:"entry" module:"rfc7951-methods"`
+	Extra  *string                                                  `path:"extra" module:"rfc7951-methods-augment"`
+	Flag   *bool                                                    `path:"flag" module:"rfc7951-methods"`
+	I64    *int64                                                   `path:"i64" module:"rfc7951-methods"`
+	I8     *int8                                                    `path:"i8" module:"rfc7951-methods"`
+	Kind   E_Rfc7951Methods_BASE                                    `path:"kind" module:"rfc7951-methods"`
+	Kinds  []E_Rfc7951Methods_BASE                                  `path:"kinds" module:"rfc7951-methods"`
+	Mixed  Rfc7951Methods_Top_Mixed_Union                           `path:"mixed" module:"rfc7951-methods"`
+	Mixes  []Rfc7951Methods_Top_Mixes_Union                         `path:"mixes" module:"rfc7951-methods"`
+	More   *Rfc7951Methods_Top_More                                 `path:"more" module:"rfc7951-methods-augment"`
+	Names  []string                                                 `path:"names" module:"rfc7951-methods"`
+	On     YANGEmpty                                                `path:"on" module:"rfc7951-methods"`
+	Pair   map[Rfc7951Methods_Top_Pair_Key]*Rfc7951Methods_Top_Pair `path:"pair" module:"rfc7951-methods"`
+	Sample []*Rfc7951Methods_Top_Sample                             `path:"sample" module:"rfc7951-methods"`
+	Step   *Rfc7951Methods_Top_Step_OrderedMap                      `path:"step" module:"rfc7951-methods"`
+	Str    *string                                                  `path:"str" module:"rfc7951-methods"`
+	U32    *uint32                                                  `path:"u32" module:"rfc7951-methods"`
+	U64    *uint64                                                  `path:"u64" module:"rfc7951-methods"`
+}
+
+// IsYANGGoStruct ensures that Rfc7951Methods_Top implements the yang.GoStruct
+// interface. This allows functions that need to handle this struct to
+// identify it as being generated by ygen.
+func (*Rfc7951Methods_Top) IsYANGGoStruct() {}
+
+// Rfc7951Methods_Top_Pair_Key represents the key for list Pair of element /rfc7951-methods/top.
+type Rfc7951Methods_Top_Pair_Key struct {
+	A string `path:"a"`
+	B uint32 `path:"b"`
+}
+
+// IsYANGGoKeyStruct ensures that Rfc7951Methods_Top_Pair_Key partially implements the
+// yang.GoKeyStruct interface. This allows functions that need to
+// handle this key struct to identify it as being generated by gogen.
+func (Rfc7951Methods_Top_Pair_Key) IsYANGGoKeyStruct() {}
+
+// ΛListKeyMap returns the values of the Rfc7951Methods_Top_Pair_Key key struct.
+func (t Rfc7951Methods_Top_Pair_Key) ΛListKeyMap() (map[string]interface{}, error) {
+	return map[string]interface{}{
+		"a": t.A,
+		"b": t.B,
+	}, nil
+}
+
+// NewEntry creates a new entry in the Entry list of the
+// Rfc7951Methods_Top struct. The keys of the list are populated from the input
+// arguments.
+func (t *Rfc7951Methods_Top) NewEntry(Name string) (*Rfc7951Methods_Top_Entry, error) {
+
+	// Initialise the list within the receiver struct if it has not already been
+	// created.
+	if t.Entry == nil {
+		t.Entry = make(map[string]*Rfc7951Methods_Top_Entry)
+	}
+
+	key := Name
+
+	// Ensure that this key has not already been used in the
+	// list. Keyed YANG lists do not allow duplicate keys to
+	// be created.
+	if _, ok := t.Entry[key]; ok {
+		return nil, fmt.Errorf("duplicate key %v for list Entry", key)
+	}
+
+	t.Entry[key] = &Rfc7951Methods_Top_Entry{
+		Name: &Name,
+	}
+
+	return t.Entry[key], nil
+}
+
+// GetOrCreateEntryMap returns the list (map) from Rfc7951Methods_Top.
+//
+// It initializes the field if not already initialized.
+func (t *Rfc7951Methods_Top) GetOrCreateEntryMap() map[string]*Rfc7951Methods_Top_Entry {
+	if t.Entry == nil {
+		t.Entry = make(map[string]*Rfc7951Methods_Top_Entry)
+	}
+	return t.Entry
+}
+
+// GetOrCreateEntry retrieves the value with the specified keys from
+// the receiver Rfc7951Methods_Top. If the entry does not exist, then it is created.
+// It returns the existing or new list member.
+func (t *Rfc7951Methods_Top) GetOrCreateEntry(Name string) *Rfc7951Methods_Top_Entry {
+
+	key := Name
+
+	if v, ok := t.Entry[key]; ok {
+		return v
+	}
+	// Panic if we receive an error, since we should have retrieved an existing
+	// list member. This allows chaining of GetOrCreate methods.
+	v, err := t.NewEntry(Name)
+	if err != nil {
+		panic(fmt.Sprintf("GetOrCreateEntry got unexpected error: %v", err))
+	}
+	return v
+}
+
+// GetEntry retrieves the value with the specified key from
+// the Entry map field of Rfc7951Methods_Top. If the receiver is nil, or
+// the specified key is not present in the list, nil is returned such that Get*
+// methods may be safely chained.
+func (t *Rfc7951Methods_Top) GetEntry(Name string) *Rfc7951Methods_Top_Entry {
+
+	if t == nil {
+		return nil
+	}
+
+	key := Name
+
+	if lm, ok := t.Entry[key]; ok {
+		return lm
+	}
+	return nil
+}
+
+// AppendEntry appends the supplied Rfc7951Methods_Top_Entry struct to the
+// list Entry of Rfc7951Methods_Top. If the key value(s) specified in
+// the supplied Rfc7951Methods_Top_Entry already exist in the list, an error is
+// returned.
+func (t *Rfc7951Methods_Top) AppendEntry(v *Rfc7951Methods_Top_Entry) error {
+	if v.Name == nil {
+		return fmt.Errorf("invalid nil key received for Name")
+	}
+
+	key := *v.Name
+
+	// Initialise the list within the receiver struct if it has not already been
+	// created.
+	if t.Entry == nil {
+		t.Entry = make(map[string]*Rfc7951Methods_Top_Entry)
+	}
+
+	if _, ok := t.Entry[key]; ok {
+		return fmt.Errorf("duplicate key for list Entry %v", key)
+	}
+
+	t.Entry[key] = v
+	return nil
+}
+
+// NewPair creates a new entry in the Pair list of the
+// Rfc7951Methods_Top struct. The keys of the list are populated from the input
+// arguments.
+func (t *Rfc7951Methods_Top) NewPair(A string, B uint32) (*Rfc7951Methods_Top_Pair, error) {
+
+	// Initialise the list within the receiver struct if it has not already been
+	// created.
+	if t.Pair == nil {
+		t.Pair = make(map[Rfc7951Methods_Top_Pair_Key]*Rfc7951Methods_Top_Pair)
+	}
+
+	key := Rfc7951Methods_Top_Pair_Key{
+		A: A,
+		B: B,
+	}
+
+	// Ensure that this key has not already been used in the
+	// list. Keyed YANG lists do not allow duplicate keys to
+	// be created.
+	if _, ok := t.Pair[key]; ok {
+		return nil, fmt.Errorf("duplicate key %v for list Pair", key)
+	}
+
+	t.Pair[key] = &Rfc7951Methods_Top_Pair{
+		A: &A,
+		B: &B,
+	}
+
+	return t.Pair[key], nil
+}
+
+// GetOrCreatePairMap returns the list (map) from Rfc7951Methods_Top.
+//
+// It initializes the field if not already initialized.
+func (t *Rfc7951Methods_Top) GetOrCreatePairMap() map[Rfc7951Methods_Top_Pair_Key]*Rfc7951Methods_Top_Pair {
+	if t.Pair == nil {
+		t.Pair = make(map[Rfc7951Methods_Top_Pair_Key]*Rfc7951Methods_Top_Pair)
+	}
+	return t.Pair
+}
+
+// GetOrCreatePair retrieves the value with the specified keys from
+// the receiver Rfc7951Methods_Top. If the entry does not exist, then it is created.
+// It returns the existing or new list member.
+func (t *Rfc7951Methods_Top) GetOrCreatePair(A string, B uint32) *Rfc7951Methods_Top_Pair {
+
+	key := Rfc7951Methods_Top_Pair_Key{
+		A: A,
+		B: B,
+	}
+
+	if v, ok := t.Pair[key]; ok {
+		return v
+	}
+	// Panic if we receive an error, since we should have retrieved an existing
+	// list member. This allows chaining of GetOrCreate methods.
+	v, err := t.NewPair(A, B)
+	if err != nil {
+		panic(fmt.Sprintf("GetOrCreatePair got unexpected error: %v", err))
+	}
+	return v
+}
+
+// GetPair retrieves the value with the specified key from
+// the Pair map field of Rfc7951Methods_Top. If the receiver is nil, or
+// the specified key is not present in the list, nil is returned such that Get*
+// methods may be safely chained.
+func (t *Rfc7951Methods_Top) GetPair(A string, B uint32) *Rfc7951Methods_Top_Pair {
+
+	if t == nil {
+		return nil
+	}
+
+	key := Rfc7951Methods_Top_Pair_Key{
+		A: A,
+		B: B,
+	}
+
+	if lm, ok := t.Pair[key]; ok {
+		return lm
+	}
+	return nil
+}
+
+// AppendPair appends the supplied Rfc7951Methods_Top_Pair struct to the
+// list Pair of Rfc7951Methods_Top. If the key value(s) specified in
+// the supplied Rfc7951Methods_Top_Pair already exist in the list, an error is
+// returned.
+func (t *Rfc7951Methods_Top) AppendPair(v *Rfc7951Methods_Top_Pair) error {
+	if v.A == nil {
+		return fmt.Errorf("invalid nil key for A")
+	}
+
+	if v.B == nil {
+		return fmt.Errorf("invalid nil key for B")
+	}
+
+	key := Rfc7951Methods_Top_Pair_Key{
+		A: *v.A,
+		B: *v.B,
+	}
+
+	// Initialise the list within the receiver struct if it has not already been
+	// created.
+	if t.Pair == nil {
+		t.Pair = make(map[Rfc7951Methods_Top_Pair_Key]*Rfc7951Methods_Top_Pair)
+	}
+
+	if _, ok := t.Pair[key]; ok {
+		return fmt.Errorf("duplicate key for list Pair %v", key)
+	}
+
+	t.Pair[key] = v
+	return nil
+}
+
+// GetOrCreateChild retrieves the value of the Child field
+// or returns the existing field if it already exists.
+func (t *Rfc7951Methods_Top) GetOrCreateChild() *Rfc7951Methods_Top_Child {
+	if t.Child != nil {
+		return t.Child
+	}
+	t.Child = &Rfc7951Methods_Top_Child{}
+	return t.Child
+}
+
+// GetOrCreateMore retrieves the value of the More field
+// or returns the existing field if it already exists.
+func (t *Rfc7951Methods_Top) GetOrCreateMore() *Rfc7951Methods_Top_More {
+	if t.More != nil {
+		return t.More
+	}
+	t.More = &Rfc7951Methods_Top_More{}
+	return t.More
+}
+
+// GetChild returns the value of the Child struct pointer
+// from Rfc7951Methods_Top. If the receiver or the field Child is nil, nil
+// is returned such that the Get* methods can be safely chained.
+func (t *Rfc7951Methods_Top) GetChild() *Rfc7951Methods_Top_Child {
+	if t != nil && t.Child != nil {
+		return t.Child
+	}
+	return nil
+}
+
+// GetMore returns the value of the More struct pointer
+// from Rfc7951Methods_Top. If the receiver or the field More is nil, nil
+// is returned such that the Get* methods can be safely chained.
+func (t *Rfc7951Methods_Top) GetMore() *Rfc7951Methods_Top_More {
+	if t != nil && t.More != nil {
+		return t.More
+	}
+	return nil
+}
+
+// GetOrCreateStepMap returns the ordered map field
+// Step from Rfc7951Methods_Top.
+//
+// It initializes the field if not already initialized.
+func (s *Rfc7951Methods_Top) GetOrCreateStepMap() *Rfc7951Methods_Top_Step_OrderedMap {
+	if s.Step == nil {
+		s.Step = &Rfc7951Methods_Top_Step_OrderedMap{}
+	}
+	return s.Step
+}
+
+// AppendNewStep creates a new entry in the Step
+// ordered map of the Rfc7951Methods_Top struct. The keys of the list are
+// populated from the input arguments.
+func (s *Rfc7951Methods_Top) AppendNewStep(Id uint32) (*Rfc7951Methods_Top_Step, error) {
+	if s.Step == nil {
+		s.Step = &Rfc7951Methods_Top_Step_OrderedMap{}
+	}
+	return s.Step.AppendNew(Id)
+}
+
+// AppendStep appends the supplied Rfc7951Methods_Top_Step struct
+// to the list Step of Rfc7951Methods_Top. If the key value(s)
+// specified in the supplied Rfc7951Methods_Top_Step already exist in the list, an
+// error is returned.
+func (s *Rfc7951Methods_Top) AppendStep(v *Rfc7951Methods_Top_Step) error {
+	if s.Step == nil {
+		s.Step = &Rfc7951Methods_Top_Step_OrderedMap{}
+	}
+	return s.Step.Append(v)
+}
+
+// GetStep retrieves the value with the specified key from the
+// Step map field of Rfc7951Methods_Top. If the receiver
+// is nil, or the specified key is not present in the list, nil is returned
+// such that Get* methods may be safely chained.
+func (s *Rfc7951Methods_Top) GetStep(Id uint32) *Rfc7951Methods_Top_Step {
+	if s == nil {
+		return nil
+	}
+	key := Id
+	return s.Step.Get(key)
+}
+
+// DeleteStep deletes the value with the specified keys from
+// the receiver Rfc7951Methods_Top. If there is no such element, the
+// function is a no-op.
+func (s *Rfc7951Methods_Top) DeleteStep(Id uint32) bool {
+	key := Id
+	return s.Step.Delete(key)
+}
+
+// Rfc7951Methods_Top_Step_OrderedMap is an ordered map that represents the "ordered-by user"
+// list elements at /rfc7951-methods/top/step.
+type Rfc7951Methods_Top_Step_OrderedMap struct {
+	keys     []uint32
+	valueMap map[uint32]*Rfc7951Methods_Top_Step
+}
+
+// IsYANGOrderedList ensures that Rfc7951Methods_Top_Step_OrderedMap implements the
+// ygot.GoOrderedMap interface.
+func (*Rfc7951Methods_Top_Step_OrderedMap) IsYANGOrderedList() {}
+
+// init initializes any uninitialized values.
+func (o *Rfc7951Methods_Top_Step_OrderedMap) init() {
+	if o == nil {
+		return
+	}
+	if o.valueMap == nil {
+		o.valueMap = map[uint32]*Rfc7951Methods_Top_Step{}
+	}
+}
+
+// Keys returns a copy of the list's keys.
+func (o *Rfc7951Methods_Top_Step_OrderedMap) Keys() []uint32 {
+	if o == nil {
+		return nil
+	}
+	return append([]uint32{}, o.keys...)
+}
+
+// Values returns the current set of the list's values in order.
+func (o *Rfc7951Methods_Top_Step_OrderedMap) Values() []*Rfc7951Methods_Top_Step {
+	if o == nil {
+		return nil
+	}
+	var values []*Rfc7951Methods_Top_Step
+	for _, key := range o.keys {
+		values = append(values, o.valueMap[key])
+	}
+	return values
+}
+
+// Len returns a size of Rfc7951Methods_Top_Step_OrderedMap
+func (o *Rfc7951Methods_Top_Step_OrderedMap) Len() int {
+	if o == nil {
+		return 0
+	}
+	return len(o.keys)
+}
+
+// Get returns the value corresponding to the key. If the key is not found, nil
+// is returned.
+func (o *Rfc7951Methods_Top_Step_OrderedMap) Get(key uint32) *Rfc7951Methods_Top_Step {
+	if o == nil {
+		return nil
+	}
+	val, _ := o.valueMap[key]
+	return val
+}
+
+// Delete deletes an element.
+func (o *Rfc7951Methods_Top_Step_OrderedMap) Delete(key uint32) bool {
+	if o == nil {
+		return false
+	}
+	if _, ok := o.valueMap[key]; !ok {
+		return false
+	}
+	for i, k := range o.keys {
+		if k == key {
+			o.keys = append(o.keys[:i], o.keys[i+1:]...)
+			delete(o.valueMap, key)
+			return true
+		}
+	}
+	return false
+}
+
+// Append appends a Rfc7951Methods_Top_Step, returning an error if the key
+// already exists in the ordered list or if the key is unspecified.
+func (o *Rfc7951Methods_Top_Step_OrderedMap) Append(v *Rfc7951Methods_Top_Step) error {
+	if o == nil {
+		return fmt.Errorf("nil ordered map, cannot append Rfc7951Methods_Top_Step")
+	}
+	if v == nil {
+		return fmt.Errorf("nil Rfc7951Methods_Top_Step")
+	}
+	if v.Id == nil {
+		return fmt.Errorf("invalid nil key received for Id")
+	}
+
+	key := *v.Id
+
+	if _, ok := o.valueMap[key]; ok {
+		return fmt.Errorf("duplicate key for list Statement %v", key)
+	}
+	o.keys = append(o.keys, key)
+	o.init()
+	o.valueMap[key] = v
+	return nil
+}
+
+// AppendNew creates and appends a new Rfc7951Methods_Top_Step, returning the
+// newly-initialized v. It returns an error if the v already exists.
+func (o *Rfc7951Methods_Top_Step_OrderedMap) AppendNew(Id uint32) (*Rfc7951Methods_Top_Step, error) {
+	if o == nil {
+		return nil, fmt.Errorf("nil ordered map, cannot append Rfc7951Methods_Top_Step")
+	}
+	key := Id
+
+	if _, ok := o.valueMap[key]; ok {
+		return nil, fmt.Errorf("duplicate key for list Statement %v", key)
+	}
+	o.keys = append(o.keys, key)
+	newElement := &Rfc7951Methods_Top_Step{
+		Id: &Id,
+	}
+	o.init()
+	o.valueMap[key] = newElement
+	return newElement, nil
+}
+
+// Validate validates s against the YANG schema corresponding to its type.
+func (t *Rfc7951Methods_Top) ΛValidate(opts ...ygot.ValidationOption) error {
+	if err := ytypes.Validate(SchemaTree["Rfc7951Methods_Top"], t, opts...); err != nil {
+		return err
+	}
+	return nil
+}
+
+// Validate validates s against the YANG schema corresponding to its type.
+func (t *Rfc7951Methods_Top) Validate(opts ...ygot.ValidationOption) error {
+	return t.ΛValidate(opts...)
+}
+
+// ΛEnumTypeMap returns a map, keyed by YANG schema path, of the enumerated types
+// that are included in the generated code.
+func (t *Rfc7951Methods_Top) ΛEnumTypeMap() map[string][]reflect.Type { return ΛEnumTypes }
+
+// MarshalRFC7951 writes the contents of Rfc7951Methods_Top to the supplied
+// encoder as RFC7951 JSON. It implements the ygot.RFC7951Marshaler interface.
+func (t *Rfc7951Methods_Top) MarshalRFC7951(e *ygot.RFC7951Encoder) error {
+	if t.Bin != nil {
+		if err := e.Set([][]string{{"bin"}}, [][]string{{"rfc7951-methods"}}, ygot.RFC7951Binary(t.Bin)); err != nil {
+			return err
+		}
+	}
+	if t.Child != nil {
+		if err := e.Struct([][]string{{"child"}}, [][]string{{"rfc7951-methods"}}, t.Child, true); err != nil {
+			return err
+		}
+	}
+	if t.Color != 0 {
+		v, err := e.Enum(t.Color, "E_Rfc7951Methods_Top_Color", int64(t.Color))
+		if err != nil {
+			return err
+		}
+		if err := e.Set([][]string{{"color"}}, [][]string{{"rfc7951-methods"}}, v); err != nil {
+			return err
+		}
+	}
+	if t.Counts != nil {
+		l := make([]interface{}, 0, len(t.Counts))
+		for _, v := range t.Counts {
+			l = append(l, float64(v))
+		}
+		if err := e.Set([][]string{{"counts"}}, [][]string{{"rfc7951-methods"}}, l); err != nil {
+			return err
+		}
+	}
+	if err := e.Field([][]string{{"data"}}, [][]string{{"rfc7951-methods"}}, &t.Data); err != nil {
+		return err
+	}
+	if t.Dec != nil {
+		if err := e.Set([][]string{{"dec"}}, [][]string{{"rfc7951-methods"}}, ygot.RFC7951Decimal64(*t.Dec)); err != nil {
+			return err
+		}
+	}
+	if t.Entry != nil {
+		l := make([]ygot.RFC7951ListElement, 0, len(t.Entry))
+		for k, v := range t.Entry {
+			l = append(l, ygot.RFC7951ListElement{Key: k, Value: v})
+		}
+		if err := e.List([][]string{{"entry"}}, [][]string{{"rfc7951-methods"}}, l); err != nil {
+			return err
+		}
+	}
+	if t.Extra != nil {
+		if err := e.Set([][]string{{"extra"}}, [][]string{{"rfc7951-methods-augment"}}, ygot.RFC7951String(*t.Extra)); err != nil {
+			return err
+		}
+	}
+	if t.Flag != nil {
+		if err := e.Set([][]string{{"flag"}}, [][]string{{"rfc7951-methods"}}, *t.Flag); err != nil {
+			return err
+		}
+	}
+	if t.I64 != nil {
+		if err := e.Set([][]string{{"i64"}}, [][]string{{"rfc7951-methods"}}, ygot.RFC7951Int64(*t.I64)); err != nil {
+			return err
+		}
+	}
+	if t.I8 != nil {
+		if err := e.Set([][]string{{"i8"}}, [][]string{{"rfc7951-methods"}}, float64(*t.I8)); err != nil {
+			return err
+		}
+	}
+	if t.Kind != 0 {
+		v, err := e.Enum(t.Kind, "E_Rfc7951Methods_BASE", int64(t.Kind))
+		if err != nil {
+			return err
+		}
+		if err := e.Set([][]string{{"kind"}}, [][]string{{"rfc7951-methods"}}, v); err != nil {
+			return err
+		}
+	}
+	if t.Kinds != nil {
+		l := make([]interface{}, 0, len(t.Kinds))
+		for _, v := range t.Kinds {
+			s, err := e.Enum(v, "E_Rfc7951Methods_BASE", int64(v))
+			if err != nil {
+				return err
+			}
+			l = append(l, s)
+		}
+		if err := e.Set([][]string{{"kinds"}}, [][]string{{"rfc7951-methods"}}, l); err != nil {
+			return err
+		}
+	}
+	switch u := t.Mixed.(type) {
+	case nil:
+	case E_Rfc7951Methods_Top_Mixed:
+		if u != 0 {
+			v, err := e.Enum(u, "E_Rfc7951Methods_Top_Mixed", int64(u))
+			if err != nil {
+				return err
+			}
+			if err := e.Set([][]string{{"mixed"}}, [][]string{{"rfc7951-methods"}}, v); err != nil {
+				return err
+			}
+		}
+	case UnionInt32:
+		if err := e.Set([][]string{{"mixed"}}, [][]string{{"rfc7951-methods"}}, float64(int32(u))); err != nil {
+			return err
+		}
+	case UnionString:
+		if err := e.Set([][]string{{"mixed"}}, [][]string{{"rfc7951-methods"}}, ygot.RFC7951String(string(u))); err != nil {
+			return err
+		}
+	default:
+		if err := e.Field([][]string{{"mixed"}}, [][]string{{"rfc7951-methods"}}, &t.Mixed); err != nil {
+			return err
+		}
+	}
+	if err := e.Field([][]string{{"mixes"}}, [][]string{{"rfc7951-methods"}}, &t.Mixes); err != nil {
+		return err
+	}
+	if t.More != nil {
+		if err := e.Struct([][]string{{"more"}}, [][]string{{"rfc7951-methods-augment"}}, t.More, false); err != nil {
+			return err
+		}
+	}
+	if t.Names != nil {
+		l := make([]interface{}, 0, len(t.Names))
+		for _, v := range t.Names {
+			l = append(l, ygot.RFC7951String(v))
+		}
+		if err := e.Set([][]string{{"names"}}, [][]string{{"rfc7951-methods"}}, l); err != nil {
+			return err
+		}
+	}
+	if t.On {
+		if err := e.Set([][]string{{"on"}}, [][]string{{"rfc7951-methods"}}, []interface{}{nil}); err != nil {
+			return err
+		}
+	}
+	if t.Pair != nil {
+		l := make([]ygot.RFC7951ListElement, 0, len(t.Pair))
+		for k, v := range t.Pair {
+			l = append(l, ygot.RFC7951ListElement{Key: k, Value: v})
+		}
+		if err := e.List([][]string{{"pair"}}, [][]string{{"rfc7951-methods"}}, l); err != nil {
+			return err
+		}
+	}
+	if t.Sample != nil {
+		l := []ygot.GoStruct{}
+		for _, v := range t.Sample {
+			l = append(l, v)
+		}
+		if err := e.OrderedList([][]string{{"sample"}}, [][]string{{"rfc7951-methods"}}, l); err != nil {
+			return err
+		}
+	}
+	if t.Step != nil {
+		l := []ygot.GoStruct{}
+		for _, v := range t.Step.Values() {
+			l = append(l, v)
+		}
+		if err := e.OrderedList([][]string{{"step"}}, [][]string{{"rfc7951-methods"}}, l); err != nil {
+			return err
+		}
+	}
+	if t.Str != nil {
+		if err := e.Set([][]string{{"str"}}, [][]string{{"rfc7951-methods"}}, ygot.RFC7951String(*t.Str)); err != nil {
+			return err
+		}
+	}
+	if t.U32 != nil {
+		if err := e.Set([][]string{{"u32"}}, [][]string{{"rfc7951-methods"}}, float64(*t.U32)); err != nil {
+			return err
+		}
+	}
+	if t.U64 != nil {
+		if err := e.Set([][]string{{"u64"}}, [][]string{{"rfc7951-methods"}}, ygot.RFC7951Uint64(*t.U64)); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// UnmarshalRFC7951 unmarshals the supplied RFC7951 JSON object into
+// Rfc7951Methods_Top. It implements the ytypes.RFC7951Unmarshaler interface.
+func (t *Rfc7951Methods_Top) UnmarshalRFC7951(d *ytypes.RFC7951Decoder, jsonTree map[string]interface{}) error {
+	if v, err := d.Value(jsonTree, [][]string{{"bin"}}, nil); err != nil {
+		return err
+	} else if v != nil {
+		x, err := d.Binary("bin", v)
+		if err != nil {
+			return err
+		}
+		t.Bin = Binary(x)
+	}
+	if err := d.Field(t, "Child", jsonTree); err != nil {
+		return err
+	}
+	if v, err := d.Value(jsonTree, [][]string{{"color"}}, nil); err != nil {
+		return err
+	} else if v != nil {
+		x, err := d.Enum("color", "Color", E_Rfc7951Methods_Top_Color(0), "E_Rfc7951Methods_Top_Color", v)
+		if err != nil {
+			return err
+		}
+		t.Color = E_Rfc7951Methods_Top_Color(x)
+	}
+	if v, err := d.Value(jsonTree, [][]string{{"counts"}}, nil); err != nil {
+		return err
+	} else if v != nil {
+		l, err := d.LeafList("counts", v)
+		if err != nil {
+			return err
+		}
+		t.Counts = nil
+		for _, v := range l {
+			if v == nil {
+				continue
+			}
+			x, err := d.Uint16("counts", v)
+			if err != nil {
+				return err
+			}
+			t.Counts = append(t.Counts, x)
+		}
+	}
+	if err := d.Field(t, "Data", jsonTree); err != nil {
+		return err
+	}
+	if v, err := d.Value(jsonTree, [][]string{{"dec"}}, nil); err != nil {
+		return err
+	} else if v != nil {
+		x, err := d.Decimal64("dec", v)
+		if err != nil {
+			return err
+		}
+		t.Dec = &x
+	}
+	if err := d.Field(t, "Entry", jsonTree); err != nil {
+		return err
+	}
+	if v, err := d.Value(jsonTree, [][]string{{"extra"}}, nil); err != nil {
+		return err
+	} else if v != nil {
+		x, err := d.String("extra", v)
+		if err != nil {
+			return err
+		}
+		t.Extra = &x
+	}
+	if v, err := d.Value(jsonTree, [][]string{{"flag"}}, nil); err != nil {
+		return err
+	} else if v != nil {
+		x, err := d.Bool("flag", v)
+		if err != nil {
+			return err
+		}
+		t.Flag = &x
+	}
+	if v, err := d.Value(jsonTree, [][]string{{"i64"}}, nil); err != nil {
+		return err
+	} else if v != nil {
+		x, err := d.Int64("i64", v)
+		if err != nil {
+			return err
+		}
+		t.I64 = &x
+	}
+	if v, err := d.Value(jsonTree, [][]string{{"i8"}}, nil); err != nil {
+		return err
+	} else if v != nil {
+		x, err := d.Int8("i8", v)
+		if err != nil {
+			return err
+		}
+		t.I8 = &x
+	}
+	if v, err := d.Value(jsonTree, [][]string{{"kind"}}, nil); err != nil {
+		return err
+	} else if v != nil {
+		x, err := d.Enum("kind", "Kind", E_Rfc7951Methods_BASE(0), "E_Rfc7951Methods_BASE", v)
+		if err != nil {
+			return err
+		}
+		t.Kind = E_Rfc7951Methods_BASE(x)
+	}
+	if v, err := d.Value(jsonTree, [][]string{{"kinds"}}, nil); err != nil {
+		return err
+	} else if v != nil {
+		l, err := d.LeafList("kinds", v)
+		if err != nil {
+			return err
+		}
+		t.Kinds = nil
+		for _, v := range l {
+			if v == nil {
+				continue
+			}
+			x, err := d.Enum("kinds", "Kinds", E_Rfc7951Methods_BASE(0), "E_Rfc7951Methods_BASE", v)
+			if err != nil {
+				return err
+			}
+			t.Kinds = append(t.Kinds, E_Rfc7951Methods_BASE(x))
+		}
+	}
+	if err := d.Field(t, "Mixed", jsonTree); err != nil {
+		return err
+	}
+	if err := d.Field(t, "Mixes", jsonTree); err != nil {
+		return err
+	}
+	if err := d.Field(t, "More", jsonTree); err != nil {
+		return err
+	}
+	if v, err := d.Value(jsonTree, [][]string{{"names"}}, nil); err != nil {
+		return err
+	} else if v != nil {
+		l, err := d.LeafList("names", v)
+		if err != nil {
+			return err
+		}
+		t.Names = nil
+		for _, v := range l {
+			if v == nil {
+				continue
+			}
+			x, err := d.String("names", v)
+			if err != nil {
+				return err
+			}
+			t.Names = append(t.Names, x)
+		}
+	}
+	if v, err := d.Value(jsonTree, [][]string{{"on"}}, nil); err != nil {
+		return err
+	} else if v != nil {
+		x, err := d.Empty("on", v)
+		if err != nil {
+			return err
+		}
+		t.On = YANGEmpty(x)
+	}
+	if err := d.Field(t, "Pair", jsonTree); err != nil {
+		return err
+	}
+	if err := d.Field(t, "Sample", jsonTree); err != nil {
+		return err
+	}
+	if err := d.Field(t, "Step", jsonTree); err != nil {
+		return err
+	}
+	if v, err := d.Value(jsonTree, [][]string{{"str"}}, nil); err != nil {
+		return err
+	} else if v != nil {
+		x, err := d.String("str", v)
+		if err != nil {
+			return err
+		}
+		t.Str = &x
+	}
+	if v, err := d.Value(jsonTree, [][]string{{"u32"}}, nil); err != nil {
+		return err
+	} else if v != nil {
+		x, err := d.Uint32("u32", v)
+		if err != nil {
+			return err
+		}
+		t.U32 = &x
+	}
+	if v, err := d.Value(jsonTree, [][]string{{"u64"}}, nil); err != nil {
+		return err
+	} else if v != nil {
+		x, err := d.Uint64("u64", v)
+		if err != nil {
+			return err
+		}
+		t.U64 = &x
+	}
+	return nil
+}
+
+// ΛBelongingModule returns the name of the module that defines the namespace
+// of Rfc7951Methods_Top.
+func (*Rfc7951Methods_Top) ΛBelongingModule() string {
+	return "rfc7951-methods"
+}
+
+// Rfc7951Methods_Top_Mixed_Union is an interface that is implemented by valid types for the union
+// for the leaf /rfc7951-methods/top/mixed within the YANG schema.
+// Union type can be one of [E_Rfc7951Methods_Top_Mixed, UnionInt32, UnionString].
+type Rfc7951Methods_Top_Mixed_Union interface {
+	// Union type can be one of [E_Rfc7951Methods_Top_Mixed, UnionInt32, UnionString]
+	Documentation_for_Rfc7951Methods_Top_Mixed_Union()
+}
+
+// Documentation_for_Rfc7951Methods_Top_Mixed_Union ensures that E_Rfc7951Methods_Top_Mixed
+// implements the Rfc7951Methods_Top_Mixed_Union interface.
+func (E_Rfc7951Methods_Top_Mixed) Documentation_for_Rfc7951Methods_Top_Mixed_Union() {}
+
+// Documentation_for_Rfc7951Methods_Top_Mixed_Union ensures that UnionInt32
+// implements the Rfc7951Methods_Top_Mixed_Union interface.
+func (UnionInt32) Documentation_for_Rfc7951Methods_Top_Mixed_Union() {}
+
+// Documentation_for_Rfc7951Methods_Top_Mixed_Union ensures that UnionString
+// implements the Rfc7951Methods_Top_Mixed_Union interface.
+func (UnionString) Documentation_for_Rfc7951Methods_Top_Mixed_Union() {}
+
+// To_Rfc7951Methods_Top_Mixed_Union takes an input interface{} and attempts to convert it to a struct
+// which implements the Rfc7951Methods_Top_Mixed_Union union. It returns an error if the interface{} supplied
+// cannot be converted to a type within the union.
+func (t *Rfc7951Methods_Top) To_Rfc7951Methods_Top_Mixed_Union(i interface{}) (Rfc7951Methods_Top_Mixed_Union, error) {
+	if v, ok := i.(Rfc7951Methods_Top_Mixed_Union); ok {
+		return v, nil
+	}
+	switch v := i.(type) {
+	case int32:
+		return UnionInt32(v), nil
+	case string:
+		return UnionString(v), nil
+	}
+	return nil, fmt.Errorf("cannot convert %v to Rfc7951Methods_Top_Mixed_Union, unknown union type, got: %T, want any of [E_Rfc7951Methods_Top_Mixed, int32, string]", i, i)
+}
+
+// Rfc7951Methods_Top_Mixes_Union is an interface that is implemented by valid types for the union
+// for the leaf /rfc7951-methods/top/mixes within the YANG schema.
+// Union type can be one of [E_Rfc7951Methods_Top_Mixes, UnionInt32, UnionString].
+type Rfc7951Methods_Top_Mixes_Union interface {
+	// Union type can be one of [E_Rfc7951Methods_Top_Mixes, UnionInt32, UnionString]
+	Documentation_for_Rfc7951Methods_Top_Mixes_Union()
+}
+
+// Documentation_for_Rfc7951Methods_Top_Mixes_Union ensures that E_Rfc7951Methods_Top_Mixes
+// implements the Rfc7951Methods_Top_Mixes_Union interface.
+func (E_Rfc7951Methods_Top_Mixes) Documentation_for_Rfc7951Methods_Top_Mixes_Union() {}
+
+// Documentation_for_Rfc7951Methods_Top_Mixes_Union ensures that UnionInt32
+// implements the Rfc7951Methods_Top_Mixes_Union interface.
+func (UnionInt32) Documentation_for_Rfc7951Methods_Top_Mixes_Union() {}
+
+// Documentation_for_Rfc7951Methods_Top_Mixes_Union ensures that UnionString
+// implements the Rfc7951Methods_Top_Mixes_Union interface.
+func (UnionString) Documentation_for_Rfc7951Methods_Top_Mixes_Union() {}
+
+// To_Rfc7951Methods_Top_Mixes_Union takes an input interface{} and attempts to convert it to a struct
+// which implements the Rfc7951Methods_Top_Mixes_Union union. It returns an error if the interface{} supplied
+// cannot be converted to a type within the union.
+func (t *Rfc7951Methods_Top) To_Rfc7951Methods_Top_Mixes_Union(i interface{}) (Rfc7951Methods_Top_Mixes_Union, error) {
+	if v, ok := i.(Rfc7951Methods_Top_Mixes_Union); ok {
+		return v, nil
+	}
+	switch v := i.(type) {
+	case int32:
+		return UnionInt32(v), nil
+	case string:
+		return UnionString(v), nil
+	}
+	return nil, fmt.Errorf("cannot convert %v to Rfc7951Methods_Top_Mixes_Union, unknown union type, got: %T, want any of [E_Rfc7951Methods_Top_Mixes, int32, string]", i, i)
+}
+
+// Rfc7951Methods_Top_Child represents the /rfc7951-methods/top/child YANG schema element.
+type Rfc7951Methods_Top_Child struct {
+	Value *string `path:"value" module:"rfc7951-methods"`
+}
+
+// IsYANGGoStruct ensures that Rfc7951Methods_Top_Child implements the yang.GoStruct
+// interface. This allows functions that need to handle this struct to
+// identify it as being generated by ygen.
+func (*Rfc7951Methods_Top_Child) IsYANGGoStruct() {}
+
+// Validate validates s against the YANG schema corresponding to its type.
+func (t *Rfc7951Methods_Top_Child) ΛValidate(opts ...ygot.ValidationOption) error {
+	if err := ytypes.Validate(SchemaTree["Rfc7951Methods_Top_Child"], t, opts...); err != nil {
+		return err
+	}
+	return nil
+}
+
+// Validate validates s against the YANG schema corresponding to its type.
+func (t *Rfc7951Methods_Top_Child) Validate(opts ...ygot.ValidationOption) error {
+	return t.ΛValidate(opts...)
+}
+
+// ΛEnumTypeMap returns a map, keyed by YANG schema path, of the enumerated types
+// that are included in the generated code.
+func (t *Rfc7951Methods_Top_Child) ΛEnumTypeMap() map[string][]reflect.Type { return ΛEnumTypes }
+
+// MarshalRFC7951 writes the contents of Rfc7951Methods_Top_Child to the supplied
+// encoder as RFC7951 JSON. It implements the ygot.RFC7951Marshaler interface.
+func (t *Rfc7951Methods_Top_Child) MarshalRFC7951(e *ygot.RFC7951Encoder) error {
+	if t.Value != nil {
+		if err := e.Set([][]string{{"value"}}, [][]string{{"rfc7951-methods"}}, ygot.RFC7951String(*t.Value)); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// UnmarshalRFC7951 unmarshals the supplied RFC7951 JSON object into
+// Rfc7951Methods_Top_Child. It implements the ytypes.RFC7951Unmarshaler interface.
+func (t *Rfc7951Methods_Top_Child) UnmarshalRFC7951(d *ytypes.RFC7951Decoder, jsonTree map[string]interface{}) error {
+	if v, err := d.Value(jsonTree, [][]string{{"value"}}, nil); err != nil {
+		return err
+	} else if v != nil {
+		x, err := d.String("value", v)
+		if err != nil {
+			return err
+		}
+		t.Value = &x
+	}
+	return nil
+}
+
+// ΛBelongingModule returns the name of the module that defines the namespace
+// of Rfc7951Methods_Top_Child.
+func (*Rfc7951Methods_Top_Child) ΛBelongingModule() string {
+	return "rfc7951-methods"
+}
+
+// Rfc7951Methods_Top_Entry represents the /rfc7951-methods/top/entry YANG schema element.
+type Rfc7951Methods_Top_Entry struct {
+	Name  *string `path:"name" module:"rfc7951-methods"`
+	Value *uint8  `path:"value" module:"rfc7951-methods"`
+}
+
+// IsYANGGoStruct ensures that Rfc7951Methods_Top_Entry implements the yang.GoStruct
+// interface. This allows functions that need to handle this struct to
+// identify it as being generated by ygen.
+func (*Rfc7951Methods_Top_Entry) IsYANGGoStruct() {}
+
+// ΛListKeyMap returns the keys of the Rfc7951Methods_Top_Entry struct, which is a YANG list entry.
+func (t *Rfc7951Methods_Top_Entry) ΛListKeyMap() (map[string]interface{}, error) {
+	if t.Name == nil {
+		return nil, fmt.Errorf("nil value for key Name")
+	}
+
+	return map[string]interface{}{
+		"name": *t.Name,
+	}, nil
+}
+
+// Validate validates s against the YANG schema corresponding to its type.
+func (t *Rfc7951Methods_Top_Entry) ΛValidate(opts ...ygot.ValidationOption) error {
+	if err := ytypes.Validate(SchemaTree["Rfc7951Methods_Top_Entry"], t, opts...); err != nil {
+		return err
+	}
+	return nil
+}
+
+// Validate validates s against the YANG schema corresponding to its type.
+func (t *Rfc7951Methods_Top_Entry) Validate(opts ...ygot.ValidationOption) error {
+	return t.ΛValidate(opts...)
+}
+
+// ΛEnumTypeMap returns a map, keyed by YANG schema path, of the enumerated types
+// that are included in the generated code.
+func (t *Rfc7951Methods_Top_Entry) ΛEnumTypeMap() map[string][]reflect.Type { return ΛEnumTypes }
+
+// MarshalRFC7951 writes the contents of Rfc7951Methods_Top_Entry to the supplied
+// encoder as RFC7951 JSON. It implements the ygot.RFC7951Marshaler interface.
+func (t *Rfc7951Methods_Top_Entry) MarshalRFC7951(e *ygot.RFC7951Encoder) error {
+	if t.Name != nil {
+		if err := e.Set([][]string{{"name"}}, [][]string{{"rfc7951-methods"}}, ygot.RFC7951String(*t.Name)); err != nil {
+			return err
+		}
+	}
+	if t.Value != nil {
+		if err := e.Set([][]string{{"value"}}, [][]string{{"rfc7951-methods"}}, float64(*t.Value)); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// UnmarshalRFC7951 unmarshals the supplied RFC7951 JSON object into
+// Rfc7951Methods_Top_Entry. It implements the ytypes.RFC7951Unmarshaler interface.
+func (t *Rfc7951Methods_Top_Entry) UnmarshalRFC7951(d *ytypes.RFC7951Decoder, jsonTree map[string]interface{}) error {
+	if v, err := d.Value(jsonTree, [][]string{{"name"}}, nil); err != nil {
+		return err
+	} else if v != nil {
+		x, err := d.String("name", v)
+		if err != nil {
+			return err
+		}
+		t.Name = &x
+	}
+	if v, err := d.Value(jsonTree, [][]string{{"value"}}, nil); err != nil {
+		return err
+	} else if v != nil {
+		x, err := d.Uint8("value", v)
+		if err != nil {
+			return err
+		}
+		t.Value = &x
+	}
+	return nil
+}
+
+// ΛBelongingModule returns the name of the module that defines the namespace
+// of Rfc7951Methods_Top_Entry.
+func (*Rfc7951Methods_Top_Entry) ΛBelongingModule() string {
+	return "rfc7951-methods"
+}
+
+// Rfc7951Methods_Top_More represents the /rfc7951-methods/top/more YANG schema element.
+type Rfc7951Methods_Top_More struct {
+	Value *int16 `path:"value" module:"rfc7951-methods-augment"`
+}
+
+// IsYANGGoStruct ensures that Rfc7951Methods_Top_More implements the yang.GoStruct
+// interface. This allows functions that need to handle this struct to
+// identify it as being generated by ygen.
+func (*Rfc7951Methods_Top_More) IsYANGGoStruct() {}
+
+// Validate validates s against the YANG schema corresponding to its type.
+func (t *Rfc7951Methods_Top_More) ΛValidate(opts ...ygot.ValidationOption) error {
+	if err := ytypes.Validate(SchemaTree["Rfc7951Methods_Top_More"], t, opts...); err != nil {
+		return err
+	}
+	return nil
+}
+
+// Validate validates s against the YANG schema corresponding to its type.
+func (t *Rfc7951Methods_Top_More) Validate(opts ...ygot.ValidationOption) error {
+	return t.ΛValidate(opts...)
+}
+
+// ΛEnumTypeMap returns a map, keyed by YANG schema path, of the enumerated types
+// that are included in the generated code.
+func (t *Rfc7951Methods_Top_More) ΛEnumTypeMap() map[string][]reflect.Type { return ΛEnumTypes }
+
+// MarshalRFC7951 writes the contents of Rfc7951Methods_Top_More to the supplied
+// encoder as RFC7951 JSON. It implements the ygot.RFC7951Marshaler interface.
+func (t *Rfc7951Methods_Top_More) MarshalRFC7951(e *ygot.RFC7951Encoder) error {
+	if t.Value != nil {
+		if err := e.Set([][]string{{"value"}}, [][]string{{"rfc7951-methods-augment"}}, float64(*t.Value)); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// UnmarshalRFC7951 unmarshals the supplied RFC7951 JSON object into
+// Rfc7951Methods_Top_More. It implements the ytypes.RFC7951Unmarshaler interface.
+func (t *Rfc7951Methods_Top_More) UnmarshalRFC7951(d *ytypes.RFC7951Decoder, jsonTree map[string]interface{}) error {
+	if v, err := d.Value(jsonTree, [][]string{{"value"}}, nil); err != nil {
+		return err
+	} else if v != nil {
+		x, err := d.Int16("value", v)
+		if err != nil {
+			return err
+		}
+		t.Value = &x
+	}
+	return nil
+}
+
+// ΛBelongingModule returns the name of the module that defines the namespace
+// of Rfc7951Methods_Top_More.
+func (*Rfc7951Methods_Top_More) ΛBelongingModule() string {
+	return "rfc7951-methods-augment"
+}
+
+// Rfc7951Methods_Top_Pair represents the /rfc7951-methods/top/pair YANG schema element.
+type Rfc7951Methods_Top_Pair struct {
+	A *string `path:"a" module:"rfc7951-methods"`
+	B *uint32 `path:"b" module:"rfc7951-methods"`
+}
+
+// IsYANGGoStruct ensures that Rfc7951Methods_Top_Pair implements the yang.GoStruct
+// interface. This allows functions that need to handle this struct to
+// identify it as being generated by ygen.
+func (*Rfc7951Methods_Top_Pair) IsYANGGoStruct() {}
+
+// ΛListKeyMap returns the keys of the Rfc7951Methods_Top_Pair struct, which is a YANG list entry.
+func (t *Rfc7951Methods_Top_Pair) ΛListKeyMap() (map[string]interface{}, error) {
+	if t.A == nil {
+		return nil, fmt.Errorf("nil value for key A")
+	}
+
+	if t.B == nil {
+		return nil, fmt.Errorf("nil value for key B")
+	}
+
+	return map[string]interface{}{
+		"a": *t.A,
+		"b": *t.B,
+	}, nil
+}
+
+// Validate validates s against the YANG schema corresponding to its type.
+func (t *Rfc7951Methods_Top_Pair) ΛValidate(opts ...ygot.ValidationOption) error {
+	if err := ytypes.Validate(SchemaTree["Rfc7951Methods_Top_Pair"], t, opts...); err != nil {
+		return err
+	}
+	return nil
+}
+
+// Validate validates s against the YANG schema corresponding to its type.
+func (t *Rfc7951Methods_Top_Pair) Validate(opts ...ygot.ValidationOption) error {
+	return t.ΛValidate(opts...)
+}
+
+// ΛEnumTypeMap returns a map, keyed by YANG schema path, of the enumerated types
+// that are included in the generated code.
+func (t *Rfc7951Methods_Top_Pair) ΛEnumTypeMap() map[string][]reflect.Type { return ΛEnumTypes }
+
+// MarshalRFC7951 writes the contents of Rfc7951Methods_Top_Pair to the supplied
+// encoder as RFC7951 JSON. It implements the ygot.RFC7951Marshaler interface.
+func (t *Rfc7951Methods_Top_Pair) MarshalRFC7951(e *ygot.RFC7951Encoder) error {
+	if t.A != nil {
+		if err := e.Set([][]string{{"a"}}, [][]string{{"rfc7951-methods"}}, ygot.RFC7951String(*t.A)); err != nil {
+			return err
+		}
+	}
+	if t.B != nil {
+		if err := e.Set([][]string{{"b"}}, [][]string{{"rfc7951-methods"}}, float64(*t.B)); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// UnmarshalRFC7951 unmarshals the supplied RFC7951 JSON object into
+// Rfc7951Methods_Top_Pair. It implements the ytypes.RFC7951Unmarshaler interface.
+func (t *Rfc7951Methods_Top_Pair) UnmarshalRFC7951(d *ytypes.RFC7951Decoder, jsonTree map[string]interface{}) error {
+	if v, err := d.Value(jsonTree, [][]string{{"a"}}, nil); err != nil {
+		return err
+	} else if v != nil {
+		x, err := d.String("a", v)
+		if err != nil {
+			return err
+		}
+		t.A = &x
+	}
+	if v, err := d.Value(jsonTree, [][]string{{"b"}}, nil); err != nil {
+		return err
+	} else if v != nil {
+		x, err := d.Uint32("b", v)
+		if err != nil {
+			return err
+		}
+		t.B = &x
+	}
+	return nil
+}
+
+// ΛBelongingModule returns the name of the module that defines the namespace
+// of Rfc7951Methods_Top_Pair.
+func (*Rfc7951Methods_Top_Pair) ΛBelongingModule() string {
+	return "rfc7951-methods"
+}
+
+// Rfc7951Methods_Top_Sample represents the /rfc7951-methods/top/sample YANG schema element.
+type Rfc7951Methods_Top_Sample struct {
+	Value *int32 `path:"value" module:"rfc7951-methods"`
+}
+
+// IsYANGGoStruct ensures that Rfc7951Methods_Top_Sample implements the yang.GoStruct
+// interface. This allows functions that need to handle this struct to
+// identify it as being generated by ygen.
+func (*Rfc7951Methods_Top_Sample) IsYANGGoStruct() {}
+
+// Validate validates s against the YANG schema corresponding to its type.
+func (t *Rfc7951Methods_Top_Sample) ΛValidate(opts ...ygot.ValidationOption) error {
+	if err := ytypes.Validate(SchemaTree["Rfc7951Methods_Top_Sample"], t, opts...); err != nil {
+		return err
+	}
+	return nil
+}
+
+// Validate validates s against the YANG schema corresponding to its type.
+func (t *Rfc7951Methods_Top_Sample) Validate(opts ...ygot.ValidationOption) error {
+	return t.ΛValidate(opts...)
+}
+
+// ΛEnumTypeMap returns a map, keyed by YANG schema path, of the enumerated types
+// that are included in the generated code.
+func (t *Rfc7951Methods_Top_Sample) ΛEnumTypeMap() map[string][]reflect.Type { return ΛEnumTypes }
+
+// MarshalRFC7951 writes the contents of Rfc7951Methods_Top_Sample to the supplied
+// encoder as RFC7951 JSON. It implements the ygot.RFC7951Marshaler interface.
+func (t *Rfc7951Methods_Top_Sample) MarshalRFC7951(e *ygot.RFC7951Encoder) error {
+	if t.Value != nil {
+		if err := e.Set([][]string{{"value"}}, [][]string{{"rfc7951-methods"}}, float64(*t.Value)); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// UnmarshalRFC7951 unmarshals the supplied RFC7951 JSON object into
+// Rfc7951Methods_Top_Sample. It implements the ytypes.RFC7951Unmarshaler interface.
+func (t *Rfc7951Methods_Top_Sample) UnmarshalRFC7951(d *ytypes.RFC7951Decoder, jsonTree map[string]interface{}) error {
+	if v, err := d.Value(jsonTree, [][]string{{"value"}}, nil); err != nil {
+		return err
+	} else if v != nil {
+		x, err := d.Int32("value", v)
+		if err != nil {
+			return err
+		}
+		t.Value = &x
+	}
+	return nil
+}
+
+// ΛBelongingModule returns the name of the module that defines the namespace
+// of Rfc7951Methods_Top_Sample.
+func (*Rfc7951Methods_Top_Sample) ΛBelongingModule() string {
+	return "rfc7951-methods"
+}
+
+// Rfc7951Methods_Top_Step represents the /rfc7951-methods/top/step YANG schema element.
+type Rfc7951Methods_Top_Step struct {
+	Id *uint32 `path:"id" module:"rfc7951-methods"`
+}
+
+// IsYANGGoStruct ensures that Rfc7951Methods_Top_Step implements the yang.GoStruct
+// interface. This allows functions that need to handle this struct to
+// identify it as being generated by ygen.
+func (*Rfc7951Methods_Top_Step) IsYANGGoStruct() {}
+
+// ΛListKeyMap returns the keys of the Rfc7951Methods_Top_Step struct, which is a YANG list entry.
+func (t *Rfc7951Methods_Top_Step) ΛListKeyMap() (map[string]interface{}, error) {
+	if t.Id == nil {
+		return nil, fmt.Errorf("nil value for key Id")
+	}
+
+	return map[string]interface{}{
+		"id": *t.Id,
+	}, nil
+}
+
+// Validate validates s against the YANG schema corresponding to its type.
+func (t *Rfc7951Methods_Top_Step) ΛValidate(opts ...ygot.ValidationOption) error {
+	if err := ytypes.Validate(SchemaTree["Rfc7951Methods_Top_Step"], t, opts...); err != nil {
+		return err
+	}
+	return nil
+}
+
+// Validate validates s against the YANG schema corresponding to its type.
+func (t *Rfc7951Methods_Top_Step) Validate(opts ...ygot.ValidationOption) error {
+	return t.ΛValidate(opts...)
+}
+
+// ΛEnumTypeMap returns a map, keyed by YANG schema path, of the enumerated types
+// that are included in the generated code.
+func (t *Rfc7951Methods_Top_Step) ΛEnumTypeMap() map[string][]reflect.Type { return ΛEnumTypes }
+
+// MarshalRFC7951 writes the contents of Rfc7951Methods_Top_Step to the supplied
+// encoder as RFC7951 JSON. It implements the ygot.RFC7951Marshaler interface.
+func (t *Rfc7951Methods_Top_Step) MarshalRFC7951(e *ygot.RFC7951Encoder) error {
+	if t.Id != nil {
+		if err := e.Set([][]string{{"id"}}, [][]string{{"rfc7951-methods"}}, float64(*t.Id)); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// UnmarshalRFC7951 unmarshals the supplied RFC7951 JSON object into
+// Rfc7951Methods_Top_Step. It implements the ytypes.RFC7951Unmarshaler interface.
+func (t *Rfc7951Methods_Top_Step) UnmarshalRFC7951(d *ytypes.RFC7951Decoder, jsonTree map[string]interface{}) error {
+	if v, err := d.Value(jsonTree, [][]string{{"id"}}, nil); err != nil {
+		return err
+	} else if v != nil {
+		x, err := d.Uint32("id", v)
+		if err != nil {
+			return err
+		}
+		t.Id = &x
+	}
+	return nil
+}
+
+// ΛBelongingModule returns the name of the module that defines the namespace
+// of Rfc7951Methods_Top_Step.
+func (*Rfc7951Methods_Top_Step) ΛBelongingModule() string {
+	return "rfc7951-methods"
+}
+
+// E_Rfc7951Methods_BASE is a derived int64 type which is used to represent
+// the enumerated node Rfc7951Methods_BASE. An additional value named
+// Rfc7951Methods_BASE_UNSET is added to the enumeration which is used as
+// the nil value, indicating that the enumeration was not explicitly set by
+// the program importing the generated structures.
+type E_Rfc7951Methods_BASE int64
+
+// IsYANGGoEnum ensures that Rfc7951Methods_BASE implements the yang.GoEnum
+// interface. This ensures that Rfc7951Methods_BASE can be identified as a
+// mapped type for a YANG enumeration.
+func (E_Rfc7951Methods_BASE) IsYANGGoEnum() {}
+
+// ΛMap returns the value lookup map associated with  Rfc7951Methods_BASE.
+func (E_Rfc7951Methods_BASE) ΛMap() map[string]map[int64]ygot.EnumDefinition { return ΛEnum }
+
+// String returns a logging-friendly string for E_Rfc7951Methods_BASE.
+func (e E_Rfc7951Methods_BASE) String() string {
+	return ygot.EnumLogString(e, int64(e), "E_Rfc7951Methods_BASE")
+}
+
+const (
+	// Rfc7951Methods_BASE_UNSET corresponds to the value UNSET of Rfc7951Methods_BASE
+	Rfc7951Methods_BASE_UNSET E_Rfc7951Methods_BASE = 0
+	// Rfc7951Methods_BASE_DERIVED corresponds to the value DERIVED of Rfc7951Methods_BASE
+	Rfc7951Methods_BASE_DERIVED E_Rfc7951Methods_BASE = 1
+)
+
+// E_Rfc7951Methods_Top_Color is a derived int64 type which is used to represent
+// the enumerated node Rfc7951Methods_Top_Color. An additional value named
+// Rfc7951Methods_Top_Color_UNSET is added to the enumeration which is used as
+// the nil value, indicating that the enumeration was not explicitly set by
+// the program importing the generated structures.
+type E_Rfc7951Methods_Top_Color int64
+
+// IsYANGGoEnum ensures that Rfc7951Methods_Top_Color implements the yang.GoEnum
+// interface. This ensures that Rfc7951Methods_Top_Color can be identified as a
+// mapped type for a YANG enumeration.
+func (E_Rfc7951Methods_Top_Color) IsYANGGoEnum() {}
+
+// ΛMap returns the value lookup map associated with  Rfc7951Methods_Top_Color.
+func (E_Rfc7951Methods_Top_Color) ΛMap() map[string]map[int64]ygot.EnumDefinition { return ΛEnum }
+
+// String returns a logging-friendly string for E_Rfc7951Methods_Top_Color.
+func (e E_Rfc7951Methods_Top_Color) String() string {
+	return ygot.EnumLogString(e, int64(e), "E_Rfc7951Methods_Top_Color")
+}
+
+const (
+	// Rfc7951Methods_Top_Color_UNSET corresponds to the value UNSET of Rfc7951Methods_Top_Color
+	Rfc7951Methods_Top_Color_UNSET E_Rfc7951Methods_Top_Color = 0
+	// Rfc7951Methods_Top_Color_RED corresponds to the value RED of Rfc7951Methods_Top_Color
+	Rfc7951Methods_Top_Color_RED E_Rfc7951Methods_Top_Color = 1
+	// Rfc7951Methods_Top_Color_BLUE corresponds to the value BLUE of Rfc7951Methods_Top_Color
+	Rfc7951Methods_Top_Color_BLUE E_Rfc7951Methods_Top_Color = 2
+)
+
+// E_Rfc7951Methods_Top_Mixed is a derived int64 type which is used to represent
+// the enumerated node Rfc7951Methods_Top_Mixed. An additional value named
+// Rfc7951Methods_Top_Mixed_UNSET is added to the enumeration which is used as
+// the nil value, indicating that the enumeration was not explicitly set by
+// the program importing the generated structures.
+type E_Rfc7951Methods_Top_Mixed int64
+
+// IsYANGGoEnum ensures that Rfc7951Methods_Top_Mixed implements the yang.GoEnum
+// interface. This ensures that Rfc7951Methods_Top_Mixed can be identified as a
+// mapped type for a YANG enumeration.
+func (E_Rfc7951Methods_Top_Mixed) IsYANGGoEnum() {}
+
+// ΛMap returns the value lookup map associated with  Rfc7951Methods_Top_Mixed.
+func (E_Rfc7951Methods_Top_Mixed) ΛMap() map[string]map[int64]ygot.EnumDefinition { return ΛEnum }
+
+// String returns a logging-friendly string for E_Rfc7951Methods_Top_Mixed.
+func (e E_Rfc7951Methods_Top_Mixed) String() string {
+	return ygot.EnumLogString(e, int64(e), "E_Rfc7951Methods_Top_Mixed")
+}
+
+const (
+	// Rfc7951Methods_Top_Mixed_UNSET corresponds to the value UNSET of Rfc7951Methods_Top_Mixed
+	Rfc7951Methods_Top_Mixed_UNSET E_Rfc7951Methods_Top_Mixed = 0
+	// Rfc7951Methods_Top_Mixed_ALPHA corresponds to the value ALPHA of Rfc7951Methods_Top_Mixed
+	Rfc7951Methods_Top_Mixed_ALPHA E_Rfc7951Methods_Top_Mixed = 1
+	// Rfc7951Methods_Top_Mixed_BRAVO corresponds to the value BRAVO of Rfc7951Methods_Top_Mixed
+	Rfc7951Methods_Top_Mixed_BRAVO E_Rfc7951Methods_Top_Mixed = 2
+)
+
+// E_Rfc7951Methods_Top_Mixes is a derived int64 type which is used to represent
+// the enumerated node Rfc7951Methods_Top_Mixes. An additional value named
+// Rfc7951Methods_Top_Mixes_UNSET is added to the enumeration which is used as
+// the nil value, indicating that the enumeration was not explicitly set by
+// the program importing the generated structures.
+type E_Rfc7951Methods_Top_Mixes int64
+
+// IsYANGGoEnum ensures that Rfc7951Methods_Top_Mixes implements the yang.GoEnum
+// interface. This ensures that Rfc7951Methods_Top_Mixes can be identified as a
+// mapped type for a YANG enumeration.
+func (E_Rfc7951Methods_Top_Mixes) IsYANGGoEnum() {}
+
+// ΛMap returns the value lookup map associated with  Rfc7951Methods_Top_Mixes.
+func (E_Rfc7951Methods_Top_Mixes) ΛMap() map[string]map[int64]ygot.EnumDefinition { return ΛEnum }
+
+// String returns a logging-friendly string for E_Rfc7951Methods_Top_Mixes.
+func (e E_Rfc7951Methods_Top_Mixes) String() string {
+	return ygot.EnumLogString(e, int64(e), "E_Rfc7951Methods_Top_Mixes")
+}
+
+const (
+	// Rfc7951Methods_Top_Mixes_UNSET corresponds to the value UNSET of Rfc7951Methods_Top_Mixes
+	Rfc7951Methods_Top_Mixes_UNSET E_Rfc7951Methods_Top_Mixes = 0
+	// Rfc7951Methods_Top_Mixes_ALPHA corresponds to the value ALPHA of Rfc7951Methods_Top_Mixes
+	Rfc7951Methods_Top_Mixes_ALPHA E_Rfc7951Methods_Top_Mixes = 1
+	// Rfc7951Methods_Top_Mixes_BRAVO corresponds to the value BRAVO of Rfc7951Methods_Top_Mixes
+	Rfc7951Methods_Top_Mixes_BRAVO E_Rfc7951Methods_Top_Mixes = 2
+)
+
+// ΛEnum is a map, keyed by the name of the type defined for each enum in the
+// generated Go code, which provides a mapping between the constant int64 value
+// of each value of the enumeration, and the string that is used to represent it
+// in the YANG schema. The map is named ΛEnum in order to avoid clash with any
+// valid YANG identifier.
+var ΛEnum = map[string]map[int64]ygot.EnumDefinition{
+	"E_Rfc7951Methods_BASE": {
+		1: {Name: "DERIVED", DefiningModule: "rfc7951-methods"},
+	},
+	"E_Rfc7951Methods_Top_Color": {
+		1: {Name: "RED"},
+		2: {Name: "BLUE"},
+	},
+	"E_Rfc7951Methods_Top_Mixed": {
+		1: {Name: "ALPHA"},
+		2: {Name: "BRAVO"},
+	},
+	"E_Rfc7951Methods_Top_Mixes": {
+		1: {Name: "ALPHA"},
+		2: {Name: "BRAVO"},
+	},
+}
+
+var (
+	// ySchema is a byte slice contain a gzip compressed representation of the
+	// YANG schema from which the Go code was generated. When uncompressed the
+	// contents of the byte slice is a JSON document containing an object, keyed
+	// on the name of the generated struct, and containing the JSON marshalled
+	// contents of a goyang yang.Entry struct, which defines the schema for the
+	// fields within the struct.
+	ySchema = []byte{
+		0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0xec, 0x5d, 0xcb, 0x6e, 0xdb, 0x3a,
+		0x10, 0xdd, 0xeb, 0x2b, 0x08, 0xae, 0x5d, 0xc4, 0x92, 0xe5, 0xe7, 0xce, 0x69, 0x5c, 0xb4, 0x68,
+		0xd3, 0x16, 0x49, 0xdb, 0xcd, 0x45, 0x50, 0xd0, 0x16, 0xe3, 0x10, 0xb5, 0x29, 0x43, 0xa2, 0x72,
+		0x63, 0x5c, 0xe4, 0xdf, 0x2f, 0xf4, 0x70, 0xe1, 0x97, 0xa4, 0xa1, 0x25, 0xdb, 0x52, 0x3c, 0xde,
+		0x45, 0x9a, 0x50, 0xe4, 0xcc, 0xe1, 0xe1, 0xe1, 0x50, 0xa4, 0xfe, 0x33, 0x08, 0x21, 0x84, 0x7e,
+		0x65, 0x73, 0x4e, 0x07, 0x84, 0x3a, 0xfc, 0x59, 0x4c, 0x38, 0x6d, 0xc4, 0x57, 0x3f, 0x0b, 0xe9,
+		0xd0, 0x01, 0x31, 0x93, 0x3f, 0xdf, 0xbb, 0xf2, 0x51, 0x4c, 0xe9, 0x80, 0x34, 0x93, 0x0b, 0x37,
+		0xc2, 0xa3, 0x03, 0x12, 0x17, 0x41, 0x08, 0x21, 0x54, 0xb9, 0x8b, 0x8d, 0x0b, 0x1b, 0x65, 0x87,
+		0x37, 0x1b, 0x9b, 0xb7, 0x36, 0x1f, 0xf0, 0xf7, 0xf2, 0xf6, 0x83, 0xfe, 0xde, 0xf8, 0xee, 0xf1,
+		0x47, 0xf1, 0xb2, 0xf3, 0x88, 0x8d, 0xc7, 0x78, 0x73, 0xda, 0xd8, 0xbd, 0x7b, 0xef, 0x06, 0xde,
+		0x84, 0xef, 0xfd, 0xcf, 0xb8, 0x26, 0x7c, 0xf9, 0xaf, 0xeb, 0x85, 0x95, 0xa1, 0x8b, 0xf8, 0x21,
+		0x8d, 0xfd, 0x86, 0x1f, 0x99, 0x3f, 0xf4, 0xa6, 0xc1, 0x9c, 0x4b, 0x45, 0x07, 0x44, 0x79, 0x01,
+		0x4f, 0x31, 0x5c, 0xb3, 0x0a, 0xeb, 0xb4, 0x63, 0xf4, 0xba, 0x71, 0xe5, 0x75, 0xab, 0xa5, 0xdb,
+		0xae, 0xfd, 0x7b, 0x63, 0x2c, 0x64, 0x7a, 0x2b, 0x56, 0x3e, 0x08, 0x8d, 0x52, 0xaa, 0x95, 0xb8,
+		0xbc, 0x99, 0x72, 0x3b, 0xcd, 0xf5, 0x90, 0x10, 0xc0, 0x42, 0x01, 0x0d, 0x89, 0x76, 0x68, 0xb4,
+		0x43, 0x04, 0x0e, 0xd5, 0xfe, 0x90, 0xa5, 0x84, 0x6e, 0xf5, 0xa3, 0x3f, 0x96, 0x0b, 0x0e, 0xf3,
+		0xd3, 0x58, 0x48, 0xe6, 0x2d, 0xb3, 0x7c, 0x95, 0x44, 0xad, 0x6f, 0xc0, 0xaa, 0xb5, 0xa7, 0x4a,
+		0x74, 0xf2, 0x24, 0x66, 0x4e, 0x3e, 0x74, 0x62, 0xb3, 0x6c, 0xf0, 0x98, 0x08, 0x9e, 0x63, 0x83,
+		0x27, 0xad, 0xff, 0xaf, 0x7e, 0xf4, 0x99, 0xcd, 0x02, 0x40, 0xfb, 0x57, 0xde, 0x8c, 0xcd, 0x73,
+		0x9a, 0x92, 0xcd, 0x0d, 0xe0, 0x30, 0xeb, 0x84, 0x5b, 0x2f, 0xec, 0xba, 0xe1, 0x3f, 0x18, 0x06,
+		0x07, 0xc3, 0x41, 0x1b, 0x16, 0xd9, 0xf0, 0xc8, 0x81, 0x09, 0x9c, 0x6b, 0x76, 0xfc, 0xec, 0x2b,
+		0x4f, 0xc8, 0x29, 0xc4, 0xd7, 0xab, 0x4e, 0xdf, 0x33, 0x0e, 0xab, 0xbf, 0x1e, 0xf0, 0xf9, 0x8b,
+		0xf2, 0xd8, 0xbb, 0x40, 0xfa, 0x8a, 0x8d, 0x67, 0x39, 0xfc, 0xb9, 0xf0, 0xb8, 0xcf, 0x65, 0x84,
+		0x83, 0x7f, 0x32, 0x2b, 0xa7, 0xe1, 0x17, 0x2e, 0xc3, 0xe7, 0x3a, 0xc7, 0x07, 0x61, 0x5c, 0xf3,
+		0x53, 0xc2, 0x70, 0xd5, 0xb4, 0xa2, 0x58, 0x4c, 0xbd, 0xfb, 0xa0, 0x15, 0xe9, 0xa1, 0x94, 0xae,
+		0x62, 0x4a, 0xb8, 0x32, 0x3b, 0xca, 0xfe, 0xe4, 0x89, 0xcf, 0xd9, 0x82, 0xa9, 0xa7, 0xb0, 0x11,
+		0x57, 0xde, 0xe3, 0xa4, 0xdb, 0x6f, 0x9b, 0xef, 0xe6, 0x5c, 0x3d, 0xb9, 0x8e, 0x7f, 0xa5, 0xdc,
+		0xc5, 0x55, 0xd6, 0xa0, 0x15, 0x97, 0xa1, 0xbc, 0x60, 0xa2, 0x64, 0x12, 0xe3, 0xbb, 0xb8, 0x88,
+		0xdb, 0xb8, 0x84, 0xdf, 0x3f, 0xdc, 0xc5, 0xef, 0xf7, 0x51, 0x09, 0x45, 0x86, 0x57, 0x77, 0xe6,
+		0x7a, 0x80, 0xe1, 0x35, 0x32, 0x43, 0x6d, 0x56, 0x1b, 0x6d, 0xc6, 0x65, 0x30, 0xe7, 0x5e, 0x0c,
+		0xd3, 0x7c, 0x81, 0x66, 0xda, 0x19, 0x36, 0x23, 0x19, 0xcc, 0xf3, 0x5d, 0xfa, 0xc3, 0xbd, 0x8f,
+		0xa9, 0x19, 0x44, 0xe7, 0xcd, 0x08, 0xcf, 0xa3, 0x1b, 0x08, 0x5f, 0x99, 0xa1, 0xed, 0xf5, 0x97,
+		0x9f, 0x23, 0x5a, 0x6c, 0xac, 0x71, 0x3f, 0x49, 0x05, 0xab, 0x5d, 0xf4, 0xb0, 0x54, 0xbd, 0xb8,
+		0xfe, 0x8b, 0xda, 0x30, 0x20, 0xcd, 0x72, 0xc7, 0x18, 0x60, 0xcf, 0x0d, 0xa4, 0xf2, 0x21, 0x5d,
+		0x37, 0xb2, 0xc3, 0xbe, 0x5b, 0x9b, 0xbe, 0x1b, 0x08, 0xa9, 0xcc, 0x0e, 0xa0, 0xdb, 0x76, 0x32,
+		0x4c, 0xee, 0x98, 0x9c, 0x96, 0xa2, 0x30, 0x6e, 0x85, 0x84, 0x6b, 0x85, 0x5f, 0x89, 0xbc, 0x6f,
+		0x02, 0xc7, 0xf9, 0x0f, 0x1e, 0x9b, 0x84, 0x1c, 0x75, 0x23, 0xa6, 0x42, 0xf9, 0x1a, 0xff, 0xf8,
+		0x95, 0x4f, 0x99, 0x12, 0xcf, 0xe1, 0xb3, 0x1e, 0xd9, 0xcc, 0xe7, 0xf9, 0xe2, 0xa0, 0x01, 0x68,
+		0x2a, 0x7b, 0xd1, 0x6f, 0x6a, 0xa7, 0xdd, 0x6e, 0xb5, 0xab, 0xd7, 0xdc, 0x93, 0x68, 0xa1, 0x2f,
+		0xc2, 0x57, 0x43, 0xa5, 0x72, 0xe6, 0x7c, 0xb7, 0x42, 0x8e, 0x66, 0x3c, 0xec, 0x51, 0x39, 0x4d,
+		0x0e, 0x03, 0xb0, 0x66, 0x69, 0xf6, 0x6c, 0xbb, 0xd3, 0xb5, 0xed, 0x66, 0xb7, 0xd5, 0x6d, 0xf6,
+		0xdb, 0x6d, 0xb3, 0x63, 0x66, 0x38, 0x9a, 0x7e, 0xf3, 0x1c, 0xee, 0x71, 0xe7, 0x7a, 0x49, 0x07,
+		0x44, 0x06, 0xb3, 0x19, 0xc4, 0xf4, 0xa7, 0xcf, 0xbd, 0x4c, 0x9f, 0xc2, 0xb8, 0xd8, 0x61, 0x8a,
+		0xe5, 0x33, 0x71, 0x64, 0x95, 0xcd, 0xc3, 0x16, 0xf2, 0xf0, 0xb1, 0x79, 0xb8, 0x44, 0xfd, 0x1e,
+		0x05, 0xb4, 0x08, 0x6c, 0xf8, 0x04, 0x80, 0x1a, 0x3e, 0xc1, 0xc1, 0xbb, 0x3e, 0x83, 0xb7, 0xc3,
+		0x27, 0x62, 0xce, 0x66, 0x1d, 0x1b, 0x22, 0xbb, 0xad, 0x86, 0x01, 0x1f, 0x2b, 0xac, 0xca, 0x8e,
+		0xf6, 0x7d, 0xcb, 0x6a, 0xb5, 0xba, 0x56, 0xb3, 0xd5, 0xe9, 0xb5, 0xed, 0x6e, 0xb7, 0xdd, 0x6b,
+		0xf6, 0x0e, 0x1d, 0x10, 0x2d, 0xfd, 0x01, 0x31, 0x04, 0xc5, 0xf9, 0x86, 0xff, 0xdd, 0xb6, 0x77,
+		0x4f, 0xd8, 0xf6, 0xb3, 0x88, 0x01, 0x10, 0xb7, 0x71, 0xa9, 0xbc, 0x65, 0x3e, 0xbb, 0xc5, 0x66,
+		0x98, 0xb7, 0xaf, 0x7a, 0xde, 0x3e, 0xc9, 0x41, 0x01, 0xd3, 0xf6, 0x91, 0x35, 0x66, 0xed, 0x31,
+		0x6b, 0x7f, 0xfa, 0xac, 0x7d, 0x03, 0xd7, 0x9e, 0x10, 0xc5, 0xc7, 0x41, 0x71, 0x98, 0x97, 0xe9,
+		0x69, 0x80, 0xb8, 0x0d, 0xc9, 0x1f, 0x82, 0x84, 0xdb, 0xea, 0x07, 0x0b, 0x1d, 0xd1, 0x15, 0x72,
+		0x3b, 0xa2, 0x06, 0x98, 0x96, 0x48, 0x97, 0x32, 0xba, 0x05, 0x68, 0x4b, 0x1a, 0x60, 0x94, 0x0f,
+		0xd6, 0x77, 0x3b, 0x2e, 0xb1, 0xda, 0xed, 0xfa, 0x38, 0xc5, 0x28, 0xc7, 0xea, 0xe1, 0x24, 0x4b,
+		0xa7, 0x9f, 0xf9, 0x32, 0x47, 0x33, 0x5c, 0x42, 0x9e, 0xe9, 0xf8, 0xd9, 0x8a, 0x2c, 0xa9, 0x4d,
+		0x40, 0xab, 0x8d, 0xa3, 0xa8, 0x84, 0x22, 0x93, 0x82, 0x70, 0x95, 0x1c, 0x30, 0x29, 0x88, 0xcc,
+		0x2a, 0x91, 0xf4, 0x60, 0x75, 0x9c, 0x15, 0xb0, 0xd3, 0xa7, 0x3d, 0x72, 0x15, 0x5e, 0x8e, 0xb2,
+		0x83, 0xe1, 0xe7, 0x71, 0xc6, 0xa6, 0xf9, 0xf0, 0x89, 0xac, 0x30, 0x65, 0x56, 0x9f, 0xf7, 0x08,
+		0x5d, 0x77, 0xc6, 0x19, 0x68, 0x9d, 0xda, 0x2c, 0x00, 0x1e, 0xd1, 0xb1, 0xf3, 0xb1, 0x23, 0x52,
+		0x13, 0x77, 0x08, 0x9d, 0x0a, 0x42, 0x47, 0x48, 0x05, 0xca, 0xb4, 0xda, 0x97, 0x90, 0x3b, 0x6d,
+		0x5e, 0x70, 0xee, 0xb4, 0xf9, 0x96, 0x72, 0xa7, 0xa2, 0x07, 0x20, 0xaa, 0x1e, 0xf2, 0x54, 0xad,
+		0x78, 0xaa, 0x07, 0x19, 0xdf, 0x2a, 0x4b, 0x53, 0xa6, 0x75, 0x39, 0xb4, 0x64, 0x5a, 0x48, 0x43,
+		0x84, 0x10, 0xfa, 0x27, 0x06, 0x65, 0x0e, 0x11, 0x45, 0x56, 0x48, 0x45, 0xf5, 0xa1, 0x22, 0x87,
+		0x4b, 0x25, 0xd4, 0xd2, 0xe3, 0x8f, 0x10, 0x46, 0xca, 0xca, 0x84, 0x7c, 0x4a, 0x8a, 0xba, 0x66,
+		0xbe, 0x46, 0x5e, 0xfd, 0x7a, 0x78, 0x3f, 0xca, 0xf3, 0x6a, 0xd4, 0x15, 0x7d, 0x50, 0x5e, 0x14,
+		0xd8, 0xb9, 0x57, 0x4f, 0xbf, 0x19, 0xdd, 0x7d, 0xfa, 0x35, 0xba, 0x29, 0x9a, 0x5d, 0x7e, 0x38,
+		0xca, 0x3b, 0x9d, 0x61, 0x5f, 0xf2, 0x61, 0x5d, 0x0e, 0xdf, 0xe8, 0xc4, 0x3e, 0x87, 0x7d, 0xee,
+		0xa0, 0xb8, 0xe1, 0x5b, 0x8b, 0x84, 0x10, 0x42, 0xe8, 0x5c, 0xbc, 0x70, 0xc0, 0x00, 0x1f, 0x9b,
+		0x21, 0xdb, 0xd4, 0x86, 0x6d, 0xb2, 0x02, 0xb6, 0xc1, 0x33, 0xfd, 0x0c, 0x9b, 0xe4, 0x71, 0xa5,
+		0x6d, 0x50, 0x2b, 0xfd, 0x15, 0x80, 0x46, 0x59, 0x35, 0x13, 0x52, 0xb5, 0x2c, 0x8d, 0x8a, 0xb5,
+		0x6a, 0xbb, 0xac, 0x6b, 0x99, 0x76, 0xd7, 0xee, 0xb5, 0x3a, 0x76, 0xef, 0x8c, 0x4b, 0x99, 0xa0,
+		0xb9, 0x1d, 0x30, 0xca, 0xdb, 0x34, 0x5c, 0x82, 0x6b, 0xba, 0xb8, 0xca, 0x7b, 0xa4, 0x7e, 0x06,
+		0xdb, 0x92, 0xb6, 0x43, 0x03, 0x36, 0xc0, 0x16, 0xb4, 0x45, 0xed, 0xb0, 0xad, 0x6a, 0x9b, 0x5b,
+		0xd6, 0x86, 0x5f, 0xbe, 0x7f, 0x1c, 0x52, 0x0d, 0x50, 0xc6, 0x9b, 0xd7, 0xee, 0x86, 0xbf, 0xbe,
+		0x51, 0xa3, 0x44, 0xc0, 0x6b, 0xec, 0x66, 0x5b, 0xfd, 0x92, 0xba, 0x6b, 0x01, 0x34, 0xa9, 0xf9,
+		0x80, 0x98, 0x25, 0x61, 0xed, 0x1c, 0x69, 0x8c, 0x70, 0x38, 0xf4, 0x61, 0x32, 0x07, 0x27, 0x55,
+		0x28, 0x73, 0x08, 0x41, 0x99, 0x83, 0x32, 0x07, 0x65, 0x0e, 0x21, 0x28, 0x73, 0x08, 0x41, 0x99,
+		0x43, 0x08, 0xca, 0x1c, 0x5d, 0xac, 0xe1, 0xee, 0xdb, 0x93, 0xe6, 0xb1, 0x5c, 0x8f, 0x03, 0xf4,
+		0x5d, 0x68, 0x55, 0x89, 0x8d, 0x46, 0x97, 0xfd, 0x4e, 0xe1, 0x05, 0x1f, 0x11, 0xc6, 0xde, 0xe2,
+		0x3e, 0x0d, 0x56, 0xbd, 0x8d, 0x1a, 0x79, 0xe7, 0x67, 0x6c, 0xc3, 0xc2, 0xaa, 0xad, 0xd4, 0x6d,
+		0x59, 0xdd, 0x0e, 0xaa, 0xdc, 0x7d, 0x5e, 0x41, 0x81, 0x0b, 0xfd, 0xff, 0xb3, 0x1d, 0x9f, 0x90,
+		0x31, 0x22, 0x13, 0xd0, 0x7e, 0x84, 0xdb, 0xb0, 0x80, 0x02, 0xc2, 0x21, 0x2c, 0x1a, 0x90, 0x19,
+		0x8a, 0xcd, 0x30, 0x33, 0x54, 0x9b, 0xcc, 0x50, 0xe1, 0xcd, 0x08, 0x28, 0xb8, 0x33, 0xfb, 0x8d,
+		0x0b, 0x38, 0xcb, 0xd9, 0xc5, 0xa3, 0x9c, 0x6b, 0xd4, 0x63, 0xf8, 0x7c, 0xa1, 0x20, 0x27, 0x39,
+		0x9b, 0xad, 0x02, 0xb0, 0x59, 0x30, 0x01, 0x38, 0x6a, 0x32, 0xb2, 0xc2, 0x03, 0x21, 0xaa, 0x7e,
+		0x20, 0x04, 0x83, 0xcf, 0xd0, 0x18, 0x6e, 0xa2, 0xc7, 0x4d, 0xf4, 0xd5, 0x3a, 0x0a, 0x62, 0x0c,
+		0x47, 0xef, 0x18, 0xd1, 0x8b, 0xe8, 0x5d, 0x3f, 0x02, 0x42, 0x6b, 0x15, 0xad, 0x8b, 0x67, 0x40,
+		0x1c, 0x7f, 0x02, 0x7d, 0xaa, 0xbc, 0x82, 0x6d, 0xf5, 0xed, 0x7e, 0xa7, 0x6b, 0xf5, 0xf1, 0x28,
+		0x08, 0x30, 0x09, 0x1f, 0x72, 0x14, 0x04, 0x23, 0x63, 0x3c, 0x09, 0xe2, 0x88, 0x89, 0x97, 0x0c,
+		0x89, 0x4d, 0x40, 0x89, 0x97, 0xef, 0x61, 0x01, 0x05, 0x66, 0x02, 0x3e, 0x9b, 0x2f, 0x66, 0x80,
+		0x35, 0x9b, 0xc4, 0xae, 0xe0, 0x6c, 0xc0, 0xc2, 0xd9, 0xc0, 0xb1, 0x67, 0x03, 0x78, 0xb4, 0x16,
+		0xea, 0xaa, 0x22, 0xba, 0x0a, 0x5f, 0x4e, 0x3a, 0xa9, 0x86, 0xc0, 0x97, 0x93, 0x52, 0x5d, 0xf3,
+		0x96, 0xe5, 0x15, 0x8a, 0xa7, 0x12, 0xc4, 0x53, 0xa6, 0x26, 0x21, 0x20, 0xf9, 0x74, 0x1f, 0x17,
+		0x51, 0x44, 0x40, 0x29, 0xbe, 0x00, 0xc8, 0xa7, 0xd0, 0x0a, 0x53, 0xa9, 0x55, 0x17, 0x4f, 0xc2,
+		0x81, 0x2b, 0x27, 0xe1, 0xa0, 0x6c, 0x42, 0xd9, 0x84, 0xe9, 0xa8, 0x2a, 0x6b, 0x02, 0x4c, 0x47,
+		0x1d, 0xd8, 0x41, 0xaa, 0xa6, 0x97, 0x92, 0x74, 0x54, 0x2a, 0xe7, 0x56, 0x57, 0x50, 0x01, 0x47,
+		0x93, 0x20, 0xd4, 0x53, 0x0d, 0xa3, 0x2c, 0x96, 0x5e, 0x67, 0x68, 0x37, 0xae, 0xcd, 0xbb, 0xf1,
+		0x12, 0x42, 0x4f, 0x87, 0x30, 0xf4, 0x06, 0x3b, 0x47, 0x2d, 0x39, 0xc2, 0x42, 0xd1, 0xb6, 0xf4,
+		0x4c, 0x9d, 0x39, 0x95, 0xf1, 0x61, 0xd0, 0x35, 0x9f, 0x95, 0xb8, 0x25, 0x09, 0x10, 0x64, 0x52,
+		0x70, 0x38, 0xd6, 0x0a, 0x36, 0x29, 0x6d, 0x48, 0xce, 0x0f, 0x3a, 0xa9, 0xe3, 0x67, 0x41, 0x33,
+		0x64, 0x3b, 0x81, 0xcd, 0x2f, 0xc2, 0x02, 0x0a, 0xcd, 0x2e, 0x3c, 0xc8, 0xe4, 0x02, 0x3f, 0x08,
+		0x7a, 0x49, 0xef, 0xc4, 0x81, 0x90, 0x13, 0xb4, 0xac, 0x7c, 0xe4, 0x04, 0xa9, 0x92, 0x15, 0x91,
+		0x53, 0x41, 0xe4, 0xe4, 0x4e, 0x32, 0x00, 0x93, 0x0b, 0xfc, 0x1c, 0x25, 0x2c, 0x2c, 0x07, 0x4f,
+		0x0e, 0x0e, 0x9e, 0x14, 0xbc, 0xf1, 0x33, 0x0c, 0x03, 0xc8, 0xa1, 0xcf, 0x01, 0x1e, 0xfa, 0x5c,
+		0x37, 0x42, 0x02, 0x9d, 0xfa, 0xdc, 0x43, 0x42, 0x3a, 0x3f, 0x21, 0xe9, 0xcd, 0x62, 0xdf, 0x2a,
+		0x35, 0x19, 0x19, 0xbe, 0xa6, 0xc3, 0x60, 0x1a, 0xf6, 0x27, 0xee, 0xec, 0xc5, 0x63, 0x0e, 0x79,
+		0x5d, 0x79, 0xf3, 0x81, 0x72, 0x17, 0xb8, 0xb5, 0xb5, 0x06, 0x5b, 0x5b, 0xb3, 0xbf, 0x7f, 0xb2,
+		0xe3, 0xcf, 0xac, 0xef, 0xa0, 0x6c, 0x07, 0x18, 0xb7, 0xb6, 0x16, 0x00, 0x84, 0x3e, 0x30, 0x80,
+		0xc4, 0xf1, 0x46, 0x5f, 0x9f, 0xce, 0xdc, 0x71, 0xbf, 0x53, 0xe7, 0x9c, 0x7d, 0x7e, 0x00, 0x9a,
+		0x42, 0x14, 0x9f, 0x15, 0xc5, 0xc5, 0xcf, 0x3a, 0xde, 0xb8, 0xf2, 0xd0, 0x30, 0x74, 0x52, 0x67,
+		0x80, 0x94, 0x19, 0x6d, 0x18, 0xda, 0x59, 0x32, 0x6a, 0xec, 0xaf, 0xe1, 0xab, 0xb1, 0x86, 0xfe,
+		0xb4, 0xba, 0x51, 0xe1, 0x7f, 0x60, 0x7f, 0xf8, 0x9d, 0xeb, 0xee, 0x86, 0x66, 0xbb, 0xbe, 0xb4,
+		0x61, 0xa4, 0x54, 0xea, 0x86, 0x3f, 0x8b, 0x09, 0xa7, 0xf1, 0x03, 0x8d, 0xd7, 0xff, 0x01, 0x00,
+		0x00, 0xff, 0xff, 0x03, 0x00, 0xda, 0x63, 0x20, 0xa8, 0xd5, 0x92, 0x00, 0x00,
+	}
+)
+
+// ΛEnumTypes is a map, keyed by a YANG schema path, of the enumerated types that
+// correspond with the leaf. The type is represented as a reflect.Type. The naming
+// of the map ensures that there are no clashes with valid YANG identifiers.
+func initΛEnumTypes() {
+	ΛEnumTypes = map[string][]reflect.Type{
+		"/top/color": {
+			reflect.TypeOf((E_Rfc7951Methods_Top_Color)(0)),
+		},
+		"/top/kind": {
+			reflect.TypeOf((E_Rfc7951Methods_BASE)(0)),
+		},
+		"/top/kinds": {
+			reflect.TypeOf((E_Rfc7951Methods_BASE)(0)),
+		},
+		"/top/mixed": {
+			reflect.TypeOf((E_Rfc7951Methods_Top_Mixed)(0)),
+		},
+		"/top/mixes": {
+			reflect.TypeOf((E_Rfc7951Methods_Top_Mixes)(0)),
+		},
+	}
+}
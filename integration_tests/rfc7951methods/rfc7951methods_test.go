@@ -0,0 +1,145 @@
+// Copyright 2026 Google Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package rfc7951methods
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/openconfig/ygot/ygot"
+	"github.com/openconfig/ygot/ytypes"
+)
+
+// The generated structs must implement the interfaces through which the
+// generic marshalling and unmarshalling functions call the generated methods.
+var (
+	_ ygot.RFC7951Marshaler     = (*Device)(nil)
+	_ ygot.RFC7951Marshaler     = (*Rfc7951Methods_Top)(nil)
+	_ ytypes.RFC7951Unmarshaler = (*Device)(nil)
+	_ ytypes.RFC7951Unmarshaler = (*Rfc7951Methods_Top)(nil)
+	_ ytypes.RFC7951Unmarshaler = (*Rfc7951Methods_Top_Step)(nil)
+	_ ygot.RFC7951Marshaler     = (*Rfc7951Methods_Top_Step)(nil)
+)
+
+// populatedDevice returns a Device in which every kind of field that the
+// generated methods handle is set.
+func populatedDevice(t *testing.T) *Device {
+	t.Helper()
+	d := &Device{}
+	top := d.GetOrCreateTop()
+	top.Str = ygot.String("a \"quoted\" string")
+	top.Flag = ygot.Bool(false)
+	top.I8 = ygot.Int8(-8)
+	top.I64 = ygot.Int64(-9223372036854775808)
+	top.U32 = ygot.Uint32(32)
+	top.U64 = ygot.Uint64(18446744073709551615)
+	top.Dec = ygot.Float64(3.14)
+	top.Bin = Binary("binary")
+	top.On = true
+	top.Color = Rfc7951Methods_Top_Color_BLUE
+	top.Kind = Rfc7951Methods_BASE_DERIVED
+	top.Mixed = Rfc7951Methods_Top_Mixed_BRAVO
+	top.Names = []string{"one", "two"}
+	top.Counts = []uint16{1, 2, 3}
+	top.Kinds = []E_Rfc7951Methods_BASE{Rfc7951Methods_BASE_DERIVED}
+	top.Mixes = []Rfc7951Methods_Top_Mixes_Union{UnionString("s"), UnionInt32(-32), Rfc7951Methods_Top_Mixes_ALPHA}
+	top.GetOrCreateChild()
+	top.Extra = ygot.String("augmented")
+	top.GetOrCreateMore().Value = ygot.Int16(-16)
+	top.GetOrCreateEntry("e1").Value = ygot.Uint8(1)
+	top.GetOrCreateEntry("e2")
+	top.GetOrCreatePair("p", 2)
+	for _, id := range []uint32{3, 1, 2} {
+		if _, err := top.AppendNewStep(id); err != nil {
+			t.Fatalf("AppendNewStep(%d): %v", id, err)
+		}
+	}
+	top.Sample = []*Rfc7951Methods_Top_Sample{{Value: ygot.Int32(-1)}, {Value: ygot.Int32(1)}}
+	return d
+}
+
+// TestMarshalRFC7951 checks that the output of the generated MarshalRFC7951
+// methods, which ygot.Marshal7951 calls, is identical to that of the
+// reflection based marshalling, which is used when PreferShadowPath is set.
+// The modules do not define any shadow paths, and hence the option does not
+// otherwise change the output.
+func TestMarshalRFC7951(t *testing.T) {
+	tests := []struct {
+		desc string
+		in   *ygot.RFC7951JSONConfig
+	}{{
+		desc: "default configuration",
+		in:   &ygot.RFC7951JSONConfig{},
+	}, {
+		desc: "module names appended",
+		in:   &ygot.RFC7951JSONConfig{AppendModuleName: true},
+	}, {
+		desc: "module names prepended to identityrefs",
+		in:   &ygot.RFC7951JSONConfig{PrependModuleNameIdentityref: true},
+	}}
+
+	d := populatedDevice(t)
+	for _, tt := range tests {
+		t.Run(tt.desc, func(t *testing.T) {
+			got, err := ygot.Marshal7951(d, tt.in)
+			if err != nil {
+				t.Fatalf("Marshal7951() with generated methods: %v", err)
+			}
+			reflectCfg := *tt.in
+			reflectCfg.PreferShadowPath = true
+			want, err := ygot.Marshal7951(d, &reflectCfg)
+			if err != nil {
+				t.Fatalf("Marshal7951() with reflection: %v", err)
+			}
+			if diff := cmp.Diff(string(want), string(got)); diff != "" {
+				t.Errorf("Marshal7951(): generated methods did not produce the same JSON as reflection, diff(-reflection, +methods):\n%s", diff)
+			}
+		})
+	}
+}
+
+// TestUnmarshalRFC7951 checks that the generated UnmarshalRFC7951 methods,
+// which ytypes.Unmarshal calls, populate the same struct as the reflection
+// based unmarshalling, which is used when PreferShadowPath is set, and that
+// the struct round trips.
+func TestUnmarshalRFC7951(t *testing.T) {
+	in := populatedDevice(t)
+	js, err := ygot.Marshal7951(in, &ygot.RFC7951JSONConfig{AppendModuleName: true})
+	if err != nil {
+		t.Fatalf("Marshal7951(): %v", err)
+	}
+	var jsonTree any
+	if err := json.Unmarshal(js, &jsonTree); err != nil {
+		t.Fatalf("json.Unmarshal(): %v", err)
+	}
+
+	got := &Device{}
+	if err := ytypes.Unmarshal(SchemaTree["Device"], got, jsonTree); err != nil {
+		t.Fatalf("ytypes.Unmarshal() with generated methods: %v", err)
+	}
+	want := &Device{}
+	if err := ytypes.Unmarshal(SchemaTree["Device"], want, jsonTree, &ytypes.PreferShadowPath{}); err != nil {
+		t.Fatalf("ytypes.Unmarshal() with reflection: %v", err)
+	}
+
+	opt := cmp.AllowUnexported(Rfc7951Methods_Top_Step_OrderedMap{})
+	if diff := cmp.Diff(want, got, opt); diff != "" {
+		t.Errorf("ytypes.Unmarshal(): generated methods did not populate the same struct as reflection, diff(-reflection, +methods):\n%s", diff)
+	}
+	if diff := cmp.Diff(in, got, opt); diff != "" {
+		t.Errorf("ytypes.Unmarshal(): did not get the marshalled struct, diff(-want, +got):\n%s", diff)
+	}
+}
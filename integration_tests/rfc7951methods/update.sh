@@ -0,0 +1,12 @@
+#!/bin/bash
+
+go run ../../generator/generator.go -path="." -output_file=rfc7951methods.go \
+  -package_name=rfc7951methods -generate_fakeroot -fakeroot_name=device \
+  -generate_simple_unions \
+  -generate_getters \
+  -generate_append \
+  -yangpresence \
+  -generate_rfc7951_methods \
+  ../../testdata/modules/rfc7951-methods.yang \
+  ../../testdata/modules/rfc7951-methods-augment.yang
+gofmt -w -s rfc7951methods.go
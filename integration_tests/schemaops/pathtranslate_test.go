@@ -0,0 +1,192 @@
+// Copyright 2023 Google Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package schemaops_test
+
+import (
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/openconfig/ygot/integration_tests/schemaops/ctestschema"
+	"github.com/openconfig/ygot/integration_tests/schemaops/utestschema"
+	"github.com/openconfig/ygot/ygot"
+	"github.com/openconfig/ygot/ygot/pathtranslate"
+	"google.golang.org/protobuf/testing/protocmp"
+
+	gpb "github.com/openconfig/gnmi/proto/gnmi"
+)
+
+func mustPath(t *testing.T, s string) *gpb.Path {
+	t.Helper()
+	p, err := ygot.StringToStructuredPath(s)
+	if err != nil {
+		t.Fatalf("cannot parse path %q: %v", s, err)
+	}
+	return p
+}
+
+// newSchemaTranslator returns a SchemaTranslator between the ctestschema and
+// utestschema packages, which are generated from the same modules with and
+// without path compression.
+func newSchemaTranslator(t *testing.T, opts ...pathtranslate.SchemaTranslatorOpt) *pathtranslate.SchemaTranslator {
+	t.Helper()
+	csch, err := ctestschema.Schema()
+	if err != nil {
+		t.Fatalf("cannot get compressed schema: %v", err)
+	}
+	usch, err := utestschema.Schema()
+	if err != nil {
+		t.Fatalf("cannot get uncompressed schema: %v", err)
+	}
+	tr, err := pathtranslate.NewSchemaTranslator(csch, usch, opts...)
+	if err != nil {
+		t.Fatalf("cannot create SchemaTranslator: %v", err)
+	}
+	return tr
+}
+
+func TestSchemaTranslatorPaths(t *testing.T) {
+	tr := newSchemaTranslator(t)
+
+	tests := []struct {
+		desc             string
+		inCompressed     string
+		inUncompressed   string
+		wantUncompressed string
+		wantCompressed   string
+	}{{
+		desc:             "leaf in container",
+		inCompressed:     "/other-data/motd",
+		inUncompressed:   "/other-data/state/motd",
+		wantUncompressed: "/other-data/config/motd",
+		wantCompressed:   "/other-data/motd",
+	}, {
+		desc:             "leaf in list",
+		inCompressed:     "/unordered-list[key=foo]/value",
+		inUncompressed:   "/unordered-lists/unordered-list[key=foo]/config/value",
+		wantUncompressed: "/unordered-lists/unordered-list[key=foo]/config/value",
+		wantCompressed:   "/unordered-list[key=foo]/value",
+	}, {
+		desc:             "list key leaf",
+		inCompressed:     "/unordered-list[key=foo]/key",
+		inUncompressed:   "/unordered-lists/unordered-list[key=foo]/key",
+		wantUncompressed: "/unordered-lists/unordered-list[key=foo]/config/key",
+		wantCompressed:   "/unordered-list[key=foo]/key",
+	}, {
+		desc:             "nested ordered lists with wildcard key",
+		inCompressed:     "/ordered-list[key=*]/ordered-list[key=bar]",
+		inUncompressed:   "/ordered-lists/ordered-list[key=*]/ordered-lists/ordered-list[key=bar]",
+		wantUncompressed: "/ordered-lists/ordered-list[key=*]/ordered-lists/ordered-list[key=bar]",
+		wantCompressed:   "/ordered-list[key=*]/ordered-list[key=bar]",
+	}, {
+		desc:             "state only leaf",
+		inCompressed:     "/ordered-list[key=foo]/ro-value",
+		inUncompressed:   "/ordered-lists/ordered-list[key=foo]/state/ro-value",
+		wantUncompressed: "/ordered-lists/ordered-list[key=foo]/state/ro-value",
+		wantCompressed:   "/ordered-list[key=foo]/ro-value",
+	}}
+
+	for _, tt := range tests {
+		t.Run(tt.desc, func(t *testing.T) {
+			gotU, err := tr.ToUncompressedPath(mustPath(t, tt.inCompressed))
+			if err != nil {
+				t.Fatalf("ToUncompressedPath(%s): got unexpected error: %v", tt.inCompressed, err)
+			}
+			if diff := cmp.Diff(mustPath(t, tt.wantUncompressed), gotU, protocmp.Transform()); diff != "" {
+				t.Errorf("ToUncompressedPath(%s): did not get expected path, diff(-want, +got):\n%s", tt.inCompressed, diff)
+			}
+
+			gotC, err := tr.ToCompressedPath(mustPath(t, tt.inUncompressed))
+			if err != nil {
+				t.Fatalf("ToCompressedPath(%s): got unexpected error: %v", tt.inUncompressed, err)
+			}
+			if diff := cmp.Diff(mustPath(t, tt.wantCompressed), gotC, protocmp.Transform()); diff != "" {
+				t.Errorf("ToCompressedPath(%s): did not get expected path, diff(-want, +got):\n%s", tt.inUncompressed, diff)
+			}
+		})
+	}
+
+	shadowTr := newSchemaTranslator(t, &pathtranslate.PreferShadowPath{})
+	got, err := shadowTr.ToUncompressedPath(mustPath(t, "/other-data/motd"))
+	if err != nil {
+		t.Fatalf("ToUncompressedPath with PreferShadowPath: got unexpected error: %v", err)
+	}
+	if diff := cmp.Diff(mustPath(t, "/other-data/state/motd"), got, protocmp.Transform()); diff != "" {
+		t.Errorf("ToUncompressedPath with PreferShadowPath: did not get expected path, diff(-want, +got):\n%s", diff)
+	}
+}
+
+func TestSchemaTranslatorStructs(t *testing.T) {
+	compressed := &ctestschema.Device{}
+	compressed.GetOrCreateOtherData().Motd = ygot.String("hello")
+	compressed.GetOrCreateUnorderedList("foo").Value = ygot.String("foo-value")
+	compressed.OrderedList = &ctestschema.OrderedList_OrderedMap{}
+	for _, k := range []string{"b", "a"} {
+		ol, err := compressed.OrderedList.AppendNew(k)
+		if err != nil {
+			t.Fatal(err)
+		}
+		ol.Value = ygot.String(k + "-value")
+	}
+
+	uncompressed := &utestschema.Device{}
+	uncompressed.GetOrCreateOtherData().GetOrCreateConfig().Motd = ygot.String("hello")
+	ul := uncompressed.GetOrCreateUnorderedLists().GetOrCreateUnorderedList("foo")
+	ul.GetOrCreateConfig().Key = ygot.String("foo")
+	ul.GetOrCreateConfig().Value = ygot.String("foo-value")
+	uncompressed.GetOrCreateOrderedLists().OrderedList = &utestschema.Ctestschema_OrderedLists_OrderedList_OrderedMap{}
+	for _, k := range []string{"b", "a"} {
+		ol, err := uncompressed.GetOrCreateOrderedLists().OrderedList.AppendNew(k)
+		if err != nil {
+			t.Fatal(err)
+		}
+		ol.GetOrCreateConfig().Key = ygot.String(k)
+		ol.GetOrCreateConfig().Value = ygot.String(k + "-value")
+	}
+
+	tr := newSchemaTranslator(t)
+	gotU, err := tr.ToUncompressed(compressed)
+	if err != nil {
+		t.Fatalf("ToUncompressed: got unexpected error: %v", err)
+	}
+	if diff, err := ygot.Diff(uncompressed, gotU); err != nil || len(diff.Update) != 0 || len(diff.Delete) != 0 {
+		t.Errorf("ToUncompressed: did not get expected struct, diff: %v, err: %v", diff, err)
+	}
+	if got, want := gotU.(*utestschema.Device).GetOrderedLists().OrderedList.Keys(), []string{"b", "a"}; !cmp.Equal(got, want) {
+		t.Errorf("ToUncompressed: ordered list keys: got %v, want %v", got, want)
+	}
+
+	// State values of the uncompressed struct are discarded by default.
+	ul.GetOrCreateState().Value = ygot.String("state-value")
+	gotC, err := tr.ToCompressed(uncompressed)
+	if err != nil {
+		t.Fatalf("ToCompressed: got unexpected error: %v", err)
+	}
+	if diff, err := ygot.Diff(compressed, gotC); err != nil || len(diff.Update) != 0 || len(diff.Delete) != 0 {
+		t.Errorf("ToCompressed: did not get expected struct, diff: %v, err: %v", diff, err)
+	}
+
+	shadowTr := newSchemaTranslator(t, &pathtranslate.PreferShadowPath{})
+	gotC, err = shadowTr.ToCompressed(uncompressed)
+	if err != nil {
+		t.Fatalf("ToCompressed with PreferShadowPath: got unexpected error: %v", err)
+	}
+	if got, want := gotC.(*ctestschema.Device).GetUnorderedList("foo").GetValue(), "state-value"; got != want {
+		t.Errorf("ToCompressed with PreferShadowPath: got value %q, want %q", got, want)
+	}
+
+	if _, err := tr.ToCompressed(compressed); err == nil {
+		t.Errorf("ToCompressed with compressed struct: did not get expected error")
+	}
+}
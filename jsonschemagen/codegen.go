@@ -0,0 +1,407 @@
+// Copyright 2023 Google Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package jsonschemagen contains a library to generate a JSON Schema
+// (draft 2020-12) or OpenAPI 3.1 document from a set of YANG modules. The
+// schemas that are generated describe the RFC7951 JSON encoding of the data
+// tree, such that RFC7951 payloads can be validated against them.
+package jsonschemagen
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/openconfig/ygot/util"
+	"github.com/openconfig/ygot/ygen"
+)
+
+const (
+	// JSONSchemaDialect is the URI of the JSON Schema dialect that is
+	// used by the generated documents.
+	JSONSchemaDialect = "https://json-schema.org/draft/2020-12/schema"
+	// OpenAPIVersion is the version of the OpenAPI specification that
+	// generated OpenAPI documents conform to.
+	OpenAPIVersion = "3.1.0"
+	// DefaultTitle is the title of a generated OpenAPI document if none
+	// is specified.
+	DefaultTitle = "YANG schema"
+	// DefaultVersion is the version of a generated OpenAPI document if
+	// none is specified.
+	DefaultVersion = "0.0.0"
+)
+
+// Format specifies the type of document that is generated.
+type Format int64
+
+const (
+	// JSONSchema specifies that a JSON Schema document is generated, with
+	// the schemas of directories and enumerated types within its $defs.
+	JSONSchema Format = iota
+	// OpenAPI specifies that an OpenAPI document is generated, with the
+	// schemas of directories and enumerated types within its components.
+	OpenAPI
+)
+
+// String returns the name of the format.
+func (f Format) String() string {
+	switch f {
+	case JSONSchema:
+		return "jsonschema"
+	case OpenAPI:
+		return "openapi"
+	default:
+		return fmt.Sprintf("unknown format %d", int64(f))
+	}
+}
+
+// CodeGenerator is a structure that is used to pass arguments as to
+// how the output document should be generated.
+type CodeGenerator struct {
+	// IROptions stores the configuration parameters used for IR generation.
+	IROptions ygen.IROptions
+	// JSONSchemaOptions stores a struct which contains JSON Schema
+	// specific options for generation post IR generation.
+	JSONSchemaOptions JSONSchemaOpts
+}
+
+// JSONSchemaOpts stores JSON Schema specific options for the generation
+// library.
+type JSONSchemaOpts struct {
+	// Format specifies the type of document that is generated.
+	Format Format
+	// ID is the URI that is used as the $id of a generated JSON Schema
+	// document. It is omitted if empty.
+	ID string
+	// Title is the title of the generated document. DefaultTitle is used
+	// for an OpenAPI document if it is empty.
+	Title string
+	// Version is the version of the API described by a generated OpenAPI
+	// document. DefaultVersion is used if it is empty.
+	Version string
+	// IncludeDescriptions specifies whether the descriptions of YANG
+	// nodes are included in the generated schemas.
+	IncludeDescriptions bool
+}
+
+// New returns a new instance of the CodeGenerator
+// struct to the calling function.
+func New(opts ygen.IROptions, jsonSchemaOpts JSONSchemaOpts) *CodeGenerator {
+	return &CodeGenerator{
+		IROptions:         opts,
+		JSONSchemaOptions: jsonSchemaOpts,
+	}
+}
+
+// GeneratedSchema stores a generated JSON Schema or OpenAPI document.
+type GeneratedSchema struct {
+	// Document is the JSON-encoded document.
+	Document []byte
+	// RootDefinition is the name of the definition of the fake root
+	// within the document. It is empty if no fake root was generated.
+	RootDefinition string
+}
+
+// Generate generates a JSON Schema or OpenAPI document for the input set of
+// YANG files. The YANG schemas for which the document is to be created are
+// supplied as the yangFiles argument, with included modules being searched
+// for in includePaths.
+//
+// Each YANG container and list is described by a definition within the
+// document, which describes the members of the RFC7951 JSON object that
+// represents it. Each enumeration and identity is described by a definition
+// that lists its valid values. Where a fake root is generated, the JSON Schema
+// document validates an RFC7951 JSON document against the fake root.
+//
+// Schema compression is not supported, since RFC7951 JSON is encoded
+// according to the uncompressed schema.
+func (cg *CodeGenerator) Generate(yangFiles, includePaths []string) (*GeneratedSchema, util.Errors) {
+	if cg.IROptions.TransformationOptions.CompressBehaviour.CompressEnabled() {
+		return nil, util.NewErrs(fmt.Errorf("schema compression is not supported for JSON Schema generation, got compress behaviour %v", cg.IROptions.TransformationOptions.CompressBehaviour))
+	}
+
+	opts := ygen.IROptions{
+		ParseOptions:                        cg.IROptions.ParseOptions,
+		TransformationOptions:               cg.IROptions.TransformationOptions,
+		AppendEnumSuffixForSimpleUnionEnums: cg.IROptions.AppendEnumSuffixForSimpleUnionEnums,
+	}
+
+	ir, err := ygen.GenerateIR(yangFiles, includePaths, NewJSONSchemaLangMapper(), opts)
+	if err != nil {
+		return nil, util.NewErrs(err)
+	}
+
+	refBase := "#/$defs/"
+	if cg.JSONSchemaOptions.Format == OpenAPI {
+		refBase = "#/components/schemas/"
+	}
+
+	defs, rootName, errs := buildDefinitions(ir, refBase, cg.JSONSchemaOptions.IncludeDescriptions)
+	if errs != nil {
+		return nil, errs
+	}
+
+	var doc schema
+	switch cg.JSONSchemaOptions.Format {
+	case JSONSchema:
+		doc = schema{
+			"$schema": JSONSchemaDialect,
+			"$defs":   defs,
+		}
+		if cg.JSONSchemaOptions.ID != "" {
+			doc["$id"] = cg.JSONSchemaOptions.ID
+		}
+		if cg.JSONSchemaOptions.Title != "" {
+			doc["title"] = cg.JSONSchemaOptions.Title
+		}
+		if rootName != "" {
+			doc["$ref"] = refBase + rootName
+		}
+	case OpenAPI:
+		title := cg.JSONSchemaOptions.Title
+		if title == "" {
+			title = DefaultTitle
+		}
+		version := cg.JSONSchemaOptions.Version
+		if version == "" {
+			version = DefaultVersion
+		}
+		doc = schema{
+			"openapi": OpenAPIVersion,
+			"info": schema{
+				"title":   title,
+				"version": version,
+			},
+			"components": schema{
+				"schemas": defs,
+			},
+		}
+	default:
+		return nil, util.NewErrs(fmt.Errorf("unsupported output format %v", cg.JSONSchemaOptions.Format))
+	}
+
+	js, err := marshalSchema(doc)
+	if err != nil {
+		return nil, util.NewErrs(err)
+	}
+	return &GeneratedSchema{
+		Document:       js,
+		RootDefinition: rootName,
+	}, nil
+}
+
+// buildDefinitions returns the definitions of the directories and enumerated
+// types within the IR, keyed by definition name, along with the name of the
+// definition of the fake root if there is one. refBase is the prefix of the
+// JSON pointer to a definition by name within the output document.
+func buildDefinitions(ir *ygen.IR, refBase string, includeDescriptions bool) (schema, string, util.Errors) {
+	defs := schema{}
+	for _, k := range orderedEnumKeys(ir.Enums) {
+		defs[enumDefinitionPrefix+ir.Enums[k].Name] = enumSchema(ir.Enums[k])
+	}
+
+	var (
+		rootName string
+		errs     util.Errors
+	)
+	for _, p := range ir.OrderedDirectoryPaths() {
+		dir := ir.Directories[p]
+		if _, ok := defs[dir.Name]; ok {
+			errs = util.AppendErr(errs, fmt.Errorf("duplicate definition name %q for directory %s", dir.Name, p))
+			continue
+		}
+		ds, err := directorySchema(dir, ir, defs, refBase, includeDescriptions)
+		if err != nil {
+			errs = util.AppendErrs(errs, err)
+			continue
+		}
+		defs[dir.Name] = ds
+		if dir.IsFakeRoot {
+			rootName = dir.Name
+		}
+	}
+	if errs != nil {
+		return nil, "", errs
+	}
+
+	return defs, rootName, nil
+}
+
+// directorySchema returns the schema of the RFC7951 JSON object that
+// represents the directory dir. Each field of the directory is mapped to a
+// property, named according to RFC7951, such that it is qualified with the
+// name of its module where it is in a different namespace to its parent.
+// The keys of a list are required properties of each of its entries, and
+// fields that are not configuration are marked read-only. References from
+// leaves to enumerated types are resolved against the definitions in defs.
+func directorySchema(dir *ygen.ParsedDirectory, ir *ygen.IR, defs schema, refBase string, includeDescriptions bool) (schema, util.Errors) {
+	var errs util.Errors
+	props := schema{}
+	for _, fn := range dir.OrderedFieldNames() {
+		field := dir.Fields[fn]
+
+		var ps schema
+		switch field.Type {
+		case ygen.ContainerNode, ygen.ListNode:
+			child, ok := ir.Directories[field.YANGDetails.Path]
+			if !ok {
+				errs = util.AppendErr(errs, fmt.Errorf("%s: no directory for field %s", dir.Path, field.YANGDetails.Path))
+				continue
+			}
+			ps = schema{"$ref": refBase + child.Name}
+			if field.Type == ygen.ListNode {
+				ps = schema{"type": "array", "items": ps}
+			}
+		case ygen.LeafNode, ygen.LeafListNode:
+			ls, ok := field.Flags[leafSchemaFlagKey]
+			if !ok {
+				errs = util.AppendErr(errs, fmt.Errorf("%s: no schema for leaf %s", dir.Path, field.YANGDetails.Path))
+				continue
+			}
+			if err := json.Unmarshal([]byte(ls), &ps); err != nil {
+				errs = util.AppendErr(errs, fmt.Errorf("%s: invalid schema for leaf %s: %v", dir.Path, field.YANGDetails.Path, err))
+				continue
+			}
+			for _, ref := range resolveRefs(ps, refBase) {
+				if _, ok := defs[ref]; !ok {
+					errs = util.AppendErr(errs, fmt.Errorf("%s: no definition for enumerated type %q of leaf %s", dir.Path, ref, field.YANGDetails.Path))
+				}
+			}
+		case ygen.AnyDataNode:
+			ps = schema{}
+		default:
+			errs = util.AppendErr(errs, fmt.Errorf("%s: unsupported type %v for field %s", dir.Path, field.Type, field.YANGDetails.Path))
+			continue
+		}
+
+		if includeDescriptions && field.YANGDetails.Description != "" {
+			ps["description"] = field.YANGDetails.Description
+		}
+		if field.YANGDetails.ConfigFalse {
+			ps["readOnly"] = true
+		}
+		props[rfc7951Name(dir, field)] = ps
+	}
+	if errs != nil {
+		return nil, errs
+	}
+
+	// Members other than the fields of the directory are not permitted,
+	// other than the RFC7952 metadata of the directory and its fields,
+	// whose names begin with "@".
+	ds := schema{
+		"type":                 "object",
+		"additionalProperties": false,
+		"patternProperties":    schema{"^@": schema{}},
+	}
+	if len(props) != 0 {
+		ds["properties"] = props
+	}
+	if len(dir.ListKeyYANGNames) != 0 {
+		var required []string
+		for _, k := range dir.ListKeyYANGNames {
+			required = append(required, rfc7951Name(dir, dir.Fields[k]))
+		}
+		ds["required"] = required
+	}
+	return ds, nil
+}
+
+// rfc7951Name returns the name of the member of the RFC7951 JSON object that
+// represents dir which contains the value of field. The name is qualified with
+// the name of the module of field if dir is the fake root, or if dir and field
+// are in different namespaces.
+func rfc7951Name(dir *ygen.ParsedDirectory, field *ygen.NodeDetails) string {
+	if field == nil {
+		return ""
+	}
+	if dir.IsFakeRoot || field.YANGDetails.BelongingModule != dir.BelongingModule {
+		return fmt.Sprintf("%s:%s", field.YANGDetails.BelongingModule, field.Name)
+	}
+	return field.Name
+}
+
+// enumSchema returns the schema of the RFC7951 JSON values of the enumerated
+// type et. The value of an identity is qualified with the name of the module
+// in which it is defined.
+func enumSchema(et *ygen.EnumeratedYANGType) schema {
+	var values []string
+	for _, d := range et.ValToYANGDetails {
+		v := d.Name
+		if et.Kind == ygen.IdentityType {
+			v = fmt.Sprintf("%s:%s", d.DefiningModule, d.Name)
+		}
+		values = append(values, v)
+	}
+	es := schema{"type": "string"}
+	if len(values) != 0 {
+		es["enum"] = values
+	} else {
+		// An identity from which no identities are derived has no
+		// valid values.
+		es["not"] = schema{}
+	}
+	return es
+}
+
+// resolveRefs prefixes the value of each $ref keyword within the schema ts,
+// which is the name of a definition, with refBase such that it resolves to
+// the definition within the output document. It returns the names of the
+// definitions that are referenced.
+func resolveRefs(ts any, refBase string) []string {
+	var refs []string
+	switch v := ts.(type) {
+	case schema:
+		return resolveRefs(map[string]any(v), refBase)
+	case map[string]any:
+		for k, sv := range v {
+			if ref, ok := sv.(string); ok && k == "$ref" && !strings.HasPrefix(ref, "#") {
+				v[k] = refBase + ref
+				refs = append(refs, ref)
+				continue
+			}
+			refs = append(refs, resolveRefs(sv, refBase)...)
+		}
+	case []any:
+		for _, sv := range v {
+			refs = append(refs, resolveRefs(sv, refBase)...)
+		}
+	}
+	return refs
+}
+
+// orderedEnumKeys returns the keys of the enums map in lexicographical order.
+func orderedEnumKeys(enums map[string]*ygen.EnumeratedYANGType) []string {
+	var keys []string
+	for k := range enums {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	return keys
+}
+
+// marshalSchema returns the indented JSON encoding of the document doc, with
+// HTML characters left unescaped such that patterns remain readable.
+func marshalSchema(doc schema) ([]byte, error) {
+	var b bytes.Buffer
+	enc := json.NewEncoder(&b)
+	enc.SetEscapeHTML(false)
+	enc.SetIndent("", "  ")
+	if err := enc.Encode(doc); err != nil {
+		return nil, err
+	}
+	return b.Bytes(), nil
+}
@@ -0,0 +1,155 @@
+// Copyright 2023 Google Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package jsonschemagen
+
+import (
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/openconfig/gnmi/errdiff"
+	"github.com/openconfig/ygot/genutil"
+	"github.com/openconfig/ygot/testutil"
+	"github.com/openconfig/ygot/ygen"
+)
+
+const (
+	// datapath is the path to common YANG test modules.
+	datapath = "../testdata/modules"
+	// deflakeRuns specifies the number of runs of generation that
+	// should be performed to check for flakes.
+	deflakeRuns int = 10
+)
+
+func TestGenerate(t *testing.T) {
+	tests := []struct {
+		name     string
+		inFiles  []string
+		inConfig CodeGenerator
+		// wantFile is the path to the file containing the document
+		// that is expected to be generated.
+		wantFile           string
+		wantRootDefinition string
+		wantErrSubstring   string
+	}{{
+		name: "json schema with fake root",
+		inFiles: []string{
+			filepath.Join(datapath, "jsonschema.yang"),
+			filepath.Join(datapath, "jsonschema-augment.yang"),
+		},
+		inConfig: CodeGenerator{
+			IROptions: ygen.IROptions{
+				TransformationOptions: ygen.TransformationOpts{
+					GenerateFakeRoot: true,
+				},
+			},
+			JSONSchemaOptions: JSONSchemaOpts{
+				ID:                  "https://example.com/jsonschema.json",
+				Title:               "jsonschema",
+				IncludeDescriptions: true,
+			},
+		},
+		wantFile:           filepath.Join("testdata", "jsonschema.fakeroot.formatted-txt"),
+		wantRootDefinition: "Device",
+	}, {
+		name: "openapi without fake root",
+		inFiles: []string{
+			filepath.Join(datapath, "jsonschema.yang"),
+			filepath.Join(datapath, "jsonschema-augment.yang"),
+		},
+		inConfig: CodeGenerator{
+			JSONSchemaOptions: JSONSchemaOpts{
+				Format:  OpenAPI,
+				Version: "1.2.3",
+			},
+		},
+		wantFile: filepath.Join("testdata", "jsonschema.openapi.formatted-txt"),
+	}, {
+		name: "json schema excluding state",
+		inFiles: []string{
+			filepath.Join(datapath, "openconfig-config-false.yang"),
+		},
+		inConfig: CodeGenerator{
+			IROptions: ygen.IROptions{
+				TransformationOptions: ygen.TransformationOpts{
+					GenerateFakeRoot:  true,
+					CompressBehaviour: genutil.UncompressedExcludeDerivedState,
+				},
+			},
+		},
+		wantFile:           filepath.Join("testdata", "openconfig-config-false.exclude-state.formatted-txt"),
+		wantRootDefinition: "Device",
+	}, {
+		name: "compressed schema",
+		inFiles: []string{
+			filepath.Join(datapath, "jsonschema.yang"),
+		},
+		inConfig: CodeGenerator{
+			IROptions: ygen.IROptions{
+				TransformationOptions: ygen.TransformationOpts{
+					CompressBehaviour: genutil.PreferIntendedConfig,
+				},
+			},
+		},
+		wantErrSubstring: "schema compression is not supported",
+	}}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cg := New(tt.inConfig.IROptions, tt.inConfig.JSONSchemaOptions)
+
+			got, errs := cg.Generate(tt.inFiles, nil)
+			var err error
+			if errs != nil {
+				err = errs
+			}
+			if diff := errdiff.Substring(err, tt.wantErrSubstring); diff != "" {
+				t.Fatalf("Generate(%v): did not get expected error, %s", tt.inFiles, diff)
+			}
+			if err != nil {
+				return
+			}
+
+			if got.RootDefinition != tt.wantRootDefinition {
+				t.Errorf("Generate(%v): did not get expected root definition, got: %q, want: %q", tt.inFiles, got.RootDefinition, tt.wantRootDefinition)
+			}
+
+			if !json.Valid(got.Document) {
+				t.Fatalf("Generate(%v): generated document is not valid JSON:\n%s", tt.inFiles, got.Document)
+			}
+
+			want, err := os.ReadFile(tt.wantFile)
+			if err != nil {
+				t.Fatalf("cannot read want file %s: %v", tt.wantFile, err)
+			}
+
+			if string(got.Document) != string(want) {
+				diff, _ := testutil.GenerateUnifiedDiff(string(want), string(got.Document))
+				t.Errorf("Generate(%v): did not get expected document (file: %s), diff(-want, +got):\n%s", tt.inFiles, tt.wantFile, diff)
+			}
+
+			for i := 0; i < deflakeRuns; i++ {
+				again, errs := New(tt.inConfig.IROptions, tt.inConfig.JSONSchemaOptions).Generate(tt.inFiles, nil)
+				if errs != nil {
+					t.Fatalf("Generate(%v): got unexpected errors on run %d: %v", tt.inFiles, i, errs)
+				}
+				if string(again.Document) != string(got.Document) {
+					t.Fatalf("Generate(%v): flaky output on run %d", tt.inFiles, i)
+				}
+			}
+		})
+	}
+}
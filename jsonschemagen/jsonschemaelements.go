@@ -0,0 +1,510 @@
+// Copyright 2023 Google Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package jsonschemagen
+
+import (
+	"encoding/json"
+	"fmt"
+	"math"
+	"strconv"
+	"strings"
+
+	log "github.com/golang/glog"
+	"github.com/openconfig/goyang/pkg/yang"
+	"github.com/openconfig/ygot/genutil"
+	"github.com/openconfig/ygot/internal/igenutil"
+	"github.com/openconfig/ygot/util"
+	"github.com/openconfig/ygot/ygen"
+)
+
+const (
+	// enumDefinitionPrefix is the prefix that is used for the names of
+	// the definitions of enumerated types in the output document, in the
+	// form:
+	//   <enumDefinitionPrefix><EnumName>
+	enumDefinitionPrefix string = "E_"
+	// leafSchemaFlagKey is the key within the Flags of a leaf or leaf-list
+	// field in the IR that stores the JSON-encoded schema of the field.
+	// Any references to definitions within the schema contain the name of
+	// the definition only, such that they can be resolved against the
+	// location of the definitions in the output document.
+	leafSchemaFlagKey string = "jsonschema-leaf-schema"
+)
+
+// schema is the representation of a JSON Schema object in the generated
+// document. Maps are used such that the output is deterministically ordered
+// by keyword when marshalled.
+type schema map[string]any
+
+// Ensure at compile time that the JSONSchemaLangMapper implements the LangMapper interface.
+var _ ygen.LangMapper = &JSONSchemaLangMapper{}
+
+// JSONSchemaLangMapper contains the functionality and state for generating
+// JSON Schema definition names and types for the generated document.
+type JSONSchemaLangMapper struct {
+	// LangMapperBase being embedded is a requirement for
+	// JSONSchemaLangMapper to implement the LangMapper interface, and also
+	// gives it access to built-in methods.
+	ygen.LangMapperBase
+
+	// definedNames specifies the definition names used during
+	// generation to avoid conflicts.
+	definedNames map[string]bool
+	// uniqueDirectoryNames is a map keyed by the path of a YANG entity
+	// representing a directory in the generated document whose value is
+	// the unique definition name that it was mapped to.
+	uniqueDirectoryNames map[string]string
+	// leafTypeSchemas is a map keyed by the path of a YANG leaf or
+	// leaf-list whose value is the schema of the values of the leaf, as
+	// calculated when mapping its type.
+	leafTypeSchemas map[string]schema
+
+	// UnimplementedLangMapperExt ensures JSONSchemaLangMapper implements
+	// the LangMapperExt interface for forwards compatibility.
+	ygen.UnimplementedLangMapperExt
+}
+
+// NewJSONSchemaLangMapper creates a new JSONSchemaLangMapper instance,
+// initialised with the default state required for generation.
+func NewJSONSchemaLangMapper() *JSONSchemaLangMapper {
+	return &JSONSchemaLangMapper{
+		definedNames:         map[string]bool{},
+		uniqueDirectoryNames: map[string]string{},
+		leafTypeSchemas:      map[string]schema{},
+	}
+}
+
+// resolveTypeArgs is a structure used as an input argument to the
+// yangTypeToSchema function which allows extra context to be handed on. This
+// provides the ability to use not only the YangType but also the yang.Entry
+// that the type was part of to resolve the possible type name.
+type resolveTypeArgs struct {
+	// yangType is a pointer to the yang.YangType that is to be mapped.
+	yangType *yang.YangType
+	// contextEntry is an optional yang.Entry which is supplied where a
+	// type requires knowledge of the leaf that it is used within to be
+	// mapped. For example, where a leaf is defined to have a type of a
+	// user-defined type (typedef) that in turn has enumerated values - the
+	// context of the yang.Entry is required such that the leaf's context
+	// can be established.
+	contextEntry *yang.Entry
+}
+
+// DirectoryName generates the definition name to be used for a particular
+// YANG schema element in the generated document. The name is of the form
+// PathElement1_PathElement2, and is made unique amongst the names of all
+// directories.
+func (s *JSONSchemaLangMapper) DirectoryName(e *yang.Entry, cb genutil.CompressBehaviour) (string, error) {
+	if name, ok := s.uniqueDirectoryNames[e.Path()]; ok {
+		return name, nil
+	}
+	name := genutil.MakeNameUnique(definitionName(e, cb.CompressEnabled()), s.definedNames)
+	s.uniqueDirectoryNames[e.Path()] = name
+	return name, nil
+}
+
+// FieldName maps the input entry's name to the local name of the member that
+// represents it in RFC7951 JSON, which is the YANG identifier of the node. The
+// module name that is required to qualify the member name where the node is
+// in a different namespace to its parent is added during generation.
+func (s *JSONSchemaLangMapper) FieldName(e *yang.Entry) (string, error) {
+	return e.Name, nil
+}
+
+// LeafType maps the input leaf entry to a ygen.MappedType object containing
+// the type information about the field. The schema of the values of the leaf
+// is stored such that it can be added to the field's flags.
+func (s *JSONSchemaLangMapper) LeafType(e *yang.Entry, opts ygen.IROptions) (*ygen.MappedType, error) {
+	mtype, ts, err := s.yangTypeToSchema(resolveTypeArgs{yangType: e.Type, contextEntry: e}, opts)
+	if err != nil {
+		return nil, err
+	}
+	s.leafTypeSchemas[e.Path()] = ts
+	return mtype, nil
+}
+
+// KeyLeafType maps the input list key entry to a ygen.MappedType object
+// containing the type information about the key field.
+func (s *JSONSchemaLangMapper) KeyLeafType(e *yang.Entry, opts ygen.IROptions) (*ygen.MappedType, error) {
+	mtype, _, err := s.yangTypeToSchema(resolveTypeArgs{yangType: e.Type, contextEntry: e}, opts)
+	return mtype, err
+}
+
+// PackageName is not used by JSON Schema generation.
+func (s *JSONSchemaLangMapper) PackageName(*yang.Entry, genutil.CompressBehaviour, bool) (string, error) {
+	return "", nil
+}
+
+// PopulateFieldFlags stores the JSON-encoded schema of leaf and leaf-list
+// fields within their flags, such that the constraints of the YANG type
+// (ranges, lengths and patterns) and the default values of the field can be
+// output in the generated document.
+func (s *JSONSchemaLangMapper) PopulateFieldFlags(nd ygen.NodeDetails, field *yang.Entry) map[string]string {
+	if nd.Type != ygen.LeafNode && nd.Type != ygen.LeafListNode {
+		return nil
+	}
+	ts, ok := s.leafTypeSchemas[field.Path()]
+	if !ok {
+		return nil
+	}
+
+	var defaults []any
+	if field.Type.Kind != yang.Yidentityref {
+		// The default values of identityrefs are not output since
+		// the module of the identity cannot be determined from the
+		// prefixed value specified in the YANG schema.
+		for _, v := range field.DefaultValues() {
+			defaults = append(defaults, defaultValue(field.Type, v))
+		}
+	}
+
+	ls := ts
+	switch {
+	case nd.Type == ygen.LeafListNode:
+		ls = schema{"type": "array", "items": ts}
+		if len(defaults) != 0 {
+			ls["default"] = defaults
+		}
+	case len(defaults) != 0:
+		ls = schema{}
+		for k, v := range ts {
+			ls[k] = v
+		}
+		ls["default"] = defaults[0]
+	}
+
+	js, err := json.Marshal(ls)
+	if err != nil {
+		// This can never occur since the schema contains only values
+		// that can be marshalled.
+		log.Errorf("cannot marshal schema for %s: %v", field.Path(), err)
+		return nil
+	}
+	return map[string]string{leafSchemaFlagKey: string(js)}
+}
+
+// definitionName takes an input yang.Entry and outputs its name in the form
+// PathElement1_PathElement2, performing schema compression if required. The
+// name is not checked for uniqueness.
+func definitionName(e *yang.Entry, compressPaths bool) string {
+	if igenutil.IsFakeRoot(e) {
+		return genutil.EntryCamelCaseName(e)
+	}
+
+	var names []string
+	for element := e; element != nil; element = element.Parent {
+		if compressPaths && util.IsOCCompressedValidElement(element) || !compressPaths && !util.IsChoiceOrCase(element) {
+			names = append([]string{genutil.EntryCamelCaseName(element)}, names...)
+		}
+	}
+	return strings.Join(names, "_")
+}
+
+// yangTypeToSchema takes a yang.YangType (YANG type definition) and maps it
+// to the JSON Schema that describes its values when encoded as RFC7951 JSON,
+// along with the ygen.MappedType that is used to represent it in the IR. The
+// NativeType of the mapped type is the JSON type of the values, or the name of
+// the definition of an enumerated type.
+func (s *JSONSchemaLangMapper) yangTypeToSchema(args resolveTypeArgs, opts ygen.IROptions) (*ygen.MappedType, schema, error) {
+	// Handle the case of a typedef which is actually an enumeration.
+	typedefName, key, isTypedef, err := s.EnumeratedTypedefTypeName(args.yangType, args.contextEntry, enumDefinitionPrefix, !opts.TransformationOptions.EnumerationsUseUnderscores, opts.TransformationOptions.UseDefiningModuleForTypedefEnumNames)
+	if err != nil {
+		return nil, nil, err
+	}
+	if isTypedef {
+		return enumeratedType(typedefName, key)
+	}
+
+	t := args.yangType
+	switch t.Kind {
+	case yang.Yint8, yang.Yint16, yang.Yint32, yang.Yuint8, yang.Yuint16, yang.Yuint32:
+		ts := schema{"type": "integer"}
+		if err := addRanges(ts, t.Range, "minimum", "maximum"); err != nil {
+			return nil, nil, fmt.Errorf("invalid range for %s: %v", args.contextEntry.Path(), err)
+		}
+		return &ygen.MappedType{NativeType: "integer"}, ts, nil
+	case yang.Yint64:
+		// RFC7951 encodes 64-bit integers as strings, such that their
+		// range cannot be checked by JSON Schema keywords, and is
+		// instead output as an annotation.
+		ts := schema{"type": "string", "pattern": "^-?[0-9]+$"}
+		addRangeAnnotation(ts, t.Range, yang.Int64Range)
+		return &ygen.MappedType{NativeType: "string"}, ts, nil
+	case yang.Yuint64:
+		ts := schema{"type": "string", "pattern": "^[0-9]+$"}
+		addRangeAnnotation(ts, t.Range, yang.Uint64Range)
+		return &ygen.MappedType{NativeType: "string"}, ts, nil
+	case yang.Ydecimal64:
+		ts := schema{"type": "string", "pattern": fmt.Sprintf("^-?[0-9]+(\\.[0-9]{1,%d})?$", t.FractionDigits)}
+		addRangeAnnotation(ts, t.Range, yang.YangRange{{
+			Min: yang.Number{Value: yang.AbsMinInt64, Negative: true, FractionDigits: uint8(t.FractionDigits)},
+			Max: yang.Number{Value: yang.MaxInt64, FractionDigits: uint8(t.FractionDigits)},
+		}})
+		return &ygen.MappedType{NativeType: "string"}, ts, nil
+	case yang.Ystring:
+		ts := schema{"type": "string"}
+		if err := addRanges(ts, t.Length, "minLength", "maxLength"); err != nil {
+			return nil, nil, fmt.Errorf("invalid length for %s: %v", args.contextEntry.Path(), err)
+		}
+		addPatterns(ts, t)
+		return &ygen.MappedType{NativeType: "string"}, ts, nil
+	case yang.Ybool:
+		return &ygen.MappedType{NativeType: "boolean"}, schema{"type": "boolean"}, nil
+	case yang.Yempty:
+		// An empty leaf is encoded as [null] in RFC7951.
+		return &ygen.MappedType{NativeType: "array"}, schema{"const": []any{nil}}, nil
+	case yang.Ybinary:
+		return &ygen.MappedType{NativeType: "string"}, schema{"type": "string", "contentEncoding": "base64"}, nil
+	case yang.Ybits:
+		return &ygen.MappedType{NativeType: "string"}, schema{"type": "string", "pattern": bitsPattern(t.Bit)}, nil
+	case yang.Yenum:
+		if args.contextEntry == nil {
+			return nil, nil, fmt.Errorf("cannot map enum without context")
+		}
+		n, key, err := s.EnumName(args.contextEntry, opts.TransformationOptions.CompressBehaviour.CompressEnabled(), !opts.TransformationOptions.EnumerationsUseUnderscores, opts.TransformationOptions.SkipEnumDeduplication, opts.TransformationOptions.ShortenEnumLeafNames, false, opts.TransformationOptions.EnumOrgPrefixesToTrim)
+		if err != nil {
+			return nil, nil, err
+		}
+		return enumeratedType(enumDefinitionPrefix+n, key)
+	case yang.Yidentityref:
+		n, key, err := s.IdentityrefBaseTypeFromIdentity(t.IdentityBase)
+		if err != nil {
+			return nil, nil, err
+		}
+		return enumeratedType(enumDefinitionPrefix+n, key)
+	case yang.Yleafref:
+		// This is a leafref, so we check what the type of the leaf that it
+		// references is by looking it up.
+		target, err := s.ResolveLeafrefTarget(t.Path, args.contextEntry)
+		if err != nil {
+			return nil, nil, err
+		}
+		return s.yangTypeToSchema(resolveTypeArgs{yangType: target.Type, contextEntry: target}, opts)
+	case yang.Yunion:
+		return s.unionSchema(args, opts)
+	default:
+		// Any value is accepted for types that cannot be described.
+		return &ygen.MappedType{}, schema{}, nil
+	}
+}
+
+// unionSchema maps a YANG union to a JSON Schema that accepts a value that is
+// valid for any of its subtypes. Nested unions are flattened, and subtypes
+// that map to the same schema are output once. Where all subtypes map to the
+// same schema, that schema is returned directly.
+func (s *JSONSchemaLangMapper) unionSchema(args resolveTypeArgs, opts ygen.IROptions) (*ygen.MappedType, schema, error) {
+	mtype := &ygen.MappedType{UnionTypes: map[string]ygen.MappedUnionSubtype{}}
+	var (
+		subschemas []any
+		seen       = map[string]bool{}
+		errs       util.Errors
+	)
+
+	var addSubtypes func(*yang.YangType)
+	addSubtypes = func(t *yang.YangType) {
+		// If t.Type is not empty then this means that this type is
+		// defined to be a union itself.
+		if len(t.Type) != 0 {
+			for _, st := range t.Type {
+				addSubtypes(st)
+			}
+			return
+		}
+		smtype, ss, err := s.yangTypeToSchema(resolveTypeArgs{yangType: t, contextEntry: args.contextEntry}, opts)
+		if err != nil {
+			errs = util.AppendErr(errs, err)
+			return
+		}
+		if _, ok := mtype.UnionTypes[smtype.NativeType]; !ok {
+			mtype.UnionTypes[smtype.NativeType] = ygen.MappedUnionSubtype{
+				Index:                 len(mtype.UnionTypes),
+				EnumeratedYANGTypeKey: smtype.EnumeratedYANGTypeKey,
+			}
+		}
+		js, err := json.Marshal(ss)
+		if err != nil {
+			errs = util.AppendErr(errs, err)
+			return
+		}
+		if !seen[string(js)] {
+			seen[string(js)] = true
+			subschemas = append(subschemas, ss)
+		}
+	}
+	addSubtypes(args.yangType)
+	if errs != nil {
+		return nil, nil, errs
+	}
+
+	if len(mtype.UnionTypes) == 1 {
+		for n, st := range mtype.UnionTypes {
+			mtype = &ygen.MappedType{
+				NativeType:            n,
+				IsEnumeratedValue:     st.EnumeratedYANGTypeKey != "",
+				EnumeratedYANGTypeKey: st.EnumeratedYANGTypeKey,
+			}
+		}
+	} else {
+		mtype.NativeType = "union"
+	}
+
+	if len(subschemas) == 1 {
+		return mtype, subschemas[0].(schema), nil
+	}
+	return mtype, schema{"anyOf": subschemas}, nil
+}
+
+// enumeratedType returns the mapped type and schema of a leaf whose values
+// are those of the enumerated type with the definition name and key supplied.
+func enumeratedType(name, key string) (*ygen.MappedType, schema, error) {
+	return &ygen.MappedType{
+		NativeType:            name,
+		IsEnumeratedValue:     true,
+		EnumeratedYANGTypeKey: key,
+	}, schema{"$ref": name}, nil
+}
+
+// addRanges adds the bounds of the YANG range r to the schema ts, using the
+// keywords supplied for the lower and upper bounds. Where the range consists
+// of more than one part, an anyOf keyword is used to accept values within
+// any of them. Bounds that cannot restrict the values of the type, such as
+// the upper bound of the length of a string that is not restricted, are not
+// output.
+func addRanges(ts schema, r yang.YangRange, minKeyword, maxKeyword string) error {
+	var parts []any
+	for _, yr := range r {
+		part := schema{}
+		min, err := yr.Min.Int()
+		if err != nil {
+			return err
+		}
+		if min != 0 || minKeyword == "minimum" {
+			part[minKeyword] = min
+		}
+		if yr.Max.Negative || yr.Max.Value != math.MaxUint64 {
+			max, err := yr.Max.Int()
+			if err != nil {
+				return err
+			}
+			part[maxKeyword] = max
+		}
+		if len(part) != 0 {
+			parts = append(parts, part)
+		}
+	}
+
+	switch {
+	case len(parts) == 1:
+		for k, v := range parts[0].(schema) {
+			ts[k] = v
+		}
+	case len(parts) > 1:
+		ts["anyOf"] = parts
+	}
+	return nil
+}
+
+// rangeAnnotationKeyword is the annotation that records the YANG range of a
+// type whose values are encoded as strings, such that the range cannot be
+// expressed using the minimum and maximum keywords.
+const rangeAnnotationKeyword = "x-yang-range"
+
+// addRangeAnnotation adds the YANG range r to the schema ts as an annotation,
+// using YANG notation, if it restricts the values of the type, i.e., if it is
+// not the range of the built-in type, full.
+func addRangeAnnotation(ts schema, r, full yang.YangRange) {
+	if len(r) == 0 || r.Equal(full) {
+		return
+	}
+	ts[rangeAnnotationKeyword] = r.String()
+}
+
+// addPatterns adds the patterns that restrict the string type t to the schema
+// ts. POSIX patterns, which are specified using the openconfig-extensions
+// posix-pattern statement, are used in preference to the YANG patterns where
+// they are present. YANG patterns are implicitly anchored, and hence are
+// anchored when they are output.
+func addPatterns(ts schema, t *yang.YangType) {
+	patterns := t.POSIXPattern
+	if len(patterns) == 0 {
+		for _, p := range t.Pattern {
+			patterns = append(patterns, fmt.Sprintf("^(?:%s)$", p))
+		}
+	}
+
+	switch len(patterns) {
+	case 0:
+	case 1:
+		ts["pattern"] = patterns[0]
+	default:
+		var all []any
+		for _, p := range patterns {
+			all = append(all, schema{"pattern": p})
+		}
+		ts["allOf"] = all
+	}
+}
+
+// bitsPattern returns a pattern that matches the RFC7951 encoding of a value
+// of a YANG bits type with the bits b, which is a space-separated list of the
+// names of the bits that are set.
+func bitsPattern(b *yang.EnumType) string {
+	var names []string
+	if b != nil {
+		for _, v := range b.Values() {
+			names = append(names, b.Name(v))
+		}
+	}
+	bit := fmt.Sprintf("(?:%s)", strings.Join(names, "|"))
+	return fmt.Sprintf("^(?:%s(?: %s)*)?$", bit, bit)
+}
+
+// defaultValue returns the JSON value that corresponds to the default value v
+// of the YANG type t. Where the value cannot be represented as the JSON type
+// that is used for t, it is returned as a string.
+func defaultValue(t *yang.YangType, v string) any {
+	if dv, ok := typedDefaultValue(t, v); ok {
+		return dv
+	}
+	return v
+}
+
+// typedDefaultValue returns the JSON value that corresponds to the default
+// value v of the YANG type t, and whether v is a valid value of t. For a
+// union, the value is that of the first subtype that v is valid for.
+func typedDefaultValue(t *yang.YangType, v string) (any, bool) {
+	switch t.Kind {
+	case yang.Yint8, yang.Yint16, yang.Yint32:
+		i, err := strconv.ParseInt(v, 0, 64)
+		return i, err == nil
+	case yang.Yuint8, yang.Yuint16, yang.Yuint32:
+		u, err := strconv.ParseUint(v, 0, 64)
+		return u, err == nil
+	case yang.Ybool:
+		b, err := strconv.ParseBool(v)
+		return b, err == nil
+	case yang.Yenum:
+		return v, t.Enum != nil && t.Enum.IsDefined(v)
+	case yang.Yunion:
+		for _, st := range t.Type {
+			if dv, ok := typedDefaultValue(st, v); ok {
+				return dv, true
+			}
+		}
+		return v, false
+	default:
+		return v, true
+	}
+}
@@ -0,0 +1,219 @@
+// Copyright 2023 Google Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package jsonschemagen
+
+import (
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/openconfig/gnmi/errdiff"
+	"github.com/openconfig/goyang/pkg/yang"
+	"github.com/openconfig/ygot/ygen"
+)
+
+// mustParseRange parses the YANG range s, failing the test if it is invalid.
+func mustParseRange(t *testing.T, s string) yang.YangRange {
+	t.Helper()
+	r, err := yang.ParseRangesInt(s)
+	if err != nil {
+		t.Fatalf("cannot parse range %q: %v", s, err)
+	}
+	return r
+}
+
+func TestYangTypeToSchema(t *testing.T) {
+	tests := []struct {
+		name             string
+		in               *yang.YangType
+		want             schema
+		wantNativeType   string
+		wantErrSubstring string
+	}{{
+		name:           "int8 with default range",
+		in:             &yang.YangType{Kind: yang.Yint8, Range: mustParseRange(t, "-128..127")},
+		want:           schema{"type": "integer", "minimum": int64(-128), "maximum": int64(127)},
+		wantNativeType: "integer",
+	}, {
+		name: "uint16 with multiple ranges",
+		in:   &yang.YangType{Kind: yang.Yuint16, Range: mustParseRange(t, "1..10|20..30")},
+		want: schema{
+			"type": "integer",
+			"anyOf": []any{
+				schema{"minimum": int64(1), "maximum": int64(10)},
+				schema{"minimum": int64(20), "maximum": int64(30)},
+			},
+		},
+		wantNativeType: "integer",
+	}, {
+		name:           "int64 encoded as string",
+		in:             &yang.YangType{Kind: yang.Yint64},
+		want:           schema{"type": "string", "pattern": "^-?[0-9]+$"},
+		wantNativeType: "string",
+	}, {
+		name:           "decimal64",
+		in:             &yang.YangType{Kind: yang.Ydecimal64, FractionDigits: 3},
+		want:           schema{"type": "string", "pattern": `^-?[0-9]+(\.[0-9]{1,3})?$`},
+		wantNativeType: "string",
+	}, {
+		name:           "int64 with default range",
+		in:             &yang.YangType{Kind: yang.Yint64, Range: yang.Int64Range},
+		want:           schema{"type": "string", "pattern": "^-?[0-9]+$"},
+		wantNativeType: "string",
+	}, {
+		name:           "uint64 with restricted range",
+		in:             &yang.YangType{Kind: yang.Yuint64, Range: mustParseRange(t, "1..10|20..30")},
+		want:           schema{"type": "string", "pattern": "^[0-9]+$", "x-yang-range": "1..10|20..30"},
+		wantNativeType: "string",
+	}, {
+		name: "decimal64 with restricted range",
+		in: &yang.YangType{Kind: yang.Ydecimal64, FractionDigits: 2, Range: yang.YangRange{{
+			Min: yang.Number{Value: 150, Negative: true, FractionDigits: 2},
+			Max: yang.Number{Value: 150, FractionDigits: 2},
+		}}},
+		want:           schema{"type": "string", "pattern": `^-?[0-9]+(\.[0-9]{1,2})?$`, "x-yang-range": "-1.50..1.50"},
+		wantNativeType: "string",
+	}, {
+		name:           "string with unbounded length",
+		in:             &yang.YangType{Kind: yang.Ystring, Length: mustParseRange(t, "2..18446744073709551615")},
+		want:           schema{"type": "string", "minLength": int64(2)},
+		wantNativeType: "string",
+	}, {
+		name: "string with YANG and POSIX patterns",
+		in: &yang.YangType{
+			Kind:         yang.Ystring,
+			Pattern:      []string{`[a-z]+`},
+			POSIXPattern: []string{`^[a-z]+$`},
+		},
+		want:           schema{"type": "string", "pattern": "^[a-z]+$"},
+		wantNativeType: "string",
+	}, {
+		name:           "empty",
+		in:             &yang.YangType{Kind: yang.Yempty},
+		want:           schema{"const": []any{nil}},
+		wantNativeType: "array",
+	}, {
+		name: "union of strings with identical schemas",
+		in: &yang.YangType{
+			Kind: yang.Yunion,
+			Type: []*yang.YangType{
+				{Kind: yang.Ystring},
+				{Kind: yang.Ystring},
+			},
+		},
+		want:           schema{"type": "string"},
+		wantNativeType: "string",
+	}, {
+		name: "union of boolean and string",
+		in: &yang.YangType{
+			Kind: yang.Yunion,
+			Type: []*yang.YangType{
+				{Kind: yang.Ybool},
+				{Kind: yang.Ystring},
+			},
+		},
+		want: schema{
+			"anyOf": []any{
+				schema{"type": "boolean"},
+				schema{"type": "string"},
+			},
+		},
+		wantNativeType: "union",
+	}, {
+		name:             "enumeration without context",
+		in:               &yang.YangType{Kind: yang.Yenum},
+		wantErrSubstring: "context",
+	}}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			s := NewJSONSchemaLangMapper()
+			gotType, got, err := s.yangTypeToSchema(resolveTypeArgs{yangType: tt.in}, ygen.IROptions{})
+			if diff := errdiff.Substring(err, tt.wantErrSubstring); diff != "" {
+				t.Fatalf("yangTypeToSchema(%v): did not get expected error, %s", tt.in, diff)
+			}
+			if err != nil {
+				return
+			}
+			if diff := cmp.Diff(tt.want, got); diff != "" {
+				t.Errorf("yangTypeToSchema(%v): did not get expected schema, diff(-want, +got):\n%s", tt.in, diff)
+			}
+			if gotType.NativeType != tt.wantNativeType {
+				t.Errorf("yangTypeToSchema(%v): did not get expected native type, got: %q, want: %q", tt.in, gotType.NativeType, tt.wantNativeType)
+			}
+		})
+	}
+}
+
+func TestDefaultValue(t *testing.T) {
+	tests := []struct {
+		name    string
+		inType  *yang.YangType
+		inValue string
+		want    any
+	}{{
+		name:    "int32",
+		inType:  &yang.YangType{Kind: yang.Yint32},
+		inValue: "-42",
+		want:    int64(-42),
+	}, {
+		name:    "boolean",
+		inType:  &yang.YangType{Kind: yang.Ybool},
+		inValue: "true",
+		want:    true,
+	}, {
+		name:    "int64 encoded as string",
+		inType:  &yang.YangType{Kind: yang.Yint64},
+		inValue: "42",
+		want:    "42",
+	}, {
+		name: "union with string before integer",
+		inType: &yang.YangType{
+			Kind: yang.Yunion,
+			Type: []*yang.YangType{{Kind: yang.Ystring}, {Kind: yang.Yuint8}},
+		},
+		inValue: "42",
+		want:    "42",
+	}, {
+		name: "union with enumeration before integer",
+		inType: &yang.YangType{
+			Kind: yang.Yunion,
+			Type: []*yang.YangType{{Kind: yang.Yenum, Enum: yang.NewEnumType()}, {Kind: yang.Yuint8}},
+		},
+		inValue: "42",
+		want:    uint64(42),
+	}}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := defaultValue(tt.inType, tt.inValue); !cmp.Equal(got, tt.want) {
+				t.Errorf("defaultValue(%v, %q): did not get expected value, got: %v (%T), want: %v (%T)", tt.inType, tt.inValue, got, got, tt.want, tt.want)
+			}
+		})
+	}
+}
+
+func TestBitsPattern(t *testing.T) {
+	bits := yang.NewBitfield()
+	if err := bits.Set("B", 1); err != nil {
+		t.Fatalf("cannot set bit: %v", err)
+	}
+	if err := bits.Set("A", 0); err != nil {
+		t.Fatalf("cannot set bit: %v", err)
+	}
+
+	if got, want := bitsPattern(bits), "^(?:(?:A|B)(?: (?:A|B))*)?$"; got != want {
+		t.Errorf("bitsPattern(%v): did not get expected pattern, got: %q, want: %q", bits, got, want)
+	}
+}
@@ -52,6 +52,7 @@ var (
 	excludeState           = flag.Bool("exclude_state", false, "If set to true, state (config false) fields in the YANG schema are not included in the generated Protobuf messages.")
 	preferOperationalState = flag.Bool("prefer_operational_state", false, "If set to true, state (config false) fields in the YANG schema are preferred over intended config leaves in the generated messages with compressed schema paths. This flag is only valid for compress_paths=true and exclude_state=false.")
 	skipEnumDedup          = flag.Bool("skip_enum_deduplication", false, "If set to true, all leaves of type enumeration will have a unique enum output for them, rather than sharing a common type (default behaviour).")
+	generateOperations     = flag.Bool("generate_operations", false, "If set to true, messages are generated for the inputs and outputs of YANG rpc and action statements, and the bodies of notification statements.")
 	goPackageBase          = flag.String("go_package_base", "", "Base name for the Go packages that are to be generated - this value is included in the go_package option of the generated protobufs - and has generated packages' names appended to it.")
 )
 
@@ -115,6 +116,7 @@ func main() {
 				GenerateFakeRoot:      *generateFakeRoot,
 				FakeRootName:          *fakeRootName,
 				SkipEnumDeduplication: *skipEnumDedup,
+				GenerateOperations:    *generateOperations,
 			},
 		},
 		protogen.ProtoOpts{
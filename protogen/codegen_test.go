@@ -264,6 +264,31 @@ func TestGenerateProto3(t *testing.T) {
 			"openconfig.enums":           filepath.Join(TestRoot, "testdata", "proto", "nested-messages.enums.formatted-txt"),
 			"openconfig.nested_messages": filepath.Join(TestRoot, "testdata", "proto", "nested-messages.nested_messages.formatted-txt"),
 		},
+	}, {
+		name: "yang schema with rpcs, actions and notifications - uncompressed with fakeroot",
+		inFiles: []string{
+			filepath.Join(datapath, "operations.yang"),
+		},
+		inConfig: CodeGenerator{
+			IROptions: ygen.IROptions{
+				TransformationOptions: ygen.TransformationOpts{
+					GenerateFakeRoot:                     true,
+					UseDefiningModuleForTypedefEnumNames: true,
+					GenerateOperations:                   true,
+				},
+			},
+			ProtoOptions: ProtoOpts{
+				AnnotateEnumNames:   true,
+				AnnotateSchemaPaths: true,
+				NestedMessages:      true,
+			},
+		},
+		wantOutputFiles: map[string]string{
+			"openconfig":                                  filepath.Join(TestRoot, "testdata", "proto", "operations.openconfig.formatted-txt"),
+			"openconfig.operations":                       filepath.Join(TestRoot, "testdata", "proto", "operations.operations.formatted-txt"),
+			"openconfig.operations.ping":                  filepath.Join(TestRoot, "testdata", "proto", "operations.operations.ping.formatted-txt"),
+			"openconfig.operations.system.server.restart": filepath.Join(TestRoot, "testdata", "proto", "operations.operations.system.server.restart.formatted-txt"),
+		},
 	}, {
 		name: "yang schema with nested messages - compressed with fakeroot",
 		inFiles: []string{
@@ -196,7 +196,21 @@ func (s *ProtoLangMapper) PackageName(e *yang.Entry, compressBehaviour genutil.C
 			}
 		}
 
-		if e.Parent != nil && e.Parent.Parent != nil {
+		// The inputs and outputs of RPCs and actions, and notification
+		// bodies, are always top-level messages, since they are not the
+		// child of any other message.
+		var opRoot *yang.Entry
+		for n := e; n != nil; n = n.Parent {
+			if util.IsOperationContainer(n) {
+				opRoot = n
+				break
+			}
+		}
+
+		switch {
+		case opRoot != nil:
+			e = opRoot
+		case e.Parent != nil && e.Parent.Parent != nil:
 			var n *yang.Entry
 			for n = e.Parent; n.Parent.Parent != nil; n = n.Parent {
 			}
@@ -30,6 +30,7 @@ import (
 	"github.com/openconfig/ygot/internal/igenutil"
 	"github.com/openconfig/ygot/util"
 	"github.com/openconfig/ygot/ygen"
+	"github.com/openconfig/ygot/ygot"
 )
 
 // Constants defining the defaults for Protobuf package generation. These constants
@@ -334,7 +335,7 @@ type protoMsgConfig struct {
 //     the message.
 func writeProto3Msg(msg *ygen.ParsedDirectory, ir *ygen.IR, cfg *protoMsgConfig) (*generatedProto3Message, util.Errors) {
 	if cfg.nestedMessages {
-		if !outputNestedMessage(msg, cfg.compressPaths) {
+		if !outputNestedMessage(msg, cfg.compressPaths) && !isOperationMessage(msg, ir) {
 			return nil, nil
 		}
 		return writeProto3MsgNested(msg, ir, cfg)
@@ -353,6 +354,22 @@ func isChildOfModule(y *ygen.ParsedDirectory) bool {
 	return false
 }
 
+// isOperationMessage determines whether the Directory represents the input or
+// output of an RPC or action, or the body of a notification. Since these
+// messages are not the children of any other message, they are always output
+// as top-level messages.
+func isOperationMessage(msg *ygen.ParsedDirectory, ir *ygen.IR) bool {
+	if !msg.InOperation {
+		return false
+	}
+	for _, op := range ir.Operations {
+		if msg.Path == op.InputPath || msg.Path == op.OutputPath || (op.Type == ygot.NotificationOperation && msg.Path == op.Path) {
+			return true
+		}
+	}
+	return false
+}
+
 // outputNestedMessage determines whether the message represented by the supplied
 // Directory is a message that should be output when nested messages are being
 // created. The compressPaths argument specifies whether path compression is enabled.
@@ -543,6 +543,65 @@ func ProtoFromPaths(p proto.Message, vals map[*gpb.Path]interface{}, opt ...Unma
 	return protoFromPathsInternal(p, vals, valPrefix, protoPrefix, hasIgnoreExtraPaths(opt))
 }
 
+// TypedValueUnmapper maps ygot-generated protobuf messages to the values of
+// the leaves that they contain. It implements ytypes.ProtoUnmapper, and hence
+// can be supplied to ytypes.UnmarshalSetRequest and
+// ytypes.UnmarshalNotifications to unmarshal values that use the gNMI PROTO
+// encoding.
+type TypedValueUnmapper struct{}
+
+// IsUnmarshalOpt marks TypedValueUnmapper as an option to ytypes unmarshalling.
+func (*TypedValueUnmapper) IsUnmarshalOpt() {}
+
+// PathsFromProto returns the values of the leaves within m, as per the
+// PathsFromProto function.
+func (*TypedValueUnmapper) PathsFromProto(m proto.Message) (map[*gpb.Path]any, error) {
+	return PathsFromProto(m)
+}
+
+// TypedValueMapper maps the leaves of a ygot GoStruct to a ygot-generated
+// protobuf message. It implements ygot.ProtoMapper, and hence can be supplied
+// to ygot.EncodeTypedValue to encode a GoStruct using the gNMI PROTO encoding.
+type TypedValueMapper struct {
+	msg    proto.Message
+	prefix *gpb.Path
+	opts   []UnmapOpt
+}
+
+// NewTypedValueMapper returns a TypedValueMapper which maps GoStructs to
+// new instances of the type of msg. The prefix specifies the data tree path
+// of the GoStruct being encoded, and hence of msg - it must be set when msg
+// is not the root message of the schema. The supplied opts are used when
+// mapping the GoStruct's leaves using ProtoFromPaths.
+func NewTypedValueMapper(msg proto.Message, prefix *gpb.Path, opts ...UnmapOpt) *TypedValueMapper {
+	return &TypedValueMapper{msg: msg, prefix: prefix, opts: opts}
+}
+
+// IsEncodeTypedValueOpt marks TypedValueMapper as an option to ygot.EncodeTypedValue.
+func (*TypedValueMapper) IsEncodeTypedValueOpt() {}
+
+// ProtoFromLeaves returns a new protobuf message of the type supplied to
+// NewTypedValueMapper, populated with the values in leaves. The paths in
+// leaves are relative to the prefix of the TypedValueMapper.
+func (t *TypedValueMapper) ProtoFromLeaves(leaves map[*gpb.Path]any) (proto.Message, error) {
+	if t.msg == nil {
+		return nil, errors.New("nil protobuf supplied")
+	}
+	m := t.msg.ProtoReflect().New().Interface()
+
+	opts := append([]UnmapOpt{}, t.opts...)
+	if t.prefix != nil {
+		// The message is mapped based on the schema path of the prefix,
+		// since the keys of any list within it are not mapped.
+		sp := schemaPath(t.prefix)
+		opts = append(opts, ValuePathPrefix(sp), ProtobufMessagePrefix(sp))
+	}
+	if err := ProtoFromPaths(m, leaves, opts...); err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+
 // schemaPath converts the path p into a schema path by removing all of the keys within the path.
 func schemaPath(p *gpb.Path) *gpb.Path {
 	np := proto.Clone(p).(*gpb.Path)
@@ -1400,3 +1400,97 @@ func TestProtoFromPaths(t *testing.T) {
 		})
 	}
 }
+
+// exRoot, exSystem and exInterface are GoStructs corresponding to the
+// exschemapath Root, System and Interface messages.
+type exRoot struct {
+	System    *exSystem               `path:"system"`
+	Interface map[string]*exInterface `path:"interfaces/interface"`
+}
+
+func (*exRoot) IsYANGGoStruct() {}
+
+type exSystem struct {
+	Hostname *string `path:"config/hostname"`
+}
+
+func (*exSystem) IsYANGGoStruct() {}
+
+type exInterface struct {
+	Name        *string `path:"config/name|name"`
+	Description *string `path:"config/description"`
+}
+
+func (*exInterface) IsYANGGoStruct() {}
+
+func (i *exInterface) ΛListKeyMap() (map[string]any, error) {
+	return map[string]any{"name": *i.Name}, nil
+}
+
+func TestTypedValueMapper(t *testing.T) {
+	tests := []struct {
+		desc             string
+		inStruct         ygot.GoStruct
+		inMapper         *TypedValueMapper
+		wantProto        proto.Message
+		wantErrSubstring string
+	}{{
+		desc: "root message",
+		inStruct: &exRoot{
+			System: &exSystem{Hostname: ygot.String("box0")},
+			Interface: map[string]*exInterface{
+				"eth0": {Name: ygot.String("eth0"), Description: ygot.String("uplink")},
+			},
+		},
+		inMapper: NewTypedValueMapper(&epb.Root{}, nil),
+		wantProto: &epb.Root{
+			System: &epb.System{Hostname: &wpb.StringValue{Value: "box0"}},
+			Interface: []*epb.Root_InterfaceKey{{
+				Name: "eth0",
+				Interface: &epb.Interface{
+					Description: &wpb.StringValue{Value: "uplink"},
+				},
+			}},
+		},
+	}, {
+		desc:      "child message",
+		inStruct:  &exSystem{Hostname: ygot.String("box0")},
+		inMapper:  NewTypedValueMapper(&epb.System{}, mustPath("/system")),
+		wantProto: &epb.System{Hostname: &wpb.StringValue{Value: "box0"}},
+	}, {
+		desc:      "list member message, ignoring keys",
+		inStruct:  &exInterface{Name: ygot.String("eth0"), Description: ygot.String("uplink")},
+		inMapper:  NewTypedValueMapper(&epb.Interface{}, mustPath("/interfaces/interface[name=eth0]"), IgnoreExtraPaths()),
+		wantProto: &epb.Interface{Description: &wpb.StringValue{Value: "uplink"}},
+	}, {
+		desc:             "list member message with unmapped keys",
+		inStruct:         &exInterface{Name: ygot.String("eth0"), Description: ygot.String("uplink")},
+		inMapper:         NewTypedValueMapper(&epb.Interface{}, mustPath("/interfaces/interface[name=eth0]")),
+		wantErrSubstring: "did not map path",
+	}, {
+		desc:             "nil message",
+		inStruct:         &exSystem{Hostname: ygot.String("box0")},
+		inMapper:         NewTypedValueMapper(nil, nil),
+		wantErrSubstring: "nil protobuf supplied",
+	}}
+
+	for _, tt := range tests {
+		t.Run(tt.desc, func(t *testing.T) {
+			tv, err := ygot.EncodeTypedValue(tt.inStruct, gpb.Encoding_PROTO, tt.inMapper)
+			if diff := errdiff.Substring(err, tt.wantErrSubstring); diff != "" {
+				t.Fatalf("did not get expected error, %s", diff)
+			}
+			if err != nil {
+				return
+			}
+
+			got, err := tv.GetAnyVal().UnmarshalNew()
+			if err != nil {
+				t.Fatalf("cannot unmarshal Any, %v", err)
+			}
+			if diff := cmp.Diff(tt.wantProto, got, protocmp.Transform()); diff != "" {
+				t.Errorf("did not get expected protobuf, diff(-want, +got):\n%s", diff)
+			}
+		})
+	}
+}
@@ -0,0 +1,987 @@
+// Copyright 2023 Google Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package tsgen contains a library to generate TypeScript code from a set of
+// YANG modules. The generated code describes the RFC7951 JSON encoding of the
+// data tree using TypeScript interfaces, along with an optional set of path
+// builders which mirror the path structs that are generated by ypathgen.
+package tsgen
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"reflect"
+	"sort"
+	"strings"
+	"text/template"
+
+	"github.com/openconfig/goyang/pkg/yang"
+	"github.com/openconfig/ygot/genutil"
+	"github.com/openconfig/ygot/internal/igenutil"
+	"github.com/openconfig/ygot/util"
+	"github.com/openconfig/ygot/ygen"
+)
+
+const (
+	// defaultPathStructSuffix is the suffix that is appended to the names
+	// of generated path builder classes if none is specified.
+	defaultPathStructSuffix = "Path"
+	// rootPathFunctionName is the name of the generated function that
+	// returns the path builder of the fake root.
+	rootPathFunctionName = "root"
+)
+
+var (
+	// preludeIdentifiers are the top-level identifiers that are declared
+	// by the prelude of the generated code.
+	preludeIdentifiers = []string{"brand", "Brand"}
+	// pathPreludeIdentifiers are the top-level identifiers that are
+	// declared by the prelude of the path builders.
+	pathPreludeIdentifiers = []string{"PathElem", "PathStruct", "LeafPath", "keyValue", "pathElemString"}
+	// pathStructMembers are the names of the members of the PathStruct
+	// class, which cannot be used as the names of the methods of a path
+	// builder.
+	pathStructMembers = []string{"constructor", "parent", "pathElems", "elems", "toString"}
+)
+
+// CodeGenerator is a structure that is used to pass arguments as to
+// how the output TypeScript code should be generated.
+type CodeGenerator struct {
+	// IROptions stores the configuration parameters used for IR generation.
+	IROptions ygen.IROptions
+	// TypeScriptOptions stores a struct which contains TypeScript
+	// specific options for code generation post IR generation.
+	TypeScriptOptions TypeScriptOpts
+}
+
+// TypeScriptOpts stores TypeScript specific options for the code generation
+// library.
+type TypeScriptOpts struct {
+	// GeneratePathBuilders specifies whether path builder classes, which
+	// construct gNMI paths to the nodes of the data tree, are generated.
+	// Path builders require a fake root to be generated.
+	GeneratePathBuilders bool
+	// PathStructSuffix is the suffix that is appended to the names of
+	// the generated path builder classes. "Path" is used if it is empty.
+	PathStructSuffix string
+	// IncludeDescriptions specifies whether the descriptions of YANG
+	// nodes are output as documentation comments.
+	IncludeDescriptions bool
+}
+
+// New returns a new instance of the CodeGenerator
+// struct to the calling function.
+func New(opts ygen.IROptions, tsOpts TypeScriptOpts) *CodeGenerator {
+	return &CodeGenerator{
+		IROptions:         opts,
+		TypeScriptOptions: tsOpts,
+	}
+}
+
+// GeneratedCode stores a generated TypeScript file.
+type GeneratedCode struct {
+	// Code is the generated TypeScript code.
+	Code string
+	// RootInterface is the name of the interface that represents the
+	// fake root. It is empty if no fake root was generated.
+	RootInterface string
+}
+
+// Generate generates TypeScript code for the input set of YANG files. The
+// YANG schemas for which code is to be created are supplied as the yangFiles
+// argument, with included modules being searched for in includePaths.
+//
+// Each YANG container and list is represented by an interface which
+// describes the RFC7951 JSON object that encodes it. Each enumeration and
+// identity is represented by a union of string literals. Scalar types whose
+// values cannot be described by a TypeScript primitive, and typedefs which
+// restrict the values of their base type, are represented by branded types,
+// for which a validation function is generated.
+//
+// Schema compression is not supported, since RFC7951 JSON is encoded
+// according to the uncompressed schema.
+func (cg *CodeGenerator) Generate(yangFiles, includePaths []string) (*GeneratedCode, util.Errors) {
+	if cg.IROptions.TransformationOptions.CompressBehaviour.CompressEnabled() {
+		return nil, util.NewErrs(fmt.Errorf("schema compression is not supported for TypeScript generation, got compress behaviour %v", cg.IROptions.TransformationOptions.CompressBehaviour))
+	}
+	if cg.TypeScriptOptions.GeneratePathBuilders && !cg.IROptions.TransformationOptions.GenerateFakeRoot {
+		return nil, util.NewErrs(fmt.Errorf("path builders can only be generated with a fake root"))
+	}
+
+	opts := ygen.IROptions{
+		ParseOptions:                        cg.IROptions.ParseOptions,
+		TransformationOptions:               cg.IROptions.TransformationOptions,
+		AppendEnumSuffixForSimpleUnionEnums: cg.IROptions.AppendEnumSuffixForSimpleUnionEnums,
+	}
+
+	ir, err := ygen.GenerateIR(yangFiles, includePaths, NewTSLangMapper(), opts)
+	if err != nil {
+		return nil, util.NewErrs(err)
+	}
+
+	suffix := cg.TypeScriptOptions.PathStructSuffix
+	if suffix == "" {
+		suffix = defaultPathStructSuffix
+	}
+	f, errs := buildFile(ir, yangFiles, suffix, cg.TypeScriptOptions)
+	if errs != nil {
+		return nil, errs
+	}
+
+	var b bytes.Buffer
+	if err := tsFileTemplate.Execute(&b, f); err != nil {
+		return nil, util.NewErrs(err)
+	}
+	return &GeneratedCode{
+		Code:          b.String(),
+		RootInterface: f.RootInterface,
+	}, nil
+}
+
+// tsFile is the input to the template that generates a TypeScript file.
+type tsFile struct {
+	// YANGFiles are the YANG files from which the code was generated.
+	YANGFiles []string
+	// Brands are the branded types that are referenced by the code.
+	Brands []*tsBrand
+	// Enums are the string-literal union types of enumerated types.
+	Enums []*tsEnum
+	// Interfaces are the interfaces that represent directories.
+	Interfaces []*tsInterface
+	// PathBuilders specifies whether the path builders are generated.
+	PathBuilders bool
+	// PathClasses are the path builder classes of directories.
+	PathClasses []*tsPathClass
+	// RootInterface is the name of the interface of the fake root.
+	RootInterface string
+	// RootPathClass is the name of the path builder class of the fake
+	// root.
+	RootPathClass string
+	// RootPathFunction is the name of the function that returns the path
+	// builder of the fake root.
+	RootPathFunction string
+}
+
+// tsBrand is the input to the template that generates a branded type and
+// its validation function.
+type tsBrand struct {
+	// Name is the name of the branded type.
+	Name string
+	// YANGType is the name of the YANG type that the brand represents.
+	YANGType string
+	// Base is the TypeScript primitive type that the brand is based on.
+	Base string
+	// Validator is the name of the validation function of the brand.
+	Validator string
+	// Body is the lines of the body of the validation function.
+	Body []string
+}
+
+// tsEnum is the input to the template that generates the string-literal
+// union type of an enumerated type.
+type tsEnum struct {
+	// Name is the name of the union type.
+	Name string
+	// ValuesName is the name of the constant that lists the values.
+	ValuesName string
+	// Kind describes the kind of the enumerated type.
+	Kind string
+	// Values are the quoted values of the enumerated type.
+	Values []string
+}
+
+// tsInterface is the input to the template that generates the interface
+// of a directory.
+type tsInterface struct {
+	// Name is the name of the interface.
+	Name string
+	// Kind is the kind of the YANG node that the directory represents.
+	Kind string
+	// Path is the YANG path of the directory.
+	Path string
+	// Members are the members of the interface.
+	Members []*tsMember
+}
+
+// tsMember is a member of an interface.
+type tsMember struct {
+	// Doc is the lines of the documentation comment of the member.
+	Doc []string
+	// Name is the quoted name of the member.
+	Name string
+	// Readonly specifies whether the member is read-only.
+	Readonly bool
+	// Optional specifies whether the member is optional.
+	Optional bool
+	// Type is the TypeScript type of the member.
+	Type string
+}
+
+// tsPathClass is the input to the template that generates the path
+// builder class of a directory.
+type tsPathClass struct {
+	// Name is the name of the class.
+	Name string
+	// Kind is the kind of the YANG node that the directory represents.
+	Kind string
+	// Path is the YANG path of the directory.
+	Path string
+	// Methods are the methods of the class.
+	Methods []*tsMethod
+}
+
+// tsMethod is a method of a path builder class, which returns the path
+// builder of a child node.
+type tsMethod struct {
+	// Doc is the documentation comment of the method.
+	Doc string
+	// Name is the name of the method.
+	Name string
+	// Params are the parameters of the method.
+	Params string
+	// ReturnType is the type of the path builder that is returned.
+	ReturnType string
+	// Elems is the array literal of the path elements that are
+	// appended to the path of the parent.
+	Elems string
+}
+
+// buildFile returns the input to the template of a TypeScript file for the
+// IR. The YANG files from which the IR was generated are listed in the
+// header of the file.
+func buildFile(ir *ygen.IR, yangFiles []string, suffix string, opts TypeScriptOpts) (*tsFile, util.Errors) {
+	f := &tsFile{
+		YANGFiles:    yangFiles,
+		PathBuilders: opts.GeneratePathBuilders,
+	}
+	// identifiers maps the top-level identifiers of the file to a
+	// description of what they are declared by, such that duplicates can
+	// be reported.
+	identifiers := map[string]string{}
+	var errs util.Errors
+	declare := func(name, by string) {
+		if prev, ok := identifiers[name]; ok {
+			errs = util.AppendErr(errs, fmt.Errorf("duplicate TypeScript identifier %q for %s, already declared for %s", name, by, prev))
+			return
+		}
+		identifiers[name] = by
+	}
+	for _, n := range preludeIdentifiers {
+		declare(n, "the prelude")
+	}
+	if opts.GeneratePathBuilders {
+		for _, n := range pathPreludeIdentifiers {
+			declare(n, "the path builder prelude")
+		}
+		declare(rootPathFunctionName, "the path builder prelude")
+	}
+
+	brands, err := collectBrands(ir)
+	if err != nil {
+		return nil, util.NewErrs(err)
+	}
+	for _, b := range brands {
+		tb := brandTemplateData(b)
+		declare(tb.Name, "branded type "+b.YANGType)
+		declare(tb.Validator, "branded type "+b.YANGType)
+		f.Brands = append(f.Brands, tb)
+	}
+
+	var enumKeys []string
+	for k := range ir.Enums {
+		enumKeys = append(enumKeys, k)
+	}
+	sort.Strings(enumKeys)
+	for _, k := range enumKeys {
+		te := enumTemplateData(ir.Enums[k])
+		declare(te.Name, "enumerated type "+k)
+		declare(te.ValuesName, "enumerated type "+k)
+		f.Enums = append(f.Enums, te)
+	}
+
+	for _, p := range ir.OrderedDirectoryPaths() {
+		dir := ir.Directories[p]
+		ti, err := interfaceTemplateData(dir, ir, opts.IncludeDescriptions)
+		if err != nil {
+			errs = util.AppendErrs(errs, err)
+			continue
+		}
+		declare(ti.Name, "directory "+p)
+		f.Interfaces = append(f.Interfaces, ti)
+		if dir.IsFakeRoot {
+			f.RootInterface = dir.Name
+		}
+
+		if !opts.GeneratePathBuilders {
+			continue
+		}
+		tc, err := pathClassTemplateData(dir, ir, suffix)
+		if err != nil {
+			errs = util.AppendErrs(errs, err)
+			continue
+		}
+		declare(tc.Name, "path builder of directory "+p)
+		f.PathClasses = append(f.PathClasses, tc)
+		if dir.IsFakeRoot {
+			f.RootPathClass = tc.Name
+			f.RootPathFunction = rootPathFunctionName
+		}
+	}
+	if errs != nil {
+		return nil, errs
+	}
+	return f, nil
+}
+
+// collectBrands returns the definitions of the branded types that are
+// referenced by the fields of the directories of the IR, sorted by name. It
+// returns an error if two different brands have the same name.
+func collectBrands(ir *ygen.IR) ([]*brandDef, error) {
+	brands := map[string]*brandDef{}
+	for _, p := range ir.OrderedDirectoryPaths() {
+		dir := ir.Directories[p]
+		for _, fn := range dir.OrderedFieldNames() {
+			field := dir.Fields[fn]
+			js, ok := field.Flags[brandsFlagKey]
+			if !ok {
+				continue
+			}
+			var bs []*brandDef
+			if err := json.Unmarshal([]byte(js), &bs); err != nil {
+				return nil, fmt.Errorf("%s: invalid branded types for field %s: %v", p, field.YANGDetails.Path, err)
+			}
+			for _, b := range bs {
+				if prev, ok := brands[b.Name]; ok && !reflect.DeepEqual(prev, b) {
+					return nil, fmt.Errorf("%s: conflicting definitions of branded type %q for field %s", p, b.Name, field.YANGDetails.Path)
+				}
+				brands[b.Name] = b
+			}
+		}
+	}
+
+	var names []string
+	for n := range brands {
+		names = append(names, n)
+	}
+	sort.Strings(names)
+	var bs []*brandDef
+	for _, n := range names {
+		bs = append(bs, brands[n])
+	}
+	return bs, nil
+}
+
+// brandTemplateData returns the input to the template of the branded type
+// b. The validation function checks that a value is encoded as required by
+// RFC7951, and that it satisfies the range, length and pattern restrictions
+// of the brand. The length of a string is measured in characters, as required
+// by RFC7950, rather than UTF-16 code units, and the length of a binary value
+// is measured in decoded bytes.
+func brandTemplateData(b *brandDef) *tsBrand {
+	var body []string
+	switch b.Encoding {
+	case integerEncoding:
+		cond := "Number.isInteger(v)"
+		if rc := rangeCondition("v", b.Ranges, ""); rc != "" {
+			cond += " && " + rc
+		}
+		body = []string{fmt.Sprintf("return %s;", cond)}
+	case int64Encoding, decimal64Encoding:
+		// Values are compared as bigints, since a number cannot represent
+		// every 64-bit integer. A decimal64 value is scaled by its fraction
+		// digits, such that it is compared as the integer that it is stored
+		// as.
+		re, ranges, conv := `/^-?[0-9]+$/`, b.Ranges, []string{"const n = BigInt(v);"}
+		if b.Unsigned {
+			re = `/^[0-9]+$/`
+		}
+		if b.Encoding == decimal64Encoding {
+			re = fmt.Sprintf(`/^-?[0-9]+(\.[0-9]{1,%d})?$/`, b.FractionDigits)
+			if b.FractionDigits == 0 {
+				re = `/^-?[0-9]+$/`
+			}
+			ranges = nil
+			for _, r := range b.Ranges {
+				ranges = append(ranges, [2]string{scaleDecimal(r[0], b.FractionDigits), scaleDecimal(r[1], b.FractionDigits)})
+			}
+			conv = []string{
+				`const [i, f = ""] = v.split(".");`,
+				fmt.Sprintf(`const n = BigInt(i + f.padEnd(%d, "0"));`, b.FractionDigits),
+			}
+		}
+		body = []string{
+			fmt.Sprintf("if (!%s.test(v)) {", re),
+			"  return false;",
+			"}",
+		}
+		if rc := rangeCondition("n", ranges, "n"); rc != "" {
+			body = append(append(body, conv...), fmt.Sprintf("return %s;", rc))
+		} else {
+			body = append(body, "return true;")
+		}
+	case stringEncoding:
+		var conds []string
+		if rc := rangeCondition("n", b.Ranges, ""); rc != "" {
+			body = append(body, "const n = [...v].length;")
+			conds = append(conds, rc)
+		}
+		for _, p := range b.Patterns {
+			conds = append(conds, fmt.Sprintf("%s.test(v)", regExp(p)))
+		}
+		if len(conds) == 0 {
+			conds = []string{"true"}
+		}
+		body = append(body, fmt.Sprintf("return %s;", strings.Join(conds, " && ")))
+	case binaryEncoding:
+		body = []string{
+			"if (v.length % 4 !== 0 || !/^[A-Za-z0-9+/]*={0,2}$/.test(v)) {",
+			"  return false;",
+			"}",
+		}
+		if rc := rangeCondition("n", b.Ranges, ""); rc != "" {
+			body = append(body, `const n = (v.length / 4) * 3 - (v.endsWith("==") ? 2 : v.endsWith("=") ? 1 : 0);`, fmt.Sprintf("return %s;", rc))
+		} else {
+			body = append(body, "return true;")
+		}
+	}
+
+	return &tsBrand{
+		Name:      b.Name,
+		YANGType:  b.YANGType,
+		Base:      b.base(),
+		Validator: "is" + b.Name,
+		Body:      body,
+	}
+}
+
+// scaleDecimal returns the decimal number d multiplied by 10 to the power of
+// fd, which is an integer if d has at most fd fraction digits. Any further
+// fraction digits are truncated. An empty string is returned unchanged, such
+// that an unrestricted bound remains unrestricted.
+func scaleDecimal(d string, fd int) string {
+	if d == "" {
+		return d
+	}
+	sign, d := "", strings.TrimPrefix(d, "+")
+	if strings.HasPrefix(d, "-") {
+		sign, d = "-", d[1:]
+	}
+	i, f, _ := strings.Cut(d, ".")
+	if len(f) < fd {
+		f += strings.Repeat("0", fd-len(f))
+	}
+	n := strings.TrimLeft(i+f[:fd], "0")
+	if n == "" {
+		return "0"
+	}
+	return sign + n
+}
+
+// rangeCondition returns a TypeScript expression which checks that the
+// variable v is within one of the ranges supplied. The suffix is appended to
+// each bound, such that it can be written as a bigint literal. It returns an
+// empty string if there are no ranges.
+func rangeCondition(v string, ranges [][2]string, suffix string) string {
+	var conds []string
+	for _, r := range ranges {
+		var c string
+		switch {
+		case r[0] == r[1]:
+			c = fmt.Sprintf("%s === %s%s", v, r[0], suffix)
+		case r[1] == "":
+			c = fmt.Sprintf("%s >= %s%s", v, r[0], suffix)
+		default:
+			c = fmt.Sprintf("%s >= %s%s && %s <= %s%s", v, r[0], suffix, v, r[1], suffix)
+		}
+		conds = append(conds, c)
+	}
+	switch len(conds) {
+	case 0:
+		return ""
+	case 1:
+		return fmt.Sprintf("(%s)", conds[0])
+	default:
+		return fmt.Sprintf("((%s))", strings.Join(conds, ") || ("))
+	}
+}
+
+// regExp returns a TypeScript expression which constructs the regular
+// expression p. The unicode flag is set only where p uses a unicode property
+// escape, since it disallows escapes that are valid in XSD regular
+// expressions.
+func regExp(p string) string {
+	flags := ""
+	if strings.Contains(p, `\p{`) || strings.Contains(p, `\P{`) {
+		flags = `, "u"`
+	}
+	return fmt.Sprintf("new RegExp(%s%s)", quote(p), flags)
+}
+
+// enumTemplateData returns the input to the template of the string-literal
+// union type of the enumerated type et. The value of an identity is
+// qualified with the name of the module in which it is defined.
+func enumTemplateData(et *ygen.EnumeratedYANGType) *tsEnum {
+	te := &tsEnum{
+		Name:       enumTypePrefix + et.Name,
+		ValuesName: enumTypePrefix + et.Name + "_VALUES",
+		Kind:       "enumeration",
+	}
+	if et.Kind == ygen.IdentityType {
+		te.Kind = fmt.Sprintf("identities derived from %s", et.IdentityBaseName)
+	}
+	for _, d := range et.ValToYANGDetails {
+		v := d.Name
+		if et.Kind == ygen.IdentityType {
+			v = fmt.Sprintf("%s:%s", d.DefiningModule, d.Name)
+		}
+		te.Values = append(te.Values, quote(v))
+	}
+	return te
+}
+
+// interfaceTemplateData returns the input to the template of the interface
+// of the directory dir. Each field of the directory is mapped to a member,
+// named according to RFC7951. The keys of a list are required members, and
+// all other members are optional. Fields that are not configuration are
+// read-only.
+func interfaceTemplateData(dir *ygen.ParsedDirectory, ir *ygen.IR, includeDescriptions bool) (*tsInterface, util.Errors) {
+	ti := &tsInterface{
+		Name: dir.Name,
+		Kind: directoryKind(dir),
+		Path: dir.Path,
+	}
+	keys := map[string]bool{}
+	for _, k := range dir.ListKeyYANGNames {
+		keys[k] = true
+	}
+
+	var errs util.Errors
+	for _, fn := range dir.OrderedFieldNames() {
+		field := dir.Fields[fn]
+		t, err := fieldType(dir, field, ir)
+		if err != nil {
+			errs = util.AppendErr(errs, err)
+			continue
+		}
+		m := &tsMember{
+			Name:     quote(rfc7951Name(dir, field)),
+			Readonly: field.YANGDetails.ConfigFalse,
+			Optional: !keys[fn],
+			Type:     t,
+		}
+		if includeDescriptions {
+			m.Doc = docLines(field.YANGDetails.Description)
+		}
+		ti.Members = append(ti.Members, m)
+	}
+	if errs != nil {
+		return nil, errs
+	}
+	return ti, nil
+}
+
+// fieldType returns the TypeScript type of the member of the interface of
+// dir that represents field.
+func fieldType(dir *ygen.ParsedDirectory, field *ygen.NodeDetails, ir *ygen.IR) (string, error) {
+	switch field.Type {
+	case ygen.ContainerNode, ygen.ListNode:
+		child, ok := ir.Directories[field.YANGDetails.Path]
+		if !ok {
+			return "", fmt.Errorf("%s: no directory for field %s", dir.Path, field.YANGDetails.Path)
+		}
+		if field.Type == ygen.ListNode {
+			return child.Name + "[]", nil
+		}
+		return child.Name, nil
+	case ygen.LeafNode, ygen.LeafListNode:
+		t, err := leafType(dir, field, ir)
+		if err != nil {
+			return "", err
+		}
+		if field.Type == ygen.LeafListNode {
+			return arrayOf(t), nil
+		}
+		return t, nil
+	case ygen.AnyDataNode:
+		return "unknown", nil
+	default:
+		return "", fmt.Errorf("%s: unsupported type %v for field %s", dir.Path, field.Type, field.YANGDetails.Path)
+	}
+}
+
+// leafType returns the TypeScript type of a value of the leaf or leaf-list
+// field of dir, checking that the enumerated types that it references are
+// defined within the IR.
+func leafType(dir *ygen.ParsedDirectory, field *ygen.NodeDetails, ir *ygen.IR) (string, error) {
+	if field.LangType == nil {
+		return "", fmt.Errorf("%s: no type for field %s", dir.Path, field.YANGDetails.Path)
+	}
+	return mappedType(field.LangType, ir)
+}
+
+// mappedType returns the TypeScript type of the mapped type mtype, checking
+// that the enumerated types that it references are defined within the IR.
+func mappedType(mtype *ygen.MappedType, ir *ygen.IR) (string, error) {
+	keys := []string{mtype.EnumeratedYANGTypeKey}
+	for _, st := range mtype.UnionTypes {
+		keys = append(keys, st.EnumeratedYANGTypeKey)
+	}
+	for _, k := range keys {
+		if _, ok := ir.Enums[k]; k != "" && !ok {
+			return "", fmt.Errorf("no enumerated type %s for type %s", k, mtype.NativeType)
+		}
+	}
+	return mtype.NativeType, nil
+}
+
+// arrayOf returns the type of an array whose elements are of type t.
+func arrayOf(t string) string {
+	if strings.Contains(t, "|") {
+		return fmt.Sprintf("(%s)[]", t)
+	}
+	return t + "[]"
+}
+
+// pathClassTemplateData returns the input to the template of the path builder
+// class of the directory dir, whose name has the suffix supplied. The class
+// has a method for each field of the directory, named in lower camel case
+// and made unique within the class, which returns the path builder of the
+// field. A list with keys has a further method with the suffix "Any", which
+// returns the path of all of the entries of the list.
+func pathClassTemplateData(dir *ygen.ParsedDirectory, ir *ygen.IR, suffix string) (*tsPathClass, util.Errors) {
+	tc := &tsPathClass{
+		Name: dir.Name + suffix,
+		Kind: directoryKind(dir),
+		Path: dir.Path,
+	}
+	methodNames := map[string]bool{}
+	for _, n := range pathStructMembers {
+		methodNames[n] = true
+	}
+
+	var errs util.Errors
+	for _, fn := range dir.OrderedFieldNames() {
+		field := dir.Fields[fn]
+		if len(field.MappedPaths) == 0 || len(field.MappedPaths[0]) == 0 {
+			errs = util.AppendErr(errs, fmt.Errorf("%s: no path for field %s", dir.Path, field.YANGDetails.Path))
+			continue
+		}
+		elems := field.MappedPaths[0]
+		name := genutil.MakeNameUnique(lowerCamelCase(field.Name), methodNames)
+
+		switch field.Type {
+		case ygen.ContainerNode:
+			child, ok := ir.Directories[field.YANGDetails.Path]
+			if !ok {
+				errs = util.AppendErr(errs, fmt.Errorf("%s: no directory for field %s", dir.Path, field.YANGDetails.Path))
+				continue
+			}
+			tc.Methods = append(tc.Methods, &tsMethod{
+				Doc:        fmt.Sprintf("%s returns the path of the container %s.", name, field.YANGDetails.Path),
+				Name:       name,
+				ReturnType: child.Name + suffix,
+				Elems:      elemsLiteral(elems, ""),
+			})
+		case ygen.ListNode:
+			child, ok := ir.Directories[field.YANGDetails.Path]
+			if !ok {
+				errs = util.AppendErr(errs, fmt.Errorf("%s: no directory for field %s", dir.Path, field.YANGDetails.Path))
+				continue
+			}
+			if len(child.ListKeyYANGNames) == 0 {
+				tc.Methods = append(tc.Methods, &tsMethod{
+					Doc:        fmt.Sprintf("%s returns the path of all of the entries of the keyless list %s.", name, field.YANGDetails.Path),
+					Name:       name,
+					ReturnType: child.Name + suffix,
+					Elems:      elemsLiteral(elems, ""),
+				})
+				continue
+			}
+			var params, keys []string
+			for _, k := range child.ListKeyYANGNames {
+				lk, ok := child.ListKeys[k]
+				if !ok || lk.LangType == nil {
+					errs = util.AppendErr(errs, fmt.Errorf("%s: no type for key %s of list %s", dir.Path, k, field.YANGDetails.Path))
+					continue
+				}
+				t, err := mappedType(lk.LangType, ir)
+				if err != nil {
+					errs = util.AppendErr(errs, fmt.Errorf("%s: invalid type for key %s of list %s: %v", dir.Path, k, field.YANGDetails.Path, err))
+					continue
+				}
+				params = append(params, fmt.Sprintf("%s?: %s", quote(k), t))
+				keys = append(keys, fmt.Sprintf("%s: keyValue(keys[%s])", quote(k), quote(k)))
+			}
+			tc.Methods = append(tc.Methods, &tsMethod{
+				Doc:        fmt.Sprintf("%s returns the path of the entries of the list %s with the keys supplied. A key that is not supplied matches any value.", name, field.YANGDetails.Path),
+				Name:       name,
+				Params:     fmt.Sprintf("keys: { %s }", strings.Join(params, "; ")),
+				ReturnType: child.Name + suffix,
+				Elems:      elemsLiteral(elems, fmt.Sprintf("{ %s }", strings.Join(keys, ", "))),
+			})
+			anyName := genutil.MakeNameUnique(name+"Any", methodNames)
+			var anyKeys []string
+			for _, k := range child.ListKeyYANGNames {
+				anyKeys = append(anyKeys, fmt.Sprintf(`%s: "*"`, quote(k)))
+			}
+			tc.Methods = append(tc.Methods, &tsMethod{
+				Doc:        fmt.Sprintf("%s returns the path of all of the entries of the list %s.", anyName, field.YANGDetails.Path),
+				Name:       anyName,
+				ReturnType: child.Name + suffix,
+				Elems:      elemsLiteral(elems, fmt.Sprintf("{ %s }", strings.Join(anyKeys, ", "))),
+			})
+		case ygen.LeafNode, ygen.LeafListNode, ygen.AnyDataNode:
+			t, err := fieldType(dir, field, ir)
+			if err != nil {
+				errs = util.AppendErr(errs, err)
+				continue
+			}
+			tc.Methods = append(tc.Methods, &tsMethod{
+				Doc:        fmt.Sprintf("%s returns the path of the %s %s.", name, nodeKind(field.Type), field.YANGDetails.Path),
+				Name:       name,
+				ReturnType: fmt.Sprintf("LeafPath<%s>", t),
+				Elems:      elemsLiteral(elems, ""),
+			})
+		default:
+			errs = util.AppendErr(errs, fmt.Errorf("%s: unsupported type %v for field %s", dir.Path, field.Type, field.YANGDetails.Path))
+		}
+	}
+	if errs != nil {
+		return nil, errs
+	}
+	return tc, nil
+}
+
+// elemsLiteral returns an array literal of the path elements with the names
+// supplied. The key object literal is added to the last element if it is not
+// empty.
+func elemsLiteral(names []string, key string) string {
+	var elems []string
+	for i, n := range names {
+		if i == len(names)-1 && key != "" {
+			elems = append(elems, fmt.Sprintf("{ name: %s, key: %s }", quote(n), key))
+			continue
+		}
+		elems = append(elems, fmt.Sprintf("{ name: %s }", quote(n)))
+	}
+	return fmt.Sprintf("[%s]", strings.Join(elems, ", "))
+}
+
+// rfc7951Name returns the name of the member of the RFC7951 JSON object that
+// represents dir which contains the value of field. The name is qualified with
+// the name of the module of field if dir is the fake root, or if dir and field
+// are in different namespaces.
+func rfc7951Name(dir *ygen.ParsedDirectory, field *ygen.NodeDetails) string {
+	if dir.IsFakeRoot || field.YANGDetails.BelongingModule != dir.BelongingModule {
+		return fmt.Sprintf("%s:%s", field.YANGDetails.BelongingModule, field.Name)
+	}
+	return field.Name
+}
+
+// directoryKind returns a description of the kind of YANG node that the
+// directory dir represents.
+func directoryKind(dir *ygen.ParsedDirectory) string {
+	switch {
+	case dir.IsFakeRoot:
+		return "root"
+	case dir.Type == ygen.Container:
+		return "container"
+	default:
+		return "list"
+	}
+}
+
+// nodeKind returns the YANG keyword of a node of type t.
+func nodeKind(t ygen.NodeType) string {
+	switch t {
+	case ygen.LeafNode:
+		return "leaf"
+	case ygen.LeafListNode:
+		return "leaf-list"
+	case ygen.AnyDataNode:
+		return "anydata"
+	default:
+		return t.String()
+	}
+}
+
+// lowerCamelCase returns the YANG identifier name in lower camel case.
+func lowerCamelCase(name string) string {
+	n := yang.CamelCase(name)
+	if n == "" {
+		return n
+	}
+	return strings.ToLower(n[:1]) + n[1:]
+}
+
+// docLines returns the lines of a documentation comment containing the
+// description s, with any sequence which would end the comment escaped.
+func docLines(s string) []string {
+	s = strings.TrimSpace(strings.ReplaceAll(s, "*/", `*\/`))
+	if s == "" {
+		return nil
+	}
+	var lines []string
+	for _, l := range strings.Split(s, "\n") {
+		lines = append(lines, strings.TrimRight(l, " \t"))
+	}
+	return lines
+}
+
+// quote returns s as a TypeScript string literal.
+func quote(s string) string {
+	var b bytes.Buffer
+	enc := json.NewEncoder(&b)
+	enc.SetEscapeHTML(false)
+	// Encoding a string can never fail.
+	enc.Encode(s)
+	return strings.TrimSuffix(b.String(), "\n")
+}
+
+// mustMakeTemplate generates a template.Template for a particular named source
+// template; with a common set of helper functions.
+func mustMakeTemplate(name, src string) *template.Template {
+	return template.Must(template.New(name).Funcs(igenutil.TemplateHelperFunctions).Parse(src))
+}
+
+var (
+	// tsFileTemplate is the template for a generated TypeScript file.
+	tsFileTemplate = mustMakeTemplate("file", `
+{{- /**/ -}}
+/**
+ * This file was generated by ygot using the following YANG input files:
+{{- range .YANGFiles }}
+ *   - {{ . }}
+{{- end }}
+ *
+ * It describes the RFC7951 JSON encoding of the data tree. DO NOT EDIT.
+ */
+
+declare const brand: unique symbol;
+
+/**
+ * Brand is a value of type T which has been checked to be a valid value of
+ * the YANG type represented by B.
+ */
+export type Brand<T, B extends string> = T & { readonly [brand]: B };
+{{- range .Brands }}
+
+/** {{ .Name }} is a value of the YANG type {{ .YANGType }}. */
+export type {{ .Name }} = Brand<{{ .Base }}, "{{ .Name }}">;
+
+/** {{ .Validator }} returns true if v is a valid value of the YANG type {{ .YANGType }}. */
+export function {{ .Validator }}(v: {{ .Base }}): v is {{ .Name }} {
+{{- range .Body }}
+  {{ . }}
+{{- end }}
+}
+{{- end }}
+{{- range .Enums }}
+
+/** {{ .Name }} is the set of values of the YANG {{ .Kind }}. */
+export type {{ .Name }} = {{ if .Values }}{{ range $i, $v := .Values }}{{ if $i }} | {{ end }}{{ $v }}{{ end }}{{ else }}never{{ end }};
+
+/** {{ .ValuesName }} lists the values of {{ .Name }}. */
+export const {{ .ValuesName }}: readonly {{ .Name }}[] = [{{ range $i, $v := .Values }}{{ if $i }}, {{ end }}{{ $v }}{{ end }}];
+{{- end }}
+{{- range .Interfaces }}
+
+/** {{ .Name }} represents the YANG {{ .Kind }} {{ .Path }}. */
+export interface {{ .Name }} {
+{{- range .Members }}
+{{- if .Doc }}
+  /**
+{{- range .Doc }}
+   *{{ if . }} {{ . }}{{ end }}
+{{- end }}
+   */
+{{- end }}
+  {{ if .Readonly }}readonly {{ end }}{{ .Name }}{{ if .Optional }}?{{ end }}: {{ .Type }};
+{{- end }}
+}
+{{- end }}
+{{- if .PathBuilders }}
+
+/** PathElem is an element of a gNMI path. */
+export interface PathElem {
+  name: string;
+  key?: Record<string, string>;
+}
+
+/**
+ * PathStruct is the base class of the path builders, each of which
+ * represents the path of a node of the data tree.
+ */
+export class PathStruct {
+  constructor(
+    readonly parent?: PathStruct,
+    readonly pathElems: readonly PathElem[] = [],
+  ) {}
+
+  /** elems returns the elements of the path from the root to this node. */
+  elems(): PathElem[] {
+    const elems = this.parent ? this.parent.elems() : [];
+    return elems.concat(this.pathElems);
+  }
+
+  /** toString returns the path in the gNMI path string format. */
+  toString(): string {
+    return "/" + this.elems().map(pathElemString).join("/");
+  }
+}
+
+/**
+ * LeafPath is the path of a leaf, leaf-list or anydata node whose
+ * value is of type T.
+ */
+export class LeafPath<T> extends PathStruct {
+  declare readonly valueType?: T;
+}
+
+/** keyValue returns the value of a list key within a path element. */
+function keyValue(v: unknown): string {
+  return v === undefined ? "*" : String(v);
+}
+
+/** pathElemString returns the path element e in the gNMI path string format. */
+function pathElemString(e: PathElem): string {
+  let s = e.name.replace(/[\\/]/g, "\\$&");
+  for (const [k, v] of Object.entries(e.key ?? {})) {
+    s += "[" + k + "=" + v.replace(/[\\\]]/g, "\\$&") + "]";
+  }
+  return s;
+}
+{{- range .PathClasses }}
+
+/** {{ .Name }} represents the path of the YANG {{ .Kind }} {{ .Path }}. */
+export class {{ .Name }} extends PathStruct {
+{{- range $i, $m := .Methods }}
+{{- if $i }}
+{{ end }}
+  /** {{ $m.Doc }} */
+  {{ $m.Name }}({{ $m.Params }}): {{ $m.ReturnType }} {
+    return new {{ $m.ReturnType }}(this, {{ $m.Elems }});
+  }
+{{- end }}
+}
+{{- end }}
+
+/** {{ .RootPathFunction }} returns the path builder of the root of the data tree. */
+export function {{ .RootPathFunction }}(): {{ .RootPathClass }} {
+  return new {{ .RootPathClass }}();
+}
+{{- end }}
+`)
+)
@@ -0,0 +1,269 @@
+// Copyright 2023 Google Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package tsgen
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/openconfig/gnmi/errdiff"
+	"github.com/openconfig/ygot/genutil"
+	"github.com/openconfig/ygot/testutil"
+	"github.com/openconfig/ygot/ygen"
+)
+
+const (
+	// datapath is the path to common YANG test modules.
+	datapath = "../testdata/modules"
+	// deflakeRuns specifies the number of runs of code generation that
+	// should be performed to check for flakes.
+	deflakeRuns int = 10
+)
+
+func TestGenerate(t *testing.T) {
+	tests := []struct {
+		name     string
+		inFiles  []string
+		inConfig CodeGenerator
+		// wantFile is the path to the file containing the code that is
+		// expected to be generated.
+		wantFile          string
+		wantRootInterface string
+		wantErrSubstring  string
+	}{{
+		name: "fake root with path builders",
+		inFiles: []string{
+			filepath.Join(datapath, "jsonschema.yang"),
+			filepath.Join(datapath, "jsonschema-augment.yang"),
+		},
+		inConfig: CodeGenerator{
+			IROptions: ygen.IROptions{
+				TransformationOptions: ygen.TransformationOpts{
+					GenerateFakeRoot: true,
+				},
+			},
+			TypeScriptOptions: TypeScriptOpts{
+				GeneratePathBuilders: true,
+				IncludeDescriptions:  true,
+			},
+		},
+		wantFile:          filepath.Join("testdata", "jsonschema.pathbuilders.formatted-txt"),
+		wantRootInterface: "Device",
+	}, {
+		name: "interfaces without fake root",
+		inFiles: []string{
+			filepath.Join(datapath, "jsonschema.yang"),
+			filepath.Join(datapath, "jsonschema-augment.yang"),
+		},
+		wantFile: filepath.Join("testdata", "jsonschema.formatted-txt"),
+	}, {
+		name: "path builders with custom suffix",
+		inFiles: []string{
+			filepath.Join(datapath, "openconfig-config-false.yang"),
+		},
+		inConfig: CodeGenerator{
+			IROptions: ygen.IROptions{
+				TransformationOptions: ygen.TransformationOpts{
+					GenerateFakeRoot: true,
+				},
+			},
+			TypeScriptOptions: TypeScriptOpts{
+				GeneratePathBuilders: true,
+				PathStructSuffix:     "_Path",
+			},
+		},
+		wantFile:          filepath.Join("testdata", "openconfig-config-false.pathbuilders.formatted-txt"),
+		wantRootInterface: "Device",
+	}, {
+		name: "compressed schema",
+		inFiles: []string{
+			filepath.Join(datapath, "jsonschema.yang"),
+		},
+		inConfig: CodeGenerator{
+			IROptions: ygen.IROptions{
+				TransformationOptions: ygen.TransformationOpts{
+					CompressBehaviour: genutil.PreferIntendedConfig,
+				},
+			},
+		},
+		wantErrSubstring: "schema compression is not supported",
+	}, {
+		name: "path builders without fake root",
+		inFiles: []string{
+			filepath.Join(datapath, "jsonschema.yang"),
+		},
+		inConfig: CodeGenerator{
+			TypeScriptOptions: TypeScriptOpts{
+				GeneratePathBuilders: true,
+			},
+		},
+		wantErrSubstring: "path builders can only be generated with a fake root",
+	}}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cg := New(tt.inConfig.IROptions, tt.inConfig.TypeScriptOptions)
+
+			got, errs := cg.Generate(tt.inFiles, nil)
+			var err error
+			if errs != nil {
+				err = errs
+			}
+			if diff := errdiff.Substring(err, tt.wantErrSubstring); diff != "" {
+				t.Fatalf("Generate(%v): did not get expected error, %s", tt.inFiles, diff)
+			}
+			if err != nil {
+				return
+			}
+
+			if got.RootInterface != tt.wantRootInterface {
+				t.Errorf("Generate(%v): did not get expected root interface, got: %q, want: %q", tt.inFiles, got.RootInterface, tt.wantRootInterface)
+			}
+
+			want, err := os.ReadFile(tt.wantFile)
+			if err != nil {
+				t.Fatalf("cannot read want file %s: %v", tt.wantFile, err)
+			}
+
+			if got.Code != string(want) {
+				diff, _ := testutil.GenerateUnifiedDiff(string(want), got.Code)
+				t.Errorf("Generate(%v): did not get expected code (file: %s), diff(-want, +got):\n%s", tt.inFiles, tt.wantFile, diff)
+			}
+
+			for i := 0; i < deflakeRuns; i++ {
+				again, errs := New(tt.inConfig.IROptions, tt.inConfig.TypeScriptOptions).Generate(tt.inFiles, nil)
+				if errs != nil {
+					t.Fatalf("Generate(%v): got unexpected errors on run %d: %v", tt.inFiles, i, errs)
+				}
+				if again.Code != got.Code {
+					t.Fatalf("Generate(%v): flaky output on run %d", tt.inFiles, i)
+				}
+			}
+		})
+	}
+}
+
+func TestBrandTemplateData(t *testing.T) {
+	tests := []struct {
+		name string
+		in   *brandDef
+		want []string
+	}{{
+		name: "integer with multiple ranges",
+		in:   &brandDef{Name: "R", Encoding: integerEncoding, Ranges: [][2]string{{"-10", "-1"}, {"1", "10"}}},
+		want: []string{"return Number.isInteger(v) && ((v >= -10 && v <= -1) || (v >= 1 && v <= 10));"},
+	}, {
+		name: "int64 with single value",
+		in:   &brandDef{Name: "R", Encoding: int64Encoding, Ranges: [][2]string{{"5", "5"}}},
+		want: []string{
+			"if (!/^-?[0-9]+$/.test(v)) {",
+			"  return false;",
+			"}",
+			"const n = BigInt(v);",
+			"return (n === 5n);",
+		},
+	}, {
+		name: "decimal64 without range",
+		in:   &brandDef{Name: "D", Encoding: decimal64Encoding, FractionDigits: 3},
+		want: []string{
+			`if (!/^-?[0-9]+(\.[0-9]{1,3})?$/.test(v)) {`,
+			"  return false;",
+			"}",
+			"return true;",
+		},
+	}, {
+		name: "decimal64 with ranges",
+		in:   &brandDef{Name: "D", Encoding: decimal64Encoding, FractionDigits: 2, Ranges: [][2]string{{"-92233720368547758.08", "-0.5"}, {"0.05", "1"}}},
+		want: []string{
+			`if (!/^-?[0-9]+(\.[0-9]{1,2})?$/.test(v)) {`,
+			"  return false;",
+			"}",
+			`const [i, f = ""] = v.split(".");`,
+			`const n = BigInt(i + f.padEnd(2, "0"));`,
+			"return ((n >= -9223372036854775808n && n <= -50n) || (n >= 5n && n <= 100n));",
+		},
+	}, {
+		name: "uint64 with range",
+		in:   &brandDef{Name: "U", Encoding: int64Encoding, Unsigned: true, Ranges: [][2]string{{"0", "18446744073709551615"}}},
+		want: []string{
+			"if (!/^[0-9]+$/.test(v)) {",
+			"  return false;",
+			"}",
+			"const n = BigInt(v);",
+			"return (n >= 0n && n <= 18446744073709551615n);",
+		},
+	}, {
+		name: "string with unbounded length and unicode pattern",
+		in:   &brandDef{Name: "S", Encoding: stringEncoding, Ranges: [][2]string{{"2", ""}}, Patterns: []string{`^(?:\p{L}+)$`}},
+		want: []string{
+			"const n = [...v].length;",
+			`return (n >= 2) && new RegExp("^(?:\\p{L}+)$", "u").test(v);`,
+		},
+	}}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := brandTemplateData(tt.in)
+			if diff := cmp.Diff(tt.want, got.Body); diff != "" {
+				t.Errorf("brandTemplateData(%v): did not get expected body, diff(-want, +got):\n%s", tt.in, diff)
+			}
+			if want := "is" + tt.in.Name; got.Validator != want {
+				t.Errorf("brandTemplateData(%v): did not get expected validator, got: %q, want: %q", tt.in, got.Validator, want)
+			}
+		})
+	}
+}
+
+func TestScaleDecimal(t *testing.T) {
+	tests := []struct {
+		in   string
+		inFD int
+		want string
+	}{
+		{in: "", inFD: 2, want: ""},
+		{in: "1", inFD: 2, want: "100"},
+		{in: "-1.5", inFD: 2, want: "-150"},
+		{in: "0.05", inFD: 2, want: "5"},
+		{in: "-0.0", inFD: 1, want: "0"},
+		{in: "92233720368547758.07", inFD: 2, want: "9223372036854775807"},
+		{in: "1.25", inFD: 1, want: "12"},
+	}
+
+	for _, tt := range tests {
+		if got := scaleDecimal(tt.in, tt.inFD); got != tt.want {
+			t.Errorf("scaleDecimal(%q, %d): got %q, want %q", tt.in, tt.inFD, got, tt.want)
+		}
+	}
+}
+
+func TestLowerCamelCase(t *testing.T) {
+	tests := []struct {
+		in   string
+		want string
+	}{
+		{in: "multi-pattern", want: "multiPattern"},
+		{in: "i8", want: "i8"},
+		{in: "ABC", want: "aBC"},
+		{in: "", want: ""},
+	}
+
+	for _, tt := range tests {
+		if got := lowerCamelCase(tt.in); got != tt.want {
+			t.Errorf("lowerCamelCase(%q): did not get expected name, got: %q, want: %q", tt.in, got, tt.want)
+		}
+	}
+}
@@ -0,0 +1,600 @@
+// Copyright 2023 Google Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package tsgen
+
+import (
+	"encoding/json"
+	"fmt"
+	"math"
+	"reflect"
+	"strings"
+
+	log "github.com/golang/glog"
+	"github.com/openconfig/goyang/pkg/yang"
+	"github.com/openconfig/ygot/genutil"
+	"github.com/openconfig/ygot/internal/igenutil"
+	"github.com/openconfig/ygot/util"
+	"github.com/openconfig/ygot/ygen"
+)
+
+const (
+	// enumTypePrefix is the prefix that is used for the names of the
+	// string-literal union types that represent enumerated types, in the
+	// form:
+	//   <enumTypePrefix><EnumName>
+	enumTypePrefix string = "E_"
+	// brandsFlagKey is the key within the Flags of a leaf or leaf-list
+	// field in the IR that stores the JSON-encoded definitions of the
+	// branded types that are referenced by the type of the field.
+	brandsFlagKey string = "typescript-brands"
+)
+
+const (
+	// integerEncoding is the encoding of a brand whose values are JSON
+	// numbers that must be integers.
+	integerEncoding = "integer"
+	// int64Encoding is the encoding of a brand whose values are 64-bit
+	// integers, which RFC7951 encodes as strings.
+	int64Encoding = "int64"
+	// decimal64Encoding is the encoding of a brand whose values are
+	// decimal64 numbers, which RFC7951 encodes as strings.
+	decimal64Encoding = "decimal64"
+	// stringEncoding is the encoding of a brand whose values are strings.
+	stringEncoding = "string"
+	// binaryEncoding is the encoding of a brand whose values are
+	// base64-encoded binary data.
+	binaryEncoding = "binary"
+)
+
+// brandDef describes a branded type, which is a TypeScript type that can only
+// be assigned a value that has been checked to satisfy the constraints of a
+// scalar YANG type. Brands are generated for the built-in YANG types whose
+// values are not described by a TypeScript primitive, and for typedefs that
+// restrict the values of their base type.
+type brandDef struct {
+	// Name is the name of the branded type.
+	Name string `json:"name"`
+	// YANGType is the name of the YANG type that the brand represents,
+	// which is qualified with the name of its module for a typedef.
+	YANGType string `json:"yang_type"`
+	// Encoding specifies how values of the brand are encoded in RFC7951
+	// JSON, and hence the primitive type that the brand is based on.
+	Encoding string `json:"encoding"`
+	// Ranges are the ranges of valid values of a numeric type, or the
+	// ranges of valid lengths of a string or binary type. Each is a pair of
+	// inclusive bounds, where an empty bound is unrestricted.
+	Ranges [][2]string `json:"ranges,omitempty"`
+	// FractionDigits is the number of fraction digits of a decimal64
+	// type.
+	FractionDigits int `json:"fraction_digits,omitempty"`
+	// Unsigned specifies that the values of a 64-bit integer type are
+	// unsigned, and hence cannot be written with a sign.
+	Unsigned bool `json:"unsigned,omitempty"`
+	// Patterns are the anchored regular expressions that all values of a
+	// string type must match.
+	Patterns []string `json:"patterns,omitempty"`
+}
+
+// base returns the TypeScript primitive type that the brand b is based on.
+func (b *brandDef) base() string {
+	if b.Encoding == integerEncoding {
+		return "number"
+	}
+	return "string"
+}
+
+// Ensure at compile time that the TSLangMapper implements the LangMapper interface.
+var _ ygen.LangMapper = &TSLangMapper{}
+
+// TSLangMapper contains the functionality and state for generating
+// TypeScript names and types for the generated code.
+type TSLangMapper struct {
+	// LangMapperBase being embedded is a requirement for TSLangMapper to
+	// implement the LangMapper interface, and also gives it access to
+	// built-in methods.
+	ygen.LangMapperBase
+
+	// definedNames specifies the interface names used during generation
+	// to avoid conflicts.
+	definedNames map[string]bool
+	// uniqueDirectoryNames is a map keyed by the path of a YANG entity
+	// representing a directory in the generated code whose value is the
+	// unique interface name that it was mapped to.
+	uniqueDirectoryNames map[string]string
+	// definedBrandNames specifies the names of the branded types that
+	// are generated for typedefs, to avoid conflicts.
+	definedBrandNames map[string]bool
+	// uniqueBrandNames is a map keyed by the path of a typedef whose value
+	// is the unique name of the branded type that it was mapped to.
+	uniqueBrandNames map[string]string
+	// leafTypeBrands is a map keyed by the path of a YANG leaf or
+	// leaf-list whose value is the set of branded types that are
+	// referenced by its type, as calculated when mapping the type.
+	leafTypeBrands map[string][]*brandDef
+
+	// UnimplementedLangMapperExt ensures TSLangMapper implements the
+	// LangMapperExt interface for forwards compatibility.
+	ygen.UnimplementedLangMapperExt
+}
+
+// NewTSLangMapper creates a new TSLangMapper instance, initialised with the
+// default state required for code generation.
+func NewTSLangMapper() *TSLangMapper {
+	return &TSLangMapper{
+		definedNames:         map[string]bool{},
+		uniqueDirectoryNames: map[string]string{},
+		definedBrandNames:    map[string]bool{},
+		uniqueBrandNames:     map[string]string{},
+		leafTypeBrands:       map[string][]*brandDef{},
+	}
+}
+
+// resolveTypeArgs is a structure used as an input argument to the
+// yangTypeToTSType function which allows extra context to be handed on. This
+// provides the ability to use not only the YangType but also the yang.Entry
+// that the type was part of to resolve the possible type name.
+type resolveTypeArgs struct {
+	// yangType is a pointer to the yang.YangType that is to be mapped.
+	yangType *yang.YangType
+	// contextEntry is an optional yang.Entry which is supplied where a
+	// type requires knowledge of the leaf that it is used within to be
+	// mapped. For example, where a leaf is defined to have a type of a
+	// user-defined type (typedef) that in turn has enumerated values - the
+	// context of the yang.Entry is required such that the leaf's context
+	// can be established.
+	contextEntry *yang.Entry
+	// unionTypedef is the typedef within which yangType is defined as a
+	// subtype of a union, if any.
+	unionTypedef *yang.Typedef
+	// subtypeName is the name of yangType within the union typedef
+	// unionTypedef, which is formed from its kind, and its index amongst
+	// the subtypes of the same kind if there is more than one.
+	subtypeName string
+}
+
+// DirectoryName generates the name of the interface to be used for a
+// particular YANG schema element in the generated code. The name is of the
+// form PathElement1_PathElement2, and is made unique amongst the names of all
+// directories.
+func (s *TSLangMapper) DirectoryName(e *yang.Entry, cb genutil.CompressBehaviour) (string, error) {
+	if name, ok := s.uniqueDirectoryNames[e.Path()]; ok {
+		return name, nil
+	}
+	name := genutil.MakeNameUnique(interfaceName(e, cb.CompressEnabled()), s.definedNames)
+	s.uniqueDirectoryNames[e.Path()] = name
+	return name, nil
+}
+
+// FieldName maps the input entry's name to the local name of the member that
+// represents it in RFC7951 JSON, which is the YANG identifier of the node. The
+// module name that is required to qualify the member name where the node is
+// in a different namespace to its parent is added during code generation.
+func (s *TSLangMapper) FieldName(e *yang.Entry) (string, error) {
+	return e.Name, nil
+}
+
+// LeafType maps the input leaf entry to a ygen.MappedType object containing
+// the type information about the field. The branded types that are
+// referenced by the type are stored such that they can be added to the
+// field's flags.
+func (s *TSLangMapper) LeafType(e *yang.Entry, opts ygen.IROptions) (*ygen.MappedType, error) {
+	mtype, brands, err := s.yangTypeToTSType(resolveTypeArgs{yangType: e.Type, contextEntry: e}, opts)
+	if err != nil {
+		return nil, err
+	}
+	s.leafTypeBrands[e.Path()] = brands
+	return mtype, nil
+}
+
+// KeyLeafType maps the input list key entry to a ygen.MappedType object
+// containing the type information about the key field.
+func (s *TSLangMapper) KeyLeafType(e *yang.Entry, opts ygen.IROptions) (*ygen.MappedType, error) {
+	mtype, _, err := s.yangTypeToTSType(resolveTypeArgs{yangType: e.Type, contextEntry: e}, opts)
+	return mtype, err
+}
+
+// PackageName is not used by TypeScript generation.
+func (s *TSLangMapper) PackageName(*yang.Entry, genutil.CompressBehaviour, bool) (string, error) {
+	return "", nil
+}
+
+// PopulateFieldFlags stores the JSON-encoded definitions of the branded types
+// that are referenced by the type of leaf and leaf-list fields within their
+// flags, such that the definitions can be output in the generated code.
+func (s *TSLangMapper) PopulateFieldFlags(nd ygen.NodeDetails, field *yang.Entry) map[string]string {
+	if nd.Type != ygen.LeafNode && nd.Type != ygen.LeafListNode {
+		return nil
+	}
+	brands := s.leafTypeBrands[field.Path()]
+	if len(brands) == 0 {
+		return nil
+	}
+	js, err := json.Marshal(brands)
+	if err != nil {
+		// This can never occur since brands contain only values that
+		// can be marshalled.
+		log.Errorf("cannot marshal brands for %s: %v", field.Path(), err)
+		return nil
+	}
+	return map[string]string{brandsFlagKey: string(js)}
+}
+
+// interfaceName takes an input yang.Entry and outputs its name in the form
+// PathElement1_PathElement2, performing schema compression if required. The
+// name is not checked for uniqueness.
+func interfaceName(e *yang.Entry, compressPaths bool) string {
+	if igenutil.IsFakeRoot(e) {
+		return genutil.EntryCamelCaseName(e)
+	}
+
+	var names []string
+	for element := e; element != nil; element = element.Parent {
+		if compressPaths && util.IsOCCompressedValidElement(element) || !compressPaths && !util.IsChoiceOrCase(element) {
+			names = append([]string{genutil.EntryCamelCaseName(element)}, names...)
+		}
+	}
+	return strings.Join(names, "_")
+}
+
+// yangTypeToTSType takes a yang.YangType (YANG type definition) and maps it
+// to the TypeScript type of its values when encoded as RFC7951 JSON, along
+// with the definitions of the branded types that the TypeScript type
+// references. The NativeType of the returned ygen.MappedType is the
+// TypeScript type expression.
+func (s *TSLangMapper) yangTypeToTSType(args resolveTypeArgs, opts ygen.IROptions) (*ygen.MappedType, []*brandDef, error) {
+	// Handle the case of a typedef which is actually an enumeration.
+	typedefName, key, isTypedef, err := s.EnumeratedTypedefTypeName(args.yangType, args.contextEntry, enumTypePrefix, !opts.TransformationOptions.EnumerationsUseUnderscores, opts.TransformationOptions.UseDefiningModuleForTypedefEnumNames)
+	if err != nil {
+		return nil, nil, err
+	}
+	if isTypedef {
+		return enumeratedType(typedefName, key), nil, nil
+	}
+
+	t := args.yangType
+	switch t.Kind {
+	case yang.Yint8, yang.Yint16, yang.Yint32, yang.Yuint8, yang.Yuint16, yang.Yuint32,
+		yang.Yint64, yang.Yuint64, yang.Ydecimal64, yang.Ystring, yang.Ybinary:
+		b, err := s.scalarBrand(args, opts)
+		if err != nil {
+			return nil, nil, fmt.Errorf("cannot map type of %s: %v", args.contextEntry.Path(), err)
+		}
+		if b == nil {
+			return &ygen.MappedType{NativeType: "string"}, nil, nil
+		}
+		return &ygen.MappedType{NativeType: b.Name}, []*brandDef{b}, nil
+	case yang.Ybool:
+		return &ygen.MappedType{NativeType: "boolean"}, nil, nil
+	case yang.Yempty:
+		// An empty leaf is encoded as [null] in RFC7951.
+		return &ygen.MappedType{NativeType: "[null]"}, nil, nil
+	case yang.Ybits:
+		// A bits value is encoded as a space-separated list of the
+		// names of the bits that are set.
+		return &ygen.MappedType{NativeType: "string"}, nil, nil
+	case yang.Yenum:
+		if args.contextEntry == nil {
+			return nil, nil, fmt.Errorf("cannot map enum without context")
+		}
+		n, key, err := s.EnumName(args.contextEntry, opts.TransformationOptions.CompressBehaviour.CompressEnabled(), !opts.TransformationOptions.EnumerationsUseUnderscores, opts.TransformationOptions.SkipEnumDeduplication, opts.TransformationOptions.ShortenEnumLeafNames, false, opts.TransformationOptions.EnumOrgPrefixesToTrim)
+		if err != nil {
+			return nil, nil, err
+		}
+		return enumeratedType(enumTypePrefix+n, key), nil, nil
+	case yang.Yidentityref:
+		n, key, err := s.IdentityrefBaseTypeFromIdentity(t.IdentityBase)
+		if err != nil {
+			return nil, nil, err
+		}
+		return enumeratedType(enumTypePrefix+n, key), nil, nil
+	case yang.Yleafref:
+		// This is a leafref, so we check what the type of the leaf that it
+		// references is by looking it up.
+		target, err := s.ResolveLeafrefTarget(t.Path, args.contextEntry)
+		if err != nil {
+			return nil, nil, err
+		}
+		return s.yangTypeToTSType(resolveTypeArgs{yangType: target.Type, contextEntry: target}, opts)
+	case yang.Yunion:
+		return s.unionType(args, opts)
+	default:
+		// Any value is accepted for types that cannot be described.
+		return &ygen.MappedType{NativeType: "unknown"}, nil, nil
+	}
+}
+
+// unionType maps a YANG union to the TypeScript union of the types of its
+// subtypes. Nested unions are flattened, and subtypes that map to the same
+// TypeScript type are output once. Where all subtypes map to the same type,
+// that type is returned directly.
+func (s *TSLangMapper) unionType(args resolveTypeArgs, opts ygen.IROptions) (*ygen.MappedType, []*brandDef, error) {
+	mtype := &ygen.MappedType{UnionTypes: map[string]ygen.MappedUnionSubtype{}}
+	var (
+		names  []string
+		brands []*brandDef
+		seen   = map[string]bool{}
+		errs   util.Errors
+	)
+
+	td := unionTypedef(args.yangType)
+	var subtypeNames map[*yang.YangType]string
+	if td != nil {
+		subtypeNames = unionSubtypeNames(util.FlattenedTypes(args.yangType.Type))
+	}
+
+	var addSubtypes func(*yang.YangType)
+	addSubtypes = func(t *yang.YangType) {
+		// If t.Type is not empty then this means that this type is
+		// defined to be a union itself.
+		if len(t.Type) != 0 {
+			for _, st := range t.Type {
+				addSubtypes(st)
+			}
+			return
+		}
+		smtype, sbrands, err := s.yangTypeToTSType(resolveTypeArgs{
+			yangType:     t,
+			contextEntry: args.contextEntry,
+			unionTypedef: td,
+			subtypeName:  subtypeNames[t],
+		}, opts)
+		if err != nil {
+			errs = util.AppendErr(errs, err)
+			return
+		}
+		if _, ok := mtype.UnionTypes[smtype.NativeType]; ok {
+			return
+		}
+		mtype.UnionTypes[smtype.NativeType] = ygen.MappedUnionSubtype{
+			Index:                 len(mtype.UnionTypes),
+			EnumeratedYANGTypeKey: smtype.EnumeratedYANGTypeKey,
+		}
+		names = append(names, smtype.NativeType)
+		for _, b := range sbrands {
+			if !seen[b.Name] {
+				seen[b.Name] = true
+				brands = append(brands, b)
+			}
+		}
+	}
+	addSubtypes(args.yangType)
+	if errs != nil {
+		return nil, nil, errs
+	}
+
+	if len(mtype.UnionTypes) == 1 {
+		st := mtype.UnionTypes[names[0]]
+		return &ygen.MappedType{
+			NativeType:            names[0],
+			IsEnumeratedValue:     st.EnumeratedYANGTypeKey != "",
+			EnumeratedYANGTypeKey: st.EnumeratedYANGTypeKey,
+		}, brands, nil
+	}
+	mtype.NativeType = strings.Join(names, " | ")
+	return mtype, brands, nil
+}
+
+// unionTypedef returns the typedef within which the union type t is defined,
+// or nil if t is not defined directly within a typedef, such as where it is
+// defined within a leaf, or within a typedef that is derived from another.
+func unionTypedef(t *yang.YangType) *yang.Typedef {
+	if t.Base == nil {
+		return nil
+	}
+	td, ok := t.Base.ParentNode().(*yang.Typedef)
+	if !ok || td.Type == nil || td.Type.Name != "union" {
+		return nil
+	}
+	return td
+}
+
+// unionSubtypeNames returns the names of the flattened subtypes of a union,
+// which are formed from the kind of each subtype, suffixed with its 1-based
+// index amongst the subtypes of the same kind if there is more than one,
+// e.g., String1 and String2 for a union of two string types.
+func unionSubtypeNames(types []*yang.YangType) map[*yang.YangType]string {
+	counts := map[yang.TypeKind]int{}
+	for _, t := range types {
+		counts[t.Kind]++
+	}
+	names := map[*yang.YangType]string{}
+	seen := map[yang.TypeKind]int{}
+	for _, t := range types {
+		name := yang.CamelCase(t.Kind.String())
+		if counts[t.Kind] > 1 {
+			seen[t.Kind]++
+			name = fmt.Sprintf("%s%d", name, seen[t.Kind])
+		}
+		names[t] = name
+	}
+	return names
+}
+
+// enumeratedType returns the mapped type of a leaf whose values are those of
+// the enumerated type with the name and key supplied.
+func enumeratedType(name, key string) *ygen.MappedType {
+	return &ygen.MappedType{
+		NativeType:            name,
+		IsEnumeratedValue:     true,
+		EnumeratedYANGTypeKey: key,
+	}
+}
+
+// scalarBrand returns the branded type that is used for the scalar YANG type
+// args.yangType, which is the type of the leaf args.contextEntry or one of the
+// subtypes of its union type. Where the type is restricted where it is used,
+// the brand is named after the union typedef and subtype where it is a
+// subtype of a union typedef, and otherwise after the leaf. Otherwise, where
+// the type is derived from a typedef that restricts the values of its base
+// type, the brand is named after the typedef. In all cases, the brand is
+// checked against all of the restrictions of the type. A built-in brand,
+// which is checked against the range of the built-in type, is used for an
+// unrestricted type, except for a string type, for which a nil brand is
+// returned.
+func (s *TSLangMapper) scalarBrand(args resolveTypeArgs, opts ygen.IROptions) (*brandDef, error) {
+	t, e := args.yangType, args.contextEntry
+	name := yang.CamelCase(t.Kind.String())
+	yangType := t.Kind.String()
+	td := restrictingTypedef(t)
+	switch {
+	case restrictedInUse(t) && args.unionTypedef != nil:
+		// The subtypes of a union typedef are shared by all of the
+		// leaves that use it, and hence are named after the typedef.
+		utd := args.unionTypedef
+		key := fmt.Sprintf("%s#%p", yang.NodePath(utd), t)
+		if n, ok := s.uniqueBrandNames[key]; ok {
+			name = n
+		} else {
+			name = genutil.MakeNameUnique(fmt.Sprintf("%s_%s_%s", genutil.ParentModulePrettyName(utd, opts.TransformationOptions.EnumOrgPrefixesToTrim...), yang.CamelCase(utd.Name), args.subtypeName), s.definedBrandNames)
+			s.uniqueBrandNames[key] = name
+		}
+		yangType = fmt.Sprintf("%s within %s:%s", yangType, genutil.ParentModuleName(utd), utd.Name)
+	case restrictedInUse(t):
+		// The type of a leaf is mapped both as a leaf and as a list
+		// key, and hence the name is cached by both the leaf and the
+		// type.
+		key := fmt.Sprintf("%s#%p", e.Path(), t)
+		if n, ok := s.uniqueBrandNames[key]; ok {
+			name = n
+		} else {
+			name = genutil.MakeNameUnique(interfaceName(e, opts.TransformationOptions.CompressBehaviour.CompressEnabled()), s.definedBrandNames)
+			s.uniqueBrandNames[key] = name
+		}
+		yangType = fmt.Sprintf("%s at %s", yangType, e.Path())
+	case td != nil:
+		key := yang.NodePath(td)
+		if n, ok := s.uniqueBrandNames[key]; ok {
+			name = n
+		} else {
+			name = genutil.MakeNameUnique(fmt.Sprintf("%s_%s", genutil.ParentModulePrettyName(td, opts.TransformationOptions.EnumOrgPrefixesToTrim...), yang.CamelCase(td.Name)), s.definedBrandNames)
+			s.uniqueBrandNames[key] = name
+		}
+		yangType = fmt.Sprintf("%s:%s", genutil.ParentModuleName(td), td.Name)
+		t = td.YangType
+	default:
+		if t.Kind == yang.Ystring {
+			return nil, nil
+		}
+		bt, ok := yang.BaseTypedefs[t.Kind.String()]
+		if !ok {
+			return nil, fmt.Errorf("no built-in type %v", t.Kind)
+		}
+		t = bt.YangType
+	}
+
+	b := &brandDef{Name: name, YANGType: yangType}
+	switch t.Kind {
+	case yang.Yint8, yang.Yint16, yang.Yint32, yang.Yuint8, yang.Yuint16, yang.Yuint32:
+		b.Encoding = integerEncoding
+		b.Ranges = rangeBounds(t.Range, false)
+	case yang.Yint64, yang.Yuint64:
+		b.Encoding = int64Encoding
+		b.Unsigned = t.Kind == yang.Yuint64
+		b.Ranges = rangeBounds(t.Range, false)
+	case yang.Ydecimal64:
+		b.Encoding = decimal64Encoding
+		b.FractionDigits = t.FractionDigits
+		b.Ranges = rangeBounds(t.Range, false)
+	case yang.Ystring:
+		b.Encoding = stringEncoding
+		b.Ranges = rangeBounds(t.Length, true)
+		b.Patterns = patterns(t)
+	case yang.Ybinary:
+		b.Encoding = binaryEncoding
+		b.Ranges = rangeBounds(t.Length, true)
+	default:
+		return nil, fmt.Errorf("type %s of kind %v cannot be branded", yangType, t.Kind)
+	}
+	return b, nil
+}
+
+// restrictedInUse returns true if the type t has restrictions which are not
+// those of the type, either built-in or a typedef, that it is based on, and
+// hence was restricted where it was used.
+func restrictedInUse(t *yang.YangType) bool {
+	if t.Base == nil || t.Base.YangType == nil {
+		return false
+	}
+	bt := t.Base.YangType
+	return !reflect.DeepEqual(t.Range, bt.Range) ||
+		!reflect.DeepEqual(t.Length, bt.Length) ||
+		!reflect.DeepEqual(t.Pattern, bt.Pattern) ||
+		!reflect.DeepEqual(t.POSIXPattern, bt.POSIXPattern) ||
+		t.FractionDigits != bt.FractionDigits
+}
+
+// restrictingTypedef returns the closest typedef from which the type t is
+// derived that restricts the values of its base type using a range, length,
+// pattern or fraction-digits statement. It returns nil if there is no such
+// typedef.
+func restrictingTypedef(t *yang.YangType) *yang.Typedef {
+	for t != nil && t.Base != nil {
+		td, ok := t.Base.ParentNode().(*yang.Typedef)
+		if !ok || td.Type == nil {
+			return nil
+		}
+		if restricts(td.Type) {
+			return td
+		}
+		t = td.Type.YangType
+	}
+	return nil
+}
+
+// restricts returns true if the type statement t restricts the values of its
+// base type.
+func restricts(t *yang.Type) bool {
+	if t.Range != nil || t.Length != nil || len(t.Pattern) != 0 || t.FractionDigits != nil {
+		return true
+	}
+	for _, ext := range t.Extensions {
+		if strings.HasSuffix(ext.Keyword, ":posix-pattern") {
+			return true
+		}
+	}
+	return false
+}
+
+// rangeBounds returns the inclusive bounds of each part of the YANG range r.
+// If unboundedMax is set, an upper bound of the maximum uint64 value, which
+// is the upper bound of the length of an unrestricted string, is returned as
+// an empty bound.
+func rangeBounds(r yang.YangRange, unboundedMax bool) [][2]string {
+	var bounds [][2]string
+	for _, yr := range r {
+		var max string
+		if !unboundedMax || yr.Max.Negative || yr.Max.Value != math.MaxUint64 {
+			max = yr.Max.String()
+		}
+		bounds = append(bounds, [2]string{yr.Min.String(), max})
+	}
+	return bounds
+}
+
+// patterns returns the anchored regular expressions that values of the string
+// type t must match. POSIX patterns, which are specified using the
+// openconfig-extensions posix-pattern statement, are used in preference to the
+// YANG patterns where they are present. YANG patterns are implicitly anchored,
+// and hence are anchored when they are returned.
+func patterns(t *yang.YangType) []string {
+	if len(t.POSIXPattern) != 0 {
+		return t.POSIXPattern
+	}
+	var ps []string
+	for _, p := range t.Pattern {
+		ps = append(ps, fmt.Sprintf("^(?:%s)$", p))
+	}
+	return ps
+}
@@ -0,0 +1,202 @@
+// Copyright 2023 Google Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package tsgen
+
+import (
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/openconfig/gnmi/errdiff"
+	"github.com/openconfig/goyang/pkg/yang"
+	"github.com/openconfig/ygot/ygen"
+)
+
+// mustParseRange parses the YANG range s, failing the test if it is invalid.
+func mustParseRange(t *testing.T, s string) yang.YangRange {
+	t.Helper()
+	r, err := yang.ParseRangesInt(s)
+	if err != nil {
+		t.Fatalf("cannot parse range %q: %v", s, err)
+	}
+	return r
+}
+
+func TestYangTypeToTSType(t *testing.T) {
+	tests := []struct {
+		name             string
+		in               *yang.YangType
+		wantNativeType   string
+		wantBrands       []*brandDef
+		wantErrSubstring string
+	}{{
+		name:           "int8 uses the built-in range",
+		in:             &yang.YangType{Kind: yang.Yint8},
+		wantNativeType: "Int8",
+		wantBrands: []*brandDef{{
+			Name:     "Int8",
+			YANGType: "int8",
+			Encoding: integerEncoding,
+			Ranges:   [][2]string{{"-128", "127"}},
+		}},
+	}, {
+		name:           "uint64 encoded as string",
+		in:             &yang.YangType{Kind: yang.Yuint64},
+		wantNativeType: "Uint64",
+		wantBrands: []*brandDef{{
+			Name:     "Uint64",
+			YANGType: "uint64",
+			Encoding: int64Encoding,
+			Ranges:   [][2]string{{"0", "18446744073709551615"}},
+			Unsigned: true,
+		}},
+	}, {
+		name:           "unrestricted string",
+		in:             &yang.YangType{Kind: yang.Ystring},
+		wantNativeType: "string",
+	}, {
+		name:           "boolean",
+		in:             &yang.YangType{Kind: yang.Ybool},
+		wantNativeType: "boolean",
+	}, {
+		name:           "empty",
+		in:             &yang.YangType{Kind: yang.Yempty},
+		wantNativeType: "[null]",
+	}, {
+		name:           "bits",
+		in:             &yang.YangType{Kind: yang.Ybits},
+		wantNativeType: "string",
+	}, {
+		name: "union of strings",
+		in: &yang.YangType{
+			Kind: yang.Yunion,
+			Type: []*yang.YangType{
+				{Kind: yang.Ystring},
+				{Kind: yang.Ystring},
+			},
+		},
+		wantNativeType: "string",
+	}, {
+		name: "nested union",
+		in: &yang.YangType{
+			Kind: yang.Yunion,
+			Type: []*yang.YangType{
+				{Kind: yang.Ybool},
+				{
+					Kind: yang.Yunion,
+					Type: []*yang.YangType{
+						{Kind: yang.Ystring},
+						{Kind: yang.Ybinary},
+					},
+				},
+			},
+		},
+		wantNativeType: "boolean | string | Binary",
+		wantBrands: []*brandDef{{
+			Name:     "Binary",
+			YANGType: "binary",
+			Encoding: binaryEncoding,
+		}},
+	}, {
+		name:             "enumeration without context",
+		in:               &yang.YangType{Kind: yang.Yenum},
+		wantErrSubstring: "context",
+	}}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			s := NewTSLangMapper()
+			got, gotBrands, err := s.yangTypeToTSType(resolveTypeArgs{yangType: tt.in}, ygen.IROptions{})
+			if diff := errdiff.Substring(err, tt.wantErrSubstring); diff != "" {
+				t.Fatalf("yangTypeToTSType(%v): did not get expected error, %s", tt.in, diff)
+			}
+			if err != nil {
+				return
+			}
+			if got.NativeType != tt.wantNativeType {
+				t.Errorf("yangTypeToTSType(%v): did not get expected native type, got: %q, want: %q", tt.in, got.NativeType, tt.wantNativeType)
+			}
+			if diff := cmp.Diff(tt.wantBrands, gotBrands); diff != "" {
+				t.Errorf("yangTypeToTSType(%v): did not get expected brands, diff(-want, +got):\n%s", tt.in, diff)
+			}
+		})
+	}
+}
+
+func TestRangeBounds(t *testing.T) {
+	tests := []struct {
+		name           string
+		in             yang.YangRange
+		inUnboundedMax bool
+		want           [][2]string
+	}{{
+		name: "multiple ranges",
+		in:   mustParseRange(t, "-10..-1|1..10"),
+		want: [][2]string{{"-10", "-1"}, {"1", "10"}},
+	}, {
+		name:           "unbounded length",
+		in:             mustParseRange(t, "2..18446744073709551615"),
+		inUnboundedMax: true,
+		want:           [][2]string{{"2", ""}},
+	}, {
+		name: "maximum uint64 value",
+		in:   mustParseRange(t, "0..18446744073709551615"),
+		want: [][2]string{{"0", "18446744073709551615"}},
+	}}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if diff := cmp.Diff(tt.want, rangeBounds(tt.in, tt.inUnboundedMax)); diff != "" {
+				t.Errorf("rangeBounds(%v, %v): did not get expected bounds, diff(-want, +got):\n%s", tt.in, tt.inUnboundedMax, diff)
+			}
+		})
+	}
+}
+
+func TestPatterns(t *testing.T) {
+	tests := []struct {
+		name string
+		in   *yang.YangType
+		want []string
+	}{{
+		name: "YANG patterns are anchored",
+		in:   &yang.YangType{Pattern: []string{`[a-z]+`, `.*x.*`}},
+		want: []string{`^(?:[a-z]+)$`, `^(?:.*x.*)$`},
+	}, {
+		name: "POSIX patterns are preferred",
+		in: &yang.YangType{
+			Pattern:      []string{`[a-z]+`},
+			POSIXPattern: []string{`^[a-z]+$`},
+		},
+		want: []string{`^[a-z]+$`},
+	}}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if diff := cmp.Diff(tt.want, patterns(tt.in)); diff != "" {
+				t.Errorf("patterns(%v): did not get expected patterns, diff(-want, +got):\n%s", tt.in, diff)
+			}
+		})
+	}
+}
+
+func TestUnionSubtypeNames(t *testing.T) {
+	s1 := &yang.YangType{Kind: yang.Ystring}
+	s2 := &yang.YangType{Kind: yang.Ystring}
+	i := &yang.YangType{Kind: yang.Yint8}
+	want := map[*yang.YangType]string{s1: "String1", i: "Int8", s2: "String2"}
+	if diff := cmp.Diff(want, unionSubtypeNames([]*yang.YangType{s1, i, s2})); diff != "" {
+		t.Errorf("unionSubtypeNames: did not get expected names, diff(-want, +got):\n%s", diff)
+	}
+}
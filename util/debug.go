@@ -218,30 +218,44 @@ func SchemaTreeString(schema *yang.Entry, prefix string) string {
 //	         MasklengthRange : "20..24" [masklength-range (leaf)]
 //	       PrefixSetName : "prefix1" [prefix-set-name (leaf)]
 func DataSchemaTreesString(schema *yang.Entry, dataTree interface{}) string {
-	printFieldsIterFunc := func(ni *NodeInfo, in, out interface{}) (errs Errors) {
-		outs := out.(*string)
-		prefix := ""
-		for i := 0; i < len(strings.Split(ni.Schema.Path(), "/")); i++ {
-			prefix += "  "
-		}
+	v := &dataSchemaTreesVisitor{}
+	if !IsValueNil(dataTree) {
+		Walk(v, WalkNodeFromGoStruct(dataTree), DefaultWalkOptions().WithSchema(schema).WithWalkErrors(&v.errs))
+	}
+	if v.errs.Errors != nil {
+		return v.errs.Errors.String()
+	}
 
-		fStr := fmt.Sprintf("%s%s", prefix, ni.StructField.Name)
-		schemaStr := fmt.Sprintf("[%s (%s)]", ni.Schema.Name, SchemaTypeStr(ni.Schema))
-		switch {
-		case IsValueScalar(ni.FieldValue):
-			*outs += fmt.Sprintf("  %s : %s %s\n", fStr, pretty.Sprint(ni.FieldValue.Interface()), schemaStr)
-		case !IsNilOrInvalidValue(ni.FieldKey):
-			*outs += fmt.Sprintf("%s%v\n", prefix, ni.FieldKey)
-		case !IsNilOrInvalidValue(ni.FieldValue):
-			*outs += fmt.Sprintf("%s %s\n", fStr, schemaStr)
-		}
-		return
+	return v.out.String()
+}
+
+// dataSchemaTreesVisitor is a Visitor that prints each node of the data tree
+// along with its schema, for use by DataSchemaTreesString.
+type dataSchemaTreesVisitor struct {
+	out  strings.Builder
+	errs DefaultWalkErrors
+}
+
+// Visit implements the Visitor interface.
+func (v *dataSchemaTreesVisitor) Visit(node WalkNode) Visitor {
+	if node == nil {
+		return nil
 	}
-	var outStr string
-	errs := ForEachField(schema, dataTree, nil, &outStr, printFieldsIterFunc)
-	if errs != nil {
-		outStr = errs.String()
+	ni := node.NodeInfo()
+	prefix := ""
+	for i := 0; i < len(strings.Split(ni.Schema.Path(), "/")); i++ {
+		prefix += "  "
 	}
 
-	return outStr
+	fStr := fmt.Sprintf("%s%s", prefix, ni.StructField.Name)
+	schemaStr := fmt.Sprintf("[%s (%s)]", ni.Schema.Name, SchemaTypeStr(ni.Schema))
+	switch {
+	case IsValueScalar(ni.FieldValue):
+		fmt.Fprintf(&v.out, "  %s : %s %s\n", fStr, pretty.Sprint(ni.FieldValue.Interface()), schemaStr)
+	case !IsNilOrInvalidValue(ni.FieldKey):
+		fmt.Fprintf(&v.out, "%s%v\n", prefix, ni.FieldKey)
+	case !IsNilOrInvalidValue(ni.FieldValue):
+		fmt.Fprintf(&v.out, "%s %s\n", fStr, schemaStr)
+	}
+	return v
 }
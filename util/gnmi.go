@@ -25,6 +25,10 @@ import (
 	gpb "github.com/openconfig/gnmi/proto/gnmi"
 )
 
+// MultiLevelWildcard is the name of a gNMI PathElem that matches zero or more
+// elements of a path.
+const MultiLevelWildcard = "..."
+
 // PathMatchesPrefix reports whether prefix is a prefix of path.
 func PathMatchesPrefix(path *gpb.Path, prefix []string) bool {
 	if len(path.GetElem()) < len(prefix) {
@@ -121,33 +125,92 @@ func PathMatchesPathElemPrefix(path, prefix *gpb.Path) bool {
 }
 
 // PathMatchesQuery returns whether query is prefix of path.
-// Only the query may contain wildcard name or keys.
-// TODO: Multilevel wildcards ("...") not supported.
+// Only the query may contain wildcard name or keys, or the multi-level
+// wildcard "...", which matches zero or more path elements.
 // If either path and query contain nil elements func returns false.
 // Both paths must use the gNMI >=0.4.0 PathElem path format.
 func PathMatchesQuery(path, query *gpb.Path) bool {
-	if len(path.GetElem()) < len(query.GetElem()) {
+	return originsMatch(path, query) && elemsMatchQuery(path.GetElem(), query.GetElem(), false, false)
+}
+
+// PathMatchesQueryExactly returns whether query matches the whole of path,
+// rather than a prefix of it. Wildcards in query are handled as per
+// PathMatchesQuery.
+func PathMatchesQueryExactly(path, query *gpb.Path) bool {
+	return originsMatch(path, query) && elemsMatchQuery(path.GetElem(), query.GetElem(), true, false)
+}
+
+// PathMatchesQueryExactlyAllKeys returns whether query matches the whole of
+// path as per PathMatchesQueryExactly, and each element of query that is
+// matched against an element of path specifies all of the element's keys,
+// either explicitly or as a wildcard. The elements of path that are matched
+// by the multi-level wildcard are not restricted.
+func PathMatchesQueryExactlyAllKeys(path, query *gpb.Path) bool {
+	return originsMatch(path, query) && elemsMatchQuery(path.GetElem(), query.GetElem(), true, true)
+}
+
+// PathIsBeneathQuery returns whether path is strictly beneath a path that is
+// matched exactly by query, i.e., whether path is a descendant of one of the
+// nodes that query selects. Wildcards in query are handled as per
+// PathMatchesQuery.
+func PathIsBeneathQuery(path, query *gpb.Path) bool {
+	if !originsMatch(path, query) {
 		return false
 	}
-	// Unset Origin fields can match "openconfig", see https://github.com/openconfig/reference/blob/master/rpc/gnmi/mixed-schema.md#special-values-of-origin.
-	if path.Origin != query.Origin && !(path.Origin == "" && query.Origin == "openconfig" || path.Origin == "openconfig" && query.Origin == "") {
-		return false
+	for i := 0; i < len(path.GetElem()); i++ {
+		if elemsMatchQuery(path.GetElem()[:i], query.GetElem(), true, false) {
+			return true
+		}
 	}
-	for i, queryElem := range query.Elem {
-		pathElem := path.Elem[i]
-		if queryElem == nil || pathElem == nil {
+	return false
+}
+
+// originsMatch returns whether the origins of path and query match.
+func originsMatch(path, query *gpb.Path) bool {
+	// Unset Origin fields can match "openconfig", see https://github.com/openconfig/reference/blob/master/rpc/gnmi/mixed-schema.md#special-values-of-origin.
+	return path.GetOrigin() == query.GetOrigin() || path.GetOrigin() == "" && query.GetOrigin() == "openconfig" || path.GetOrigin() == "openconfig" && query.GetOrigin() == ""
+}
+
+// elemsMatchQuery returns whether the query elements are a prefix of the path
+// elements, handling wildcards as per PathMatchesQuery. If exact is set, the
+// query elements must match all of the path elements. If allKeys is set, each
+// query element must specify all of the keys of the path element that it
+// matches.
+func elemsMatchQuery(path, query []*gpb.PathElem, exact, allKeys bool) bool {
+	for i, queryElem := range query {
+		if queryElem == nil {
+			return false
+		}
+		if queryElem.Name == MultiLevelWildcard {
+			// Try each number of path elements that the wildcard can
+			// consume, including none.
+			for j := i; j <= len(path); j++ {
+				if elemsMatchQuery(path[j:], query[i+1:], exact, allKeys) {
+					return true
+				}
+			}
+			return false
+		}
+		if i >= len(path) {
+			return false
+		}
+		pathElem := path[i]
+		if pathElem == nil {
 			return false
 		}
 		if queryElem.Name != "*" && queryElem.Name != pathElem.Name {
 			return false
 		}
+		if allKeys && len(queryElem.Key) != len(pathElem.Key) {
+			return false
+		}
 		for qk, qv := range queryElem.Key {
 			if pv, ok := pathElem.Key[qk]; !ok || (qv != "*" && qv != pv) {
 				return false
 			}
 		}
 	}
-	return true
+	return !exact || len(path) == len(query)
 }
 
 // TrimGNMIPathPrefix returns path with the prefix trimmed. It returns the
@@ -829,6 +829,169 @@ func TestPathMatchesQuery(t *testing.T) {
 				Key:  map[string]string{"seven": "*"},
 			}},
 		},
+	}, {
+		desc: "multi-level wildcard matching zero elements",
+		inPath: &gpb.Path{
+			Elem: []*gpb.PathElem{{
+				Name: "one",
+			}, {
+				Name: "two",
+			}},
+		},
+		inQuery: &gpb.Path{
+			Elem: []*gpb.PathElem{{
+				Name: "one",
+			}, {
+				Name: "...",
+			}, {
+				Name: "two",
+			}},
+		},
+		want: true,
+	}, {
+		desc: "multi-level wildcard matching several elements",
+		inPath: &gpb.Path{
+			Elem: []*gpb.PathElem{{
+				Name: "one",
+			}, {
+				Name: "two",
+			}, {
+				Name: "three",
+				Key:  map[string]string{"four": "five"},
+			}, {
+				Name: "six",
+			}},
+		},
+		inQuery: &gpb.Path{
+			Elem: []*gpb.PathElem{{
+				Name: "one",
+			}, {
+				Name: "...",
+			}, {
+				Name: "six",
+			}},
+		},
+		want: true,
+	}, {
+		desc: "multi-level wildcard followed by keyed element",
+		inPath: &gpb.Path{
+			Elem: []*gpb.PathElem{{
+				Name: "one",
+			}, {
+				Name: "two",
+			}, {
+				Name: "three",
+				Key:  map[string]string{"four": "five"},
+			}, {
+				Name: "six",
+			}},
+		},
+		inQuery: &gpb.Path{
+			Elem: []*gpb.PathElem{{
+				Name: "...",
+			}, {
+				Name: "three",
+				Key:  map[string]string{"four": "*"},
+			}},
+		},
+		want: true,
+	}, {
+		desc: "multi-level wildcard followed by non-matching key",
+		inPath: &gpb.Path{
+			Elem: []*gpb.PathElem{{
+				Name: "one",
+			}, {
+				Name: "two",
+			}, {
+				Name: "three",
+				Key:  map[string]string{"four": "five"},
+			}, {
+				Name: "six",
+			}},
+		},
+		inQuery: &gpb.Path{
+			Elem: []*gpb.PathElem{{
+				Name: "...",
+			}, {
+				Name: "three",
+				Key:  map[string]string{"four": "six"},
+			}},
+		},
+		want: false,
+	}, {
+		desc: "trailing multi-level wildcard",
+		inPath: &gpb.Path{
+			Elem: []*gpb.PathElem{{
+				Name: "one",
+			}, {
+				Name: "two",
+			}},
+		},
+		inQuery: &gpb.Path{
+			Elem: []*gpb.PathElem{{
+				Name: "one",
+			}, {
+				Name: "two",
+			}, {
+				Name: "...",
+			}},
+		},
+		want: true,
+	}, {
+		desc: "multi-level wildcard only",
+		inPath: &gpb.Path{
+			Elem: []*gpb.PathElem{{
+				Name: "one",
+			}},
+		},
+		inQuery: &gpb.Path{
+			Elem: []*gpb.PathElem{{
+				Name: "...",
+			}},
+		},
+		want: true,
+	}, {
+		desc: "multi-level wildcard with no match for remaining elements",
+		inPath: &gpb.Path{
+			Elem: []*gpb.PathElem{{
+				Name: "one",
+			}, {
+				Name: "two",
+			}, {
+				Name: "three",
+			}},
+		},
+		inQuery: &gpb.Path{
+			Elem: []*gpb.PathElem{{
+				Name: "one",
+			}, {
+				Name: "...",
+			}, {
+				Name: "four",
+			}},
+		},
+		want: false,
+	}, {
+		desc: "consecutive multi-level wildcards",
+		inPath: &gpb.Path{
+			Elem: []*gpb.PathElem{{
+				Name: "one",
+			}, {
+				Name: "two",
+			}, {
+				Name: "three",
+			}},
+		},
+		inQuery: &gpb.Path{
+			Elem: []*gpb.PathElem{{
+				Name: "...",
+			}, {
+				Name: "...",
+			}, {
+				Name: "three",
+			}},
+		},
+		want: true,
 	}}
 	for _, tt := range tests {
 		t.Run(tt.desc, func(t *testing.T) {
@@ -839,6 +1002,202 @@ func TestPathMatchesQuery(t *testing.T) {
 	}
 }
 
+func TestPathMatchesQueryExactly(t *testing.T) {
+	elems := func(names ...string) *gpb.Path {
+		p := &gpb.Path{}
+		for _, n := range names {
+			p.Elem = append(p.Elem, &gpb.PathElem{Name: n})
+		}
+		return p
+	}
+
+	tests := []struct {
+		desc    string
+		inPath  *gpb.Path
+		inQuery *gpb.Path
+		want    bool
+	}{{
+		desc:    "equal paths",
+		inPath:  elems("one", "two"),
+		inQuery: elems("one", "two"),
+		want:    true,
+	}, {
+		desc:    "query is a prefix",
+		inPath:  elems("one", "two"),
+		inQuery: elems("one"),
+		want:    false,
+	}, {
+		desc:    "wildcard name",
+		inPath:  elems("one", "two"),
+		inQuery: elems("*", "two"),
+		want:    true,
+	}, {
+		desc:    "multi-level wildcard matching remaining elements",
+		inPath:  elems("one", "two", "three"),
+		inQuery: elems("one", "..."),
+		want:    true,
+	}, {
+		desc:    "multi-level wildcard followed by last element",
+		inPath:  elems("one", "two", "three"),
+		inQuery: elems("...", "three"),
+		want:    true,
+	}, {
+		desc:    "multi-level wildcard followed by intermediate element",
+		inPath:  elems("one", "two", "three"),
+		inQuery: elems("...", "two"),
+		want:    false,
+	}, {
+		desc:    "query longer than path",
+		inPath:  elems("one"),
+		inQuery: elems("one", "..."),
+		want:    true,
+	}, {
+		desc:    "query with more elements than path",
+		inPath:  elems("one"),
+		inQuery: elems("one", "...", "two"),
+		want:    false,
+	}}
+	for _, tt := range tests {
+		t.Run(tt.desc, func(t *testing.T) {
+			if got := util.PathMatchesQueryExactly(tt.inPath, tt.inQuery); got != tt.want {
+				t.Fatalf("did not get expected result, got: %v, want: %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestPathMatchesQueryExactlyAllKeys(t *testing.T) {
+	tests := []struct {
+		desc    string
+		inPath  string
+		inQuery string
+		want    bool
+	}{{
+		desc:    "all keys specified",
+		inPath:  "/a/b[k=x][l=y]/c",
+		inQuery: "/a/b[k=x][l=*]/c",
+		want:    true,
+	}, {
+		desc:    "key omitted",
+		inPath:  "/a/b[k=x][l=y]/c",
+		inQuery: "/a/b[k=x]/c",
+		want:    false,
+	}, {
+		desc:    "keys omitted from element",
+		inPath:  "/a/b[k=x][l=y]/c",
+		inQuery: "/a/b/c",
+		want:    false,
+	}, {
+		desc:    "keyed element matched by multi-level wildcard",
+		inPath:  "/a/b[k=x][l=y]/c",
+		inQuery: "/a/.../c",
+		want:    true,
+	}}
+	for _, tt := range tests {
+		t.Run(tt.desc, func(t *testing.T) {
+			path, err := ygot.StringToStructuredPath(tt.inPath)
+			if err != nil {
+				t.Fatal(err)
+			}
+			query, err := ygot.StringToStructuredPath(tt.inQuery)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if got := util.PathMatchesQueryExactlyAllKeys(path, query); got != tt.want {
+				t.Errorf("PathMatchesQueryExactlyAllKeys(%s, %s): got %v, want %v", tt.inPath, tt.inQuery, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestPathIsBeneathQuery(t *testing.T) {
+	tests := []struct {
+		desc          string
+		inPath        string
+		inQuery       string
+		inQueryOrigin string
+		want          bool
+	}{{
+		desc:    "equal paths",
+		inPath:  "/a/b[k=x]/c",
+		inQuery: "/a/b[k=x]/c",
+		want:    false,
+	}, {
+		desc:    "path matched exactly by wildcard key",
+		inPath:  "/a/b[k=x]/c",
+		inQuery: "/a/b[k=*]/c",
+		want:    false,
+	}, {
+		desc:    "path matched exactly by wildcard name",
+		inPath:  "/a/b[k=x]/c",
+		inQuery: "/a/*/c",
+		want:    false,
+	}, {
+		desc:    "descendant of path",
+		inPath:  "/a/b[k=x]/c/d",
+		inQuery: "/a/b[k=x]/c",
+		want:    true,
+	}, {
+		desc:    "descendant of path matched by wildcard key",
+		inPath:  "/a/b[k=x]/c/d",
+		inQuery: "/a/b[k=*]/c",
+		want:    true,
+	}, {
+		desc:    "path not matched",
+		inPath:  "/a/b[k=x]/d/e",
+		inQuery: "/a/b[k=*]/c",
+		want:    false,
+	}, {
+		desc:    "ancestor of path",
+		inPath:  "/a/b[k=x]",
+		inQuery: "/a/b[k=*]/c",
+		want:    false,
+	}, {
+		desc:    "multi-level wildcard matching path exactly",
+		inPath:  "/a/b[k=x]/c",
+		inQuery: "/a/.../c",
+		want:    false,
+	}, {
+		desc:    "multi-level wildcard matching ancestor",
+		inPath:  "/a/b[k=x]/c/d",
+		inQuery: "/a/.../c",
+		want:    true,
+	}, {
+		desc:    "trailing multi-level wildcard",
+		inPath:  "/a/b",
+		inQuery: "/a/...",
+		want:    true,
+	}, {
+		desc:          "implied openconfig origin",
+		inPath:        "/a/b",
+		inQuery:       "/a",
+		inQueryOrigin: "openconfig",
+		want:          true,
+	}, {
+		desc:          "different origin",
+		inPath:        "/a/b",
+		inQuery:       "/a",
+		inQueryOrigin: "foo",
+		want:          false,
+	}}
+	for _, tt := range tests {
+		t.Run(tt.desc, func(t *testing.T) {
+			path, err := ygot.StringToStructuredPath(tt.inPath)
+			if err != nil {
+				t.Fatal(err)
+			}
+			query, err := ygot.StringToStructuredPath(tt.inQuery)
+			if err != nil {
+				t.Fatal(err)
+			}
+			query.Origin = tt.inQueryOrigin
+			if got := util.PathIsBeneathQuery(path, query); got != tt.want {
+				t.Errorf("PathIsBeneathQuery(%s, %s): got %v, want %v", tt.inPath, tt.inQuery, got, tt.want)
+			}
+		})
+	}
+}
+
 func TestTrimGNMIPathElemPrefix(t *testing.T) {
 	tests := []struct {
 		desc     string
@@ -0,0 +1,418 @@
+// Copyright 2023 Google Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package util
+
+import (
+	"sort"
+	"strings"
+
+	"google.golang.org/protobuf/proto"
+
+	gpb "github.com/openconfig/gnmi/proto/gnmi"
+)
+
+// PathTrie is a trie that stores values of type T against gNMI paths. Each
+// level of the trie corresponds to a PathElem, such that paths sharing a
+// prefix share the nodes for that prefix. Paths stored in the trie may contain
+// wildcard names or keys, and the multi-level wildcard "...", which allows
+// the set of stored queries that match a path to be found without comparing
+// the path against each query in turn.
+//
+// The unset and "openconfig" origins are treated as equivalent, as per
+// PathMatchesQuery. Paths must use the gNMI >=0.4.0 PathElem path format.
+//
+// The zero value of PathTrie is not usable; use NewPathTrie to create a
+// PathTrie.
+type PathTrie[T any] struct {
+	// roots is the root node of the trie for each origin.
+	roots map[string]*pathTrieNode[T]
+	// size is the number of values stored in the trie.
+	size int
+}
+
+// PathTrieEntry is a path and its associated value that is stored within a
+// PathTrie.
+type PathTrieEntry[T any] struct {
+	Path  *gpb.Path
+	Value T
+}
+
+// pathTrieNode is a node of a PathTrie.
+type pathTrieNode[T any] struct {
+	// children is the set of child nodes, keyed by the name of the
+	// PathElem, and then by the canonical string form of its keys.
+	children map[string]map[string]*pathTrieNode[T]
+	// elem is the PathElem that corresponds to the node, and is nil for
+	// the root node.
+	elem *gpb.PathElem
+	// path is the path that was inserted at the node, and is nil if no
+	// value is stored at the node.
+	path *gpb.Path
+	// val is the value that is stored at the node.
+	val T
+}
+
+// NewPathTrie returns a new, empty PathTrie.
+func NewPathTrie[T any]() *PathTrie[T] {
+	return &PathTrie[T]{roots: map[string]*pathTrieNode[T]{}}
+}
+
+// trieOrigin returns the origin under which a path is stored in a PathTrie.
+func trieOrigin(path *gpb.Path) string {
+	if o := path.GetOrigin(); o != "openconfig" {
+		return o
+	}
+	return ""
+}
+
+// trieKeyString returns a canonical string form of the keys of a PathElem.
+func trieKeyString(e *gpb.PathElem) string {
+	if len(e.GetKey()) == 0 {
+		return ""
+	}
+	keys := make([]string, 0, len(e.GetKey()))
+	for k := range e.GetKey() {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	var b strings.Builder
+	for _, k := range keys {
+		b.WriteString("[")
+		b.WriteString(k)
+		b.WriteString("=")
+		b.WriteString(e.GetKey()[k])
+		b.WriteString("]")
+	}
+	return b.String()
+}
+
+// child returns the child of n that corresponds to e, or nil if there is no
+// such child.
+func (n *pathTrieNode[T]) child(e *gpb.PathElem) *pathTrieNode[T] {
+	return n.children[e.GetName()][trieKeyString(e)]
+}
+
+// sortedChildren returns the children of n in a stable order.
+func (n *pathTrieNode[T]) sortedChildren() []*pathTrieNode[T] {
+	names := make([]string, 0, len(n.children))
+	for name := range n.children {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	var children []*pathTrieNode[T]
+	for _, name := range names {
+		keys := make([]string, 0, len(n.children[name]))
+		for k := range n.children[name] {
+			keys = append(keys, k)
+		}
+		sort.Strings(keys)
+		for _, k := range keys {
+			children = append(children, n.children[name][k])
+		}
+	}
+	return children
+}
+
+// find returns the node storing exactly path, or nil if no such node exists.
+func (t *PathTrie[T]) find(path *gpb.Path) *pathTrieNode[T] {
+	n := t.roots[trieOrigin(path)]
+	for _, e := range path.GetElem() {
+		if n == nil {
+			return nil
+		}
+		n = n.child(e)
+	}
+	return n
+}
+
+// Len returns the number of paths stored in the trie.
+func (t *PathTrie[T]) Len() int {
+	return t.size
+}
+
+// Insert stores val against path in the trie, replacing any value that was
+// previously stored against the same path. Paths are compared element-wise,
+// such that the order in which keys are specified does not matter.
+func (t *PathTrie[T]) Insert(path *gpb.Path, val T) {
+	origin := trieOrigin(path)
+	n, ok := t.roots[origin]
+	if !ok {
+		n = &pathTrieNode[T]{}
+		t.roots[origin] = n
+	}
+	for _, e := range path.GetElem() {
+		c := n.child(e)
+		if c == nil {
+			c = &pathTrieNode[T]{elem: proto.Clone(e).(*gpb.PathElem)}
+			if n.children == nil {
+				n.children = map[string]map[string]*pathTrieNode[T]{}
+			}
+			if n.children[e.GetName()] == nil {
+				n.children[e.GetName()] = map[string]*pathTrieNode[T]{}
+			}
+			n.children[e.GetName()][trieKeyString(e)] = c
+		}
+		n = c
+	}
+	if n.path == nil {
+		t.size++
+	}
+	n.path = proto.Clone(path).(*gpb.Path)
+	n.val = val
+}
+
+// Get returns the value stored against exactly path in the trie, and whether
+// such a value was found. Wildcards within path are not expanded.
+func (t *PathTrie[T]) Get(path *gpb.Path) (T, bool) {
+	n := t.find(path)
+	if n == nil || n.path == nil {
+		var zero T
+		return zero, false
+	}
+	return n.val, true
+}
+
+// Delete removes the value stored against exactly path from the trie, and
+// reports whether a value was removed. Paths stored beneath path are not
+// affected.
+func (t *PathTrie[T]) Delete(path *gpb.Path) bool {
+	origin := trieOrigin(path)
+	n := t.roots[origin]
+	if n == nil {
+		return false
+	}
+	// Record the nodes that were traversed such that nodes that are left
+	// without a value or children can be pruned.
+	nodes := []*pathTrieNode[T]{n}
+	for _, e := range path.GetElem() {
+		if n = n.child(e); n == nil {
+			return false
+		}
+		nodes = append(nodes, n)
+	}
+	if n.path == nil {
+		return false
+	}
+	var zero T
+	n.path, n.val = nil, zero
+	t.size--
+
+	for i := len(nodes) - 1; i > 0; i-- {
+		if nodes[i].path != nil || len(nodes[i].children) != 0 {
+			return true
+		}
+		e := path.GetElem()[i-1]
+		parent := nodes[i-1]
+		delete(parent.children[e.GetName()], trieKeyString(e))
+		if len(parent.children[e.GetName()]) == 0 {
+			delete(parent.children, e.GetName())
+		}
+	}
+	if len(nodes[0].children) == 0 && nodes[0].path == nil {
+		delete(t.roots, origin)
+	}
+	return true
+}
+
+// LongestPrefix returns the entry stored in the trie whose path is the
+// longest prefix of path, including path itself, and whether such an entry
+// was found. Elements are compared for equality, with wildcards within the
+// stored paths and path being treated as literal values.
+func (t *PathTrie[T]) LongestPrefix(path *gpb.Path) (PathTrieEntry[T], bool) {
+	var (
+		found PathTrieEntry[T]
+		ok    bool
+	)
+	n := t.roots[trieOrigin(path)]
+	for i := 0; n != nil; i++ {
+		if n.path != nil {
+			found, ok = PathTrieEntry[T]{Path: n.path, Value: n.val}, true
+		}
+		if i == len(path.GetElem()) {
+			break
+		}
+		n = n.child(path.GetElem()[i])
+	}
+	return found, ok
+}
+
+// Subtree returns the entries stored in the trie whose paths have prefix as
+// a prefix, including prefix itself. Elements are compared for equality, with
+// wildcards being treated as literal values. Entries are returned in a
+// stable order, with each entry preceding those beneath it.
+func (t *PathTrie[T]) Subtree(prefix *gpb.Path) []PathTrieEntry[T] {
+	var entries []PathTrieEntry[T]
+	if n := t.find(prefix); n != nil {
+		n.collect(map[*pathTrieNode[T]]bool{}, &entries)
+	}
+	return entries
+}
+
+// collect appends the entries stored at n, and all of its descendants, that
+// have not already been seen to entries.
+func (n *pathTrieNode[T]) collect(seen map[*pathTrieNode[T]]bool, entries *[]PathTrieEntry[T]) {
+	if seen[n] {
+		return
+	}
+	seen[n] = true
+	if n.path != nil {
+		*entries = append(*entries, PathTrieEntry[T]{Path: n.path, Value: n.val})
+	}
+	for _, c := range n.sortedChildren() {
+		c.collect(seen, entries)
+	}
+}
+
+// MatchingQueries returns the entries stored in the trie whose paths, when
+// treated as queries, match path, i.e., those entries whose path q satisfies
+// PathMatchesQuery(path, q). This allows the set of subscriptions which
+// match a particular update to be found. Entries are returned in a stable
+// order.
+func (t *PathTrie[T]) MatchingQueries(path *gpb.Path) []PathTrieEntry[T] {
+	n := t.roots[trieOrigin(path)]
+	if n == nil {
+		return nil
+	}
+	var entries []PathTrieEntry[T]
+	n.matchQueries(path.GetElem(), map[*pathTrieNode[T]]bool{}, &entries)
+	sortEntries(entries)
+	return entries
+}
+
+// matchQueries appends the entries stored at or beneath n whose remaining
+// elements are query elements that match a prefix of the path elements
+// elems.
+func (n *pathTrieNode[T]) matchQueries(elems []*gpb.PathElem, seen map[*pathTrieNode[T]]bool, entries *[]PathTrieEntry[T]) {
+	if n.path != nil && !seen[n] {
+		seen[n] = true
+		*entries = append(*entries, PathTrieEntry[T]{Path: n.path, Value: n.val})
+	}
+	// The multi-level wildcard may consume any number of the remaining
+	// elements, including none.
+	for _, c := range n.children[MultiLevelWildcard] {
+		for j := 0; j <= len(elems); j++ {
+			c.matchQueries(elems[j:], seen, entries)
+		}
+	}
+	if len(elems) == 0 || elems[0] == nil {
+		return
+	}
+	e := elems[0]
+	for _, name := range []string{e.GetName(), "*"} {
+		for _, c := range n.children[name] {
+			if keysMatchQuery(e, c.elem) {
+				c.matchQueries(elems[1:], seen, entries)
+			}
+		}
+		if e.GetName() == "*" {
+			break
+		}
+	}
+}
+
+// keysMatchQuery returns whether the keys of the path element e match those
+// of the query element q, which may contain wildcard values.
+func keysMatchQuery(e, q *gpb.PathElem) bool {
+	for qk, qv := range q.GetKey() {
+		if pv, ok := e.GetKey()[qk]; !ok || (qv != "*" && qv != pv) {
+			return false
+		}
+	}
+	return true
+}
+
+// MatchQuery returns the entries stored in the trie whose paths are matched
+// by query, i.e., those entries whose path p satisfies
+// PathMatchesQuery(p, query). query may contain wildcard names or keys, and
+// the multi-level wildcard "...". Entries are returned in a stable order.
+func (t *PathTrie[T]) MatchQuery(query *gpb.Path) []PathTrieEntry[T] {
+	n := t.roots[trieOrigin(query)]
+	if n == nil {
+		return nil
+	}
+	var entries []PathTrieEntry[T]
+	n.matchPaths(query.GetElem(), map[matchState[T]]bool{}, map[*pathTrieNode[T]]bool{}, &entries)
+	sortEntries(entries)
+	return entries
+}
+
+// matchState is a position reached while matching a query against the trie,
+// which is used to avoid repeated traversals when a query contains
+// multi-level wildcards.
+type matchState[T any] struct {
+	node  *pathTrieNode[T]
+	depth int
+}
+
+// matchPaths appends the entries stored at or beneath n whose remaining
+// elements are matched by the query elements query.
+func (n *pathTrieNode[T]) matchPaths(query []*gpb.PathElem, visited map[matchState[T]]bool, seen map[*pathTrieNode[T]]bool, entries *[]PathTrieEntry[T]) {
+	st := matchState[T]{node: n, depth: len(query)}
+	if visited[st] {
+		return
+	}
+	visited[st] = true
+	if len(query) == 0 {
+		// The query is a prefix of every path stored beneath n.
+		n.collect(seen, entries)
+		return
+	}
+	q := query[0]
+	if q == nil {
+		return
+	}
+	if q.GetName() == MultiLevelWildcard {
+		// Consume no elements, or consume the next element and retain
+		// the wildcard to allow it to consume more.
+		n.matchPaths(query[1:], visited, seen, entries)
+		for _, cs := range n.children {
+			for _, c := range cs {
+				c.matchPaths(query, visited, seen, entries)
+			}
+		}
+		return
+	}
+	for name, cs := range n.children {
+		if q.GetName() != "*" && q.GetName() != name {
+			continue
+		}
+		for _, c := range cs {
+			if keysMatchQuery(c.elem, q) {
+				c.matchPaths(query[1:], visited, seen, entries)
+			}
+		}
+	}
+}
+
+// sortEntries sorts entries into a stable order, such that each entry
+// precedes the entries that are beneath it.
+func sortEntries[T any](entries []PathTrieEntry[T]) {
+	sort.Slice(entries, func(i, j int) bool {
+		return trieSortKey(entries[i].Path) < trieSortKey(entries[j].Path)
+	})
+}
+
+// trieSortKey returns a string that is used to order the paths within a
+// PathTrie.
+func trieSortKey(path *gpb.Path) string {
+	var b strings.Builder
+	b.WriteString(trieOrigin(path))
+	for _, e := range path.GetElem() {
+		b.WriteString("/")
+		b.WriteString(e.GetName())
+		b.WriteString(trieKeyString(e))
+	}
+	return b.String()
+}
@@ -0,0 +1,397 @@
+// Copyright 2023 Google Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package util_test
+
+import (
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/openconfig/ygot/util"
+	"github.com/openconfig/ygot/ygot"
+
+	gpb "github.com/openconfig/gnmi/proto/gnmi"
+)
+
+// newTestTrie returns a PathTrie storing each of the supplied string paths
+// against its own string form.
+func newTestTrie(t *testing.T, paths ...string) *util.PathTrie[string] {
+	t.Helper()
+	trie := util.NewPathTrie[string]()
+	for _, p := range paths {
+		trie.Insert(mustStringToPath(t, p), p)
+	}
+	return trie
+}
+
+// entryValues returns the values of the supplied entries.
+func entryValues(entries []util.PathTrieEntry[string]) []string {
+	var vals []string
+	for _, e := range entries {
+		vals = append(vals, e.Value)
+	}
+	return vals
+}
+
+func TestPathTrieInsertGetDelete(t *testing.T) {
+	trie := newTestTrie(t,
+		"/interfaces/interface[name=eth0]/config/mtu",
+		"/interfaces/interface[name=eth0]",
+		"/network-instances/network-instance[name=DEFAULT]/protocols/protocol[identifier=BGP][name=15169]",
+	)
+	if got, want := trie.Len(), 3; got != want {
+		t.Fatalf("Len(): got %d, want %d", got, want)
+	}
+
+	// Keys in a different order refer to the same path.
+	p := &gpb.Path{Elem: []*gpb.PathElem{
+		{Name: "network-instances"},
+		{Name: "network-instance", Key: map[string]string{"name": "DEFAULT"}},
+		{Name: "protocols"},
+		{Name: "protocol", Key: map[string]string{"name": "15169", "identifier": "BGP"}},
+	}}
+	if _, ok := trie.Get(p); !ok {
+		t.Errorf("Get(%v): did not find path with reordered keys", p)
+	}
+	// The openconfig origin is equivalent to an unset origin.
+	ocPath := mustStringToPath(t, "/interfaces/interface[name=eth0]")
+	ocPath.Origin = "openconfig"
+	if got, ok := trie.Get(ocPath); !ok || got != "/interfaces/interface[name=eth0]" {
+		t.Errorf("Get(%v): got (%q, %v), want stored value", ocPath, got, ok)
+	}
+	if _, ok := trie.Get(mustStringToPath(t, "/interfaces")); ok {
+		t.Errorf("Get(/interfaces): found value for intermediate node")
+	}
+	if _, ok := trie.Get(mustStringToPath(t, "/interfaces/interface[name=*]")); ok {
+		t.Errorf("Get(/interfaces/interface[name=*]): wildcard was unexpectedly expanded")
+	}
+
+	trie.Insert(mustStringToPath(t, "/interfaces/interface[name=eth0]"), "replaced")
+	if got, want := trie.Len(), 3; got != want {
+		t.Errorf("Len() after replace: got %d, want %d", got, want)
+	}
+	if got, _ := trie.Get(mustStringToPath(t, "/interfaces/interface[name=eth0]")); got != "replaced" {
+		t.Errorf("Get() after replace: got %q, want %q", got, "replaced")
+	}
+
+	if trie.Delete(mustStringToPath(t, "/interfaces")) {
+		t.Errorf("Delete(/interfaces): got true for path with no value")
+	}
+	if !trie.Delete(mustStringToPath(t, "/interfaces/interface[name=eth0]")) {
+		t.Errorf("Delete(/interfaces/interface[name=eth0]): got false, want true")
+	}
+	if _, ok := trie.Get(mustStringToPath(t, "/interfaces/interface[name=eth0]/config/mtu")); !ok {
+		t.Errorf("Delete removed a descendant of the deleted path")
+	}
+	if !trie.Delete(mustStringToPath(t, "/interfaces/interface[name=eth0]/config/mtu")) {
+		t.Errorf("Delete(/interfaces/interface[name=eth0]/config/mtu): got false, want true")
+	}
+	if got := entryValues(trie.Subtree(mustStringToPath(t, "/interfaces"))); len(got) != 0 {
+		t.Errorf("Subtree(/interfaces) after deletion: got %v, want none", got)
+	}
+	if got, want := trie.Len(), 1; got != want {
+		t.Errorf("Len() after deletion: got %d, want %d", got, want)
+	}
+}
+
+func TestPathTrieLongestPrefix(t *testing.T) {
+	trie := newTestTrie(t,
+		"/",
+		"/interfaces",
+		"/interfaces/interface[name=eth0]/config",
+		"/interfaces/interface[name=*]/state",
+	)
+
+	tests := []struct {
+		desc      string
+		inPath    string
+		wantVal   string
+		wantFound bool
+	}{{
+		desc:      "exact match",
+		inPath:    "/interfaces/interface[name=eth0]/config",
+		wantVal:   "/interfaces/interface[name=eth0]/config",
+		wantFound: true,
+	}, {
+		desc:      "longest of several prefixes",
+		inPath:    "/interfaces/interface[name=eth0]/config/mtu",
+		wantVal:   "/interfaces/interface[name=eth0]/config",
+		wantFound: true,
+	}, {
+		desc:      "keys differ",
+		inPath:    "/interfaces/interface[name=eth1]/config/mtu",
+		wantVal:   "/interfaces",
+		wantFound: true,
+	}, {
+		desc:      "wildcards are literal",
+		inPath:    "/interfaces/interface[name=eth0]/state/mtu",
+		wantVal:   "/interfaces",
+		wantFound: true,
+	}, {
+		desc:      "root is a prefix of everything",
+		inPath:    "/system",
+		wantVal:   "/",
+		wantFound: true,
+	}}
+
+	for _, tt := range tests {
+		t.Run(tt.desc, func(t *testing.T) {
+			got, found := trie.LongestPrefix(mustStringToPath(t, tt.inPath))
+			if found != tt.wantFound {
+				t.Fatalf("LongestPrefix(%s): got found %v, want %v", tt.inPath, found, tt.wantFound)
+			}
+			if got.Value != tt.wantVal {
+				t.Errorf("LongestPrefix(%s): got %q, want %q", tt.inPath, got.Value, tt.wantVal)
+			}
+		})
+	}
+
+	if _, found := newTestTrie(t, "/a/b").LongestPrefix(mustStringToPath(t, "/a")); found {
+		t.Errorf("LongestPrefix(/a): got found for longer stored path")
+	}
+}
+
+func TestPathTrieSubtree(t *testing.T) {
+	trie := newTestTrie(t,
+		"/interfaces/interface[name=eth1]/config/mtu",
+		"/interfaces/interface[name=eth0]/config/mtu",
+		"/interfaces/interface[name=eth0]",
+		"/interfaces/interface[name=eth0]/config/description",
+		"/system/config/hostname",
+	)
+
+	tests := []struct {
+		desc     string
+		inPrefix string
+		want     []string
+	}{{
+		desc:     "entire tree",
+		inPrefix: "/",
+		want: []string{
+			"/interfaces/interface[name=eth0]",
+			"/interfaces/interface[name=eth0]/config/description",
+			"/interfaces/interface[name=eth0]/config/mtu",
+			"/interfaces/interface[name=eth1]/config/mtu",
+			"/system/config/hostname",
+		},
+	}, {
+		desc:     "keyed prefix",
+		inPrefix: "/interfaces/interface[name=eth0]",
+		want: []string{
+			"/interfaces/interface[name=eth0]",
+			"/interfaces/interface[name=eth0]/config/description",
+			"/interfaces/interface[name=eth0]/config/mtu",
+		},
+	}, {
+		desc:     "no such prefix",
+		inPrefix: "/interfaces/interface[name=eth2]",
+	}}
+
+	for _, tt := range tests {
+		t.Run(tt.desc, func(t *testing.T) {
+			got := entryValues(trie.Subtree(mustStringToPath(t, tt.inPrefix)))
+			if diff := cmp.Diff(tt.want, got); diff != "" {
+				t.Errorf("Subtree(%s): did not get expected entries, diff(-want, +got):\n%s", tt.inPrefix, diff)
+			}
+		})
+	}
+}
+
+func TestPathTrieMatchingQueries(t *testing.T) {
+	trie := newTestTrie(t,
+		"/",
+		"/interfaces",
+		"/interfaces/interface[name=eth0]",
+		"/interfaces/interface[name=*]/config",
+		"/interfaces/interface/state",
+		"/interfaces/*/config/mtu",
+		"/interfaces/.../mtu",
+		"/.../counters",
+		"/system/...",
+		"/network-instances/network-instance[name=DEFAULT]/protocols/protocol[identifier=BGP]",
+	)
+
+	tests := []struct {
+		desc   string
+		inPath string
+		want   []string
+	}{{
+		desc:   "keyed path",
+		inPath: "/interfaces/interface[name=eth0]/config/mtu",
+		want: []string{
+			"/",
+			"/interfaces",
+			"/interfaces/*/config/mtu",
+			"/interfaces/.../mtu",
+			"/interfaces/interface[name=*]/config",
+			"/interfaces/interface[name=eth0]",
+		},
+	}, {
+		desc:   "different key",
+		inPath: "/interfaces/interface[name=eth1]/config/description",
+		want: []string{
+			"/",
+			"/interfaces",
+			"/interfaces/interface[name=*]/config",
+		},
+	}, {
+		desc:   "query without keys matches any key",
+		inPath: "/interfaces/interface[name=eth1]/state/counters/in-pkts",
+		want: []string{
+			"/",
+			"/.../counters",
+			"/interfaces",
+			"/interfaces/interface/state",
+		},
+	}, {
+		desc:   "multi-level wildcard matching no elements",
+		inPath: "/system",
+		want:   []string{"/", "/system/..."},
+	}, {
+		desc:   "query with subset of keys",
+		inPath: "/network-instances/network-instance[name=DEFAULT]/protocols/protocol[identifier=BGP][name=15169]/config",
+		want: []string{
+			"/",
+			"/network-instances/network-instance[name=DEFAULT]/protocols/protocol[identifier=BGP]",
+		},
+	}, {
+		desc:   "query has key missing from path",
+		inPath: "/interfaces/interface/config",
+		want:   []string{"/", "/interfaces"},
+	}}
+
+	for _, tt := range tests {
+		t.Run(tt.desc, func(t *testing.T) {
+			path := mustStringToPath(t, tt.inPath)
+			got := entryValues(trie.MatchingQueries(path))
+			if diff := cmp.Diff(tt.want, got); diff != "" {
+				t.Errorf("MatchingQueries(%s): did not get expected entries, diff(-want, +got):\n%s", tt.inPath, diff)
+			}
+			for _, e := range trie.MatchingQueries(path) {
+				if !util.PathMatchesQuery(path, e.Path) {
+					t.Errorf("MatchingQueries(%s): returned %s, which does not match", tt.inPath, e.Value)
+				}
+			}
+		})
+	}
+
+	ocPath := mustStringToPath(t, "/system")
+	ocPath.Origin = "openconfig"
+	if got, want := len(trie.MatchingQueries(ocPath)), 2; got != want {
+		t.Errorf("MatchingQueries(openconfig:/system): got %d entries, want %d", got, want)
+	}
+	ocPath.Origin = "other"
+	if got := trie.MatchingQueries(ocPath); len(got) != 0 {
+		t.Errorf("MatchingQueries(other:/system): got %v, want none", entryValues(got))
+	}
+}
+
+func TestPathTrieMatchQuery(t *testing.T) {
+	paths := []string{
+		"/interfaces/interface[name=eth0]/config/mtu",
+		"/interfaces/interface[name=eth0]/state/mtu",
+		"/interfaces/interface[name=eth1]/config/mtu",
+		"/interfaces/interface[name=eth1]/config/description",
+		"/interfaces/interface[name=eth1]",
+		"/network-instances/network-instance[name=DEFAULT]/protocols/protocol[identifier=BGP][name=15169]/config/name",
+		"/system/config/hostname",
+	}
+	trie := newTestTrie(t, paths...)
+
+	tests := []struct {
+		desc    string
+		inQuery string
+		want    []string
+	}{{
+		desc:    "concrete query",
+		inQuery: "/interfaces/interface[name=eth1]/config",
+		want: []string{
+			"/interfaces/interface[name=eth1]/config/description",
+			"/interfaces/interface[name=eth1]/config/mtu",
+		},
+	}, {
+		desc:    "wildcard key",
+		inQuery: "/interfaces/interface[name=*]/config/mtu",
+		want: []string{
+			"/interfaces/interface[name=eth0]/config/mtu",
+			"/interfaces/interface[name=eth1]/config/mtu",
+		},
+	}, {
+		desc:    "wildcard name",
+		inQuery: "/interfaces/interface/*/mtu",
+		want: []string{
+			"/interfaces/interface[name=eth0]/config/mtu",
+			"/interfaces/interface[name=eth0]/state/mtu",
+			"/interfaces/interface[name=eth1]/config/mtu",
+		},
+	}, {
+		desc:    "multi-level wildcard",
+		inQuery: "/.../mtu",
+		want: []string{
+			"/interfaces/interface[name=eth0]/config/mtu",
+			"/interfaces/interface[name=eth0]/state/mtu",
+			"/interfaces/interface[name=eth1]/config/mtu",
+		},
+	}, {
+		desc:    "subset of keys",
+		inQuery: "/network-instances/network-instance/protocols/protocol[identifier=BGP]/.../name",
+		want: []string{
+			"/network-instances/network-instance[name=DEFAULT]/protocols/protocol[identifier=BGP][name=15169]/config/name",
+		},
+	}, {
+		desc:    "no match",
+		inQuery: "/interfaces/interface[name=eth2]",
+	}}
+
+	for _, tt := range tests {
+		t.Run(tt.desc, func(t *testing.T) {
+			query := mustStringToPath(t, tt.inQuery)
+			got := entryValues(trie.MatchQuery(query))
+			if diff := cmp.Diff(tt.want, got); diff != "" {
+				t.Errorf("MatchQuery(%s): did not get expected entries, diff(-want, +got):\n%s", tt.inQuery, diff)
+			}
+
+			// Check against a pairwise comparison of each stored path.
+			var want []string
+			for _, p := range paths {
+				if util.PathMatchesQuery(mustStringToPath(t, p), query) {
+					want = append(want, p)
+				}
+			}
+			if diff := cmp.Diff(want, got, cmp.Transformer("sort", func(in []string) map[string]bool {
+				m := map[string]bool{}
+				for _, s := range in {
+					m[s] = true
+				}
+				return m
+			})); diff != "" {
+				t.Errorf("MatchQuery(%s): result differs from PathMatchesQuery, diff(-want, +got):\n%s", tt.inQuery, diff)
+			}
+		})
+	}
+}
+
+// Ensure that ygot's path strings round-trip through the trie unchanged.
+func TestPathTrieStoresPath(t *testing.T) {
+	trie := newTestTrie(t, "/interfaces/interface[name=eth0]/config/mtu")
+	for _, e := range trie.Subtree(&gpb.Path{}) {
+		s, err := ygot.PathToString(e.Path)
+		if err != nil {
+			t.Fatalf("PathToString(%v): %v", e.Path, err)
+		}
+		if s != e.Value {
+			t.Errorf("stored path: got %s, want %s", s, e.Value)
+		}
+	}
+}
@@ -631,7 +631,6 @@ const (
 // in, out are passed through from the caller to the iteration visitor function
 // and can be used to pass state in and out. They are not otherwise touched.
 // It returns what next iteration action to take as well as an error.
-// TODO Deprecate the ForEachField and ForEachDataField functions in favor of Walk.
 type FieldIteratorFunc2 func(ni *NodeInfo, in, out any) (IterationAction, Errors)
 
 // ForEachField recursively iterates through the fields of value (which may be
@@ -649,7 +648,7 @@ type FieldIteratorFunc2 func(ni *NodeInfo, in, out any) (IterationAction, Errors
 //
 // It returns a slice of errors encountered while processing the struct.
 //
-// See util.Walk if more dynamic control of the traversal is needed.
+// Deprecated: Use util.Walk with the WithSchema option instead.
 func ForEachField(schema *yang.Entry, value any, in, out any, iterFunction FieldIteratorFunc) Errors {
 	if IsValueNil(value) {
 		return nil
@@ -736,7 +735,7 @@ func (vf forEachFieldMemoVisitor) Visit(node WalkNode) Visitor {
 // without inspection by this function, and can be used by the caller to store
 // input and output during the iteration through the data tree.
 //
-// Deprecated: Use ForEachDataField2 or util.Walk instead.
+// Deprecated: Use util.Walk instead.
 func ForEachDataField(value, in, out any, iterFunction FieldIteratorFunc) Errors {
 	if IsValueNil(value) {
 		return nil
@@ -15,9 +15,11 @@
 package util
 
 import (
+	"context"
 	"fmt"
 	"reflect"
 	"strings"
+	"sync"
 	"testing"
 
 	"github.com/google/go-cmp/cmp"
@@ -1298,6 +1300,182 @@ func TestWalk(t *testing.T) {
 	}
 }
 
+// testPostWalkVisitor records the order in which nodes are entered and left,
+// along with the number of descendants of each struct pointer node, which is
+// aggregated bottom-up.
+type testPostWalkVisitor struct {
+	events []string
+	// count is the number of nodes visited beneath each node.
+	count map[WalkNode]int
+	stack []WalkNode
+}
+
+func (v *testPostWalkVisitor) Visit(node WalkNode) Visitor {
+	if node == nil {
+		return nil
+	}
+	for _, n := range v.stack {
+		v.count[n]++
+	}
+	v.stack = append(v.stack, node)
+	v.events = append(v.events, "enter "+node.NodeInfo().FieldValue.Type().String())
+	return v
+}
+
+func (v *testPostWalkVisitor) PostVisit(node WalkNode) {
+	v.stack = v.stack[:len(v.stack)-1]
+	v.events = append(v.events, "leave "+node.NodeInfo().FieldValue.Type().String())
+}
+
+func TestWalkPostVisit(t *testing.T) {
+	v := &testPostWalkVisitor{count: map[WalkNode]int{}}
+	root := WalkNodeFromGoStruct(&StructOfStructs{BasicStructPtrField: &basicStruct1})
+	if errs := Walk(v, root, nil).(*DefaultWalkErrors).Errors; errs != nil {
+		t.Fatalf("Walk: got unexpected errors: %v", errs)
+	}
+	want := []string{
+		"enter *util.StructOfStructs",
+		"enter util.BasicStruct",
+		"enter int32",
+		"leave int32",
+		"enter string",
+		"leave string",
+		"leave util.BasicStruct",
+		"enter *util.BasicStruct",
+		"enter int32",
+		"leave int32",
+		"enter string",
+		"leave string",
+		"enter *int32",
+		"leave *int32",
+		"enter *string",
+		"leave *string",
+		"leave *util.BasicStruct",
+		"leave *util.StructOfStructs",
+	}
+	if diff := cmp.Diff(want, v.events); diff != "" {
+		t.Errorf("Walk: did not get expected visit order, diff(-want, +got):\n%s", diff)
+	}
+	if got, want := v.count[root], 8; got != want {
+		t.Errorf("Walk: got %d descendants of root, want %d", got, want)
+	}
+}
+
+// countWalkVisitor counts the visited nodes, and is safe for concurrent use.
+type countWalkVisitor struct {
+	mu    sync.Mutex
+	count int
+	// cancel, if set, is called once the count reaches cancelAt.
+	cancel   func()
+	cancelAt int
+}
+
+func (v *countWalkVisitor) Visit(node WalkNode) Visitor {
+	if node == nil {
+		return nil
+	}
+	v.mu.Lock()
+	defer v.mu.Unlock()
+	v.count++
+	if v.cancel != nil && v.count == v.cancelAt {
+		v.cancel()
+	}
+	return v
+}
+
+func TestWalkContext(t *testing.T) {
+	in := &StructOfMapOfStructs{BasicStructPtrMapField: map[string]*BasicStruct{}}
+	for i := 0; i < 10; i++ {
+		in.BasicStructPtrMapField[fmt.Sprint(i)] = &BasicStruct{Int32Field: int32(i)}
+	}
+
+	t.Run("cancelled before walk", func(t *testing.T) {
+		ctx, cancel := context.WithCancel(context.Background())
+		cancel()
+		v := &countWalkVisitor{}
+		errs := Walk(v, WalkNodeFromGoStruct(in), DefaultWalkOptions().WithContext(ctx)).(*DefaultWalkErrors).Errors
+		if diff := cmp.Diff(Errors{context.Canceled}.String(), errs.String()); diff != "" {
+			t.Errorf("Walk: did not get expected errors, diff(-want, +got):\n%s", diff)
+		}
+		if v.count != 0 {
+			t.Errorf("Walk: got %d visited nodes, want 0", v.count)
+		}
+	})
+
+	t.Run("cancelled during walk", func(t *testing.T) {
+		ctx, cancel := context.WithCancel(context.Background())
+		defer cancel()
+		v := &countWalkVisitor{cancel: cancel, cancelAt: 5}
+		errs := Walk(v, WalkNodeFromGoStruct(in), DefaultWalkOptions().WithContext(ctx)).(*DefaultWalkErrors).Errors
+		if diff := cmp.Diff(Errors{context.Canceled}.String(), errs.String()); diff != "" {
+			t.Errorf("Walk: did not get expected errors, diff(-want, +got):\n%s", diff)
+		}
+		if v.count != 5 {
+			t.Errorf("Walk: got %d visited nodes, want 5", v.count)
+		}
+	})
+
+	t.Run("not cancelled", func(t *testing.T) {
+		v := &countWalkVisitor{}
+		if errs := Walk(v, WalkNodeFromGoStruct(in), DefaultWalkOptions().WithContext(context.Background())).(*DefaultWalkErrors).Errors; errs != nil {
+			t.Errorf("Walk: got unexpected errors: %v", errs)
+		}
+		// The root, the map field, and the element with its two non-nil
+		// leaves for each of the list elements.
+		if got, want := v.count, 32; got != want {
+			t.Errorf("Walk: got %d visited nodes, want %d", got, want)
+		}
+	})
+}
+
+func TestWalkConcurrency(t *testing.T) {
+	in := &StructOfMapOfStructs{
+		BasicStructMapField:    map[string]BasicStruct{},
+		BasicStructPtrMapField: map[string]*BasicStruct{},
+	}
+	for i := 0; i < 100; i++ {
+		in.BasicStructMapField[fmt.Sprint(i)] = BasicStruct{Int32Field: int32(i)}
+		in.BasicStructPtrMapField[fmt.Sprint(i)] = &BasicStruct{Int32Field: int32(i)}
+	}
+	schema := &yang.Entry{
+		Name: "struct-of-map-of-structs",
+		Kind: yang.DirectoryEntry,
+		Dir: map[string]*yang.Entry{
+			"basic-struct": {
+				Name:     "basic-struct",
+				Kind:     yang.DirectoryEntry,
+				ListAttr: &yang.ListAttr{},
+				Dir: map[string]*yang.Entry{
+					"int32":     {Name: "int32", Kind: yang.LeafEntry, Type: &yang.YangType{Kind: yang.Yint32}},
+					"string":    {Name: "string", Kind: yang.LeafEntry, Type: &yang.YangType{Kind: yang.Ystring}},
+					"int32ptr":  {Name: "int32ptr", Kind: yang.LeafEntry, Type: &yang.YangType{Kind: yang.Yint32}},
+					"stringptr": {Name: "stringptr", Kind: yang.LeafEntry, Type: &yang.YangType{Kind: yang.Ystring}},
+				},
+			},
+		},
+	}
+
+	for _, withSchema := range []bool{false, true} {
+		t.Run(fmt.Sprintf("schema %v", withSchema), func(t *testing.T) {
+			seqOpts, concOpts := DefaultWalkOptions(), DefaultWalkOptions().WithConcurrency(4)
+			if withSchema {
+				seqOpts, concOpts = seqOpts.WithSchema(schema), concOpts.WithSchema(schema)
+			}
+			seq := &countWalkVisitor{}
+			if errs := Walk(seq, WalkNodeFromGoStruct(in), seqOpts).(*DefaultWalkErrors).Errors; errs != nil {
+				t.Fatalf("sequential Walk: got unexpected errors: %v", errs)
+			}
+			conc := &countWalkVisitor{}
+			if errs := Walk(conc, WalkNodeFromGoStruct(in), concOpts).(*DefaultWalkErrors).Errors; errs != nil {
+				t.Fatalf("concurrent Walk: got unexpected errors: %v", errs)
+			}
+			if seq.count == 0 || seq.count != conc.count {
+				t.Errorf("concurrent Walk: got %d visited nodes, want %d", conc.count, seq.count)
+			}
+		})
+	}
+}
+
 func TestForEachDataField(t *testing.T) {
 	tests := []struct {
 		desc         string
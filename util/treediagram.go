@@ -0,0 +1,329 @@
+// Copyright 2023 Google Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package util
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/openconfig/goyang/pkg/yang"
+)
+
+// TreeNode is a node of an RFC 8340 tree diagram, which renders a schema
+// node. The children of a node need not be those of its schema node, such
+// that a tree diagram can be rendered for a compressed schema.
+type TreeNode struct {
+	// Entry is the schema node that is rendered.
+	Entry *yang.Entry
+	// Annotation is text that is appended to the line of the node as a
+	// comment. It is omitted if empty.
+	Annotation string
+	// Children are the nodes that are rendered beneath the node.
+	Children []*TreeNode
+}
+
+// TreeDiagramModule is the section of an RFC 8340 tree diagram that renders
+// the nodes of a module.
+type TreeDiagramModule struct {
+	// Module is the module whose nodes are rendered.
+	Module *yang.Entry
+	// Data are the top-level data nodes of the module.
+	Data []*TreeNode
+	// RPCs are the rpcs of the module.
+	RPCs []*TreeNode
+	// Notifications are the top-level notifications of the module.
+	Notifications []*TreeNode
+}
+
+// EntryTreeNode returns the tree diagram node of e, whose descendants are
+// those of e within the schema. The input and output of an rpc or action are
+// its children.
+func EntryTreeNode(e *yang.Entry) *TreeNode {
+	n := &TreeNode{Entry: e}
+	if e.RPC != nil {
+		for _, io := range []*yang.Entry{e.RPC.Input, e.RPC.Output} {
+			if io != nil {
+				n.Children = append(n.Children, EntryTreeNode(io))
+			}
+		}
+		return n
+	}
+	for _, ch := range e.Dir {
+		n.Children = append(n.Children, EntryTreeNode(ch))
+	}
+	return n
+}
+
+// TreeDiagram returns the RFC 8340 tree diagram of the uncompressed schema of
+// the modules supplied, in the format output by "pyang -f tree". Nodes that
+// are augmented into a module are rendered within it, prefixed with the
+// prefix of their own module.
+func TreeDiagram(modules []*yang.Entry) string {
+	var sections []*TreeDiagramModule
+	for _, m := range modules {
+		s := &TreeDiagramModule{Module: m}
+		for _, ch := range m.Dir {
+			switch n := EntryTreeNode(ch); {
+			case ch.RPC != nil:
+				s.RPCs = append(s.RPCs, n)
+			case ch.Kind == yang.NotificationEntry:
+				s.Notifications = append(s.Notifications, n)
+			default:
+				s.Data = append(s.Data, n)
+			}
+		}
+		sections = append(sections, s)
+	}
+	return RenderTreeDiagram(sections)
+}
+
+// RenderTreeDiagram returns the RFC 8340 tree diagram containing the sections
+// supplied. Sections are rendered in the lexicographical order of the names
+// of their modules, and sections which contain no nodes are omitted. The
+// children of each node are rendered with the keys of a list first, in the
+// order of the key statement, followed by the remaining data nodes and then
+// the operations, each in lexicographical order.
+func RenderTreeDiagram(sections []*TreeDiagramModule) string {
+	sections = append([]*TreeDiagramModule{}, sections...)
+	sort.SliceStable(sections, func(i, j int) bool {
+		return sections[i].Module.Name < sections[j].Module.Name
+	})
+
+	var b strings.Builder
+	for _, s := range sections {
+		if len(s.Data) == 0 && len(s.RPCs) == 0 && len(s.Notifications) == 0 {
+			continue
+		}
+		if b.Len() != 0 {
+			b.WriteString("\n")
+		}
+		fmt.Fprintf(&b, "module: %s\n", s.Module.Name)
+		prefix := modulePrefix(s.Module)
+		writeTreeNodes(&b, prefix, nil, s.Data, "  ")
+		if len(s.RPCs) != 0 {
+			b.WriteString("\n  rpcs:\n")
+			writeTreeNodes(&b, prefix, nil, s.RPCs, "    ")
+		}
+		if len(s.Notifications) != 0 {
+			b.WriteString("\n  notifications:\n")
+			writeTreeNodes(&b, prefix, nil, s.Notifications, "    ")
+		}
+	}
+	return b.String()
+}
+
+// writeTreeNodes writes the lines of the sibling nodes supplied, and their
+// descendants, to b. Each line is preceded by indent, and node names which
+// are not in the module with the prefix modPrefix are prefixed with the
+// prefix of their module. parent is the node whose children are being
+// written, which is nil for the top-level nodes of a section. The types of
+// leaves, and the annotations of all nodes, are aligned amongst siblings.
+func writeTreeNodes(b *strings.Builder, modPrefix string, parent *TreeNode, nodes []*TreeNode, indent string) {
+	keys := map[string]int{}
+	if parent != nil && parent.Entry.IsList() {
+		for i, k := range strings.Fields(parent.Entry.Key) {
+			keys[k] = i
+		}
+	}
+	nodes = sortedTreeNodes(nodes, keys)
+
+	labels := make([]string, len(nodes))
+	typeCol := 0
+	for i, n := range nodes {
+		_, isKey := keys[n.Entry.Name]
+		labels[i] = treeLabel(n.Entry, modPrefix, isKey)
+		if treeType(n.Entry) != "" && len(labels[i]) > typeCol {
+			typeCol = len(labels[i])
+		}
+	}
+
+	lines := make([]string, len(nodes))
+	annotationCol := 0
+	for i, n := range nodes {
+		line := labels[i]
+		if t := treeType(n.Entry); t != "" {
+			line += strings.Repeat(" ", typeCol-len(line)) + "   " + t
+		}
+		if f := treeFeatures(n.Entry); f != "" {
+			line += " " + f
+		}
+		lines[i] = line
+		if n.Annotation != "" && len(line) > annotationCol {
+			annotationCol = len(line)
+		}
+	}
+
+	for i, n := range nodes {
+		line := lines[i]
+		if n.Annotation != "" {
+			line += strings.Repeat(" ", annotationCol-len(line)) + "  # " + n.Annotation
+		}
+		fmt.Fprintf(b, "%s%s\n", indent, line)
+
+		childIndent := indent + "   "
+		if i != len(nodes)-1 {
+			childIndent = indent + "|  "
+		}
+		writeTreeNodes(b, modPrefix, n, n.Children, childIndent)
+	}
+}
+
+// sortedTreeNodes returns the nodes supplied in the order in which they are
+// rendered. keys maps the names of the keys of the parent list, if there is
+// one, to their index within its key statement.
+func sortedTreeNodes(nodes []*TreeNode, keys map[string]int) []*TreeNode {
+	nodes = append([]*TreeNode{}, nodes...)
+	rank := func(n *TreeNode) int {
+		switch {
+		case IsOperation(n.Entry):
+			return len(keys) + 1
+		case n.Entry.IsLeaf():
+			if i, ok := keys[n.Entry.Name]; ok {
+				return i
+			}
+		}
+		return len(keys)
+	}
+	sort.SliceStable(nodes, func(i, j int) bool {
+		ri, rj := rank(nodes[i]), rank(nodes[j])
+		switch {
+		case ri != rj:
+			return ri < rj
+		case nodes[i].Entry.Name != nodes[j].Entry.Name:
+			return nodes[i].Entry.Name < nodes[j].Entry.Name
+		default:
+			return modulePrefix(nodes[i].Entry) < modulePrefix(nodes[j].Entry)
+		}
+	})
+	return nodes
+}
+
+// treeLabel returns the status, flags, name and options of the node e, as
+// they are rendered within a tree diagram. isKey specifies whether e is a
+// key of its parent list.
+func treeLabel(e *yang.Entry, modPrefix string, isKey bool) string {
+	name := e.Name
+	if p := modulePrefix(e); p != "" && p != modPrefix && e.Kind != yang.InputEntry && e.Kind != yang.OutputEntry {
+		name = fmt.Sprintf("%s:%s", p, name)
+	}
+	if e.IsCase() {
+		return fmt.Sprintf("%s--:(%s)", treeStatus(e), name)
+	}
+
+	var opts string
+	switch {
+	case e.IsChoice():
+		name = fmt.Sprintf("(%s)", name)
+		if e.Mandatory != yang.TSTrue {
+			opts = "?"
+		}
+	case e.IsList():
+		opts = "*"
+		if e.Key != "" {
+			opts += fmt.Sprintf(" [%s]", e.Key)
+		}
+	case e.IsLeafList():
+		opts = "*"
+	case e.IsLeaf(), IsAnydataOrAnyxml(e):
+		if !isKey && e.Mandatory != yang.TSTrue {
+			opts = "?"
+		}
+	case e.IsContainer():
+		if len(e.Extra["presence"]) != 0 {
+			opts = "!"
+		}
+	}
+	return fmt.Sprintf("%s--%s %s%s", treeStatus(e), treeFlags(e), name, opts)
+}
+
+// treeStatus returns the symbol that represents the status of the node e.
+func treeStatus(e *yang.Entry) string {
+	for _, v := range e.Extra["status"] {
+		if s, ok := v.(*yang.Value); ok {
+			switch s.Name {
+			case "deprecated":
+				return "x"
+			case "obsolete":
+				return "o"
+			}
+		}
+	}
+	return "+"
+}
+
+// treeFlags returns the flags of the node e, which specify whether it is
+// configuration, state, an operation, or the input or output of one.
+func treeFlags(e *yang.Entry) string {
+	switch {
+	case e.RPC != nil:
+		return "-x"
+	case e.Kind == yang.NotificationEntry:
+		return "-n"
+	}
+	for p := e; p != nil; p = p.Parent {
+		switch p.Kind {
+		case yang.InputEntry:
+			return "-w"
+		case yang.OutputEntry, yang.NotificationEntry:
+			return "ro"
+		}
+	}
+	if e.ReadOnly() {
+		return "ro"
+	}
+	return "rw"
+}
+
+// treeType returns the type of the node e as it is rendered within a tree
+// diagram. It is empty for nodes other than leaves, leaf-lists, anydata and
+// anyxml.
+func treeType(e *yang.Entry) string {
+	switch {
+	case e.Kind == yang.AnyDataEntry:
+		return "<anydata>"
+	case e.Kind == yang.AnyXMLEntry:
+		return "<anyxml>"
+	case !e.IsLeaf() && !e.IsLeafList(), e.Type == nil:
+		return ""
+	case e.Type.Kind == yang.Yleafref:
+		return "-> " + e.Type.Path
+	default:
+		return e.Type.Name
+	}
+}
+
+// treeFeatures returns the if-feature statements of the node e, in the form
+// {feature1,feature2}?, or an empty string if there are none.
+func treeFeatures(e *yang.Entry) string {
+	var features []string
+	for _, v := range e.Extra["if-feature"] {
+		if f, ok := v.(*yang.Value); ok {
+			features = append(features, f.Name)
+		}
+	}
+	if len(features) == 0 {
+		return ""
+	}
+	return fmt.Sprintf("{%s}?", strings.Join(features, ","))
+}
+
+// modulePrefix returns the prefix of the module of the node e.
+func modulePrefix(e *yang.Entry) string {
+	if e.Prefix == nil {
+		return ""
+	}
+	return e.Prefix.Name
+}
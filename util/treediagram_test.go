@@ -0,0 +1,176 @@
+// Copyright 2023 Google Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package util
+
+import (
+	"testing"
+
+	"github.com/openconfig/goyang/pkg/yang"
+	"github.com/openconfig/ygot/testutil"
+)
+
+func TestTreeDiagram(t *testing.T) {
+	tests := []struct {
+		name      string
+		inModules map[string]string
+		inRender  []string
+		want      string
+	}{{
+		name: "data nodes",
+		inModules: map[string]string{
+			"test.yang": `
+				module test {
+					prefix "t";
+					namespace "urn:t";
+
+					feature f;
+					feature g;
+
+					container c {
+						presence "enabled";
+						leaf-list ll { type int8; }
+						anydata ad;
+						leaf mandatory-leaf { type string; mandatory true; }
+						leaf old { type string; status deprecated; }
+						leaf gone { type string; status obsolete; }
+						leaf feat { type string; if-feature "f"; if-feature "g"; }
+						leaf state { type string; config false; }
+					}
+
+					list l {
+						key "b a";
+						leaf a { type string; }
+						leaf b { type uint32; }
+						leaf ref { type leafref { path "../a"; } }
+						choice ch {
+							mandatory true;
+							case one {
+								leaf x { type string; }
+							}
+							leaf y { type string; }
+						}
+					}
+				}`,
+		},
+		inRender: []string{"test"},
+		want: `module: test
+  +--rw c!
+  |  +--rw ad?              <anydata>
+  |  +--rw feat?            string {f,g}?
+  |  o--rw gone?            string
+  |  +--rw ll*              int8
+  |  +--rw mandatory-leaf   string
+  |  x--rw old?             string
+  |  +--ro state?           string
+  +--rw l* [b a]
+     +--rw b      uint32
+     +--rw a      string
+     +--rw (ch)
+     |  +--:(one)
+     |  |  +--rw x?   string
+     |  +--:(y)
+     |     +--rw y?   string
+     +--rw ref?   -> ../a
+`,
+	}, {
+		name: "operations and augments",
+		inModules: map[string]string{
+			"test.yang": `
+				module test {
+					prefix "t";
+					namespace "urn:t";
+
+					container c {
+						action reset {
+							input {
+								leaf delay { type uint32; }
+							}
+						}
+					}
+
+					rpc ping {
+						input {
+							leaf destination { type string; }
+						}
+						output {
+							leaf rtt { type uint64; }
+						}
+					}
+
+					notification event {
+						leaf reason { type string; }
+					}
+				}`,
+			"aug.yang": `
+				module aug {
+					prefix "a";
+					namespace "urn:a";
+
+					import test { prefix t; }
+
+					augment "/t:c" {
+						leaf extra { type string; }
+					}
+				}`,
+		},
+		inRender: []string{"test", "aug"},
+		want: `module: test
+  +--rw c
+     +--rw a:extra?   string
+     +---x reset
+        +---w input
+           +---w delay?   uint32
+
+  rpcs:
+    +---x ping
+       +---w input
+       |  +---w destination?   string
+       +--ro output
+          +--ro rtt?   uint64
+
+  notifications:
+    +---n event
+       +--ro reason?   string
+`,
+	}}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ms := yang.NewModules()
+			for n, m := range tt.inModules {
+				if err := ms.Parse(m, n); err != nil {
+					t.Fatalf("cannot parse module %s: %v", n, err)
+				}
+			}
+			if errs := ms.Process(); errs != nil {
+				t.Fatalf("cannot process modules: %v", errs)
+			}
+
+			var modules []*yang.Entry
+			for _, n := range tt.inRender {
+				m, err := ms.GetModule(n)
+				if err != nil {
+					t.Fatalf("cannot find module %s: %v", n, err)
+				}
+				modules = append(modules, m)
+			}
+
+			if got := TreeDiagram(modules); got != tt.want {
+				diff, _ := testutil.GenerateUnifiedDiff(tt.want, got)
+				t.Errorf("TreeDiagram: did not get expected tree diagram, diff(-want, +got):\n%s", diff)
+			}
+		})
+	}
+}
@@ -16,6 +16,7 @@ package util
 
 import (
 	"fmt"
+	"reflect"
 
 	"github.com/openconfig/goyang/pkg/yang"
 )
@@ -45,3 +46,25 @@ type goOrderedMap interface {
 	// Len returns the size of the ordered list.
 	Len() int
 }
+
+// anyData is a convenience interface for ygot.AnyData. It is here to avoid a
+// circular dependency.
+type anyData interface {
+	// IsYANGAnyData is a marker method that indicates that the struct
+	// stores the contents of an anydata or anyxml node.
+	IsYANGAnyData()
+}
+
+// anyDataType is the reflect.Type of the anyData interface.
+var anyDataType = reflect.TypeOf((*anyData)(nil)).Elem()
+
+// goChoiceCase is a convenience interface for ygot.GoChoiceCase. It is here
+// to avoid a circular dependency.
+type goChoiceCase interface {
+	// IsYANGChoiceCase is a marker method that indicates that the struct
+	// represents a case of a YANG choice.
+	IsYANGChoiceCase()
+}
+
+// goChoiceCaseType is the reflect.Type of the goChoiceCase interface.
+var goChoiceCaseType = reflect.TypeOf((*goChoiceCase)(nil)).Elem()
@@ -1,8 +1,10 @@
 package util
 
 import (
+	"context"
 	"fmt"
 	"reflect"
+	"sync"
 
 	log "github.com/golang/glog"
 	"github.com/openconfig/goyang/pkg/yang"
@@ -24,6 +26,15 @@ type Visitor interface {
 	Visit(node WalkNode) (w Visitor)
 }
 
+// PostVisitor is a Visitor that is additionally notified when Walk leaves a
+// node. PostVisit is called on the visitor whose Visit method was called for
+// the node, once all of the node's descendants have been walked, allowing
+// results to be aggregated from the bottom of the tree upwards.
+type PostVisitor interface {
+	Visitor
+	PostVisit(node WalkNode)
+}
+
 // Walk traverses the nodes with a customized visitor.
 //
 // It traverses a GoStruct in depth-first order: It starts by calling
@@ -36,10 +47,19 @@ type Visitor interface {
 // If a customized schema is provided via WithSchema WalkOptions,
 // then the traversal will visit the schema entry even if the GoStruct does not populate it.
 //
+// If the visitor implements PostVisitor, then its PostVisit method is called
+// for each node that it visited once the node's children have been walked.
+//
 // The Visitor should handle its own error reporting and early termination.
 // Any error during the traversal that is not part of Visitor will be aggregated into the
 // returned WalkErrors.
 // If not overwritten, the returned WalkErrors is of DefaultWalkErrors type, which is an alias of Errors.
+//
+// If a context is supplied via WithContext, then the traversal stops once the
+// context is done, and the context's error is added to the returned WalkErrors.
+// If concurrency is enabled via WithConcurrency, then the elements of lists
+// may be walked concurrently, and hence the Visitor must be safe for
+// concurrent use.
 func Walk(v Visitor, node WalkNode, o *WalkOptions) WalkErrors {
 	if o == nil {
 		o = &WalkOptions{}
@@ -47,15 +67,19 @@ func Walk(v Visitor, node WalkNode, o *WalkOptions) WalkErrors {
 	if o.WalkErrors == nil {
 		o.WalkErrors = &DefaultWalkErrors{}
 	}
+	w := &walker{WalkOptions: o}
+	if o.concurrency > 1 {
+		w.sem = make(chan struct{}, o.concurrency-1)
+	}
 	if o.schema == nil {
-		walkDataFieldInternal(v, node, o)
+		w.walkDataField(v, node)
 		return o.WalkErrors
 	}
 	node = WalkNodeFromNodeInfo(&NodeInfo{
 		Schema:     o.schema,
 		FieldValue: node.NodeInfo().FieldValue,
 	})
-	walkFieldInternal(v, node, o)
+	w.walkField(v, node)
 	return o.WalkErrors
 }
 
@@ -84,7 +108,9 @@ func WalkNodeFromNodeInfo(ni *NodeInfo) WalkNode {
 // WalkOptions are configurations of the Walk function.
 type WalkOptions struct {
 	WalkErrors
-	schema *yang.Entry
+	schema      *yang.Entry
+	ctx         context.Context
+	concurrency int
 }
 
 // DefaultWalkOptions initialize a WalkOptions.
@@ -98,6 +124,24 @@ func (o *WalkOptions) WithSchema(schema *yang.Entry) *WalkOptions {
 	return o
 }
 
+// WithContext stops the traversal once ctx is done. The error of the context
+// is collected into the WalkErrors returned by Walk.
+func (o *WalkOptions) WithContext(ctx context.Context) *WalkOptions {
+	o.ctx = ctx
+	return o
+}
+
+// WithConcurrency allows the elements of each list to be walked by up to n
+// goroutines at a time. Values of n less than 2 result in a sequential
+// traversal, which is the default. When concurrency is enabled, the Visitor
+// is called from multiple goroutines, and the order in which list elements are
+// visited is not defined. Errors collected by the traversal itself are
+// serialised, but the Visitor must synchronise any errors that it collects.
+func (o *WalkOptions) WithConcurrency(n int) *WalkOptions {
+	o.concurrency = n
+	return o
+}
+
 // WithWalkErrors customizes the WalkErrors returned by the Walk function.
 // If unspecified, the DefaultWalkErrors is used.
 func (o *WalkOptions) WithWalkErrors(we WalkErrors) *WalkOptions {
@@ -117,14 +161,105 @@ func (e *DefaultWalkErrors) Collect(err error) {
 	e.Errors = AppendErr(e.Errors, err)
 }
 
-// walkFieldInternal traverses a GoStruct in depth-first order: It starts by calling
+// walker holds the state of a single call to Walk.
+type walker struct {
+	*WalkOptions
+	// mu serialises the collection of errors when list elements are
+	// walked concurrently.
+	mu sync.Mutex
+	// sem limits the number of additional goroutines that are used to
+	// walk list elements. It is nil if the walk is sequential.
+	sem chan struct{}
+	// cancelled records that the context was found to be done, such that
+	// its error is only collected once.
+	cancelled bool
+}
+
+// collect adds err to the errors of the walk.
+func (w *walker) collect(err error) {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	w.WalkErrors.Collect(err)
+}
+
+// done reports whether the walk should stop since its context is done. The
+// error of the context is collected the first time that this is detected.
+func (w *walker) done() bool {
+	if w.ctx == nil {
+		return false
+	}
+	err := w.ctx.Err()
+	if err == nil {
+		return false
+	}
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	if !w.cancelled {
+		w.cancelled = true
+		w.WalkErrors.Collect(err)
+	}
+	return true
+}
+
+// visit visits node with visitor, and returns the visitor to be used for
+// its children along with a function that must be called once the children
+// have been walked.
+func (w *walker) visit(visitor Visitor, node WalkNode) (Visitor, func()) {
+	childVisitor := visitor.Visit(node)
+	return childVisitor, func() {
+		if childVisitor != nil {
+			childVisitor.Visit(nil)
+		}
+		if pv, ok := visitor.(PostVisitor); ok {
+			pv.PostVisit(node)
+		}
+	}
+}
+
+// elementGroup walks the elements of a single list, concurrently if the
+// walker permits it.
+type elementGroup struct {
+	w  *walker
+	wg sync.WaitGroup
+}
+
+// walk calls f, in a new goroutine if one is available.
+func (g *elementGroup) walk(f func()) {
+	if g.w.sem == nil {
+		f()
+		return
+	}
+	// Where no goroutine is available, the element is walked in the current
+	// goroutine, such that nested lists cannot deadlock waiting for the
+	// goroutines held by their ancestors.
+	select {
+	case g.w.sem <- struct{}{}:
+		g.wg.Add(1)
+		go func() {
+			defer func() {
+				<-g.w.sem
+				g.wg.Done()
+			}()
+			f()
+		}()
+	default:
+		f()
+	}
+}
+
+// wait waits for all elements of the list to have been walked.
+func (g *elementGroup) wait() {
+	g.wg.Wait()
+}
+
+// walkField traverses a GoStruct in depth-first order: It starts by calling
 // v.Visit(node); node must not be nil. If the visitor w returned by
 // v.Visit(node) is not nil, Walk is invoked recursively with visitor
 // w for each of the non-nil children of node, followed by a call of
 // w.Visit(nil).
 // It behaves similar to ForEachField to determine the set of children of a node.
-// Precondition: o.errorCollector must be initialized.
-func walkFieldInternal(visitor Visitor, node WalkNode, o *WalkOptions) {
+// Precondition: w.WalkErrors must be initialized.
+func (w *walker) walkField(visitor Visitor, node WalkNode) {
 	ni := node.NodeInfo()
 	// Ignore nil field.
 	if IsValueNil(ni) {
@@ -136,15 +271,24 @@ func walkFieldInternal(visitor Visitor, node WalkNode, o *WalkOptions) {
 		return
 	}
 	// walk the node itself
-	childVisitor := visitor.Visit(node)
+	if w.done() {
+		return
+	}
+	childVisitor, leave := w.visit(visitor, node)
+	defer leave()
 	if childVisitor == nil {
 		return
 	}
-	defer childVisitor.Visit(nil)
 
 	v := ni.FieldValue
 	t := v.Type()
 
+	// The contents of anydata and anyxml nodes are not described by the
+	// schema, and hence are not walked.
+	if t.Implements(anyDataType) {
+		return
+	}
+
 	// walk children
 	orderedMap, isOrderedMap := v.Interface().(goOrderedMap)
 
@@ -165,7 +309,7 @@ func walkFieldInternal(visitor Visitor, node WalkNode, o *WalkOptions) {
 			var err error
 			elemType, err = yreflect.OrderedMapElementType(orderedMap)
 			if err != nil {
-				o.WalkErrors.Collect(err)
+				w.collect(err)
 				return
 			}
 		default:
@@ -179,11 +323,15 @@ func walkFieldInternal(visitor Visitor, node WalkNode, o *WalkOptions) {
 		nn.Parent = ni
 		nn.PathFromParent = relPath
 
+		g := &elementGroup{w: w}
+		defer g.wait()
 		visitListElement := func(k, v reflect.Value) {
-			nn := nn
-			nn.FieldValue = v
-			nn.FieldKey = k
-			walkFieldInternal(childVisitor, WalkNodeFromNodeInfo(&nn), o)
+			g.walk(func() {
+				nn := nn
+				nn.FieldValue = v
+				nn.FieldKey = k
+				w.walkField(childVisitor, WalkNodeFromNodeInfo(&nn))
+			})
 		}
 
 		switch {
@@ -198,14 +346,14 @@ func walkFieldInternal(visitor Visitor, node WalkNode, o *WalkOptions) {
 			var err error
 			nn.FieldKeys, err = yreflect.OrderedMapKeys(orderedMap)
 			if err != nil {
-				o.WalkErrors.Collect(err)
+				w.collect(err)
 				return
 			}
 			if err := yreflect.RangeOrderedMap(orderedMap, func(k, v reflect.Value) bool {
 				visitListElement(k, v)
 				return true
 			}); err != nil {
-				o.WalkErrors.Collect(err)
+				w.collect(err)
 			}
 		case IsTypeMap(t):
 			nn.FieldKeys = ni.FieldValue.MapKeys()
@@ -221,9 +369,8 @@ func walkFieldInternal(visitor Visitor, node WalkNode, o *WalkOptions) {
 		}
 		fallthrough
 	case IsTypeStruct(t):
-		for i := 0; i < t.NumField(); i++ {
-			sf := t.Field(i)
-
+		sfs, fvs := structFields(t, v)
+		for i, sf := range sfs {
 			// Do not handle annotation fields, since they have no schema.
 			if IsYgotAnnotation(sf) {
 				continue
@@ -232,15 +379,11 @@ func walkFieldInternal(visitor Visitor, node WalkNode, o *WalkOptions) {
 			nn := &NodeInfo{
 				Parent:      ni,
 				StructField: sf,
-			}
-			if !IsNilOrInvalidValue(v) {
-				nn.FieldValue = v.Field(i)
-			} else {
-				nn.FieldValue = reflect.Zero(sf.Type)
+				FieldValue:  fvs[i],
 			}
 			ps, err := SchemaPaths(nn.StructField)
 			if err != nil {
-				o.WalkErrors.Collect(err)
+				w.collect(err)
 				return
 			}
 
@@ -254,20 +397,20 @@ func walkFieldInternal(visitor Visitor, node WalkNode, o *WalkOptions) {
 					continue
 				}
 				nn.PathFromParent = p
-				walkFieldInternal(childVisitor, WalkNodeFromNodeInfo(nn), o)
+				w.walkField(childVisitor, WalkNodeFromNodeInfo(nn))
 			}
 		}
 	}
 }
 
-// walkDataFieldInternal traverses a GoStruct in depth-first order: It starts by calling
+// walkDataField traverses a GoStruct in depth-first order: It starts by calling
 // v.Visit(node); node must not be nil. If the visitor w returned by
 // v.Visit(node) is not nil, Walk is invoked recursively with visitor
 // w for each of the non-nil children of node, followed by a call of
 // w.Visit(nil).
 // It behaves similar to ForEachDataField2 to determine the set of children of a node.
-// Precondition: o.errorCollector must be initialized.
-func walkDataFieldInternal(visitor Visitor, node WalkNode, o *WalkOptions) {
+// Precondition: w.WalkErrors must be initialized.
+func (w *walker) walkDataField(visitor Visitor, node WalkNode) {
 	ni := node.NodeInfo()
 	if IsValueNil(ni) {
 		return
@@ -280,11 +423,14 @@ func walkDataFieldInternal(visitor Visitor, node WalkNode, o *WalkOptions) {
 	}
 
 	// walk the node itself
-	childVisitor := visitor.Visit(node)
+	if w.done() {
+		return
+	}
+	childVisitor, leave := w.visit(visitor, node)
+	defer leave()
 	if childVisitor == nil {
 		return
 	}
-	defer childVisitor.Visit(nil)
 
 	v := ni.FieldValue
 	t := v.Type()
@@ -293,8 +439,11 @@ func walkDataFieldInternal(visitor Visitor, node WalkNode, o *WalkOptions) {
 
 	// Determine whether we need to recurse into the field, or whether it is
 	// a leaf or leaf-list, which are not recursed into when traversing the
-	// data tree.
+	// data tree. anydata and anyxml nodes are handled in the same way as
+	// leaves.
 	switch {
+	case t.Implements(anyDataType):
+		return
 	case isOrderedMap:
 		// Handle the case of a keyed map, which is a YANG list.
 		if IsNilOrInvalidValue(v) {
@@ -305,17 +454,19 @@ func walkDataFieldInternal(visitor Visitor, node WalkNode, o *WalkOptions) {
 		var err error
 		nn.FieldKeys, err = yreflect.OrderedMapKeys(orderedMap)
 		if err != nil {
-			o.WalkErrors.Collect(err)
+			w.collect(err)
 			return
 		}
+		g := &elementGroup{w: w}
+		defer g.wait()
 		if err := yreflect.RangeOrderedMap(orderedMap, func(k, v reflect.Value) bool {
 			nn := nn
 			nn.FieldValue = v
 			nn.FieldKey = k
-			walkDataFieldInternal(childVisitor, WalkNodeFromNodeInfo(&nn), o)
+			g.walk(func() { w.walkDataField(childVisitor, WalkNodeFromNodeInfo(&nn)) })
 			return true
 		}); err != nil {
-			o.WalkErrors.Collect(err)
+			w.collect(err)
 		}
 	case IsTypeStructPtr(t):
 		// A struct pointer in a GoStruct is a pointer to another container within
@@ -327,18 +478,16 @@ func walkDataFieldInternal(visitor Visitor, node WalkNode, o *WalkOptions) {
 		fallthrough
 	case IsTypeStruct(t):
 		// Handle non-pointer structs by recursing into each field of the struct.
-		for i := 0; i < t.NumField(); i++ {
-			sf := t.Field(i)
+		sfs, fvs := structFields(t, v)
+		for i, sf := range sfs {
 			nn := &NodeInfo{
 				Parent:      ni,
 				StructField: sf,
-				FieldValue:  reflect.Zero(sf.Type),
+				FieldValue:  fvs[i],
 			}
-
-			nn.FieldValue = v.Field(i)
 			ps, err := SchemaPaths(nn.StructField)
 			if err != nil {
-				o.WalkErrors.Collect(err)
+				w.collect(err)
 				return
 			}
 			// In the case that the field expands to >1 different data tree path,
@@ -355,7 +504,7 @@ func walkDataFieldInternal(visitor Visitor, node WalkNode, o *WalkOptions) {
 					// trailing spaces (e.g., a path tag of config/bar/).
 					nn.PathFromParent = p[0:1]
 				}
-				walkDataFieldInternal(childVisitor, WalkNodeFromNodeInfo(nn), o)
+				w.walkDataField(childVisitor, WalkNodeFromNodeInfo(nn))
 			}
 		}
 	case IsTypeSlice(t):
@@ -365,6 +514,8 @@ func walkDataFieldInternal(visitor Visitor, node WalkNode, o *WalkOptions) {
 			return
 		}
 
+		g := &elementGroup{w: w}
+		defer g.wait()
 		for i := 0; i < ni.FieldValue.Len(); i++ {
 			nn := *ni
 			nn.Parent = ni
@@ -373,20 +524,50 @@ func walkDataFieldInternal(visitor Visitor, node WalkNode, o *WalkOptions) {
 			// the parent.
 			nn.PathFromParent = ni.PathFromParent
 			nn.FieldValue = ni.FieldValue.Index(i)
-			walkDataFieldInternal(childVisitor, WalkNodeFromNodeInfo(&nn), o)
+			g.walk(func() { w.walkDataField(childVisitor, WalkNodeFromNodeInfo(&nn)) })
 		}
 	case IsTypeMap(t):
 		// Handle the case of a keyed map, which is a YANG list.
 		if IsNilOrInvalidValue(v) {
 			return
 		}
+		g := &elementGroup{w: w}
+		defer g.wait()
 		for _, key := range ni.FieldValue.MapKeys() {
 			nn := *ni
 			nn.Parent = ni
 			nn.FieldValue = ni.FieldValue.MapIndex(key)
 			nn.FieldKey = key
 			nn.FieldKeys = ni.FieldValue.MapKeys()
-			walkDataFieldInternal(childVisitor, WalkNodeFromNodeInfo(&nn), o)
+			g.walk(func() { w.walkDataField(childVisitor, WalkNodeFromNodeInfo(&nn)) })
+		}
+	}
+}
+
+// structFields returns the fields of the struct type t, along with their
+// values within v. If v is invalid, then the zero value of each field is
+// returned, such that the type tree can be traversed. The fields of the
+// selected case of a YANG choice that is generated as a sum type are
+// returned in place of the field storing the choice, since they are children
+// of the struct; choices for which no case is selected are omitted.
+func structFields(t reflect.Type, v reflect.Value) ([]reflect.StructField, []reflect.Value) {
+	var sfs []reflect.StructField
+	var fvs []reflect.Value
+	for i := 0; i < t.NumField(); i++ {
+		sf := t.Field(i)
+		fv := reflect.Zero(sf.Type)
+		if !IsNilOrInvalidValue(v) {
+			fv = v.Field(i)
+		}
+		if IsChoiceField(sf) {
+			if IsNilOrInvalidValue(fv) || !IsValueStructPtr(fv.Elem()) {
+				continue
+			}
+			csfs, cfvs := structFields(fv.Elem().Type().Elem(), fv.Elem().Elem())
+			sfs, fvs = append(sfs, csfs...), append(fvs, cfvs...)
+			continue
 		}
+		sfs, fvs = append(sfs, sf), append(fvs, fv)
 	}
+	return sfs, fvs
 }
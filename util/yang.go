@@ -43,6 +43,53 @@ func Children(e *yang.Entry) []*yang.Entry {
 	return entries
 }
 
+// Operations returns all child elements of a directory element e that are
+// RPC, action or notification entries.
+func Operations(e *yang.Entry) []*yang.Entry {
+	var entries []*yang.Entry
+
+	for _, e := range e.Dir {
+		if IsOperation(e) {
+			entries = append(entries, e)
+		}
+	}
+	return entries
+}
+
+// IsOperation returns true if the entry is an RPC, action or notification
+// node within the schema. Such nodes are not part of the data tree.
+func IsOperation(e *yang.Entry) bool {
+	if e == nil {
+		return false
+	}
+	return e.RPC != nil || e.Kind == yang.NotificationEntry
+}
+
+// IsOperationContainer returns true if the entry is the input or output of
+// an RPC or action, or is a notification. These entries are the roots of the
+// trees of data that are carried by an operation, and behave as containers.
+func IsOperationContainer(e *yang.Entry) bool {
+	if e == nil {
+		return false
+	}
+	switch e.Kind {
+	case yang.InputEntry, yang.OutputEntry, yang.NotificationEntry:
+		return true
+	}
+	return false
+}
+
+// IsOperationData returns true if the entry is an operation container, or
+// is a descendant of one, such that it is not part of the data tree.
+func IsOperationData(e *yang.Entry) bool {
+	for ; e != nil; e = e.Parent {
+		if IsOperationContainer(e) {
+			return true
+		}
+	}
+	return false
+}
+
 // TopLevelModule returns the module in which the root node of the schema tree
 // in which the input node was instantiated was declared. It returns nil if
 // schema is nil.
@@ -131,6 +178,21 @@ func IsAnydata(e *yang.Entry) bool {
 	return e.Kind == yang.AnyDataEntry
 }
 
+// IsAnyxml returns true if the entry is an Anyxml node.
+func IsAnyxml(e *yang.Entry) bool {
+	if e == nil {
+		return false
+	}
+	return e.Kind == yang.AnyXMLEntry
+}
+
+// IsAnydataOrAnyxml returns true if the entry is an Anydata or Anyxml node.
+// Both are represented in the same way in generated code, since their contents
+// are not described by the schema.
+func IsAnydataOrAnyxml(e *yang.Entry) bool {
+	return IsAnydata(e) || IsAnyxml(e)
+}
+
 // IsLeafRef reports whether schema is a leafref schema node type.
 func IsLeafRef(schema *yang.Entry) bool {
 	if schema == nil || schema.Type == nil {
@@ -214,6 +276,14 @@ func IsYangPresence(s reflect.StructField) bool {
 	return ok
 }
 
+// IsChoiceField reports whether struct field s stores a YANG choice that is
+// generated as a sum type, i.e., whether it is of an interface type that is
+// implemented by the structs representing the cases of the choice. The
+// fields of the selected case are children of the struct containing s.
+func IsChoiceField(s reflect.StructField) bool {
+	return s.Type.Kind() == reflect.Interface && s.Type.Implements(goChoiceCaseType)
+}
+
 // IsSimpleEnumerationType returns true when the type supplied is a simple
 // enumeration (i.e., a leaf that is defined as type enumeration { ... },
 // and is not a typedef that contains an enumeration, or a union that
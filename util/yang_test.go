@@ -650,6 +650,7 @@ func TestIsYangTypes(t *testing.T) {
 		wantUnion      bool
 		wantEnumerated bool
 		wantAnydata    bool
+		wantAnyxml     bool
 		wantSimpleEnum bool
 	}{
 		{
@@ -812,6 +813,17 @@ func TestIsYangTypes(t *testing.T) {
 			wantAnydata:    true,
 			wantSimpleEnum: false,
 		},
+		{
+			desc: "anyxml",
+			schema: &yang.Entry{
+				Kind: yang.AnyXMLEntry,
+			},
+			wantLeafRef:    false,
+			wantUnion:      false,
+			wantEnumerated: false,
+			wantAnyxml:     true,
+			wantSimpleEnum: false,
+		},
 		{
 			desc: "non-simple enum",
 			schema: &yang.Entry{
@@ -837,6 +849,12 @@ func TestIsYangTypes(t *testing.T) {
 			if got, want := IsAnydata(tt.schema), tt.wantAnydata; got != want {
 				t.Errorf("IsAnydata got: %v want: %v", got, want)
 			}
+			if got, want := IsAnyxml(tt.schema), tt.wantAnyxml; got != want {
+				t.Errorf("IsAnyxml got: %v want: %v", got, want)
+			}
+			if got, want := IsAnydataOrAnyxml(tt.schema), tt.wantAnydata || tt.wantAnyxml; got != want {
+				t.Errorf("IsAnydataOrAnyxml got: %v want: %v", got, want)
+			}
 			if tt.schema != nil { // These functions take in type as the parameter.
 				if got, want := IsUnionType(tt.schema.Type), tt.wantUnion; got != want {
 					t.Errorf("IsUnionType got: %v want: %v", got, want)
@@ -895,6 +913,97 @@ func TestIsChoiceOrCase(t *testing.T) {
 	}
 }
 
+func TestOperations(t *testing.T) {
+	rpc := &yang.Entry{
+		Name: "rpc",
+		Kind: yang.DirectoryEntry,
+		RPC: &yang.RPCEntry{
+			Input:  &yang.Entry{Name: "input", Kind: yang.InputEntry},
+			Output: &yang.Entry{Name: "output", Kind: yang.OutputEntry},
+		},
+	}
+	leaf := &yang.Entry{Name: "leaf", Kind: yang.LeafEntry, Type: &yang.YangType{Kind: yang.Ystring}}
+	notif := &yang.Entry{
+		Name: "notif",
+		Kind: yang.NotificationEntry,
+		Dir:  map[string]*yang.Entry{"leaf": leaf},
+	}
+	leaf.Parent = notif
+	rpc.RPC.Input.Parent = rpc
+	module := &yang.Entry{
+		Name: "module",
+		Kind: yang.DirectoryEntry,
+		Dir: map[string]*yang.Entry{
+			"rpc":       rpc,
+			"notif":     notif,
+			"container": {Name: "container", Kind: yang.DirectoryEntry, Dir: map[string]*yang.Entry{}},
+		},
+	}
+	for _, e := range module.Dir {
+		e.Parent = module
+	}
+
+	names := func(es []*yang.Entry) map[string]bool {
+		m := map[string]bool{}
+		for _, e := range es {
+			m[e.Name] = true
+		}
+		return m
+	}
+	if diff := cmp.Diff(map[string]bool{"container": true, "notif": true}, names(Children(module))); diff != "" {
+		t.Errorf("Children: did not get expected children, diff(-want, +got):\n%s", diff)
+	}
+	if diff := cmp.Diff(map[string]bool{"rpc": true, "notif": true}, names(Operations(module))); diff != "" {
+		t.Errorf("Operations: did not get expected operations, diff(-want, +got):\n%s", diff)
+	}
+
+	tests := []struct {
+		desc              string
+		in                *yang.Entry
+		wantOperation     bool
+		wantContainer     bool
+		wantOperationData bool
+	}{{
+		desc: "nil",
+	}, {
+		desc:          "rpc",
+		in:            rpc,
+		wantOperation: true,
+	}, {
+		desc:              "rpc input",
+		in:                rpc.RPC.Input,
+		wantContainer:     true,
+		wantOperationData: true,
+	}, {
+		desc:              "notification",
+		in:                notif,
+		wantOperation:     true,
+		wantContainer:     true,
+		wantOperationData: true,
+	}, {
+		desc:              "leaf within notification",
+		in:                leaf,
+		wantOperationData: true,
+	}, {
+		desc: "container",
+		in:   module.Dir["container"],
+	}}
+
+	for _, tt := range tests {
+		t.Run(tt.desc, func(t *testing.T) {
+			if got := IsOperation(tt.in); got != tt.wantOperation {
+				t.Errorf("IsOperation: got %v, want %v", got, tt.wantOperation)
+			}
+			if got := IsOperationContainer(tt.in); got != tt.wantContainer {
+				t.Errorf("IsOperationContainer: got %v, want %v", got, tt.wantContainer)
+			}
+			if got := IsOperationData(tt.in); got != tt.wantOperationData {
+				t.Errorf("IsOperationData: got %v, want %v", got, tt.wantOperationData)
+			}
+		})
+	}
+}
+
 func TestIsFakeRoot(t *testing.T) {
 	tests := []struct {
 		desc   string
@@ -1054,6 +1163,45 @@ func TestIsYangPresence(t *testing.T) {
 	}
 }
 
+// choiceTestIntf is an interface representing a choice that is generated as a
+// sum type.
+type choiceTestIntf interface {
+	IsYANGChoiceCase()
+	Is_choiceTestIntf()
+}
+
+func TestIsChoiceField(t *testing.T) {
+	type testStruct struct {
+		Choice choiceTestIntf `choice:"foo"`
+		Union  interface{ Is_union() }
+		Leaf   *string `path:"leaf"`
+	}
+
+	tests := []struct {
+		name string
+		in   reflect.StructField
+		want bool
+	}{{
+		name: "choice field",
+		in:   reflect.TypeOf(testStruct{}).Field(0),
+		want: true,
+	}, {
+		name: "other interface field",
+		in:   reflect.TypeOf(testStruct{}).Field(1),
+		want: false,
+	}, {
+		name: "standard field",
+		in:   reflect.TypeOf(testStruct{}).Field(2),
+		want: false,
+	}}
+
+	for _, tt := range tests {
+		if got := IsChoiceField(tt.in); got != tt.want {
+			t.Errorf("%s: IsChoiceField(%#v): did not get expected result, got: %v, want: %v", tt.name, tt.in, got, tt.want)
+		}
+	}
+}
+
 // complexUnionTypeName is the name used to refer to the name of the union
 // type containing the slice of input types to the functions.
 const complexUnionTypeName = "complexUnionTypeName"
@@ -0,0 +1,192 @@
+// Copyright 2023 Google Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package yangcompat compares two versions of a set of YANG modules, and
+// reports the changes between them that are not backwards compatible, both
+// according to the update rules of RFC 7950 section 11, and in terms of the
+// Go API that ygot generates for the modules.
+package yangcompat
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/openconfig/ygot/gogen"
+	"github.com/openconfig/ygot/ygen"
+)
+
+// ChangeKind describes the kind of a backwards incompatible change.
+type ChangeKind string
+
+const (
+	// NodeRemoved indicates that a schema node, or a module, was removed.
+	NodeRemoved ChangeKind = "node-removed"
+	// NodeKindChanged indicates that a schema node changed from one kind
+	// of node to another, for example from a leaf to a leaf-list.
+	NodeKindChanged ChangeKind = "node-kind-changed"
+	// TypeChanged indicates that the type of a leaf or leaf-list changed
+	// such that its syntax or semantics differ.
+	TypeChanged ChangeKind = "type-changed"
+	// RangeNarrowed indicates that the range or length of a type no longer
+	// permits values that it previously permitted.
+	RangeNarrowed ChangeKind = "range-narrowed"
+	// PatternChanged indicates that a pattern was added to a type, such
+	// that values that were previously permitted may no longer be.
+	PatternChanged ChangeKind = "pattern-changed"
+	// EnumRemoved indicates that an enum, bit or identity that was
+	// previously a valid value of a type was removed.
+	EnumRemoved ChangeKind = "enum-removed"
+	// EnumValueChanged indicates that the value of an enum, or the
+	// position of a bit, changed.
+	EnumValueChanged ChangeKind = "enum-value-changed"
+	// MandatoryAdded indicates that a mandatory node was added to an
+	// existing node, or that an existing node became mandatory, or that
+	// the minimum number of elements of a list or leaf-list was increased.
+	MandatoryAdded ChangeKind = "mandatory-added"
+	// ConstraintAdded indicates that a constraint, such as a must or when
+	// statement or an if-feature, was added to or changed for an existing
+	// node, or that the maximum number of elements of a list or leaf-list
+	// was reduced.
+	ConstraintAdded ChangeKind = "constraint-added"
+	// SemanticsChanged indicates that a property of a node that determines
+	// the meaning of its data, such as its keys, default or units, changed.
+	SemanticsChanged ChangeKind = "semantics-changed"
+
+	// GoTypeRemoved indicates that a generated Go struct or enumerated type
+	// was removed.
+	GoTypeRemoved ChangeKind = "go-type-removed"
+	// GoFieldRemoved indicates that a field of a generated Go struct was
+	// removed.
+	GoFieldRemoved ChangeKind = "go-field-removed"
+	// GoFieldTypeChanged indicates that the type of a field of a generated
+	// Go struct, or of a key of a generated list, changed.
+	GoFieldTypeChanged ChangeKind = "go-field-type-changed"
+	// GoEnumValueRemoved indicates that a value of a generated enumerated
+	// type was removed.
+	GoEnumValueRemoved ChangeKind = "go-enum-value-removed"
+)
+
+// Change is a backwards incompatible change between two versions of a set
+// of modules.
+type Change struct {
+	// Kind is the kind of the change.
+	Kind ChangeKind
+	// Path identifies the element that changed. For changes to the schema,
+	// it is the schema path of the node, and for changes to the generated
+	// Go API it is the name of the Go type, or of the type and its field,
+	// separated by a period.
+	Path string
+	// Detail describes the change.
+	Detail string
+}
+
+// String returns a human readable representation of the change.
+func (c *Change) String() string {
+	return fmt.Sprintf("%s: %s (%s)", c.Path, c.Detail, c.Kind)
+}
+
+// Report is the set of backwards incompatible changes between two versions
+// of a set of modules.
+type Report struct {
+	// SchemaChanges are the changes to the YANG schema that are not
+	// permitted by RFC 7950 section 11, in the order of their paths.
+	SchemaChanges []*Change
+	// GoAPIChanges are the changes to the generated Go API that may cause
+	// code that uses it to no longer compile, in the order of their paths.
+	GoAPIChanges []*Change
+}
+
+// Breaking returns true if the report contains any backwards incompatible
+// changes.
+func (r *Report) Breaking() bool {
+	return len(r.SchemaChanges) != 0 || len(r.GoAPIChanges) != 0
+}
+
+// String returns a human readable representation of the report, with one
+// line per change.
+func (r *Report) String() string {
+	var b strings.Builder
+	for _, s := range []struct {
+		title   string
+		changes []*Change
+	}{
+		{title: "YANG schema changes", changes: r.SchemaChanges},
+		{title: "Go API changes", changes: r.GoAPIChanges},
+	} {
+		if len(s.changes) == 0 {
+			continue
+		}
+		fmt.Fprintf(&b, "%s:\n", s.title)
+		for _, c := range s.changes {
+			fmt.Fprintf(&b, "  %s\n", c)
+		}
+	}
+	return b.String()
+}
+
+// ModuleSet is a version of a set of YANG modules.
+type ModuleSet struct {
+	// Files are the YANG files that contain the modules to be compared.
+	Files []string
+	// IncludePaths are the paths that are searched for the modules and
+	// submodules that the files import or include.
+	IncludePaths []string
+}
+
+// Opts controls how two versions of a set of modules are compared.
+type Opts struct {
+	// IROptions are the options used to parse both versions of the
+	// modules and to generate their IR. The schema compression options
+	// determine the shape of the Go API that is compared, whereas schema
+	// changes are always found using the uncompressed schema.
+	IROptions ygen.IROptions
+	// GenerateSimpleUnions specifies whether the Go API that is compared
+	// represents unions as simple unions, as per the option of the same
+	// name of the Go generator.
+	GenerateSimpleUnions bool
+	// GenerateOrderedListsAsUnorderedMaps specifies whether the Go API that
+	// is compared represents lists that are ordered-by user as unordered
+	// maps, as per the option of the same name of the Go generator.
+	GenerateOrderedListsAsUnorderedMaps bool
+}
+
+// Compare returns the backwards incompatible changes that were made to the
+// modules in from to produce the modules in to.
+func Compare(from, to ModuleSet, opts Opts) (*Report, error) {
+	fromIR, err := ygen.GenerateIR(from.Files, from.IncludePaths, gogen.NewGoLangMapper(opts.GenerateSimpleUnions), opts.IROptions)
+	if err != nil {
+		return nil, fmt.Errorf("cannot generate IR for the original modules: %v", err)
+	}
+	toIR, err := ygen.GenerateIR(to.Files, to.IncludePaths, gogen.NewGoLangMapper(opts.GenerateSimpleUnions), opts.IROptions)
+	if err != nil {
+		return nil, fmt.Errorf("cannot generate IR for the updated modules: %v", err)
+	}
+
+	r := &Report{
+		SchemaChanges: compareSchemas(fromIR.Modules(), toIR.Modules()),
+		GoAPIChanges:  compareGoAPIs(fromIR, toIR, !opts.GenerateOrderedListsAsUnorderedMaps),
+	}
+	sortChanges(r.SchemaChanges)
+	sortChanges(r.GoAPIChanges)
+	return r, nil
+}
+
+// sortChanges sorts changes by their path, retaining the order in which the
+// changes to a single path were found.
+func sortChanges(changes []*Change) {
+	sort.SliceStable(changes, func(i, j int) bool {
+		return changes[i].Path < changes[j].Path
+	})
+}
@@ -0,0 +1,114 @@
+// Copyright 2023 Google Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package yangcompat
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/openconfig/gnmi/errdiff"
+	"github.com/openconfig/ygot/genutil"
+	"github.com/openconfig/ygot/testutil"
+	"github.com/openconfig/ygot/ygen"
+)
+
+// datapath is the path to common YANG test modules.
+const datapath = "../testdata/modules"
+
+func TestCompare(t *testing.T) {
+	tests := []struct {
+		name   string
+		inFrom ModuleSet
+		inTo   ModuleSet
+		inOpts Opts
+		// wantFile is the path to the file containing the report that is
+		// expected to be produced.
+		wantFile         string
+		wantBreaking     bool
+		wantErrSubstring string
+	}{{
+		name:   "uncompressed schema",
+		inFrom: ModuleSet{Files: []string{filepath.Join("testdata", "v1", "compat.yang")}},
+		inTo:   ModuleSet{Files: []string{filepath.Join("testdata", "v2", "compat.yang")}},
+		inOpts: Opts{
+			IROptions: ygen.IROptions{
+				TransformationOptions: ygen.TransformationOpts{
+					GenerateFakeRoot:           true,
+					EnumerationsUseUnderscores: true,
+				},
+			},
+		},
+		wantFile:     filepath.Join("testdata", "compat.formatted-txt"),
+		wantBreaking: true,
+	}, {
+		name: "compressed schema",
+		inFrom: ModuleSet{
+			Files:        []string{filepath.Join(datapath, "openconfig-simple.yang")},
+			IncludePaths: []string{datapath},
+		},
+		inTo: ModuleSet{
+			Files:        []string{filepath.Join("testdata", "v2", "openconfig-simple.yang")},
+			IncludePaths: []string{datapath},
+		},
+		inOpts: Opts{
+			IROptions: ygen.IROptions{
+				TransformationOptions: ygen.TransformationOpts{
+					CompressBehaviour:          genutil.PreferIntendedConfig,
+					GenerateFakeRoot:           true,
+					EnumerationsUseUnderscores: true,
+				},
+			},
+		},
+		wantFile:     filepath.Join("testdata", "openconfig-simple.formatted-txt"),
+		wantBreaking: true,
+	}, {
+		name:   "unchanged modules",
+		inFrom: ModuleSet{Files: []string{filepath.Join("testdata", "v1", "compat.yang")}},
+		inTo:   ModuleSet{Files: []string{filepath.Join("testdata", "v1", "compat.yang")}},
+	}, {
+		name:             "invalid original modules",
+		inFrom:           ModuleSet{Files: []string{filepath.Join("testdata", "v1", "does-not-exist.yang")}},
+		inTo:             ModuleSet{Files: []string{filepath.Join("testdata", "v2", "compat.yang")}},
+		wantErrSubstring: "cannot generate IR for the original modules",
+	}}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := Compare(tt.inFrom, tt.inTo, tt.inOpts)
+			if diff := errdiff.Substring(err, tt.wantErrSubstring); diff != "" {
+				t.Fatalf("Compare: did not get expected error, %s", diff)
+			}
+			if err != nil {
+				return
+			}
+
+			if got.Breaking() != tt.wantBreaking {
+				t.Errorf("Compare: did not get expected breaking status, got: %v, want: %v", got.Breaking(), tt.wantBreaking)
+			}
+
+			var want []byte
+			if tt.wantFile != "" {
+				if want, err = os.ReadFile(tt.wantFile); err != nil {
+					t.Fatalf("cannot read want file %s: %v", tt.wantFile, err)
+				}
+			}
+			if got.String() != string(want) {
+				diff, _ := testutil.GenerateUnifiedDiff(string(want), got.String())
+				t.Errorf("Compare: did not get expected report (file: %s), diff(-want, +got):\n%s", tt.wantFile, diff)
+			}
+		})
+	}
+}
@@ -0,0 +1,178 @@
+// Copyright 2023 Google Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package yangcompat
+
+import (
+	"fmt"
+	"sort"
+
+	"github.com/openconfig/ygot/gogen"
+	"github.com/openconfig/ygot/ygen"
+)
+
+// compareGoAPIs returns the changes that were made to the Go API generated
+// for the IR from to produce the Go API generated for the IR to, which may
+// cause code that uses the API to no longer compile. Structs and enumerated
+// types are matched by their generated names, and fields by their names
+// within their struct. generateOrderedMaps specifies whether lists that are
+// ordered-by user are represented as ordered maps.
+func compareGoAPIs(from, to *ygen.IR, generateOrderedMaps bool) []*Change {
+	var changes []*Change
+	add := func(kind ChangeKind, path, format string, args ...any) {
+		changes = append(changes, &Change{Kind: kind, Path: path, Detail: fmt.Sprintf(format, args...)})
+	}
+
+	toDirs := map[string]*ygen.ParsedDirectory{}
+	for _, d := range to.Directories {
+		toDirs[d.Name] = d
+	}
+	for _, p := range from.OrderedDirectoryPathsByName() {
+		od := from.Directories[p]
+		nd, ok := toDirs[od.Name]
+		if !ok {
+			add(GoTypeRemoved, od.Name, "struct removed")
+			continue
+		}
+
+		newFields := map[string]*ygen.NodeDetails{}
+		for _, f := range nd.Fields {
+			newFields[f.Name] = f
+		}
+		for _, fn := range od.OrderedFieldNames() {
+			of := od.Fields[fn]
+			path := fmt.Sprintf("%s.%s", od.Name, of.Name)
+			nf, ok := newFields[of.Name]
+			if !ok {
+				add(GoFieldRemoved, path, "field removed")
+				continue
+			}
+			ot, err := goFieldType(from, od, of, generateOrderedMaps)
+			if err != nil {
+				add(GoFieldTypeChanged, path, "cannot determine original type: %v", err)
+				continue
+			}
+			nt, err := goFieldType(to, nd, nf, generateOrderedMaps)
+			if err != nil {
+				add(GoFieldTypeChanged, path, "cannot determine updated type: %v", err)
+				continue
+			}
+			if ot != nt {
+				add(GoFieldTypeChanged, path, "type changed from %s to %s", ot, nt)
+				continue
+			}
+			if of.LangType != nil && len(of.LangType.UnionTypes) > 1 {
+				for _, t := range sortedKeys(of.LangType.UnionTypes) {
+					if _, ok := nf.LangType.UnionTypes[t]; !ok {
+						add(GoFieldTypeChanged, path, "union %s no longer accepts %s", ot, t)
+					}
+				}
+			}
+		}
+
+		// The keys of a list with multiple keys are fields of a generated
+		// key struct, whose name is derived from that of the list.
+		if len(od.ListKeys) > 1 && len(nd.ListKeys) > 1 {
+			keyStruct := fmt.Sprintf("%s_Key", od.Name)
+			newKeys := map[string]*ygen.ListKey{}
+			for _, k := range nd.ListKeys {
+				newKeys[k.Name] = k
+			}
+			for _, kn := range od.ListKeyYANGNames {
+				okey := od.ListKeys[kn]
+				path := fmt.Sprintf("%s.%s", keyStruct, okey.Name)
+				switch nk, found := newKeys[okey.Name]; {
+				case !found:
+					add(GoFieldRemoved, path, "key field removed")
+				case okey.LangType.NativeType != nk.LangType.NativeType:
+					add(GoFieldTypeChanged, path, "type changed from %s to %s", okey.LangType.NativeType, nk.LangType.NativeType)
+				}
+			}
+		}
+	}
+
+	toEnums := map[string]*ygen.EnumeratedYANGType{}
+	for _, e := range to.Enums {
+		toEnums[e.Name] = e
+	}
+	fromEnums := make([]*ygen.EnumeratedYANGType, 0, len(from.Enums))
+	for _, e := range from.Enums {
+		fromEnums = append(fromEnums, e)
+	}
+	sort.Slice(fromEnums, func(i, j int) bool { return fromEnums[i].Name < fromEnums[j].Name })
+	for _, oe := range fromEnums {
+		name := "E_" + oe.Name
+		ne, ok := toEnums[oe.Name]
+		if !ok {
+			add(GoTypeRemoved, name, "enumerated type removed")
+			continue
+		}
+		newValues := map[string]bool{}
+		for _, v := range ne.ValToYANGDetails {
+			newValues[v.Name] = true
+		}
+		for _, v := range oe.ValToYANGDetails {
+			if !newValues[v.Name] {
+				add(GoEnumValueRemoved, name, "value %s removed", v.Name)
+			}
+		}
+	}
+	return changes
+}
+
+// goFieldType returns the Go type of the field f of the struct generated for
+// the directory d of ir.
+func goFieldType(ir *ygen.IR, d *ygen.ParsedDirectory, f *ygen.NodeDetails, generateOrderedMaps bool) (string, error) {
+	switch f.Type {
+	case ygen.ContainerNode:
+		cd, ok := ir.Directories[f.YANGDetails.Path]
+		if !ok {
+			return "", fmt.Errorf("cannot find struct for %s", f.YANGDetails.Path)
+		}
+		return "*" + cd.Name, nil
+	case ygen.ListNode:
+		ld, ok := ir.Directories[f.YANGDetails.Path]
+		if !ok {
+			return "", fmt.Errorf("cannot find struct for %s", f.YANGDetails.Path)
+		}
+		switch {
+		case len(ld.ListKeys) == 0:
+			return "[]*" + ld.Name, nil
+		case f.YANGDetails.OrderedByUser && generateOrderedMaps:
+			return "*" + gogen.OrderedMapTypeName(ld.Name), nil
+		}
+		listType, _, _, err := gogen.UnorderedMapTypeName(f.YANGDetails.Path, f.Name, d.Name, ir.Directories)
+		return listType, err
+	case ygen.AnyDataNode:
+		return "*ygot.AnyData", nil
+	case ygen.LeafListNode:
+		return "[]" + f.LangType.NativeType, nil
+	default:
+		if gogen.IsScalarField(f) {
+			return "*" + f.LangType.NativeType, nil
+		}
+		return f.LangType.NativeType, nil
+	}
+}
+
+// sortedKeys returns the keys of the union subtypes m in lexicographical
+// order.
+func sortedKeys(m map[string]ygen.MappedUnionSubtype) []string {
+	keys := make([]string, 0, len(m))
+	for k := range m {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	return keys
+}
@@ -0,0 +1,431 @@
+// Copyright 2023 Google Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package yangcompat
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/openconfig/goyang/pkg/yang"
+	"github.com/openconfig/ygot/util"
+)
+
+// compareSchemas returns the changes that were made to the schema trees of
+// the modules from to produce the modules to, which are not permitted by RFC
+// 7950 section 11. Nodes are matched by their schema path, such that a node
+// that is moved, including into or out of a choice, is reported as removed.
+func compareSchemas(from, to []*yang.Entry) []*Change {
+	fromNodes, toNodes := schemaNodes(from), schemaNodes(to)
+
+	var changes []*Change
+	removed := map[string]bool{}
+	for _, p := range sortedPaths(fromNodes) {
+		// Only the highest removed node is reported, since all of its
+		// descendants are necessarily removed too.
+		if removed[parentPath(p)] {
+			removed[p] = true
+			continue
+		}
+		o := fromNodes[p]
+		n, ok := toNodes[p]
+		if !ok {
+			removed[p] = true
+			changes = append(changes, &Change{Kind: NodeRemoved, Path: p, Detail: fmt.Sprintf("%s removed", nodeKind(o))})
+			continue
+		}
+		changes = append(changes, nodeChanges(p, o, n)...)
+	}
+
+	for _, p := range sortedPaths(toNodes) {
+		if _, ok := fromNodes[p]; ok {
+			continue
+		}
+		// New nodes may be added to a new node without restriction, and
+		// may be added to an existing node only if they are not mandatory
+		// or depend upon a feature.
+		if _, ok := fromNodes[parentPath(p)]; !ok {
+			continue
+		}
+		if n := toNodes[p]; isMandatory(n) && len(extraNames(n, "if-feature")) == 0 {
+			changes = append(changes, &Change{Kind: MandatoryAdded, Path: p, Detail: fmt.Sprintf("mandatory %s added", nodeKind(n))})
+		}
+	}
+	return changes
+}
+
+// schemaNodes returns the nodes of the schema trees of the modules supplied,
+// including the modules themselves and the inputs and outputs of operations,
+// keyed by their schema path.
+func schemaNodes(modules []*yang.Entry) map[string]*yang.Entry {
+	nodes := map[string]*yang.Entry{}
+	var walk func(e *yang.Entry)
+	walk = func(e *yang.Entry) {
+		nodes[e.Path()] = e
+		if e.RPC != nil {
+			for _, io := range []*yang.Entry{e.RPC.Input, e.RPC.Output} {
+				if io != nil {
+					walk(io)
+				}
+			}
+		}
+		for _, ch := range e.Dir {
+			walk(ch)
+		}
+	}
+	for _, m := range modules {
+		walk(m)
+	}
+	return nodes
+}
+
+// sortedPaths returns the keys of nodes in lexicographical order, such that
+// every node is preceded by its ancestors.
+func sortedPaths(nodes map[string]*yang.Entry) []string {
+	paths := make([]string, 0, len(nodes))
+	for p := range nodes {
+		paths = append(paths, p)
+	}
+	sort.Strings(paths)
+	return paths
+}
+
+// parentPath returns the schema path of the parent of the node with the
+// schema path p.
+func parentPath(p string) string {
+	return p[:strings.LastIndex(p, "/")]
+}
+
+// nodeChanges returns the backwards incompatible changes between the
+// versions o and n of the node with the schema path p.
+func nodeChanges(p string, o, n *yang.Entry) []*Change {
+	if ok, nk := nodeKind(o), nodeKind(n); ok != nk {
+		return []*Change{{Kind: NodeKindChanged, Path: p, Detail: fmt.Sprintf("changed from %s to %s", ok, nk)}}
+	}
+
+	var changes []*Change
+	add := func(kind ChangeKind, format string, args ...any) {
+		changes = append(changes, &Change{Kind: kind, Path: p, Detail: fmt.Sprintf(format, args...)})
+	}
+
+	// Changes to whether a node is configuration are reported only for the
+	// highest node whose config statement changed.
+	if o.Parent == nil || o.Parent.ReadOnly() == n.Parent.ReadOnly() {
+		switch {
+		case !o.ReadOnly() && n.ReadOnly():
+			add(SemanticsChanged, "changed from configuration to state")
+		case o.ReadOnly() && !n.ReadOnly() && isMandatory(n):
+			add(MandatoryAdded, "changed from state to mandatory configuration")
+		}
+	}
+
+	switch {
+	case o.ListAttr != nil:
+		if n.ListAttr.MinElements > o.ListAttr.MinElements {
+			add(MandatoryAdded, "min-elements increased from %d to %d", o.ListAttr.MinElements, n.ListAttr.MinElements)
+		}
+		if n.ListAttr.MaxElements < o.ListAttr.MaxElements {
+			add(ConstraintAdded, "max-elements decreased from %d to %d", o.ListAttr.MaxElements, n.ListAttr.MaxElements)
+		}
+		if o.ListAttr.OrderedByUser != n.ListAttr.OrderedByUser {
+			add(SemanticsChanged, "ordered-by changed from %s to %s", orderedBy(o), orderedBy(n))
+		}
+	case o.Mandatory != yang.TSTrue && n.Mandatory == yang.TSTrue:
+		add(MandatoryAdded, "became mandatory")
+	}
+
+	if o.Key != n.Key {
+		add(SemanticsChanged, "keys changed from [%s] to [%s]", o.Key, n.Key)
+	}
+	if op, np := len(o.Extra["presence"]) != 0, len(n.Extra["presence"]) != 0; op != np {
+		if np {
+			add(SemanticsChanged, "presence statement added")
+		} else {
+			add(SemanticsChanged, "presence statement removed")
+		}
+	}
+
+	if o.Type != nil && n.Type != nil {
+		changes = append(changes, typeChanges(p, o.Type, n.Type)...)
+	}
+	if od, nd := o.DefaultValues(), n.DefaultValues(); len(od) != 0 && strings.Join(od, ",") != strings.Join(nd, ",") {
+		add(SemanticsChanged, "default changed from %v to %v", od, nd)
+	}
+	if ou, nu := units(o), units(n); ou != "" && ou != nu {
+		add(SemanticsChanged, "units changed from %q to %q", ou, nu)
+	}
+
+	for _, kw := range []string{"if-feature", "must"} {
+		old := map[string]bool{}
+		for _, name := range extraNames(o, kw) {
+			old[name] = true
+		}
+		for _, name := range extraNames(n, kw) {
+			if !old[name] {
+				add(ConstraintAdded, "%s %q added", kw, name)
+			}
+		}
+	}
+	if nw, ok := n.GetWhenXPath(); ok {
+		switch ow, ok := o.GetWhenXPath(); {
+		case !ok:
+			add(ConstraintAdded, "when %q added", nw)
+		case ow != nw:
+			add(ConstraintAdded, "when changed from %q to %q", ow, nw)
+		}
+	}
+	return changes
+}
+
+// typeChanges returns the backwards incompatible changes between the
+// versions o and n of the type of the node with the schema path p.
+func typeChanges(p string, o, n *yang.YangType) []*Change {
+	var changes []*Change
+	add := func(kind ChangeKind, format string, args ...any) {
+		changes = append(changes, &Change{Kind: kind, Path: p, Detail: fmt.Sprintf(format, args...)})
+	}
+
+	if o.Kind != n.Kind {
+		add(TypeChanged, "type changed from %s to %s", typeName(o), typeName(n))
+		return changes
+	}
+
+	switch o.Kind {
+	case yang.Yint8, yang.Yint16, yang.Yint32, yang.Yint64, yang.Yuint8, yang.Yuint16, yang.Yuint32, yang.Yuint64, yang.Ydecimal64:
+		if o.Kind == yang.Ydecimal64 && o.FractionDigits != n.FractionDigits {
+			add(TypeChanged, "fraction-digits changed from %d to %d", o.FractionDigits, n.FractionDigits)
+			break
+		}
+		if or, nr := effectiveRange(o, o.Range), effectiveRange(n, n.Range); !nr.Contains(or) {
+			add(RangeNarrowed, "range narrowed from %s to %s", or, nr)
+		}
+	case yang.Ystring, yang.Ybinary:
+		if ol, nl := effectiveRange(o, o.Length), effectiveRange(n, n.Length); !nl.Contains(ol) {
+			add(RangeNarrowed, "length narrowed from %s to %s", ol, nl)
+		}
+		oldPatterns := map[string]bool{}
+		for _, pt := range append(append([]string{}, o.Pattern...), o.POSIXPattern...) {
+			oldPatterns[pt] = true
+		}
+		for _, pt := range append(append([]string{}, n.Pattern...), n.POSIXPattern...) {
+			if !oldPatterns[pt] {
+				add(PatternChanged, "pattern %q added", pt)
+			}
+		}
+	case yang.Yenum, yang.Ybits:
+		what, oe, ne := "enum", o.Enum, n.Enum
+		if o.Kind == yang.Ybits {
+			what, oe, ne = "bit", o.Bit, n.Bit
+		}
+		if oe == nil || ne == nil {
+			break
+		}
+		newValues := ne.NameMap()
+		for _, name := range oe.Names() {
+			switch nv, ok := newValues[name]; {
+			case !ok:
+				add(EnumRemoved, "%s %s removed", what, name)
+			case nv != oe.Value(name):
+				add(EnumValueChanged, "%s %s changed from %d to %d", what, name, oe.Value(name), nv)
+			}
+		}
+	case yang.Yleafref:
+		if o.Path != n.Path {
+			add(TypeChanged, "leafref path changed from %s to %s", o.Path, n.Path)
+		}
+	case yang.Yidentityref:
+		if o.IdentityBase == nil || n.IdentityBase == nil {
+			break
+		}
+		if ob, nb := identityName(o.IdentityBase), identityName(n.IdentityBase); ob != nb {
+			add(TypeChanged, "identityref base changed from %s to %s", ob, nb)
+			break
+		}
+		newValues := map[string]bool{}
+		for _, v := range n.IdentityBase.Values {
+			newValues[identityName(v)] = true
+		}
+		for _, v := range o.IdentityBase.Values {
+			if name := identityName(v); !newValues[name] {
+				add(EnumRemoved, "identity %s removed", name)
+			}
+		}
+	case yang.Yunion:
+		// Member types may be added to the end of a union, but existing
+		// member types must retain their position, since it determines
+		// how a value is interpreted.
+		compatible := len(n.Type) >= len(o.Type)
+		for i := 0; compatible && i < len(o.Type); i++ {
+			compatible = o.Type[i].Kind == n.Type[i].Kind
+		}
+		if !compatible {
+			add(TypeChanged, "union member types changed from %s to %s", typeNames(o.Type), typeNames(n.Type))
+			break
+		}
+		for i := range o.Type {
+			changes = append(changes, typeChanges(p, o.Type[i], n.Type[i])...)
+		}
+	}
+	return changes
+}
+
+// effectiveRange returns r, the range or length of the type t, or the range
+// of the built-in type of t if r is empty, such that an unrestricted type can
+// be compared to a restricted one.
+func effectiveRange(t *yang.YangType, r yang.YangRange) yang.YangRange {
+	if len(r) != 0 {
+		return r
+	}
+	base, ok := yang.BaseTypedefs[t.Kind.String()]
+	if !ok {
+		return nil
+	}
+	switch t.Kind {
+	case yang.Ystring, yang.Ybinary:
+		return base.YangType.Length
+	default:
+		return base.YangType.Range
+	}
+}
+
+// typeName returns the name of the type t, followed by its built-in type if
+// it is a typedef.
+func typeName(t *yang.YangType) string {
+	if k := t.Kind.String(); t.Name != k {
+		return fmt.Sprintf("%s (%s)", t.Name, k)
+	}
+	return t.Name
+}
+
+// typeNames returns the names of the types ts as a list.
+func typeNames(ts []*yang.YangType) string {
+	names := make([]string, 0, len(ts))
+	for _, t := range ts {
+		names = append(names, typeName(t))
+	}
+	return fmt.Sprintf("[%s]", strings.Join(names, ", "))
+}
+
+// identityName returns the name of the identity i, qualified with the name
+// of the module that defines it.
+func identityName(i *yang.Identity) string {
+	m := yang.RootNode(i)
+	switch {
+	case m == nil:
+		return i.Name
+	case m.BelongsTo != nil:
+		return fmt.Sprintf("%s:%s", m.BelongsTo.Name, i.Name)
+	default:
+		return fmt.Sprintf("%s:%s", m.Name, i.Name)
+	}
+}
+
+// isMandatory returns true if e is a mandatory node as defined by RFC 7950
+// section 3, that is a leaf, choice, anydata or anyxml with a mandatory
+// statement of true, a list or leaf-list with a non-zero min-elements, or a
+// non-presence container with a mandatory child.
+func isMandatory(e *yang.Entry) bool {
+	switch {
+	case e.ListAttr != nil:
+		return e.ListAttr.MinElements > 0
+	case e.IsLeaf(), e.IsChoice(), util.IsAnydataOrAnyxml(e):
+		return e.Mandatory == yang.TSTrue
+	case e.IsContainer() && len(e.Extra["presence"]) == 0:
+		for _, ch := range e.Dir {
+			if isMandatory(ch) {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// nodeKind returns the name of the kind of the node e.
+func nodeKind(e *yang.Entry) string {
+	switch {
+	case e.Parent == nil:
+		return "module"
+	case e.RPC != nil && e.Parent.Parent == nil:
+		return "rpc"
+	case e.RPC != nil:
+		return "action"
+	case e.Kind == yang.NotificationEntry:
+		return "notification"
+	case e.Kind == yang.InputEntry:
+		return "input"
+	case e.Kind == yang.OutputEntry:
+		return "output"
+	case e.Kind == yang.AnyDataEntry:
+		return "anydata"
+	case e.Kind == yang.AnyXMLEntry:
+		return "anyxml"
+	case e.IsChoice():
+		return "choice"
+	case e.IsCase():
+		return "case"
+	case e.IsList():
+		return "list"
+	case e.IsLeafList():
+		return "leaf-list"
+	case e.IsLeaf():
+		return "leaf"
+	default:
+		return "container"
+	}
+}
+
+// units returns the units of the node e, which are those of its units
+// statement, or otherwise those of its type.
+func units(e *yang.Entry) string {
+	switch l := e.Node.(type) {
+	case *yang.Leaf:
+		if l.Units != nil {
+			return l.Units.Name
+		}
+	case *yang.LeafList:
+		if l.Units != nil {
+			return l.Units.Name
+		}
+	}
+	if e.Type != nil && e.Type.Units != "" {
+		return e.Type.Units
+	}
+	return e.Units
+}
+
+// orderedBy returns the value of the ordered-by statement of the list or
+// leaf-list e.
+func orderedBy(e *yang.Entry) string {
+	if e.ListAttr.OrderedByUser {
+		return "user"
+	}
+	return "system"
+}
+
+// extraNames returns the arguments of the statements of the keyword kw of the
+// node e, such as its if-feature or must statements, which goyang does not
+// otherwise interpret.
+func extraNames(e *yang.Entry, kw string) []string {
+	var names []string
+	for _, v := range e.Extra[kw] {
+		switch s := v.(type) {
+		case *yang.Value:
+			names = append(names, s.Name)
+		case *yang.Must:
+			names = append(names, s.Name)
+		}
+	}
+	return names
+}
@@ -0,0 +1,121 @@
+// Copyright 2023 Google Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package yangcompat
+
+import (
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/openconfig/goyang/pkg/yang"
+)
+
+// mustParseRange parses the YANG range s, failing the test if it is invalid.
+func mustParseRange(t *testing.T, s string) yang.YangRange {
+	t.Helper()
+	r, err := yang.ParseRangesInt(s)
+	if err != nil {
+		t.Fatalf("cannot parse range %q: %v", s, err)
+	}
+	return r
+}
+
+// mustEnum returns an enumeration containing the names supplied, with the
+// values of their position.
+func mustEnum(t *testing.T, names ...string) *yang.EnumType {
+	t.Helper()
+	e := yang.NewEnumType()
+	for _, n := range names {
+		if err := e.SetNext(n); err != nil {
+			t.Fatalf("cannot add enum %s: %v", n, err)
+		}
+	}
+	return e
+}
+
+func TestTypeChanges(t *testing.T) {
+	tests := []struct {
+		name  string
+		inOld *yang.YangType
+		inNew *yang.YangType
+		want  []*Change
+	}{{
+		name:  "unrestricted integer is narrowed",
+		inOld: &yang.YangType{Name: "int8", Kind: yang.Yint8},
+		inNew: &yang.YangType{Name: "int8", Kind: yang.Yint8, Range: mustParseRange(t, "0..10")},
+		want:  []*Change{{Kind: RangeNarrowed, Path: "/m/l", Detail: "range narrowed from -128..127 to 0..10"}},
+	}, {
+		name:  "range split around existing values",
+		inOld: &yang.YangType{Name: "int8", Kind: yang.Yint8, Range: mustParseRange(t, "1..2|5..6")},
+		inNew: &yang.YangType{Name: "int8", Kind: yang.Yint8, Range: mustParseRange(t, "0..3|4..10")},
+	}, {
+		name:  "decimal64 fraction digits changed",
+		inOld: &yang.YangType{Name: "decimal64", Kind: yang.Ydecimal64, FractionDigits: 2},
+		inNew: &yang.YangType{Name: "decimal64", Kind: yang.Ydecimal64, FractionDigits: 3},
+		want:  []*Change{{Kind: TypeChanged, Path: "/m/l", Detail: "fraction-digits changed from 2 to 3"}},
+	}, {
+		name:  "typedef changes built-in type",
+		inOld: &yang.YangType{Name: "counter", Kind: yang.Yuint32},
+		inNew: &yang.YangType{Name: "counter", Kind: yang.Yuint64},
+		want:  []*Change{{Kind: TypeChanged, Path: "/m/l", Detail: "type changed from counter (uint32) to counter (uint64)"}},
+	}, {
+		name:  "bit removed",
+		inOld: &yang.YangType{Name: "bits", Kind: yang.Ybits, Bit: mustEnum(t, "A", "B")},
+		inNew: &yang.YangType{Name: "bits", Kind: yang.Ybits, Bit: mustEnum(t, "A")},
+		want:  []*Change{{Kind: EnumRemoved, Path: "/m/l", Detail: "bit B removed"}},
+	}, {
+		name: "union member appended",
+		inOld: &yang.YangType{Name: "union", Kind: yang.Yunion, Type: []*yang.YangType{
+			{Name: "string", Kind: yang.Ystring},
+		}},
+		inNew: &yang.YangType{Name: "union", Kind: yang.Yunion, Type: []*yang.YangType{
+			{Name: "string", Kind: yang.Ystring},
+			{Name: "int8", Kind: yang.Yint8},
+		}},
+	}, {
+		name: "union members reordered",
+		inOld: &yang.YangType{Name: "union", Kind: yang.Yunion, Type: []*yang.YangType{
+			{Name: "int8", Kind: yang.Yint8},
+			{Name: "string", Kind: yang.Ystring},
+		}},
+		inNew: &yang.YangType{Name: "union", Kind: yang.Yunion, Type: []*yang.YangType{
+			{Name: "string", Kind: yang.Ystring},
+			{Name: "int8", Kind: yang.Yint8},
+		}},
+		want: []*Change{{Kind: TypeChanged, Path: "/m/l", Detail: "union member types changed from [int8, string] to [string, int8]"}},
+	}, {
+		name: "union member narrowed",
+		inOld: &yang.YangType{Name: "union", Kind: yang.Yunion, Type: []*yang.YangType{
+			{Name: "string", Kind: yang.Ystring},
+		}},
+		inNew: &yang.YangType{Name: "union", Kind: yang.Yunion, Type: []*yang.YangType{
+			{Name: "string", Kind: yang.Ystring, Pattern: []string{"a.*"}},
+		}},
+		want: []*Change{{Kind: PatternChanged, Path: "/m/l", Detail: `pattern "a.*" added`}},
+	}, {
+		name:  "leafref target changed",
+		inOld: &yang.YangType{Name: "leafref", Kind: yang.Yleafref, Path: "../a"},
+		inNew: &yang.YangType{Name: "leafref", Kind: yang.Yleafref, Path: "../b"},
+		want:  []*Change{{Kind: TypeChanged, Path: "/m/l", Detail: "leafref path changed from ../a to ../b"}},
+	}}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := typeChanges("/m/l", tt.inOld, tt.inNew)
+			if diff := cmp.Diff(tt.want, got); diff != "" {
+				t.Errorf("typeChanges(%v, %v): did not get expected changes, diff(-want, +got):\n%s", tt.inOld, tt.inNew, diff)
+			}
+		})
+	}
+}
@@ -0,0 +1,112 @@
+// Copyright 2023 Google Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Binary yangcompat compares two versions of a set of YANG modules, and
+// reports the changes between them that are not backwards compatible, both
+// for the YANG schema and for the Go code that ygot generates for it. It exits
+// with a non-zero status if there are any such changes.
+package main
+
+import (
+	"flag"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+
+	log "github.com/golang/glog"
+	"github.com/openconfig/ygot/genutil"
+	"github.com/openconfig/ygot/yangcompat"
+	"github.com/openconfig/ygot/ygen"
+)
+
+var (
+	fromFiles                = flag.String("from", "", "Comma separated list of the YANG files of the original version of the modules.")
+	toFiles                  = flag.String("to", "", "Comma separated list of the YANG files of the updated version of the modules.")
+	fromPaths                = flag.String("from_path", "", "Comma separated list of paths to be recursively searched for the modules and submodules that the original version of the modules import or include.")
+	toPaths                  = flag.String("to_path", "", "Comma separated list of paths to be recursively searched for the modules and submodules that the updated version of the modules import or include.")
+	excludeModules           = flag.String("exclude_modules", "", "Comma separated set of module names that should be excluded from the comparison.")
+	compressPaths            = flag.Bool("compress_paths", false, "If set to true, the Go API that is compared is that generated with compressed schema paths.")
+	excludeState             = flag.Bool("exclude_state", false, "If set to true, the Go API that is compared excludes state (config false) fields.")
+	preferOperationalState   = flag.Bool("prefer_operational_state", false, "If set to true, the Go API that is compared prefers state (config false) fields over intended config leaves with compressed schema paths.")
+	shortenEnumLeafNames     = flag.Bool("shorten_enum_leaf_names", false, "If set to true when compress_paths=true, the names of enumerated types of leaves in the Go API that is compared are not prefixed with the name of their module.")
+	trimEnumOpenConfigPrefix = flag.Bool("trim_enum_openconfig_prefix", false, `If set to true when compress_paths=true, the organizational prefix "openconfig-" is trimmed from the names of enumerated types in the Go API that is compared.`)
+	generateSimpleUnions     = flag.Bool("generate_simple_unions", false, "If set to true, the Go API that is compared represents union subtypes using typedefs rather than wrapper struct types.")
+	ignoreUnsupported        = flag.Bool("ignore_unsupported", false, "If set to true, unsupported YANG statements are ignored.")
+)
+
+// splitList returns the elements of the comma separated list s.
+func splitList(s string) []string {
+	if s == "" {
+		return nil
+	}
+	return strings.Split(s, ",")
+}
+
+// includePaths returns the paths to be recursively searched for the
+// dependencies of a set of modules, given as the comma separated list s.
+func includePaths(s string) []string {
+	var paths []string
+	for _, p := range splitList(s) {
+		paths = append(paths, filepath.Join(p, "..."))
+	}
+	return paths
+}
+
+func main() {
+	flag.Parse()
+	if *fromFiles == "" || *toFiles == "" {
+		log.Exitln("Error: both the from and to modules must be specified")
+	}
+
+	compressBehaviour, err := genutil.TranslateToCompressBehaviour(*compressPaths, *excludeState, *preferOperationalState)
+	if err != nil {
+		log.Exitf("Error: %v", err)
+	}
+	var enumOrgPrefixesToTrim []string
+	if *compressPaths && *trimEnumOpenConfigPrefix {
+		enumOrgPrefixesToTrim = []string{"openconfig"}
+	}
+
+	report, err := yangcompat.Compare(
+		yangcompat.ModuleSet{Files: splitList(*fromFiles), IncludePaths: includePaths(*fromPaths)},
+		yangcompat.ModuleSet{Files: splitList(*toFiles), IncludePaths: includePaths(*toPaths)},
+		yangcompat.Opts{
+			IROptions: ygen.IROptions{
+				ParseOptions: ygen.ParseOpts{
+					ExcludeModules:              splitList(*excludeModules),
+					IgnoreUnsupportedStatements: *ignoreUnsupported,
+				},
+				TransformationOptions: ygen.TransformationOpts{
+					CompressBehaviour:          compressBehaviour,
+					GenerateFakeRoot:           true,
+					ShortenEnumLeafNames:       *shortenEnumLeafNames,
+					EnumOrgPrefixesToTrim:      enumOrgPrefixesToTrim,
+					EnumerationsUseUnderscores: true,
+				},
+			},
+			GenerateSimpleUnions: *generateSimpleUnions,
+		},
+	)
+	if err != nil {
+		log.Exitf("Error comparing modules: %v", err)
+	}
+
+	if !report.Breaking() {
+		fmt.Println("No backwards incompatible changes found.")
+		return
+	}
+	fmt.Print(report)
+	os.Exit(1)
+}
@@ -112,6 +112,19 @@ type TransformationOpts struct {
 	// EnumerationsUseUnderscores specifies whether enumeration names
 	// should use underscores between path segments.
 	EnumerationsUseUnderscores bool
+	// PathAllowlist is a set of uncompressed data tree paths, such as
+	// /interfaces/interface/config/mtu, to which the schema is pruned prior
+	// to code generation. Elements of the paths may contain the wildcards
+	// supported by path.Match. When set, only the nodes matched by the
+	// paths, their descendants and ancestors, and the list keys and
+	// leafref targets that they require are retained. When empty, the
+	// whole schema is retained.
+	PathAllowlist []string
+	// GenerateOperations specifies whether the inputs and outputs of RPCs
+	// and actions, and the bodies of notifications, are mapped to entities
+	// in the generated code and included in the serialised schema. When
+	// unset, rpc, action and notification statements are ignored.
+	GenerateOperations bool
 }
 
 // yangEnum represents an enumerated type in YANG that is to be output in the
@@ -221,6 +234,14 @@ func mappedDefinitions(yangFiles, includePaths []string, opts IROptions) (*mappe
 		return nil, errs
 	}
 
+	// Prune the schema prior to any transformation, such that the paths
+	// are matched against the uncompressed schema.
+	if len(opts.TransformationOptions.PathAllowlist) != 0 {
+		if err := pruneToAllowlist(modules, opts.TransformationOptions.PathAllowlist); err != nil {
+			return nil, []error{err}
+		}
+	}
+
 	// Build a map of excluded modules to simplify lookup.
 	excluded := map[string]bool{}
 	for _, e := range opts.ParseOptions.ExcludeModules {
@@ -236,7 +257,7 @@ func mappedDefinitions(yangFiles, includePaths []string, opts IROptions) (*mappe
 		// Need to transform the AST based on compression behaviour.
 		genutil.TransformEntry(module, opts.TransformationOptions.CompressBehaviour)
 
-		errs = append(errs, findMappableEntities(module, dirs, enums, opts.ParseOptions.ExcludeModules, opts.TransformationOptions.CompressBehaviour.CompressEnabled(), opts.ParseOptions.IgnoreUnsupportedStatements, modules)...)
+		errs = append(errs, findMappableEntities(module, dirs, enums, opts.ParseOptions.ExcludeModules, opts.TransformationOptions.CompressBehaviour.CompressEnabled(), opts.ParseOptions.IgnoreUnsupportedStatements, opts.TransformationOptions.GenerateOperations, modules)...)
 		if module == nil {
 			errs = append(errs, errors.New("found a nil module in the returned module set"))
 			continue
@@ -298,10 +319,11 @@ func mappedDefinitions(yangFiles, includePaths []string, opts IROptions) (*mappe
 // unions containing these types, or typedefs containing these types) are appended to the
 // enums map, which is again keyed by schema path. If any child of the entry is in a module
 // defined in excludeModules, it is skipped. If compressPaths is set to true, then names are
-// mapped with path compression enabled. The set of modules that the current code generation
-// is processing is specified by the modules slice. This function returns slice of errors
+// mapped with path compression enabled. If operations is set to true, the inputs and outputs
+// of RPCs and actions, and the bodies of notifications, are also mapped. The set of modules
+// that the current code generation is processing is specified by the modules slice. This function returns slice of errors
 // encountered during processing.
-func findMappableEntities(e *yang.Entry, dirs map[string]*yang.Entry, enums map[string]*yang.Entry, excludeModules []string, compressPaths, ignoreUnsupportedStatements bool, modules []*yang.Entry) util.Errors {
+func findMappableEntities(e *yang.Entry, dirs map[string]*yang.Entry, enums map[string]*yang.Entry, excludeModules []string, compressPaths, ignoreUnsupportedStatements, operations bool, modules []*yang.Entry) util.Errors {
 	// Skip entities who are defined within a module that we have been instructed
 	// not to generate code for.
 	for _, s := range excludeModules {
@@ -315,6 +337,10 @@ func findMappableEntities(e *yang.Entry, dirs map[string]*yang.Entry, enums map[
 	var errs util.Errors
 	for _, ch := range util.Children(e) {
 		switch {
+		case operations && util.IsOperation(ch):
+			// Notifications are mapped along with RPCs and actions
+			// below.
+			continue
 		case ch.IsLeaf(), ch.IsLeafList():
 			// Leaves are not mapped as directories so do not map them unless we find
 			// something that will be an enumeration - so that we can deal with this
@@ -326,12 +352,12 @@ func findMappableEntities(e *yang.Entry, dirs map[string]*yang.Entry, enums map[
 			// If this is a config or state container and we are compressing paths
 			// then we do not want to map this container - but we do want to map its
 			// children.
-			errs = util.AppendErrs(errs, findMappableEntities(ch, dirs, enums, excludeModules, compressPaths, ignoreUnsupportedStatements, modules))
+			errs = util.AppendErrs(errs, findMappableEntities(ch, dirs, enums, excludeModules, compressPaths, ignoreUnsupportedStatements, operations, modules))
 		case util.HasOnlyChild(ch) && util.Children(ch)[0].IsList() && compressPaths:
 			// This is a surrounding container for a list, and we are compressing
 			// paths, so we don't want to map it but again we do want to map its
 			// children.
-			errs = util.AppendErrs(errs, findMappableEntities(ch, dirs, enums, excludeModules, compressPaths, ignoreUnsupportedStatements, modules))
+			errs = util.AppendErrs(errs, findMappableEntities(ch, dirs, enums, excludeModules, compressPaths, ignoreUnsupportedStatements, operations, modules))
 		case util.IsChoiceOrCase(ch):
 			// Don't map for a choice or case node itself, and rather skip over it.
 			// However, we must walk each branch to find the first container that
@@ -351,13 +377,15 @@ func findMappableEntities(e *yang.Entry, dirs map[string]*yang.Entry, enums map[
 				if gch.IsContainer() || gch.IsList() {
 					dirs[fmt.Sprintf("%s/%s", ch.Parent.Path(), gch.Name)] = gch
 				}
-				errs = util.AppendErrs(errs, findMappableEntities(gch, dirs, enums, excludeModules, compressPaths, ignoreUnsupportedStatements, modules))
+				errs = util.AppendErrs(errs, findMappableEntities(gch, dirs, enums, excludeModules, compressPaths, ignoreUnsupportedStatements, operations, modules))
 			}
 		case ch.IsContainer(), ch.IsList():
 			dirs[ch.Path()] = ch
 			// Recurse down the tree.
-			errs = util.AppendErrs(errs, findMappableEntities(ch, dirs, enums, excludeModules, compressPaths, ignoreUnsupportedStatements, modules))
-		case ch.Kind == yang.AnyDataEntry:
+			errs = util.AppendErrs(errs, findMappableEntities(ch, dirs, enums, excludeModules, compressPaths, ignoreUnsupportedStatements, operations, modules))
+		case util.IsAnydataOrAnyxml(ch):
+			// anydata and anyxml nodes are fields of their parent, and
+			// have no children that are described by the schema.
 			continue
 		default:
 			if ignoreUnsupportedStatements {
@@ -367,6 +395,30 @@ func findMappableEntities(e *yang.Entry, dirs map[string]*yang.Entry, enums map[
 			errs = util.AppendErr(errs, fmt.Errorf("unsupported statement type (%v) in findMappableEntities for %s", ch.Kind, ch.Path()))
 		}
 	}
+
+	if !operations {
+		return errs
+	}
+	// RPCs, actions and notifications are not part of the data tree, but the
+	// input and output of an RPC or action, and the body of a notification,
+	// are mapped in the same way as containers.
+	for _, op := range util.Operations(e) {
+		var opDirs []*yang.Entry
+		switch {
+		case op.RPC != nil:
+			for _, io := range []*yang.Entry{op.RPC.Input, op.RPC.Output} {
+				if io != nil {
+					opDirs = append(opDirs, io)
+				}
+			}
+		default:
+			opDirs = append(opDirs, op)
+		}
+		for _, d := range opDirs {
+			dirs[d.Path()] = d
+			errs = util.AppendErrs(errs, findMappableEntities(d, dirs, enums, excludeModules, compressPaths, ignoreUnsupportedStatements, operations, modules))
+		}
+	}
 	return errs
 }
 
@@ -32,6 +32,7 @@ func TestFindMappableEntities(t *testing.T) {
 		inSkipModules                 []string      // inSkipModules is a slice of strings indicating modules to be skipped.
 		inModules                     []*yang.Entry // inModules is the set of modules that the code generation is for.
 		inIgnoreUnsupportedStatements bool          // inIgnoreUnsupportedStatements determines whether unsupported statements should error out.
+		inOperations                  bool          // inOperations determines whether RPCs, actions and notifications are mapped.
 		// wantCompressed is a map keyed by the string "structs" or "enums" which contains a slice
 		// of the YANG identifiers for the corresponding mappable entities that should be
 		// found. wantCompressed is the set that are expected when compression is enabled.
@@ -140,6 +141,80 @@ func TestFindMappableEntities(t *testing.T) {
 			"structs": {"base", "config", "state"},
 			"enums":   {},
 		},
+	}, {
+		name: "operations",
+		in: func() *yang.Entry {
+			action := &yang.Entry{
+				Name: "action",
+				Kind: yang.DirectoryEntry,
+				RPC: &yang.RPCEntry{
+					Input: &yang.Entry{
+						Name: "input",
+						Kind: yang.InputEntry,
+						Dir:  map[string]*yang.Entry{},
+					},
+				},
+			}
+			rpc := &yang.Entry{
+				Name: "rpc",
+				Kind: yang.DirectoryEntry,
+				RPC: &yang.RPCEntry{
+					Input: &yang.Entry{
+						Name: "input",
+						Kind: yang.InputEntry,
+						Dir: map[string]*yang.Entry{
+							"inner": {
+								Name: "inner",
+								Kind: yang.DirectoryEntry,
+								Dir:  map[string]*yang.Entry{},
+							},
+						},
+					},
+					Output: &yang.Entry{
+						Name: "output",
+						Kind: yang.OutputEntry,
+						Dir:  map[string]*yang.Entry{},
+					},
+				},
+			}
+			m := &yang.Entry{
+				Name: "module",
+				Kind: yang.DirectoryEntry,
+				Dir: map[string]*yang.Entry{
+					"base": {
+						Name: "base",
+						Kind: yang.DirectoryEntry,
+						Dir: map[string]*yang.Entry{
+							"action": action,
+						},
+					},
+					"rpc": rpc,
+					"notification": {
+						Name: "notification",
+						Kind: yang.NotificationEntry,
+						Dir:  map[string]*yang.Entry{},
+					},
+				},
+			}
+			m.Dir["base"].Parent = m
+			action.Parent = m.Dir["base"]
+			action.RPC.Input.Parent = action
+			rpc.Parent = m
+			rpc.RPC.Input.Parent = rpc
+			rpc.RPC.Input.Dir["inner"].Parent = rpc.RPC.Input
+			rpc.RPC.Output.Parent = rpc
+			m.Dir["notification"].Parent = m
+			return m
+		}(),
+		inOperations: true,
+		wantCompressed: map[string][]string{
+			"structs": {"base", "input", "inner", "output", "notification"},
+			"enums":   {},
+		},
+		wantUncompressed: map[string][]string{
+			"structs": {"base", "input", "inner", "output", "notification"},
+			"enums":   {},
+		},
 	}, {
 		name: "enum-test",
 		in: &yang.Entry{
@@ -407,7 +482,7 @@ func TestFindMappableEntities(t *testing.T) {
 			structs := make(map[string]*yang.Entry)
 			enums := make(map[string]*yang.Entry)
 
-			errs := findMappableEntities(tt.in, structs, enums, tt.inSkipModules, compress, tt.inIgnoreUnsupportedStatements, tt.inModules)
+			errs := findMappableEntities(tt.in, structs, enums, tt.inSkipModules, compress, tt.inIgnoreUnsupportedStatements, tt.inOperations, tt.inModules)
 
 			var err error
 			switch {
@@ -180,6 +180,7 @@ func getOrderedDirDetails(langMapper LangMapper, directory map[string]*Directory
 			DefiningModule:    definingModuleName,
 			RootElementModule: rootModule,
 			ConfigFalse:       !util.IsConfig(dir.Entry),
+			InOperation:       util.IsOperationData(dir.Entry),
 		}
 		switch {
 		case dir.Entry.IsList():
@@ -267,6 +268,7 @@ func getOrderedDirDetails(langMapper LangMapper, directory map[string]*Directory
 					LeafrefTargetPath: target.Path(),
 					Description:       field.Description,
 					ConfigFalse:       !util.IsConfig(field),
+					Choices:           choiceCases(field),
 				},
 				MappedPaths:             mp,
 				MappedPathModules:       mm,
@@ -294,7 +296,7 @@ func getOrderedDirDetails(langMapper LangMapper, directory map[string]*Directory
 			case field.IsList():
 				nd.Type = ListNode
 				nd.YANGDetails.OrderedByUser = field.ListAttr.OrderedByUser
-			case util.IsAnydata(field):
+			case util.IsAnydataOrAnyxml(field):
 				nd.Type = AnyDataNode
 			case field.IsContainer():
 				nd.Type = ContainerNode
@@ -326,6 +328,23 @@ func getOrderedDirDetails(langMapper LangMapper, directory map[string]*Directory
 	return dirDets, nil
 }
 
+// choiceCases returns the choice and case statements within which the
+// supplied entry is defined, ordered from the outermost choice. It returns
+// nil if the entry is not within a choice.
+func choiceCases(e *yang.Entry) []*YANGChoiceCase {
+	var cs []*YANGChoiceCase
+	for n := e; n.Parent != nil && util.IsChoiceOrCase(n.Parent); n = n.Parent {
+		if !n.Parent.IsChoice() {
+			continue
+		}
+		// A case specified using the shorthand syntax is not always
+		// represented by its own entry, in which case it is named after
+		// the node it contains.
+		cs = append([]*YANGChoiceCase{{Choice: n.Parent.Name, Case: n.Name}}, cs...)
+	}
+	return cs
+}
+
 // FindSchemaPath finds the relative or absolute schema path of a given field
 // of a Directory. The Field is specified as a name in order to guarantee its
 // existence before processing.
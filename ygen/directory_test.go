@@ -277,6 +277,60 @@ func findEntry(t *testing.T, ms *yang.Modules, moduleName, path string) *yang.En
 	return entry
 }
 
+func TestChoiceCases(t *testing.T) {
+	ms := compileModules(t, map[string]string{
+		"module": `
+			module module {
+				prefix "m";
+				namespace "urn:m";
+
+				container foo {
+					leaf bar { type string; }
+					choice transport {
+						case tcp {
+							leaf tcp-port { type uint16; }
+						}
+						case udp {
+							choice mode {
+								leaf lite { type boolean; }
+							}
+						}
+					}
+				}
+			}
+		`,
+	})
+
+	tests := []struct {
+		desc   string
+		inPath string
+		want   []*YANGChoiceCase
+	}{{
+		desc:   "not within a choice",
+		inPath: "foo/bar",
+	}, {
+		desc:   "within a case",
+		inPath: "foo/transport/tcp/tcp-port",
+		want:   []*YANGChoiceCase{{Choice: "transport", Case: "tcp"}},
+	}, {
+		desc:   "within a shorthand case of a nested choice",
+		inPath: "foo/transport/udp/mode/lite/lite",
+		want: []*YANGChoiceCase{
+			{Choice: "transport", Case: "udp"},
+			{Choice: "mode", Case: "lite"},
+		},
+	}}
+
+	for _, tt := range tests {
+		t.Run(tt.desc, func(t *testing.T) {
+			got := choiceCases(findEntry(t, ms, "module", tt.inPath))
+			if diff := cmp.Diff(tt.want, got); diff != "" {
+				t.Errorf("did not get expected choices and cases, (-want, +got):\n%s", diff)
+			}
+		})
+	}
+}
+
 func TestFindSchemaPath(t *testing.T) {
 	ms := compileModules(t, map[string]string{
 		"module": `
@@ -65,6 +65,11 @@ type enumSet struct {
 	// a module such as openconfig-bgp which defines /bgp and is also used at
 	// /network-instances/network-instance/protocols/protocol/bgp.
 	uniqueEnumeratedLeafNames map[string]string
+	// valueNames is a map, keyed by the key of an enumerated type, of the
+	// names of the enumerated values of the type that are overridden.
+	// The value of the map maps the YANG name of each overridden value to
+	// its name.
+	valueNames map[string]map[string]string
 }
 
 // newEnumSet initializes a new empty enumSet instance.
@@ -73,6 +78,7 @@ func newEnumSet() *enumSet {
 		uniqueIdentityNames:          map[string]string{},
 		uniqueEnumeratedTypedefNames: map[string]string{},
 		uniqueEnumeratedLeafNames:    map[string]string{},
+		valueNames:                   map[string]map[string]string{},
 	}
 }
 
@@ -415,7 +421,7 @@ func enumIdentifier(e *yang.Entry, compressPaths bool) string {
 // into a common type.
 // The returned enumSet can be used to query for enum/identity names.
 // The returned map is the set of generated enums to be used for enum code generation.
-func findEnumSet(entries map[string]*yang.Entry, compressPaths, noUnderscores, skipEnumDedup, shortenEnumLeafNames, useDefiningModuleForTypedefEnumNames, appendEnumSuffixForSimpleUnionEnums bool, enumOrgPrefixesToTrim []string) (*enumSet, map[string]*yangEnum, []error) {
+func findEnumSet(entries map[string]*yang.Entry, compressPaths, noUnderscores, skipEnumDedup, shortenEnumLeafNames, useDefiningModuleForTypedefEnumNames, appendEnumSuffixForSimpleUnionEnums bool, enumOrgPrefixesToTrim []string, lock *NameLock, overrides *NameOverrides) (*enumSet, map[string]*yangEnum, []error) {
 	validEnums := make(map[string]*yang.Entry)
 	var enumPaths []string
 	var errs []error
@@ -518,6 +524,29 @@ func findEnumSet(entries map[string]*yang.Entry, compressPaths, noUnderscores, s
 		return nil, nil, append(errs, err)
 	}
 
+	// Replace the generated names with any names that are locked, such
+	// that existing types keep their names regardless of any clashes
+	// that were resolved above.
+	if lock != nil {
+		defaultNames := map[string]string{}
+		for name, clashSet := range s.enumeratedLeafNameClashSets {
+			for k := range clashSet {
+				defaultNames[k] = name
+			}
+		}
+		if err := s.enumSet.applyNameLock(lock, defaultNames); err != nil {
+			return nil, nil, append(errs, err)
+		}
+	}
+
+	// Replace the generated names with any names that are overridden,
+	// which take precedence over those that are locked.
+	if overrides != nil {
+		if err := s.enumSet.applyNameOverrides(validEnums, overrides, compressPaths, noUnderscores, skipEnumDedup, shortenEnumLeafNames, useDefiningModuleForTypedefEnumNames, enumOrgPrefixesToTrim); err != nil {
+			return nil, nil, append(errs, err)
+		}
+	}
+
 	// This is the second and final pass over the input enum entries.
 	// During this pass, the generated names are retrieved and packaged
 	// into yangEnum entries.
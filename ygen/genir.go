@@ -53,6 +53,18 @@ type IROptions struct {
 	// to true.
 	// NOTE: This flag will be removed by v1 release.
 	AppendEnumSuffixForSimpleUnionEnums bool
+
+	// NameLock, if set, specifies the names that are assigned to
+	// directories, enumerated types and unions, such that names remain
+	// stable when the input modules change. When it is set, name clashes
+	// are reported as errors rather than being resolved by renaming.
+	NameLock *NameLock
+
+	// NameOverrides, if set, specifies names that are used in place of
+	// those that are generated by default for directories, fields,
+	// enumerated types and their values, and unions. Overridden names
+	// take precedence over those within NameLock.
+	NameOverrides *NameOverrides
 }
 
 // GenerateIR creates the ygen intermediate representation for a set of
@@ -76,13 +88,15 @@ func GenerateIR(yangFiles, includePaths []string, langMapper LangMapper, opts IR
 		return nil, errs
 	}
 
-	enumSet, genEnums, errs := findEnumSet(mdef.enumEntries, opts.TransformationOptions.CompressBehaviour.CompressEnabled(), !opts.TransformationOptions.EnumerationsUseUnderscores, opts.TransformationOptions.SkipEnumDeduplication, opts.TransformationOptions.ShortenEnumLeafNames, opts.TransformationOptions.UseDefiningModuleForTypedefEnumNames, opts.AppendEnumSuffixForSimpleUnionEnums, opts.TransformationOptions.EnumOrgPrefixesToTrim)
+	enumSet, genEnums, errs := findEnumSet(mdef.enumEntries, opts.TransformationOptions.CompressBehaviour.CompressEnabled(), !opts.TransformationOptions.EnumerationsUseUnderscores, opts.TransformationOptions.SkipEnumDeduplication, opts.TransformationOptions.ShortenEnumLeafNames, opts.TransformationOptions.UseDefiningModuleForTypedefEnumNames, opts.AppendEnumSuffixForSimpleUnionEnums, opts.TransformationOptions.EnumOrgPrefixesToTrim, opts.NameLock, opts.NameOverrides)
 	if errs != nil {
 		return nil, errs
 	}
 
 	langMapper.setEnumSet(enumSet)
 	langMapper.setSchemaTree(mdef.schematree)
+	langMapper.setNameLock(opts.NameLock)
+	langMapper.setNameOverrides(opts.NameOverrides)
 
 	directoryMap, errs := buildDirectoryDefinitions(langMapper, mdef.directoryEntries, opts)
 	if errs != nil {
@@ -100,6 +114,23 @@ func GenerateIR(yangFiles, includePaths []string, langMapper LangMapper, opts IR
 	if err != nil {
 		return nil, util.AppendErr(errs, err)
 	}
+	if opts.NameLock != nil || opts.NameOverrides != nil {
+		if err := checkUnionNames(dirDets); err != nil {
+			if opts.NameLock != nil {
+				err = fmt.Errorf("name lock: %v; assign a unique name to the new union in the name lock", err)
+			} else {
+				err = fmt.Errorf("name overrides: %v", err)
+			}
+			return nil, util.AppendErr(errs, err)
+		}
+	}
+
+	var operations map[string]*ParsedOperation
+	if opts.TransformationOptions.GenerateOperations {
+		if operations, err = buildOperations(mdef.modules, directoryMap); err != nil {
+			return nil, util.AppendErr(errs, err)
+		}
+	}
 
 	var enumDefinitionMap map[string]*EnumeratedYANGType
 	if len(genEnums) != 0 {
@@ -168,6 +199,7 @@ func GenerateIR(yangFiles, includePaths []string, langMapper LangMapper, opts IR
 			}
 		}
 
+		et.ValueNames = enumSet.valueNames[enum.id]
 		et.Flags = langMapper.PopulateEnumFlags(*et, enum.entry.Type)
 
 		enumDefinitionMap[enum.id] = et
@@ -177,12 +209,83 @@ func GenerateIR(yangFiles, includePaths []string, langMapper LangMapper, opts IR
 		return nil, errs
 	}
 
+	if opts.NameOverrides != nil {
+		if err := checkNameOverrides(opts.NameOverrides, dirDets); err != nil {
+			return nil, util.AppendErr(errs, err)
+		}
+	}
+
 	return &IR{
 		Directories:   dirDets,
 		Enums:         enumDefinitionMap,
 		ModelData:     mdef.modelData,
+		Operations:    operations,
 		opts:          opts,
 		fakeroot:      rootEntry,
 		parsedModules: mdef.modules,
 	}, nil
 }
+
+// buildOperations returns the RPCs and notifications defined at the top-level
+// of the supplied modules, and the actions and notifications defined within
+// the supplied directories, keyed by the absolute YANG path of the operation.
+// The input, output and body of each operation are expected to be within the
+// directory map if they are defined. It returns nil if there are no
+// operations.
+func buildOperations(modules []*yang.Entry, directoryMap map[string]*Directory) (map[string]*ParsedOperation, error) {
+	var opEntries []*yang.Entry
+	for _, m := range modules {
+		opEntries = append(opEntries, util.Operations(m)...)
+	}
+	for _, dirPath := range GetOrderedPathDirectories(directoryMap) {
+		if dir := directoryMap[dirPath]; !dir.IsFakeRoot {
+			opEntries = append(opEntries, util.Operations(dir.Entry)...)
+		}
+	}
+
+	var ops map[string]*ParsedOperation
+	for _, e := range opEntries {
+		belongingModule, err := e.InstantiatingModule()
+		if err != nil {
+			return nil, fmt.Errorf("ygen: cannot find instantiating module for operation %s: %v", e.Path(), err)
+		}
+		op := &ParsedOperation{
+			Name:            e.Name,
+			Path:            e.Path(),
+			SchemaPath:      util.SchemaTreePathNoModule(e),
+			BelongingModule: belongingModule,
+		}
+
+		switch {
+		case e.Kind == yang.NotificationEntry:
+			op.Type = ygot.NotificationOperation
+			if _, ok := directoryMap[e.Path()]; !ok {
+				return nil, fmt.Errorf("ygen: cannot find directory for notification %s", e.Path())
+			}
+		case util.IsRoot(e.Parent):
+			op.Type = ygot.RPCOperation
+		default:
+			op.Type = ygot.ActionOperation
+		}
+		if e.RPC != nil {
+			for _, io := range []struct {
+				e   *yang.Entry
+				dst *string
+			}{{e.RPC.Input, &op.InputPath}, {e.RPC.Output, &op.OutputPath}} {
+				if io.e == nil {
+					continue
+				}
+				if _, ok := directoryMap[io.e.Path()]; !ok {
+					return nil, fmt.Errorf("ygen: cannot find directory for %s", io.e.Path())
+				}
+				*io.dst = io.e.Path()
+			}
+		}
+
+		if ops == nil {
+			ops = map[string]*ParsedOperation{}
+		}
+		ops[op.Path] = op
+	}
+	return ops, nil
+}
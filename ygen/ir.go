@@ -106,6 +106,14 @@ type LangMapperBaseSetup interface {
 	// their target leaves.
 	setSchemaTree(*yangschema.Tree)
 
+	// setNameLock is used to supply the names that are locked for the
+	// directories and unions that are named by the mapper, if any.
+	setNameLock(*NameLock)
+
+	// setNameOverrides is used to supply the names that are overridden
+	// for the entities that are named by the mapper, if any.
+	setNameOverrides(*NameOverrides)
+
 	// InjectEnumSet is intended to be called by unit tests in order to set up the
 	// LangMapperBase such that generated enumeration/identity names can be looked
 	// up. The input parameters correspond to fields in IROptions.
@@ -130,6 +138,14 @@ type LangMapperBase struct {
 	// schematree is a copy of the YANG schema tree, containing only leaf
 	// entries, such that schema paths can be referenced.
 	schematree *yangschema.Tree
+
+	// nameLock contains the names that are locked, or is nil if names
+	// are not locked.
+	nameLock *NameLock
+
+	// nameOverrides contains the names that are overridden, or is nil if
+	// no names are overridden.
+	nameOverrides *NameOverrides
 }
 
 // setEnumSet is used to supply a set of enumerated values to the
@@ -155,6 +171,70 @@ func (s *LangMapperBase) setSchemaTree(st *yangschema.Tree) {
 	s.schematree = st
 }
 
+// setNameLock is used to supply the names that are locked for the
+// directories and unions that are named by the mapper, if any.
+//
+// NB: This method is a set-up method that GenerateIR automatically invokes.
+func (s *LangMapperBase) setNameLock(l *NameLock) {
+	s.nameLock = l
+}
+
+// NameLock returns the names that are locked for the entities that are named
+// by the mapper, or nil if names are not locked. A LangMapper that supports
+// name locking assigns the locked name to each directory or union that has
+// one, and returns an error rather than renaming an entity whose name would
+// otherwise clash with another name.
+func (s *LangMapperBase) NameLock() *NameLock {
+	return s.nameLock
+}
+
+// setNameOverrides is used to supply the names that are overridden for the
+// entities that are named by the mapper, if any.
+//
+// NB: This method is a set-up method that GenerateIR automatically invokes.
+func (s *LangMapperBase) setNameOverrides(o *NameOverrides) {
+	s.nameOverrides = o
+}
+
+// NameOverrides returns the names that are overridden for the entities that
+// are named by the mapper, or nil if no names are overridden. A LangMapper
+// that supports name overrides assigns the overridden name to each directory,
+// field or union that has one, and returns an error if it clashes with
+// another name.
+func (s *LangMapperBase) NameOverrides() *NameOverrides {
+	return s.nameOverrides
+}
+
+// EnumValueNames returns the names of the values of the enumerated type with
+// the supplied key that are overridden, keyed by the YANG name of each value,
+// or nil if no value names are overridden. The key is that returned by the
+// methods that retrieve the name of an enumerated type, such as EnumName.
+func (s *LangMapperBase) EnumValueNames(key string) map[string]string {
+	if s.enumSet == nil {
+		return nil
+	}
+	return s.enumSet.valueNames[key]
+}
+
+// IsEnumeratedTypeName returns true if name is the name that is assigned to
+// an enumerated type, i.e., an enumeration leaf, a typedef of an enumerated
+// type, or the base of an identityref, excluding any prefix that is added by
+// the language. It is used to check that names do not clash with those of
+// enumerated types.
+func (s *LangMapperBase) IsEnumeratedTypeName(name string) bool {
+	if s.enumSet == nil {
+		return false
+	}
+	for _, m := range []map[string]string{s.enumSet.uniqueIdentityNames, s.enumSet.uniqueEnumeratedTypedefNames, s.enumSet.uniqueEnumeratedLeafNames} {
+		for _, n := range m {
+			if n == name {
+				return true
+			}
+		}
+	}
+	return false
+}
+
 // InjectEnumSet is intended to be called by unit tests in order to set up the
 // LangMapperBase such that generated enumeration/identity names can be looked
 // up. It walks the input map of enumerated value leaves keyed by path and
@@ -163,7 +243,7 @@ func (s *LangMapperBase) setSchemaTree(st *yangschema.Tree) {
 // It returns an error if there is a failure to generate the enumerated values'
 // names.
 func (s *LangMapperBase) InjectEnumSet(entries map[string]*yang.Entry, compressPaths, noUnderscores, skipEnumDedup, shortenEnumLeafNames, useDefiningModuleForTypedefEnumNames, appendEnumSuffixForSimpleUnionEnums bool, enumOrgPrefixesToTrim []string) error {
-	enumSet, _, errs := findEnumSet(entries, compressPaths, noUnderscores, skipEnumDedup, shortenEnumLeafNames, useDefiningModuleForTypedefEnumNames, appendEnumSuffixForSimpleUnionEnums, enumOrgPrefixesToTrim)
+	enumSet, _, errs := findEnumSet(entries, compressPaths, noUnderscores, skipEnumDedup, shortenEnumLeafNames, useDefiningModuleForTypedefEnumNames, appendEnumSuffixForSimpleUnionEnums, enumOrgPrefixesToTrim, nil, nil)
 	if errs != nil {
 		return fmt.Errorf("%v", errs)
 	}
@@ -302,6 +382,11 @@ type IR struct {
 	// ModelData stores the metadata extracted from the input YANG modules.
 	ModelData []*gpb.ModelData
 
+	// Operations is the set of YANG RPCs, actions and notifications whose
+	// inputs, outputs or bodies are included within Directories. They are
+	// keyed by the absolute YANG path of the operation.
+	Operations map[string]*ParsedOperation
+
 	// opts stores the IROptions that were used to generate the IR.
 	opts IROptions
 
@@ -312,6 +397,16 @@ type IR struct {
 	// fakeroot stores the fake root's AST node for creating a serialized
 	// version of the AST if needed.
 	fakeroot *yang.Entry
+
+	// loaded indicates that the IR was reconstructed from its serialised
+	// form by UnmarshalIR, and hence that parsedModules is not available.
+	loaded bool
+
+	// rawSchemaTree stores the serialised schema tree of a loaded IR, if it
+	// was included when the IR was serialised. rawSchemaTreeDescribed
+	// indicates whether it contains the descriptions of YANG nodes.
+	rawSchemaTree          []byte
+	rawSchemaTreeDescribed bool
 }
 
 // OrderedDirectoryPaths returns the absolute YANG paths of all ParsedDirectory
@@ -353,6 +448,32 @@ func (ir *IR) OrderedDirectoryPathsByName() []string {
 	return paths
 }
 
+// OrderedOperationPaths returns the absolute YANG paths of all ParsedOperation
+// entries in the IR in lexicographical order.
+func (ir *IR) OrderedOperationPaths() []string {
+	if ir == nil {
+		return nil
+	}
+
+	paths := make([]string, 0, len(ir.Operations))
+	for path := range ir.Operations {
+		paths = append(paths, path)
+	}
+	sort.Strings(paths)
+	return paths
+}
+
+// Modules returns the schema trees of the modules that the IR was generated
+// for, such that tools built on the IR can inspect the details of the schema
+// that are not part of the IR. It returns nil for an IR that was loaded using
+// UnmarshalIR.
+func (ir *IR) Modules() []*yang.Entry {
+	if ir == nil || ir.loaded {
+		return nil
+	}
+	return ir.parsedModules
+}
+
 // SchemaTree returns a JSON serialised tree of the schema for the set of
 // modules used to generate the IR. The JSON document that is returned is
 // always rooted on a yang.Entry which corresponds to the root item, and stores
@@ -361,12 +482,26 @@ func (ir *IR) OrderedDirectoryPathsByName() []string {
 // they correspond to in the generated code, and the absolute schema path that
 // the entry corresponds to. In the case that there is not a fake root struct,
 // a synthetic root entry is used to store the schema tree.
+//
+// For an IR that was loaded using UnmarshalIR, the schema tree is available
+// only if it was included, with the same inclDescriptions value, when the IR
+// was serialised.
 func (ir *IR) SchemaTree(inclDescriptions bool) ([]byte, error) {
+	if ir.loaded {
+		switch {
+		case ir.rawSchemaTree == nil:
+			return nil, fmt.Errorf("schema tree was not included in the serialised IR")
+		case ir.rawSchemaTreeDescribed != inclDescriptions:
+			return nil, fmt.Errorf("schema tree in the serialised IR was not generated with inclDescriptions=%v", inclDescriptions)
+		}
+		return ir.rawSchemaTree, nil
+	}
+
 	dirNames := make(map[string]string, len(ir.Directories))
 	for p, d := range ir.Directories {
 		dirNames[p] = d.Name
 	}
-	rawSchema, err := buildJSONTree(ir.parsedModules, dirNames, ir.fakeroot, ir.opts.TransformationOptions.CompressBehaviour.CompressEnabled(), inclDescriptions)
+	rawSchema, err := buildJSONTree(ir.parsedModules, dirNames, ir.fakeroot, ir.opts.TransformationOptions.CompressBehaviour.CompressEnabled(), inclDescriptions, ir.opts.TransformationOptions.GenerateOperations)
 	if err != nil {
 		return nil, err
 	}
@@ -468,6 +603,37 @@ type ParsedDirectory struct {
 	//
 	// https://github.com/openconfig/public/blob/master/release/models/openconfig-extensions.yang#L154
 	CompressedTelemetryAtomic bool
+	// InOperation indicates that the directory is the input or output of
+	// a YANG RPC or action, or the body of a notification, or is a
+	// descendant of such a node. These directories are not part of the
+	// data tree, and hence cannot be addressed by a data tree path.
+	InOperation bool
+}
+
+// ParsedOperation describes a YANG rpc, action or notification statement
+// whose contents are mapped to directories in the IR.
+type ParsedOperation struct {
+	// Name is the YANG identifier of the operation.
+	Name string
+	// Type is the kind of YANG statement that defines the operation.
+	Type ygot.OperationType
+	// Path specifies the absolute YANG schema path of the operation.
+	Path string
+	// SchemaPath specifies the absolute YANG schema node path of the
+	// operation. It does not include the module name nor choice/case
+	// elements in the YANG file. For an action, it is the path of the data
+	// tree node with which the action is associated, followed by the name
+	// of the action.
+	SchemaPath string
+	// BelongingModule is the name of the module having the same XML
+	// namespace as the operation.
+	BelongingModule string
+	// InputPath and OutputPath are the keys within the IR's Directories
+	// of the input and output of an RPC or action. They are empty if
+	// the operation has no input or output. The body of a notification is
+	// the directory whose key is the Path of the operation.
+	InputPath  string
+	OutputPath string
 }
 
 // OrderedFieldNames returns the YANG name of all fields belonging to the
@@ -606,7 +772,7 @@ const (
 	LeafNode
 	// LeafListNode represents a YANG 'leaf-list'.
 	LeafListNode
-	// AnyDataNode represents a YANG 'anydata'.
+	// AnyDataNode represents a YANG 'anydata' or 'anyxml'.
 	AnyDataNode
 )
 
@@ -709,6 +875,22 @@ type YANGNodeDetails struct {
 	// statement in YANG:
 	// https://datatracker.ietf.org/doc/html/rfc7950#section-7.21.1
 	ConfigFalse bool
+	// Choices describes the choice and case statements within which the
+	// node is defined, ordered from the outermost choice. Only those
+	// choices that are between the node and its closest ancestor data
+	// tree node are included, and it is empty if the node is not within
+	// a choice.
+	Choices []*YANGChoiceCase
+}
+
+// YANGChoiceCase describes a case of a YANG choice statement.
+type YANGChoiceCase struct {
+	// Choice is the name of the choice statement.
+	Choice string
+	// Case is the name of the case statement. For a case that is
+	// specified using the shorthand syntax, it is the name of the node
+	// within the case.
+	Case string
 }
 
 // EnumeratedValueType is used to indicate the source YANG type
@@ -778,6 +960,10 @@ type EnumeratedYANGType struct {
 	// and its YANG-specific details (as defined by the
 	// ygot.EnumDefinition).
 	ValToYANGDetails []ygot.EnumDefinition
+	// ValueNames maps the YANG name of each enumerated value whose name
+	// in the generated code is overridden to that name. It is nil if no
+	// value names are overridden.
+	ValueNames map[string]string
 	// Flags contains extra information that can be populated by the
 	// LangMapper during IR generation to assist the code generation stage.
 	// Specifically, this field is set by the
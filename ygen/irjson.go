@@ -0,0 +1,628 @@
+// Copyright 2023 Google Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package ygen
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"strings"
+
+	gpb "github.com/openconfig/gnmi/proto/gnmi"
+	"github.com/openconfig/ygot/ygot"
+)
+
+// This file describes the serialised form of the IR, which allows code
+// generators that are not written in Go to consume the output of ygen, and
+// allows Go generators to be run against an IR that was produced earlier.
+//
+// The serialised form is a JSON document whose structure is defined by the
+// ir* types below rather than the Go types of the IR, such that it remains
+// stable when the Go types change. Enumerated values within the IR, such as
+// DirType, are written as strings. Any incompatible change to the document
+// must increment IRFormatVersion.
+
+// IRFormatVersion is the version of the serialised IR document that is
+// written by MarshalIR. UnmarshalIR only accepts documents of this version.
+const IRFormatVersion = 1
+
+// MarshalIROpts stores options that control the serialisation of the IR.
+type MarshalIROpts struct {
+	// IncludeSchemaTree specifies whether the JSON schema tree returned by
+	// IR.SchemaTree is included in the serialised document, such that it
+	// is also available from an IR that is loaded using UnmarshalIR.
+	IncludeSchemaTree bool
+	// IncludeDescriptions specifies whether the included schema tree
+	// contains the descriptions of YANG nodes.
+	IncludeDescriptions bool
+}
+
+// irDocument is the top-level JSON object of a serialised IR.
+type irDocument struct {
+	FormatVersion          int                     `json:"format_version"`
+	Directories            map[string]*irDirectory `json:"directories,omitempty"`
+	Enums                  map[string]*irEnum      `json:"enums,omitempty"`
+	ModelData              []*irModelData          `json:"model_data,omitempty"`
+	Operations             map[string]*irOperation `json:"operations,omitempty"`
+	SchemaTree             json.RawMessage         `json:"schema_tree,omitempty"`
+	SchemaTreeDescriptions bool                    `json:"schema_tree_descriptions,omitempty"`
+}
+
+// irDirectory is the serialised form of a ParsedDirectory.
+type irDirectory struct {
+	Name                      string                `json:"name"`
+	Type                      string                `json:"type"`
+	Path                      string                `json:"path"`
+	SchemaPath                string                `json:"schema_path"`
+	Fields                    map[string]*irField   `json:"fields,omitempty"`
+	ListKeys                  map[string]*irListKey `json:"list_keys,omitempty"`
+	ListKeyYANGNames          []string              `json:"list_key_yang_names,omitempty"`
+	PackageName               string                `json:"package_name,omitempty"`
+	IsFakeRoot                bool                  `json:"is_fake_root,omitempty"`
+	BelongingModule           string                `json:"belonging_module,omitempty"`
+	RootElementModule         string                `json:"root_element_module,omitempty"`
+	DefiningModule            string                `json:"defining_module,omitempty"`
+	ConfigFalse               bool                  `json:"config_false,omitempty"`
+	TelemetryAtomic           bool                  `json:"telemetry_atomic,omitempty"`
+	CompressedTelemetryAtomic bool                  `json:"compressed_telemetry_atomic,omitempty"`
+	InOperation               bool                  `json:"in_operation,omitempty"`
+}
+
+// irField is the serialised form of a NodeDetails.
+type irField struct {
+	Name                    string            `json:"name"`
+	YANGDetails             *irYANGDetails    `json:"yang_details"`
+	Type                    string            `json:"type"`
+	LangType                *irMappedType     `json:"lang_type,omitempty"`
+	MappedPaths             [][]string        `json:"mapped_paths,omitempty"`
+	MappedPathModules       [][]string        `json:"mapped_path_modules,omitempty"`
+	ShadowMappedPaths       [][]string        `json:"shadow_mapped_paths,omitempty"`
+	ShadowMappedPathModules [][]string        `json:"shadow_mapped_path_modules,omitempty"`
+	Flags                   map[string]string `json:"flags,omitempty"`
+}
+
+// irYANGDetails is the serialised form of a YANGNodeDetails.
+type irYANGDetails struct {
+	Name              string          `json:"name"`
+	Defaults          []string        `json:"defaults,omitempty"`
+	BelongingModule   string          `json:"belonging_module,omitempty"`
+	RootElementModule string          `json:"root_element_module,omitempty"`
+	DefiningModule    string          `json:"defining_module,omitempty"`
+	Path              string          `json:"path"`
+	SchemaPath        string          `json:"schema_path"`
+	ShadowSchemaPath  string          `json:"shadow_schema_path,omitempty"`
+	LeafrefTargetPath string          `json:"leafref_target_path,omitempty"`
+	PresenceStatement *string         `json:"presence_statement,omitempty"`
+	Description       string          `json:"description,omitempty"`
+	OrderedByUser     bool            `json:"ordered_by_user,omitempty"`
+	ConfigFalse       bool            `json:"config_false,omitempty"`
+	Choices           []*irChoiceCase `json:"choices,omitempty"`
+}
+
+// irChoiceCase is the serialised form of a YANGChoiceCase.
+type irChoiceCase struct {
+	Choice string `json:"choice"`
+	Case   string `json:"case"`
+}
+
+// irListKey is the serialised form of a ListKey.
+type irListKey struct {
+	Name     string        `json:"name"`
+	LangType *irMappedType `json:"lang_type,omitempty"`
+}
+
+// irMappedType is the serialised form of a MappedType.
+type irMappedType struct {
+	NativeType            string                     `json:"native_type"`
+	UnionTypes            map[string]*irUnionSubtype `json:"union_types,omitempty"`
+	IsEnumeratedValue     bool                       `json:"is_enumerated_value,omitempty"`
+	EnumeratedYANGTypeKey string                     `json:"enumerated_yang_type_key,omitempty"`
+	ZeroValue             string                     `json:"zero_value,omitempty"`
+	DefaultValue          *string                    `json:"default_value,omitempty"`
+}
+
+// irUnionSubtype is the serialised form of a MappedUnionSubtype.
+type irUnionSubtype struct {
+	Index                 int    `json:"index"`
+	EnumeratedYANGTypeKey string `json:"enumerated_yang_type_key,omitempty"`
+}
+
+// irEnum is the serialised form of an EnumeratedYANGType.
+type irEnum struct {
+	Name             string            `json:"name"`
+	Kind             string            `json:"kind"`
+	IdentityBaseName string            `json:"identity_base_name,omitempty"`
+	TypeName         string            `json:"type_name,omitempty"`
+	TypeDefaultValue string            `json:"type_default_value,omitempty"`
+	Values           []*irEnumValue    `json:"values,omitempty"`
+	ValueNames       map[string]string `json:"value_names,omitempty"`
+	Flags            map[string]string `json:"flags,omitempty"`
+}
+
+// irEnumValue is the serialised form of a ygot.EnumDefinition.
+type irEnumValue struct {
+	Name           string `json:"name"`
+	DefiningModule string `json:"defining_module,omitempty"`
+	Value          int    `json:"value"`
+}
+
+// irModelData is the serialised form of a gNMI ModelData message.
+type irModelData struct {
+	Name         string `json:"name"`
+	Organization string `json:"organization,omitempty"`
+	Version      string `json:"version,omitempty"`
+}
+
+// irOperation is the serialised form of a ParsedOperation.
+type irOperation struct {
+	Name            string `json:"name"`
+	Type            string `json:"type"`
+	Path            string `json:"path"`
+	SchemaPath      string `json:"schema_path"`
+	BelongingModule string `json:"belonging_module,omitempty"`
+	InputPath       string `json:"input_path,omitempty"`
+	OutputPath      string `json:"output_path,omitempty"`
+}
+
+var (
+	// dirTypeNames maps a DirType to its name in the serialised IR.
+	dirTypeNames = map[DirType]string{
+		Container:   "container",
+		List:        "list",
+		OrderedList: "ordered-list",
+	}
+	// nodeTypeNames maps a NodeType to its name in the serialised IR.
+	nodeTypeNames = map[NodeType]string{
+		ContainerNode: "container",
+		ListNode:      "list",
+		LeafNode:      "leaf",
+		LeafListNode:  "leaf-list",
+		AnyDataNode:   "anydata",
+	}
+	// enumKindNames maps an EnumeratedValueType to its name in the
+	// serialised IR.
+	enumKindNames = map[EnumeratedValueType]string{
+		SimpleEnumerationType:       "enumeration",
+		DerivedEnumerationType:      "derived-enumeration",
+		UnionEnumerationType:        "union-enumeration",
+		DerivedUnionEnumerationType: "derived-union-enumeration",
+		IdentityType:                "identity",
+	}
+	// operationTypeNames maps a ygot.OperationType to its name in the
+	// serialised IR.
+	operationTypeNames = map[ygot.OperationType]string{
+		ygot.RPCOperation:          "rpc",
+		ygot.ActionOperation:       "action",
+		ygot.NotificationOperation: "notification",
+	}
+)
+
+// lookupName returns the serialised name of the enumerated value v within
+// names, or an error describing v using kind if it has no name.
+func lookupName[T comparable](names map[T]string, v T, kind string) (string, error) {
+	n, ok := names[v]
+	if !ok {
+		return "", fmt.Errorf("invalid %s %v", kind, v)
+	}
+	return n, nil
+}
+
+// lookupValue returns the enumerated value whose serialised name within
+// names is n, or an error describing n using kind if there is no such
+// value.
+func lookupValue[T comparable](names map[T]string, n, kind string) (T, error) {
+	for v, name := range names {
+		if name == n {
+			return v, nil
+		}
+	}
+	var zero T
+	return zero, fmt.Errorf("invalid %s %q", kind, n)
+}
+
+// MarshalIR returns the serialised form of ir, which is a JSON document of
+// version IRFormatVersion. The IR can be reconstructed from the document
+// using UnmarshalIR.
+func MarshalIR(ir *IR, opts MarshalIROpts) ([]byte, error) {
+	if ir == nil {
+		return nil, fmt.Errorf("cannot marshal nil IR")
+	}
+
+	doc := &irDocument{FormatVersion: IRFormatVersion}
+	if len(ir.Directories) > 0 {
+		doc.Directories = make(map[string]*irDirectory, len(ir.Directories))
+	}
+	for p, d := range ir.Directories {
+		sd, err := marshalDirectory(d)
+		if err != nil {
+			return nil, fmt.Errorf("directory %s: %v", p, err)
+		}
+		doc.Directories[p] = sd
+	}
+
+	if len(ir.Enums) > 0 {
+		doc.Enums = make(map[string]*irEnum, len(ir.Enums))
+	}
+	for k, e := range ir.Enums {
+		kind, err := lookupName(enumKindNames, e.Kind, "enumeration kind")
+		if err != nil {
+			return nil, fmt.Errorf("enumeration %s: %v", k, err)
+		}
+		se := &irEnum{
+			Name:             e.Name,
+			Kind:             kind,
+			IdentityBaseName: e.IdentityBaseName,
+			TypeName:         e.TypeName,
+			TypeDefaultValue: e.TypeDefaultValue,
+			ValueNames:       e.ValueNames,
+			Flags:            e.Flags,
+		}
+		for _, v := range e.ValToYANGDetails {
+			se.Values = append(se.Values, &irEnumValue{
+				Name:           v.Name,
+				DefiningModule: v.DefiningModule,
+				Value:          v.Value,
+			})
+		}
+		doc.Enums[k] = se
+	}
+
+	for _, m := range ir.ModelData {
+		doc.ModelData = append(doc.ModelData, &irModelData{
+			Name:         m.GetName(),
+			Organization: m.GetOrganization(),
+			Version:      m.GetVersion(),
+		})
+	}
+
+	if len(ir.Operations) > 0 {
+		doc.Operations = make(map[string]*irOperation, len(ir.Operations))
+	}
+	for p, o := range ir.Operations {
+		t, err := lookupName(operationTypeNames, o.Type, "operation type")
+		if err != nil {
+			return nil, fmt.Errorf("operation %s: %v", p, err)
+		}
+		doc.Operations[p] = &irOperation{
+			Name:            o.Name,
+			Type:            t,
+			Path:            o.Path,
+			SchemaPath:      o.SchemaPath,
+			BelongingModule: o.BelongingModule,
+			InputPath:       o.InputPath,
+			OutputPath:      o.OutputPath,
+		}
+	}
+
+	if opts.IncludeSchemaTree {
+		tree, err := ir.SchemaTree(opts.IncludeDescriptions)
+		if err != nil {
+			return nil, fmt.Errorf("cannot build schema tree: %v", err)
+		}
+		doc.SchemaTree = tree
+		doc.SchemaTreeDescriptions = opts.IncludeDescriptions
+	}
+
+	return json.MarshalIndent(doc, "", "  ")
+}
+
+// marshalDirectory returns the serialised form of the directory d.
+func marshalDirectory(d *ParsedDirectory) (*irDirectory, error) {
+	t, err := lookupName(dirTypeNames, d.Type, "directory type")
+	if err != nil {
+		return nil, err
+	}
+	sd := &irDirectory{
+		Name:                      d.Name,
+		Type:                      t,
+		Path:                      d.Path,
+		SchemaPath:                d.SchemaPath,
+		ListKeyYANGNames:          d.ListKeyYANGNames,
+		PackageName:               d.PackageName,
+		IsFakeRoot:                d.IsFakeRoot,
+		BelongingModule:           d.BelongingModule,
+		RootElementModule:         d.RootElementModule,
+		DefiningModule:            d.DefiningModule,
+		ConfigFalse:               d.ConfigFalse,
+		TelemetryAtomic:           d.TelemetryAtomic,
+		CompressedTelemetryAtomic: d.CompressedTelemetryAtomic,
+		InOperation:               d.InOperation,
+	}
+
+	if len(d.Fields) > 0 {
+		sd.Fields = make(map[string]*irField, len(d.Fields))
+	}
+	for n, f := range d.Fields {
+		ft, err := lookupName(nodeTypeNames, f.Type, "node type")
+		if err != nil {
+			return nil, fmt.Errorf("field %s: %v", n, err)
+		}
+		y := f.YANGDetails
+		sf := &irField{
+			Name: f.Name,
+			YANGDetails: &irYANGDetails{
+				Name:              y.Name,
+				Defaults:          y.Defaults,
+				BelongingModule:   y.BelongingModule,
+				RootElementModule: y.RootElementModule,
+				DefiningModule:    y.DefiningModule,
+				Path:              y.Path,
+				SchemaPath:        y.SchemaPath,
+				ShadowSchemaPath:  y.ShadowSchemaPath,
+				LeafrefTargetPath: y.LeafrefTargetPath,
+				PresenceStatement: y.PresenceStatement,
+				Description:       y.Description,
+				OrderedByUser:     y.OrderedByUser,
+				ConfigFalse:       y.ConfigFalse,
+			},
+			Type:                    ft,
+			LangType:                marshalMappedType(f.LangType),
+			MappedPaths:             f.MappedPaths,
+			MappedPathModules:       f.MappedPathModules,
+			ShadowMappedPaths:       f.ShadowMappedPaths,
+			ShadowMappedPathModules: f.ShadowMappedPathModules,
+			Flags:                   f.Flags,
+		}
+		for _, c := range y.Choices {
+			sf.YANGDetails.Choices = append(sf.YANGDetails.Choices, &irChoiceCase{Choice: c.Choice, Case: c.Case})
+		}
+		sd.Fields[n] = sf
+	}
+
+	if len(d.ListKeys) > 0 {
+		sd.ListKeys = make(map[string]*irListKey, len(d.ListKeys))
+	}
+	for n, k := range d.ListKeys {
+		sd.ListKeys[n] = &irListKey{Name: k.Name, LangType: marshalMappedType(k.LangType)}
+	}
+	return sd, nil
+}
+
+// marshalMappedType returns the serialised form of the MappedType t.
+func marshalMappedType(t *MappedType) *irMappedType {
+	if t == nil {
+		return nil
+	}
+	st := &irMappedType{
+		NativeType:            t.NativeType,
+		IsEnumeratedValue:     t.IsEnumeratedValue,
+		EnumeratedYANGTypeKey: t.EnumeratedYANGTypeKey,
+		ZeroValue:             t.ZeroValue,
+		DefaultValue:          t.DefaultValue,
+	}
+	if len(t.UnionTypes) > 0 {
+		st.UnionTypes = make(map[string]*irUnionSubtype, len(t.UnionTypes))
+	}
+	for n, u := range t.UnionTypes {
+		st.UnionTypes[n] = &irUnionSubtype{Index: u.Index, EnumeratedYANGTypeKey: u.EnumeratedYANGTypeKey}
+	}
+	return st
+}
+
+// UnmarshalIR reconstructs the IR from its serialised form b, as produced by
+// MarshalIR. Since the YANG modules from which the IR was generated are not
+// available, the SchemaTree method of the returned IR succeeds only if the
+// schema tree was included when the IR was serialised.
+func UnmarshalIR(b []byte) (*IR, error) {
+	doc := &irDocument{}
+	if err := json.Unmarshal(b, doc); err != nil {
+		return nil, fmt.Errorf("cannot parse serialised IR: %v", err)
+	}
+	if doc.FormatVersion != IRFormatVersion {
+		return nil, fmt.Errorf("unsupported serialised IR format version %d, must be %d", doc.FormatVersion, IRFormatVersion)
+	}
+
+	ir := &IR{
+		loaded:                 true,
+		rawSchemaTreeDescribed: doc.SchemaTreeDescriptions,
+	}
+	if doc.SchemaTree != nil {
+		// The schema tree is re-indented when it is embedded within the
+		// document, hence it is restored to the indentation used by
+		// buildJSONTree such that SchemaTree returns identical output.
+		var buf bytes.Buffer
+		if err := json.Indent(&buf, doc.SchemaTree, "", strings.Repeat(" ", 4)); err != nil {
+			return nil, fmt.Errorf("invalid schema tree: %v", err)
+		}
+		ir.rawSchemaTree = buf.Bytes()
+	}
+	if doc.Directories != nil {
+		ir.Directories = make(map[string]*ParsedDirectory, len(doc.Directories))
+	}
+	for p, sd := range doc.Directories {
+		if sd == nil {
+			return nil, fmt.Errorf("directory %s: empty directory", p)
+		}
+		d, err := unmarshalDirectory(sd)
+		if err != nil {
+			return nil, fmt.Errorf("directory %s: %v", p, err)
+		}
+		ir.Directories[p] = d
+	}
+
+	if doc.Enums != nil {
+		ir.Enums = make(map[string]*EnumeratedYANGType, len(doc.Enums))
+	}
+	for k, se := range doc.Enums {
+		if se == nil {
+			return nil, fmt.Errorf("enumeration %s: empty enumeration", k)
+		}
+		kind, err := lookupValue(enumKindNames, se.Kind, "enumeration kind")
+		if err != nil {
+			return nil, fmt.Errorf("enumeration %s: %v", k, err)
+		}
+		e := &EnumeratedYANGType{
+			Name:             se.Name,
+			Kind:             kind,
+			IdentityBaseName: se.IdentityBaseName,
+			TypeName:         se.TypeName,
+			TypeDefaultValue: se.TypeDefaultValue,
+			ValueNames:       se.ValueNames,
+			Flags:            se.Flags,
+		}
+		for _, v := range se.Values {
+			if v == nil {
+				return nil, fmt.Errorf("enumeration %s: empty value", k)
+			}
+			e.ValToYANGDetails = append(e.ValToYANGDetails, ygot.EnumDefinition{
+				Name:           v.Name,
+				DefiningModule: v.DefiningModule,
+				Value:          v.Value,
+			})
+		}
+		ir.Enums[k] = e
+	}
+
+	for _, m := range doc.ModelData {
+		if m == nil {
+			return nil, fmt.Errorf("empty model data")
+		}
+		ir.ModelData = append(ir.ModelData, &gpb.ModelData{
+			Name:         m.Name,
+			Organization: m.Organization,
+			Version:      m.Version,
+		})
+	}
+
+	if doc.Operations != nil {
+		ir.Operations = make(map[string]*ParsedOperation, len(doc.Operations))
+	}
+	for p, so := range doc.Operations {
+		if so == nil {
+			return nil, fmt.Errorf("operation %s: empty operation", p)
+		}
+		t, err := lookupValue(operationTypeNames, so.Type, "operation type")
+		if err != nil {
+			return nil, fmt.Errorf("operation %s: %v", p, err)
+		}
+		ir.Operations[p] = &ParsedOperation{
+			Name:            so.Name,
+			Type:            t,
+			Path:            so.Path,
+			SchemaPath:      so.SchemaPath,
+			BelongingModule: so.BelongingModule,
+			InputPath:       so.InputPath,
+			OutputPath:      so.OutputPath,
+		}
+	}
+
+	return ir, nil
+}
+
+// unmarshalDirectory reconstructs a ParsedDirectory from its serialised form
+// sd.
+func unmarshalDirectory(sd *irDirectory) (*ParsedDirectory, error) {
+	t, err := lookupValue(dirTypeNames, sd.Type, "directory type")
+	if err != nil {
+		return nil, err
+	}
+	d := &ParsedDirectory{
+		Name:                      sd.Name,
+		Type:                      t,
+		Path:                      sd.Path,
+		SchemaPath:                sd.SchemaPath,
+		ListKeyYANGNames:          sd.ListKeyYANGNames,
+		PackageName:               sd.PackageName,
+		IsFakeRoot:                sd.IsFakeRoot,
+		BelongingModule:           sd.BelongingModule,
+		RootElementModule:         sd.RootElementModule,
+		DefiningModule:            sd.DefiningModule,
+		ConfigFalse:               sd.ConfigFalse,
+		TelemetryAtomic:           sd.TelemetryAtomic,
+		CompressedTelemetryAtomic: sd.CompressedTelemetryAtomic,
+		InOperation:               sd.InOperation,
+	}
+
+	if sd.Fields != nil {
+		d.Fields = make(map[string]*NodeDetails, len(sd.Fields))
+	}
+	for n, sf := range sd.Fields {
+		if sf == nil || sf.YANGDetails == nil {
+			return nil, fmt.Errorf("field %s: missing details", n)
+		}
+		ft, err := lookupValue(nodeTypeNames, sf.Type, "node type")
+		if err != nil {
+			return nil, fmt.Errorf("field %s: %v", n, err)
+		}
+		y := sf.YANGDetails
+		f := &NodeDetails{
+			Name: sf.Name,
+			YANGDetails: YANGNodeDetails{
+				Name:              y.Name,
+				Defaults:          y.Defaults,
+				BelongingModule:   y.BelongingModule,
+				RootElementModule: y.RootElementModule,
+				DefiningModule:    y.DefiningModule,
+				Path:              y.Path,
+				SchemaPath:        y.SchemaPath,
+				ShadowSchemaPath:  y.ShadowSchemaPath,
+				LeafrefTargetPath: y.LeafrefTargetPath,
+				PresenceStatement: y.PresenceStatement,
+				Description:       y.Description,
+				OrderedByUser:     y.OrderedByUser,
+				ConfigFalse:       y.ConfigFalse,
+			},
+			Type:                    ft,
+			LangType:                unmarshalMappedType(sf.LangType),
+			MappedPaths:             sf.MappedPaths,
+			MappedPathModules:       sf.MappedPathModules,
+			ShadowMappedPaths:       sf.ShadowMappedPaths,
+			ShadowMappedPathModules: sf.ShadowMappedPathModules,
+			Flags:                   sf.Flags,
+		}
+		for _, c := range y.Choices {
+			if c == nil {
+				return nil, fmt.Errorf("field %s: empty choice", n)
+			}
+			f.YANGDetails.Choices = append(f.YANGDetails.Choices, &YANGChoiceCase{Choice: c.Choice, Case: c.Case})
+		}
+		d.Fields[n] = f
+	}
+
+	if sd.ListKeys != nil {
+		d.ListKeys = make(map[string]*ListKey, len(sd.ListKeys))
+	}
+	for n, k := range sd.ListKeys {
+		if k == nil {
+			return nil, fmt.Errorf("list key %s: empty key", n)
+		}
+		d.ListKeys[n] = &ListKey{Name: k.Name, LangType: unmarshalMappedType(k.LangType)}
+	}
+	return d, nil
+}
+
+// unmarshalMappedType reconstructs a MappedType from its serialised form st.
+func unmarshalMappedType(st *irMappedType) *MappedType {
+	if st == nil {
+		return nil
+	}
+	t := &MappedType{
+		NativeType:            st.NativeType,
+		IsEnumeratedValue:     st.IsEnumeratedValue,
+		EnumeratedYANGTypeKey: st.EnumeratedYANGTypeKey,
+		ZeroValue:             st.ZeroValue,
+		DefaultValue:          st.DefaultValue,
+	}
+	if st.UnionTypes != nil {
+		t.UnionTypes = make(map[string]MappedUnionSubtype, len(st.UnionTypes))
+	}
+	for n, u := range st.UnionTypes {
+		if u == nil {
+			continue
+		}
+		t.UnionTypes[n] = MappedUnionSubtype{Index: u.Index, EnumeratedYANGTypeKey: u.EnumeratedYANGTypeKey}
+	}
+	return t
+}
@@ -0,0 +1,357 @@
+// Copyright 2023 Google Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package ygen
+
+import (
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/google/go-cmp/cmp/cmpopts"
+	"github.com/openconfig/gnmi/errdiff"
+	"github.com/openconfig/ygot/testutil"
+	"github.com/openconfig/ygot/ygot"
+	"google.golang.org/protobuf/testing/protocmp"
+
+	gpb "github.com/openconfig/gnmi/proto/gnmi"
+)
+
+// testIR returns an IR containing one of each kind of element.
+func testIR() *IR {
+	return &IR{
+		Directories: map[string]*ParsedDirectory{
+			"/m/c": {
+				Name:       "C",
+				Type:       Container,
+				Path:       "/m/c",
+				SchemaPath: "/c",
+				Fields: map[string]*NodeDetails{
+					"l": {
+						Name: "L",
+						YANGDetails: YANGNodeDetails{
+							Name:       "l",
+							Defaults:   []string{"ONE"},
+							Path:       "/m/c/l",
+							SchemaPath: "/c/l",
+							Choices:    []*YANGChoiceCase{{Choice: "ch", Case: "ca"}},
+						},
+						Type: LeafNode,
+						LangType: &MappedType{
+							NativeType:            "E_M_C_L",
+							IsEnumeratedValue:     true,
+							EnumeratedYANGTypeKey: "/m/c/l",
+							DefaultValue:          ygot.String("ONE"),
+						},
+						MappedPaths:       [][]string{{"c", "l"}},
+						MappedPathModules: [][]string{{"m", "m"}},
+						Flags:             map[string]string{"flag": "value"},
+					},
+				},
+			},
+			"/m/c/li": {
+				Name:       "C_Li",
+				Type:       OrderedList,
+				Path:       "/m/c/li",
+				SchemaPath: "/c/li",
+				ListKeys: map[string]*ListKey{
+					"k": {
+						Name: "K",
+						LangType: &MappedType{
+							NativeType: "C_Li_K_Union",
+							UnionTypes: map[string]MappedUnionSubtype{
+								"string": {Index: 0},
+								"E_M_K":  {Index: 1, EnumeratedYANGTypeKey: "/m/k"},
+							},
+						},
+					},
+				},
+				ListKeyYANGNames: []string{"k"},
+				ConfigFalse:      true,
+			},
+		},
+		Enums: map[string]*EnumeratedYANGType{
+			"/m/c/l": {
+				Name:     "M_C_L",
+				Kind:     SimpleEnumerationType,
+				TypeName: "enumeration",
+				ValToYANGDetails: []ygot.EnumDefinition{
+					{Name: "ONE", Value: 0},
+					{Name: "TWO", Value: 1},
+				},
+				ValueNames: map[string]string{"TWO": "Two"},
+			},
+		},
+		ModelData: []*gpb.ModelData{{Name: "m", Organization: "org", Version: "1"}},
+		Operations: map[string]*ParsedOperation{
+			"/m/op": {
+				Name:       "op",
+				Type:       ygot.RPCOperation,
+				Path:       "/m/op",
+				SchemaPath: "/op",
+				InputPath:  "/m/op/input",
+			},
+		},
+	}
+}
+
+func TestMarshalIR(t *testing.T) {
+	want := `{
+  "format_version": 1,
+  "directories": {
+    "/m/c": {
+      "name": "C",
+      "type": "container",
+      "path": "/m/c",
+      "schema_path": "/c",
+      "fields": {
+        "l": {
+          "name": "L",
+          "yang_details": {
+            "name": "l",
+            "defaults": [
+              "ONE"
+            ],
+            "path": "/m/c/l",
+            "schema_path": "/c/l",
+            "choices": [
+              {
+                "choice": "ch",
+                "case": "ca"
+              }
+            ]
+          },
+          "type": "leaf",
+          "lang_type": {
+            "native_type": "E_M_C_L",
+            "is_enumerated_value": true,
+            "enumerated_yang_type_key": "/m/c/l",
+            "default_value": "ONE"
+          },
+          "mapped_paths": [
+            [
+              "c",
+              "l"
+            ]
+          ],
+          "mapped_path_modules": [
+            [
+              "m",
+              "m"
+            ]
+          ],
+          "flags": {
+            "flag": "value"
+          }
+        }
+      }
+    },
+    "/m/c/li": {
+      "name": "C_Li",
+      "type": "ordered-list",
+      "path": "/m/c/li",
+      "schema_path": "/c/li",
+      "list_keys": {
+        "k": {
+          "name": "K",
+          "lang_type": {
+            "native_type": "C_Li_K_Union",
+            "union_types": {
+              "E_M_K": {
+                "index": 1,
+                "enumerated_yang_type_key": "/m/k"
+              },
+              "string": {
+                "index": 0
+              }
+            }
+          }
+        }
+      },
+      "list_key_yang_names": [
+        "k"
+      ],
+      "config_false": true
+    }
+  },
+  "enums": {
+    "/m/c/l": {
+      "name": "M_C_L",
+      "kind": "enumeration",
+      "type_name": "enumeration",
+      "values": [
+        {
+          "name": "ONE",
+          "value": 0
+        },
+        {
+          "name": "TWO",
+          "value": 1
+        }
+      ],
+      "value_names": {
+        "TWO": "Two"
+      }
+    }
+  },
+  "model_data": [
+    {
+      "name": "m",
+      "organization": "org",
+      "version": "1"
+    }
+  ],
+  "operations": {
+    "/m/op": {
+      "name": "op",
+      "type": "rpc",
+      "path": "/m/op",
+      "schema_path": "/op",
+      "input_path": "/m/op/input"
+    }
+  }
+}`
+
+	got, err := MarshalIR(testIR(), MarshalIROpts{})
+	if err != nil {
+		t.Fatalf("MarshalIR: got unexpected error: %v", err)
+	}
+	if string(got) != want {
+		diff, _ := testutil.GenerateUnifiedDiff(want, string(got))
+		t.Errorf("MarshalIR: did not get expected document, diff(-want, +got):\n%s", diff)
+	}
+
+	loaded, err := UnmarshalIR(got)
+	if err != nil {
+		t.Fatalf("UnmarshalIR: got unexpected error: %v", err)
+	}
+	if diff := cmp.Diff(testIR(), loaded, cmpopts.IgnoreUnexported(IR{}), protocmp.Transform()); diff != "" {
+		t.Errorf("UnmarshalIR: did not get expected IR, diff(-want, +got):\n%s", diff)
+	}
+	if _, err := loaded.SchemaTree(false); err == nil {
+		t.Errorf("SchemaTree: did not get expected error for IR serialised without a schema tree")
+	}
+}
+
+func TestMarshalIRErrors(t *testing.T) {
+	tests := []struct {
+		name             string
+		in               *IR
+		wantErrSubstring string
+	}{{
+		name:             "nil IR",
+		wantErrSubstring: "nil IR",
+	}, {
+		name: "invalid directory type",
+		in: &IR{
+			Directories: map[string]*ParsedDirectory{"/m/c": {Name: "C"}},
+		},
+		wantErrSubstring: "invalid directory type",
+	}, {
+		name: "invalid node type",
+		in: &IR{
+			Directories: map[string]*ParsedDirectory{
+				"/m/c": {
+					Name:   "C",
+					Type:   Container,
+					Fields: map[string]*NodeDetails{"l": {Name: "L"}},
+				},
+			},
+		},
+		wantErrSubstring: "invalid node type",
+	}, {
+		name: "invalid enumeration kind",
+		in: &IR{
+			Enums: map[string]*EnumeratedYANGType{"/m/e": {Name: "E"}},
+		},
+		wantErrSubstring: "invalid enumeration kind",
+	}}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := MarshalIR(tt.in, MarshalIROpts{})
+			if diff := errdiff.Substring(err, tt.wantErrSubstring); diff != "" {
+				t.Errorf("MarshalIR: did not get expected error, %s", diff)
+			}
+		})
+	}
+}
+
+func TestUnmarshalIR(t *testing.T) {
+	tests := []struct {
+		name             string
+		in               string
+		wantIR           *IR
+		wantSchemaTree   string
+		wantErrSubstring string
+	}{{
+		name:   "empty IR",
+		in:     `{"format_version": 1}`,
+		wantIR: &IR{},
+	}, {
+		name:           "schema tree",
+		in:             `{"format_version": 1, "schema_tree": {"name": "device"}}`,
+		wantIR:         &IR{},
+		wantSchemaTree: "{\n    \"name\": \"device\"\n}",
+	}, {
+		name:             "invalid JSON",
+		in:               `{`,
+		wantErrSubstring: "cannot parse serialised IR",
+	}, {
+		name:             "unsupported version",
+		in:               `{"format_version": 2}`,
+		wantErrSubstring: "unsupported serialised IR format version 2",
+	}, {
+		name:             "invalid directory type",
+		in:               `{"format_version": 1, "directories": {"/m/c": {"name": "C", "type": "leaf"}}}`,
+		wantErrSubstring: `invalid directory type "leaf"`,
+	}, {
+		name:             "missing field details",
+		in:               `{"format_version": 1, "directories": {"/m/c": {"name": "C", "type": "container", "fields": {"l": {"name": "L", "type": "leaf"}}}}}`,
+		wantErrSubstring: "missing details",
+	}, {
+		name:             "invalid operation type",
+		in:               `{"format_version": 1, "operations": {"/m/op": {"name": "op", "type": "notify"}}}`,
+		wantErrSubstring: `invalid operation type "notify"`,
+	}}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := UnmarshalIR([]byte(tt.in))
+			if diff := errdiff.Substring(err, tt.wantErrSubstring); diff != "" {
+				t.Fatalf("UnmarshalIR: did not get expected error, %s", diff)
+			}
+			if err != nil {
+				return
+			}
+			if diff := cmp.Diff(tt.wantIR, got, cmpopts.IgnoreUnexported(IR{})); diff != "" {
+				t.Errorf("UnmarshalIR: did not get expected IR, diff(-want, +got):\n%s", diff)
+			}
+
+			tree, err := got.SchemaTree(false)
+			switch {
+			case tt.wantSchemaTree == "" && err == nil:
+				t.Errorf("SchemaTree: did not get expected error, got tree: %s", tree)
+			case tt.wantSchemaTree != "" && err != nil:
+				t.Errorf("SchemaTree: got unexpected error: %v", err)
+			case string(tree) != tt.wantSchemaTree:
+				t.Errorf("SchemaTree: did not get expected tree, got: %s, want: %s", tree, tt.wantSchemaTree)
+			}
+			if tt.wantSchemaTree != "" {
+				if _, err := got.SchemaTree(true); err == nil {
+					t.Errorf("SchemaTree(true): did not get expected error for a schema tree without descriptions")
+				}
+			}
+		})
+	}
+}
@@ -0,0 +1,246 @@
+// Copyright 2023 Google Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package ygen
+
+import (
+	"encoding/json"
+	"fmt"
+	"sort"
+	"strings"
+)
+
+// NameLockFormatVersion is the version of the serialised name lock document
+// that is written by MarshalNameLock. UnmarshalNameLock only accepts
+// documents of this version.
+const NameLockFormatVersion = 1
+
+// NameLock records the names that were assigned to the directories,
+// enumerated types and unions of a generated IR, such that the same names can
+// be assigned when code is regenerated for an updated set of modules. Without
+// a lock, the name of an entity may change when an entity whose name clashes
+// with it is added to the schema, since clashes are resolved by renaming.
+//
+// When a NameLock is supplied in IROptions, each entity that has a locked
+// name is assigned that name, and a clash between the name of any entity and
+// a name that is in use or locked is reported as an error rather than being
+// resolved, such that the user can choose a name for the new entity by adding
+// it to the lock. Directory and union names are assigned by the LangMapper,
+// and hence are locked only for LangMappers that consult the lock, such as
+// the Go LangMapper.
+type NameLock struct {
+	// Directories maps the absolute schema path of each directory,
+	// including its module and any choice and case nodes, as used as the
+	// key of the IR's Directories map, to its name.
+	Directories map[string]string
+	// Enums maps the key of each enumerated type, as used as the key of the
+	// IR's Enums map, to its name. The key of an enumerated type is based
+	// on the schema path of the leaf that defines it, or on the module and
+	// name of the typedef or identity that it represents.
+	Enums map[string]string
+	// Unions maps the absolute schema path of each union leaf, including
+	// its module and any choice and case nodes, to the name of the type
+	// that represents the union.
+	Unions map[string]string
+
+	// directoryPaths is the inverse of Directories, used to find the
+	// directory that a name is locked to.
+	directoryPaths map[string]string
+}
+
+// nameLockDocument is the JSON form of a NameLock.
+type nameLockDocument struct {
+	FormatVersion int               `json:"format_version"`
+	Directories   map[string]string `json:"directories,omitempty"`
+	Enums         map[string]string `json:"enums,omitempty"`
+	Unions        map[string]string `json:"unions,omitempty"`
+}
+
+// NewNameLock returns a NameLock that records the names that were assigned
+// to the directories, enumerated types and unions of ir.
+func NewNameLock(ir *IR) *NameLock {
+	l := &NameLock{
+		Directories: map[string]string{},
+		Enums:       map[string]string{},
+		Unions:      map[string]string{},
+	}
+	for p, d := range ir.Directories {
+		l.Directories[p] = d.Name
+		for _, f := range d.Fields {
+			if f.LangType != nil && len(f.LangType.UnionTypes) > 1 {
+				l.Unions[f.YANGDetails.Path] = f.LangType.NativeType
+			}
+		}
+	}
+	for k, e := range ir.Enums {
+		l.Enums[k] = e.Name
+	}
+	return l
+}
+
+// MarshalNameLock returns the JSON serialisation of the lock l. Entries are
+// written in the order of their keys, such that the output is stable.
+func MarshalNameLock(l *NameLock) ([]byte, error) {
+	b, err := json.MarshalIndent(&nameLockDocument{
+		FormatVersion: NameLockFormatVersion,
+		Directories:   l.Directories,
+		Enums:         l.Enums,
+		Unions:        l.Unions,
+	}, "", "  ")
+	if err != nil {
+		return nil, fmt.Errorf("cannot marshal name lock: %v", err)
+	}
+	return append(b, '\n'), nil
+}
+
+// UnmarshalNameLock returns the NameLock serialised in b. It returns an error
+// if the document is invalid, or if a name is locked to more than one
+// directory or enumerated type.
+func UnmarshalNameLock(b []byte) (*NameLock, error) {
+	doc := &nameLockDocument{}
+	if err := json.Unmarshal(b, doc); err != nil {
+		return nil, fmt.Errorf("cannot unmarshal name lock: %v", err)
+	}
+	if doc.FormatVersion != NameLockFormatVersion {
+		return nil, fmt.Errorf("unsupported name lock format version %d, want %d", doc.FormatVersion, NameLockFormatVersion)
+	}
+	l := &NameLock{
+		Directories: doc.Directories,
+		Enums:       doc.Enums,
+		Unions:      doc.Unions,
+	}
+	for kind, names := range map[string]map[string]string{"directory": l.Directories, "enumerated type": l.Enums} {
+		if err := checkUniqueNames(kind, names); err != nil {
+			return nil, fmt.Errorf("invalid name lock: %v", err)
+		}
+	}
+	return l, nil
+}
+
+// DirectoryPath returns the path of the directory whose name is locked to
+// name, and true, or false if the name is not locked to any directory. The
+// lookup is indexed on first use, and hence Directories must not be modified
+// after DirectoryPath is called.
+func (l *NameLock) DirectoryPath(name string) (string, bool) {
+	if l.directoryPaths == nil {
+		l.directoryPaths = make(map[string]string, len(l.Directories))
+		for p, n := range l.Directories {
+			l.directoryPaths[n] = p
+		}
+	}
+	p, ok := l.directoryPaths[name]
+	return p, ok
+}
+
+// checkUniqueNames returns an error if any name in names, which maps the key
+// of an entity of the given kind to its name, is assigned to more than one
+// entity.
+func checkUniqueNames(kind string, names map[string]string) error {
+	keys := map[string][]string{}
+	for k, n := range names {
+		keys[n] = append(keys[n], k)
+	}
+	var errs []string
+	for n, ks := range keys {
+		if len(ks) > 1 {
+			sort.Strings(ks)
+			errs = append(errs, fmt.Sprintf("%s name %q is assigned to more than one %s: %s", kind, n, kind, strings.Join(ks, ", ")))
+		}
+	}
+	if errs == nil {
+		return nil
+	}
+	sort.Strings(errs)
+	return fmt.Errorf("%s", strings.Join(errs, "; "))
+}
+
+// applyNameLock assigns the names that are locked in l to the enumerated
+// types within s. defaultNames maps the key of each enumerated leaf to the
+// name that it was given before name clashes were resolved. An error is
+// returned if any name is assigned to more than one enumerated type once the
+// locked names are applied, or if the default name of an enumerated type that
+// is not in the lock is locked to another enumerated type.
+func (s *enumSet) applyNameLock(l *NameLock, defaultNames map[string]string) error {
+	lockedKeys := map[string]string{}
+	for k, n := range l.Enums {
+		lockedKeys[n] = k
+	}
+	var errs []string
+	names := map[string]string{}
+	for _, m := range []map[string]string{s.uniqueIdentityNames, s.uniqueEnumeratedTypedefNames, s.uniqueEnumeratedLeafNames} {
+		for k := range m {
+			if n, ok := l.Enums[k]; ok {
+				m[k] = n
+			} else {
+				name, ok := defaultNames[k]
+				if !ok {
+					name = m[k]
+				}
+				if lk, ok := lockedKeys[name]; ok && lk != k {
+					errs = append(errs, fmt.Sprintf("enumerated type name %q for %s is locked to %s; assign a unique name to %s in the name lock", name, k, lk, k))
+				}
+			}
+			names[k] = m[k]
+		}
+	}
+	if err := checkUniqueNames("enumerated type", names); err != nil {
+		return fmt.Errorf("name lock: %v; assign a unique name to the new enumerated type in the name lock", err)
+	}
+	if errs != nil {
+		sort.Strings(errs)
+		return fmt.Errorf("name lock: %s", strings.Join(errs, "; "))
+	}
+	return nil
+}
+
+// checkUnionNames returns an error if the same name is used for unions with
+// different member types within the directories dirs. Since the name of a
+// union is shared by all of the leaves that have the same union type, a
+// clash can only be detected by comparing the member types. The error
+// describes each clash, and is qualified by the caller.
+func checkUnionNames(dirs map[string]*ParsedDirectory) error {
+	members := map[string]string{}
+	paths := map[string]string{}
+	var errs []string
+	for _, dp := range sortedKeys(dirs) {
+		d := dirs[dp]
+		for _, fn := range sortedKeys(d.Fields) {
+			f := d.Fields[fn]
+			if f.LangType == nil || len(f.LangType.UnionTypes) < 2 {
+				continue
+			}
+			m := strings.Join(sortedKeys(f.LangType.UnionTypes), ",")
+			name := f.LangType.NativeType
+			if prev, ok := members[name]; ok && prev != m {
+				errs = append(errs, fmt.Sprintf("union name %q is assigned to both %s and %s, which have different member types", name, paths[name], f.YANGDetails.Path))
+				continue
+			}
+			members[name], paths[name] = m, f.YANGDetails.Path
+		}
+	}
+	if errs == nil {
+		return nil
+	}
+	return fmt.Errorf("%s", strings.Join(errs, "; "))
+}
+
+// sortedKeys returns the keys of m in sorted order.
+func sortedKeys[T any](m map[string]T) []string {
+	var ks []string
+	for k := range m {
+		ks = append(ks, k)
+	}
+	sort.Strings(ks)
+	return ks
+}
@@ -0,0 +1,146 @@
+// Copyright 2023 Google Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package ygen
+
+import (
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/google/go-cmp/cmp/cmpopts"
+	"github.com/openconfig/gnmi/errdiff"
+)
+
+func TestUnmarshalNameLock(t *testing.T) {
+	tests := []struct {
+		name             string
+		in               string
+		want             *NameLock
+		wantErrSubstring string
+	}{{
+		name: "valid lock",
+		in: `{
+  "format_version": 1,
+  "directories": {"/m/a": "A"},
+  "enums": {"/m/a/b": "M_A_B"},
+  "unions": {"/m/a/c": "A_C_Union"}
+}`,
+		want: &NameLock{
+			Directories: map[string]string{"/m/a": "A"},
+			Enums:       map[string]string{"/m/a/b": "M_A_B"},
+			Unions:      map[string]string{"/m/a/c": "A_C_Union"},
+		},
+	}, {
+		name:             "unsupported version",
+		in:               `{"format_version": 2}`,
+		wantErrSubstring: "unsupported name lock format version 2",
+	}, {
+		name:             "invalid JSON",
+		in:               `{`,
+		wantErrSubstring: "cannot unmarshal name lock",
+	}, {
+		name:             "name locked to two directories",
+		in:               `{"format_version": 1, "directories": {"/m/a": "A", "/m/b": "A"}}`,
+		wantErrSubstring: `directory name "A" is assigned to more than one directory: /m/a, /m/b`,
+	}}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := UnmarshalNameLock([]byte(tt.in))
+			if diff := errdiff.Substring(err, tt.wantErrSubstring); diff != "" {
+				t.Fatalf("UnmarshalNameLock: did not get expected error, %s", diff)
+			}
+			if err != nil {
+				return
+			}
+			if diff := cmp.Diff(tt.want, got, cmpopts.IgnoreUnexported(NameLock{})); diff != "" {
+				t.Errorf("UnmarshalNameLock: did not get expected lock, diff(-want, +got):\n%s", diff)
+			}
+
+			b, err := MarshalNameLock(got)
+			if err != nil {
+				t.Fatalf("MarshalNameLock: got unexpected error: %v", err)
+			}
+			roundTrip, err := UnmarshalNameLock(b)
+			if err != nil {
+				t.Fatalf("UnmarshalNameLock: got unexpected error for marshalled lock: %v", err)
+			}
+			if diff := cmp.Diff(got, roundTrip, cmpopts.IgnoreUnexported(NameLock{})); diff != "" {
+				t.Errorf("MarshalNameLock: lock did not round trip, diff(-want, +got):\n%s", diff)
+			}
+		})
+	}
+}
+
+func TestApplyNameLock(t *testing.T) {
+	tests := []struct {
+		name   string
+		inLock *NameLock
+		// inDefaultNames maps the key of each enumerated leaf to its
+		// name before name clashes were resolved.
+		inDefaultNames   map[string]string
+		want             *enumSet
+		wantErrSubstring string
+	}{{
+		name:   "locked names replace generated names",
+		inLock: &NameLock{Enums: map[string]string{"/m/a/b": "M_B", "m/typedef": "M_Typedef", "/m/unused": "M_Unused"}},
+		want: &enumSet{
+			uniqueIdentityNames:          map[string]string{"m/base": "M_Base"},
+			uniqueEnumeratedTypedefNames: map[string]string{"m/typedef": "M_Typedef"},
+			uniqueEnumeratedLeafNames:    map[string]string{"/m/a/b": "M_B", "/m/c/b": "M_C_B"},
+		},
+	}, {
+		name:             "locked name clashes with generated name",
+		inLock:           &NameLock{Enums: map[string]string{"/m/a/b": "M_C_B"}},
+		wantErrSubstring: `enumerated type name "M_C_B" is assigned to more than one enumerated type: /m/a/b, /m/c/b`,
+	}, {
+		name:             "default name of unlocked enumerated type is locked",
+		inLock:           &NameLock{Enums: map[string]string{"/m/a/b": "M_B"}},
+		inDefaultNames:   map[string]string{"/m/a/b": "M_B", "/m/c/b": "M_B"},
+		wantErrSubstring: `enumerated type name "M_B" for /m/c/b is locked to /m/a/b; assign a unique name to /m/c/b in the name lock`,
+	}, {
+		name:           "default name of locked enumerated types clash",
+		inLock:         &NameLock{Enums: map[string]string{"/m/a/b": "M_B", "/m/c/b": "M_C_B"}},
+		inDefaultNames: map[string]string{"/m/a/b": "M_B", "/m/c/b": "M_B"},
+		want: &enumSet{
+			uniqueIdentityNames:          map[string]string{"m/base": "M_Base"},
+			uniqueEnumeratedTypedefNames: map[string]string{"m/typedef": "M_MyTypedef"},
+			uniqueEnumeratedLeafNames:    map[string]string{"/m/a/b": "M_B", "/m/c/b": "M_C_B"},
+		},
+	}, {
+		name:             "generated name of unlocked enumerated type is locked to a removed type",
+		inLock:           &NameLock{Enums: map[string]string{"/m/removed": "M_MyTypedef"}},
+		wantErrSubstring: `enumerated type name "M_MyTypedef" for m/typedef is locked to /m/removed`,
+	}}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			s := &enumSet{
+				uniqueIdentityNames:          map[string]string{"m/base": "M_Base"},
+				uniqueEnumeratedTypedefNames: map[string]string{"m/typedef": "M_MyTypedef"},
+				uniqueEnumeratedLeafNames:    map[string]string{"/m/a/b": "M_A_B", "/m/c/b": "M_C_B"},
+			}
+			err := s.applyNameLock(tt.inLock, tt.inDefaultNames)
+			if diff := errdiff.Substring(err, tt.wantErrSubstring); diff != "" {
+				t.Fatalf("applyNameLock: did not get expected error, %s", diff)
+			}
+			if err != nil {
+				return
+			}
+			if diff := cmp.Diff(tt.want, s, cmp.AllowUnexported(enumSet{})); diff != "" {
+				t.Errorf("applyNameLock: did not get expected names, diff(-want, +got):\n%s", diff)
+			}
+		})
+	}
+}
@@ -0,0 +1,267 @@
+// Copyright 2023 Google Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package ygen
+
+import (
+	"encoding/json"
+	"fmt"
+	"go/token"
+	"strings"
+
+	"github.com/openconfig/goyang/pkg/yang"
+	"github.com/openconfig/ygot/util"
+)
+
+// NameOverridesFormatVersion is the version of the name overrides document
+// that is read by UnmarshalNameOverrides.
+const NameOverridesFormatVersion = 1
+
+// NameOverride specifies the names that are used in generated code for a
+// schema node in place of the names that are generated for it by default.
+type NameOverride struct {
+	// Type is the name of the type that represents the node. It may be
+	// specified for a container or list, in which case it is the name of
+	// the generated struct, or for a leaf of enumerated or union type, in
+	// which case it is the name of the enumerated type or union. Since an
+	// enumerated type may be shared by several leaves, for example, when
+	// leaves use the same typedef or identity, the type is renamed for all
+	// of them.
+	Type string `json:"type,omitempty"`
+	// Field is the name of the field that represents the node within the
+	// type of its parent.
+	Field string `json:"field,omitempty"`
+	// Values maps the YANG name of a value of the enumerated type of a
+	// leaf to the name of the value, excluding the name of the type. The
+	// YANG name of the value is unchanged, such that serialisation is
+	// unaffected.
+	Values map[string]string `json:"values,omitempty"`
+}
+
+// NameOverrides specifies the names that are used in generated code in place
+// of those that are generated by default, such that unwieldy names can be
+// replaced. Only the names used within the generated code are changed, the
+// schema paths and YANG names used for serialisation are unaffected.
+//
+// Overrides are applied to directories and fields by the LangMapper, and
+// hence are used only by LangMappers that consult them, such as the Go
+// LangMapper. An error is returned during IR generation if an override does
+// not correspond to a generated entity, or if an overridden name clashes
+// with another name.
+type NameOverrides struct {
+	// Nodes maps the absolute data tree path of a schema node, which does
+	// not include choice and case nodes or module prefixes, such as
+	// /interfaces/interface/config/mtu, to the overrides for the node.
+	Nodes map[string]*NameOverride
+}
+
+// nameOverridesDocument is the JSON form of a NameOverrides.
+type nameOverridesDocument struct {
+	FormatVersion int                      `json:"format_version"`
+	Nodes         map[string]*NameOverride `json:"nodes"`
+}
+
+// UnmarshalNameOverrides returns the NameOverrides serialised in b. The paths
+// within the document may include module prefixes, which are removed. An
+// error is returned if the document is invalid, a path is specified more than
+// once, or if a name is not a valid identifier.
+func UnmarshalNameOverrides(b []byte) (*NameOverrides, error) {
+	doc := &nameOverridesDocument{}
+	if err := json.Unmarshal(b, doc); err != nil {
+		return nil, fmt.Errorf("cannot unmarshal name overrides: %v", err)
+	}
+	if doc.FormatVersion != NameOverridesFormatVersion {
+		return nil, fmt.Errorf("unsupported name overrides format version %d, want %d", doc.FormatVersion, NameOverridesFormatVersion)
+	}
+	o := &NameOverrides{Nodes: map[string]*NameOverride{}}
+	for _, p := range sortedKeys(doc.Nodes) {
+		no := doc.Nodes[p]
+		if !strings.HasPrefix(p, "/") || p == "/" {
+			return nil, fmt.Errorf("invalid name overrides: path %q is not an absolute schema path", p)
+		}
+		sp := util.StripModulePrefixesStr(p)
+		if _, ok := o.Nodes[sp]; ok {
+			return nil, fmt.Errorf("invalid name overrides: path %s is specified more than once", sp)
+		}
+		if err := no.validate(); err != nil {
+			return nil, fmt.Errorf("invalid name overrides: path %s: %v", sp, err)
+		}
+		o.Nodes[sp] = no
+	}
+	return o, nil
+}
+
+// validate returns an error if the override o does not specify any names, or
+// if any name is not a valid identifier.
+func (o *NameOverride) validate() error {
+	if o == nil || (o.Type == "" && o.Field == "" && len(o.Values) == 0) {
+		return fmt.Errorf("no names specified")
+	}
+	names := []string{o.Type, o.Field}
+	for _, v := range sortedKeys(o.Values) {
+		if o.Values[v] == "" {
+			return fmt.Errorf("empty name for value %s", v)
+		}
+		names = append(names, o.Values[v])
+	}
+	for _, n := range names {
+		if n != "" && !token.IsIdentifier(n) {
+			return fmt.Errorf("%q is not a valid identifier", n)
+		}
+	}
+	return nil
+}
+
+// Lookup returns the overrides for the schema node e, or nil if there are
+// none.
+func (o *NameOverrides) Lookup(e *yang.Entry) *NameOverride {
+	if o == nil {
+		return nil
+	}
+	return o.Nodes[util.SchemaTreePathNoModule(e)]
+}
+
+// applyNameOverrides assigns the type names of the overrides in o to the
+// enumerated types of the leaves within entries, and records the value names
+// of the overrides, such that they can be retrieved for each enumerated type.
+// The remaining arguments are as per findEnumSet. It returns an error if
+// different names are given to the same enumerated type or value, or if a
+// name is then assigned to more than one enumerated type.
+func (s *enumSet) applyNameOverrides(entries map[string]*yang.Entry, o *NameOverrides, compressPaths, noUnderscores, skipEnumDedup, shortenEnumLeafNames, useDefiningModuleForTypedefEnumNames bool, enumOrgPrefixesToTrim []string) error {
+	typePaths := map[string]string{}
+	valuePaths := map[string]map[string]string{}
+	for _, p := range sortedKeys(entries) {
+		e := entries[p]
+		no := o.Lookup(e)
+		if no == nil || (no.Type == "" && len(no.Values) == 0) {
+			continue
+		}
+
+		var names map[string]string
+		var key string
+		switch {
+		case e.Type.Name == "union", e.Type.Kind == yang.Yunion && !util.IsYANGBaseType(e.Type):
+			// The type name of a union leaf is the name of the union,
+			// which is assigned by the LangMapper.
+			continue
+		case e.Type.Name == "identityref":
+			if e.Type.IdentityBase == nil {
+				continue
+			}
+			names, key = s.uniqueIdentityNames, s.identityBaseKey(e.Type.IdentityBase)
+		case e.Type.Name == "enumeration":
+			names = s.uniqueEnumeratedLeafNames
+			key, _ = s.enumLeafKey(e, compressPaths, noUnderscores, skipEnumDedup, shortenEnumLeafNames, false, enumOrgPrefixesToTrim)
+		default:
+			k, _, err := s.enumeratedTypedefKey(resolveTypeArgs{contextEntry: e, yangType: e.Type}, noUnderscores, useDefiningModuleForTypedefEnumNames)
+			if err != nil {
+				return err
+			}
+			names, key = s.uniqueEnumeratedTypedefNames, k
+		}
+		sp := util.SchemaTreePathNoModule(e)
+
+		if no.Type != "" {
+			if prev, ok := typePaths[key]; ok && names[key] != no.Type {
+				return fmt.Errorf("name overrides: enumerated type of %s is named both %q and %q by the overrides for %s and %s", sp, names[key], no.Type, prev, sp)
+			}
+			names[key], typePaths[key] = no.Type, sp
+		}
+
+		if len(no.Values) == 0 {
+			continue
+		}
+		if s.valueNames[key] == nil {
+			s.valueNames[key] = map[string]string{}
+			valuePaths[key] = map[string]string{}
+		}
+		for _, v := range sortedKeys(no.Values) {
+			if !isEnumeratedValue(e.Type, v) {
+				return fmt.Errorf("name overrides: value %s of %s is not a value of its enumerated type", v, sp)
+			}
+			n := no.Values[v]
+			if prev, ok := s.valueNames[key][v]; ok && prev != n {
+				return fmt.Errorf("name overrides: value %s of the enumerated type of %s is named both %q and %q by the overrides for %s and %s", v, sp, prev, n, valuePaths[key][v], sp)
+			}
+			s.valueNames[key][v], valuePaths[key][v] = n, sp
+		}
+	}
+
+	all := map[string]string{}
+	for _, m := range []map[string]string{s.uniqueIdentityNames, s.uniqueEnumeratedTypedefNames, s.uniqueEnumeratedLeafNames} {
+		for k, n := range m {
+			all[k] = n
+		}
+	}
+	if err := checkUniqueNames("enumerated type", all); err != nil {
+		return fmt.Errorf("name overrides: %v", err)
+	}
+	return nil
+}
+
+// checkNameOverrides returns an error if any override within o does not
+// correspond to an entity within the directories dirs, or if a field name
+// that is overridden clashes with the name of another field of the same
+// directory.
+func checkNameOverrides(o *NameOverrides, dirs map[string]*ParsedDirectory) error {
+	fieldOverridden := func(p string) bool {
+		no := o.Nodes[p]
+		return no != nil && no.Field != ""
+	}
+	dirPaths := map[string]bool{}
+	fields := map[string]*NodeDetails{}
+	for _, dp := range sortedKeys(dirs) {
+		d := dirs[dp]
+		dirPaths[d.SchemaPath] = true
+		fieldNames := map[string]string{}
+		for _, fn := range d.OrderedFieldNames() {
+			f := d.Fields[fn]
+			fields[f.YANGDetails.SchemaPath] = f
+			if prev, ok := fieldNames[f.Name]; ok && (fieldOverridden(prev) || fieldOverridden(f.YANGDetails.SchemaPath)) {
+				return fmt.Errorf("name overrides: fields %s and %s of %s have the same name %q", prev, f.YANGDetails.SchemaPath, d.SchemaPath, f.Name)
+			}
+			fieldNames[f.Name] = f.YANGDetails.SchemaPath
+		}
+	}
+
+	var errs []string
+	for _, p := range sortedKeys(o.Nodes) {
+		no := o.Nodes[p]
+		f := fields[p]
+		switch {
+		case no.Field != "" && f == nil:
+			errs = append(errs, fmt.Sprintf("field name for %s does not correspond to a generated field", p))
+		case no.Type != "" && !dirPaths[p] && (f == nil || f.LangType == nil || (!f.LangType.IsEnumeratedValue && len(f.LangType.UnionTypes) < 2)):
+			errs = append(errs, fmt.Sprintf("type name for %s does not correspond to a generated struct, or a leaf of enumerated or union type", p))
+		case len(no.Values) != 0 && (f == nil || f.LangType == nil || !f.LangType.IsEnumeratedValue):
+			errs = append(errs, fmt.Sprintf("value names for %s do not correspond to a leaf of enumerated type", p))
+		}
+	}
+	if errs == nil {
+		return nil
+	}
+	return fmt.Errorf("name overrides: %s", strings.Join(errs, "; "))
+}
+
+// isEnumeratedValue returns true if v is a value of the enumeration or
+// identityref type t.
+func isEnumeratedValue(t *yang.YangType, v string) bool {
+	switch {
+	case t.Enum != nil:
+		return t.Enum.IsDefined(v)
+	case t.IdentityBase != nil:
+		return t.IdentityBase.IsDefined(v)
+	}
+	return false
+}
@@ -0,0 +1,88 @@
+// Copyright 2023 Google Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package ygen
+
+import (
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/openconfig/gnmi/errdiff"
+)
+
+func TestUnmarshalNameOverrides(t *testing.T) {
+	tests := []struct {
+		name             string
+		in               string
+		want             *NameOverrides
+		wantErrSubstring string
+	}{{
+		name: "valid overrides",
+		in: `{
+  "format_version": 1,
+  "nodes": {
+    "/a:interfaces/a:interface": {"type": "Intf"},
+    "/interfaces/interface/config/description": {"field": "Desc"},
+    "/interfaces/interface/config/status": {"type": "Status", "values": {"UP": "Up"}}
+  }
+}`,
+		want: &NameOverrides{
+			Nodes: map[string]*NameOverride{
+				"/interfaces/interface":                    {Type: "Intf"},
+				"/interfaces/interface/config/description": {Field: "Desc"},
+				"/interfaces/interface/config/status":      {Type: "Status", Values: map[string]string{"UP": "Up"}},
+			},
+		},
+	}, {
+		name:             "unsupported version",
+		in:               `{"format_version": 2}`,
+		wantErrSubstring: "unsupported name overrides format version 2",
+	}, {
+		name:             "invalid JSON",
+		in:               `{`,
+		wantErrSubstring: "cannot unmarshal name overrides",
+	}, {
+		name:             "relative path",
+		in:               `{"format_version": 1, "nodes": {"interfaces": {"type": "Intf"}}}`,
+		wantErrSubstring: `path "interfaces" is not an absolute schema path`,
+	}, {
+		name:             "path specified twice",
+		in:               `{"format_version": 1, "nodes": {"/a:interfaces": {"type": "Intf"}, "/interfaces": {"type": "Intf"}}}`,
+		wantErrSubstring: "path /interfaces is specified more than once",
+	}, {
+		name:             "no names",
+		in:               `{"format_version": 1, "nodes": {"/interfaces": {}}}`,
+		wantErrSubstring: "path /interfaces: no names specified",
+	}, {
+		name:             "invalid identifier",
+		in:               `{"format_version": 1, "nodes": {"/interfaces": {"field": "my-interfaces"}}}`,
+		wantErrSubstring: `path /interfaces: "my-interfaces" is not a valid identifier`,
+	}, {
+		name:             "empty value name",
+		in:               `{"format_version": 1, "nodes": {"/status": {"values": {"UP": ""}}}}`,
+		wantErrSubstring: "path /status: empty name for value UP",
+	}}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := UnmarshalNameOverrides([]byte(tt.in))
+			if diff := errdiff.Substring(err, tt.wantErrSubstring); diff != "" {
+				t.Fatalf("UnmarshalNameOverrides: did not get expected error, %s", diff)
+			}
+			if diff := cmp.Diff(tt.want, got); diff != "" {
+				t.Errorf("UnmarshalNameOverrides: did not get expected overrides, diff(-want, +got):\n%s", diff)
+			}
+		})
+	}
+}
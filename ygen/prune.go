@@ -0,0 +1,265 @@
+// Copyright 2023 Google Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package ygen
+
+import (
+	"fmt"
+	"path"
+	"strings"
+
+	"github.com/openconfig/goyang/pkg/yang"
+	"github.com/openconfig/ygot/util"
+	"github.com/openconfig/ygot/yangschema"
+)
+
+// allowlistPattern is a path of the PathAllowlist transformation option,
+// split into its elements.
+type allowlistPattern struct {
+	// path is the path as it was specified.
+	path string
+	// elems are the elements of the path, with any module prefixes
+	// removed.
+	elems []string
+	// matched records whether the path matched any node of the schema.
+	matched bool
+}
+
+// patternPos is a position within an allowlistPattern, used when walking the
+// schema tree to find the nodes that the pattern matches.
+type patternPos struct {
+	pattern *allowlistPattern
+	// idx is the index of the element of the pattern that is to be matched
+	// against the next data tree node.
+	idx int
+}
+
+// schemaPruner determines the set of schema nodes that are retained when a
+// schema is pruned to the nodes that match a path allowlist.
+type schemaPruner struct {
+	// tree is the schema tree of the unpruned schema, used to resolve
+	// the targets of leafrefs.
+	tree *yangschema.Tree
+	// keep is the set of nodes that are retained.
+	keep map[*yang.Entry]bool
+	// queue is the set of retained nodes whose dependencies have not yet
+	// been added to keep.
+	queue []*yang.Entry
+}
+
+// pruneToAllowlist removes all nodes from the schema trees rooted at the
+// supplied modules, other than those that are matched by one of the paths
+// within allowlist. Each path is an absolute data tree path, which does not
+// include choice or case nodes, and whose elements may optionally include a
+// module prefix. The elements of a path may contain the wildcards supported
+// by path.Match, such that /interfaces/interface/*/mtu matches both the
+// config and state mtu leaves of an interface.
+//
+// A node that is matched is retained along with all its descendants and
+// ancestors. In order that the pruned schema remains valid, the keys of any
+// retained list, and the targets of any retained leafref are also retained.
+// RPCs, actions and notifications are retained in their entirety if any node
+// within them is retained. An error is returned if a path is invalid, does
+// not match any node, or if a leafref within the retained nodes cannot be
+// resolved.
+func pruneToAllowlist(modules []*yang.Entry, allowlist []string) error {
+	var patterns []*allowlistPattern
+	for _, p := range allowlist {
+		pat, err := parseAllowlistPath(p)
+		if err != nil {
+			return err
+		}
+		patterns = append(patterns, pat)
+	}
+
+	var treeElems []*yang.Entry
+	for _, m := range modules {
+		treeElems = append(treeElems, util.Children(m)...)
+	}
+	st, err := yangschema.BuildTree(treeElems)
+	if err != nil {
+		return fmt.Errorf("cannot build schema tree for path allowlist: %v", err)
+	}
+
+	p := &schemaPruner{
+		tree: st,
+		keep: map[*yang.Entry]bool{},
+	}
+
+	var start []patternPos
+	for _, pat := range patterns {
+		start = append(start, patternPos{pattern: pat})
+	}
+	for _, m := range modules {
+		p.match(m, start)
+	}
+	for _, pat := range patterns {
+		if !pat.matched {
+			return fmt.Errorf("path %s in allowlist does not match any schema node", pat.path)
+		}
+	}
+
+	if err := p.closeDependencies(); err != nil {
+		return err
+	}
+	for _, m := range modules {
+		p.prune(m)
+	}
+	return nil
+}
+
+// parseAllowlistPath parses the path p of an allowlist.
+func parseAllowlistPath(p string) (*allowlistPattern, error) {
+	if !strings.HasPrefix(p, "/") || p == "/" {
+		return nil, fmt.Errorf("path %q in allowlist is not an absolute schema path", p)
+	}
+	pat := &allowlistPattern{path: p}
+	for _, e := range strings.Split(strings.TrimPrefix(p, "/"), "/") {
+		e = util.StripModulePrefix(e)
+		if _, err := path.Match(e, ""); err != nil || e == "" {
+			return nil, fmt.Errorf("path %q in allowlist has invalid element %q", p, e)
+		}
+		pat.elems = append(pat.elems, e)
+	}
+	return pat, nil
+}
+
+// match walks the children of the entry e, retaining those that complete a
+// match of any of the patterns at the positions in pos. Choice and case nodes
+// are not data tree nodes, and hence are transparent to matching.
+func (p *schemaPruner) match(e *yang.Entry, pos []patternPos) {
+	for _, ch := range prunableChildren(e) {
+		if util.IsChoiceOrCase(ch) {
+			p.match(ch, pos)
+			continue
+		}
+		var next []patternPos
+		for _, pp := range pos {
+			if ok, _ := path.Match(pp.pattern.elems[pp.idx], ch.Name); !ok {
+				continue
+			}
+			if pp.idx == len(pp.pattern.elems)-1 {
+				pp.pattern.matched = true
+				p.addSubtree(ch)
+				continue
+			}
+			next = append(next, patternPos{pattern: pp.pattern, idx: pp.idx + 1})
+		}
+		if len(next) != 0 {
+			p.match(ch, next)
+		}
+	}
+}
+
+// add marks the entry e as being retained.
+func (p *schemaPruner) add(e *yang.Entry) {
+	if e == nil || p.keep[e] {
+		return
+	}
+	p.keep[e] = true
+	p.queue = append(p.queue, e)
+}
+
+// addSubtree marks the entry e and all of its descendants as being retained.
+func (p *schemaPruner) addSubtree(e *yang.Entry) {
+	p.add(e)
+	for _, ch := range prunableChildren(e) {
+		p.addSubtree(ch)
+	}
+}
+
+// closeDependencies retains the nodes that the retained nodes depend upon,
+// repeating until no further nodes are added. These are the ancestors of
+// each node, the keys of lists, and the targets of leafrefs.
+func (p *schemaPruner) closeDependencies() error {
+	for len(p.queue) != 0 {
+		e := p.queue[0]
+		p.queue = p.queue[1:]
+
+		p.add(e.Parent)
+		if util.IsOperation(e) {
+			p.addSubtree(e)
+		}
+		if e.IsList() {
+			for _, k := range strings.Fields(e.Key) {
+				p.add(e.Dir[k])
+			}
+		}
+		if e.Type == nil {
+			continue
+		}
+		for _, lr := range leafrefPaths(e.Type) {
+			target, err := p.tree.ResolveLeafrefTarget(lr, e)
+			switch {
+			case err != nil && util.IsOperationData(e):
+				// The schema tree contains only the data tree, and
+				// hence leafrefs to other nodes within an operation
+				// cannot be resolved. Operations are retained in
+				// their entirety, so there is nothing to add.
+			case err != nil:
+				return fmt.Errorf("cannot resolve leafref %s at %s for path allowlist: %v", lr, e.Path(), err)
+			default:
+				p.add(target)
+			}
+		}
+	}
+	return nil
+}
+
+// prune removes the children of e that are not retained. The contents of
+// retained operations are not pruned.
+func (p *schemaPruner) prune(e *yang.Entry) {
+	for name, ch := range e.Dir {
+		if !p.keep[ch] {
+			delete(e.Dir, name)
+			continue
+		}
+		if !util.IsOperation(ch) {
+			p.prune(ch)
+		}
+	}
+}
+
+// prunableChildren returns the children of e, including the input and output
+// of an RPC or action.
+func prunableChildren(e *yang.Entry) []*yang.Entry {
+	var children []*yang.Entry
+	for _, ch := range e.Dir {
+		children = append(children, ch)
+	}
+	if e.RPC != nil {
+		for _, io := range []*yang.Entry{e.RPC.Input, e.RPC.Output} {
+			if io != nil {
+				children = append(children, io)
+			}
+		}
+	}
+	return children
+}
+
+// leafrefPaths returns the paths of the leafrefs within the type t, including
+// those that are members of a union.
+func leafrefPaths(t *yang.YangType) []string {
+	switch t.Kind {
+	case yang.Yleafref:
+		return []string{t.Path}
+	case yang.Yunion:
+		var paths []string
+		for _, ut := range t.Type {
+			paths = append(paths, leafrefPaths(ut)...)
+		}
+		return paths
+	}
+	return nil
+}
@@ -35,15 +35,27 @@ import (
 // YANG directories are annotated in the output JSON with the name of the type
 // they correspond to in the generated code, and the absolute schema path that
 // the entry corresponds to. In the case that the fake root struct that is provided
-// is nil, a synthetic root entry is used to store the schema tree.
-func buildJSONTree(ms []*yang.Entry, dn map[string]string, fakeroot *yang.Entry, compressed bool, inclDescriptions bool) ([]byte, error) {
+// is nil, a synthetic root entry is used to store the schema tree. If operations
+// is set, the RPCs, actions and notifications within the modules are included
+// in the tree.
+func buildJSONTree(ms []*yang.Entry, dn map[string]string, fakeroot *yang.Entry, compressed, inclDescriptions, operations bool) ([]byte, error) {
 	rootEntry := &yang.Entry{
 		Dir:        map[string]*yang.Entry{},
 		Annotation: map[string]interface{}{},
 	}
 	for _, m := range ms {
-		annotateChildren(m, dn, inclDescriptions)
-		for _, ch := range util.Children(m) {
+		annotateChildren(m, dn, inclDescriptions, operations)
+		children := util.Children(m)
+		if operations {
+			// RPCs defined at the top-level of the module are stored
+			// alongside its other children such that the schema for
+			// their inputs and outputs can be retrieved.
+			children = nil
+			for _, ch := range m.Dir {
+				children = append(children, ch)
+			}
+		}
+		for _, ch := range children {
 			if _, ex := rootEntry.Dir[ch.Name]; ex {
 				return nil, fmt.Errorf("overlapping root children for key %s", ch.Name)
 			}
@@ -79,15 +91,31 @@ func buildJSONTree(ms []*yang.Entry, dn map[string]string, fakeroot *yang.Entry,
 // annotateChildren annotates the children of e with their schema path, and the value corresponding
 // to its path in the supplied dn map. The dn map is assumed to contain the
 // names of unique directories that are generated within the code to be output.
-// The children of e are recursively annotated.
-func annotateChildren(e *yang.Entry, dn map[string]string, inclDescriptions bool) {
+// The children of e, and, if operations is set, the inputs and outputs of any
+// RPCs and actions defined within it, are recursively annotated.
+func annotateChildren(e *yang.Entry, dn map[string]string, inclDescriptions, operations bool) {
 	annotateEntry(e, dn, inclDescriptions)
 	for _, ch := range util.Children(e) {
 		annotateEntry(ch, dn, inclDescriptions)
 		if ch.IsDir() {
 			ch.Annotation["schemapath"] = ch.Path()
 			// Recurse to annotate the children of this entry.
-			annotateChildren(ch, dn, inclDescriptions)
+			annotateChildren(ch, dn, inclDescriptions, operations)
+		}
+	}
+	if !operations {
+		return
+	}
+	for _, op := range util.Operations(e) {
+		if op.RPC == nil {
+			// Notifications are annotated as children of e.
+			continue
+		}
+		annotateEntry(op, dn, inclDescriptions)
+		for _, io := range []*yang.Entry{op.RPC.Input, op.RPC.Output} {
+			if io != nil {
+				annotateChildren(io, dn, inclDescriptions, operations)
+			}
 		}
 	}
 }
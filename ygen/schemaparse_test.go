@@ -274,7 +274,7 @@ func TestBuildJSONTree(t *testing.T) {
 	}}
 
 	for _, tt := range tests {
-		gotb, err := buildJSONTree(tt.inEntries, tt.inDirectoryNames, tt.inFakeRoot, tt.inCompressed, tt.inIncludeDescriptions)
+		gotb, err := buildJSONTree(tt.inEntries, tt.inDirectoryNames, tt.inFakeRoot, tt.inCompressed, tt.inIncludeDescriptions, false)
 		if err != nil && err.Error() != tt.wantErr {
 			t.Errorf("%s: buildJSONTree(%v, %v): did not get expected error, got: %v, want: %v", tt.name, tt.inEntries, tt.inDirectoryNames, err, tt.wantErr)
 		}
@@ -486,7 +486,7 @@ func TestSchemaRoundtrip(t *testing.T) {
 	}}
 
 	for _, tt := range tests {
-		gotByte, err := buildJSONTree(tt.inEntries, tt.inDirectoryNames, tt.inFakeRoot, tt.inCompressed, tt.inInclDescriptions)
+		gotByte, err := buildJSONTree(tt.inEntries, tt.inDirectoryNames, tt.inFakeRoot, tt.inCompressed, tt.inInclDescriptions, false)
 		if err != nil && err.Error() != tt.wantJSONErr {
 			t.Errorf("%s: buildJSONTree(%v, %v): did not get expected error, got: %v, want: %v", tt.name, tt.inEntries, tt.inDirectoryNames, err, tt.wantJSONErr)
 			continue
@@ -513,3 +513,73 @@ func TestSchemaRoundtrip(t *testing.T) {
 		}
 	}
 }
+
+func TestSchemaRoundtripOperations(t *testing.T) {
+	module := &yang.Entry{
+		Name: "module",
+		Kind: yang.DirectoryEntry,
+		Dir:  map[string]*yang.Entry{},
+	}
+	rpc := &yang.Entry{
+		Name:   "rpc",
+		Kind:   yang.DirectoryEntry,
+		Parent: module,
+		RPC:    &yang.RPCEntry{},
+	}
+	rpc.RPC.Input = &yang.Entry{
+		Name:   "input",
+		Kind:   yang.InputEntry,
+		Parent: rpc,
+		Dir:    map[string]*yang.Entry{},
+	}
+	rpc.RPC.Input.Dir["leaf"] = &yang.Entry{
+		Name:   "leaf",
+		Kind:   yang.LeafEntry,
+		Type:   &yang.YangType{Kind: yang.Ystring},
+		Parent: rpc.RPC.Input,
+	}
+	notif := &yang.Entry{
+		Name:   "notif",
+		Kind:   yang.NotificationEntry,
+		Parent: module,
+		Dir:    map[string]*yang.Entry{},
+	}
+	module.Dir["rpc"] = rpc
+	module.Dir["notif"] = notif
+
+	gotByte, err := buildJSONTree([]*yang.Entry{module}, map[string]string{
+		"/module/rpc/input": "Rpc_Input",
+		"/module/notif":     "Notif",
+	}, nil, false, false, true)
+	if err != nil {
+		t.Fatalf("buildJSONTree: got unexpected error: %v", err)
+	}
+	gotGzip, err := WriteGzippedByteSlice(gotByte)
+	if err != nil {
+		t.Fatalf("WriteGzippedByteSlice: got unexpected error: %v", err)
+	}
+	got, err := ygot.GzipToSchema(gotGzip)
+	if err != nil {
+		t.Fatalf("ygot.GzipToSchema: got unexpected error: %v", err)
+	}
+
+	wantSchemaPaths := map[string]string{
+		"Rpc_Input": "/module/rpc/input",
+		"Notif":     "/module/notif",
+	}
+	gotSchemaPaths := map[string]string{}
+	for n, e := range got {
+		gotSchemaPaths[n], _ = e.Annotation["schemapath"].(string)
+	}
+	if diff := cmp.Diff(wantSchemaPaths, gotSchemaPaths); diff != "" {
+		t.Fatalf("GzipToSchema(...): did not get expected structs, diff(-want, +got):\n%s", diff)
+	}
+
+	input := got["Rpc_Input"]
+	if input.Kind != yang.InputEntry || input.Parent == nil || input.Parent.Name != "rpc" {
+		t.Errorf("GzipToSchema(...): input entry does not have correct kind and parent, got kind %v, parent %v", input.Kind, input.Parent)
+	}
+	if leaf := input.Dir["leaf"]; leaf == nil || leaf.Parent != input {
+		t.Errorf("GzipToSchema(...): input leaf does not have correct parent, got %v", leaf)
+	}
+}
@@ -0,0 +1,299 @@
+// Copyright 2023 Google Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package ygen
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/openconfig/goyang/pkg/yang"
+	"github.com/openconfig/ygot/util"
+	"github.com/openconfig/ygot/ygot"
+)
+
+// TreeDiagram returns the RFC 8340 tree diagram of the schema that is
+// represented by the IR, such that the shape of a compressed schema can be
+// inspected. Each node is annotated with the name of the field that
+// represents it in the generated code, and each container and list is also
+// annotated with the name of its directory. Where the IR has a fake root, the
+// nodes beneath it are rendered within the sections of their modules.
+//
+// The tree diagram cannot be rendered for an IR that was loaded using
+// UnmarshalIR, since the IR does not contain the schema details, such as YANG
+// types, that are rendered.
+func (ir *IR) TreeDiagram() (string, error) {
+	if ir.loaded {
+		return "", fmt.Errorf("cannot render the tree diagram of an IR that was loaded using UnmarshalIR")
+	}
+	tb := &treeBuilder{
+		ir:       ir,
+		modules:  map[string]*yang.Entry{},
+		dirNodes: map[string]*util.TreeNode{},
+	}
+	for _, m := range ir.parsedModules {
+		tb.modules[m.Name] = m
+	}
+	return tb.build()
+}
+
+// treeBuilder builds the nodes of the tree diagram of an IR.
+type treeBuilder struct {
+	// ir is the IR whose tree diagram is built.
+	ir *IR
+	// modules is a map, keyed by module name, of the modules of the IR.
+	modules map[string]*yang.Entry
+	// dirNodes is a map, keyed by the path of a directory, of the nodes
+	// that have been built for directories.
+	dirNodes map[string]*util.TreeNode
+}
+
+// build returns the tree diagram of the IR. The top-level nodes of the data
+// tree are the children of the fake root, or otherwise those directories
+// which are not a field of another directory. Each operation is rendered
+// within the section of its module if it is at the top-level of the schema,
+// or otherwise beneath the closest directory that contains it.
+func (tb *treeBuilder) build() (string, error) {
+	ir := tb.ir
+	opBodies := map[string]bool{}
+	for _, op := range ir.Operations {
+		opBodies[op.InputPath] = true
+		opBodies[op.OutputPath] = true
+		if op.Type == ygot.NotificationOperation {
+			opBodies[op.Path] = true
+		}
+	}
+	fields := map[string]bool{}
+	for _, dir := range ir.Directories {
+		for _, field := range dir.Fields {
+			if field.Type == ContainerNode || field.Type == ListNode {
+				fields[field.YANGDetails.Path] = true
+			}
+		}
+	}
+
+	sections := map[string]*util.TreeDiagramModule{}
+	section := func(e *yang.Entry) *util.TreeDiagramModule {
+		m := util.TopLevelModule(e)
+		s, ok := sections[m.Name]
+		if !ok {
+			s = &util.TreeDiagramModule{Module: m}
+			sections[m.Name] = s
+		}
+		return s
+	}
+
+	for _, p := range ir.OrderedDirectoryPaths() {
+		dir := ir.Directories[p]
+		if fields[p] || opBodies[p] {
+			continue
+		}
+		if dir.IsFakeRoot {
+			children, err := tb.fieldNodes(dir, ir.fakeroot)
+			if err != nil {
+				return "", err
+			}
+			for _, n := range children {
+				section(n.Entry).Data = append(section(n.Entry).Data, n)
+			}
+			continue
+		}
+		n, err := tb.dirNode(dir)
+		if err != nil {
+			return "", err
+		}
+		section(n.Entry).Data = append(section(n.Entry).Data, n)
+	}
+
+	for _, p := range ir.OrderedOperationPaths() {
+		op := ir.Operations[p]
+		n, err := tb.operationNode(op)
+		if err != nil {
+			return "", err
+		}
+		if util.IsRoot(n.Entry.Parent) {
+			s := section(n.Entry)
+			if op.Type == ygot.NotificationOperation {
+				s.Notifications = append(s.Notifications, n)
+			} else {
+				s.RPCs = append(s.RPCs, n)
+			}
+			continue
+		}
+		parent, err := tb.containingNode(p)
+		if err != nil {
+			return "", err
+		}
+		parent.Children = append(parent.Children, n)
+	}
+
+	var ss []*util.TreeDiagramModule
+	for _, s := range sections {
+		ss = append(ss, s)
+	}
+	return util.RenderTreeDiagram(ss), nil
+}
+
+// operationNode returns the node of the operation op. The children of an rpc
+// or action are its input and output, and the children of a notification are
+// the fields of its body.
+func (tb *treeBuilder) operationNode(op *ParsedOperation) (*util.TreeNode, error) {
+	if op.Type == ygot.NotificationOperation {
+		dir, ok := tb.ir.Directories[op.Path]
+		if !ok {
+			return nil, fmt.Errorf("cannot find directory for notification %s", op.Path)
+		}
+		return tb.dirNode(dir)
+	}
+
+	e, err := tb.entry(op.Path)
+	if err != nil {
+		return nil, err
+	}
+	n := &util.TreeNode{Entry: e}
+	for _, p := range []string{op.InputPath, op.OutputPath} {
+		if p == "" {
+			continue
+		}
+		dir, ok := tb.ir.Directories[p]
+		if !ok {
+			return nil, fmt.Errorf("cannot find directory %s of operation %s", p, op.Path)
+		}
+		child, err := tb.dirNode(dir)
+		if err != nil {
+			return nil, err
+		}
+		n.Children = append(n.Children, child)
+	}
+	return n, nil
+}
+
+// containingNode returns the node of the closest directory that contains the
+// node with the path p.
+func (tb *treeBuilder) containingNode(p string) (*util.TreeNode, error) {
+	for i := strings.LastIndex(p, "/"); i > 0; i = strings.LastIndex(p, "/") {
+		p = p[:i]
+		if n, ok := tb.dirNodes[p]; ok {
+			return n, nil
+		}
+	}
+	return nil, fmt.Errorf("cannot find directory containing %s", p)
+}
+
+// dirNode returns the node of the directory dir, annotated with its name.
+func (tb *treeBuilder) dirNode(dir *ParsedDirectory) (*util.TreeNode, error) {
+	e, err := tb.entry(dir.Path)
+	if err != nil {
+		return nil, err
+	}
+	children, err := tb.fieldNodes(dir, e)
+	if err != nil {
+		return nil, err
+	}
+	n := &util.TreeNode{
+		Entry:      e,
+		Annotation: dir.Name,
+		Children:   children,
+	}
+	tb.dirNodes[dir.Path] = n
+	return n, nil
+}
+
+// fieldNodes returns the nodes of the fields of the directory dir, whose
+// schema node is dirEntry. Fields that are within a choice are rendered
+// within nodes for the choice and case statements between the field and the
+// directory, and are otherwise rendered as children of the directory.
+func (tb *treeBuilder) fieldNodes(dir *ParsedDirectory, dirEntry *yang.Entry) ([]*util.TreeNode, error) {
+	var nodes []*util.TreeNode
+	choiceNodes := map[*yang.Entry]*util.TreeNode{}
+	for _, fn := range dir.OrderedFieldNames() {
+		field := dir.Fields[fn]
+		n, err := tb.fieldNode(field)
+		if err != nil {
+			return nil, fmt.Errorf("%s: %v", dir.Path, err)
+		}
+
+		var choices []*yang.Entry
+		for p := n.Entry.Parent; p != nil && p != dirEntry; p = p.Parent {
+			if util.IsChoiceOrCase(p) {
+				choices = append([]*yang.Entry{p}, choices...)
+			}
+		}
+		siblings := &nodes
+		for _, c := range choices {
+			cn, ok := choiceNodes[c]
+			if !ok {
+				cn = &util.TreeNode{Entry: c}
+				choiceNodes[c] = cn
+				*siblings = append(*siblings, cn)
+			}
+			siblings = &cn.Children
+		}
+		*siblings = append(*siblings, n)
+	}
+	return nodes, nil
+}
+
+// fieldNode returns the node of the field, annotated with the name of the
+// field, and the name of its directory for a container or list.
+func (tb *treeBuilder) fieldNode(field *NodeDetails) (*util.TreeNode, error) {
+	switch field.Type {
+	case ContainerNode, ListNode:
+		dir, ok := tb.ir.Directories[field.YANGDetails.Path]
+		if !ok {
+			return nil, fmt.Errorf("cannot find directory for field %s", field.YANGDetails.Path)
+		}
+		n, err := tb.dirNode(dir)
+		if err != nil {
+			return nil, err
+		}
+		n.Annotation = fmt.Sprintf("%s (%s)", field.Name, dir.Name)
+		return n, nil
+	default:
+		e, err := tb.entry(field.YANGDetails.Path)
+		if err != nil {
+			return nil, err
+		}
+		return &util.TreeNode{Entry: e, Annotation: field.Name}, nil
+	}
+}
+
+// entry returns the schema node with the absolute YANG path p, which includes
+// the module name as well as choice and case elements.
+func (tb *treeBuilder) entry(p string) (*yang.Entry, error) {
+	if tb.ir.fakeroot != nil && p == tb.ir.fakeroot.Path() {
+		return tb.ir.fakeroot, nil
+	}
+	parts := strings.Split(strings.TrimPrefix(p, "/"), "/")
+	e, ok := tb.modules[parts[0]]
+	if !ok {
+		return nil, fmt.Errorf("cannot find module %s of schema node %s", parts[0], p)
+	}
+	for _, name := range parts[1:] {
+		var child *yang.Entry
+		switch {
+		case e.RPC != nil && name == "input":
+			child = e.RPC.Input
+		case e.RPC != nil && name == "output":
+			child = e.RPC.Output
+		default:
+			child = e.Dir[name]
+		}
+		if child == nil {
+			return nil, fmt.Errorf("cannot find schema node %s", p)
+		}
+		e = child
+	}
+	return e, nil
+}
@@ -0,0 +1,450 @@
+// Copyright 2024 Google Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package ygot
+
+import (
+	"fmt"
+	"reflect"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/fxamacker/cbor/v2"
+	"github.com/openconfig/gnmi/errlist"
+	"github.com/openconfig/ygot/internal/yreflect"
+	"github.com/openconfig/ygot/util"
+
+	gnmipb "github.com/openconfig/gnmi/proto/gnmi"
+)
+
+const (
+	// CBORTagDecimal64 is the CBOR tag of a decimal fraction, used to
+	// encode decimal64 values per RFC9254 Section 6.3.
+	CBORTagDecimal64 = 4
+	// CBORTagBits is the CBOR tag used to encode bits values within a
+	// union, per RFC9254 Section 9.3.
+	CBORTagBits = 43
+	// CBORTagEnumeration is the CBOR tag used to encode enumeration values
+	// within a union, per RFC9254 Section 9.3.
+	CBORTagEnumeration = 44
+	// CBORTagIdentityref is the CBOR tag used to encode SID-based
+	// identityref values within a union, per RFC9254 Section 9.3.
+	CBORTagIdentityref = 45
+)
+
+// cborEncMode is the encoding mode used for YANG-CBOR. Deterministic
+// encoding is used such that equal GoStructs are encoded to equal bytes.
+var cborEncMode = func() cbor.EncMode {
+	em, err := cbor.CoreDetEncOptions().EncMode()
+	if err != nil {
+		panic(fmt.Sprintf("invalid CBOR encoding options: %v", err))
+	}
+	return em
+}()
+
+// CBORConfig specifies how a GoStruct is encoded to YANG-CBOR.
+type CBORConfig struct {
+	// SIDs specifies the SIDs of data nodes and identities. When set,
+	// SID-based member identifiers, per RFC9254 Section 3.2, are used, and
+	// identityref values are encoded as SIDs. When unset, name-based member
+	// identifiers, per RFC9254 Section 3.3, are used.
+	SIDs *SIDMap
+	// SchemaPath is the schema path of the data node that the encoded
+	// GoStruct corresponds to, in the form accepted by SIDMap.DataSID. It
+	// is used to determine the SIDs of its children when SIDs is set. If it
+	// is unset, the GoStruct is assumed to be the root of the schema.
+	SchemaPath string
+	// PreferShadowPath uses the name of the "shadow-path" tag of a
+	// GoStruct to determine the encoded members instead of the "path" tag,
+	// whenever the former is present.
+	PreferShadowPath bool
+}
+
+// MarshalCBOR encodes the supplied GoStruct to YANG-CBOR, as defined in
+// RFC9254. The encoding of each leaf is determined from the type of the
+// corresponding field of the GoStruct:
+//   - integers are encoded as CBOR integers,
+//   - decimal64 values are encoded as decimal fractions (tag 4),
+//   - enumerations are encoded as their integer value, or as their name with
+//     tag 44 within a union,
+//   - identityrefs are encoded as their SID when SIDs are used (with tag 45
+//     within a union), and as their module-qualified name otherwise,
+//   - empty leaves are encoded as null, and binary leaves as byte strings.
+func MarshalCBOR(s GoStruct, cfg *CBORConfig) ([]byte, error) {
+	v, err := ConstructCBORTree(s, cfg)
+	if err != nil {
+		return nil, err
+	}
+	return cborEncMode.Marshal(v)
+}
+
+// EncodeCBORTypedValue encodes the supplied GoStruct to YANG-CBOR, as per
+// MarshalCBOR, and returns it as a gNMI TypedValue carrying the encoded
+// bytes.
+func EncodeCBORTypedValue(s GoStruct, cfg *CBORConfig) (*gnmipb.TypedValue, error) {
+	b, err := MarshalCBOR(s, cfg)
+	if err != nil {
+		return nil, err
+	}
+	return &gnmipb.TypedValue{Value: &gnmipb.TypedValue_BytesVal{BytesVal: b}}, nil
+}
+
+// ConstructCBORTree returns the tree of Go values, suitable for encoding with
+// a CBOR library, that represents the YANG-CBOR encoding of the supplied
+// GoStruct. Maps within the tree are of type map[any]any, keyed by either
+// a string member name or an int64 SID delta.
+func ConstructCBORTree(s GoStruct, cfg *CBORConfig) (map[any]any, error) {
+	if util.IsValueNil(s) {
+		return nil, fmt.Errorf("cannot encode nil GoStruct to CBOR")
+	}
+	if cfg == nil {
+		cfg = &CBORConfig{}
+	}
+	e := &cborEncoder{cfg: cfg}
+	var path string
+	var sid uint64
+	if cfg.SchemaPath != "" {
+		if cfg.SIDs == nil {
+			return nil, fmt.Errorf("schema path %s specified without SIDs", cfg.SchemaPath)
+		}
+		var ok bool
+		if sid, ok = cfg.SIDs.DataSID(cfg.SchemaPath); !ok {
+			return nil, fmt.Errorf("no SID found for schema path %s", cfg.SchemaPath)
+		}
+		path, _ = cfg.SIDs.DataPath(sid)
+	}
+	return e.structCBOR(reflect.ValueOf(s), cborNode{path: path, module: lastPathModule(path), sid: sid})
+}
+
+// lastPathModule returns the module of the last element of the qualified
+// schema path p.
+func lastPathModule(p string) string {
+	e := p[strings.LastIndex(p, "/")+1:]
+	if i := strings.Index(e, ":"); i != -1 {
+		return e[:i]
+	}
+	return ""
+}
+
+// cborEncoder encodes GoStructs to YANG-CBOR.
+type cborEncoder struct {
+	cfg *CBORConfig
+}
+
+// cborNode describes a data node within the schema.
+type cborNode struct {
+	// path is the schema path of the node, with each element qualified by
+	// the name of its module.
+	path string
+	// module is the name of the module that defines the node.
+	module string
+	// sid is the SID of the node, which is 0 for the root.
+	sid uint64
+}
+
+// child returns the child node of n with the supplied name and module, and
+// the key that identifies the child within the CBOR map of n.
+func (e *cborEncoder) child(n cborNode, name, module string) (cborNode, any, error) {
+	c := cborNode{path: fmt.Sprintf("%s/%s:%s", n.path, module, name), module: module}
+	if e.cfg.SIDs == nil {
+		if module == n.module {
+			return c, name, nil
+		}
+		return c, fmt.Sprintf("%s:%s", module, name), nil
+	}
+	sid, ok := e.cfg.SIDs.DataSID(c.path)
+	if !ok {
+		return c, nil, fmt.Errorf("no SID found for schema path %s", c.path)
+	}
+	c.sid = sid
+	return c, int64(sid) - int64(n.sid), nil
+}
+
+// structCBOR returns the CBOR map for the GoStruct pointer sval, which
+// corresponds to the data node n.
+func (e *cborEncoder) structCBOR(sval reflect.Value, n cborNode) (map[any]any, error) {
+	var errs errlist.List
+	out := map[any]any{}
+	v := sval.Elem()
+	t := v.Type()
+	for i := 0; i < v.NumField(); i++ {
+		field, fType := v.Field(i), t.Field(i)
+		// Unset fields are skipped before their paths are resolved, such
+		// that a SID is only required for the data nodes that are encoded.
+		if util.IsYgotAnnotation(fType) || util.IsValueNilOrDefault(field.Interface()) {
+			continue
+		}
+
+		paths, err := structTagToLibPaths(fType, newStringSliceGNMIPath([]string{}), e.cfg.PreferShadowPath)
+		if err != nil {
+			errs.Add(fmt.Errorf("%s: %v", fType.Name, err))
+			continue
+		}
+		modules, err := structTagToLibModules(fType, e.cfg.PreferShadowPath)
+		if err != nil {
+			errs.Add(fmt.Errorf("%s: %v", fType.Name, err))
+			continue
+		}
+		if len(modules) != len(paths) {
+			errs.Add(fmt.Errorf("%s: number of paths and modules in struct tag not the same: (paths: %v, modules: %v)", fType.Name, len(paths), len(modules)))
+			continue
+		}
+
+		for j, p := range paths {
+			ps, ms := p.stringSlicePath, modules[j].stringSlicePath
+			if len(ps) != len(ms) || len(ps) == 0 {
+				errs.Add(fmt.Errorf("%s: invalid path and module tags: (paths: %v, modules: %v)", fType.Name, ps, ms))
+				continue
+			}
+			// Determine the keys of each element of the path.
+			cn, keys := n, make([]any, len(ps))
+			var keyErr error
+			for k := 0; k < len(ps) && keyErr == nil; k++ {
+				cn, keys[k], keyErr = e.child(cn, ps[k], ms[k])
+			}
+			if keyErr != nil {
+				errs.Add(fmt.Errorf("%s: %v", fType.Name, keyErr))
+				continue
+			}
+
+			val, set, err := e.fieldCBOR(field, fType, cn)
+			if err != nil {
+				errs.Add(fmt.Errorf("%s: %v", fType.Name, err))
+				continue
+			}
+			if !set {
+				continue
+			}
+
+			m := out
+			for _, k := range keys[:len(keys)-1] {
+				c, ok := m[k].(map[any]any)
+				if !ok {
+					c = map[any]any{}
+					m[k] = c
+				}
+				m = c
+			}
+			m[keys[len(keys)-1]] = val
+		}
+	}
+	if err := errs.Err(); err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// fieldCBOR returns the CBOR representation of the field with value v and
+// type f, which corresponds to the data node n. It returns false if the
+// field is not set.
+func (e *cborEncoder) fieldCBOR(v reflect.Value, f reflect.StructField, n cborNode) (any, bool, error) {
+	if util.IsNilOrInvalidValue(v) || (v.Kind() == reflect.Interface || v.Kind() == reflect.Slice || v.Kind() == reflect.Map) && v.IsNil() {
+		return nil, false, nil
+	}
+
+	if _, ok := v.Interface().(*AnyData); ok {
+		return nil, false, fmt.Errorf("cannot encode anydata field %s as CBOR, unsupported", f.Name)
+	}
+
+	if om, ok := v.Interface().(GoOrderedMap); ok {
+		var l []any
+		var err error
+		if rerr := yreflect.RangeOrderedMap(om, func(_ reflect.Value, lv reflect.Value) bool {
+			var m map[any]any
+			if m, err = e.structCBOR(lv, n); err != nil {
+				return false
+			}
+			l = append(l, m)
+			return true
+		}); rerr != nil {
+			return nil, false, rerr
+		}
+		if err != nil {
+			return nil, false, err
+		}
+		return l, len(l) != 0, nil
+	}
+
+	switch {
+	case v.Kind() == reflect.Map:
+		type entry struct {
+			k string
+			v reflect.Value
+		}
+		var entries []entry
+		iter := v.MapRange()
+		for iter.Next() {
+			k, err := mapKeyToJSONString(iter.Key(), jsonOutputConfig{jType: Internal})
+			if err != nil {
+				return nil, false, err
+			}
+			entries = append(entries, entry{k: k, v: iter.Value()})
+		}
+		sort.Slice(entries, func(i, j int) bool { return entries[i].k < entries[j].k })
+		var l []any
+		for _, en := range entries {
+			m, err := e.structCBOR(en.v, n)
+			if err != nil {
+				return nil, false, err
+			}
+			l = append(l, m)
+		}
+		return l, len(l) != 0, nil
+	case v.Kind() == reflect.Slice && util.IsTypeStructPtr(v.Type().Elem()):
+		// Unkeyed list.
+		var l []any
+		for i := 0; i < v.Len(); i++ {
+			m, err := e.structCBOR(v.Index(i), n)
+			if err != nil {
+				return nil, false, err
+			}
+			l = append(l, m)
+		}
+		return l, len(l) != 0, nil
+	case v.Kind() == reflect.Slice && v.Type().Name() != BinaryTypeName:
+		// Leaf-list.
+		var l []any
+		for i := 0; i < v.Len(); i++ {
+			lv, set, err := e.leafCBOR(v.Index(i), false)
+			if err != nil {
+				return nil, false, err
+			}
+			if set {
+				l = append(l, lv)
+			}
+		}
+		return l, len(l) != 0, nil
+	case util.IsValueStructPtr(v):
+		if _, ok := v.Interface().(GoStruct); !ok {
+			break
+		}
+		m, err := e.structCBOR(v, n)
+		if err != nil {
+			return nil, false, err
+		}
+		return m, len(m) != 0 || util.IsYangPresence(f), nil
+	}
+
+	return e.leafCBOR(v, false)
+}
+
+// leafCBOR returns the CBOR representation of the leaf value v, and whether
+// the value is set. inUnion indicates that v is a member of a union.
+func (e *cborEncoder) leafCBOR(v reflect.Value, inUnion bool) (any, bool, error) {
+	switch {
+	case util.IsNilOrInvalidValue(v):
+		return nil, false, nil
+	case v.Kind() == reflect.Interface:
+		if v.IsNil() {
+			return nil, false, nil
+		}
+		if util.IsValueInterfaceToStructPtr(v) {
+			s := v.Elem().Elem()
+			if !util.IsStructValueWithNFields(s, 1) {
+				return nil, false, fmt.Errorf("received a union type which did not have one field, had: %v", s.NumField())
+			}
+			return e.leafCBOR(s.Field(0), true)
+		}
+		return e.leafCBOR(v.Elem(), true)
+	case v.Kind() == reflect.Ptr:
+		if v.IsNil() {
+			return nil, false, nil
+		}
+		if util.IsValueStructPtr(v) {
+			// Union pointer, used when a list is keyed by a union.
+			if !util.IsStructValueWithNFields(v.Elem(), 1) {
+				return nil, false, fmt.Errorf("received a union pointer struct that didn't have one field, got: %v", v.Elem().NumField())
+			}
+			return e.leafCBOR(v.Elem().Field(0), true)
+		}
+		return e.leafCBOR(v.Elem(), inUnion)
+	}
+
+	if _, ok := v.Interface().(GoEnum); ok {
+		return e.enumCBOR(v, inUnion)
+	}
+
+	switch v.Kind() {
+	case reflect.String:
+		return v.String(), true, nil
+	case reflect.Bool:
+		if v.Type().Name() == EmptyTypeName {
+			// An empty leaf is encoded as null when it is set, and is
+			// omitted otherwise.
+			return nil, v.Bool(), nil
+		}
+		return v.Bool(), true, nil
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		return v.Int(), true, nil
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		return v.Uint(), true, nil
+	case reflect.Float32, reflect.Float64:
+		d, err := decimal64CBOR(v.Float())
+		return d, err == nil, err
+	case reflect.Slice:
+		if v.Type().Elem().Kind() == reflect.Uint8 {
+			return v.Bytes(), true, nil
+		}
+	}
+	return nil, false, fmt.Errorf("cannot encode value of type %v to CBOR", v.Type())
+}
+
+// enumCBOR returns the CBOR representation of the enumerated value v, which
+// is either a YANG enumeration or identityref.
+func (e *cborEncoder) enumCBOR(v reflect.Value, inUnion bool) (any, bool, error) {
+	if v.Int() == 0 {
+		return nil, false, nil
+	}
+	name, _, err := enumFieldToString(v, false)
+	if err != nil {
+		return nil, false, err
+	}
+	def := v.Interface().(GoEnum).ΛMap()[v.Type().Name()][v.Int()]
+
+	if def.DefiningModule == "" {
+		if inUnion {
+			return cbor.Tag{Number: CBORTagEnumeration, Content: name}, true, nil
+		}
+		// Generated enumerated types store the YANG value of an
+		// enumeration incremented by one, such that 0 is UNSET.
+		return v.Int() - 1, true, nil
+	}
+
+	if e.cfg.SIDs != nil {
+		if sid, ok := e.cfg.SIDs.IdentitySID(def.DefiningModule, name); ok {
+			if inUnion {
+				return cbor.Tag{Number: CBORTagIdentityref, Content: sid}, true, nil
+			}
+			return sid, true, nil
+		}
+	}
+	return fmt.Sprintf("%s:%s", def.DefiningModule, name), true, nil
+}
+
+// decimal64CBOR returns the decimal fraction representation of the supplied
+// decimal64 value, per RFC9254 Section 6.3.
+func decimal64CBOR(f float64) (cbor.Tag, error) {
+	s := strconv.FormatFloat(f, 'f', -1, 64)
+	var exp int64
+	if i := strings.Index(s, "."); i != -1 {
+		exp = -int64(len(s) - i - 1)
+		s = s[:i] + s[i+1:]
+	}
+	m, err := strconv.ParseInt(s, 10, 64)
+	if err != nil {
+		return cbor.Tag{}, fmt.Errorf("cannot encode %v as decimal64: %v", f, err)
+	}
+	return cbor.Tag{Number: CBORTagDecimal64, Content: []any{exp, m}}, nil
+}
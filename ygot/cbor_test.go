@@ -0,0 +1,282 @@
+// Copyright 2024 Google Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package ygot
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/fxamacker/cbor/v2"
+	"github.com/google/go-cmp/cmp"
+	"github.com/openconfig/gnmi/errdiff"
+)
+
+// cborTestEnum is an enumeration used in the CBOR encoding tests, whose
+// YANG values are 0 and 10.
+type cborTestEnum int64
+
+func (cborTestEnum) IsYANGGoEnum()    {}
+func (cborTestEnum) IsCBORTestUnion() {}
+func (e cborTestEnum) String() string { return EnumLogString(e, int64(e), "cborTestEnum") }
+
+func (cborTestEnum) ΛMap() map[string]map[int64]EnumDefinition {
+	return map[string]map[int64]EnumDefinition{
+		"cborTestEnum": {
+			1:  {Name: "ZERO"},
+			11: {Name: "TEN"},
+		},
+	}
+}
+
+// cborTestUnion is a union used in the CBOR encoding tests.
+type cborTestUnion interface {
+	IsCBORTestUnion()
+}
+
+type cborTestUnionString struct {
+	String string
+}
+
+func (*cborTestUnionString) IsCBORTestUnion() {}
+
+type cborTestUnionIdentity struct {
+	Identity EnumTest
+}
+
+func (*cborTestUnionIdentity) IsCBORTestUnion() {}
+
+type cborTestRoot struct {
+	Name     *string                 `path:"config/name|name" module:"cmod/cmod|cmod"`
+	Counter  *int64                  `path:"state/counter" module:"cmod/cmod"`
+	Ratio    *float64                `path:"state/ratio" module:"cmod/cmod"`
+	Enum     cborTestEnum            `path:"state/enum" module:"cmod/cmod"`
+	Ident    EnumTest                `path:"state/ident" module:"cmod/cmod"`
+	Union    cborTestUnion           `path:"state/union" module:"cmod/cmod"`
+	Unions   []cborTestUnion         `path:"state/unions" module:"cmod/cmod"`
+	Flag     YANGEmpty               `path:"state/flag" module:"cmod/cmod"`
+	Data     Binary                  `path:"state/data" module:"cmod/cmod"`
+	Aug      *uint8                  `path:"state/aug" module:"cmod/caug"`
+	List     map[string]*cborTestSKE `path:"entries/entry" module:"cmod/cmod"`
+	Unkeyed  []*cborTestSKE          `path:"unkeyed" module:"cmod"`
+	Presence *cborTestSKE            `path:"presence" module:"cmod" yangPresence:"true"`
+}
+
+func (*cborTestRoot) IsYANGGoStruct()                         {}
+func (*cborTestRoot) ΛValidate(...ValidationOption) error     { return nil }
+func (*cborTestRoot) ΛEnumTypeMap() map[string][]reflect.Type { return nil }
+func (*cborTestRoot) ΛBelongingModule() string                { return "cmod" }
+
+type cborTestSKE struct {
+	Key *string `path:"key" module:"cmod"`
+}
+
+func (*cborTestSKE) IsYANGGoStruct()                         {}
+func (*cborTestSKE) ΛValidate(...ValidationOption) error     { return nil }
+func (*cborTestSKE) ΛEnumTypeMap() map[string][]reflect.Type { return nil }
+func (*cborTestSKE) ΛBelongingModule() string                { return "cmod" }
+
+func (e *cborTestSKE) ΛListKeyMap() (map[string]any, error) {
+	return map[string]any{"key": *e.Key}, nil
+}
+
+// cborTestSIDs returns the SIDs used for the cborTestRoot struct.
+func cborTestSIDs(t *testing.T) *SIDMap {
+	t.Helper()
+	m := NewSIDMap()
+	for p, sid := range map[string]uint64{
+		"/cmod:config":            1000,
+		"/cmod:config/name":       1001,
+		"/cmod:name":              1002,
+		"/cmod:state":             1010,
+		"/cmod:state/counter":     1011,
+		"/cmod:state/ident":       1012,
+		"/cmod:state/union":       1013,
+		"/cmod:state/caug:aug":    1100,
+		"/cmod:entries":           1020,
+		"/cmod:entries/entry":     1021,
+		"/cmod:entries/entry/key": 1022,
+	} {
+		if err := m.AddDataSID(p, sid); err != nil {
+			t.Fatalf("cannot add SID for %s: %v", p, err)
+		}
+	}
+	if err := m.AddIdentitySID("bar", "VAL_TWO", 2000); err != nil {
+		t.Fatalf("cannot add identity SID: %v", err)
+	}
+	return m
+}
+
+func TestConstructCBORTree(t *testing.T) {
+	tests := []struct {
+		desc             string
+		in               GoStruct
+		inConfig         *CBORConfig
+		want             map[any]any
+		wantErrSubstring string
+	}{{
+		desc: "name-based identifiers",
+		in: &cborTestRoot{
+			Name:     String("n"),
+			Counter:  Int64(-42),
+			Ratio:    Float64(-1.25),
+			Enum:     11,
+			Ident:    EnumTestVALTWO,
+			Union:    cborTestEnum(1),
+			Unions:   []cborTestUnion{&cborTestUnionString{"s"}, &cborTestUnionIdentity{EnumTestVALONE}},
+			Flag:     true,
+			Data:     Binary("abc"),
+			Aug:      Uint8(1),
+			List:     map[string]*cborTestSKE{"b": {Key: String("b")}, "a": {Key: String("a")}},
+			Unkeyed:  []*cborTestSKE{{Key: String("u")}},
+			Presence: &cborTestSKE{},
+		},
+		want: map[any]any{
+			"cmod:config": map[any]any{"name": "n"},
+			"cmod:name":   "n",
+			"cmod:state": map[any]any{
+				"counter":  int64(-42),
+				"ratio":    cbor.Tag{Number: CBORTagDecimal64, Content: []any{int64(-2), int64(-125)}},
+				"enum":     int64(10),
+				"ident":    "bar:VAL_TWO",
+				"union":    cbor.Tag{Number: CBORTagEnumeration, Content: "ZERO"},
+				"unions":   []any{"s", "foo:VAL_ONE"},
+				"flag":     nil,
+				"data":     []byte("abc"),
+				"caug:aug": uint64(1),
+			},
+			"cmod:entries": map[any]any{
+				"entry": []any{
+					map[any]any{"key": "a"},
+					map[any]any{"key": "b"},
+				},
+			},
+			"cmod:unkeyed":  []any{map[any]any{"key": "u"}},
+			"cmod:presence": map[any]any{},
+		},
+	}, {
+		desc: "SID-based identifiers",
+		in: &cborTestRoot{
+			Name:  String("n"),
+			Ident: EnumTestVALTWO,
+			Union: &cborTestUnionIdentity{EnumTestVALTWO},
+			Aug:   Uint8(1),
+			List:  map[string]*cborTestSKE{"a": {Key: String("a")}},
+		},
+		inConfig: &CBORConfig{SIDs: cborTestSIDs(t)},
+		want: map[any]any{
+			int64(1000): map[any]any{int64(1): "n"},
+			int64(1002): "n",
+			int64(1010): map[any]any{
+				int64(2):  uint64(2000),
+				int64(3):  cbor.Tag{Number: CBORTagIdentityref, Content: uint64(2000)},
+				int64(90): uint64(1),
+			},
+			int64(1020): map[any]any{
+				int64(1): []any{map[any]any{int64(1): "a"}},
+			},
+		},
+	}, {
+		desc:     "SID-based identifiers relative to a schema path",
+		in:       &cborTestSKE{Key: String("a")},
+		inConfig: &CBORConfig{SIDs: cborTestSIDs(t), SchemaPath: "/entries/entry"},
+		want: map[any]any{
+			int64(1): "a",
+		},
+	}, {
+		desc:             "missing SID",
+		in:               &cborTestRoot{Counter: Int64(1), Ratio: Float64(1)},
+		inConfig:         &CBORConfig{SIDs: cborTestSIDs(t)},
+		wantErrSubstring: "no SID found for schema path /cmod:state/cmod:ratio",
+	}, {
+		desc:             "missing SID for schema path",
+		in:               &cborTestSKE{},
+		inConfig:         &CBORConfig{SIDs: cborTestSIDs(t), SchemaPath: "/fish"},
+		wantErrSubstring: "no SID found for schema path /fish",
+	}, {
+		desc:             "schema path without SIDs",
+		in:               &cborTestSKE{},
+		inConfig:         &CBORConfig{SchemaPath: "/entries/entry"},
+		wantErrSubstring: "schema path /entries/entry specified without SIDs",
+	}, {
+		desc:             "nil struct",
+		in:               (*cborTestRoot)(nil),
+		wantErrSubstring: "cannot encode nil GoStruct",
+	}}
+
+	for _, tt := range tests {
+		t.Run(tt.desc, func(t *testing.T) {
+			got, err := ConstructCBORTree(tt.in, tt.inConfig)
+			if diff := errdiff.Substring(err, tt.wantErrSubstring); diff != "" {
+				t.Fatalf("ConstructCBORTree: did not get expected error, %s", diff)
+			}
+			if err != nil {
+				return
+			}
+			if diff := cmp.Diff(tt.want, got); diff != "" {
+				t.Errorf("ConstructCBORTree: did not get expected tree, diff(-want, +got):\n%s", diff)
+			}
+		})
+	}
+}
+
+func TestMarshalCBOR(t *testing.T) {
+	in := &cborTestRoot{
+		Counter: Int64(1),
+		Ratio:   Float64(0.5),
+		Union:   &cborTestUnionString{"s"},
+	}
+	got, err := MarshalCBOR(in, nil)
+	if err != nil {
+		t.Fatalf("MarshalCBOR: %v", err)
+	}
+	gotDiag, err := cbor.Diagnose(got)
+	if err != nil {
+		t.Fatalf("cannot diagnose CBOR output: %v", err)
+	}
+	want := `{"cmod:state": {"ratio": 4([-1, 5]), "union": "s", "counter": 1}}`
+	if gotDiag != want {
+		t.Errorf("MarshalCBOR(%v): got %s, want %s", in, gotDiag, want)
+	}
+
+	tv, err := EncodeCBORTypedValue(in, nil)
+	if err != nil {
+		t.Fatalf("EncodeCBORTypedValue: %v", err)
+	}
+	if diff := cmp.Diff(got, tv.GetBytesVal()); diff != "" {
+		t.Errorf("EncodeCBORTypedValue: did not get expected bytes, diff(-want, +got):\n%s", diff)
+	}
+}
+
+func TestDecimal64CBOR(t *testing.T) {
+	tests := []struct {
+		in   float64
+		want []any
+	}{
+		{in: 0, want: []any{int64(0), int64(0)}},
+		{in: 42, want: []any{int64(0), int64(42)}},
+		{in: 273.15, want: []any{int64(-2), int64(27315)}},
+		{in: -0.001, want: []any{int64(-3), int64(-1)}},
+	}
+	for _, tt := range tests {
+		got, err := decimal64CBOR(tt.in)
+		if err != nil {
+			t.Errorf("decimal64CBOR(%v): got unexpected error: %v", tt.in, err)
+			continue
+		}
+		if diff := cmp.Diff(cbor.Tag{Number: CBORTagDecimal64, Content: tt.want}, got); diff != "" {
+			t.Errorf("decimal64CBOR(%v): did not get expected tag, diff(-want, +got):\n%s", tt.in, diff)
+		}
+	}
+}
@@ -231,127 +231,188 @@ func toStringPathMap(pathMap map[*pathSpec]interface{}) (map[string]*pathInfo, e
 // not included within the returned map, such that only leaf or leaf-list values
 // that are set are returned.
 //
-// The ForEachDataField helper of the util library is used to perform the iterative
-// walk of the struct - using the out argument to store the set of changed leaves.
-// A specific Annotation is used to store the absolute path of the entity during
-// the walk.
+// util.Walk is used to perform the iterative walk of the struct, with a
+// findSetLeavesVisitor storing the set of changed leaves. A specific
+// Annotation is used to store the absolute path of the entity during the walk.
 //
 // - orderedMapAsLeaf=true specifies that ordered maps (GoOrderedMap
 // interface) will be treated as a leaf and will be returned as-is instead of
 // being walked and its leaves populated.
 func findSetLeaves(s GoStruct, orderedMapAsLeaf bool, opts ...DiffOpt) (map[*pathSpec]interface{}, error) {
-	pathOpt := hasDiffPathOpt(opts)
-	processedPaths := map[string]bool{}
+	v := newFindSetLeavesVisitor(orderedMapAsLeaf, opts)
+	if !util.IsValueNil(s) {
+		util.Walk(v, util.WalkNodeFromGoStruct(s), util.DefaultWalkOptions().WithWalkErrors(v.errs))
+	}
+	if v.errs.Errors != nil {
+		return nil, fmt.Errorf("error from walking data tree: %v", v.errs.Errors)
+	}
 
-	findSetIterFunc := func(ni *util.NodeInfo, in, out interface{}) (action util.IterationAction, errs util.Errors) {
-		if reflect.DeepEqual(ni.StructField, reflect.StructField{}) {
-			return
-		}
+	return v.out, nil
+}
 
-		// Handle the case of having an annotated struct - in the diff case we
-		// do not process schema annotations.
-		if util.IsYgotAnnotation(ni.StructField) {
-			return
-		}
+// findSetLeavesVisitor is a util.Visitor that records the leaves that are set
+// within the data tree being walked.
+type findSetLeavesVisitor struct {
+	// pathOpt is the DiffPathOpt specified by the caller, if any.
+	pathOpt *DiffPathOpt
+	// orderedMapAsLeaf specifies whether ordered maps are treated as
+	// leaves.
+	orderedMapAsLeaf bool
+	// processedPaths is the set of paths that have already been visited,
+	// keyed by their string form.
+	processedPaths map[string]bool
+	// out stores the value of each set leaf, keyed by its path.
+	out map[*pathSpec]interface{}
+	// errs collects the errors encountered during the walk.
+	errs *util.DefaultWalkErrors
+}
 
-		var sp [][]string
-		if pathOpt != nil && pathOpt.PreferShadowPath {
-			// Try the shadow-path tag first to see if it exists.
-			sp = util.ShadowSchemaPaths(ni.StructField)
-		}
-		if len(sp) == 0 {
-			var err error
-			if sp, err = util.SchemaPaths(ni.StructField); err != nil {
-				errs = util.AppendErr(errs, err)
-				return
-			}
-		}
-		if len(sp) == 0 {
-			errs = util.AppendErr(errs, fmt.Errorf("invalid schema path for %s", ni.StructField.Name))
-			return
-		}
+// newFindSetLeavesVisitor returns a findSetLeavesVisitor that records the
+// set leaves according to the supplied options.
+func newFindSetLeavesVisitor(orderedMapAsLeaf bool, opts []DiffOpt) *findSetLeavesVisitor {
+	return &findSetLeavesVisitor{
+		pathOpt:          hasDiffPathOpt(opts),
+		orderedMapAsLeaf: orderedMapAsLeaf,
+		processedPaths:   map[string]bool{},
+		out:              map[*pathSpec]interface{}{},
+		errs:             &util.DefaultWalkErrors{},
+	}
+}
 
-		// If the path options specify that each value should only be mapped to
-		// a single path, then choose the most specific path.
-		if pathOpt != nil && pathOpt.MapToSinglePath {
-			sp = [][]string{leastSpecificPath(sp)}
-		}
+// Visit implements the util.Visitor interface.
+func (v *findSetLeavesVisitor) Visit(node util.WalkNode) util.Visitor {
+	if node == nil {
+		return nil
+	}
+	action, errs := v.visitNode(node.NodeInfo())
+	for _, err := range errs {
+		v.errs.Collect(err)
+	}
+	if action == util.DoNotIterateDescendants {
+		return nil
+	}
+	return v
+}
 
-		vp, err := nodeValuePath(ni, sp)
-		if err != nil {
-			errs = util.NewErrs(err)
-			return
-		}
+// visitNode records the value of the node described by ni if it is a set
+// leaf, and returns whether its descendants should be walked.
+func (v *findSetLeavesVisitor) visitNode(ni *util.NodeInfo) (action util.IterationAction, errs util.Errors) {
+	if reflect.DeepEqual(ni.StructField, reflect.StructField{}) {
+		return
+	}
 
-		// Avoid processing twice if there is duplicate path.
-		keys := make([]string, len(vp.gNMIPaths))
-		for i, paths := range vp.gNMIPaths {
-			s, err := PathToString(paths)
-			if err != nil {
-				errs = util.NewErrs(err)
-				return
-			}
-			keys[i] = s
-		}
-		sort.Strings(keys)
-		key := strings.Join(keys, "/")
-		if _, ok := processedPaths[key]; ok {
+	// Handle the case of having an annotated struct - in the diff case we
+	// do not process schema annotations.
+	if util.IsYgotAnnotation(ni.StructField) {
+		return
+	}
+
+	var sp [][]string
+	if v.pathOpt != nil && v.pathOpt.PreferShadowPath {
+		// Try the shadow-path tag first to see if it exists.
+		sp = util.ShadowSchemaPaths(ni.StructField)
+	}
+	if len(sp) == 0 {
+		var err error
+		if sp, err = util.SchemaPaths(ni.StructField); err != nil {
+			errs = util.AppendErr(errs, err)
 			return
 		}
-		processedPaths[key] = true
+	}
+	if len(sp) == 0 {
+		errs = util.AppendErr(errs, fmt.Errorf("invalid schema path for %s", ni.StructField.Name))
+		return
+	}
 
-		ni.Annotation = []interface{}{vp}
+	// If the path options specify that each value should only be mapped to
+	// a single path, then choose the most specific path.
+	if v.pathOpt != nil && v.pathOpt.MapToSinglePath {
+		sp = [][]string{leastSpecificPath(sp)}
+	}
 
-		ival := ni.FieldValue.Interface()
+	vp, err := nodeValuePath(ni, sp)
+	if err != nil {
+		errs = util.NewErrs(err)
+		return
+	}
 
-		orderedMap, isOrderedMap := ival.(GoOrderedMap)
+	// Avoid processing twice if there is duplicate path.
+	key, err := pathSpecKey(vp)
+	if err != nil {
+		errs = util.NewErrs(err)
+		return
+	}
+	if _, ok := v.processedPaths[key]; ok {
+		return
+	}
+	v.processedPaths[key] = true
 
-		// Ignore non-data, or default data values.
-		if util.IsNilOrInvalidValue(ni.FieldValue) || util.IsValueNilOrDefault(ni.FieldValue.Interface()) || util.IsValueMap(ni.FieldValue) {
-			return
-		}
-		// Ignore structs unless it is an ordered map and we're
-		// treating it as a leaf (since it is assumed to be
-		// telemetry-atomic in order to preserve ordering of entries).
-		if (!isOrderedMap || !orderedMapAsLeaf) && util.IsValueStructPtr(ni.FieldValue) {
-			return
-		}
-		if isOrderedMap && orderedMap.Len() == 0 {
-			return
-		}
+	ni.Annotation = []interface{}{vp}
 
-		// If this is an enumerated value in the output structs, then check whether
-		// it is set. Only include values that are set to a non-zero value.
-		if _, isEnum := ival.(GoEnum); isEnum {
-			val := ni.FieldValue
-			// If the value is a simple union enum, then extract
-			// the underlying enum value from the interface.
-			if val.Kind() == reflect.Interface {
-				val = val.Elem()
-			}
-			if val.Int() == 0 {
-				return
-			}
-		}
+	ival := ni.FieldValue.Interface()
 
-		outs := out.(map[*pathSpec]interface{})
-		outs[vp] = ival
+	orderedMap, isOrderedMap := ival.(GoOrderedMap)
+	anyData, isAnyData := ival.(*AnyData)
 
-		if isOrderedMap && orderedMapAsLeaf {
-			// We treat the ordered map as a leaf, so don't
-			// traverse any descendant elements.
-			action = util.DoNotIterateDescendants
-		}
+	// Ignore non-data, or default data values.
+	if util.IsNilOrInvalidValue(ni.FieldValue) || util.IsValueNilOrDefault(ni.FieldValue.Interface()) || util.IsValueMap(ni.FieldValue) {
+		return
+	}
+	// Ignore structs unless it is an ordered map and we're
+	// treating it as a leaf (since it is assumed to be
+	// telemetry-atomic in order to preserve ordering of entries),
+	// or it is the contents of an anydata or anyxml node, which
+	// is always treated as a leaf.
+	if (!isOrderedMap || !v.orderedMapAsLeaf) && !isAnyData && util.IsValueStructPtr(ni.FieldValue) {
+		return
+	}
+	if isAnyData && util.IsValueNil(anyData.Value) && len(anyData.JSON) == 0 {
+		return
+	}
+	if isOrderedMap && orderedMap.Len() == 0 {
+		return
+	}
 
+	// If this is an enumerated value in the output structs, then check whether
+	// it is set. Only include values that are set to a non-zero value.
+	if isUnsetEnum(ival) {
 		return
 	}
 
-	out := map[*pathSpec]interface{}{}
-	if errs := util.ForEachDataField2(s, nil, out, findSetIterFunc); errs != nil {
-		return nil, fmt.Errorf("error from ForEachDataField iteration: %v", errs)
+	v.out[vp] = ival
+
+	if isOrderedMap && v.orderedMapAsLeaf {
+		// We treat the ordered map as a leaf, so don't
+		// traverse any descendant elements.
+		action = util.DoNotIterateDescendants
+	}
+
+	return
+}
+
+// pathSpecKey returns a string that uniquely identifies the set of paths
+// within vp.
+func pathSpecKey(vp *pathSpec) (string, error) {
+	keys := make([]string, len(vp.gNMIPaths))
+	for i, paths := range vp.gNMIPaths {
+		s, err := PathToString(paths)
+		if err != nil {
+			return "", err
+		}
+		keys[i] = s
 	}
+	sort.Strings(keys)
+	return strings.Join(keys, "/"), nil
+}
 
-	return out, nil
+// isUnsetEnum determines whether v is a value of an enumerated type that is
+// not set to a non-zero value. Simple union enums are passed as their
+// underlying enum value.
+func isUnsetEnum(v interface{}) bool {
+	if _, isEnum := v.(GoEnum); !isEnum {
+		return false
+	}
+	return reflect.ValueOf(v).Int() == 0
 }
 
 // hasDiffPathOpt extracts a DiffPathOpt from the opts slice provided. In
@@ -657,14 +718,20 @@ func diff(original, modified GoStruct, withAtomic bool, opts ...DiffOpt) ([]*gnm
 		return nil, fmt.Errorf("cannot diff structs of different types, original: %T, modified: %T", original, modified)
 	}
 
-	origLeaves, err := findSetLeaves(original, withAtomic, opts...)
-	if err != nil {
-		return nil, fmt.Errorf("could not extract set leaves from original struct: %v", err)
-	}
-
-	modLeaves, err := findSetLeaves(modified, withAtomic, opts...)
-	if err != nil {
-		return nil, fmt.Errorf("could not extract set leaves from modified struct: %v", err)
+	var origLeaves, modLeaves map[*pathSpec]interface{}
+	if d, ok := original.(GoStructDiffer); ok {
+		var err error
+		if origLeaves, modLeaves, err = findDifferingLeaves(d, modified, withAtomic, opts...); err != nil {
+			return nil, fmt.Errorf("could not extract differing leaves from structs: %v", err)
+		}
+	} else {
+		var err error
+		if origLeaves, err = findSetLeaves(original, withAtomic, opts...); err != nil {
+			return nil, fmt.Errorf("could not extract set leaves from original struct: %v", err)
+		}
+		if modLeaves, err = findSetLeaves(modified, withAtomic, opts...); err != nil {
+			return nil, fmt.Errorf("could not extract set leaves from modified struct: %v", err)
+		}
 	}
 
 	origLeavesStr, err := toStringPathMap(origLeaves)
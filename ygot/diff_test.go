@@ -429,7 +429,7 @@ func TestFindSetLeaves(t *testing.T) {
 	}{{
 		desc:     "struct with fields missing path annotation",
 		inStruct: &errorStruct{Value: String("foo")},
-		wantErr:  "error from ForEachDataField iteration: field Value did not specify a path",
+		wantErr:  "error from walking data tree: field Value did not specify a path",
 	}, {
 		desc:     "struct with empty value",
 		inStruct: &basicStruct{EmptyValue: YANGEmpty(true)},
@@ -838,6 +838,50 @@ func TestDiff(t *testing.T) {
 				Val: &gnmipb.TypedValue{Value: &gnmipb.TypedValue_StringVal{"cabernet-sauvignon"}},
 			}},
 		},
+	}, {
+		desc: "anydata contents modified",
+		inOrig: &anyDataExample{
+			Data: &AnyData{JSON: []byte(`{"other:bar":1}`)},
+		},
+		inMod: &anyDataExample{
+			Data: &AnyData{JSON: []byte(`{"other:bar":2}`)},
+		},
+		want: &gnmipb.Notification{
+			Update: []*gnmipb.Update{{
+				Path: &gnmipb.Path{
+					Elem: []*gnmipb.PathElem{{
+						Name: "config",
+					}, {
+						Name: "data",
+					}},
+				},
+				Val: &gnmipb.TypedValue{Value: &gnmipb.TypedValue_JsonIetfVal{JsonIetfVal: []byte(`{"other:bar":2}`)}},
+			}},
+		},
+	}, {
+		desc: "different case of choice selected",
+		inOrig: &choiceExample{
+			Protocol: &choiceExampleTCP{Port: Uint16(42)},
+		},
+		inMod: &choiceExample{
+			Protocol: &choiceExampleUDP{Port: Uint16(43)},
+		},
+		want: &gnmipb.Notification{
+			Delete: []*gnmipb.Path{{
+				Elem: []*gnmipb.PathElem{{Name: "tcp-port"}},
+			}},
+			Update: []*gnmipb.Update{{
+				Path: &gnmipb.Path{Elem: []*gnmipb.PathElem{{Name: "udp-port"}}},
+				Val:  &gnmipb.TypedValue{Value: &gnmipb.TypedValue_UintVal{UintVal: 43}},
+			}},
+		},
+	}, {
+		desc:   "anydata with no contents",
+		inOrig: &anyDataExample{},
+		inMod: &anyDataExample{
+			Data: &AnyData{},
+		},
+		want: &gnmipb.Notification{},
 	}, {
 		desc:   "path additions with PreferShadowPath, one path has and one path doesn't have shadow path",
 		inOrig: &renderExample{},
@@ -0,0 +1,248 @@
+// Copyright 2023 Google Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package ygot
+
+import (
+	"fmt"
+	"reflect"
+
+	"github.com/openconfig/ygot/util"
+)
+
+// GoStructDiffer is an interface which is implemented by generated structs
+// that have methods to find the fields that differ between two instances of
+// the struct without the use of reflection. When the original struct supplied
+// to Diff implements GoStructDiffer, ΛDiff is used to find the leaves that
+// differ, such that unchanged subtrees are not walked.
+type GoStructDiffer interface {
+	// ΛDiff reports each field of the receiver that differs from the
+	// corresponding field of other, which is of the same type as the
+	// receiver, to d. Either of the receiver and other may be nil.
+	ΛDiff(d *DiffWalker, other GoStruct) error
+}
+
+// DiffWalker records the leaves that differ between an original and modified
+// GoStruct. It is supplied to the ΛDiff method of a GoStructDiffer, which
+// calls the DiffWalker's methods for each field that differs.
+type DiffWalker struct {
+	// parent is the path of the struct whose fields are being reported,
+	// which is nil for the root of the diff.
+	parent *pathSpec
+	// orig and mod record the set leaves of the original and modified
+	// structs respectively.
+	orig, mod *findSetLeavesVisitor
+}
+
+// findDifferingLeaves returns the set leaves of original and modified that
+// may differ, using the ΛDiff method of original to skip the fields that are
+// equal in both structs.
+func findDifferingLeaves(original GoStructDiffer, modified GoStruct, orderedMapAsLeaf bool, opts ...DiffOpt) (map[*pathSpec]interface{}, map[*pathSpec]interface{}, error) {
+	d := &DiffWalker{
+		orig: newFindSetLeavesVisitor(orderedMapAsLeaf, opts),
+		mod:  newFindSetLeavesVisitor(orderedMapAsLeaf, opts),
+	}
+	if err := original.ΛDiff(d, modified); err != nil {
+		return nil, nil, err
+	}
+	if d.orig.errs.Errors != nil {
+		return nil, nil, fmt.Errorf("error from walking original data tree: %v", d.orig.errs.Errors)
+	}
+	if d.mod.errs.Errors != nil {
+		return nil, nil, fmt.Errorf("error from walking modified data tree: %v", d.mod.errs.Errors)
+	}
+	return d.orig.out, d.mod.out, nil
+}
+
+// childPath returns the path of a field of the current struct, whose schema
+// paths are paths, and whose shadow schema paths are shadowPaths.
+func (d *DiffWalker) childPath(paths, shadowPaths [][]string) (*pathSpec, error) {
+	sp := paths
+	pathOpt := d.orig.pathOpt
+	if pathOpt != nil && pathOpt.PreferShadowPath && len(shadowPaths) != 0 {
+		sp = shadowPaths
+	}
+	if len(sp) == 0 {
+		return nil, fmt.Errorf("invalid schema paths %v", paths)
+	}
+	if pathOpt != nil && pathOpt.MapToSinglePath {
+		sp = [][]string{leastSpecificPath(sp)}
+	}
+	if d.parent == nil {
+		return nodeRootPath(sp), nil
+	}
+	return nodeChildPath(d.parent, sp)
+}
+
+// Leaf reports that the leaf or leaf-list with the supplied schema paths has
+// the value orig in the original struct, and mod in the modified struct.
+func (d *DiffWalker) Leaf(paths, shadowPaths [][]string, orig, mod interface{}) error {
+	vp, err := d.childPath(paths, shadowPaths)
+	if err != nil {
+		return err
+	}
+	for _, s := range []struct {
+		v   *findSetLeavesVisitor
+		val interface{}
+	}{{d.orig, orig}, {d.mod, mod}} {
+		if err := s.v.leaf(vp, s.val); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// leaf records val at the path vp if it is set.
+func (v *findSetLeavesVisitor) leaf(vp *pathSpec, val interface{}) error {
+	if util.IsValueNil(val) {
+		return nil
+	}
+	key, err := pathSpecKey(vp)
+	if err != nil {
+		return err
+	}
+	if v.processedPaths[key] {
+		return nil
+	}
+	v.processedPaths[key] = true
+	if util.IsValueNilOrDefault(val) || isUnsetEnum(val) {
+		return nil
+	}
+	v.out[vp] = val
+	return nil
+}
+
+// Struct reports the container with the supplied schema paths, whose value is
+// orig in the original struct, and mod in the modified struct.
+func (d *DiffWalker) Struct(paths, shadowPaths [][]string, orig, mod GoStruct) error {
+	vp, err := d.childPath(paths, shadowPaths)
+	if err != nil {
+		return err
+	}
+	return d.structs(vp, orig, mod)
+}
+
+// structs reports the differing leaves of orig and mod, which are found at
+// the path vp.
+func (d *DiffWalker) structs(vp *pathSpec, orig, mod GoStruct) error {
+	c := &DiffWalker{parent: vp, orig: d.orig, mod: d.mod}
+	if o, ok := orig.(GoStructDiffer); ok {
+		return o.ΛDiff(c, mod)
+	}
+	c.walk(c.orig, orig)
+	c.walk(c.mod, mod)
+	return nil
+}
+
+// walk records all set leaves of s, whose path is the parent of d, using
+// the visitor v.
+func (d *DiffWalker) walk(v *findSetLeavesVisitor, s GoStruct) {
+	if util.IsValueNil(s) {
+		return
+	}
+	ni := &util.NodeInfo{FieldValue: reflect.ValueOf(s)}
+	if d.parent != nil {
+		ni.Annotation = []interface{}{d.parent}
+	}
+	util.Walk(v, util.WalkNodeFromNodeInfo(ni), util.DefaultWalkOptions().WithWalkErrors(v.errs))
+}
+
+// Field reports the field with the supplied name of the structs orig and
+// mod, which are pointers to the same type of struct, by walking the field
+// of each struct using reflection. It is used for fields that do not have a
+// type known at the time that code is generated, such as anydata nodes, and
+// lists which are not represented as maps.
+func (d *DiffWalker) Field(orig, mod interface{}, name string) error {
+	parent := &util.NodeInfo{}
+	if d.parent != nil {
+		parent.Annotation = []interface{}{d.parent}
+	}
+	for _, s := range []struct {
+		v   *findSetLeavesVisitor
+		val interface{}
+	}{{d.orig, orig}, {d.mod, mod}} {
+		sv := reflect.ValueOf(s.val)
+		if !util.IsValueStructPtr(sv) || sv.IsNil() {
+			return fmt.Errorf("cannot diff field %s of %T, not a struct pointer", name, s.val)
+		}
+		sf, ok := sv.Elem().Type().FieldByName(name)
+		if !ok {
+			return fmt.Errorf("cannot diff field %s of %T, field does not exist", name, s.val)
+		}
+		fv := sv.Elem().FieldByIndex(sf.Index)
+		if util.IsNilOrInvalidValue(fv) {
+			continue
+		}
+		ps, err := util.SchemaPaths(sf)
+		if err != nil {
+			return err
+		}
+		for _, p := range ps {
+			ni := &util.NodeInfo{
+				Parent:         parent,
+				StructField:    sf,
+				FieldValue:     fv,
+				PathFromParent: p,
+			}
+			if util.IsTypeSlice(sf.Type) || util.IsTypeMap(sf.Type) {
+				ni.PathFromParent = p[0:1]
+			}
+			util.Walk(s.v, util.WalkNodeFromNodeInfo(ni), util.DefaultWalkOptions().WithWalkErrors(s.v.errs))
+		}
+	}
+	return nil
+}
+
+// DiffMaps reports the elements of the keyed list with the supplied schema
+// paths to d, whose value is orig in the original struct, and mod in the
+// modified struct. Elements that are equal in both maps are skipped.
+func DiffMaps[M ~map[K]V, K comparable, V interface {
+	comparable
+	KeyHelperGoStruct
+	Equal(V) bool
+}](d *DiffWalker, paths, shadowPaths [][]string, orig, mod M) error {
+	vp, err := d.childPath(paths, shadowPaths)
+	if err != nil {
+		return err
+	}
+	var zero V
+	diffElem := func(o, m V) error {
+		if o.Equal(m) {
+			return nil
+		}
+		l := o
+		if l == zero {
+			l = m
+		}
+		ep, err := nodeMapPath(l, vp)
+		if err != nil {
+			return err
+		}
+		return d.structs(ep, o, m)
+	}
+	for k, o := range orig {
+		if err := diffElem(o, mod[k]); err != nil {
+			return err
+		}
+	}
+	for k, m := range mod {
+		if _, ok := orig[k]; ok {
+			continue
+		}
+		if err := diffElem(zero, m); err != nil {
+			return err
+		}
+	}
+	return nil
+}
@@ -0,0 +1,317 @@
+// Copyright 2023 Google Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package ygot
+
+import (
+	"encoding/json"
+	"reflect"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/openconfig/gnmi/errdiff"
+	"github.com/openconfig/ygot/testutil"
+	"google.golang.org/protobuf/testing/protocmp"
+
+	gnmipb "github.com/openconfig/gnmi/proto/gnmi"
+)
+
+// diffExample is a struct that is diffed using reflection.
+type diffExample struct {
+	Str   *string                     `path:"str"`
+	Int   *int32                      `path:"config/int|int"`
+	Enum  EnumTest                    `path:"enum"`
+	Names []string                    `path:"names"`
+	Child *diffExampleChild           `path:"child"`
+	List  map[string]*diffExampleList `path:"list"`
+	Data  *AnyData                    `path:"data"`
+	Meta  []Annotation                `path:"@str" ygotAnnotation:"true"`
+}
+
+func (*diffExample) IsYANGGoStruct()                         {}
+func (*diffExample) ΛValidate(...ValidationOption) error     { return nil }
+func (*diffExample) ΛEnumTypeMap() map[string][]reflect.Type { return nil }
+func (*diffExample) ΛBelongingModule() string                { return "m" }
+
+// diffMethodsExample is a struct with the same fields as diffExample which
+// implements the GoStructDiffer interface.
+type diffMethodsExample diffExample
+
+func (*diffMethodsExample) IsYANGGoStruct()                         {}
+func (*diffMethodsExample) ΛValidate(...ValidationOption) error     { return nil }
+func (*diffMethodsExample) ΛEnumTypeMap() map[string][]reflect.Type { return nil }
+func (*diffMethodsExample) ΛBelongingModule() string                { return "m" }
+
+func (t *diffMethodsExample) ΛDiff(d *DiffWalker, other GoStruct) error {
+	o, _ := other.(*diffMethodsExample)
+	if t == nil {
+		t = &diffMethodsExample{}
+	}
+	if o == nil {
+		o = &diffMethodsExample{}
+	}
+	if !EqualPtrs(t.Str, o.Str) {
+		if err := d.Leaf([][]string{{"str"}}, nil, t.Str, o.Str); err != nil {
+			return err
+		}
+	}
+	if !EqualPtrs(t.Int, o.Int) {
+		if err := d.Leaf([][]string{{"config", "int"}, {"int"}}, nil, t.Int, o.Int); err != nil {
+			return err
+		}
+	}
+	if t.Enum != o.Enum {
+		if err := d.Leaf([][]string{{"enum"}}, nil, t.Enum, o.Enum); err != nil {
+			return err
+		}
+	}
+	if !EqualSlices(t.Names, o.Names) {
+		if err := d.Leaf([][]string{{"names"}}, nil, t.Names, o.Names); err != nil {
+			return err
+		}
+	}
+	if !reflect.DeepEqual(t.Child, o.Child) {
+		if err := d.Struct([][]string{{"child"}}, nil, t.Child, o.Child); err != nil {
+			return err
+		}
+	}
+	if !EqualMaps(t.List, o.List) {
+		if err := DiffMaps(d, [][]string{{"list"}}, nil, t.List, o.List); err != nil {
+			return err
+		}
+	}
+	if !reflect.DeepEqual(t.Data, o.Data) {
+		if err := d.Field(t, o, "Data"); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// diffExampleChild is a container that does not implement GoStructDiffer,
+// and hence is walked using reflection.
+type diffExampleChild struct {
+	Val *string `path:"val"`
+}
+
+func (*diffExampleChild) IsYANGGoStruct()                         {}
+func (*diffExampleChild) ΛValidate(...ValidationOption) error     { return nil }
+func (*diffExampleChild) ΛEnumTypeMap() map[string][]reflect.Type { return nil }
+func (*diffExampleChild) ΛBelongingModule() string                { return "m" }
+
+// diffExampleList is a list element that implements GoStructDiffer.
+type diffExampleList struct {
+	Key *string `path:"config/key|key"`
+	Val *uint32 `path:"config/val"`
+}
+
+func (*diffExampleList) IsYANGGoStruct()                         {}
+func (*diffExampleList) ΛValidate(...ValidationOption) error     { return nil }
+func (*diffExampleList) ΛEnumTypeMap() map[string][]reflect.Type { return nil }
+func (*diffExampleList) ΛBelongingModule() string                { return "m" }
+
+func (l *diffExampleList) ΛListKeyMap() (map[string]interface{}, error) {
+	return map[string]interface{}{"key": *l.Key}, nil
+}
+
+func (t *diffExampleList) Equal(o *diffExampleList) bool {
+	if t == nil || o == nil {
+		return t == o
+	}
+	return EqualPtrs(t.Key, o.Key) && EqualPtrs(t.Val, o.Val)
+}
+
+func (t *diffExampleList) ΛDiff(d *DiffWalker, other GoStruct) error {
+	o, _ := other.(*diffExampleList)
+	if t == nil {
+		t = &diffExampleList{}
+	}
+	if o == nil {
+		o = &diffExampleList{}
+	}
+	if !EqualPtrs(t.Key, o.Key) {
+		if err := d.Leaf([][]string{{"config", "key"}, {"key"}}, nil, t.Key, o.Key); err != nil {
+			return err
+		}
+	}
+	if !EqualPtrs(t.Val, o.Val) {
+		if err := d.Leaf([][]string{{"config", "val"}}, nil, t.Val, o.Val); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func TestDiffWalker(t *testing.T) {
+	tests := []struct {
+		desc   string
+		inOrig *diffExample
+		inMod  *diffExample
+		inOpts []DiffOpt
+	}{{
+		desc:   "empty structs",
+		inOrig: &diffExample{},
+		inMod:  &diffExample{},
+	}, {
+		desc: "identical structs",
+		inOrig: &diffExample{
+			Str:   String("a"),
+			Int:   Int32(42),
+			Enum:  EnumTestVALONE,
+			Names: []string{"a", "b"},
+			Child: &diffExampleChild{Val: String("c")},
+			List: map[string]*diffExampleList{
+				"k": {Key: String("k"), Val: Uint32(1)},
+			},
+		},
+		inMod: &diffExample{
+			Str:   String("a"),
+			Int:   Int32(42),
+			Enum:  EnumTestVALONE,
+			Names: []string{"a", "b"},
+			Child: &diffExampleChild{Val: String("c")},
+			List: map[string]*diffExampleList{
+				"k": {Key: String("k"), Val: Uint32(1)},
+			},
+		},
+	}, {
+		desc:   "leaves added",
+		inOrig: &diffExample{},
+		inMod: &diffExample{
+			Str:   String("a"),
+			Int:   Int32(42),
+			Enum:  EnumTestVALTWO,
+			Names: []string{"a"},
+		},
+	}, {
+		desc: "leaves deleted and changed",
+		inOrig: &diffExample{
+			Str:   String("a"),
+			Int:   Int32(42),
+			Enum:  EnumTestVALONE,
+			Names: []string{"a"},
+		},
+		inMod: &diffExample{
+			Int:   Int32(43),
+			Names: []string{},
+		},
+	}, {
+		desc:   "nil original",
+		inOrig: nil,
+		inMod:  &diffExample{Str: String("a"), Child: &diffExampleChild{Val: String("c")}},
+	}, {
+		desc:   "container changed",
+		inOrig: &diffExample{Child: &diffExampleChild{Val: String("c")}},
+		inMod:  &diffExample{Child: &diffExampleChild{Val: String("d")}},
+	}, {
+		desc:   "container deleted",
+		inOrig: &diffExample{Child: &diffExampleChild{Val: String("c")}},
+		inMod:  &diffExample{Child: &diffExampleChild{}},
+	}, {
+		desc: "list elements added, changed and deleted",
+		inOrig: &diffExample{
+			List: map[string]*diffExampleList{
+				"a": {Key: String("a"), Val: Uint32(1)},
+				"b": {Key: String("b"), Val: Uint32(2)},
+				"c": {Key: String("c")},
+			},
+		},
+		inMod: &diffExample{
+			List: map[string]*diffExampleList{
+				"a": {Key: String("a"), Val: Uint32(1)},
+				"b": {Key: String("b"), Val: Uint32(3)},
+				"d": {Key: String("d"), Val: Uint32(4)},
+			},
+		},
+	}, {
+		desc:   "list changed with single path",
+		inOrig: &diffExample{Int: Int32(1), List: map[string]*diffExampleList{"a": {Key: String("a")}}},
+		inMod:  &diffExample{Int: Int32(2), List: map[string]*diffExampleList{"a": {Key: String("a"), Val: Uint32(1)}}},
+		inOpts: []DiffOpt{&DiffPathOpt{MapToSinglePath: true}},
+	}, {
+		desc:   "anydata changed",
+		inOrig: &diffExample{Data: &AnyData{JSON: json.RawMessage(`{"a":1}`)}},
+		inMod:  &diffExample{Data: &AnyData{JSON: json.RawMessage(`{"a":2}`)}},
+	}, {
+		desc:   "annotations are ignored",
+		inOrig: &diffExample{Str: String("a"), Meta: []Annotation{&testAnnotation{AnnotationFieldOne: "one"}}},
+		inMod:  &diffExample{Str: String("a")},
+	}, {
+		desc:   "ignore additions",
+		inOrig: &diffExample{Str: String("a")},
+		inMod:  &diffExample{Int: Int32(1), Child: &diffExampleChild{Val: String("c")}},
+		inOpts: []DiffOpt{&IgnoreAdditions{}},
+	}}
+
+	for _, tt := range tests {
+		t.Run(tt.desc, func(t *testing.T) {
+			var orig, mod GoStruct = tt.inOrig, tt.inMod
+			if tt.inOrig == nil {
+				orig = (*diffExample)(nil)
+			}
+			want, wantErr := Diff(orig, mod, tt.inOpts...)
+			got, gotErr := Diff((*diffMethodsExample)(tt.inOrig), (*diffMethodsExample)(tt.inMod), tt.inOpts...)
+			if (wantErr != nil) != (gotErr != nil) {
+				t.Fatalf("Diff: did not get expected error, got: %v, want: %v", gotErr, wantErr)
+			}
+			if !testutil.NotificationSetEqual([]*gnmipb.Notification{want}, []*gnmipb.Notification{got}) {
+				diff := cmp.Diff(want, got, protocmp.Transform())
+				t.Errorf("Diff: did not get expected Notification, diff(-reflect, +methods):\n%s", diff)
+			}
+		})
+	}
+}
+
+// diffErrorExample is a struct whose ΛDiff method reports a leaf without
+// paths when Str is set, and a field that does not exist otherwise.
+type diffErrorExample struct {
+	Str *string `path:"str"`
+}
+
+func (*diffErrorExample) IsYANGGoStruct()                         {}
+func (*diffErrorExample) ΛValidate(...ValidationOption) error     { return nil }
+func (*diffErrorExample) ΛEnumTypeMap() map[string][]reflect.Type { return nil }
+func (*diffErrorExample) ΛBelongingModule() string                { return "m" }
+
+func (t *diffErrorExample) ΛDiff(d *DiffWalker, other GoStruct) error {
+	if t.Str != nil {
+		return d.Leaf(nil, nil, t.Str, other.(*diffErrorExample).Str)
+	}
+	return d.Field(t, other, "Missing")
+}
+
+func TestDiffWalkerErrors(t *testing.T) {
+	tests := []struct {
+		desc             string
+		in               *diffErrorExample
+		wantErrSubstring string
+	}{{
+		desc:             "no paths for leaf",
+		in:               &diffErrorExample{Str: String("a")},
+		wantErrSubstring: "invalid schema paths",
+	}, {
+		desc:             "field does not exist",
+		in:               &diffErrorExample{},
+		wantErrSubstring: "field does not exist",
+	}}
+
+	for _, tt := range tests {
+		t.Run(tt.desc, func(t *testing.T) {
+			_, err := Diff(tt.in, &diffErrorExample{})
+			if diff := errdiff.Substring(err, tt.wantErrSubstring); diff != "" {
+				t.Errorf("Diff: %s", diff)
+			}
+		})
+	}
+}
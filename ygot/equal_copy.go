@@ -0,0 +1,142 @@
+// Copyright 2023 Google Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package ygot
+
+import (
+	"fmt"
+	"reflect"
+	"slices"
+)
+
+// This file contains the helpers that are used by the Equal and Copy methods
+// of generated GoStructs, which compare and copy the fields of a struct
+// without reflecting over its layout.
+
+// GoStructCopier is an interface which is implemented by generated structs
+// that have methods to create a deep copy of themselves without the use of
+// reflection. When a GoStruct implements GoStructCopier, DeepCopy and
+// MergeStructs use ΛDeepCopy to copy it.
+type GoStructCopier interface {
+	// ΛDeepCopy returns a deep copy of the struct. As with DeepCopy, empty
+	// maps and slices within the struct are not retained in the copy.
+	ΛDeepCopy() (GoStruct, error)
+}
+
+// EqualPtrs reports whether a and b are both nil, or point to equal values.
+func EqualPtrs[T comparable](a, b *T) bool {
+	if a == nil || b == nil {
+		return a == b
+	}
+	return *a == *b
+}
+
+// EqualSlices reports whether a and b contain the same elements in the same
+// order. As with reflect.DeepEqual, a nil slice is not equal to an empty
+// slice.
+func EqualSlices[S ~[]E, E comparable](a, b S) bool {
+	if (a == nil) != (b == nil) {
+		return false
+	}
+	return slices.Equal(a, b)
+}
+
+// EqualMaps reports whether a and b contain the same keys, and whether the
+// values for each key are equal according to their Equal method. As with
+// reflect.DeepEqual, a nil map is not equal to an empty map.
+func EqualMaps[M ~map[K]V, K comparable, V interface{ Equal(V) bool }](a, b M) bool {
+	if (a == nil) != (b == nil) || len(a) != len(b) {
+		return false
+	}
+	for k, av := range a {
+		bv, ok := b[k]
+		if !ok || !av.Equal(bv) {
+			return false
+		}
+	}
+	return true
+}
+
+// EqualStructSlices reports whether a and b contain the same number of
+// elements, and whether the elements at each index are equal according to
+// their Equal method. As with reflect.DeepEqual, a nil slice is not equal to
+// an empty slice.
+func EqualStructSlices[S ~[]V, V interface{ Equal(V) bool }](a, b S) bool {
+	if (a == nil) != (b == nil) {
+		return false
+	}
+	return slices.EqualFunc(a, b, func(x, y V) bool { return x.Equal(y) })
+}
+
+// CopySlice returns a copy of s, whose elements are copied by assignment. As
+// with DeepCopy, nil is returned if s is empty.
+func CopySlice[S ~[]E, E any](s S) S {
+	if len(s) == 0 {
+		return nil
+	}
+	return append(S(nil), s...)
+}
+
+// CopyMap returns a copy of m, whose values are copied using their Copy
+// method. As with DeepCopy, nil is returned if m is empty, and an error is
+// returned if m contains a nil value.
+func CopyMap[M ~map[K]V, K comparable, V interface {
+	comparable
+	Copy() (V, error)
+}](m M) (M, error) {
+	if len(m) == 0 {
+		return nil, nil
+	}
+	var zero V
+	n := make(M, len(m))
+	for k, v := range m {
+		if v == zero {
+			return nil, fmt.Errorf("map key %v, got nil value", k)
+		}
+		c, err := v.Copy()
+		if err != nil {
+			return nil, err
+		}
+		n[k] = c
+	}
+	return n, nil
+}
+
+// CopyStructSlice returns a copy of s, whose elements are copied using their
+// Copy method. As with DeepCopy, nil is returned if s is empty.
+func CopyStructSlice[S ~[]V, V interface{ Copy() (V, error) }](s S) (S, error) {
+	if len(s) == 0 {
+		return nil, nil
+	}
+	n := make(S, 0, len(s))
+	for _, v := range s {
+		c, err := v.Copy()
+		if err != nil {
+			return nil, err
+		}
+		n = append(n, c)
+	}
+	return n, nil
+}
+
+// CopyField returns a deep copy of v, which is the value of a field of a
+// GoStruct, using reflection. It is used for fields whose types do not have
+// a known layout when code is generated, such as unions and anydata.
+func CopyField[T any](v T) (T, error) {
+	var n T
+	if err := copyField(reflect.ValueOf(&n).Elem(), reflect.ValueOf(&v).Elem(), false, ""); err != nil {
+		return n, err
+	}
+	return n, nil
+}
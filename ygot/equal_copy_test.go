@@ -0,0 +1,194 @@
+// Copyright 2023 Google Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package ygot
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/openconfig/gnmi/errdiff"
+)
+
+// copyExample is a struct that implements the GoStructCopier interface.
+type copyExample struct {
+	Str   *string                     `path:"str"`
+	Names []string                    `path:"names"`
+	List  map[string]*copyExampleList `path:"list"`
+	Steps []*copyExampleList          `path:"steps"`
+	Union renderExampleUnion          `path:"union"`
+}
+
+func (*copyExample) IsYANGGoStruct()                         {}
+func (*copyExample) ΛValidate(...ValidationOption) error     { return nil }
+func (*copyExample) ΛEnumTypeMap() map[string][]reflect.Type { return nil }
+func (*copyExample) ΛBelongingModule() string                { return "m" }
+
+func (t *copyExample) Equal(o *copyExample) bool {
+	if t == nil || o == nil {
+		return t == o
+	}
+	return EqualPtrs(t.Str, o.Str) && EqualSlices(t.Names, o.Names) && EqualMaps(t.List, o.List) &&
+		EqualStructSlices(t.Steps, o.Steps) && reflect.DeepEqual(t.Union, o.Union)
+}
+
+func (t *copyExample) Copy() (*copyExample, error) {
+	if t == nil {
+		return nil, nil
+	}
+	n := &copyExample{}
+	var err error
+	if t.Str != nil {
+		v := *t.Str
+		n.Str = &v
+	}
+	n.Names = CopySlice(t.Names)
+	if n.List, err = CopyMap(t.List); err != nil {
+		return nil, err
+	}
+	if n.Steps, err = CopyStructSlice(t.Steps); err != nil {
+		return nil, err
+	}
+	if n.Union, err = CopyField(t.Union); err != nil {
+		return nil, err
+	}
+	return n, nil
+}
+
+func (t *copyExample) ΛDeepCopy() (GoStruct, error) {
+	return t.Copy()
+}
+
+type copyExampleList struct {
+	Val *uint32 `path:"val"`
+}
+
+func (*copyExampleList) IsYANGGoStruct()                         {}
+func (*copyExampleList) ΛValidate(...ValidationOption) error     { return nil }
+func (*copyExampleList) ΛEnumTypeMap() map[string][]reflect.Type { return nil }
+func (*copyExampleList) ΛBelongingModule() string                { return "m" }
+
+func (t *copyExampleList) Equal(o *copyExampleList) bool {
+	if t == nil || o == nil {
+		return t == o
+	}
+	return EqualPtrs(t.Val, o.Val)
+}
+
+func (t *copyExampleList) Copy() (*copyExampleList, error) {
+	if t == nil {
+		return nil, nil
+	}
+	n := &copyExampleList{}
+	if t.Val != nil {
+		v := *t.Val
+		n.Val = &v
+	}
+	return n, nil
+}
+
+func TestEqualHelpers(t *testing.T) {
+	tests := []struct {
+		desc string
+		got  bool
+		want bool
+	}{
+		{"nil pointers", EqualPtrs[int](nil, nil), true},
+		{"nil and non-nil pointer", EqualPtrs(nil, Int32(1)), false},
+		{"equal pointers", EqualPtrs(String("a"), String("a")), true},
+		{"unequal pointers", EqualPtrs(String("a"), String("b")), false},
+		{"nil and empty slice", EqualSlices(nil, []string{}), false},
+		{"equal slices", EqualSlices([]string{"a", "b"}, []string{"a", "b"}), true},
+		{"reordered slices", EqualSlices([]string{"a", "b"}, []string{"b", "a"}), false},
+		{"nil and empty map", EqualMaps(nil, map[string]*copyExampleList{}), false},
+		{"equal maps", EqualMaps(map[string]*copyExampleList{"a": {Val: Uint32(1)}}, map[string]*copyExampleList{"a": {Val: Uint32(1)}}), true},
+		{"maps with different values", EqualMaps(map[string]*copyExampleList{"a": {Val: Uint32(1)}}, map[string]*copyExampleList{"a": {Val: Uint32(2)}}), false},
+		{"maps with different keys", EqualMaps(map[string]*copyExampleList{"a": {}}, map[string]*copyExampleList{"b": {}}), false},
+		{"maps with nil value", EqualMaps(map[string]*copyExampleList{"a": nil}, map[string]*copyExampleList{"a": {}}), false},
+		{"equal struct slices", EqualStructSlices([]*copyExampleList{{Val: Uint32(1)}, nil}, []*copyExampleList{{Val: Uint32(1)}, nil}), true},
+		{"unequal struct slices", EqualStructSlices([]*copyExampleList{{Val: Uint32(1)}}, []*copyExampleList{{Val: Uint32(2)}}), false},
+	}
+
+	for _, tt := range tests {
+		if tt.got != tt.want {
+			t.Errorf("%s: got %v, want %v", tt.desc, tt.got, tt.want)
+		}
+	}
+}
+
+// copyReflectExample is a struct with the same fields as copyExample, which
+// is copied using reflection.
+type copyReflectExample copyExample
+
+func (*copyReflectExample) IsYANGGoStruct()                         {}
+func (*copyReflectExample) ΛValidate(...ValidationOption) error     { return nil }
+func (*copyReflectExample) ΛEnumTypeMap() map[string][]reflect.Type { return nil }
+func (*copyReflectExample) ΛBelongingModule() string                { return "m" }
+
+func TestDeepCopyWithCopier(t *testing.T) {
+	tests := []struct {
+		desc             string
+		in               *copyExample
+		wantErrSubstring string
+	}{{
+		desc: "empty struct",
+		in:   &copyExample{},
+	}, {
+		desc: "populated struct",
+		in: &copyExample{
+			Str:   String("a"),
+			Names: []string{"a", "b"},
+			List: map[string]*copyExampleList{
+				"a": {Val: Uint32(1)},
+				"b": {},
+			},
+			Steps: []*copyExampleList{{Val: Uint32(2)}},
+			Union: &renderExampleUnionString{String: "u"},
+		},
+	}, {
+		desc: "empty map and slices",
+		in: &copyExample{
+			Names: []string{},
+			List:  map[string]*copyExampleList{},
+			Steps: []*copyExampleList{},
+		},
+	}, {
+		desc:             "nil map value",
+		in:               &copyExample{List: map[string]*copyExampleList{"a": nil}},
+		wantErrSubstring: "map key a, got nil value",
+	}}
+
+	for _, tt := range tests {
+		t.Run(tt.desc, func(t *testing.T) {
+			want, wantErr := DeepCopy((*copyReflectExample)(tt.in))
+			got, err := DeepCopy(tt.in)
+			if diff := errdiff.Substring(err, tt.wantErrSubstring); diff != "" {
+				t.Fatalf("DeepCopy(%#v): did not get expected error, %s", tt.in, diff)
+			}
+			if diff := errdiff.Substring(wantErr, tt.wantErrSubstring); diff != "" {
+				t.Fatalf("DeepCopy(%#v) using reflection: did not get expected error, %s", tt.in, diff)
+			}
+			if err != nil {
+				return
+			}
+			if diff := cmp.Diff(want, (*copyReflectExample)(got.(*copyExample))); diff != "" {
+				t.Errorf("DeepCopy(%#v): did not get expected copy, diff(-reflect, +methods):\n%s", tt.in, diff)
+			}
+			if tt.in.Str != nil && got.(*copyExample).Str == tt.in.Str {
+				t.Errorf("DeepCopy(%#v): copy shares leaf with original", tt.in)
+			}
+		})
+	}
+}
@@ -42,27 +42,43 @@ const (
 // underneath a "config false" branch, per RFC7950
 // (https://datatracker.ietf.org/doc/html/rfc7950#section-7.21.1).
 func PruneConfigFalse(schema *yang.Entry, s GoStruct) error {
-	pruneReadOnlyIterFunc := func(ni *util.NodeInfo, in, out interface{}) util.Errors {
-		if ni == nil || util.IsNilOrInvalidValue(ni.FieldValue) || ni.FieldValue.IsZero() {
-			return nil
-		}
-		if util.IsConfig(ni.Schema) {
-			return nil
-		}
-		if ni.Schema.Annotation[GoCompressedLeafAnnotation] != nil {
-			return nil
-		}
-		// The top-level GoStruct cannot be written to since it is
-		// unaddressable, so the best we can do is to skip writing to
-		// it, and prune its children.
-		if ni.Parent == nil {
-			return nil
-		}
-		ni.FieldValue.Set(reflect.Zero(ni.FieldValue.Type()))
+	if util.IsValueNil(s) {
 		return nil
 	}
-	if errs := util.ForEachField(schema, s, nil, nil, pruneReadOnlyIterFunc); errs != nil {
-		return errs
+	errs := &util.DefaultWalkErrors{}
+	util.Walk(pruneConfigFalseVisitor{}, util.WalkNodeFromGoStruct(s), util.DefaultWalkOptions().WithSchema(schema).WithWalkErrors(errs))
+	if errs.Errors != nil {
+		return errs.Errors
 	}
 	return nil
 }
+
+// pruneConfigFalseVisitor is a util.Visitor that removes the nodes that are
+// pruned by PruneConfigFalse from the data tree being walked.
+type pruneConfigFalseVisitor struct{}
+
+// Visit implements the util.Visitor interface. The descendants of a pruned
+// node are not walked, since they have been removed.
+func (v pruneConfigFalseVisitor) Visit(node util.WalkNode) util.Visitor {
+	if node == nil {
+		return nil
+	}
+	ni := node.NodeInfo()
+	if util.IsNilOrInvalidValue(ni.FieldValue) || ni.FieldValue.IsZero() {
+		return v
+	}
+	if util.IsConfig(ni.Schema) {
+		return v
+	}
+	if ni.Schema.Annotation[GoCompressedLeafAnnotation] != nil {
+		return v
+	}
+	// The top-level GoStruct cannot be written to since it is
+	// unaddressable, so the best we can do is to skip writing to
+	// it, and prune its children.
+	if ni.Parent == nil {
+		return v
+	}
+	ni.FieldValue.Set(reflect.Zero(ni.FieldValue.Type()))
+	return nil
+}
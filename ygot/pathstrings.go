@@ -134,6 +134,9 @@ func elemToString(name string, kv map[string]string) (string, error) {
 	if len(kv) == 0 {
 		return name, nil
 	}
+	if name == util.MultiLevelWildcard {
+		return "", fmt.Errorf("multi-level wildcard %s cannot have keys, got: %v", name, kv)
+	}
 
 	var keys []string
 	for k, v := range kv {
@@ -217,6 +220,9 @@ func StringToStringSlicePath(path string) (*gnmipb.Path, error) {
 
 // StringToStructuredPath takes a string representing a path, and converts it to
 // a gnmi.Path, using the PathElem element message that is defined in gNMI 0.4.0.
+// Wildcards, including the multi-level wildcard "...", are retained as the
+// names or key values of the elements, such that the returned path can be
+// converted back to the same string by PathToString.
 func StringToStructuredPath(path string) (*gnmipb.Path, error) {
 	parts := util.PathStringToElements(path)
 
@@ -226,6 +232,9 @@ func StringToStructuredPath(path string) (*gnmipb.Path, error) {
 		if err != nil {
 			return nil, fmt.Errorf("error parsing path %s: %v", path, err)
 		}
+		if name == util.MultiLevelWildcard && len(kv) != 0 {
+			return nil, fmt.Errorf("error parsing path %s: multi-level wildcard %s cannot have keys", path, name)
+		}
 		gpath.Elem = append(gpath.Elem, &gnmipb.PathElem{
 			Name: name,
 			Key:  kv,
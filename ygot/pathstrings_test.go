@@ -88,6 +88,22 @@ func TestPathToString(t *testing.T) {
 			{Name: "a", Key: map[string]string{"": "d"}},
 		}},
 		wantErr: "empty key name (value: d) in element a",
+	}, {
+		name: "structured path with wildcards",
+		in: &gnmipb.Path{Elem: []*gnmipb.PathElem{
+			{Name: "a"},
+			{Name: "..."},
+			{Name: "b", Key: map[string]string{"c": "*"}},
+			{Name: "*"},
+		}},
+		want: "/a/.../b[c=*]/*",
+	}, {
+		name: "structured path with keys on multi-level wildcard",
+		in: &gnmipb.Path{Elem: []*gnmipb.PathElem{
+			{Name: "a"},
+			{Name: "...", Key: map[string]string{"c": "d"}},
+		}},
+		wantErr: "multi-level wildcard ... cannot have keys",
 	}, {
 		name: "both path types set",
 		in: &gnmipb.Path{
@@ -325,6 +341,24 @@ func TestStringToPath(t *testing.T) {
 				{Name: "bar", Key: map[string]string{"baz": "]bat"}},
 			},
 		},
+	}, {
+		name:                "path with wildcards",
+		in:                  "/a/.../b[c=*]/*/...",
+		wantStringSlicePath: &gnmipb.Path{Element: []string{"a", "...", "b[c=*]", "*", "..."}},
+		wantStructuredPath: &gnmipb.Path{
+			Elem: []*gnmipb.PathElem{
+				{Name: "a"},
+				{Name: "..."},
+				{Name: "b", Key: map[string]string{"c": "*"}},
+				{Name: "*"},
+				{Name: "..."},
+			},
+		},
+	}, {
+		name:              "multi-level wildcard with keys",
+		in:                "/a/...[c=d]/e",
+		wantSliceErr:      "multi-level wildcard ... cannot have keys",
+		wantStructuredErr: "multi-level wildcard ... cannot have keys",
 	}, {
 		name:              "trailing garbage outside of kv name",
 		in:                `/foo/bar[baz=bat]hat`,
@@ -457,6 +491,34 @@ func TestStringToPath(t *testing.T) {
 	}
 }
 
+func TestStructuredPathRoundTrip(t *testing.T) {
+	tests := []string{
+		"/",
+		"/a/b[c=d]/e",
+		"/a/.../e",
+		"/...",
+		"/a/b[c=*][d=e]/*/f/...",
+		"/a/.../b/.../c[d=...]",
+		`/a/b[c=d\]e]/...`,
+	}
+
+	for _, in := range tests {
+		t.Run(in, func(t *testing.T) {
+			p, err := StringToStructuredPath(in)
+			if err != nil {
+				t.Fatalf("StringToStructuredPath(%s): got unexpected error: %v", in, err)
+			}
+			got, err := PathToString(p)
+			if err != nil {
+				t.Fatalf("PathToString(%v): got unexpected error: %v", p, err)
+			}
+			if got != in {
+				t.Errorf("PathToString(StringToStructuredPath(%s)): got %s, want %s", in, got, in)
+			}
+		})
+	}
+}
+
 func TestPathToSchemaPath(t *testing.T) {
 	tests := []struct {
 		name             string
@@ -13,7 +13,9 @@
 // limitations under the License.
 
 // Package pathtranslate exports api to transform given string slice into
-// different forms in a schema aware manner.
+// different forms in a schema aware manner. It also provides a translator
+// between the paths and data of compressed and uncompressed generated code
+// for the same schema.
 package pathtranslate
 
 import (
@@ -0,0 +1,460 @@
+// Copyright 2023 Google Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package pathtranslate
+
+import (
+	"errors"
+	"fmt"
+	"reflect"
+	"strings"
+
+	"github.com/openconfig/goyang/pkg/yang"
+	"github.com/openconfig/ygot/util"
+	"github.com/openconfig/ygot/ygot"
+	"github.com/openconfig/ygot/ytypes"
+
+	gnmipb "github.com/openconfig/gnmi/proto/gnmi"
+)
+
+// SchemaTranslator translates paths and data between the compressed and
+// uncompressed forms of the same set of YANG modules, i.e., between the Go
+// code generated for the modules with and without the -compress_paths
+// option.
+//
+// The compressed path of a node is formed from the names of the compressed
+// GoStruct fields that are traversed to reach the node from the root. It
+// omits the containers that are removed by path compression, i.e., the
+// container that surrounds each list, and the config and state containers.
+// For example, the uncompressed path /interfaces/interface[name=eth0]/config/mtu
+// corresponds to the compressed path /interface[name=eth0]/mtu.
+type SchemaTranslator struct {
+	// compressed is the schema of the code generated with path
+	// compression.
+	compressed *ytypes.Schema
+	// uncompressed is the schema of the code generated without path
+	// compression.
+	uncompressed *ytypes.Schema
+	// preferShadowPath specifies that the shadow paths of the compressed
+	// GoStructs are used when translating to the uncompressed form.
+	preferShadowPath bool
+}
+
+// SchemaTranslatorOpt is an interface that is implemented by options to
+// NewSchemaTranslator.
+type SchemaTranslatorOpt interface {
+	// IsSchemaTranslatorOpt is a marker method for each option.
+	IsSchemaTranslatorOpt()
+}
+
+// PreferShadowPath specifies that the "shadow-path" annotations of the
+// compressed GoStructs, rather than their "path" annotations, determine the
+// uncompressed paths that compressed data is translated to, and the
+// uncompressed data that populates the compressed GoStructs. For code
+// generated with the default options, the shadow paths are the paths of
+// the state leaves.
+type PreferShadowPath struct{}
+
+// IsSchemaTranslatorOpt implements the SchemaTranslatorOpt interface.
+func (*PreferShadowPath) IsSchemaTranslatorOpt() {}
+
+// NewSchemaTranslator returns a SchemaTranslator that translates between the
+// supplied compressed and uncompressed schemas, which are typically those
+// returned by the Schema function of the respective generated packages. It
+// returns an error if either schema is not fully populated.
+func NewSchemaTranslator(compressed, uncompressed *ytypes.Schema, opts ...SchemaTranslatorOpt) (*SchemaTranslator, error) {
+	if compressed == nil || !compressed.IsValid() {
+		return nil, errors.New("invalid compressed schema: not fully populated")
+	}
+	if uncompressed == nil || !uncompressed.IsValid() {
+		return nil, errors.New("invalid uncompressed schema: not fully populated")
+	}
+	t := &SchemaTranslator{
+		compressed:   compressed,
+		uncompressed: uncompressed,
+	}
+	for _, o := range opts {
+		if _, ok := o.(*PreferShadowPath); ok {
+			t.preferShadowPath = true
+		}
+	}
+	return t, nil
+}
+
+// goOrderedMapType is the reflect.Type of the ygot.GoOrderedMap interface.
+var goOrderedMapType = reflect.TypeOf((*ygot.GoOrderedMap)(nil)).Elem()
+
+// fieldPaths returns the relative paths specified by the path annotations of
+// the supplied struct field. If shadow is set, the "shadow-path" annotation is
+// used, otherwise the "path" annotation is used.
+func fieldPaths(f reflect.StructField, shadow bool) [][]string {
+	tag := "path"
+	if shadow {
+		tag = "shadow-path"
+	}
+	annotation, ok := f.Tag.Lookup(tag)
+	if !ok || annotation == "" {
+		return nil
+	}
+	var paths [][]string
+	for _, p := range strings.Split(annotation, "|") {
+		var elems []string
+		for _, e := range strings.Split(p, "/") {
+			if e != "" {
+				elems = append(elems, e)
+			}
+		}
+		if len(elems) != 0 {
+			paths = append(paths, elems)
+		}
+	}
+	return paths
+}
+
+// childStructType returns the type of the GoStruct that is the value of, or
+// the list member within, a field of type t. It returns nil if the field is
+// a leaf or leaf-list.
+func childStructType(t reflect.Type) reflect.Type {
+	// Ordered maps are pointers to structs, and hence are checked first.
+	switch {
+	case t.Implements(goOrderedMapType):
+		if m, ok := t.MethodByName("Values"); ok && m.Type.NumOut() == 1 && m.Type.Out(0).Kind() == reflect.Slice {
+			return m.Type.Out(0).Elem().Elem()
+		}
+	case util.IsTypeStructPtr(t):
+		return t.Elem()
+	case util.IsTypeMap(t) && util.IsTypeStructPtr(t.Elem()):
+		return t.Elem().Elem()
+	}
+	return nil
+}
+
+// checkTranslatable returns an error if the supplied element cannot be
+// translated, since it does not identify a single schema node.
+func checkTranslatable(e *gnmipb.PathElem) error {
+	if e == nil {
+		return errors.New("nil path element")
+	}
+	if e.Name == "*" || e.Name == util.MultiLevelWildcard {
+		return fmt.Errorf("cannot translate wildcard path element %q", e.Name)
+	}
+	return nil
+}
+
+// copyKeys returns a copy of the supplied key map.
+func copyKeys(keys map[string]string) map[string]string {
+	if len(keys) == 0 {
+		return nil
+	}
+	c := make(map[string]string, len(keys))
+	for k, v := range keys {
+		c[k] = v
+	}
+	return c
+}
+
+// childEntry returns the child of the schema entry e with the supplied
+// name, looking through any choice and case nodes, since they do not
+// appear within data paths. It returns nil if there is no such child.
+func childEntry(e *yang.Entry, name string) *yang.Entry {
+	name = util.StripModulePrefix(name)
+	if c, ok := e.Dir[name]; ok && !util.IsChoiceOrCase(c) {
+		return c
+	}
+	for _, c := range util.FindFirstNonChoiceOrCase(e) {
+		if c.Name == name {
+			return c
+		}
+	}
+	return nil
+}
+
+// resolveEntry returns the schema entry that is reached by traversing the
+// supplied element names from the schema entry e. It returns nil if the
+// names do not identify a node within the schema tree.
+func resolveEntry(e *yang.Entry, names []string) *yang.Entry {
+	for _, n := range names {
+		if e == nil || !e.IsDir() {
+			return nil
+		}
+		e = childEntry(e, n)
+	}
+	return e
+}
+
+// schemaMatch is a field of a compressed GoStruct, along with the relative
+// path within its path annotations that matched a path, and the schema
+// entry of the node at that path.
+type schemaMatch struct {
+	field reflect.StructField
+	path  []string
+	entry *yang.Entry
+}
+
+// resolveUncompressed resolves each element of the supplied uncompressed
+// path within the uncompressed schema tree, returning the schema entry of
+// each element. It returns an error if the path does not exist within the
+// schema, or if keys are specified for an element that is not a list.
+func (t *SchemaTranslator) resolveUncompressed(path *gnmipb.Path) ([]*yang.Entry, error) {
+	e := t.uncompressed.RootSchema()
+	if e == nil {
+		return nil, fmt.Errorf("uncompressed schema has no entry for root %T", t.uncompressed.Root)
+	}
+	var entries []*yang.Entry
+	for i, pe := range path.GetElem() {
+		if err := checkTranslatable(pe); err != nil {
+			return nil, err
+		}
+		if !e.IsDir() {
+			return nil, fmt.Errorf("invalid uncompressed path %v, element %d is beneath a leaf", path, i)
+		}
+		if e = childEntry(e, pe.Name); e == nil {
+			return nil, fmt.Errorf("invalid uncompressed path %v, element %s does not exist in the uncompressed schema", path, pe.Name)
+		}
+		if len(pe.Key) != 0 && !e.IsList() {
+			return nil, fmt.Errorf("invalid uncompressed path %v, element %s is not a list but has keys", path, pe.Name)
+		}
+		entries = append(entries, e)
+	}
+	return entries, nil
+}
+
+// ToUncompressedPath translates the supplied compressed path to the
+// corresponding uncompressed path. Each element is resolved within the
+// compressed schema tree, and the resulting path is checked against the
+// uncompressed schema tree. Keys are carried over to the list element of
+// the uncompressed path, and wildcard key values are preserved. It returns
+// an error if the path does not exist within either schema, if an element
+// matches more than one field, or if it contains a wildcard element name.
+func (t *SchemaTranslator) ToUncompressedPath(path *gnmipb.Path) (*gnmipb.Path, error) {
+	out := &gnmipb.Path{Origin: path.GetOrigin(), Target: path.GetTarget()}
+	e := t.compressed.RootSchema()
+	if e == nil {
+		return nil, fmt.Errorf("compressed schema has no entry for root %T", t.compressed.Root)
+	}
+	st := reflect.TypeOf(t.compressed.Root).Elem()
+	for i, pe := range path.GetElem() {
+		if err := checkTranslatable(pe); err != nil {
+			return nil, err
+		}
+		if st == nil || !e.IsDir() {
+			return nil, fmt.Errorf("invalid compressed path %v, element %d is beneath a leaf", path, i)
+		}
+		m, err := t.compressedField(st, e, pe.Name)
+		if err != nil {
+			return nil, fmt.Errorf("invalid compressed path %v, %v", path, err)
+		}
+		if len(pe.Key) != 0 && !m.entry.IsList() {
+			return nil, fmt.Errorf("invalid compressed path %v, element %s is not a list but has keys", path, pe.Name)
+		}
+		for j, name := range m.path {
+			ue := &gnmipb.PathElem{Name: name}
+			if j == len(m.path)-1 {
+				ue.Key = copyKeys(pe.Key)
+			}
+			out.Elem = append(out.Elem, ue)
+		}
+		e, st = m.entry, childStructType(m.field.Type)
+	}
+	if _, err := t.resolveUncompressed(out); err != nil {
+		return nil, fmt.Errorf("cannot translate compressed path %v, %v", path, err)
+	}
+	return out, nil
+}
+
+// compressedField returns the field of the compressed GoStruct type st,
+// whose schema entry is e, that has the compressed name name. A field's
+// compressed name is the name of the schema node that its path annotation
+// resolves to. It returns an error if no field, or more than one field,
+// has the name.
+func (t *SchemaTranslator) compressedField(st reflect.Type, e *yang.Entry, name string) (*schemaMatch, error) {
+	var matches []*schemaMatch
+	for i := 0; i < st.NumField(); i++ {
+		f := st.Field(i)
+		var paths [][]string
+		if t.preferShadowPath {
+			paths = fieldPaths(f, true)
+		}
+		if len(paths) == 0 {
+			paths = fieldPaths(f, false)
+		}
+		// A field may have several paths that resolve to nodes with the
+		// name, e.g., the config leaf and the key leaf of a list, in which
+		// case the first is used.
+		for _, p := range paths {
+			if c := resolveEntry(e, p); c != nil && c.Name == name {
+				matches = append(matches, &schemaMatch{field: f, path: p, entry: c})
+				break
+			}
+		}
+	}
+	switch len(matches) {
+	case 0:
+		return nil, fmt.Errorf("%s has no field named %s", st.Name(), name)
+	case 1:
+		return matches[0], nil
+	}
+	var names []string
+	for _, m := range matches {
+		names = append(names, m.field.Name)
+	}
+	return nil, fmt.Errorf("%s name %s is ambiguous, matched by fields %v", st.Name(), name, names)
+}
+
+// ToCompressedPath translates the supplied uncompressed path to the
+// corresponding compressed path. The path is resolved within the
+// uncompressed schema tree, and mapped to the compressed fields whose path
+// or shadow path annotations resolve to the same schema nodes within the
+// compressed schema tree, such that the paths of both config and state
+// leaves are translated. It returns an error if the path does not exist
+// within either schema, if more than one field matches a part of the path,
+// or if it contains a wildcard element name.
+func (t *SchemaTranslator) ToCompressedPath(path *gnmipb.Path) (*gnmipb.Path, error) {
+	entries, err := t.resolveUncompressed(path)
+	if err != nil {
+		return nil, err
+	}
+	out := &gnmipb.Path{Origin: path.GetOrigin(), Target: path.GetTarget()}
+	e := t.compressed.RootSchema()
+	if e == nil {
+		return nil, fmt.Errorf("compressed schema has no entry for root %T", t.compressed.Root)
+	}
+	st := reflect.TypeOf(t.compressed.Root).Elem()
+	elems := path.GetElem()
+	for len(elems) != 0 {
+		if st == nil || !e.IsDir() {
+			return nil, fmt.Errorf("invalid uncompressed path %v, %v is beneath a leaf", path, elems)
+		}
+		m, err := uncompressedField(st, e, elems)
+		if err != nil {
+			return nil, fmt.Errorf("invalid uncompressed path %v, %v", path, err)
+		}
+		n := len(m.path)
+		ue := entries[len(entries)-len(elems)+n-1]
+		if m.entry.Name != ue.Name || m.entry.IsList() != ue.IsList() || m.entry.IsDir() != ue.IsDir() {
+			return nil, fmt.Errorf("invalid uncompressed path %v, compressed node %s does not match uncompressed node %s", path, m.entry.Path(), ue.Path())
+		}
+		last := elems[n-1]
+		out.Elem = append(out.Elem, &gnmipb.PathElem{Name: m.entry.Name, Key: copyKeys(last.Key)})
+		elems = elems[n:]
+		e, st = m.entry, childStructType(m.field.Type)
+	}
+	return out, nil
+}
+
+// uncompressedField returns the field of the compressed GoStruct type st,
+// whose schema entry is e, that has a path or shadow path annotation that
+// resolves within the schema and matches the longest prefix of elems. It
+// returns an error if no field matches, or if more than one field matches
+// the longest prefix.
+func uncompressedField(st reflect.Type, e *yang.Entry, elems []*gnmipb.PathElem) (*schemaMatch, error) {
+	var matches []*schemaMatch
+	for i := 0; i < st.NumField(); i++ {
+		f := st.Field(i)
+		var fm *schemaMatch
+		for _, p := range append(fieldPaths(f, false), fieldPaths(f, true)...) {
+			if (fm != nil && len(p) <= len(fm.path)) || len(p) > len(elems) {
+				continue
+			}
+			match := true
+			for j, name := range p {
+				if util.StripModulePrefix(elems[j].Name) != util.StripModulePrefix(name) {
+					match = false
+					break
+				}
+			}
+			if !match {
+				continue
+			}
+			if c := resolveEntry(e, p); c != nil {
+				fm = &schemaMatch{field: f, path: p, entry: c}
+			}
+		}
+		switch {
+		case fm == nil:
+		case len(matches) == 0 || len(fm.path) == len(matches[0].path):
+			matches = append(matches, fm)
+		case len(fm.path) > len(matches[0].path):
+			matches = []*schemaMatch{fm}
+		}
+	}
+	switch len(matches) {
+	case 0:
+		return nil, fmt.Errorf("%s has no field matching %v", st.Name(), elems)
+	case 1:
+		return matches[0], nil
+	}
+	var names []string
+	for _, m := range matches {
+		names = append(names, m.field.Name)
+	}
+	return nil, fmt.Errorf("%s fields %v ambiguously match %v", st.Name(), names, elems)
+}
+
+// ToUncompressed returns a new root GoStruct of the uncompressed schema that
+// contains the data within the supplied root GoStruct of the compressed
+// schema. It returns an error if s is not of the type of the compressed
+// schema's root, or if its data cannot be represented in the uncompressed
+// schema.
+func (t *SchemaTranslator) ToUncompressed(s ygot.GoStruct) (ygot.ValidatedGoStruct, error) {
+	if reflect.TypeOf(s) != reflect.TypeOf(t.compressed.Root) {
+		return nil, fmt.Errorf("got %T, want root of compressed schema %T", s, t.compressed.Root)
+	}
+	return translate(s, t.uncompressed, t.preferShadowPath)
+}
+
+// ToCompressed returns a new root GoStruct of the compressed schema that
+// contains the data within the supplied root GoStruct of the uncompressed
+// schema. Uncompressed data is mapped to the compressed GoStructs using
+// their path annotations, or their shadow path annotations if the
+// PreferShadowPath option was specified, and data at the other paths is
+// discarded. It returns an error if s is not of the type of the uncompressed
+// schema's root, or if its data cannot be represented in the compressed
+// schema.
+func (t *SchemaTranslator) ToCompressed(s ygot.GoStruct) (ygot.ValidatedGoStruct, error) {
+	if reflect.TypeOf(s) != reflect.TypeOf(t.uncompressed.Root) {
+		return nil, fmt.Errorf("got %T, want root of uncompressed schema %T", s, t.uncompressed.Root)
+	}
+	var opts []ytypes.UnmarshalOpt
+	if t.preferShadowPath {
+		opts = append(opts, &ytypes.PreferShadowPath{})
+	}
+	return translate(s, t.compressed, false, opts...)
+}
+
+// translate renders the data within s as gNMI notifications, and unmarshals
+// them into a new root GoStruct of the schema dst. If preferShadowPath is set,
+// s is rendered using its shadow paths.
+func translate(s ygot.GoStruct, dst *ytypes.Schema, preferShadowPath bool, opts ...ytypes.UnmarshalOpt) (ygot.ValidatedGoStruct, error) {
+	ns, err := ygot.TogNMINotifications(s, 0, ygot.GNMINotificationsConfig{
+		UsePathElem:      true,
+		PreferShadowPath: preferShadowPath,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("cannot render %T as notifications, %v", s, err)
+	}
+	root, ok := reflect.New(reflect.TypeOf(dst.Root).Elem()).Interface().(ygot.ValidatedGoStruct)
+	if !ok {
+		return nil, fmt.Errorf("root %T of schema is not a ValidatedGoStruct", dst.Root)
+	}
+	schema := &ytypes.Schema{
+		Root:       root,
+		SchemaTree: dst.SchemaTree,
+		Unmarshal:  dst.Unmarshal,
+	}
+	if err := ytypes.UnmarshalNotifications(schema, ns, opts...); err != nil {
+		return nil, fmt.Errorf("cannot unmarshal data into %T, %v", root, err)
+	}
+	return root, nil
+}
@@ -0,0 +1,447 @@
+// Copyright 2023 Google Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package pathtranslate
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/openconfig/gnmi/errdiff"
+	"github.com/openconfig/goyang/pkg/yang"
+	"github.com/openconfig/ygot/ygot"
+	"github.com/openconfig/ygot/ytypes"
+	"google.golang.org/protobuf/testing/protocmp"
+
+	gnmipb "github.com/openconfig/gnmi/proto/gnmi"
+)
+
+// compressedRoot is a compressed fakeroot GoStruct used for testing.
+type compressedRoot struct {
+	Interface map[string]*compressedInterface `path:"interfaces/interface"`
+	Hostname  *string                         `path:"system/config/hostname" shadow-path:"system/state/hostname"`
+}
+
+func (*compressedRoot) IsYANGGoStruct()                         {}
+func (*compressedRoot) Validate(...ygot.ValidationOption) error { return nil }
+func (*compressedRoot) ΛEnumTypeMap() map[string][]reflect.Type { return nil }
+func (*compressedRoot) ΛBelongingModule() string                { return "" }
+
+// compressedInterface is a compressed list member GoStruct used for testing.
+type compressedInterface struct {
+	Name        *string            `path:"config/name|name" shadow-path:"state/name|name"`
+	Mtu         *uint16            `path:"config/mtu" shadow-path:"state/mtu"`
+	Counters    *compressedCounter `path:"state/counters"`
+	Description *string            `path:"config/description"`
+}
+
+func (*compressedInterface) IsYANGGoStruct() {}
+
+// compressedCounter is a compressed container GoStruct used for testing.
+type compressedCounter struct {
+	InPkts *uint64 `path:"in-pkts"`
+}
+
+func (*compressedCounter) IsYANGGoStruct() {}
+
+// testEntry returns a schema entry with the supplied name and kind, whose
+// children are the supplied entries.
+func testEntry(name string, kind yang.EntryKind, children ...*yang.Entry) *yang.Entry {
+	e := &yang.Entry{Name: name, Kind: kind}
+	if kind == yang.DirectoryEntry {
+		e.Dir = map[string]*yang.Entry{}
+	}
+	for _, c := range children {
+		c.Parent = e
+		e.Dir[c.Name] = c
+	}
+	return e
+}
+
+// testLeaf returns a leaf schema entry with the supplied name.
+func testLeaf(name string) *yang.Entry {
+	return &yang.Entry{Name: name, Kind: yang.LeafEntry, Type: &yang.YangType{Kind: yang.Ystring}}
+}
+
+// testRoot returns a fakeroot schema entry whose children are the supplied
+// entries.
+func testRoot(children ...*yang.Entry) *yang.Entry {
+	e := testEntry("device", yang.DirectoryEntry, children...)
+	e.Annotation = map[string]interface{}{"isFakeRoot": true}
+	return e
+}
+
+// testSchemaTree returns the schema tree of the module that compressedRoot
+// is generated for, keyed by the names of the compressed GoStructs.
+func testSchemaTree() map[string]*yang.Entry {
+	counters := testEntry("counters", yang.DirectoryEntry, testLeaf("in-pkts"))
+	intf := testEntry("interface", yang.DirectoryEntry,
+		testLeaf("name"),
+		testEntry("config", yang.DirectoryEntry, testLeaf("name"), testLeaf("mtu"), testLeaf("description")),
+		testEntry("state", yang.DirectoryEntry, testLeaf("name"), testLeaf("mtu"), counters),
+	)
+	intf.Key = "name"
+	intf.ListAttr = &yang.ListAttr{}
+	root := testRoot(
+		testEntry("interfaces", yang.DirectoryEntry, intf),
+		testEntry("system", yang.DirectoryEntry,
+			testEntry("config", yang.DirectoryEntry, testLeaf("hostname")),
+			testEntry("state", yang.DirectoryEntry, testLeaf("hostname")),
+		),
+	)
+	return map[string]*yang.Entry{
+		"compressedRoot":      root,
+		"compressedInterface": intf,
+		"compressedCounter":   counters,
+	}
+}
+
+// testSchemaTranslator returns a SchemaTranslator whose compressed schema is
+// rooted at compressedRoot. The uncompressed schema shares the schema tree
+// of the compressed schema, since only its schema tree is used for path
+// translation.
+func testSchemaTranslator(t *testing.T, opts ...SchemaTranslatorOpt) *SchemaTranslator {
+	t.Helper()
+	s := &ytypes.Schema{
+		Root:       &compressedRoot{},
+		SchemaTree: testSchemaTree(),
+		Unmarshal:  func([]byte, ygot.GoStruct, ...ytypes.UnmarshalOpt) error { return nil },
+	}
+	tr, err := NewSchemaTranslator(s, s, opts...)
+	if err != nil {
+		t.Fatalf("NewSchemaTranslator: got unexpected error: %v", err)
+	}
+	return tr
+}
+
+// ambiguousRoot is a compressed fakeroot GoStruct used for testing, whose
+// fields cannot be told apart by their compressed names or paths.
+type ambiguousRoot struct {
+	AddrA   *string `path:"a/config/addr"`
+	AddrB   *string `path:"b/config/addr"`
+	Unique  *string `path:"c/config/unique"`
+	EnableA *string `path:"d/enable"`
+	EnableB *string `path:"d/enable"`
+}
+
+func (*ambiguousRoot) IsYANGGoStruct()                         {}
+func (*ambiguousRoot) Validate(...ygot.ValidationOption) error { return nil }
+func (*ambiguousRoot) ΛEnumTypeMap() map[string][]reflect.Type { return nil }
+func (*ambiguousRoot) ΛBelongingModule() string                { return "" }
+
+// ambiguousSchemaTranslator returns a SchemaTranslator whose compressed
+// schema is rooted at ambiguousRoot.
+func ambiguousSchemaTranslator(t *testing.T) *SchemaTranslator {
+	t.Helper()
+	s := &ytypes.Schema{
+		Root: &ambiguousRoot{},
+		SchemaTree: map[string]*yang.Entry{
+			"ambiguousRoot": testRoot(
+				testEntry("a", yang.DirectoryEntry, testEntry("config", yang.DirectoryEntry, testLeaf("addr"))),
+				testEntry("b", yang.DirectoryEntry, testEntry("config", yang.DirectoryEntry, testLeaf("addr"))),
+				testEntry("c", yang.DirectoryEntry, testEntry("config", yang.DirectoryEntry, testLeaf("unique"))),
+				testEntry("d", yang.DirectoryEntry, testLeaf("enable")),
+			),
+		},
+		Unmarshal: func([]byte, ygot.GoStruct, ...ytypes.UnmarshalOpt) error { return nil },
+	}
+	tr, err := NewSchemaTranslator(s, s)
+	if err != nil {
+		t.Fatalf("NewSchemaTranslator: got unexpected error: %v", err)
+	}
+	return tr
+}
+
+func TestAmbiguousPaths(t *testing.T) {
+	tr := ambiguousSchemaTranslator(t)
+
+	got, err := tr.ToUncompressedPath(&gnmipb.Path{Elem: []*gnmipb.PathElem{{Name: "unique"}}})
+	if err != nil {
+		t.Fatalf("ToUncompressedPath(unique): got unexpected error: %v", err)
+	}
+	want := &gnmipb.Path{Elem: []*gnmipb.PathElem{{Name: "c"}, {Name: "config"}, {Name: "unique"}}}
+	if diff := cmp.Diff(want, got, protocmp.Transform()); diff != "" {
+		t.Errorf("ToUncompressedPath(unique): did not get expected path, diff(-want, +got):\n%s", diff)
+	}
+
+	_, err = tr.ToUncompressedPath(&gnmipb.Path{Elem: []*gnmipb.PathElem{{Name: "addr"}}})
+	if diff := errdiff.Substring(err, "is ambiguous"); diff != "" {
+		t.Errorf("ToUncompressedPath(addr): %s", diff)
+	}
+
+	_, err = tr.ToCompressedPath(&gnmipb.Path{Elem: []*gnmipb.PathElem{{Name: "d"}, {Name: "enable"}}})
+	if diff := errdiff.Substring(err, "ambiguously match"); diff != "" {
+		t.Errorf("ToCompressedPath(d/enable): %s", diff)
+	}
+}
+
+func TestNewSchemaTranslator(t *testing.T) {
+	valid := &ytypes.Schema{
+		Root:       &compressedRoot{},
+		SchemaTree: map[string]*yang.Entry{},
+		Unmarshal:  func([]byte, ygot.GoStruct, ...ytypes.UnmarshalOpt) error { return nil },
+	}
+	tests := []struct {
+		desc             string
+		inCompressed     *ytypes.Schema
+		inUncompressed   *ytypes.Schema
+		wantErrSubstring string
+	}{{
+		desc:           "valid schemas",
+		inCompressed:   valid,
+		inUncompressed: valid,
+	}, {
+		desc:             "nil compressed schema",
+		inUncompressed:   valid,
+		wantErrSubstring: "invalid compressed schema",
+	}, {
+		desc:             "incomplete uncompressed schema",
+		inCompressed:     valid,
+		inUncompressed:   &ytypes.Schema{Root: &compressedRoot{}},
+		wantErrSubstring: "invalid uncompressed schema",
+	}}
+
+	for _, tt := range tests {
+		t.Run(tt.desc, func(t *testing.T) {
+			_, err := NewSchemaTranslator(tt.inCompressed, tt.inUncompressed)
+			if diff := errdiff.Substring(err, tt.wantErrSubstring); diff != "" {
+				t.Errorf("NewSchemaTranslator: %s", diff)
+			}
+		})
+	}
+}
+
+func TestToUncompressedPath(t *testing.T) {
+	tests := []struct {
+		desc             string
+		inPath           *gnmipb.Path
+		inOpts           []SchemaTranslatorOpt
+		want             *gnmipb.Path
+		wantErrSubstring string
+	}{{
+		desc: "list leaf",
+		inPath: &gnmipb.Path{Origin: "openconfig", Elem: []*gnmipb.PathElem{
+			{Name: "interface", Key: map[string]string{"name": "eth0"}},
+			{Name: "mtu"},
+		}},
+		want: &gnmipb.Path{Origin: "openconfig", Elem: []*gnmipb.PathElem{
+			{Name: "interfaces"},
+			{Name: "interface", Key: map[string]string{"name": "eth0"}},
+			{Name: "config"},
+			{Name: "mtu"},
+		}},
+	}, {
+		desc: "list leaf with shadow path",
+		inPath: &gnmipb.Path{Elem: []*gnmipb.PathElem{
+			{Name: "interface", Key: map[string]string{"name": "eth0"}},
+			{Name: "mtu"},
+		}},
+		inOpts: []SchemaTranslatorOpt{&PreferShadowPath{}},
+		want: &gnmipb.Path{Elem: []*gnmipb.PathElem{
+			{Name: "interfaces"},
+			{Name: "interface", Key: map[string]string{"name": "eth0"}},
+			{Name: "state"},
+			{Name: "mtu"},
+		}},
+	}, {
+		desc: "shadow path preferred, but field has no shadow path",
+		inPath: &gnmipb.Path{Elem: []*gnmipb.PathElem{
+			{Name: "interface", Key: map[string]string{"name": "*"}},
+			{Name: "description"},
+		}},
+		inOpts: []SchemaTranslatorOpt{&PreferShadowPath{}},
+		want: &gnmipb.Path{Elem: []*gnmipb.PathElem{
+			{Name: "interfaces"},
+			{Name: "interface", Key: map[string]string{"name": "*"}},
+			{Name: "config"},
+			{Name: "description"},
+		}},
+	}, {
+		desc: "container beneath list",
+		inPath: &gnmipb.Path{Elem: []*gnmipb.PathElem{
+			{Name: "interface"},
+			{Name: "counters"},
+			{Name: "in-pkts"},
+		}},
+		want: &gnmipb.Path{Elem: []*gnmipb.PathElem{
+			{Name: "interfaces"},
+			{Name: "interface"},
+			{Name: "state"},
+			{Name: "counters"},
+			{Name: "in-pkts"},
+		}},
+	}, {
+		desc:   "root",
+		inPath: &gnmipb.Path{},
+		want:   &gnmipb.Path{},
+	}, {
+		desc:             "unknown field",
+		inPath:           &gnmipb.Path{Elem: []*gnmipb.PathElem{{Name: "system"}}},
+		wantErrSubstring: "has no field named system",
+	}, {
+		desc: "keys on a non-list",
+		inPath: &gnmipb.Path{Elem: []*gnmipb.PathElem{
+			{Name: "hostname", Key: map[string]string{"name": "eth0"}},
+		}},
+		wantErrSubstring: "is not a list but has keys",
+	}, {
+		desc: "path beneath a leaf",
+		inPath: &gnmipb.Path{Elem: []*gnmipb.PathElem{
+			{Name: "hostname"},
+			{Name: "value"},
+		}},
+		wantErrSubstring: "is beneath a leaf",
+	}, {
+		desc: "field path not in schema",
+		inPath: &gnmipb.Path{Elem: []*gnmipb.PathElem{
+			{Name: "interface", Key: map[string]string{"name": "eth0"}},
+			{Name: "speed"},
+		}},
+		wantErrSubstring: "has no field named speed",
+	}, {
+		desc:             "wildcard name",
+		inPath:           &gnmipb.Path{Elem: []*gnmipb.PathElem{{Name: "*"}}},
+		wantErrSubstring: "cannot translate wildcard",
+	}}
+
+	for _, tt := range tests {
+		t.Run(tt.desc, func(t *testing.T) {
+			got, err := testSchemaTranslator(t, tt.inOpts...).ToUncompressedPath(tt.inPath)
+			if diff := errdiff.Substring(err, tt.wantErrSubstring); diff != "" {
+				t.Fatalf("ToUncompressedPath(%v): %s", tt.inPath, diff)
+			}
+			if diff := cmp.Diff(tt.want, got, protocmp.Transform()); diff != "" {
+				t.Errorf("ToUncompressedPath(%v): did not get expected path, diff(-want, +got):\n%s", tt.inPath, diff)
+			}
+		})
+	}
+}
+
+func TestToCompressedPath(t *testing.T) {
+	tests := []struct {
+		desc             string
+		inPath           *gnmipb.Path
+		want             *gnmipb.Path
+		wantErrSubstring string
+	}{{
+		desc: "config leaf",
+		inPath: &gnmipb.Path{Target: "dut", Elem: []*gnmipb.PathElem{
+			{Name: "interfaces"},
+			{Name: "interface", Key: map[string]string{"name": "eth0"}},
+			{Name: "config"},
+			{Name: "mtu"},
+		}},
+		want: &gnmipb.Path{Target: "dut", Elem: []*gnmipb.PathElem{
+			{Name: "interface", Key: map[string]string{"name": "eth0"}},
+			{Name: "mtu"},
+		}},
+	}, {
+		desc: "state leaf",
+		inPath: &gnmipb.Path{Elem: []*gnmipb.PathElem{
+			{Name: "system"},
+			{Name: "state"},
+			{Name: "hostname"},
+		}},
+		want: &gnmipb.Path{Elem: []*gnmipb.PathElem{
+			{Name: "hostname"},
+		}},
+	}, {
+		desc: "list key leaf",
+		inPath: &gnmipb.Path{Elem: []*gnmipb.PathElem{
+			{Name: "interfaces"},
+			{Name: "interface", Key: map[string]string{"name": "eth0"}},
+			{Name: "name"},
+		}},
+		want: &gnmipb.Path{Elem: []*gnmipb.PathElem{
+			{Name: "interface", Key: map[string]string{"name": "eth0"}},
+			{Name: "name"},
+		}},
+	}, {
+		desc: "container beneath list",
+		inPath: &gnmipb.Path{Elem: []*gnmipb.PathElem{
+			{Name: "interfaces"},
+			{Name: "interface", Key: map[string]string{"name": "*"}},
+			{Name: "state"},
+			{Name: "counters"},
+			{Name: "in-pkts"},
+		}},
+		want: &gnmipb.Path{Elem: []*gnmipb.PathElem{
+			{Name: "interface", Key: map[string]string{"name": "*"}},
+			{Name: "counters"},
+			{Name: "in-pkts"},
+		}},
+	}, {
+		desc: "path to a removed container",
+		inPath: &gnmipb.Path{Elem: []*gnmipb.PathElem{
+			{Name: "interfaces"},
+		}},
+		wantErrSubstring: "has no field matching",
+	}, {
+		desc: "path not in uncompressed schema",
+		inPath: &gnmipb.Path{Elem: []*gnmipb.PathElem{
+			{Name: "interfaces"},
+			{Name: "interface", Key: map[string]string{"name": "eth0"}},
+			{Name: "config"},
+			{Name: "speed"},
+		}},
+		wantErrSubstring: "does not exist in the uncompressed schema",
+	}, {
+		desc: "keys on a non-list",
+		inPath: &gnmipb.Path{Elem: []*gnmipb.PathElem{
+			{Name: "system", Key: map[string]string{"name": "eth0"}},
+			{Name: "config"},
+			{Name: "hostname"},
+		}},
+		wantErrSubstring: "is not a list but has keys",
+	}, {
+		desc: "path beneath a leaf",
+		inPath: &gnmipb.Path{Elem: []*gnmipb.PathElem{
+			{Name: "system"},
+			{Name: "config"},
+			{Name: "hostname"},
+			{Name: "value"},
+		}},
+		wantErrSubstring: "is beneath a leaf",
+	}, {
+		desc: "multi-level wildcard",
+		inPath: &gnmipb.Path{Elem: []*gnmipb.PathElem{
+			{Name: "interfaces"},
+			{Name: "..."},
+		}},
+		wantErrSubstring: "cannot translate wildcard",
+	}}
+
+	for _, tt := range tests {
+		t.Run(tt.desc, func(t *testing.T) {
+			got, err := testSchemaTranslator(t).ToCompressedPath(tt.inPath)
+			if diff := errdiff.Substring(err, tt.wantErrSubstring); diff != "" {
+				t.Fatalf("ToCompressedPath(%v): %s", tt.inPath, diff)
+			}
+			if diff := cmp.Diff(tt.want, got, protocmp.Transform()); diff != "" {
+				t.Errorf("ToCompressedPath(%v): did not get expected path, diff(-want, +got):\n%s", tt.inPath, diff)
+			}
+		})
+	}
+}
+
+func TestTranslateStructType(t *testing.T) {
+	tr := testSchemaTranslator(t)
+	if _, err := tr.ToUncompressed(&compressedInterface{}); err == nil {
+		t.Errorf("ToUncompressed(%T): did not get expected error", &compressedInterface{})
+	}
+	if _, err := tr.ToCompressed(&compressedCounter{}); err == nil {
+		t.Errorf("ToCompressed(%T): did not get expected error", &compressedCounter{})
+	}
+}
@@ -15,10 +15,12 @@
 package ygot
 
 import (
+	"encoding/base64"
 	"encoding/json"
 	"errors"
 	"fmt"
 	"reflect"
+	"strconv"
 	"strings"
 
 	"github.com/openconfig/gnmi/errlist"
@@ -28,6 +30,7 @@ import (
 	"golang.org/x/exp/slices"
 	"google.golang.org/protobuf/encoding/prototext"
 	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/types/known/anypb"
 
 	gnmipb "github.com/openconfig/gnmi/proto/gnmi"
 )
@@ -354,6 +357,10 @@ type GNMINotificationsConfig struct {
 	// prefix that concatenates the given prefix with the relative path of
 	// the ordered map from the given node.
 	PathElemPrefix []*gnmipb.PathElem
+	// PreferShadowPath specifies that the "shadow-path" struct tag of
+	// each field should be used to determine its path in the output
+	// notifications, where it is present, rather than the "path" tag.
+	PreferShadowPath bool
 }
 
 // TogNMINotifications takes an input GoStruct and renders it to slice of
@@ -382,7 +389,7 @@ func TogNMINotifications(s GoStruct, ts int64, cfg GNMINotificationsConfig) ([]*
 	}
 
 	leaves := map[*path]any{}
-	if err := findUpdatedLeaves(leaves, s, pfx, false); err != nil {
+	if err := findUpdatedLeaves(leaves, s, pfx, cfg.PreferShadowPath); err != nil {
 		return nil, err
 	}
 
@@ -436,10 +443,12 @@ func findUpdatedOrderedListLeaves(leaves any, s GoOrderedMap, parent *gnmiPath,
 // If errors are encountered they are appended to the errlist.List supplied. If
 // the GoStruct contains fields that are themselves structured objects (YANG
 // lists, or containers - represented as maps or struct pointers), the function
-// is called recursively on them.
+// is called recursively on them. s may also be the struct representing the
+// selected case of a YANG choice, since its fields are children of the
+// GoStruct containing the choice.
 //
 // Note: the returned paths use a shallow copy of the parentPath.
-func findUpdatedLeaves(leaves any, s GoStruct, parent *gnmiPath, preferShadowPath bool) error {
+func findUpdatedLeaves(leaves any, s any, parent *gnmiPath, preferShadowPath bool) error {
 	// addLeaf is the function that must be used to add a single leaf or
 	// atomic update to the input cache of leaves. The reason this is
 	// different is because atomic values must be added in a different way
@@ -484,6 +493,15 @@ func findUpdatedLeaves(leaves any, s GoStruct, parent *gnmiPath, preferShadowPat
 		fval := sval.Field(i)
 		ftype := stype.Field(i)
 
+		if util.IsChoiceField(ftype) {
+			// The fields of the selected case of a choice are at the
+			// same level of the data tree as the other fields of s.
+			if !fval.IsNil() {
+				errs.Add(findUpdatedLeaves(leaves, fval.Interface(), parent, preferShadowPath))
+			}
+			continue
+		}
+
 		// Handle nil values, and enumerations specifically.
 		switch fval.Kind() {
 		case reflect.Map, reflect.Slice, reflect.Ptr, reflect.Interface:
@@ -516,6 +534,14 @@ func findUpdatedLeaves(leaves any, s GoStruct, parent *gnmiPath, preferShadowPat
 				errs.Add(findUpdatedLeaves(leaves, goStruct, childPath, preferShadowPath))
 			}
 		case reflect.Ptr:
+			if ad, ok := fval.Interface().(*AnyData); ok {
+				// The contents of an anydata or anyxml node are not described
+				// by the schema, and hence are output as a single value.
+				for _, p := range mapPaths {
+					addLeaf(&path{p}, ad)
+				}
+				continue
+			}
 			if ol, ok := fval.Interface().(GoOrderedMap); ok {
 				// This is an ordered-map for YANG "ordered-by user" lists.
 				errs.Add(findUpdatedOrderedListLeaves(leaves, ol, mapPaths[0], preferShadowPath))
@@ -841,19 +867,70 @@ type EncodeTypedValueOpt interface {
 	IsEncodeTypedValueOpt()
 }
 
+// ProtoMapper is an EncodeTypedValueOpt that maps the leaves of a GoStruct
+// to a protobuf message, such that the GoStruct can be encoded using the
+// gNMI PROTO encoding. It is typically implemented using the protobufs
+// generated by ygot's protogen package, for example by the protomap package.
+type ProtoMapper interface {
+	EncodeTypedValueOpt
+	// ProtoFromLeaves returns a protobuf message populated with the values
+	// in leaves, which is keyed by the path of each leaf relative to the
+	// GoStruct being encoded.
+	ProtoFromLeaves(leaves map[*gnmipb.Path]any) (proto.Message, error)
+}
+
 // EncodeTypedValue encodes val into a gNMI TypedValue message, using the specified encoding
 // type if the value is a struct.
+//
+// Structs can be encoded as JSON, JSON_IETF or PROTO. The PROTO encoding
+// requires a ProtoMapper to be supplied in opts, and results in a TypedValue
+// containing the mapped protobuf as an Any. Ordered lists can be encoded as
+// JSON or JSON_IETF, but not as PROTO. Scalar values and leaf-lists are
+// encoded as the corresponding typed TypedValue, unless the ASCII or BYTES
+// encoding is specified, in which case their string representation is used.
 func EncodeTypedValue(val any, enc gnmipb.Encoding, opts ...EncodeTypedValueOpt) (*gnmipb.TypedValue, error) {
 	jc := &RFC7951JSONConfig{}
+	var pm ProtoMapper
 	for _, opt := range opts {
-		if cfg, ok := opt.(*RFC7951JSONConfig); ok {
-			jc = cfg
+		switch o := opt.(type) {
+		case *RFC7951JSONConfig:
+			jc = o
+		case ProtoMapper:
+			pm = o
 		}
 	}
 
 	switch v := val.(type) {
-	case GoStruct, GoOrderedMap:
+	case GoStruct:
+		if enc == gnmipb.Encoding_PROTO {
+			return marshalStructProto(v, pm)
+		}
+		return marshalStructOrOrderedList(v, enc, jc)
+	case GoOrderedMap:
+		if enc == gnmipb.Encoding_PROTO {
+			return nil, fmt.Errorf("cannot encode ordered list %T using PROTO encoding, only GoStructs can be mapped to a protobuf", v)
+		}
 		return marshalStructOrOrderedList(v, enc, jc)
+	case *AnyData:
+		return marshalAnyData(v, jc)
+	}
+
+	tv, err := encodeScalarTypedValue(val)
+	if err != nil || tv == nil {
+		return tv, err
+	}
+
+	switch enc {
+	case gnmipb.Encoding_ASCII, gnmipb.Encoding_BYTES:
+		return textTypedValue(tv, enc)
+	}
+	return tv, nil
+}
+
+// encodeScalarTypedValue encodes the scalar or leaf-list value val into a
+// gNMI TypedValue message of the corresponding type.
+func encodeScalarTypedValue(val any) (*gnmipb.TypedValue, error) {
+	switch v := val.(type) {
 	case GoEnum:
 		en, err := EnumName(v)
 		if err != nil {
@@ -912,6 +989,89 @@ func EncodeTypedValue(val any, enc gnmipb.Encoding, opts ...EncodeTypedValueOpt)
 	return value.FromScalar(vv.Interface())
 }
 
+// marshalStructProto encodes the GoStruct s as a protobuf message using the
+// supplied ProtoMapper, and returns it as an Any within a TypedValue.
+func marshalStructProto(s GoStruct, pm ProtoMapper) (*gnmipb.TypedValue, error) {
+	if reflect.ValueOf(s).IsNil() {
+		return nil, nil
+	}
+	if pm == nil {
+		return nil, errors.New("cannot encode struct using PROTO encoding, no ProtoMapper supplied")
+	}
+
+	notifs, err := TogNMINotifications(s, 0, GNMINotificationsConfig{UsePathElem: true})
+	if err != nil {
+		return nil, fmt.Errorf("cannot extract leaves of struct, %v", err)
+	}
+
+	leaves := map[*gnmipb.Path]any{}
+	for _, n := range notifs {
+		for _, u := range n.GetUpdate() {
+			p, err := util.JoinPaths(n.GetPrefix(), u.GetPath())
+			if err != nil {
+				return nil, fmt.Errorf("invalid path for leaf, %v", err)
+			}
+			leaves[p] = u.GetVal()
+		}
+	}
+
+	m, err := pm.ProtoFromLeaves(leaves)
+	if err != nil {
+		return nil, fmt.Errorf("cannot map struct to protobuf, %v", err)
+	}
+	a, err := anypb.New(m)
+	if err != nil {
+		return nil, fmt.Errorf("cannot marshal protobuf to Any, %v", err)
+	}
+	return &gnmipb.TypedValue{Value: &gnmipb.TypedValue_AnyVal{AnyVal: a}}, nil
+}
+
+// textTypedValue converts the scalar or leaf-list TypedValue tv to the ASCII
+// or BYTES encoding specified by enc. Values are represented by their
+// canonical string representation, with the exception of binary values,
+// which are base64 encoded when using ASCII, and unmodified when using BYTES.
+func textTypedValue(tv *gnmipb.TypedValue, enc gnmipb.Encoding) (*gnmipb.TypedValue, error) {
+	if ll := tv.GetLeaflistVal(); ll != nil {
+		arr := &gnmipb.ScalarArray{}
+		for _, e := range ll.GetElement() {
+			te, err := textTypedValue(e, enc)
+			if err != nil {
+				return nil, err
+			}
+			arr.Element = append(arr.Element, te)
+		}
+		return &gnmipb.TypedValue{Value: &gnmipb.TypedValue_LeaflistVal{LeaflistVal: arr}}, nil
+	}
+
+	var s string
+	switch v := tv.GetValue().(type) {
+	case *gnmipb.TypedValue_StringVal:
+		s = v.StringVal
+	case *gnmipb.TypedValue_IntVal:
+		s = strconv.FormatInt(v.IntVal, 10)
+	case *gnmipb.TypedValue_UintVal:
+		s = strconv.FormatUint(v.UintVal, 10)
+	case *gnmipb.TypedValue_BoolVal:
+		s = strconv.FormatBool(v.BoolVal)
+	case *gnmipb.TypedValue_DoubleVal:
+		s = strconv.FormatFloat(v.DoubleVal, 'f', -1, 64)
+	case *gnmipb.TypedValue_FloatVal:
+		s = strconv.FormatFloat(float64(v.FloatVal), 'f', -1, 32)
+	case *gnmipb.TypedValue_BytesVal:
+		if enc == gnmipb.Encoding_BYTES {
+			return &gnmipb.TypedValue{Value: &gnmipb.TypedValue_BytesVal{BytesVal: v.BytesVal}}, nil
+		}
+		s = base64.StdEncoding.EncodeToString(v.BytesVal)
+	default:
+		return nil, fmt.Errorf("cannot encode value %v using %v encoding", tv, gnmipb.Encoding_name[int32(enc)])
+	}
+
+	if enc == gnmipb.Encoding_BYTES {
+		return &gnmipb.TypedValue{Value: &gnmipb.TypedValue_BytesVal{BytesVal: []byte(s)}}, nil
+	}
+	return &gnmipb.TypedValue{Value: &gnmipb.TypedValue_AsciiVal{AsciiVal: s}}, nil
+}
+
 // marshalStructOrOrderedList encodes the struct/ordered list s according to
 // the encoding specified by enc. It is returned as a TypedValue gNMI message.
 func marshalStructOrOrderedList(s any, enc gnmipb.Encoding, cfg *RFC7951JSONConfig) (*gnmipb.TypedValue, error) {
@@ -954,6 +1114,47 @@ func marshalStructOrOrderedList(s any, enc gnmipb.Encoding, cfg *RFC7951JSONConf
 	return encfn(string(js)), nil
 }
 
+// marshalAnyData encodes the contents of the anydata or anyxml node a as a
+// TypedValue gNMI message. Since the contents of such nodes are always
+// RFC7951 JSON, the JSON_IETF encoding is used regardless of the encoding
+// requested for structs.
+func marshalAnyData(a *AnyData, cfg *RFC7951JSONConfig) (*gnmipb.TypedValue, error) {
+	if a == nil {
+		return nil, nil
+	}
+
+	c := *cfg
+	c.AppendModuleName = true
+	j, err := anyDataValue(a, jsonOutputConfig{jType: RFC7951, rfc7951Config: &c})
+	if err != nil || j == nil {
+		return nil, err
+	}
+
+	js, err := json.Marshal(j)
+	if err != nil {
+		return nil, fmt.Errorf("cannot encode JSON, %v", err)
+	}
+	return &gnmipb.TypedValue{Value: &gnmipb.TypedValue_JsonIetfVal{JsonIetfVal: js}}, nil
+}
+
+// anyDataValue returns the contents of the anydata or anyxml node a as a value
+// that can be marshalled to JSON according to args. If the contents are stored
+// as a GoStruct they are rendered as a separate data tree, otherwise the stored
+// JSON is returned. A nil value is returned if a has no contents.
+func anyDataValue(a *AnyData, args jsonOutputConfig) (any, error) {
+	switch {
+	case !util.IsValueNil(a.Value):
+		return structJSON(a.Value, "", args)
+	case len(a.JSON) != 0:
+		var v any
+		if err := json.Unmarshal(a.JSON, &v); err != nil {
+			return nil, fmt.Errorf("invalid JSON contents for anydata, %v", err)
+		}
+		return v, nil
+	}
+	return nil, nil
+}
+
 // leaflistToSlice takes a reflect.Value that represents a leaf list in the YANG schema
 // (GoStruct) and outputs a slice of any that corresponds to its contents that
 // should be used within a Notification. If prependModuleNameIref is set to true, then
@@ -1221,6 +1422,12 @@ type jsonOutputConfig struct {
 	// rfc7951Config stores the configuration to be used when outputting RFC7951
 	// JSON.
 	rfc7951Config *RFC7951JSONConfig
+	// nativeScalars specifies that int64, uint64 and decimal64 values should
+	// not be rendered as strings in RFC7951 output, and that values should
+	// retain their Go types rather than being normalized through
+	// encoding/json. It is used for encodings such as YAML that can
+	// represent these values without loss.
+	nativeScalars bool
 }
 
 // rewriteModName rewrites the module mod according to the specified rewrite rules.
@@ -1291,8 +1498,18 @@ func prependmodsJSON(fType reflect.StructField, parentMod string, args jsonOutpu
 // whether to prepend the name of the module to an element. The format of JSON to
 // be produced and whether such module names are prepended is controlled through the
 // supplied jsonOutputConfig. Returns an error if the GoStruct cannot be rendered
-// to JSON.
-func structJSON(s GoStruct, parentMod string, args jsonOutputConfig) (map[string]any, error) {
+// to JSON. s may also be the struct representing the selected case of a YANG
+// choice, whose fields are rendered as though they were fields of the GoStruct
+// containing the choice.
+func structJSON(s any, parentMod string, args jsonOutputConfig) (map[string]any, error) {
+	if m, ok := s.(RFC7951Marshaler); ok && useRFC7951Marshaler(args) {
+		e := &RFC7951Encoder{parentMod: parentMod, args: args, out: map[string]any{}}
+		if err := m.MarshalRFC7951(e); err != nil {
+			return nil, err
+		}
+		return e.out, nil
+	}
+
 	var errs errlist.List
 
 	sval := reflect.ValueOf(s).Elem()
@@ -1306,6 +1523,23 @@ func structJSON(s GoStruct, parentMod string, args jsonOutputConfig) (map[string
 		field := sval.Field(i)
 		fType := stype.Field(i)
 
+		if util.IsChoiceField(fType) {
+			if field.IsNil() {
+				continue
+			}
+			// The fields of the selected case of a choice are output
+			// alongside the other fields of s.
+			value, err := structJSON(field.Interface(), parentMod, args)
+			if err != nil {
+				errs.Add(err)
+				continue
+			}
+			for k, v := range value {
+				jsonout[k] = v
+			}
+			continue
+		}
+
 		// Module names to prepend to the path in RFC7951 output mode.
 		var prependmods [][]string
 		var chMod string
@@ -1392,7 +1626,7 @@ func structJSON(s GoStruct, parentMod string, args jsonOutputConfig) (map[string
 			if prependmods != nil && prependmods[i][j] != "" {
 				k = fmt.Sprintf("%s:%s", prependmods[i][j], k)
 			}
-			if args.jType != Internal {
+			if args.jType != Internal && !args.nativeScalars {
 				value, err = normalizeJSONValue(value)
 			}
 			if err != nil {
@@ -1610,6 +1844,9 @@ func jsonValue(field reflect.Value, parentMod string, args jsonOutputConfig) (an
 			errs.Add(err)
 		}
 	case reflect.Ptr:
+		if ad, ok := field.Interface().(*AnyData); ok {
+			return anyDataValue(ad, args)
+		}
 		if om, ok := field.Interface().(GoOrderedMap); ok {
 			var pairs []mapValuePair
 			if err := yreflect.RangeOrderedMap(om, func(k reflect.Value, v reflect.Value) bool {
@@ -1646,7 +1883,7 @@ func jsonValue(field reflect.Value, parentMod string, args jsonOutputConfig) (an
 			}
 		default:
 			value = field.Elem().Interface()
-			if args.jType == RFC7951 {
+			if args.jType == RFC7951 && !args.nativeScalars {
 				value = writeIETFScalarJSON(value)
 			}
 		}
@@ -1719,7 +1956,7 @@ func jsonValue(field reflect.Value, parentMod string, args jsonOutputConfig) (an
 				return nil, err
 			}
 		}
-		if args.jType == RFC7951 {
+		if args.jType == RFC7951 && !args.nativeScalars {
 			value = writeIETFScalarJSON(value)
 		}
 	case reflect.Bool:
@@ -1756,7 +1993,7 @@ func jsonValue(field reflect.Value, parentMod string, args jsonOutputConfig) (an
 		if value, err = resolveUnionVal(field.Interface(), prependModuleNameIref); err != nil {
 			return nil, err
 		}
-		if args.jType == RFC7951 {
+		if args.jType == RFC7951 && !args.nativeScalars {
 			value = writeIETFScalarJSON(value)
 		}
 	}
@@ -1825,7 +2062,7 @@ func jsonSlice(field reflect.Value, parentMod string, args jsonOutputConfig) (an
 			// This is a slice within a slice which can only be a binary value,
 			// so we base64 encode it.
 			sl[j] = binaryBase64(reflect.ValueOf(e).Bytes())
-		case args.jType == RFC7951:
+		case args.jType == RFC7951 && !args.nativeScalars:
 			sl[j] = writeIETFScalarJSON(e)
 		}
 	}
@@ -614,6 +614,11 @@ func TestEncodeTypedValueOrderedMap(t *testing.T) {
     }
   }
 ]`)}},
+	}, {
+		name:             "ordered list type - proto",
+		inVal:            ctestschema.GetOrderedMap(t),
+		inEnc:            gnmipb.Encoding_PROTO,
+		wantErrSubstring: "cannot encode ordered list *ctestschema.OrderedList_OrderedMap using PROTO encoding",
 	}}
 
 	for _, tt := range tests {
@@ -19,6 +19,7 @@ import (
 	"encoding/json"
 	"fmt"
 	"reflect"
+	"sort"
 	"testing"
 
 	"github.com/google/go-cmp/cmp"
@@ -28,6 +29,7 @@ import (
 	"google.golang.org/protobuf/encoding/prototext"
 	"google.golang.org/protobuf/proto"
 	"google.golang.org/protobuf/testing/protocmp"
+	"google.golang.org/protobuf/types/known/anypb"
 
 	gnmipb "github.com/openconfig/gnmi/proto/gnmi"
 )
@@ -1043,6 +1045,21 @@ func TestTogNMINotifications(t *testing.T) {
 				Val:  &gnmipb.TypedValue{Value: &gnmipb.TypedValue_StringVal{"hello"}},
 			}},
 		}},
+	}, {
+		name:        "simple single leaf example with shadow path",
+		inTimestamp: 42,
+		inStruct:    &renderExample{Str: String("hello")},
+		inConfig: GNMINotificationsConfig{
+			UsePathElem:      true,
+			PreferShadowPath: true,
+		},
+		want: []*gnmipb.Notification{{
+			Timestamp: 42,
+			Update: []*gnmipb.Update{{
+				Path: &gnmipb.Path{Elem: []*gnmipb.PathElem{{Name: "srt"}}},
+				Val:  &gnmipb.TypedValue{Value: &gnmipb.TypedValue_StringVal{StringVal: "hello"}},
+			}},
+		}},
 	}, {
 		name:        "simple float value leaf example",
 		inTimestamp: 42,
@@ -1079,6 +1096,22 @@ func TestTogNMINotifications(t *testing.T) {
 		inTimestamp: 42,
 		inStruct:    nil,
 		wantErr:     true,
+	}, {
+		name:        "choice with selected case",
+		inTimestamp: 42,
+		inStruct: &choiceExample{
+			Protocol: &choiceExampleUDP{Port: Uint16(42)},
+		},
+		inConfig: GNMINotificationsConfig{
+			UsePathElem: true,
+		},
+		want: []*gnmipb.Notification{{
+			Timestamp: 42,
+			Update: []*gnmipb.Update{{
+				Path: &gnmipb.Path{Elem: []*gnmipb.PathElem{{Name: "udp-port"}}},
+				Val:  &gnmipb.TypedValue{Value: &gnmipb.TypedValue_UintVal{UintVal: 42}},
+			}},
+		}},
 	}, {
 		name:        "no path tags on struct",
 		inTimestamp: 42,
@@ -2095,6 +2128,63 @@ func (t *unmarshalableJSON) UnmarshalJSON(d []byte) error {
 	return fmt.Errorf("unimplemented")
 }
 
+// anyDataExample is a GoStruct that contains anydata fields.
+type anyDataExample struct {
+	Name *string  `path:"name" module:"admod"`
+	Data *AnyData `path:"config/data" module:"admod/admod"`
+}
+
+func (*anyDataExample) IsYANGGoStruct()                         {}
+func (*anyDataExample) ΛValidate(...ValidationOption) error     { return nil }
+func (*anyDataExample) ΛEnumTypeMap() map[string][]reflect.Type { return nil }
+func (*anyDataExample) ΛBelongingModule() string                { return "admod" }
+
+// choiceExample is a GoStruct that contains a YANG choice represented as a
+// sum type.
+type choiceExample struct {
+	Name     *string               `path:"name" module:"chmod"`
+	Protocol choiceExampleProtocol `choice:"protocol"`
+}
+
+func (*choiceExample) IsYANGGoStruct()                         {}
+func (*choiceExample) ΛValidate(...ValidationOption) error     { return nil }
+func (*choiceExample) ΛEnumTypeMap() map[string][]reflect.Type { return nil }
+func (*choiceExample) ΛBelongingModule() string                { return "chmod" }
+func (*choiceExample) ΛChoiceCaseTypes() map[string][]reflect.Type {
+	return map[string][]reflect.Type{
+		"Protocol": {reflect.TypeOf((*choiceExampleTCP)(nil)), reflect.TypeOf((*choiceExampleUDP)(nil))},
+	}
+}
+
+type choiceExampleProtocol interface {
+	GoChoiceCase
+	Is_choiceExampleProtocol()
+}
+
+type choiceExampleTCP struct {
+	Port  *uint16                `path:"tcp-port" module:"chmod"`
+	Child *choiceExampleTCPChild `path:"tcp-options" module:"chmod"`
+}
+
+func (*choiceExampleTCP) IsYANGChoiceCase()         {}
+func (*choiceExampleTCP) Is_choiceExampleProtocol() {}
+
+type choiceExampleTCPChild struct {
+	Window *uint32 `path:"window" module:"chmod"`
+}
+
+func (*choiceExampleTCPChild) IsYANGGoStruct()                         {}
+func (*choiceExampleTCPChild) ΛValidate(...ValidationOption) error     { return nil }
+func (*choiceExampleTCPChild) ΛEnumTypeMap() map[string][]reflect.Type { return nil }
+func (*choiceExampleTCPChild) ΛBelongingModule() string                { return "chmod" }
+
+type choiceExampleUDP struct {
+	Port *uint16 `path:"udp-port" module:"chmod"`
+}
+
+func (*choiceExampleUDP) IsYANGChoiceCase()         {}
+func (*choiceExampleUDP) Is_choiceExampleProtocol() {}
+
 func TestConstructJSON(t *testing.T) {
 	tests := []struct {
 		name                     string
@@ -2954,6 +3044,104 @@ func TestConstructJSON(t *testing.T) {
 			},
 		},
 		wantSame: true,
+	}, {
+		name: "anydata with JSON contents",
+		in: &anyDataExample{
+			Name: String("foo"),
+			Data: &AnyData{JSON: []byte(`{"other:bar": {"baz": 42}}`)},
+		},
+		inAppendMod: true,
+		wantIETF: map[string]any{
+			"admod:name": "foo",
+			"admod:config": map[string]any{
+				"data": map[string]any{
+					"other:bar": map[string]any{
+						"baz": float64(42),
+					},
+				},
+			},
+		},
+		wantInternal: map[string]any{
+			"name": "foo",
+			"config": map[string]any{
+				"data": map[string]any{
+					"other:bar": map[string]any{
+						"baz": float64(42),
+					},
+				},
+			},
+		},
+	}, {
+		name: "anydata with GoStruct contents",
+		in: &anyDataExample{
+			Data: &AnyData{Value: &ietfRenderExample{
+				F1: String("hello"),
+			}},
+		},
+		inAppendMod: true,
+		wantIETF: map[string]any{
+			"admod:config": map[string]any{
+				"data": map[string]any{
+					"f1mod:f1": "hello",
+				},
+			},
+		},
+		wantInternal: map[string]any{
+			"config": map[string]any{
+				"data": map[string]any{
+					"f1": "hello",
+				},
+			},
+		},
+	}, {
+		name: "anydata with no contents",
+		in: &anyDataExample{
+			Name: String("foo"),
+			Data: &AnyData{},
+		},
+		wantIETF: map[string]any{
+			"name": "foo",
+		},
+		wantSame: true,
+	}, {
+		name: "anydata with invalid JSON contents",
+		in: &anyDataExample{
+			Data: &AnyData{JSON: []byte(`{`)},
+		},
+		wantErr: true,
+	}, {
+		name: "choice with selected case",
+		in: &choiceExample{
+			Name: String("foo"),
+			Protocol: &choiceExampleTCP{
+				Port:  Uint16(42),
+				Child: &choiceExampleTCPChild{Window: Uint32(128)},
+			},
+		},
+		inAppendMod: true,
+		wantIETF: map[string]any{
+			"chmod:name":     "foo",
+			"chmod:tcp-port": float64(42),
+			"chmod:tcp-options": map[string]any{
+				"window": float64(128),
+			},
+		},
+		wantInternal: map[string]any{
+			"name":     "foo",
+			"tcp-port": uint16(42),
+			"tcp-options": map[string]any{
+				"window": uint32(128),
+			},
+		},
+	}, {
+		name: "choice with no selected case",
+		in: &choiceExample{
+			Name: String("foo"),
+		},
+		wantIETF: map[string]any{
+			"name": "foo",
+		},
+		wantSame: true,
 	}, {
 		name: "module append example",
 		in: &ietfRenderExample{
@@ -3984,6 +4172,24 @@ func TestEncodeTypedValue(t *testing.T) {
 					{Value: &gnmipb.TypedValue_BoolVal{false}}},
 			}},
 		},
+	}, {
+		name:  "anydata with JSON contents",
+		inVal: &AnyData{JSON: []byte(`{"other:bar": 42}`)},
+		want:  &gnmipb.TypedValue{Value: &gnmipb.TypedValue_JsonIetfVal{JsonIetfVal: []byte(`{"other:bar":42}`)}},
+	}, {
+		name: "anydata with GoStruct contents",
+		inVal: &AnyData{Value: &ietfRenderExample{
+			F1: String("hello"),
+		}},
+		want: &gnmipb.TypedValue{Value: &gnmipb.TypedValue_JsonIetfVal{JsonIetfVal: []byte(`{"f1mod:f1":"hello"}`)}},
+	}, {
+		name:  "anydata with no contents",
+		inVal: &AnyData{},
+		want:  nil,
+	}, {
+		name:             "anydata with invalid JSON contents",
+		inVal:            &AnyData{JSON: []byte(`{`)},
+		wantErrSubstring: "invalid JSON contents for anydata",
 	}, {
 		name: "struct val - ietf json",
 		inVal: &ietfRenderExample{
@@ -4028,8 +4234,89 @@ func TestEncodeTypedValue(t *testing.T) {
 	}, {
 		name:             "unsupported encoding",
 		inVal:            &ietfRenderExample{},
-		inEnc:            gnmipb.Encoding_PROTO,
+		inEnc:            gnmipb.Encoding_ASCII,
 		wantErrSubstring: "invalid encoding",
+	}, {
+		name:             "struct val - proto without mapper",
+		inVal:            &ietfRenderExample{},
+		inEnc:            gnmipb.Encoding_PROTO,
+		wantErrSubstring: "no ProtoMapper supplied",
+	}, {
+		name: "struct val - proto",
+		inVal: &ietfRenderExample{
+			F1: String("hi"),
+			F2: String("there"),
+		},
+		inEnc:  gnmipb.Encoding_PROTO,
+		inArgs: []EncodeTypedValueOpt{&notificationProtoMapper{}},
+		want: &gnmipb.TypedValue{Value: &gnmipb.TypedValue_AnyVal{AnyVal: mustAny(&gnmipb.Notification{
+			Update: []*gnmipb.Update{{
+				Path: &gnmipb.Path{Elem: mustPathElem("config/f2")},
+				Val:  &gnmipb.TypedValue{Value: &gnmipb.TypedValue_StringVal{StringVal: "there"}},
+			}, {
+				Path: &gnmipb.Path{Elem: mustPathElem("f1")},
+				Val:  &gnmipb.TypedValue{Value: &gnmipb.TypedValue_StringVal{StringVal: "hi"}},
+			}},
+		})}},
+	}, {
+		name:             "struct val - proto mapper error",
+		inVal:            &ietfRenderExample{F1: String("hi")},
+		inEnc:            gnmipb.Encoding_PROTO,
+		inArgs:           []EncodeTypedValueOpt{&notificationProtoMapper{err: fmt.Errorf("no fields")}},
+		wantErrSubstring: "cannot map struct to protobuf, no fields",
+	}, {
+		name:  "string - ascii",
+		inVal: String("hello"),
+		inEnc: gnmipb.Encoding_ASCII,
+		want:  &gnmipb.TypedValue{Value: &gnmipb.TypedValue_AsciiVal{AsciiVal: "hello"}},
+	}, {
+		name:  "enumeration - ascii",
+		inVal: EnumTestVALONE,
+		inEnc: gnmipb.Encoding_ASCII,
+		want:  &gnmipb.TypedValue{Value: &gnmipb.TypedValue_AsciiVal{AsciiVal: "VAL_ONE"}},
+	}, {
+		name:  "int64 - bytes",
+		inVal: Int64(-42),
+		inEnc: gnmipb.Encoding_BYTES,
+		want:  &gnmipb.TypedValue{Value: &gnmipb.TypedValue_BytesVal{BytesVal: []byte("-42")}},
+	}, {
+		name:  "uint8 - ascii",
+		inVal: Uint8(42),
+		inEnc: gnmipb.Encoding_ASCII,
+		want:  &gnmipb.TypedValue{Value: &gnmipb.TypedValue_AsciiVal{AsciiVal: "42"}},
+	}, {
+		name:  "bool - ascii",
+		inVal: Bool(true),
+		inEnc: gnmipb.Encoding_ASCII,
+		want:  &gnmipb.TypedValue{Value: &gnmipb.TypedValue_AsciiVal{AsciiVal: "true"}},
+	}, {
+		name:  "float64 - ascii",
+		inVal: Float64(273.15),
+		inEnc: gnmipb.Encoding_ASCII,
+		want:  &gnmipb.TypedValue{Value: &gnmipb.TypedValue_AsciiVal{AsciiVal: "273.15"}},
+	}, {
+		name:  "binary - ascii",
+		inVal: testBinary,
+		inEnc: gnmipb.Encoding_ASCII,
+		want:  &gnmipb.TypedValue{Value: &gnmipb.TypedValue_AsciiVal{AsciiVal: base64testStringEncoded}},
+	}, {
+		name:  "binary - bytes",
+		inVal: testBinary,
+		inEnc: gnmipb.Encoding_BYTES,
+		want:  &gnmipb.TypedValue{Value: &gnmipb.TypedValue_BytesVal{BytesVal: []byte(base64testString)}},
+	}, {
+		name:  "leaf-list of string - ascii",
+		inVal: []string{"one", "two"},
+		inEnc: gnmipb.Encoding_ASCII,
+		want: &gnmipb.TypedValue{Value: &gnmipb.TypedValue_LeaflistVal{
+			LeaflistVal: &gnmipb.ScalarArray{
+				Element: []*gnmipb.TypedValue{{
+					Value: &gnmipb.TypedValue_AsciiVal{AsciiVal: "one"},
+				}, {
+					Value: &gnmipb.TypedValue_AsciiVal{AsciiVal: "two"},
+				}},
+			},
+		}},
 	}, {
 		name:  "nil struct",
 		inVal: (*ietfRenderExample)(nil),
@@ -4061,6 +4348,36 @@ func TestEncodeTypedValue(t *testing.T) {
 	}
 }
 
+// notificationProtoMapper is a ProtoMapper that maps the supplied leaves to
+// the updates of a gNMI Notification, ordered by their path.
+type notificationProtoMapper struct {
+	err error
+}
+
+func (*notificationProtoMapper) IsEncodeTypedValueOpt() {}
+
+func (m *notificationProtoMapper) ProtoFromLeaves(leaves map[*gnmipb.Path]any) (proto.Message, error) {
+	if m.err != nil {
+		return nil, m.err
+	}
+	n := &gnmipb.Notification{}
+	for p, v := range leaves {
+		n.Update = append(n.Update, &gnmipb.Update{Path: p, Val: v.(*gnmipb.TypedValue)})
+	}
+	sort.Slice(n.Update, func(i, j int) bool {
+		return prototext.Format(n.Update[i].Path) < prototext.Format(n.Update[j].Path)
+	})
+	return n, nil
+}
+
+func mustAny(m proto.Message) *anypb.Any {
+	a, err := anypb.New(m)
+	if err != nil {
+		panic(err)
+	}
+	return a
+}
+
 func mustPathElem(s string) []*gnmipb.PathElem {
 	p, err := StringToStructuredPath(s)
 	if err != nil {
@@ -0,0 +1,318 @@
+// Copyright 2023 Google Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package ygot
+
+import (
+	"fmt"
+	"reflect"
+	"slices"
+	"strconv"
+	"strings"
+	"unicode/utf8"
+)
+
+// RFC7951Marshaler is an interface which is implemented by generated structs
+// that have methods to marshal themselves to RFC7951 JSON without the use of
+// reflection. When a GoStruct implements RFC7951Marshaler, ConstructIETFJSON
+// and Marshal7951 call MarshalRFC7951 rather than walking the fields of the
+// struct, unless the PreferShadowPath or RewriteModuleNames options are used.
+//
+// The output of MarshalRFC7951 must be identical to that of the reflection
+// based marshalling: values that are written to the RFC7951Encoder must be of
+// the types that are produced by unmarshalling JSON using encoding/json, which
+// the RFC7951* helper functions in this package produce.
+type RFC7951Marshaler interface {
+	// MarshalRFC7951 writes the contents of the struct to the supplied
+	// encoder.
+	MarshalRFC7951(e *RFC7951Encoder) error
+}
+
+// RFC7951Encoder is used by implementations of RFC7951Marshaler to write the
+// contents of a struct as RFC7951 JSON. It tracks the module within which the
+// struct is defined, such that module names are prepended to the names of
+// the struct's children where required.
+type RFC7951Encoder struct {
+	// parentMod is the module within which the struct being marshalled is
+	// defined.
+	parentMod string
+	// args is the configuration used to output JSON.
+	args jsonOutputConfig
+	// out is the JSON object that the contents of the struct are written
+	// to.
+	out map[string]any
+}
+
+// useRFC7951Marshaler determines whether the RFC7951Marshaler implementation
+// of a struct can be used to produce JSON according to the supplied
+// configuration.
+func useRFC7951Marshaler(args jsonOutputConfig) bool {
+	if args.jType != RFC7951 || args.nativeScalars {
+		return false
+	}
+	cfg := args.rfc7951Config
+	return cfg == nil || (!cfg.PreferShadowPath && len(cfg.RewriteModuleNames) == 0)
+}
+
+// appendModuleName determines whether module names are to be prepended to
+// the names of elements that are defined in a different module to their
+// parent.
+func (e *RFC7951Encoder) appendModuleName() bool {
+	return e.args.rfc7951Config != nil && e.args.rfc7951Config.AppendModuleName
+}
+
+// prependModuleNameIref determines whether module names are to be prepended
+// to identity values.
+func (e *RFC7951Encoder) prependModuleNameIref() bool {
+	cfg := e.args.rfc7951Config
+	return cfg != nil && (cfg.AppendModuleName || cfg.PrependModuleNameIdentityref)
+}
+
+// childModule returns the names of the JSON members that the element at the
+// supplied paths, which are defined in the supplied modules, is written to.
+// It also returns the module within which the element is defined, which is
+// the empty string if module names are not to be prepended.
+func (e *RFC7951Encoder) childModule(paths, mods [][]string) ([][]string, string, error) {
+	if !e.appendModuleName() || len(mods) == 0 {
+		return paths, "", nil
+	}
+	if len(paths) != len(mods) {
+		return nil, "", fmt.Errorf("number of paths and modules not the same: (paths: %v, modules: %v)", paths, mods)
+	}
+
+	var chMod string
+	names := make([][]string, 0, len(paths))
+	for i, p := range paths {
+		if len(p) != len(mods[i]) {
+			return nil, "", fmt.Errorf("number of paths and modules elements not the same: (paths: %v, modules: %v)", p, mods[i])
+		}
+		n := make([]string, 0, len(p))
+		prevMod := e.parentMod
+		for j, mod := range mods[i] {
+			if mod == prevMod {
+				n = append(n, p[j])
+				continue
+			}
+			n = append(n, fmt.Sprintf("%s:%s", mod, p[j]))
+			prevMod = mod
+		}
+		if chMod != "" && prevMod != chMod {
+			return nil, "", fmt.Errorf("child modules between all paths are not equal: %v", mods)
+		}
+		names = append(names, n)
+		chMod = prevMod
+	}
+	return names, chMod, nil
+}
+
+// write sets the JSON members with the supplied names to v, creating the
+// objects that contain them where required.
+func (e *RFC7951Encoder) write(names [][]string, v any) {
+	for _, n := range names {
+		parent := e.out
+		for _, k := range n[:len(n)-1] {
+			if _, ok := parent[k]; !ok {
+				parent[k] = map[string]any{}
+			}
+			parent = parent[k].(map[string]any)
+		}
+		parent[n[len(n)-1]] = v
+	}
+}
+
+// Set writes the value v of the element found at the supplied paths, which
+// are defined in the supplied modules, to the output JSON. v must be of a
+// type that is produced by encoding/json when unmarshalling JSON, and is
+// skipped if it is nil.
+func (e *RFC7951Encoder) Set(paths, mods [][]string, v any) error {
+	if v == nil {
+		return nil
+	}
+	names, _, err := e.childModule(paths, mods)
+	if err != nil {
+		return err
+	}
+	e.write(names, v)
+	return nil
+}
+
+// Struct writes the container v found at the supplied paths, which are
+// defined in the supplied modules, to the output JSON. Empty containers are
+// skipped unless presence is set.
+func (e *RFC7951Encoder) Struct(paths, mods [][]string, v GoStruct, presence bool) error {
+	names, chMod, err := e.childModule(paths, mods)
+	if err != nil {
+		return err
+	}
+	js, err := structJSON(v, chMod, e.args)
+	if err != nil {
+		return err
+	}
+	if len(js) == 0 && !presence {
+		return nil
+	}
+	e.write(names, js)
+	return nil
+}
+
+// RFC7951ListElement is an element of a keyed YANG list that is marshalled
+// by an RFC7951Encoder.
+type RFC7951ListElement struct {
+	// Key is the key of the element within the map that stores the list.
+	Key any
+	// Value is the element.
+	Value GoStruct
+}
+
+// List writes the keyed list l found at the supplied paths, which are defined
+// in the supplied modules, to the output JSON. The elements of the list are
+// output in the order of their keys.
+func (e *RFC7951Encoder) List(paths, mods [][]string, l []RFC7951ListElement) error {
+	type element struct {
+		k string
+		v GoStruct
+	}
+	elems := make([]element, 0, len(l))
+	for _, el := range l {
+		k, err := mapKeyToJSONString(reflect.ValueOf(el.Key), e.args)
+		if err != nil {
+			return err
+		}
+		elems = append(elems, element{k: k, v: el.Value})
+	}
+	slices.SortFunc(elems, func(a, b element) int { return strings.Compare(a.k, b.k) })
+
+	vals := make([]GoStruct, 0, len(elems))
+	for _, el := range elems {
+		vals = append(vals, el.v)
+	}
+	return e.OrderedList(paths, mods, vals)
+}
+
+// OrderedList writes the list l, whose elements are output in the order
+// that they are supplied, found at the supplied paths, which are defined in
+// the supplied modules, to the output JSON. It is used for unkeyed lists and
+// lists that are ordered by the user.
+func (e *RFC7951Encoder) OrderedList(paths, mods [][]string, l []GoStruct) error {
+	names, chMod, err := e.childModule(paths, mods)
+	if err != nil {
+		return err
+	}
+	vals := make([]any, 0, len(l))
+	for _, v := range l {
+		js, err := structJSON(v, chMod, e.args)
+		if err != nil {
+			return err
+		}
+		vals = append(vals, js)
+	}
+	e.write(names, vals)
+	return nil
+}
+
+// Choice writes the fields of c, which is the selected case of a YANG choice
+// within the struct being marshalled, to the output JSON.
+func (e *RFC7951Encoder) Choice(c GoChoiceCase) error {
+	js, err := structJSON(c, e.parentMod, e.args)
+	if err != nil {
+		return err
+	}
+	for k, v := range js {
+		e.out[k] = v
+	}
+	return nil
+}
+
+// Field writes the field pointed to by ptr, found at the supplied paths,
+// which are defined in the supplied modules, to the output JSON, determining
+// its value using reflection. It is used for fields whose types do not have
+// a known representation when code is generated, such as anydata and
+// annotation fields.
+func (e *RFC7951Encoder) Field(paths, mods [][]string, ptr any) error {
+	names, chMod, err := e.childModule(paths, mods)
+	if err != nil {
+		return err
+	}
+	v, err := jsonValue(reflect.ValueOf(ptr).Elem(), chMod, e.args)
+	switch {
+	case err != nil:
+		return err
+	case v == nil:
+		return nil
+	}
+	if mp, ok := v.(map[string]any); ok && len(mp) == 0 {
+		return nil
+	}
+	if v, err = normalizeJSONValue(v); err != nil {
+		return err
+	}
+	e.write(names, v)
+	return nil
+}
+
+// Enum returns the name of the value i of the enumerated type v, whose name
+// is typeName, as it is output in RFC7951 JSON. The empty string is returned
+// if the value is unset.
+func (e *RFC7951Encoder) Enum(v GoEnum, typeName string, i int64) (string, error) {
+	if i == 0 {
+		return "", nil
+	}
+	lookup, ok := v.ΛMap()[typeName]
+	if !ok {
+		return "", fmt.Errorf("cannot map enumerated value as type %s was unknown", typeName)
+	}
+	def, ok := lookup[i]
+	if !ok {
+		return "", fmt.Errorf("cannot map enumerated value as type %s has unknown value %d", typeName, i)
+	}
+	if e.prependModuleNameIref() && def.DefiningModule != "" {
+		return fmt.Sprintf("%s:%s", def.DefiningModule, def.Name), nil
+	}
+	return def.Name, nil
+}
+
+// RFC7951String returns the string s as it is output in RFC7951 JSON, where
+// each byte that is not part of a valid UTF-8 sequence is replaced by the
+// Unicode replacement character.
+func RFC7951String(s string) string {
+	if utf8.ValidString(s) {
+		return s
+	}
+	var b strings.Builder
+	for _, r := range s {
+		b.WriteRune(r)
+	}
+	return b.String()
+}
+
+// RFC7951Int64 returns the int64 value v as it is output in RFC7951 JSON.
+func RFC7951Int64(v int64) string {
+	return strconv.FormatInt(v, 10)
+}
+
+// RFC7951Uint64 returns the uint64 value v as it is output in RFC7951 JSON.
+func RFC7951Uint64(v uint64) string {
+	return strconv.FormatUint(v, 10)
+}
+
+// RFC7951Decimal64 returns the decimal64 value v as it is output in RFC7951
+// JSON.
+func RFC7951Decimal64(v float64) string {
+	return strconv.FormatFloat(v, 'g', -1, 64)
+}
+
+// RFC7951Binary returns the binary value v as it is output in RFC7951 JSON.
+func RFC7951Binary(v []byte) string {
+	return binaryBase64(v)
+}
@@ -0,0 +1,343 @@
+// Copyright 2023 Google Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package ygot
+
+import (
+	"fmt"
+	"reflect"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/openconfig/gnmi/errdiff"
+)
+
+// rfc7951Example is a struct that is marshalled to RFC7951 JSON using
+// reflection.
+type rfc7951Example struct {
+	Str      *string                          `path:"str" module:"m1"`
+	I64      *int64                           `path:"config/i64" module:"m1/m2"`
+	Enum     EnumTest                         `path:"enum" module:"m1"`
+	EnumList []EnumTest                       `path:"enum-list" module:"m1"`
+	Child    *rfc7951ExampleChild             `path:"child" module:"m2"`
+	Presence *rfc7951ExampleChild             `path:"presence" module:"m1" yangPresence:"true"`
+	List     map[string]*rfc7951ExampleList   `path:"list" module:"m1"`
+	Ordered  []*rfc7951ExampleList            `path:"ordered" module:"m1"`
+	Meta     []Annotation                     `path:"@str" ygotAnnotation:"true"`
+	Other    map[string]*rfc7951ExampleChild2 `path:"other" module:"m2"`
+}
+
+func (*rfc7951Example) IsYANGGoStruct()                         {}
+func (*rfc7951Example) ΛValidate(...ValidationOption) error     { return nil }
+func (*rfc7951Example) ΛEnumTypeMap() map[string][]reflect.Type { return nil }
+func (*rfc7951Example) ΛBelongingModule() string                { return "m1" }
+
+// rfc7951MethodsExample is a struct with the same fields as rfc7951Example
+// which implements the RFC7951Marshaler interface.
+type rfc7951MethodsExample rfc7951Example
+
+func (*rfc7951MethodsExample) IsYANGGoStruct()                         {}
+func (*rfc7951MethodsExample) ΛValidate(...ValidationOption) error     { return nil }
+func (*rfc7951MethodsExample) ΛEnumTypeMap() map[string][]reflect.Type { return nil }
+func (*rfc7951MethodsExample) ΛBelongingModule() string                { return "m1" }
+
+func (t *rfc7951MethodsExample) MarshalRFC7951(e *RFC7951Encoder) error {
+	if t.Str != nil {
+		if err := e.Set([][]string{{"str"}}, [][]string{{"m1"}}, RFC7951String(*t.Str)); err != nil {
+			return err
+		}
+	}
+	if t.I64 != nil {
+		if err := e.Set([][]string{{"config", "i64"}}, [][]string{{"m1", "m2"}}, RFC7951Int64(*t.I64)); err != nil {
+			return err
+		}
+	}
+	if t.Enum != 0 {
+		v, err := e.Enum(t.Enum, "EnumTest", int64(t.Enum))
+		if err != nil {
+			return err
+		}
+		if err := e.Set([][]string{{"enum"}}, [][]string{{"m1"}}, v); err != nil {
+			return err
+		}
+	}
+	if t.EnumList != nil {
+		l := make([]any, 0, len(t.EnumList))
+		for _, ev := range t.EnumList {
+			v, err := e.Enum(ev, "EnumTest", int64(ev))
+			if err != nil {
+				return err
+			}
+			l = append(l, v)
+		}
+		if err := e.Set([][]string{{"enum-list"}}, [][]string{{"m1"}}, l); err != nil {
+			return err
+		}
+	}
+	if t.Child != nil {
+		if err := e.Struct([][]string{{"child"}}, [][]string{{"m2"}}, t.Child, false); err != nil {
+			return err
+		}
+	}
+	if t.Presence != nil {
+		if err := e.Struct([][]string{{"presence"}}, [][]string{{"m1"}}, t.Presence, true); err != nil {
+			return err
+		}
+	}
+	if t.List != nil {
+		l := make([]RFC7951ListElement, 0, len(t.List))
+		for k, v := range t.List {
+			l = append(l, RFC7951ListElement{Key: k, Value: v})
+		}
+		if err := e.List([][]string{{"list"}}, [][]string{{"m1"}}, l); err != nil {
+			return err
+		}
+	}
+	if t.Ordered != nil {
+		l := make([]GoStruct, 0, len(t.Ordered))
+		for _, v := range t.Ordered {
+			l = append(l, v)
+		}
+		if err := e.OrderedList([][]string{{"ordered"}}, [][]string{{"m1"}}, l); err != nil {
+			return err
+		}
+	}
+	if t.Meta != nil {
+		if err := e.Field([][]string{{"@str"}}, nil, &t.Meta); err != nil {
+			return err
+		}
+	}
+	if t.Other != nil {
+		if err := e.Field([][]string{{"other"}}, [][]string{{"m2"}}, &t.Other); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+type rfc7951ExampleChild struct {
+	Val *uint32 `path:"val" module:"m2"`
+}
+
+func (*rfc7951ExampleChild) IsYANGGoStruct()                         {}
+func (*rfc7951ExampleChild) ΛValidate(...ValidationOption) error     { return nil }
+func (*rfc7951ExampleChild) ΛEnumTypeMap() map[string][]reflect.Type { return nil }
+func (*rfc7951ExampleChild) ΛBelongingModule() string                { return "m2" }
+
+type rfc7951ExampleChild2 struct {
+	Name *string `path:"name" module:"m2"`
+}
+
+func (*rfc7951ExampleChild2) IsYANGGoStruct()                         {}
+func (*rfc7951ExampleChild2) ΛValidate(...ValidationOption) error     { return nil }
+func (*rfc7951ExampleChild2) ΛEnumTypeMap() map[string][]reflect.Type { return nil }
+func (*rfc7951ExampleChild2) ΛBelongingModule() string                { return "m2" }
+
+type rfc7951ExampleList struct {
+	Name  *string  `path:"name" module:"m1"`
+	Value *float64 `path:"value" module:"m1"`
+}
+
+func (*rfc7951ExampleList) IsYANGGoStruct()                         {}
+func (*rfc7951ExampleList) ΛValidate(...ValidationOption) error     { return nil }
+func (*rfc7951ExampleList) ΛEnumTypeMap() map[string][]reflect.Type { return nil }
+func (*rfc7951ExampleList) ΛBelongingModule() string                { return "m1" }
+
+// rfc7951ErrorExample is a struct whose RFC7951Marshaler implementation
+// always returns an error.
+type rfc7951ErrorExample struct {
+	Str *string `path:"str" module:"m1"`
+}
+
+func (*rfc7951ErrorExample) IsYANGGoStruct()                         {}
+func (*rfc7951ErrorExample) ΛValidate(...ValidationOption) error     { return nil }
+func (*rfc7951ErrorExample) ΛEnumTypeMap() map[string][]reflect.Type { return nil }
+func (*rfc7951ErrorExample) ΛBelongingModule() string                { return "m1" }
+
+func (*rfc7951ErrorExample) MarshalRFC7951(*RFC7951Encoder) error {
+	return fmt.Errorf("MarshalRFC7951 called")
+}
+
+func TestRFC7951Marshaler(t *testing.T) {
+	tests := []struct {
+		name string
+		in   *rfc7951Example
+	}{{
+		name: "empty struct",
+		in:   &rfc7951Example{},
+	}, {
+		name: "leaves",
+		in: &rfc7951Example{
+			Str:  String("a\xffb"),
+			I64:  Int64(-42),
+			Enum: EnumTestVALTWO,
+		},
+	}, {
+		name: "leaf-list of enumerated values",
+		in: &rfc7951Example{
+			EnumList: []EnumTest{EnumTestVALONE, EnumTestVALTWO},
+		},
+	}, {
+		name: "empty containers",
+		in: &rfc7951Example{
+			Child:    &rfc7951ExampleChild{},
+			Presence: &rfc7951ExampleChild{},
+		},
+	}, {
+		name: "populated containers",
+		in: &rfc7951Example{
+			Child:    &rfc7951ExampleChild{Val: Uint32(42)},
+			Presence: &rfc7951ExampleChild{Val: Uint32(84)},
+		},
+	}, {
+		name: "lists",
+		in: &rfc7951Example{
+			List: map[string]*rfc7951ExampleList{
+				"zz": {Name: String("zz"), Value: Float64(1.5)},
+				"aa": {Name: String("aa")},
+				"mm": {Name: String("mm")},
+			},
+			Ordered: []*rfc7951ExampleList{
+				{Name: String("zz")},
+				{Name: String("aa")},
+			},
+		},
+	}, {
+		name: "empty lists",
+		in: &rfc7951Example{
+			List:    map[string]*rfc7951ExampleList{},
+			Ordered: []*rfc7951ExampleList{},
+		},
+	}, {
+		name: "fields marshalled using reflection",
+		in: &rfc7951Example{
+			Str: String("a"),
+			Meta: []Annotation{
+				&testAnnotation{AnnotationFieldOne: "one"},
+			},
+			Other: map[string]*rfc7951ExampleChild2{
+				"b": {Name: String("b")},
+				"a": {Name: String("a")},
+			},
+		},
+	}}
+
+	cfgs := []*RFC7951JSONConfig{
+		nil,
+		{AppendModuleName: true},
+		{PrependModuleNameIdentityref: true},
+	}
+
+	for _, tt := range tests {
+		for _, cfg := range cfgs {
+			t.Run(fmt.Sprintf("%s with %+v", tt.name, cfg), func(t *testing.T) {
+				want, err := ConstructIETFJSON(tt.in, cfg)
+				if err != nil {
+					t.Fatalf("ConstructIETFJSON(%#v): cannot marshal reflective struct, %v", tt.in, err)
+				}
+				got, err := ConstructIETFJSON((*rfc7951MethodsExample)(tt.in), cfg)
+				if err != nil {
+					t.Fatalf("ConstructIETFJSON(%#v): cannot marshal struct with methods, %v", tt.in, err)
+				}
+				if diff := cmp.Diff(want, got); diff != "" {
+					t.Errorf("ConstructIETFJSON(%#v): did not get expected output, diff(-reflect, +methods):\n%s", tt.in, diff)
+				}
+			})
+		}
+	}
+}
+
+func TestRFC7951MarshalerUsed(t *testing.T) {
+	tests := []struct {
+		name             string
+		inFunc           func(GoStruct) (map[string]any, error)
+		wantErrSubstring string
+	}{{
+		name:             "IETF JSON",
+		inFunc:           func(s GoStruct) (map[string]any, error) { return ConstructIETFJSON(s, nil) },
+		wantErrSubstring: "MarshalRFC7951 called",
+	}, {
+		name: "IETF JSON appending module names",
+		inFunc: func(s GoStruct) (map[string]any, error) {
+			return ConstructIETFJSON(s, &RFC7951JSONConfig{AppendModuleName: true})
+		},
+		wantErrSubstring: "MarshalRFC7951 called",
+	}, {
+		name: "IETF JSON preferring shadow paths",
+		inFunc: func(s GoStruct) (map[string]any, error) {
+			return ConstructIETFJSON(s, &RFC7951JSONConfig{PreferShadowPath: true})
+		},
+	}, {
+		name: "IETF JSON rewriting module names",
+		inFunc: func(s GoStruct) (map[string]any, error) {
+			return ConstructIETFJSON(s, &RFC7951JSONConfig{RewriteModuleNames: map[string]string{"m1": "m2"}})
+		},
+	}, {
+		name:   "internal JSON",
+		inFunc: func(s GoStruct) (map[string]any, error) { return ConstructInternalJSON(s) },
+	}}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := tt.inFunc(&rfc7951ErrorExample{Str: String("a")})
+			if diff := errdiff.Substring(err, tt.wantErrSubstring); diff != "" {
+				t.Fatalf("did not get expected error, %s", diff)
+			}
+		})
+	}
+}
+
+func TestRFC7951Scalars(t *testing.T) {
+	tests := []struct {
+		name string
+		got  string
+		want string
+	}{{
+		name: "valid string",
+		got:  RFC7951String("abc"),
+		want: "abc",
+	}, {
+		name: "string with invalid UTF-8",
+		got:  RFC7951String("a\xff\xfeb"),
+		want: "a��b",
+	}, {
+		name: "int64",
+		got:  RFC7951Int64(-9223372036854775808),
+		want: "-9223372036854775808",
+	}, {
+		name: "uint64",
+		got:  RFC7951Uint64(18446744073709551615),
+		want: "18446744073709551615",
+	}, {
+		name: "decimal64",
+		got:  RFC7951Decimal64(3.14),
+		want: "3.14",
+	}, {
+		name: "large decimal64",
+		got:  RFC7951Decimal64(1e21),
+		want: "1e+21",
+	}, {
+		name: "binary",
+		got:  RFC7951Binary([]byte("abc")),
+		want: "YWJj",
+	}}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if tt.got != tt.want {
+				t.Errorf("got %q, want %q", tt.got, tt.want)
+			}
+		})
+	}
+}
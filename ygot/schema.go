@@ -63,4 +63,13 @@ func rebuildSchemaMap(e, parent *yang.Entry, schema map[string]*yang.Entry) {
 	for _, ch := range e.Dir {
 		rebuildSchemaMap(ch, e, schema)
 	}
+	// The input and output of RPCs and actions are not stored within the
+	// Dir of the entry, and hence must be traversed separately.
+	if e.RPC != nil {
+		for _, ch := range []*yang.Entry{e.RPC.Input, e.RPC.Output} {
+			if ch != nil {
+				rebuildSchemaMap(ch, e, schema)
+			}
+		}
+	}
 }
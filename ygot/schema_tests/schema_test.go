@@ -34,6 +34,7 @@ import (
 	"github.com/openconfig/ygot/testutil"
 	"github.com/openconfig/ygot/uexampleoc"
 	"github.com/openconfig/ygot/ygot"
+	"github.com/openconfig/ygot/ytypes"
 	"google.golang.org/protobuf/encoding/prototext"
 
 	gnmipb "github.com/openconfig/gnmi/proto/gnmi"
@@ -715,3 +716,28 @@ func BenchmarkNotificationOutputElement(b *testing.B) {
 		}
 	}
 }
+
+func TestCBORRoundTrip(t *testing.T) {
+	d := &exampleoc.Device{}
+	b := d.GetOrCreateNetworkInstance("DEFAULT").GetOrCreateProtocol(exampleoc.PolicyTypes_INSTALL_PROTOCOL_TYPE_BGP, "15169").GetOrCreateBgp()
+	n := b.GetOrCreateNeighbor("192.0.2.1")
+	n.PeerAs = ygot.Uint32(29636)
+	n.PeerType = exampleoc.BgpTypes_PeerType_EXTERNAL
+	n.SendCommunity = exampleoc.BgpTypes_CommunityType_STANDARD
+	i := d.GetOrCreateInterface("eth0")
+	i.Description = ygot.String("foo")
+	i.Mtu = ygot.Uint16(1500)
+	i.Enabled = ygot.Bool(true)
+
+	tv, err := ygot.EncodeCBORTypedValue(d, nil)
+	if err != nil {
+		t.Fatalf("EncodeCBORTypedValue: %v", err)
+	}
+	got := &exampleoc.Device{}
+	if err := ytypes.UnmarshalCBORTypedValue(exampleoc.SchemaTree["Device"], got, tv, nil); err != nil {
+		t.Fatalf("UnmarshalCBORTypedValue: %v", err)
+	}
+	if diff := cmp.Diff(d, got); diff != "" {
+		t.Errorf("did not get expected struct after CBOR round trip, diff(-want, +got):\n%s", diff)
+	}
+}
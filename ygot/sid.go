@@ -0,0 +1,226 @@
+// Copyright 2024 Google Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package ygot
+
+import (
+	"encoding/json"
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+// SIDMap stores the YANG Schema Item iDentifiers (SIDs), defined in RFC9595,
+// that are assigned to data nodes and identities. It is used to encode and
+// decode YANG-CBOR using SID-based identifiers.
+type SIDMap struct {
+	// dataSIDs maps the qualified schema path of a data node, in which
+	// each element is prefixed by the name of its module, to its SID.
+	dataSIDs map[string]uint64
+	// unqualifiedSIDs maps the schema path of a data node without module
+	// prefixes to its SID. ambiguous stores the unqualified paths that
+	// correspond to more than one data node.
+	unqualifiedSIDs map[string]uint64
+	ambiguous       map[string]bool
+	// identitySIDs maps the module-qualified name of an identity to its SID.
+	identitySIDs map[string]uint64
+	// sidToData and sidToIdentity are the reverse mappings of dataSIDs and
+	// identitySIDs.
+	sidToData     map[uint64]string
+	sidToIdentity map[uint64]string
+}
+
+// NewSIDMap returns an empty SIDMap.
+func NewSIDMap() *SIDMap {
+	return &SIDMap{
+		dataSIDs:        map[string]uint64{},
+		unqualifiedSIDs: map[string]uint64{},
+		ambiguous:       map[string]bool{},
+		identitySIDs:    map[string]uint64{},
+		sidToData:       map[uint64]string{},
+		sidToIdentity:   map[uint64]string{},
+	}
+}
+
+// sidFile is the JSON representation of a ".sid" file, as defined by the
+// ietf-sid-file YANG module in RFC9595.
+type sidFile struct {
+	ModuleName string    `json:"module-name"`
+	Items      []sidItem `json:"item"`
+	// LegacyItems is the name of the item list used by earlier drafts
+	// of RFC9595.
+	LegacyItems []sidItem `json:"items"`
+}
+
+// sidItem is an entry of the item list of a ".sid" file.
+type sidItem struct {
+	Namespace  string          `json:"namespace"`
+	Identifier string          `json:"identifier"`
+	SID        json.RawMessage `json:"sid"`
+}
+
+// LoadSIDFile adds the SIDs within the supplied ".sid" file, as defined by
+// RFC9595, to the SIDMap. The file may be wrapped in the
+// "ietf-sid-file:sid-file" container, as specified by RFC9595, or be
+// unwrapped as generated by earlier tools. Only items within the "data" and
+// "identity" namespaces are loaded.
+func (m *SIDMap) LoadSIDFile(b []byte) error {
+	var wrapped map[string]json.RawMessage
+	if err := json.Unmarshal(b, &wrapped); err != nil {
+		return fmt.Errorf("cannot parse SID file: %v", err)
+	}
+	if c, ok := wrapped["ietf-sid-file:sid-file"]; ok {
+		b = c
+	}
+
+	var f sidFile
+	if err := json.Unmarshal(b, &f); err != nil {
+		return fmt.Errorf("cannot parse SID file: %v", err)
+	}
+
+	for _, it := range append(f.Items, f.LegacyItems...) {
+		sid, err := parseSID(it.SID)
+		if err != nil {
+			return fmt.Errorf("invalid SID for item %s: %v", it.Identifier, err)
+		}
+		switch it.Namespace {
+		case "data":
+			if err := m.AddDataSID(it.Identifier, sid); err != nil {
+				return err
+			}
+		case "identity":
+			mod, name := f.ModuleName, it.Identifier
+			if i := strings.Index(name, ":"); i != -1 {
+				mod, name = name[:i], name[i+1:]
+			}
+			if err := m.AddIdentitySID(mod, name, sid); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+// parseSID parses a SID, which may be encoded as a JSON number, or as a JSON
+// string per the RFC7951 encoding of uint64 values.
+func parseSID(raw json.RawMessage) (uint64, error) {
+	var s string
+	if err := json.Unmarshal(raw, &s); err != nil {
+		s = string(raw)
+	}
+	return strconv.ParseUint(s, 10, 64)
+}
+
+// AddDataSID adds the SID for the data node at the supplied schema path to
+// the SIDMap. The path must be absolute, and its first element must be
+// prefixed by the name of the module that defines it, as used for data node
+// identifiers within a ".sid" file - for example,
+// "/ietf-system:system/clock". Subsequent elements that are not prefixed
+// are defined by the same module as their parent.
+func (m *SIDMap) AddDataSID(path string, sid uint64) error {
+	qualified, unqualified, err := qualifySchemaPath(path)
+	if err != nil {
+		return err
+	}
+	if p, ok := m.sidToData[sid]; ok && p != qualified {
+		return fmt.Errorf("SID %d is assigned to both %s and %s", sid, p, qualified)
+	}
+	m.dataSIDs[qualified] = sid
+	m.sidToData[sid] = qualified
+	if s, ok := m.unqualifiedSIDs[unqualified]; ok && s != sid {
+		m.ambiguous[unqualified] = true
+	}
+	m.unqualifiedSIDs[unqualified] = sid
+	return nil
+}
+
+// AddIdentitySID adds the SID for the identity with the supplied name,
+// defined by the supplied module, to the SIDMap.
+func (m *SIDMap) AddIdentitySID(module, name string, sid uint64) error {
+	id := fmt.Sprintf("%s:%s", module, name)
+	if i, ok := m.sidToIdentity[sid]; ok && i != id {
+		return fmt.Errorf("SID %d is assigned to both %s and %s", sid, i, id)
+	}
+	m.identitySIDs[id] = sid
+	m.sidToIdentity[sid] = id
+	return nil
+}
+
+// DataSID returns the SID of the data node at the supplied schema path. The
+// path may be qualified with module names, in the form accepted by
+// AddDataSID, or have no module prefixes, in which case it must correspond
+// to a single data node. It returns false if no SID is found.
+func (m *SIDMap) DataSID(path string) (uint64, bool) {
+	if !strings.Contains(path, ":") {
+		if m.ambiguous[path] {
+			return 0, false
+		}
+		sid, ok := m.unqualifiedSIDs[path]
+		return sid, ok
+	}
+	qualified, _, err := qualifySchemaPath(path)
+	if err != nil {
+		return 0, false
+	}
+	sid, ok := m.dataSIDs[qualified]
+	return sid, ok
+}
+
+// DataPath returns the schema path of the data node with the supplied SID,
+// with each element prefixed by the name of its module.
+func (m *SIDMap) DataPath(sid uint64) (string, bool) {
+	p, ok := m.sidToData[sid]
+	return p, ok
+}
+
+// IdentitySID returns the SID of the identity with the supplied name,
+// defined by the supplied module. It returns false if no SID is found.
+func (m *SIDMap) IdentitySID(module, name string) (uint64, bool) {
+	sid, ok := m.identitySIDs[fmt.Sprintf("%s:%s", module, name)]
+	return sid, ok
+}
+
+// Identity returns the module and name of the identity with the supplied
+// SID. It returns false if no identity is found.
+func (m *SIDMap) Identity(sid uint64) (string, string, bool) {
+	id, ok := m.sidToIdentity[sid]
+	if !ok {
+		return "", "", false
+	}
+	i := strings.Index(id, ":")
+	return id[:i], id[i+1:], true
+}
+
+// qualifySchemaPath returns the supplied absolute schema path with each
+// element prefixed by the name of its module, and the path with no module
+// prefixes.
+func qualifySchemaPath(path string) (string, string, error) {
+	if !strings.HasPrefix(path, "/") {
+		return "", "", fmt.Errorf("schema path %q is not absolute", path)
+	}
+	var q, u strings.Builder
+	var mod string
+	for _, e := range strings.Split(path[1:], "/") {
+		name := e
+		if i := strings.Index(e, ":"); i != -1 {
+			mod, name = e[:i], e[i+1:]
+		}
+		if mod == "" || name == "" {
+			return "", "", fmt.Errorf("invalid schema path %q, element %q has no module", path, e)
+		}
+		fmt.Fprintf(&q, "/%s:%s", mod, name)
+		fmt.Fprintf(&u, "/%s", name)
+	}
+	return q.String(), u.String(), nil
+}
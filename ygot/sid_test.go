@@ -0,0 +1,135 @@
+// Copyright 2024 Google Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package ygot
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/openconfig/gnmi/errdiff"
+)
+
+func TestLoadSIDFile(t *testing.T) {
+	tests := []struct {
+		desc             string
+		in               string
+		wantData         map[string]uint64
+		wantIdentities   map[string]uint64
+		wantErrSubstring string
+	}{{
+		desc: "RFC9595 format",
+		in: `{
+  "ietf-sid-file:sid-file": {
+    "module-name": "ietf-system",
+    "module-revision": "2014-08-06",
+    "assignment-range": [{"entry-point": "1700", "size": "100"}],
+    "item": [
+      {"namespace": "module", "identifier": "ietf-system", "sid": "1700"},
+      {"namespace": "identity", "identifier": "radius", "sid": "1703"},
+      {"namespace": "data", "identifier": "/ietf-system:system", "sid": "1717"},
+      {"namespace": "data", "identifier": "/ietf-system:system/clock", "sid": "1718"},
+      {"namespace": "data", "identifier": "/ietf-system:system/clock/timezone-name", "sid": "1719"}
+    ]
+  }
+}`,
+		wantData: map[string]uint64{
+			"/ietf-system:system":                                 1717,
+			"/ietf-system:system/ietf-system:clock":               1718,
+			"/system/clock/timezone-name":                         1719,
+			"/ietf-system:system/clock/ietf-system:timezone-name": 1719,
+		},
+		wantIdentities: map[string]uint64{
+			"ietf-system:radius": 1703,
+		},
+	}, {
+		desc: "legacy format",
+		in: `{
+  "module-name": "m",
+  "items": [
+    {"namespace": "identity", "identifier": "other:ID", "sid": 10},
+    {"namespace": "data", "identifier": "/m:a/n:b", "sid": 12}
+  ]
+}`,
+		wantData: map[string]uint64{
+			"/m:a/n:b": 12,
+			"/a/b":     12,
+		},
+		wantIdentities: map[string]uint64{
+			"other:ID": 10,
+		},
+	}, {
+		desc:             "invalid JSON",
+		in:               `{`,
+		wantErrSubstring: "cannot parse SID file",
+	}, {
+		desc:             "invalid SID",
+		in:               `{"item": [{"namespace": "data", "identifier": "/m:a", "sid": "x"}]}`,
+		wantErrSubstring: "invalid SID for item /m:a",
+	}, {
+		desc:             "unqualified data identifier",
+		in:               `{"item": [{"namespace": "data", "identifier": "/a", "sid": 1}]}`,
+		wantErrSubstring: `element "a" has no module`,
+	}, {
+		desc:             "duplicate SID",
+		in:               `{"item": [{"namespace": "data", "identifier": "/m:a", "sid": 1}, {"namespace": "data", "identifier": "/m:b", "sid": 1}]}`,
+		wantErrSubstring: "SID 1 is assigned to both /m:a and /m:b",
+	}}
+
+	for _, tt := range tests {
+		t.Run(tt.desc, func(t *testing.T) {
+			m := NewSIDMap()
+			err := m.LoadSIDFile([]byte(tt.in))
+			if diff := errdiff.Substring(err, tt.wantErrSubstring); diff != "" {
+				t.Fatalf("LoadSIDFile: did not get expected error, %s", diff)
+			}
+			for p, want := range tt.wantData {
+				got, ok := m.DataSID(p)
+				if !ok || got != want {
+					t.Errorf("DataSID(%s): got (%d, %v), want %d", p, got, ok, want)
+				}
+				if rp, ok := m.DataPath(want); !ok || rp == "" {
+					t.Errorf("DataPath(%d): got (%s, %v), want path", want, rp, ok)
+				}
+			}
+			for id, want := range tt.wantIdentities {
+				mod, name, _ := strings.Cut(id, ":")
+				got, ok := m.IdentitySID(mod, name)
+				if !ok || got != want {
+					t.Errorf("IdentitySID(%s, %s): got (%d, %v), want %d", mod, name, got, ok, want)
+				}
+				gotMod, gotName, ok := m.Identity(want)
+				if !ok || gotMod != mod || gotName != name {
+					t.Errorf("Identity(%d): got (%s, %s, %v), want (%s, %s)", want, gotMod, gotName, ok, mod, name)
+				}
+			}
+		})
+	}
+}
+
+func TestSIDMapAmbiguousPath(t *testing.T) {
+	m := NewSIDMap()
+	if err := m.AddDataSID("/a:x/y", 1); err != nil {
+		t.Fatalf("AddDataSID: %v", err)
+	}
+	if err := m.AddDataSID("/a:x/b:y", 2); err != nil {
+		t.Fatalf("AddDataSID: %v", err)
+	}
+	if got, ok := m.DataSID("/x/y"); ok {
+		t.Errorf("DataSID(/x/y): got %d for ambiguous path, want not found", got)
+	}
+	if got, ok := m.DataSID("/a:x/b:y"); !ok || got != 2 {
+		t.Errorf("DataSID(/a:x/b:y): got (%d, %v), want 2", got, ok)
+	}
+}
@@ -212,8 +212,19 @@ func initialiseTree(t reflect.Type, v reflect.Value) {
 		fVal := v.Field(i)
 		fType := t.Field(i)
 
+		if util.IsChoiceField(fType) {
+			// A case of a choice is never selected implicitly, hence only
+			// the selected case is initialised.
+			if !fVal.IsNil() {
+				cv := fVal.Elem().Elem()
+				initialiseTree(cv.Type(), cv)
+			}
+			continue
+		}
+
 		_, isOrderedMap := fVal.Interface().(GoOrderedMap)
-		if !isOrderedMap && util.IsTypeStructPtr(fType.Type) {
+		_, isAnyData := fVal.Interface().(*AnyData)
+		if !isOrderedMap && !isAnyData && util.IsTypeStructPtr(fType.Type) {
 			// Only initialise nested struct pointers, since all struct fields within
 			// a GoStruct are expected to be pointers, and we do not want to initialise
 			// non-struct values. If the struct pointer is not nil, it is skipped.
@@ -254,6 +265,20 @@ func pruneBranchesInternal(t reflect.Type, v reflect.Value) bool {
 	for i := 0; i < v.NumField(); i++ {
 		fVal := v.Field(i)
 		fType := t.Field(i)
+		if util.IsChoiceField(fType) {
+			if fVal.IsNil() {
+				continue
+			}
+			// The selected case of a choice is removed if all of its
+			// fields are pruned.
+			cv := fVal.Elem().Elem()
+			if pruneBranchesInternal(cv.Type(), cv) {
+				fVal.Set(reflect.Zero(fType.Type))
+			} else {
+				allChildrenPruned = false
+			}
+			continue
+		}
 		if util.IsTypeStructPtr(fType.Type) {
 			// Create an empty version of the struct that is within the struct pointer.
 			// We can safely call Elem() here since we verified above that this type
@@ -624,6 +649,14 @@ func deepCopy(s GoStruct, keepEmptyMaps bool) (GoStruct, error) {
 	if util.IsNilOrInvalidValue(reflect.ValueOf(s)) {
 		return nil, fmt.Errorf("invalid input to DeepCopy, got nil value: %v", s)
 	}
+	// The generated copy methods do not retain empty maps.
+	if c, ok := s.(GoStructCopier); ok && !keepEmptyMaps {
+		n, err := c.ΛDeepCopy()
+		if err != nil {
+			return nil, fmt.Errorf("cannot DeepCopy struct: %v", err)
+		}
+		return n, nil
+	}
 	n := reflect.New(reflect.TypeOf(s).Elem())
 	var opts []MergeOpt
 	if keepEmptyMaps {
@@ -678,44 +711,55 @@ func copyStruct(dstVal, srcVal reflect.Value, accessPath string, opts ...MergeOp
 	var errs errlist.Error
 	errs.Separator = "\n"
 	for i := 0; i < srcVal.NumField(); i++ {
-		srcField := srcVal.Field(i)
-		dstField := dstVal.Field(i)
-		accessPath := accessPath + "." + srcVal.Type().Field(i).Name
-
-		orderedMap, isOrderedMap := srcField.Interface().(GoOrderedMap)
-		switch srcField.Kind() {
-		case reflect.Ptr:
-			if isOrderedMap {
-				errs.Add(copyOrderedMap(dstField, orderedMap, accessPath, opts...))
-			} else {
-				errs.Add(copyPtrField(dstField, srcField, accessPath, opts...))
-			}
-		case reflect.Interface:
-			errs.Add(copyInterfaceField(dstField, srcField, accessPath, opts...))
-		case reflect.Map:
-			errs.Add(copyMapField(dstField, srcField, accessPath, opts...))
-		case reflect.Slice:
-			errs.Add(copySliceField(dstField, srcField, accessPath, opts...))
-		case reflect.Int64:
-			// In the case of an int64 field, which represents a YANG enumeration
-			// we should only set the value in the destination if it is not set
-			// to the default value in the source.
-			vSrc, vDst := srcField.Int(), dstField.Int()
-			switch {
-			case vSrc != 0 && vDst != 0 && vSrc != vDst:
-				if !fieldOverwriteEnabled(opts) {
-					errs.Add(fmt.Errorf("%s: destination and source values were set when merging enum field, dst: %d, src: %d", accessPath, vSrc, vDst))
-					break
-				}
-				dstField.Set(srcField)
-			case vSrc != 0 && vDst == 0:
-				dstField.Set(srcField)
-			}
+		sf := srcVal.Type().Field(i)
+		errs.Add(copyField(dstVal.Field(i), srcVal.Field(i), util.IsChoiceField(sf), accessPath+"."+sf.Name, opts...))
+	}
+	return errs.Err()
+}
+
+// copyField copies srcField, which is a field of a GoStruct, into dstField.
+// isChoice indicates that the field stores a YANG choice that is generated as
+// a sum type.
+func copyField(dstField, srcField reflect.Value, isChoice bool, accessPath string, opts ...MergeOpt) error {
+	orderedMap, isOrderedMap := srcField.Interface().(GoOrderedMap)
+	anyData, isAnyData := srcField.Interface().(*AnyData)
+	switch srcField.Kind() {
+	case reflect.Ptr:
+		switch {
+		case isOrderedMap:
+			return copyOrderedMap(dstField, orderedMap, accessPath, opts...)
+		case isAnyData:
+			return copyAnyDataField(dstField, anyData, accessPath, opts...)
 		default:
+			return copyPtrField(dstField, srcField, accessPath, opts...)
+		}
+	case reflect.Interface:
+		if isChoice {
+			return copyChoiceField(dstField, srcField, accessPath, opts...)
+		}
+		return copyInterfaceField(dstField, srcField, accessPath, opts...)
+	case reflect.Map:
+		return copyMapField(dstField, srcField, accessPath, opts...)
+	case reflect.Slice:
+		return copySliceField(dstField, srcField, accessPath, opts...)
+	case reflect.Int64:
+		// In the case of an int64 field, which represents a YANG enumeration
+		// we should only set the value in the destination if it is not set
+		// to the default value in the source.
+		vSrc, vDst := srcField.Int(), dstField.Int()
+		switch {
+		case vSrc != 0 && vDst != 0 && vSrc != vDst:
+			if !fieldOverwriteEnabled(opts) {
+				return fmt.Errorf("%s: destination and source values were set when merging enum field, dst: %d, src: %d", accessPath, vSrc, vDst)
+			}
+			dstField.Set(srcField)
+		case vSrc != 0 && vDst == 0:
 			dstField.Set(srcField)
 		}
+	default:
+		dstField.Set(srcField)
 	}
-	return errs.Err()
+	return nil
 }
 
 // copyPtrField copies srcField to dstField. srcField and dstField must be
@@ -768,6 +812,69 @@ func copyPtrField(dstField, srcField reflect.Value, accessPath string, opts ...M
 	return nil
 }
 
+// copyChoiceField copies the selected case of the YANG choice stored in
+// srcField into dstField. If the same case is selected in dstField, then the
+// contents of the cases are merged. If a different case is selected in
+// dstField, an error is returned, unless overwriting existing fields is
+// enabled, in which case the case in dstField is replaced.
+func copyChoiceField(dstField, srcField reflect.Value, accessPath string, opts ...MergeOpt) error {
+	if util.IsNilOrInvalidValue(srcField) {
+		return nil
+	}
+
+	s := srcField.Elem()
+	if !util.IsValueStructPtr(s) {
+		return fmt.Errorf("%s: choice field contains non-struct ptr type %T", accessPath, s.Interface())
+	}
+
+	d := reflect.New(s.Type().Elem())
+	if !util.IsNilOrInvalidValue(dstField) {
+		switch {
+		case dstField.Elem().Type() == s.Type():
+			d = dstField.Elem()
+		case !fieldOverwriteEnabled(opts):
+			return fmt.Errorf("%s: different cases of choice were selected in src and dst, src: %T, dst: %T", accessPath, s.Interface(), dstField.Elem().Interface())
+		}
+	}
+
+	if err := copyStruct(d.Elem(), s.Elem(), accessPath, opts...); err != nil {
+		return err
+	}
+	dstField.Set(d)
+	return nil
+}
+
+// copyAnyDataField copies the contents of the anydata or anyxml node src into
+// dstField, which must be a reflect.Value containing a *AnyData. Since the
+// contents of such nodes are not described by the schema, they are not merged,
+// and an error is returned if dstField is populated with different contents,
+// unless overwriting existing fields is enabled.
+func copyAnyDataField(dstField reflect.Value, src *AnyData, accessPath string, opts ...MergeOpt) error {
+	if src == nil {
+		return nil
+	}
+
+	if !util.IsNilOrInvalidValue(dstField) {
+		if d := dstField.Interface().(*AnyData); !fieldOverwriteEnabled(opts) && !reflect.DeepEqual(src, d) {
+			return fmt.Errorf("%s: destination and source anydata were set but were not equal, src: %v, dst: %v", accessPath, src, d)
+		}
+	}
+
+	d := &AnyData{}
+	if src.JSON != nil {
+		d.JSON = append(json.RawMessage{}, src.JSON...)
+	}
+	if !util.IsValueNil(src.Value) {
+		v, err := DeepCopy(src.Value)
+		if err != nil {
+			return fmt.Errorf("%s: cannot copy anydata contents, %v", accessPath, err)
+		}
+		d.Value = v
+	}
+	dstField.Set(reflect.ValueOf(d))
+	return nil
+}
+
 // copyInterfaceField copies srcField into dstField. Both srcField and dstField
 // are reflect.Value structs which contain an interface value.
 func copyInterfaceField(dstField, srcField reflect.Value, accessPath string, opts ...MergeOpt) error {
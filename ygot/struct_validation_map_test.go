@@ -552,6 +552,26 @@ func TestPruneEmptyBranches(t *testing.T) {
 		name:     "struct with no children",
 		inStruct: &emptyBranchTestOne{},
 		want:     &emptyBranchTestOne{},
+	}, {
+		name: "choice with empty case",
+		inStruct: &choiceExample{
+			Name:     String("foo"),
+			Protocol: &choiceExampleTCP{Child: &choiceExampleTCPChild{}},
+		},
+		want: &choiceExample{
+			Name: String("foo"),
+		},
+	}, {
+		name: "choice with populated case",
+		inStruct: &choiceExample{
+			Protocol: &choiceExampleTCP{
+				Port:  Uint16(42),
+				Child: &choiceExampleTCPChild{},
+			},
+		},
+		want: &choiceExample{
+			Protocol: &choiceExampleTCP{Port: Uint16(42)},
+		},
 	}, {
 		name: "struct with empty child",
 		inStruct: &emptyBranchTestOne{
@@ -2154,6 +2174,53 @@ var mergeStructTests = []struct {
 	want: &validatedMergeTest{
 		UnionField: &copyUnionI{42},
 	},
+}, {
+	name: "merge choice: same case selected",
+	inA: &choiceExample{
+		Protocol: &choiceExampleTCP{Port: Uint16(42)},
+	},
+	inB: &choiceExample{
+		Protocol: &choiceExampleTCP{Child: &choiceExampleTCPChild{Window: Uint32(128)}},
+	},
+	want: &choiceExample{
+		Protocol: &choiceExampleTCP{
+			Port:  Uint16(42),
+			Child: &choiceExampleTCPChild{Window: Uint32(128)},
+		},
+	},
+}, {
+	name: "merge choice: case selected only in src",
+	inA:  &choiceExample{Name: String("foo")},
+	inB: &choiceExample{
+		Protocol: &choiceExampleUDP{Port: Uint16(42)},
+	},
+	want: &choiceExample{
+		Name:     String("foo"),
+		Protocol: &choiceExampleUDP{Port: Uint16(42)},
+	},
+}, {
+	name: "merge choice: different cases selected",
+	inA: &choiceExample{
+		Protocol: &choiceExampleTCP{Port: Uint16(42)},
+	},
+	inB: &choiceExample{
+		Protocol: &choiceExampleUDP{Port: Uint16(42)},
+	},
+	wantErr: "different cases of choice were selected",
+}, {
+	name: "overwrite merge choice: different cases selected",
+	inA: &choiceExample{
+		Protocol: &choiceExampleTCP{Port: Uint16(42)},
+	},
+	inB: &choiceExample{
+		Protocol: &choiceExampleUDP{Port: Uint16(43)},
+	},
+	inOpts: []MergeOpt{
+		&MergeOverwriteExistingFields{},
+	},
+	want: &choiceExample{
+		Protocol: &choiceExampleUDP{Port: Uint16(43)},
+	},
 }}
 
 func TestMergeStructs(t *testing.T) {
@@ -15,6 +15,7 @@
 package ygot
 
 import (
+	"encoding/json"
 	"fmt"
 	"reflect"
 )
@@ -124,6 +125,39 @@ type GoKeyStruct interface {
 	ΛListKeyMap() (map[string]interface{}, error)
 }
 
+// GoChoiceCase is an interface which is implemented by the structs that are
+// generated to represent the cases of a YANG choice, when choices are
+// generated as sum types. Each choice is represented by a field of an
+// interface type, which is implemented by the struct for each of its cases,
+// such that only one case of the choice can be selected. For example:
+//
+//	type GoStructExample struct {
+//	   Protocol GoStructExample_Protocol_Choice
+//	}
+//
+//	type GoStructExample_Protocol_Choice_Tcp struct {
+//	   Port *uint16 `path:"port"`
+//	}
+//
+// The fields of the struct for a case are data tree children of the struct
+// containing the choice, and hence the field storing the choice does not have
+// a path tag.
+type GoChoiceCase interface {
+	// IsYANGChoiceCase is a marker method that indicates that the struct
+	// represents a case of a YANG choice.
+	IsYANGChoiceCase()
+}
+
+// ChoiceCaseTypeMapper is an interface which is implemented by generated
+// structs that contain fields representing YANG choices that are generated as
+// sum types.
+type ChoiceCaseTypeMapper interface {
+	// ΛChoiceCaseTypes returns a map, keyed by the name of each field that
+	// stores a choice, of the types of the structs that represent the cases
+	// of the choice.
+	ΛChoiceCaseTypes() map[string][]reflect.Type
+}
+
 // GoEnum is an interface which can be implemented by derived types which
 // represent an enumerated value within a YANG schema. This allows handling
 // code that finds struct fields that implement this interface to do specific
@@ -169,6 +203,86 @@ type EnumDefinition struct {
 	Value int
 }
 
+// OperationType describes the kind of YANG statement that defines an
+// operation.
+type OperationType int64
+
+const (
+	// UnknownOperationType is used when the kind of an operation is not known.
+	UnknownOperationType OperationType = iota
+	// RPCOperation indicates that the operation is a YANG rpc.
+	RPCOperation
+	// ActionOperation indicates that the operation is a YANG action, which
+	// is invoked on a node of the data tree.
+	ActionOperation
+	// NotificationOperation indicates that the operation is a YANG
+	// notification.
+	NotificationOperation
+)
+
+// String returns the YANG keyword corresponding to the OperationType.
+func (o OperationType) String() string {
+	switch o {
+	case RPCOperation:
+		return "rpc"
+	case ActionOperation:
+		return "action"
+	case NotificationOperation:
+		return "notification"
+	}
+	return "unknown"
+}
+
+// Operation describes a YANG rpc, action or notification for which GoStructs
+// have been generated. Generated code stores the set of operations defined by
+// each YANG module in a map of Operation structs.
+type Operation struct {
+	// Name is the YANG identifier of the operation.
+	Name string
+	// Module is the name of the YANG module in whose namespace the
+	// operation is defined.
+	Module string
+	// Type is the kind of the operation.
+	Type OperationType
+	// Path is the schema path of the operation, excluding module names.
+	// For an action, the path includes the data tree node with which the
+	// action is associated.
+	Path string
+	// Input and Output are the types of the GoStructs that represent the
+	// input and output of an RPC or action. They are nil if the operation
+	// does not define an input or output.
+	Input  reflect.Type
+	Output reflect.Type
+	// Notification is the type of the GoStruct that represents the body of
+	// a notification.
+	Notification reflect.Type
+}
+
+// AnyData stores the contents of a YANG anydata or anyxml node within a
+// GoStruct. Since the schema does not describe the contents of such nodes,
+// they are stored either as raw RFC7951 JSON, or as a GoStruct when the
+// schema of the contents is known to the caller. Generated code uses a
+// *AnyData field for each anydata or anyxml node, for example:
+//
+//	type GoStructExample struct {
+//	   Data *ygot.AnyData `path:"data"`
+//	}
+//
+// AnyData is treated as a leaf when a GoStruct is traversed, rendered or
+// diffed, and is encoded as RFC7951 JSON in gNMI TypedValue messages.
+type AnyData struct {
+	// JSON stores the RFC7951 JSON encoding of the contents of the node.
+	// It is used only when Value is nil.
+	JSON json.RawMessage
+	// Value stores the contents of the node as a GoStruct, typically the
+	// root of the schema that describes the contents.
+	Value GoStruct
+}
+
+// IsYANGAnyData is a marker method that indicates that the struct stores
+// the contents of an anydata or anyxml node.
+func (*AnyData) IsYANGAnyData() {}
+
 // Annotation defines an interface that is implemented by optional metadata
 // fields within a GoStruct. Annotations are stored within each struct, and
 // for a struct field, for example:
@@ -0,0 +1,976 @@
+// Copyright 2024 Google Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package ygot
+
+import (
+	"encoding/xml"
+	"fmt"
+	"reflect"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/openconfig/gnmi/errlist"
+	"github.com/openconfig/goyang/pkg/yang"
+	"github.com/openconfig/ygot/internal/yreflect"
+	"github.com/openconfig/ygot/util"
+)
+
+const (
+	// NETCONFBaseNamespace is the XML namespace of the NETCONF base
+	// protocol, defined in RFC6241. The wrapping element of rendered XML
+	// documents, and the edit-config operation attribute, reside within
+	// this namespace.
+	NETCONFBaseNamespace = "urn:ietf:params:xml:ns:netconf:base:1.0"
+	// netconfPrefix is the XML namespace prefix that is used for
+	// attributes within the NETCONF base namespace.
+	netconfPrefix = "nc"
+	// YANGMetadataNamespace is the XML namespace of the attributes that
+	// are defined by YANG for use in NETCONF edit-config operations, such
+	// as the "insert" attribute defined in RFC7950 Section 7.8.6.
+	YANGMetadataNamespace = "urn:ietf:params:xml:ns:yang:1"
+	// yangPrefix is the XML namespace prefix that is used for attributes
+	// within the YANG metadata namespace.
+	yangPrefix = "yang"
+)
+
+// XMLOperation is the value of the NETCONF edit-config "operation" attribute
+// as defined in RFC6241 Section 7.2.
+type XMLOperation string
+
+const (
+	// XMLOperationNone indicates that no operation attribute is set.
+	XMLOperationNone XMLOperation = ""
+	// XMLOperationMerge indicates that the element is merged with the
+	// existing configuration.
+	XMLOperationMerge XMLOperation = "merge"
+	// XMLOperationReplace indicates that the element replaces the existing
+	// configuration.
+	XMLOperationReplace XMLOperation = "replace"
+	// XMLOperationCreate indicates that the element is created, and must
+	// not already exist.
+	XMLOperationCreate XMLOperation = "create"
+	// XMLOperationDelete indicates that the element is deleted, and must
+	// already exist.
+	XMLOperationDelete XMLOperation = "delete"
+	// XMLOperationRemove indicates that the element is deleted if it
+	// exists.
+	XMLOperationRemove XMLOperation = "remove"
+)
+
+// XMLConfig specifies how a GoStruct is rendered to XML.
+type XMLConfig struct {
+	// Namespaces maps the name of a YANG module to the XML namespace
+	// (the argument of the module's "namespace" statement) of the module.
+	// An entry must exist for each module that defines an element, or an
+	// identity, that is rendered.
+	Namespaces map[string]string
+	// RootElement is the name of the element within the NETCONF base
+	// namespace that wraps the rendered GoStruct. If unset, "data" is used
+	// by MarshalXML and "config" is used by MarshalXMLEditConfig.
+	RootElement string
+	// Indent is the string used to indent each level of the rendered XML.
+	// If unset, the XML is rendered without whitespace between elements.
+	Indent string
+	// PreferShadowPath uses the name of the "shadow-path" tag of a
+	// GoStruct to determine the rendered elements instead of the "path"
+	// tag, whenever the former is present.
+	PreferShadowPath bool
+	// DeleteOperation is the operation used by MarshalXMLEditConfig for
+	// elements that exist in the original GoStruct but not in the modified
+	// one. If unset, XMLOperationDelete is used.
+	DeleteOperation XMLOperation
+	// Schema is the schema of the rendered GoStruct. When set, it is used
+	// to determine whether lists and leaf-lists are ordered-by user, and
+	// the keys of list entries whose GoStruct does not implement
+	// KeyHelperGoStruct. If unset, only lists that are stored in a
+	// GoOrderedMap are considered to be ordered-by user.
+	Schema *yang.Entry
+}
+
+// xmlElement is an intermediate representation of an XML element that is
+// rendered from a GoStruct.
+type xmlElement struct {
+	// name is the name of the YANG node that the element represents.
+	name string
+	// module is the name of the YANG module that defines the element.
+	module string
+	// value is the text content of a leaf or leaf-list element. It is nil
+	// for elements that are not leaves.
+	value *string
+	// identityModules is the set of modules that define identities that
+	// are referenced within value.
+	identityModules []string
+	// keys stores the keys of a list entry as they are rendered in a gNMI
+	// path. It is nil for elements that are not keyed list entries.
+	keys map[string]string
+	// keyNames are the names of the keys of a list entry, in the order in
+	// which they are specified in the YANG "key" statement.
+	keyNames []string
+	// listEntry indicates that the element is an entry of a list.
+	listEntry bool
+	// leafListEntry indicates that the element is an entry of a leaf-list.
+	leafListEntry bool
+	// index is the position of the element within an unkeyed list.
+	index int
+	// ordered indicates that the element is an entry of an ordered-by
+	// user list or leaf-list.
+	ordered bool
+	// operation is the edit-config operation applied to the element.
+	operation XMLOperation
+	// insert is the value of the "insert" attribute of the element, which
+	// positions an entry of an ordered-by user list or leaf-list as
+	// defined in RFC7950 Section 7.8.6.
+	insert string
+	// insertAfter is the entry that the element is positioned after when
+	// insert is "after".
+	insertAfter *xmlElement
+	// children are the child elements of the element.
+	children []*xmlElement
+}
+
+// identity returns a string which uniquely identifies the element amongst
+// its siblings.
+func (e *xmlElement) identity() string {
+	id := e.module + ":" + e.name
+	switch {
+	case e.keys != nil:
+		var ks []string
+		for k, v := range e.keys {
+			ks = append(ks, fmt.Sprintf("[%s=%s]", k, v))
+		}
+		sort.Strings(ks)
+		id += strings.Join(ks, "")
+	case e.listEntry:
+		id += fmt.Sprintf("[%d]", e.index)
+	case e.leafListEntry:
+		// Leaf-list entries with the same name are distinguished by
+		// their value.
+		id += "=" + *e.value
+	}
+	return id
+}
+
+// isLeaf reports whether the element represents a leaf or leaf-list entry.
+func (e *xmlElement) isLeaf() bool {
+	return e.value != nil
+}
+
+// shallowCopy returns a copy of the element without its children. The key
+// leaves of a list entry are retained, since they are required to identify
+// the entry.
+func (e *xmlElement) shallowCopy() *xmlElement {
+	n := *e
+	n.children = nil
+	n.operation = XMLOperationNone
+	n.insert, n.insertAfter = "", nil
+	if e.keys != nil {
+		for _, ch := range e.children {
+			if _, ok := e.keys[ch.name]; ok && ch.isLeaf() {
+				n.children = append(n.children, ch)
+			}
+		}
+	}
+	return &n
+}
+
+// childContainer returns the child element of e with the supplied name and
+// module that is not a list entry or leaf, creating it if it does not exist.
+func (e *xmlElement) childContainer(name, module string) *xmlElement {
+	for _, ch := range e.children {
+		if ch.name == name && ch.module == module && !ch.listEntry && !ch.isLeaf() {
+			return ch
+		}
+	}
+	ch := &xmlElement{name: name, module: module}
+	e.children = append(e.children, ch)
+	return ch
+}
+
+// MarshalXML renders the supplied GoStruct to NETCONF-style XML, as defined
+// in RFC7950 Section 7. The content of the GoStruct is wrapped by an element
+// in the NETCONF base namespace, named according to the RootElement field of
+// the supplied XMLConfig. Each element is placed into the namespace of the
+// module that defines it, and identityref values are qualified with a
+// prefix equal to the name of the module that defines the identity.
+func MarshalXML(s GoStruct, cfg *XMLConfig) ([]byte, error) {
+	if cfg == nil {
+		cfg = &XMLConfig{}
+	}
+	root, err := goStructToXMLElement(s, cfg)
+	if err != nil {
+		return nil, err
+	}
+	root.name = cfg.RootElement
+	if root.name == "" {
+		root.name = "data"
+	}
+	return renderXMLDocument(root, cfg)
+}
+
+// MarshalXMLEditConfig renders the difference between the original and
+// modified GoStructs as the <config> payload of a NETCONF <edit-config>
+// operation. Elements that are added or modified are rendered with their
+// values in modified, such that they are merged with the existing
+// configuration. Elements that exist in original but not in modified are
+// rendered with an operation attribute, as specified by the DeleteOperation
+// field of the supplied XMLConfig. The keys of list entries are always
+// rendered such that each entry can be identified.
+//
+// Since a merge appends new entries to an ordered-by user list or leaf-list,
+// and does not move existing ones, each entry of a list or leaf-list whose
+// entries would not be in the order of modified after the merge is rendered
+// with the "insert" attribute defined in RFC7950 Section 7.8.6, positioning
+// it after the preceding entry in modified. Whether a list or leaf-list is
+// ordered-by user is determined by the Schema field of the supplied
+// XMLConfig; see its documentation for the behaviour when it is unset.
+//
+// The original and modified GoStructs must be of the same type.
+func MarshalXMLEditConfig(original, modified GoStruct, cfg *XMLConfig) ([]byte, error) {
+	if reflect.TypeOf(original) != reflect.TypeOf(modified) {
+		return nil, fmt.Errorf("cannot diff structs of different types, original: %T, modified: %T", original, modified)
+	}
+	if cfg == nil {
+		cfg = &XMLConfig{}
+	}
+	orig, err := goStructToXMLElement(original, cfg)
+	if err != nil {
+		return nil, fmt.Errorf("cannot render original GoStruct: %v", err)
+	}
+	mod, err := goStructToXMLElement(modified, cfg)
+	if err != nil {
+		return nil, fmt.Errorf("cannot render modified GoStruct: %v", err)
+	}
+
+	delOp := cfg.DeleteOperation
+	if delOp == XMLOperationNone {
+		delOp = XMLOperationDelete
+	}
+	root, _ := diffXMLElements(orig, mod, delOp)
+	root.name = cfg.RootElement
+	if root.name == "" {
+		root.name = "config"
+	}
+	return renderXMLDocument(root, cfg)
+}
+
+// diffXMLElements returns an element describing the changes required to
+// transform orig into mod. It returns false if there are no changes.
+func diffXMLElements(orig, mod *xmlElement, delOp XMLOperation) (*xmlElement, bool) {
+	out := mod.shallowCopy()
+	var changed bool
+
+	origChildren := map[string]*xmlElement{}
+	for _, ch := range orig.children {
+		origChildren[ch.identity()] = ch
+	}
+	modChildren := map[string]bool{}
+	reordered := reorderedEntries(orig, mod)
+	// prev stores the last entry of each reordered list or leaf-list
+	// within mod, which the next entry is positioned after.
+	prev := map[string]*xmlElement{}
+
+	for _, mch := range mod.children {
+		id := mch.identity()
+		modChildren[id] = true
+		och, ok := origChildren[id]
+		var d *xmlElement
+		switch {
+		case !ok:
+			// The element does not exist in the original tree, and hence
+			// is rendered in its entirety.
+			d = mch
+		case mch.isLeaf():
+			if *mch.value != *och.value {
+				d = mch
+			}
+		default:
+			if cd, ok := diffXMLElements(och, mch, delOp); ok {
+				d = cd
+			}
+		}
+
+		if n := mch.module + ":" + mch.name; mch.ordered && reordered[n] {
+			// Each entry of a reordered list or leaf-list is rendered
+			// with its position, even if its content is unchanged.
+			r := mch.shallowCopy()
+			if d != nil {
+				c := *d
+				r = &c
+			}
+			r.insert = "first"
+			if p := prev[n]; p != nil {
+				r.insert, r.insertAfter = "after", p
+			}
+			prev[n] = mch
+			d = r
+		}
+
+		if d != nil {
+			out.appendChild(d)
+			changed = true
+		}
+	}
+
+	for _, och := range orig.children {
+		if modChildren[och.identity()] {
+			continue
+		}
+		d := och.shallowCopy()
+		d.operation = delOp
+		if !och.isLeaf() {
+			d.value = nil
+		}
+		out.appendChild(d)
+		changed = true
+	}
+	return out, changed
+}
+
+// reorderedEntries returns the set of ordered-by user lists and leaf-lists
+// amongst the children of mod, keyed by the module and name of their
+// entries, whose entries would be in a different order to that in mod if
+// mod were merged with orig. The merge retains the order of the entries of
+// orig, and appends those entries that exist only within mod.
+func reorderedEntries(orig, mod *xmlElement) map[string]bool {
+	ordered := func(e *xmlElement) map[string][]string {
+		ids := map[string][]string{}
+		for _, ch := range e.children {
+			if ch.ordered {
+				n := ch.module + ":" + ch.name
+				ids[n] = append(ids[n], ch.identity())
+			}
+		}
+		return ids
+	}
+	reordered := map[string]bool{}
+	origIDs := ordered(orig)
+	for n, modIDs := range ordered(mod) {
+		inMod, inOrig := map[string]bool{}, map[string]bool{}
+		for _, id := range modIDs {
+			inMod[id] = true
+		}
+		var merged []string
+		for _, id := range origIDs[n] {
+			inOrig[id] = true
+			if inMod[id] {
+				merged = append(merged, id)
+			}
+		}
+		for _, id := range modIDs {
+			if !inOrig[id] {
+				merged = append(merged, id)
+			}
+		}
+		for i, id := range modIDs {
+			if merged[i] != id {
+				reordered[n] = true
+				break
+			}
+		}
+	}
+	return reordered
+}
+
+// appendChild appends ch to the children of e, unless an element with the
+// same identity is already present.
+func (e *xmlElement) appendChild(ch *xmlElement) {
+	id := ch.identity()
+	for i, ex := range e.children {
+		if ex.identity() == id {
+			e.children[i] = ch
+			return
+		}
+	}
+	e.children = append(e.children, ch)
+}
+
+// goStructToXMLElement returns an unnamed xmlElement whose children are the
+// elements rendered from the fields of the supplied GoStruct.
+func goStructToXMLElement(s GoStruct, cfg *XMLConfig) (*xmlElement, error) {
+	if util.IsValueNil(s) {
+		return nil, fmt.Errorf("cannot render nil GoStruct to XML")
+	}
+	root := &xmlElement{}
+	if err := structXML(reflect.ValueOf(s), cfg.Schema, root, cfg); err != nil {
+		return nil, err
+	}
+	return root, nil
+}
+
+// structXML appends the elements rendered from the fields of the struct
+// pointer sval as children of the parent element. schema is the schema of
+// the struct, and may be nil if it is not known.
+func structXML(sval reflect.Value, schema *yang.Entry, parent *xmlElement, cfg *XMLConfig) error {
+	var errs errlist.List
+	v := sval.Elem()
+	t := v.Type()
+	for i := 0; i < v.NumField(); i++ {
+		field, fType := v.Field(i), t.Field(i)
+		if util.IsYgotAnnotation(fType) {
+			continue
+		}
+
+		paths, err := structTagToLibPaths(fType, newStringSliceGNMIPath([]string{}), cfg.PreferShadowPath)
+		if err != nil {
+			errs.Add(fmt.Errorf("%s: %v", fType.Name, err))
+			continue
+		}
+		modules, err := structTagToLibModules(fType, cfg.PreferShadowPath)
+		if err != nil {
+			errs.Add(fmt.Errorf("%s: %v", fType.Name, err))
+			continue
+		}
+		if len(modules) != len(paths) {
+			errs.Add(fmt.Errorf("%s: number of paths and modules in struct tag not the same: (paths: %v, modules: %v)", fType.Name, len(paths), len(modules)))
+			continue
+		}
+
+		var fSchema *yang.Entry
+		if schema != nil {
+			childSchema := util.ChildSchema
+			if cfg.PreferShadowPath {
+				childSchema = util.ChildSchemaPreferShadow
+			}
+			if fSchema, err = childSchema(schema, fType); err != nil {
+				errs.Add(fmt.Errorf("%s: %v", fType.Name, err))
+				continue
+			}
+		}
+
+		for j, p := range paths {
+			if p.Len() != modules[j].Len() {
+				errs.Add(fmt.Errorf("%s: number of path and module elements not the same: (paths: %v, modules: %v)", fType.Name, p, modules[j]))
+				continue
+			}
+			if p.Len() == 0 {
+				errs.Add(fmt.Errorf("%s: empty path specified for field", fType.Name))
+				continue
+			}
+			if err := fieldXML(field, fType, fSchema, p.stringSlicePath, modules[j].stringSlicePath, parent, cfg); err != nil {
+				errs.Add(fmt.Errorf("%s: %v", fType.Name, err))
+			}
+		}
+	}
+
+	return errs.Err()
+}
+
+// fieldXML appends the elements rendered from the field with value v and
+// type f, at the schema path p relative to parent with the corresponding
+// module names mods, to the parent element. schema is the schema of the
+// field, and may be nil if it is not known.
+func fieldXML(v reflect.Value, f reflect.StructField, schema *yang.Entry, p, mods []string, parent *xmlElement, cfg *XMLConfig) error {
+	if util.IsNilOrInvalidValue(v) || (v.Kind() == reflect.Interface || v.Kind() == reflect.Slice || v.Kind() == reflect.Map) && v.IsNil() {
+		return nil
+	}
+
+	name, mod := p[len(p)-1], mods[len(mods)-1]
+	// ensureParent creates the intermediate containers in the compressed
+	// path of the field when the field has content to render.
+	ensureParent := func() *xmlElement {
+		e := parent
+		for i := 0; i < len(p)-1; i++ {
+			e = e.childContainer(p[i], mods[i])
+		}
+		return e
+	}
+
+	if _, ok := v.Interface().(*AnyData); ok {
+		return fmt.Errorf("cannot encode anydata contents as XML, unsupported")
+	}
+
+	if om, ok := v.Interface().(GoOrderedMap); ok {
+		if om.Len() == 0 {
+			return nil
+		}
+		pe := ensureParent()
+		var errs errlist.List
+		kt, err := yreflect.OrderedMapKeyType(om)
+		if err != nil {
+			return err
+		}
+		if err := yreflect.RangeOrderedMap(om, func(_ reflect.Value, lv reflect.Value) bool {
+			errs.Add(appendListEntryXML(lv, kt, schema, name, mod, orderedByUser(schema, true), pe, cfg))
+			return true
+		}); err != nil {
+			return err
+		}
+		return errs.Err()
+	}
+
+	switch {
+	case v.Kind() == reflect.Map:
+		type entry struct {
+			k string
+			v reflect.Value
+		}
+		var entries []entry
+		iter := v.MapRange()
+		for iter.Next() {
+			k, err := mapKeyToJSONString(iter.Key(), jsonOutputConfig{jType: Internal})
+			if err != nil {
+				return err
+			}
+			entries = append(entries, entry{k: k, v: iter.Value()})
+		}
+		if len(entries) == 0 {
+			return nil
+		}
+		sort.Slice(entries, func(i, j int) bool { return entries[i].k < entries[j].k })
+		pe := ensureParent()
+		var errs errlist.List
+		for _, e := range entries {
+			errs.Add(appendListEntryXML(e.v, v.Type().Key(), schema, name, mod, orderedByUser(schema, false), pe, cfg))
+		}
+		return errs.Err()
+	case v.Kind() == reflect.Slice && util.IsTypeStructPtr(v.Type().Elem()):
+		// Unkeyed list.
+		if v.Len() == 0 {
+			return nil
+		}
+		pe := ensureParent()
+		for i := 0; i < v.Len(); i++ {
+			e := &xmlElement{name: name, module: mod, listEntry: true, index: i}
+			if err := structXML(v.Index(i), schema, e, cfg); err != nil {
+				return err
+			}
+			pe.children = append(pe.children, e)
+		}
+		return nil
+	case v.Kind() == reflect.Slice && v.Type().Name() != BinaryTypeName:
+		// Leaf-list.
+		var elems []*xmlElement
+		ordered := orderedByUser(schema, false)
+		for i := 0; i < v.Len(); i++ {
+			val, idMods, set, err := xmlLeafValue(v.Index(i))
+			if err != nil {
+				return err
+			}
+			if !set {
+				continue
+			}
+			elems = append(elems, &xmlElement{name: name, module: mod, value: &val, identityModules: idMods, leafListEntry: true, ordered: ordered})
+		}
+		if len(elems) != 0 {
+			pe := ensureParent()
+			pe.children = append(pe.children, elems...)
+		}
+		return nil
+	case util.IsValueStructPtr(v) && !util.IsValueInterface(v):
+		if _, ok := v.Interface().(GoStruct); !ok {
+			break
+		}
+		e := &xmlElement{name: name, module: mod}
+		if err := structXML(v, schema, e, cfg); err != nil {
+			return err
+		}
+		if len(e.children) == 0 && !util.IsYangPresence(f) {
+			return nil
+		}
+		pe := ensureParent()
+		pe.children = append(pe.children, e)
+		return nil
+	}
+
+	val, idMods, set, err := xmlLeafValue(v)
+	if err != nil {
+		return err
+	}
+	if !set {
+		return nil
+	}
+	pe := ensureParent()
+	pe.children = append(pe.children, &xmlElement{name: name, module: mod, value: &val, identityModules: idMods})
+	return nil
+}
+
+// appendListEntryXML renders the list entry v, which must be a pointer to a
+// GoStruct, as a child of parent with the supplied name and module. keyType
+// is the type of the key of the map in which the entry is stored, and ordered
+// indicates that the entry is within an ordered-by user list. schema is the
+// schema of the list, and may be nil if it is not known.
+//
+// The keys of the entry are determined by its ΛListKeyMap method if it
+// implements KeyHelperGoStruct, or otherwise by the key leaves named by the
+// schema, such that distinct entries can always be identified.
+func appendListEntryXML(v reflect.Value, keyType reflect.Type, schema *yang.Entry, name, mod string, ordered bool, parent *xmlElement, cfg *XMLConfig) error {
+	e := &xmlElement{name: name, module: mod, listEntry: true, ordered: ordered}
+	if err := structXML(v, schema, e, cfg); err != nil {
+		return err
+	}
+
+	hasSchemaKeys := schema != nil && schema.Key != ""
+	switch kh, ok := v.Interface().(KeyHelperGoStruct); {
+	case ok:
+		km, err := kh.ΛListKeyMap()
+		if err != nil {
+			return err
+		}
+		if e.keys, err = keyMapAsStrings(km); err != nil {
+			return err
+		}
+	case hasSchemaKeys:
+		keys, err := schemaListKeys(schema, e)
+		if err != nil {
+			return err
+		}
+		e.keys = keys
+	default:
+		return fmt.Errorf("cannot determine the keys of list entry %s of type %T, it does not implement KeyHelperGoStruct and no schema is known", name, v.Interface())
+	}
+	e.keyNames = listKeyOrder(keyType, e.keys)
+	if hasSchemaKeys {
+		e.keyNames = strings.Fields(schema.Key)
+	}
+
+	// The keys of a list entry must be rendered prior to any other child
+	// element per RFC7950 Section 7.8.5.
+	orderListKeys(e)
+	parent.children = append(parent.children, e)
+	return nil
+}
+
+// schemaListKeys returns the values of the key leaves, named by the "key"
+// statement of the list schema, amongst the rendered children of the list
+// entry e.
+func schemaListKeys(schema *yang.Entry, e *xmlElement) (map[string]string, error) {
+	keys := map[string]string{}
+	for _, kn := range strings.Fields(schema.Key) {
+		for _, ch := range e.children {
+			if ch.name == kn && ch.isLeaf() {
+				keys[kn] = *ch.value
+				break
+			}
+		}
+		if _, ok := keys[kn]; !ok {
+			return nil, fmt.Errorf("list entry %s has no value for key leaf %s", e.name, kn)
+		}
+	}
+	return keys, nil
+}
+
+// orderedByUser reports whether the list or leaf-list described by schema is
+// ordered-by user. If schema is nil, def is returned.
+func orderedByUser(schema *yang.Entry, def bool) bool {
+	if schema == nil {
+		return def
+	}
+	return schema.ListAttr != nil && schema.ListAttr.OrderedByUser
+}
+
+// orderListKeys reorders the children of the list entry e such that the key
+// leaves are first, in the order in which they are specified within the
+// YANG "key" statement.
+func orderListKeys(e *xmlElement) {
+	var keys, others []*xmlElement
+	for _, kn := range e.keyNames {
+		for _, ch := range e.children {
+			if ch.name == kn && ch.isLeaf() {
+				keys = append(keys, ch)
+				break
+			}
+		}
+	}
+	for _, ch := range e.children {
+		if _, isKey := e.keys[ch.name]; isKey && ch.isLeaf() {
+			continue
+		}
+		others = append(others, ch)
+	}
+	e.children = append(keys, others...)
+}
+
+// listKeyOrder returns the names of the supplied keys in the order in which
+// they are specified in the YANG schema. For multi-keyed lists, keyType is
+// the generated key struct, whose fields are in schema order and are tagged
+// with the name of the key leaf.
+func listKeyOrder(keyType reflect.Type, keys map[string]string) []string {
+	var names []string
+	for k := range keys {
+		names = append(names, k)
+	}
+	sort.Strings(names)
+	if len(names) < 2 || !util.IsTypeStruct(keyType) {
+		return names
+	}
+
+	var ordered []string
+	for i := 0; i < keyType.NumField(); i++ {
+		kn, ok := keyType.Field(i).Tag.Lookup("path")
+		if _, isKey := keys[kn]; !ok || !isKey {
+			return names
+		}
+		ordered = append(ordered, kn)
+	}
+	return ordered
+}
+
+// xmlLeafValue returns the XML text representation of the leaf value v. It
+// returns the modules of any identities referenced by the value, and whether
+// the value is set.
+func xmlLeafValue(v reflect.Value) (string, []string, bool, error) {
+	switch {
+	case util.IsNilOrInvalidValue(v):
+		return "", nil, false, nil
+	case v.Kind() == reflect.Interface:
+		if v.IsNil() {
+			return "", nil, false, nil
+		}
+		if util.IsValueInterfaceToStructPtr(v) {
+			s := v.Elem().Elem()
+			if !util.IsStructValueWithNFields(s, 1) {
+				return "", nil, false, fmt.Errorf("received a union type which did not have one field, had: %v", s.NumField())
+			}
+			return xmlLeafValue(s.Field(0))
+		}
+		return xmlLeafValue(v.Elem())
+	case v.Kind() == reflect.Ptr:
+		if v.IsNil() {
+			return "", nil, false, nil
+		}
+		if util.IsValueStructPtr(v) {
+			// Union pointer, used when a list is keyed by a union.
+			if !util.IsStructValueWithNFields(v.Elem(), 1) {
+				return "", nil, false, fmt.Errorf("received a union pointer struct that didn't have one field, got: %v", v.Elem().NumField())
+			}
+			return xmlLeafValue(v.Elem().Field(0))
+		}
+		return xmlLeafValue(v.Elem())
+	}
+
+	if e, ok := v.Interface().(GoEnum); ok {
+		if v.Int() == 0 {
+			return "", nil, false, nil
+		}
+		name, _, err := enumFieldToString(v, false)
+		if err != nil {
+			return "", nil, false, err
+		}
+		def := e.ΛMap()[v.Type().Name()][v.Int()]
+		if def.DefiningModule != "" {
+			// Identityref values are qualified with a namespace prefix
+			// per RFC7950 Section 9.10.3.
+			return fmt.Sprintf("%s:%s", def.DefiningModule, name), []string{def.DefiningModule}, true, nil
+		}
+		return name, nil, true, nil
+	}
+
+	switch v.Kind() {
+	case reflect.String:
+		return v.String(), nil, true, nil
+	case reflect.Bool:
+		if v.Type().Name() == EmptyTypeName {
+			// An empty leaf is rendered as an element without content when
+			// it is set, and omitted otherwise.
+			return "", nil, v.Bool(), nil
+		}
+		return strconv.FormatBool(v.Bool()), nil, true, nil
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		return strconv.FormatInt(v.Int(), 10), nil, true, nil
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		return strconv.FormatUint(v.Uint(), 10), nil, true, nil
+	case reflect.Float32, reflect.Float64:
+		return strconv.FormatFloat(v.Float(), 'f', -1, 64), nil, true, nil
+	case reflect.Slice:
+		if v.Type().Elem().Kind() == reflect.Uint8 {
+			return binaryBase64(v.Bytes()), nil, true, nil
+		}
+	}
+	return "", nil, false, fmt.Errorf("cannot render value of type %v to XML", v.Type())
+}
+
+// xmlWriter renders a tree of xmlElement values to XML text.
+type xmlWriter struct {
+	b         strings.Builder
+	cfg       *XMLConfig
+	errs      errlist.List
+	hasOps    bool
+	hasInsert bool
+}
+
+// renderXMLDocument renders the root element, and its descendants, to XML.
+func renderXMLDocument(root *xmlElement, cfg *XMLConfig) ([]byte, error) {
+	w := &xmlWriter{cfg: cfg, hasOps: hasXMLOperation(root), hasInsert: hasXMLInsert(root)}
+	w.writeElement(root, NETCONFBaseNamespace, 0, true)
+	if err := w.errs.Err(); err != nil {
+		return nil, err
+	}
+	if cfg.Indent != "" {
+		w.b.WriteString("\n")
+	}
+	return []byte(w.b.String()), nil
+}
+
+// hasXMLOperation reports whether e or any of its descendants has an
+// edit-config operation specified.
+func hasXMLOperation(e *xmlElement) bool {
+	if e.operation != XMLOperationNone {
+		return true
+	}
+	for _, ch := range e.children {
+		if hasXMLOperation(ch) {
+			return true
+		}
+	}
+	return false
+}
+
+// hasXMLInsert reports whether e or any of its descendants has an insert
+// attribute specified.
+func hasXMLInsert(e *xmlElement) bool {
+	if e.insert != "" {
+		return true
+	}
+	for _, ch := range e.children {
+		if hasXMLInsert(ch) {
+			return true
+		}
+	}
+	return false
+}
+
+// namespace returns the XML namespace of the supplied module.
+func (w *xmlWriter) namespace(module string) string {
+	ns, ok := w.cfg.Namespaces[module]
+	if !ok {
+		w.errs.Add(fmt.Errorf("no XML namespace specified for module %q", module))
+	}
+	return ns
+}
+
+// writeElement writes the element e at the supplied depth. parentNS is the
+// default namespace in scope at the element.
+func (w *xmlWriter) writeElement(e *xmlElement, parentNS string, depth int, isRoot bool) {
+	if w.cfg.Indent != "" && depth > 0 {
+		w.b.WriteString("\n")
+		w.b.WriteString(strings.Repeat(w.cfg.Indent, depth))
+	}
+
+	ns := parentNS
+	if !isRoot {
+		ns = w.namespace(e.module)
+	}
+
+	w.b.WriteString("<")
+	w.b.WriteString(e.name)
+	if isRoot || ns != parentNS {
+		w.writeAttr("xmlns", ns)
+	}
+	if isRoot && w.hasOps {
+		w.writeAttr("xmlns:"+netconfPrefix, NETCONFBaseNamespace)
+	}
+	if isRoot && w.hasInsert {
+		w.writeAttr("xmlns:"+yangPrefix, YANGMetadataNamespace)
+	}
+	prefixModules := e.identityModules
+	if a := e.insertAfter; a != nil && !a.leafListEntry {
+		// The key predicates of the entry that the element is positioned
+		// after are qualified with the prefix of the list's module.
+		prefixModules = append(append([]string{}, prefixModules...), a.module)
+	}
+	seen := map[string]bool{}
+	for _, m := range prefixModules {
+		if seen[m] {
+			continue
+		}
+		seen[m] = true
+		w.writeAttr("xmlns:"+m, w.namespace(m))
+	}
+	if e.operation != XMLOperationNone {
+		w.writeAttr(netconfPrefix+":operation", string(e.operation))
+	}
+	if e.insert != "" {
+		w.writeAttr(yangPrefix+":insert", e.insert)
+		switch a := e.insertAfter; {
+		case a == nil:
+		case a.leafListEntry:
+			w.writeAttr(yangPrefix+":value", *a.value)
+		default:
+			w.writeAttr(yangPrefix+":key", keyPredicates(a))
+		}
+	}
+
+	switch {
+	case e.value != nil && *e.value == "":
+		w.b.WriteString("/>")
+		return
+	case e.value != nil:
+		w.b.WriteString(">")
+		w.writeText(*e.value)
+	case len(e.children) == 0:
+		w.b.WriteString("/>")
+		return
+	default:
+		w.b.WriteString(">")
+		for _, ch := range e.children {
+			w.writeElement(ch, ns, depth+1, false)
+		}
+		if w.cfg.Indent != "" {
+			w.b.WriteString("\n")
+			w.b.WriteString(strings.Repeat(w.cfg.Indent, depth))
+		}
+	}
+	w.b.WriteString("</")
+	w.b.WriteString(e.name)
+	w.b.WriteString(">")
+}
+
+// keyPredicates returns the key predicates that identify the list entry e,
+// in the form used by the "key" attribute defined in RFC7950 Section 7.8.6.
+func keyPredicates(e *xmlElement) string {
+	var b strings.Builder
+	for _, k := range e.keyNames {
+		v := e.keys[k]
+		q := "'"
+		if strings.Contains(v, "'") {
+			q = `"`
+		}
+		fmt.Fprintf(&b, "[%s:%s=%s%s%s]", e.module, k, q, v, q)
+	}
+	return b.String()
+}
+
+// writeAttr writes an attribute with the supplied name and value.
+func (w *xmlWriter) writeAttr(name, value string) {
+	w.b.WriteString(" ")
+	w.b.WriteString(name)
+	w.b.WriteString(`="`)
+	w.b.WriteString(xmlAttrEscaper.Replace(value))
+	w.b.WriteString(`"`)
+}
+
+// xmlAttrEscaper escapes the characters that cannot appear literally within
+// a double-quoted XML attribute value. Single quotes are retained, since
+// they delimit the values within key predicates.
+var xmlAttrEscaper = strings.NewReplacer(
+	"&", "&amp;",
+	"<", "&lt;",
+	">", "&gt;",
+	`"`, "&quot;",
+	"\t", "&#x9;",
+	"\n", "&#xA;",
+	"\r", "&#xD;",
+)
+
+// writeText writes the escaped form of s.
+func (w *xmlWriter) writeText(s string) {
+	var b strings.Builder
+	if err := xml.EscapeText(&b, []byte(s)); err != nil {
+		w.errs.Add(err)
+		return
+	}
+	w.b.WriteString(b.String())
+}
@@ -0,0 +1,98 @@
+// Copyright 2024 Google Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package ygot_test
+
+import (
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/openconfig/ygot/integration_tests/schemaops/ctestschema"
+	"github.com/openconfig/ygot/ygot"
+)
+
+func TestMarshalXMLEditConfigOrderedMap(t *testing.T) {
+	orderedMap := func(keys ...string) *ctestschema.OrderedList_OrderedMap {
+		om := &ctestschema.OrderedList_OrderedMap{}
+		for _, k := range keys {
+			v, err := om.AppendNew(k)
+			if err != nil {
+				t.Fatal(err)
+			}
+			v.Value = ygot.String(k + "-val")
+		}
+		return om
+	}
+
+	tests := []struct {
+		desc       string
+		inOriginal *ctestschema.Device
+		inModified *ctestschema.Device
+		want       string
+	}{{
+		desc:       "entry appended",
+		inOriginal: &ctestschema.Device{OrderedList: orderedMap("foo", "bar")},
+		inModified: &ctestschema.Device{OrderedList: orderedMap("foo", "bar", "baz")},
+		want: `<config xmlns="urn:ietf:params:xml:ns:netconf:base:1.0">` +
+			`<ordered-lists xmlns="urn:ctestschema">` +
+			`<ordered-list><key>baz</key><config><key>baz</key><value>baz-val</value></config></ordered-list>` +
+			`</ordered-lists>` +
+			`</config>`,
+	}, {
+		desc:       "entries reordered",
+		inOriginal: &ctestschema.Device{OrderedList: orderedMap("foo", "bar")},
+		inModified: &ctestschema.Device{OrderedList: orderedMap("bar", "foo")},
+		want: `<config xmlns="urn:ietf:params:xml:ns:netconf:base:1.0" xmlns:yang="urn:ietf:params:xml:ns:yang:1">` +
+			`<ordered-lists xmlns="urn:ctestschema">` +
+			`<ordered-list yang:insert="first"><key>bar</key></ordered-list>` +
+			`<ordered-list xmlns:ctestschema="urn:ctestschema" yang:insert="after" yang:key="[ctestschema:key='bar']"><key>foo</key></ordered-list>` +
+			`</ordered-lists>` +
+			`</config>`,
+	}, {
+		desc:       "entries reordered and modified",
+		inOriginal: &ctestschema.Device{OrderedList: orderedMap("foo", "bar")},
+		inModified: &ctestschema.Device{OrderedList: orderedMap("baz", "foo")},
+		want: `<config xmlns="urn:ietf:params:xml:ns:netconf:base:1.0" xmlns:nc="urn:ietf:params:xml:ns:netconf:base:1.0" xmlns:yang="urn:ietf:params:xml:ns:yang:1">` +
+			`<ordered-lists xmlns="urn:ctestschema">` +
+			`<ordered-list yang:insert="first"><key>baz</key><config><key>baz</key><value>baz-val</value></config></ordered-list>` +
+			`<ordered-list xmlns:ctestschema="urn:ctestschema" yang:insert="after" yang:key="[ctestschema:key='baz']"><key>foo</key></ordered-list>` +
+			`<ordered-list nc:operation="delete"><key>bar</key></ordered-list>` +
+			`</ordered-lists>` +
+			`</config>`,
+	}, {
+		desc:       "entry removed",
+		inOriginal: &ctestschema.Device{OrderedList: orderedMap("foo", "bar")},
+		inModified: &ctestschema.Device{OrderedList: orderedMap("bar")},
+		want: `<config xmlns="urn:ietf:params:xml:ns:netconf:base:1.0" xmlns:nc="urn:ietf:params:xml:ns:netconf:base:1.0">` +
+			`<ordered-lists xmlns="urn:ctestschema">` +
+			`<ordered-list nc:operation="delete"><key>foo</key></ordered-list>` +
+			`</ordered-lists>` +
+			`</config>`,
+	}}
+
+	for _, tt := range tests {
+		t.Run(tt.desc, func(t *testing.T) {
+			got, err := ygot.MarshalXMLEditConfig(tt.inOriginal, tt.inModified, &ygot.XMLConfig{
+				Namespaces: map[string]string{"ctestschema": "urn:ctestschema"},
+				Schema:     ctestschema.SchemaTree["Device"],
+			})
+			if err != nil {
+				t.Fatalf("MarshalXMLEditConfig: %v", err)
+			}
+			if diff := cmp.Diff(tt.want, string(got)); diff != "" {
+				t.Errorf("MarshalXMLEditConfig: did not get expected output, diff(-want, +got):\n%s", diff)
+			}
+		})
+	}
+}
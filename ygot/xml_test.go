@@ -0,0 +1,466 @@
+// Copyright 2024 Google Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package ygot
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/openconfig/gnmi/errdiff"
+	"github.com/openconfig/goyang/pkg/yang"
+)
+
+// xmlTestRoot is the root struct used for XML rendering tests.
+type xmlTestRoot struct {
+	Name      *string                         `path:"config/name|name" module:"xmod/xmod|xmod"`
+	Counter   *uint32                         `path:"state/counter" module:"xmod/xmod"`
+	Enabled   *bool                           `path:"config/enabled" module:"xmod/xmod"`
+	Ident     EnumTest                        `path:"config/ident" module:"xmod/xmod"`
+	Tags      []string                        `path:"config/tag" module:"xmod/xmod"`
+	Data      Binary                          `path:"config/data" module:"xmod/xmod"`
+	Flag      YANGEmpty                       `path:"config/flag" module:"xmod/xmod"`
+	Text      *string                         `path:"config/text" module:"xmod/xmod"`
+	Aug       *string                         `path:"config/aug" module:"xmod/xaug"`
+	Child     *xmlTestChild                   `path:"child" module:"xaug"`
+	Multi     map[xmlTestMultiKey]*xmlTestMKE `path:"multis/multi" module:"xmod/xmod"`
+	Single    map[string]*xmlTestSKE          `path:"single" module:"xmod"`
+	Unkeyed   []*xmlTestSKE                   `path:"unkeyed" module:"xmod"`
+	Plain     map[string]*xmlTestPlainEntry   `path:"plain" module:"xmod"`
+	ΛMetadata []*string                       `path:"@" ygotAnnotation:"true"`
+}
+
+func (*xmlTestRoot) IsYANGGoStruct()                         {}
+func (*xmlTestRoot) ΛValidate(...ValidationOption) error     { return nil }
+func (*xmlTestRoot) ΛEnumTypeMap() map[string][]reflect.Type { return nil }
+func (*xmlTestRoot) ΛBelongingModule() string                { return "xmod" }
+
+type xmlTestChild struct {
+	Value *int8 `path:"value" module:"xaug"`
+}
+
+func (*xmlTestChild) IsYANGGoStruct()                         {}
+func (*xmlTestChild) ΛValidate(...ValidationOption) error     { return nil }
+func (*xmlTestChild) ΛEnumTypeMap() map[string][]reflect.Type { return nil }
+func (*xmlTestChild) ΛBelongingModule() string                { return "xaug" }
+
+type xmlTestMultiKey struct {
+	B string `path:"b"`
+	A uint32 `path:"a"`
+}
+
+// xmlTestMKE is a multi-keyed list entry, whose keys are b and a in the
+// order that they are specified by xmlTestMultiKey.
+type xmlTestMKE struct {
+	Value *string `path:"config/value" module:"xmod/xmod"`
+	A     *uint32 `path:"config/a|a" module:"xmod/xmod|xmod"`
+	B     *string `path:"config/b|b" module:"xmod/xmod|xmod"`
+}
+
+func (*xmlTestMKE) IsYANGGoStruct()                         {}
+func (*xmlTestMKE) ΛValidate(...ValidationOption) error     { return nil }
+func (*xmlTestMKE) ΛEnumTypeMap() map[string][]reflect.Type { return nil }
+func (*xmlTestMKE) ΛBelongingModule() string                { return "xmod" }
+
+func (e *xmlTestMKE) ΛListKeyMap() (map[string]any, error) {
+	return map[string]any{"a": *e.A, "b": *e.B}, nil
+}
+
+type xmlTestSKE struct {
+	Value *string `path:"value" module:"xmod"`
+	Key   *string `path:"key" module:"xmod"`
+}
+
+func (*xmlTestSKE) IsYANGGoStruct()                         {}
+func (*xmlTestSKE) ΛValidate(...ValidationOption) error     { return nil }
+func (*xmlTestSKE) ΛEnumTypeMap() map[string][]reflect.Type { return nil }
+func (*xmlTestSKE) ΛBelongingModule() string                { return "xmod" }
+
+func (e *xmlTestSKE) ΛListKeyMap() (map[string]any, error) {
+	return map[string]any{"key": *e.Key}, nil
+}
+
+// xmlTestPlainEntry is a list entry that does not implement
+// KeyHelperGoStruct, whose key leaf is named by the schema of the list.
+type xmlTestPlainEntry struct {
+	Value *string `path:"value" module:"xmod"`
+	Key   *string `path:"key" module:"xmod"`
+}
+
+func (*xmlTestPlainEntry) IsYANGGoStruct()                         {}
+func (*xmlTestPlainEntry) ΛValidate(...ValidationOption) error     { return nil }
+func (*xmlTestPlainEntry) ΛEnumTypeMap() map[string][]reflect.Type { return nil }
+func (*xmlTestPlainEntry) ΛBelongingModule() string                { return "xmod" }
+
+// xmlTestSchema is the schema of xmlTestRoot, within which the tag
+// leaf-list and the single list are ordered-by user.
+var xmlTestSchema = &yang.Entry{
+	Name: "device",
+	Kind: yang.DirectoryEntry,
+	Dir: map[string]*yang.Entry{
+		"config": {
+			Name: "config",
+			Kind: yang.DirectoryEntry,
+			Dir: map[string]*yang.Entry{
+				"tag": {
+					Name:     "tag",
+					Kind:     yang.LeafEntry,
+					ListAttr: &yang.ListAttr{OrderedByUser: true},
+					Type:     &yang.YangType{Kind: yang.Ystring},
+				},
+			},
+		},
+		"single": {
+			Name:     "single",
+			Kind:     yang.DirectoryEntry,
+			ListAttr: &yang.ListAttr{OrderedByUser: true},
+			Key:      "key",
+			Dir: map[string]*yang.Entry{
+				"key":   {Name: "key", Kind: yang.LeafEntry, Type: &yang.YangType{Kind: yang.Ystring}},
+				"value": {Name: "value", Kind: yang.LeafEntry, Type: &yang.YangType{Kind: yang.Ystring}},
+			},
+		},
+		"plain": {
+			Name:     "plain",
+			Kind:     yang.DirectoryEntry,
+			ListAttr: yang.NewDefaultListAttr(),
+			Key:      "key",
+			Dir: map[string]*yang.Entry{
+				"key":   {Name: "key", Kind: yang.LeafEntry, Type: &yang.YangType{Kind: yang.Ystring}},
+				"value": {Name: "value", Kind: yang.LeafEntry, Type: &yang.YangType{Kind: yang.Ystring}},
+			},
+		},
+	},
+}
+
+// xmlTestNamespaces is the set of namespaces used for XML rendering tests.
+var xmlTestNamespaces = map[string]string{
+	"xmod": "urn:x",
+	"xaug": "urn:xa",
+	"foo":  "urn:foo",
+	"bar":  "urn:bar",
+}
+
+func TestMarshalXML(t *testing.T) {
+	tests := []struct {
+		desc             string
+		in               GoStruct
+		inConfig         *XMLConfig
+		want             string
+		wantErrSubstring string
+	}{{
+		desc:     "empty struct",
+		in:       &xmlTestRoot{},
+		inConfig: &XMLConfig{Namespaces: xmlTestNamespaces},
+		want:     `<data xmlns="urn:ietf:params:xml:ns:netconf:base:1.0"/>`,
+	}, {
+		desc: "leaves with compressed paths",
+		in: &xmlTestRoot{
+			Name:    String("a<b"),
+			Counter: Uint32(42),
+			Enabled: Bool(true),
+		},
+		inConfig: &XMLConfig{Namespaces: xmlTestNamespaces},
+		want: `<data xmlns="urn:ietf:params:xml:ns:netconf:base:1.0">` +
+			`<config xmlns="urn:x"><name>a&lt;b</name><enabled>true</enabled></config>` +
+			`<name xmlns="urn:x">a&lt;b</name>` +
+			`<state xmlns="urn:x"><counter>42</counter></state>` +
+			`</data>`,
+	}, {
+		desc: "identityref, leaf-list, binary and empty",
+		in: &xmlTestRoot{
+			Ident: EnumTestVALTWO,
+			Tags:  []string{"one", "two"},
+			Data:  Binary("abc"),
+			Flag:  true,
+		},
+		inConfig: &XMLConfig{Namespaces: xmlTestNamespaces},
+		want: `<data xmlns="urn:ietf:params:xml:ns:netconf:base:1.0">` +
+			`<config xmlns="urn:x">` +
+			`<ident xmlns:bar="urn:bar">bar:VAL_TWO</ident>` +
+			`<tag>one</tag><tag>two</tag>` +
+			`<data>YWJj</data>` +
+			`<flag/>` +
+			`</config>` +
+			`</data>`,
+	}, {
+		desc: "augmented elements in a different namespace",
+		in: &xmlTestRoot{
+			Text:  String("t"),
+			Aug:   String("a"),
+			Child: &xmlTestChild{Value: Int8(-1)},
+		},
+		inConfig: &XMLConfig{Namespaces: xmlTestNamespaces, RootElement: "config"},
+		want: `<config xmlns="urn:ietf:params:xml:ns:netconf:base:1.0">` +
+			`<config xmlns="urn:x"><text>t</text><aug xmlns="urn:xa">a</aug></config>` +
+			`<child xmlns="urn:xa"><value>-1</value></child>` +
+			`</config>`,
+	}, {
+		desc: "lists with keys first in schema order",
+		in: &xmlTestRoot{
+			Multi: map[xmlTestMultiKey]*xmlTestMKE{
+				{B: "y", A: 2}: {B: String("y"), A: Uint32(2), Value: String("v2")},
+				{B: "x", A: 1}: {B: String("x"), A: Uint32(1), Value: String("v1")},
+			},
+			Single: map[string]*xmlTestSKE{
+				"k2": {Key: String("k2")},
+				"k1": {Key: String("k1"), Value: String("v")},
+			},
+			Unkeyed: []*xmlTestSKE{{Value: String("u1")}, {Value: String("u0")}},
+		},
+		inConfig: &XMLConfig{Namespaces: xmlTestNamespaces},
+		want: `<data xmlns="urn:ietf:params:xml:ns:netconf:base:1.0">` +
+			`<multis xmlns="urn:x">` +
+			`<multi><b>x</b><a>1</a><config><value>v1</value><a>1</a><b>x</b></config></multi>` +
+			`<multi><b>y</b><a>2</a><config><value>v2</value><a>2</a><b>y</b></config></multi>` +
+			`</multis>` +
+			`<single xmlns="urn:x"><key>k1</key><value>v</value></single>` +
+			`<single xmlns="urn:x"><key>k2</key></single>` +
+			`<unkeyed xmlns="urn:x"><value>u1</value></unkeyed>` +
+			`<unkeyed xmlns="urn:x"><value>u0</value></unkeyed>` +
+			`</data>`,
+	}, {
+		desc: "list entries keyed by the schema",
+		in: &xmlTestRoot{
+			Plain: map[string]*xmlTestPlainEntry{
+				"k2": {Key: String("k2")},
+				"k1": {Key: String("k1"), Value: String("v")},
+			},
+		},
+		inConfig: &XMLConfig{Namespaces: xmlTestNamespaces, Schema: xmlTestSchema},
+		want: `<data xmlns="urn:ietf:params:xml:ns:netconf:base:1.0">` +
+			`<plain xmlns="urn:x"><key>k1</key><value>v</value></plain>` +
+			`<plain xmlns="urn:x"><key>k2</key></plain>` +
+			`</data>`,
+	}, {
+		desc: "list entries without keys",
+		in: &xmlTestRoot{
+			Plain: map[string]*xmlTestPlainEntry{
+				"k1": {Key: String("k1")},
+			},
+		},
+		inConfig:         &XMLConfig{Namespaces: xmlTestNamespaces},
+		wantErrSubstring: "cannot determine the keys of list entry plain",
+	}, {
+		desc: "list entry without a value for its key",
+		in: &xmlTestRoot{
+			Plain: map[string]*xmlTestPlainEntry{
+				"k1": {Value: String("v")},
+			},
+		},
+		inConfig:         &XMLConfig{Namespaces: xmlTestNamespaces, Schema: xmlTestSchema},
+		wantErrSubstring: "list entry plain has no value for key leaf key",
+	}, {
+		desc: "indented",
+		in: &xmlTestRoot{
+			Child: &xmlTestChild{Value: Int8(1)},
+		},
+		inConfig: &XMLConfig{Namespaces: xmlTestNamespaces, Indent: "  "},
+		want: "<data xmlns=\"urn:ietf:params:xml:ns:netconf:base:1.0\">\n" +
+			"  <child xmlns=\"urn:xa\">\n" +
+			"    <value>1</value>\n" +
+			"  </child>\n" +
+			"</data>\n",
+	}, {
+		desc:             "missing namespace",
+		in:               &xmlTestRoot{Child: &xmlTestChild{Value: Int8(1)}},
+		inConfig:         &XMLConfig{Namespaces: map[string]string{"xmod": "urn:x"}},
+		wantErrSubstring: `no XML namespace specified for module "xaug"`,
+	}, {
+		desc:             "nil struct",
+		in:               (*xmlTestRoot)(nil),
+		inConfig:         &XMLConfig{Namespaces: xmlTestNamespaces},
+		wantErrSubstring: "cannot render nil GoStruct",
+	}, {
+		desc:             "unmapped enum value",
+		in:               &xmlTestRoot{Ident: EnumTestVALTHREE},
+		inConfig:         &XMLConfig{Namespaces: xmlTestNamespaces},
+		wantErrSubstring: "cannot map enumerated value",
+	}}
+
+	for _, tt := range tests {
+		t.Run(tt.desc, func(t *testing.T) {
+			got, err := MarshalXML(tt.in, tt.inConfig)
+			if diff := errdiff.Substring(err, tt.wantErrSubstring); diff != "" {
+				t.Fatalf("MarshalXML(%v): did not get expected error, %s", tt.in, diff)
+			}
+			if err != nil {
+				return
+			}
+			if diff := cmp.Diff(tt.want, string(got)); diff != "" {
+				t.Errorf("MarshalXML(%v): did not get expected output, diff(-want, +got):\n%s", tt.in, diff)
+			}
+		})
+	}
+}
+
+func TestMarshalXMLEditConfig(t *testing.T) {
+	tests := []struct {
+		desc             string
+		inOriginal       GoStruct
+		inModified       GoStruct
+		inConfig         *XMLConfig
+		want             string
+		wantErrSubstring string
+	}{{
+		desc:       "no changes",
+		inOriginal: &xmlTestRoot{Name: String("n")},
+		inModified: &xmlTestRoot{Name: String("n")},
+		inConfig:   &XMLConfig{Namespaces: xmlTestNamespaces},
+		want:       `<config xmlns="urn:ietf:params:xml:ns:netconf:base:1.0"/>`,
+	}, {
+		desc:       "leaf added and modified",
+		inOriginal: &xmlTestRoot{Text: String("a")},
+		inModified: &xmlTestRoot{Text: String("b"), Enabled: Bool(false)},
+		inConfig:   &XMLConfig{Namespaces: xmlTestNamespaces},
+		want: `<config xmlns="urn:ietf:params:xml:ns:netconf:base:1.0">` +
+			`<config xmlns="urn:x"><enabled>false</enabled><text>b</text></config>` +
+			`</config>`,
+	}, {
+		desc:       "leaf and container removed",
+		inOriginal: &xmlTestRoot{Text: String("a"), Enabled: Bool(true), Child: &xmlTestChild{Value: Int8(1)}},
+		inModified: &xmlTestRoot{Enabled: Bool(true)},
+		inConfig:   &XMLConfig{Namespaces: xmlTestNamespaces},
+		want: `<config xmlns="urn:ietf:params:xml:ns:netconf:base:1.0" xmlns:nc="urn:ietf:params:xml:ns:netconf:base:1.0">` +
+			`<config xmlns="urn:x"><text nc:operation="delete">a</text></config>` +
+			`<child xmlns="urn:xa" nc:operation="delete"/>` +
+			`</config>`,
+	}, {
+		desc:       "leaf-list entry removed with remove operation",
+		inOriginal: &xmlTestRoot{Tags: []string{"one", "two"}},
+		inModified: &xmlTestRoot{Tags: []string{"two"}},
+		inConfig:   &XMLConfig{Namespaces: xmlTestNamespaces, DeleteOperation: XMLOperationRemove},
+		want: `<config xmlns="urn:ietf:params:xml:ns:netconf:base:1.0" xmlns:nc="urn:ietf:params:xml:ns:netconf:base:1.0">` +
+			`<config xmlns="urn:x"><tag nc:operation="remove">one</tag></config>` +
+			`</config>`,
+	}, {
+		desc:       "leaf-list entry appended",
+		inOriginal: &xmlTestRoot{Tags: []string{"one", "two"}},
+		inModified: &xmlTestRoot{Tags: []string{"one", "two", "three"}},
+		inConfig:   &XMLConfig{Namespaces: xmlTestNamespaces},
+		want: `<config xmlns="urn:ietf:params:xml:ns:netconf:base:1.0">` +
+			`<config xmlns="urn:x"><tag>three</tag></config>` +
+			`</config>`,
+	}, {
+		desc:       "ordered-by user leaf-list entries reordered",
+		inOriginal: &xmlTestRoot{Tags: []string{"one", "two"}, Text: String("a")},
+		inModified: &xmlTestRoot{Tags: []string{"two", "one"}, Text: String("a")},
+		inConfig:   &XMLConfig{Namespaces: xmlTestNamespaces, Schema: xmlTestSchema},
+		want: `<config xmlns="urn:ietf:params:xml:ns:netconf:base:1.0" xmlns:yang="urn:ietf:params:xml:ns:yang:1">` +
+			`<config xmlns="urn:x">` +
+			`<tag yang:insert="first">two</tag>` +
+			`<tag yang:insert="after" yang:value="two">one</tag>` +
+			`</config>` +
+			`</config>`,
+	}, {
+		desc:       "ordered-by user leaf-list entry inserted before existing entries",
+		inOriginal: &xmlTestRoot{Tags: []string{"two", "three"}},
+		inModified: &xmlTestRoot{Tags: []string{"one", "two"}},
+		inConfig:   &XMLConfig{Namespaces: xmlTestNamespaces, Schema: xmlTestSchema},
+		want: `<config xmlns="urn:ietf:params:xml:ns:netconf:base:1.0" xmlns:nc="urn:ietf:params:xml:ns:netconf:base:1.0" xmlns:yang="urn:ietf:params:xml:ns:yang:1">` +
+			`<config xmlns="urn:x">` +
+			`<tag yang:insert="first">one</tag>` +
+			`<tag yang:insert="after" yang:value="one">two</tag>` +
+			`<tag nc:operation="delete">three</tag>` +
+			`</config>` +
+			`</config>`,
+	}, {
+		desc:       "ordered-by system leaf-list entries reordered",
+		inOriginal: &xmlTestRoot{Tags: []string{"one", "two"}},
+		inModified: &xmlTestRoot{Tags: []string{"two", "one"}},
+		inConfig:   &XMLConfig{Namespaces: xmlTestNamespaces},
+		want:       `<config xmlns="urn:ietf:params:xml:ns:netconf:base:1.0"/>`,
+	}, {
+		desc: "top-level ordered-by user list entry inserted before existing entries",
+		inOriginal: &xmlTestRoot{
+			Single: map[string]*xmlTestSKE{
+				"k1": {Key: String("k1")},
+				"k2": {Key: String("k2"), Value: String("v")},
+			},
+		},
+		inModified: &xmlTestRoot{
+			Single: map[string]*xmlTestSKE{
+				"k0": {Key: String("k0")},
+				"k1": {Key: String("k1")},
+				"k2": {Key: String("k2"), Value: String("v")},
+			},
+		},
+		inConfig: &XMLConfig{Namespaces: xmlTestNamespaces, Schema: xmlTestSchema},
+		want: `<config xmlns="urn:ietf:params:xml:ns:netconf:base:1.0" xmlns:yang="urn:ietf:params:xml:ns:yang:1">` +
+			`<single xmlns="urn:x" yang:insert="first"><key>k0</key></single>` +
+			`<single xmlns="urn:x" xmlns:xmod="urn:x" yang:insert="after" yang:key="[xmod:key='k0']"><key>k1</key></single>` +
+			`<single xmlns="urn:x" xmlns:xmod="urn:x" yang:insert="after" yang:key="[xmod:key='k1']"><key>k2</key></single>` +
+			`</config>`,
+	}, {
+		desc: "list entries keyed by the schema modified and removed",
+		inOriginal: &xmlTestRoot{
+			Plain: map[string]*xmlTestPlainEntry{
+				"k1": {Key: String("k1"), Value: String("v")},
+				"k2": {Key: String("k2")},
+			},
+		},
+		inModified: &xmlTestRoot{
+			Plain: map[string]*xmlTestPlainEntry{
+				"k1": {Key: String("k1"), Value: String("w")},
+			},
+		},
+		inConfig: &XMLConfig{Namespaces: xmlTestNamespaces, Schema: xmlTestSchema},
+		want: `<config xmlns="urn:ietf:params:xml:ns:netconf:base:1.0" xmlns:nc="urn:ietf:params:xml:ns:netconf:base:1.0">` +
+			`<plain xmlns="urn:x"><key>k1</key><value>w</value></plain>` +
+			`<plain xmlns="urn:x" nc:operation="delete"><key>k2</key></plain>` +
+			`</config>`,
+	}, {
+		desc: "list entries modified and removed",
+		inOriginal: &xmlTestRoot{
+			Single: map[string]*xmlTestSKE{
+				"k1": {Key: String("k1"), Value: String("v")},
+				"k2": {Key: String("k2")},
+			},
+		},
+		inModified: &xmlTestRoot{
+			Single: map[string]*xmlTestSKE{
+				"k1": {Key: String("k1"), Value: String("w")},
+				"k3": {Key: String("k3")},
+			},
+		},
+		inConfig: &XMLConfig{Namespaces: xmlTestNamespaces},
+		want: `<config xmlns="urn:ietf:params:xml:ns:netconf:base:1.0" xmlns:nc="urn:ietf:params:xml:ns:netconf:base:1.0">` +
+			`<single xmlns="urn:x"><key>k1</key><value>w</value></single>` +
+			`<single xmlns="urn:x"><key>k3</key></single>` +
+			`<single xmlns="urn:x" nc:operation="delete"><key>k2</key></single>` +
+			`</config>`,
+	}, {
+		desc:             "different types",
+		inOriginal:       &xmlTestRoot{},
+		inModified:       &xmlTestChild{},
+		inConfig:         &XMLConfig{Namespaces: xmlTestNamespaces},
+		wantErrSubstring: "cannot diff structs of different types",
+	}}
+
+	for _, tt := range tests {
+		t.Run(tt.desc, func(t *testing.T) {
+			got, err := MarshalXMLEditConfig(tt.inOriginal, tt.inModified, tt.inConfig)
+			if diff := errdiff.Substring(err, tt.wantErrSubstring); diff != "" {
+				t.Fatalf("MarshalXMLEditConfig: did not get expected error, %s", diff)
+			}
+			if err != nil {
+				return
+			}
+			if diff := cmp.Diff(tt.want, string(got)); diff != "" {
+				t.Errorf("MarshalXMLEditConfig: did not get expected output, diff(-want, +got):\n%s", diff)
+			}
+		})
+	}
+}
@@ -0,0 +1,58 @@
+// Copyright 2024 Google Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package ygot
+
+import (
+	"bytes"
+	"fmt"
+	"reflect"
+
+	"gopkg.in/yaml.v3"
+)
+
+// MarshalYAML renders the supplied value, which must be a GoStruct or a
+// member field of a generated GoStruct, to YAML. The YAML document follows
+// the RFC7951 mapping of YANG data to JSON, such that element names and
+// identityref values are qualified by module names according to the
+// supplied RFC7951JSONConfig. Since YAML represents 64-bit integers and
+// decimal numbers without loss of precision, int64, uint64 and decimal64
+// values are written as YAML numbers rather than as strings.
+func MarshalYAML(d any, args ...Marshal7951Arg) ([]byte, error) {
+	var rfcCfg *RFC7951JSONConfig
+	for _, a := range args {
+		if v, ok := a.(*RFC7951JSONConfig); ok {
+			rfcCfg = v
+		}
+	}
+	j, err := jsonValue(reflect.ValueOf(d), "", jsonOutputConfig{
+		jType:         RFC7951,
+		rfc7951Config: rfcCfg,
+		nativeScalars: true,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	var b bytes.Buffer
+	enc := yaml.NewEncoder(&b)
+	enc.SetIndent(2)
+	if err := enc.Encode(j); err != nil {
+		return nil, fmt.Errorf("could not marshal YAML, %v", err)
+	}
+	if err := enc.Close(); err != nil {
+		return nil, fmt.Errorf("could not marshal YAML, %v", err)
+	}
+	return b.Bytes(), nil
+}
@@ -0,0 +1,95 @@
+// Copyright 2024 Google Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package ygot
+
+import (
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/openconfig/gnmi/errdiff"
+)
+
+func TestMarshalYAML(t *testing.T) {
+	tests := []struct {
+		desc             string
+		in               any
+		inArgs           []Marshal7951Arg
+		want             string
+		wantErrSubstring string
+	}{{
+		desc: "leaves of each type with module names",
+		in: &cborTestRoot{
+			Name:    String("yes"),
+			Counter: Int64(-9007199254740993),
+			Ratio:   Float64(2.5),
+			Enum:    11,
+			Ident:   EnumTestVALTWO,
+			Unions:  []cborTestUnion{&cborTestUnionString{"s"}, &cborTestUnionIdentity{EnumTestVALONE}},
+			Flag:    true,
+			Data:    Binary("abc"),
+			Aug:     Uint8(1),
+		},
+		inArgs: []Marshal7951Arg{&RFC7951JSONConfig{AppendModuleName: true}},
+		want: `cmod:config:
+  name: "yes"
+cmod:name: "yes"
+cmod:state:
+  caug:aug: 1
+  counter: -9007199254740993
+  data: YWJj
+  enum: TEN
+  flag:
+    - null
+  ident: bar:VAL_TWO
+  ratio: 2.5
+  unions:
+    - s
+    - foo:VAL_ONE
+`,
+	}, {
+		desc: "lists",
+		in: &cborTestRoot{
+			List:    map[string]*cborTestSKE{"b": {Key: String("b")}, "a": {Key: String("a")}},
+			Unkeyed: []*cborTestSKE{{Key: String("u")}},
+		},
+		want: `entries:
+  entry:
+    - key: a
+    - key: b
+unkeyed:
+  - key: u
+`,
+	}, {
+		desc: "member field",
+		in:   []*cborTestSKE{{Key: String("u")}},
+		want: "- key: u\n",
+	}, {
+		desc:             "invalid enum value",
+		in:               &cborTestRoot{Enum: 42},
+		wantErrSubstring: "cannot map enumerated value",
+	}}
+
+	for _, tt := range tests {
+		t.Run(tt.desc, func(t *testing.T) {
+			got, err := MarshalYAML(tt.in, tt.inArgs...)
+			if diff := errdiff.Substring(err, tt.wantErrSubstring); diff != "" {
+				t.Fatalf("MarshalYAML: did not get expected error, %s", diff)
+			}
+			if diff := cmp.Diff(tt.want, string(got)); diff != "" {
+				t.Errorf("MarshalYAML: did not get expected output, diff(-want, +got):\n%s", diff)
+			}
+		})
+	}
+}
@@ -152,6 +152,22 @@ type GenConfig struct {
 	// code generation. This is due to the fact that some schemas (e.g., OpenConfig
 	// interfaces) currently result in overlapping entities (e.g., /interfaces).
 	ExcludeModules []string
+	// PathAllowlist is a set of uncompressed schema paths to which the
+	// schema is pruned prior to generating path structs. It must match the
+	// allowlist used to generate the schema structs, if any. See the
+	// option of the same name in ygen.TransformationOpts.
+	PathAllowlist []string
+	// NameLock, if set, specifies the names that are assigned to the
+	// directories, enumerated types and unions of the schema. It must match
+	// the lock used to generate the schema structs, if any. See the option
+	// of the same name in ygen.IROptions.
+	NameLock *ygen.NameLock
+	// NameOverrides, if set, specifies names that are used in place of
+	// those that are generated by default, such that the names of path
+	// structs and their methods follow those of the schema structs. It
+	// must match the overrides used to generate the schema structs, if
+	// any. See the option of the same name in ygen.IROptions.
+	NameOverrides *ygen.NameOverrides
 	// YANGParseOptions provides the options that should be handed to the
 	// github.com/openconfig/goyang/pkg/yang library. These specify how the
 	// input YANG files should be parsed.
@@ -220,6 +236,44 @@ func (goLangMapper) PopulateFieldFlags(nd ygen.NodeDetails, field *yang.Entry) m
 	return flags
 }
 
+// GenerateIR returns the IR from which path structs are generated for the
+// YANG files, yangFiles, whose dependencies are found within includePaths,
+// using the options within cg. It allows callers to inspect the names that
+// are assigned to the generated path structs, for example in order to write
+// a name lock.
+func (cg *GenConfig) GenerateIR(yangFiles, includePaths []string) (*ygen.IR, error) {
+	compressBehaviour, err := genutil.TranslateToCompressBehaviour(true, cg.ExcludeState, cg.PreferOperationalState)
+	if err != nil {
+		return nil, fmt.Errorf("ypathgen: unable to translate compress behaviour: %v", err)
+	}
+
+	opts := ygen.IROptions{
+		ParseOptions: ygen.ParseOpts{
+			IgnoreUnsupportedStatements: cg.IgnoreUnsupportedStatements,
+			YANGParseOptions:            cg.YANGParseOptions,
+			ExcludeModules:              cg.ExcludeModules,
+		},
+		TransformationOptions: ygen.TransformationOpts{
+			CompressBehaviour:                    compressBehaviour,
+			GenerateFakeRoot:                     true,
+			FakeRootName:                         cg.FakeRootName,
+			SkipEnumDeduplication:                cg.SkipEnumDeduplication,
+			ShortenEnumLeafNames:                 cg.ShortenEnumLeafNames,
+			EnumOrgPrefixesToTrim:                cg.EnumOrgPrefixesToTrim,
+			UseDefiningModuleForTypedefEnumNames: cg.UseDefiningModuleForTypedefEnumNames,
+			EnumerationsUseUnderscores:           true,
+			PathAllowlist:                        cg.PathAllowlist,
+		},
+		NestedDirectories:                   false,
+		AbsoluteMapPaths:                    false,
+		AppendEnumSuffixForSimpleUnionEnums: cg.AppendEnumSuffixForSimpleUnionEnums,
+		NameLock:                            cg.NameLock,
+		NameOverrides:                       cg.NameOverrides,
+	}
+
+	return ygen.GenerateIR(yangFiles, includePaths, goLangMapper{GoLangMapper: gogen.NewGoLangMapper(true)}, opts)
+}
+
 // GeneratePathCode takes a slice of strings containing the path to a set of YANG
 // files which contain YANG modules, and a second slice of strings which
 // specifies the set of paths that are to be searched for associated models (e.g.,
@@ -246,34 +300,8 @@ func (cg *GenConfig) GeneratePathCode(yangFiles, includePaths []string) (map[str
 	// many ways in which compilation may fail, coupled with the plethora
 	// of configurations, means there is an argument to force the user to
 	// debug instead of making ypathgen having to catch every error.
-	compressBehaviour, err := genutil.TranslateToCompressBehaviour(true, cg.ExcludeState, cg.PreferOperationalState)
-	if err != nil {
-		return nil, nil, util.NewErrs(fmt.Errorf("ypathgen: unable to translate compress behaviour: %v", err))
-	}
-
-	opts := ygen.IROptions{
-		ParseOptions: ygen.ParseOpts{
-			IgnoreUnsupportedStatements: cg.IgnoreUnsupportedStatements,
-			YANGParseOptions:            cg.YANGParseOptions,
-			ExcludeModules:              cg.ExcludeModules,
-		},
-		TransformationOptions: ygen.TransformationOpts{
-			CompressBehaviour:                    compressBehaviour,
-			GenerateFakeRoot:                     true,
-			FakeRootName:                         cg.FakeRootName,
-			SkipEnumDeduplication:                cg.SkipEnumDeduplication,
-			ShortenEnumLeafNames:                 cg.ShortenEnumLeafNames,
-			EnumOrgPrefixesToTrim:                cg.EnumOrgPrefixesToTrim,
-			UseDefiningModuleForTypedefEnumNames: cg.UseDefiningModuleForTypedefEnumNames,
-			EnumerationsUseUnderscores:           true,
-		},
-		NestedDirectories:                   false,
-		AbsoluteMapPaths:                    false,
-		AppendEnumSuffixForSimpleUnionEnums: cg.AppendEnumSuffixForSimpleUnionEnums,
-	}
-
 	var errs util.Errors
-	ir, err := ygen.GenerateIR(yangFiles, includePaths, goLangMapper{GoLangMapper: gogen.NewGoLangMapper(true)}, opts)
+	ir, err := cg.GenerateIR(yangFiles, includePaths)
 	if err != nil {
 		return nil, nil, util.AppendErr(errs, err)
 	}
@@ -293,6 +321,11 @@ func (cg *GenConfig) GeneratePathCode(yangFiles, includePaths []string) (map[str
 	var structSnippets []GoPathStructCodeSnippet
 	for _, directoryPath := range ir.OrderedDirectoryPathsByName() {
 		directory := ir.Directories[directoryPath]
+		if directory.InOperation {
+			// RPC, action and notification contents are not
+			// addressable by data tree paths.
+			continue
+		}
 
 		var listBuilderKeyThreshold uint
 		if cg.GenerateWildcardPaths {
@@ -630,6 +663,9 @@ func getNodeDataMap(ir *ygen.IR, fakeRootName, schemaStructPkgAccessor, pathStru
 	nodeDataMap := NodeDataMap{}
 	var errs util.Errors
 	for _, dir := range ir.Directories {
+		if dir.InOperation {
+			continue
+		}
 		if dir.IsFakeRoot {
 			// Since we always generate the fake root, we add the
 			// fake root GoStruct to the data map as well.
@@ -656,7 +692,8 @@ func getNodeDataMap(ir *ygen.IR, fakeRootName, schemaStructPkgAccessor, pathStru
 			}
 
 			mType := field.LangType
-			isLeaf := mType != nil
+			isAnyData := field.Type == ygen.AnyDataNode
+			isLeaf := mType != nil || isAnyData
 
 			subsumingGoStructName := dir.Name
 			if !isLeaf {
@@ -665,6 +702,10 @@ func getNodeDataMap(ir *ygen.IR, fakeRootName, schemaStructPkgAccessor, pathStru
 
 			var goTypeName, localGoTypeName string
 			switch {
+			case isAnyData:
+				// anydata and anyxml nodes are leaves whose contents are
+				// stored using the generic ygot type.
+				goTypeName = "*ygot.AnyData"
 			case !isLeaf:
 				goTypeName = "*" + schemaStructPkgAccessor + subsumingGoStructName
 				localGoTypeName = "*" + subsumingGoStructName
@@ -694,7 +735,7 @@ func getNodeDataMap(ir *ygen.IR, fakeRootName, schemaStructPkgAccessor, pathStru
 				SubsumingGoStructName: subsumingGoStructName,
 				IsLeaf:                isLeaf,
 				IsScalarField:         gogen.IsScalarField(field),
-				HasDefault:            isLeaf && (len(field.YANGDetails.Defaults) > 0 || mType.DefaultValue != nil),
+				HasDefault:            mType != nil && (len(field.YANGDetails.Defaults) > 0 || mType.DefaultValue != nil),
 				YANGTypeName:          yangTypeName,
 				YANGPath:              field.YANGDetails.Path,
 				GoPathPackageName:     goPackageName(field.YANGDetails.RootElementModule, splitByModule, false, packageName, packageSuffix, trimPackagePrefix),
@@ -879,8 +920,9 @@ func generateDirectorySnippet(directory *ygen.ParsedDirectory, directories map[s
 
 		// Since leaves don't have their own Directory entries, we need
 		// to output their struct snippets somewhere, and here is
-		// convenient.
-		if field.Type == ygen.LeafNode || field.Type == ygen.LeafListNode {
+		// convenient. anydata and anyxml nodes are also leaves of the
+		// data tree.
+		if field.Type == ygen.LeafNode || field.Type == ygen.LeafListNode || field.Type == ygen.AnyDataNode {
 			leafTypeName, err := getFieldTypeName(directory, fName, goFieldName, directories, pathStructSuffix)
 			if err != nil {
 				errs = util.AppendErr(errs, err)
@@ -1009,6 +1009,38 @@ func TestGeneratePathCode(t *testing.T) {
 	}
 }
 
+// TestGeneratePathCodeNameOverrides tests that the names of path structs and
+// their methods follow the overridden names of the schema structs and their
+// fields, and that the paths that they generate are unchanged.
+func TestGeneratePathCodeNameOverrides(t *testing.T) {
+	cg := NewDefaultConfig("github.com/openconfig/ygot/ypathgen/testdata/exampleoc")
+	cg.GeneratingBinary = "pathgen-tests"
+	cg.FakeRootName = "device"
+	cg.PackageName = "ocstructs"
+	cg.NameOverrides = &ygen.NameOverrides{
+		Nodes: map[string]*ygen.NameOverride{
+			"/interfaces/interface/vrrp/vrrp-group/interface-tracking": {Type: "VrrpTracking"},
+			"/interfaces/interface/config/description":                 {Field: "Desc"},
+		},
+	}
+	code, _, err := cg.GeneratePathCode([]string{filepath.Join(datapath, "name-overrides.yang")}, nil)
+	if err != nil {
+		t.Fatalf("GeneratePathCode: got unexpected error: %v", err)
+	}
+	got := code[cg.PackageName].String()
+	for _, want := range []string{
+		"type VrrpTrackingPath struct",
+		"func (n *Interface_VrrpGroupPath) InterfaceTracking() *VrrpTrackingPath {",
+		"type Interface_DescPath struct",
+		"func (n *InterfacePath) Desc() *Interface_DescPath {",
+		`[]string{"config", "description"}`,
+	} {
+		if !strings.Contains(got, want) {
+			t.Errorf("GeneratePathCode: did not find %q in generated code", want)
+		}
+	}
+}
+
 func TestGeneratePathCodeSplitFiles(t *testing.T) {
 	tests := []struct {
 		name                  string   // Name is the identifier for the test.
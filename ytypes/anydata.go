@@ -0,0 +1,141 @@
+// Copyright 2023 Google Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package ytypes
+
+import (
+	"encoding/json"
+	"fmt"
+	"reflect"
+
+	"github.com/openconfig/goyang/pkg/yang"
+	"github.com/openconfig/ygot/util"
+	"github.com/openconfig/ygot/ygot"
+
+	gpb "github.com/openconfig/gnmi/proto/gnmi"
+)
+
+// AnyDataSchema is an unmarshal option that specifies the Schema that
+// describes the contents of the anydata or anyxml node at Path. When the
+// option is supplied, the contents of the node are unmarshalled into a new
+// instance of the root of the Schema, which is stored as the Value of the
+// ygot.AnyData field. Otherwise, the contents are stored as raw RFC7951 JSON.
+type AnyDataSchema struct {
+	// Path is the schema path of the anydata or anyxml node, excluding
+	// module names, e.g., "/modules-state/module/data".
+	Path string
+	// Schema is the schema that describes the contents of the node.
+	Schema *Schema
+}
+
+// IsUnmarshalOpt marks AnyDataSchema as a valid UnmarshalOpt.
+func (*AnyDataSchema) IsUnmarshalOpt() {}
+
+// anyDataSchema returns the Schema specified in opts for the anydata or
+// anyxml node with the supplied schema, or nil if none is specified.
+func anyDataSchema(schema *yang.Entry, opts []UnmarshalOpt) *Schema {
+	var path string
+	for _, o := range opts {
+		ao, ok := o.(*AnyDataSchema)
+		if !ok {
+			continue
+		}
+		if path == "" {
+			path = util.SchemaTreePathNoModule(schema)
+		}
+		if ao.Path == path {
+			return ao.Schema
+		}
+	}
+	return nil
+}
+
+// validateAnyData validates value, which must be a *ygot.AnyData, against
+// the supplied anydata or anyxml schema. Since the schema does not describe
+// the contents of the node, only GoStruct contents, which carry their own
+// schema, are validated in detail.
+func validateAnyData(schema *yang.Entry, value interface{}) util.Errors {
+	if util.IsValueNil(value) {
+		return nil
+	}
+
+	ad, ok := value.(*ygot.AnyData)
+	if !ok {
+		return util.NewErrs(fmt.Errorf("type %T is not a *ygot.AnyData for anydata schema %s", value, schema.Name))
+	}
+
+	switch {
+	case !util.IsValueNil(ad.Value):
+		if err := ygot.ValidateGoStruct(ad.Value); err != nil {
+			return util.NewErrs(fmt.Errorf("invalid contents for anydata %s: %v", schema.Name, err))
+		}
+	case len(ad.JSON) != 0 && !json.Valid(ad.JSON):
+		return util.NewErrs(fmt.Errorf("invalid JSON contents for anydata %s", schema.Name))
+	}
+	return nil
+}
+
+// unmarshalAnyData unmarshals value, which contains the contents of the
+// anydata or anyxml node with the supplied schema, into the corresponding
+// field of parent. In JSONEncoding mode, value is the decoded JSON tree of
+// the contents. In GNMIEncoding mode, value must be a TypedValue containing
+// RFC7951 JSON.
+func unmarshalAnyData(schema *yang.Entry, parent interface{}, value interface{}, enc Encoding, opts ...UnmarshalOpt) error {
+	if util.IsValueNil(value) {
+		if enc == JSONEncoding {
+			return nil
+		}
+		return fmt.Errorf("unmarshalAnyData: invalid nil value to unmarshal")
+	}
+
+	util.DbgPrint("unmarshalAnyData value %v, type %T, into parent type %T, schema name %s", util.ValueStrDebug(value), value, parent, schema.Name)
+
+	fieldName, _, err := schemaToStructFieldName(schema, parent, hasPreferShadowPath(opts))
+	if err != nil {
+		return fmt.Errorf("unmarshal failed: %v", err)
+	}
+
+	var js []byte
+	switch enc {
+	case JSONEncoding:
+		if js, err = json.Marshal(value); err != nil {
+			return fmt.Errorf("cannot marshal contents of anydata %s: %v", schema.Name, err)
+		}
+	case GNMIEncoding, gNMIEncodingWithJSONTolerance:
+		tv, ok := value.(*gpb.TypedValue)
+		if !ok || tv.GetJsonIetfVal() == nil {
+			return fmt.Errorf("got %T for anydata %s, want TypedValue with JSON_IETF value", value, schema.Name)
+		}
+		js = tv.GetJsonIetfVal()
+	default:
+		return fmt.Errorf("unknown encoding %v", enc)
+	}
+
+	ad := &ygot.AnyData{JSON: js}
+	if s := anyDataSchema(schema, opts); s != nil {
+		if !s.IsValid() {
+			return fmt.Errorf("invalid schema for anydata %s: not fully populated", schema.Name)
+		}
+		root, ok := reflect.New(reflect.TypeOf(s.Root).Elem()).Interface().(ygot.GoStruct)
+		if !ok {
+			return fmt.Errorf("root %T of schema for anydata %s is not a GoStruct", s.Root, schema.Name)
+		}
+		if err := s.Unmarshal(js, root, opts...); err != nil {
+			return fmt.Errorf("cannot unmarshal contents of anydata %s: %v", schema.Name, err)
+		}
+		ad = &ygot.AnyData{Value: root}
+	}
+
+	return util.InsertIntoStruct(parent, fieldName, ad)
+}
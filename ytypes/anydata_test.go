@@ -0,0 +1,222 @@
+// Copyright 2023 Google Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package ytypes
+
+import (
+	"encoding/json"
+	"reflect"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/openconfig/gnmi/errdiff"
+	"github.com/openconfig/goyang/pkg/yang"
+	"github.com/openconfig/ygot/ygot"
+
+	gpb "github.com/openconfig/gnmi/proto/gnmi"
+)
+
+type anyDataParent struct {
+	Data *ygot.AnyData `path:"data"`
+}
+
+func (*anyDataParent) IsYANGGoStruct()                          {}
+func (*anyDataParent) ΛValidate(...ygot.ValidationOption) error { return nil }
+func (*anyDataParent) ΛEnumTypeMap() map[string][]reflect.Type  { return nil }
+func (*anyDataParent) ΛBelongingModule() string                 { return "admod" }
+
+type anyDataContents struct {
+	Name *string `path:"name"`
+}
+
+func (*anyDataContents) IsYANGGoStruct()                          {}
+func (*anyDataContents) ΛValidate(...ygot.ValidationOption) error { return nil }
+func (*anyDataContents) ΛEnumTypeMap() map[string][]reflect.Type  { return nil }
+func (*anyDataContents) ΛBelongingModule() string                 { return "othermod" }
+
+// anyDataTestSchemas returns the schema of a container holding an anydata
+// node, along with a Schema describing the contents of the anydata node.
+func anyDataTestSchemas() (*yang.Entry, *Schema) {
+	parent := &yang.Entry{
+		Name:   "top",
+		Kind:   yang.DirectoryEntry,
+		Dir:    map[string]*yang.Entry{},
+		Parent: &yang.Entry{Name: "admod"},
+	}
+	parent.Dir["data"] = &yang.Entry{
+		Name:   "data",
+		Kind:   yang.AnyDataEntry,
+		Parent: parent,
+	}
+
+	contents := &yang.Entry{
+		Name: "contents",
+		Kind: yang.DirectoryEntry,
+		Dir: map[string]*yang.Entry{
+			"name": {
+				Name: "name",
+				Kind: yang.LeafEntry,
+				Type: &yang.YangType{Kind: yang.Ystring},
+			},
+		},
+	}
+	s := &Schema{
+		Root:       &anyDataContents{},
+		SchemaTree: map[string]*yang.Entry{"anyDataContents": contents},
+		Unmarshal: func(b []byte, d ygot.GoStruct, opts ...UnmarshalOpt) error {
+			var v any
+			if err := json.Unmarshal(b, &v); err != nil {
+				return err
+			}
+			return Unmarshal(contents, d, v, opts...)
+		},
+	}
+	return parent, s
+}
+
+func TestUnmarshalAnyData(t *testing.T) {
+	parentSchema, contentSchema := anyDataTestSchemas()
+
+	tests := []struct {
+		desc             string
+		inJSON           string
+		inOpts           []UnmarshalOpt
+		want             *anyDataParent
+		wantErrSubstring string
+	}{{
+		desc:   "contents stored as JSON",
+		inJSON: `{"data": {"othermod:name": "foo"}}`,
+		want: &anyDataParent{
+			Data: &ygot.AnyData{JSON: []byte(`{"othermod:name":"foo"}`)},
+		},
+	}, {
+		desc:   "contents unmarshalled using schema",
+		inJSON: `{"data": {"name": "foo"}}`,
+		inOpts: []UnmarshalOpt{&AnyDataSchema{Path: "/top/data", Schema: contentSchema}},
+		want: &anyDataParent{
+			Data: &ygot.AnyData{Value: &anyDataContents{Name: ygot.String("foo")}},
+		},
+	}, {
+		desc:   "schema for a different path",
+		inJSON: `{"data": {"name": "foo"}}`,
+		inOpts: []UnmarshalOpt{&AnyDataSchema{Path: "/other/data", Schema: contentSchema}},
+		want: &anyDataParent{
+			Data: &ygot.AnyData{JSON: []byte(`{"name":"foo"}`)},
+		},
+	}, {
+		desc:             "contents invalid for schema",
+		inJSON:           `{"data": {"bad-leaf": "foo"}}`,
+		inOpts:           []UnmarshalOpt{&AnyDataSchema{Path: "/top/data", Schema: contentSchema}},
+		wantErrSubstring: "cannot unmarshal contents of anydata data",
+	}}
+
+	for _, tt := range tests {
+		t.Run(tt.desc, func(t *testing.T) {
+			var v any
+			if err := json.Unmarshal([]byte(tt.inJSON), &v); err != nil {
+				t.Fatalf("cannot unmarshal input JSON: %v", err)
+			}
+
+			got := &anyDataParent{}
+			err := Unmarshal(parentSchema, got, v, tt.inOpts...)
+			if diff := errdiff.Substring(err, tt.wantErrSubstring); diff != "" {
+				t.Fatalf("did not get expected error, %s", diff)
+			}
+			if err != nil {
+				return
+			}
+			if diff := cmp.Diff(tt.want, got); diff != "" {
+				t.Errorf("did not get expected struct, (-want, +got):\n%s", diff)
+			}
+		})
+	}
+}
+
+func TestUnmarshalAnyDataGNMI(t *testing.T) {
+	parentSchema, _ := anyDataTestSchemas()
+	dataSchema := parentSchema.Dir["data"]
+
+	tests := []struct {
+		desc             string
+		inVal            any
+		want             *anyDataParent
+		wantErrSubstring string
+	}{{
+		desc:  "JSON_IETF value",
+		inVal: &gpb.TypedValue{Value: &gpb.TypedValue_JsonIetfVal{JsonIetfVal: []byte(`{"othermod:name":"foo"}`)}},
+		want: &anyDataParent{
+			Data: &ygot.AnyData{JSON: []byte(`{"othermod:name":"foo"}`)},
+		},
+	}, {
+		desc:             "scalar value",
+		inVal:            &gpb.TypedValue{Value: &gpb.TypedValue_StringVal{StringVal: "foo"}},
+		wantErrSubstring: "want TypedValue with JSON_IETF value",
+	}, {
+		desc:             "nil value",
+		wantErrSubstring: "invalid nil value",
+	}}
+
+	for _, tt := range tests {
+		t.Run(tt.desc, func(t *testing.T) {
+			got := &anyDataParent{}
+			err := unmarshalGeneric(dataSchema, got, tt.inVal, GNMIEncoding)
+			if diff := errdiff.Substring(err, tt.wantErrSubstring); diff != "" {
+				t.Fatalf("did not get expected error, %s", diff)
+			}
+			if err != nil {
+				return
+			}
+			if diff := cmp.Diff(tt.want, got); diff != "" {
+				t.Errorf("did not get expected struct, (-want, +got):\n%s", diff)
+			}
+		})
+	}
+}
+
+func TestValidateAnyData(t *testing.T) {
+	parentSchema, _ := anyDataTestSchemas()
+	dataSchema := parentSchema.Dir["data"]
+
+	tests := []struct {
+		desc    string
+		in      any
+		wantErr bool
+	}{{
+		desc: "nil value",
+		in:   (*ygot.AnyData)(nil),
+	}, {
+		desc: "valid JSON contents",
+		in:   &ygot.AnyData{JSON: []byte(`{"othermod:name":"foo"}`)},
+	}, {
+		desc:    "invalid JSON contents",
+		in:      &ygot.AnyData{JSON: []byte(`{`)},
+		wantErr: true,
+	}, {
+		desc: "GoStruct contents",
+		in:   &ygot.AnyData{Value: &anyDataContents{Name: ygot.String("foo")}},
+	}, {
+		desc:    "wrong type",
+		in:      ygot.String("foo"),
+		wantErr: true,
+	}}
+
+	for _, tt := range tests {
+		t.Run(tt.desc, func(t *testing.T) {
+			errs := Validate(dataSchema, tt.in)
+			if got, want := errs != nil, tt.wantErr; got != want {
+				t.Errorf("got error %v, want error: %v", errs, want)
+			}
+		})
+	}
+}
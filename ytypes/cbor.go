@@ -0,0 +1,381 @@
+// Copyright 2024 Google Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package ytypes
+
+import (
+	"encoding/base64"
+	"fmt"
+	"math/big"
+	"strconv"
+	"strings"
+
+	"github.com/fxamacker/cbor/v2"
+	"github.com/openconfig/goyang/pkg/yang"
+	"github.com/openconfig/ygot/util"
+	"github.com/openconfig/ygot/ygot"
+
+	gpb "github.com/openconfig/gnmi/proto/gnmi"
+)
+
+// UnmarshalCBOR unmarshals the YANG-CBOR encoded data, as defined in RFC9254,
+// into the parent GoStruct, which must correspond to the supplied schema.
+// sids specifies the SIDs of data nodes and identities, and must be set if
+// the data uses SID-based member identifiers or encodes identityref values
+// as SIDs. Both SID-based and name-based member identifiers are accepted.
+//
+// The data is converted to the equivalent RFC7951 JSON tree by using the
+// schema to determine the type of each leaf, such that the same validation
+// is applied as for JSON input.
+func UnmarshalCBOR(schema *yang.Entry, parent interface{}, data []byte, sids *ygot.SIDMap, opts ...UnmarshalOpt) error {
+	if schema == nil {
+		return fmt.Errorf("nil schema for parent type %T", parent)
+	}
+	var v interface{}
+	if err := cbor.Unmarshal(data, &v); err != nil {
+		return fmt.Errorf("cannot parse CBOR: %v", err)
+	}
+	m, ok := v.(map[interface{}]interface{})
+	if !ok {
+		return fmt.Errorf("CBOR data for %s is not a map, got %T", schema.Name, v)
+	}
+
+	d := &cborDecoder{sids: sids}
+	sid, err := d.schemaSID(schema)
+	if err != nil {
+		return err
+	}
+	tree, err := d.toJSONTree(schema, m, sid)
+	if err != nil {
+		return err
+	}
+	return Unmarshal(schema, parent, tree, opts...)
+}
+
+// UnmarshalCBORTypedValue unmarshals the YANG-CBOR encoded bytes carried by
+// the supplied gNMI TypedValue, as generated by ygot.EncodeCBORTypedValue,
+// into the parent GoStruct as per UnmarshalCBOR.
+func UnmarshalCBORTypedValue(schema *yang.Entry, parent interface{}, tv *gpb.TypedValue, sids *ygot.SIDMap, opts ...UnmarshalOpt) error {
+	b, ok := tv.GetValue().(*gpb.TypedValue_BytesVal)
+	if !ok {
+		return fmt.Errorf("TypedValue does not carry CBOR bytes, got type %T", tv.GetValue())
+	}
+	return UnmarshalCBOR(schema, parent, b.BytesVal, sids, opts...)
+}
+
+// cborDecoder converts decoded YANG-CBOR data to RFC7951 JSON trees.
+type cborDecoder struct {
+	sids *ygot.SIDMap
+}
+
+// schemaSID returns the SID of the data node described by schema, which is
+// 0 for the root of the schema.
+func (d *cborDecoder) schemaSID(schema *yang.Entry) (uint64, error) {
+	var elems []string
+	for e := schema; e.Parent != nil && !util.IsFakeRoot(e); e = e.Parent {
+		if !util.IsChoiceOrCase(e) {
+			elems = append([]string{e.Name}, elems...)
+		}
+	}
+	if len(elems) == 0 || d.sids == nil {
+		return 0, nil
+	}
+	p := "/" + strings.Join(elems, "/")
+	sid, ok := d.sids.DataSID(p)
+	if !ok {
+		return 0, fmt.Errorf("no SID found for schema path %s", p)
+	}
+	return sid, nil
+}
+
+// memberName returns the name of the member of the data node with the
+// supplied SID that is identified by the CBOR map key k, along with the SID
+// of the member.
+func (d *cborDecoder) memberName(k interface{}, sid uint64) (string, uint64, error) {
+	if s, ok := k.(string); ok {
+		return util.StripModulePrefix(s), 0, nil
+	}
+	delta, ok := cborInt(k)
+	if !ok {
+		return "", 0, fmt.Errorf("invalid CBOR map key %v of type %T", k, k)
+	}
+	if d.sids == nil {
+		return "", 0, fmt.Errorf("SID-based member identifier %v used without SIDs", k)
+	}
+	csid := new(big.Int).Add(new(big.Int).SetUint64(sid), delta)
+	if !csid.IsUint64() {
+		return "", 0, fmt.Errorf("invalid SID delta %v for parent SID %d", k, sid)
+	}
+	p, ok := d.sids.DataPath(csid.Uint64())
+	if !ok {
+		return "", 0, fmt.Errorf("unknown SID %d", csid.Uint64())
+	}
+	i := strings.LastIndex(p, "/")
+	if sid != 0 {
+		if pp, _ := d.sids.DataPath(sid); pp != p[:i] {
+			return "", 0, fmt.Errorf("SID %d of %s is not a child of SID %d", csid.Uint64(), p, sid)
+		}
+	}
+	return util.StripModulePrefix(p[i+1:]), csid.Uint64(), nil
+}
+
+// toJSONTree converts the CBOR map m, which corresponds to the container or
+// list entry described by schema with the supplied SID, to an RFC7951 JSON
+// tree. Members that are not described by the schema are retained, such
+// that they are reported by Unmarshal unless extra fields are ignored.
+func (d *cborDecoder) toJSONTree(schema *yang.Entry, m map[interface{}]interface{}, sid uint64) (map[string]interface{}, error) {
+	children := map[string]*yang.Entry{}
+	for _, ch := range util.FindFirstNonChoiceOrCase(schema) {
+		children[ch.Name] = ch
+	}
+
+	tree := map[string]interface{}{}
+	for k, v := range m {
+		name, csid, err := d.memberName(k, sid)
+		if err != nil {
+			return nil, err
+		}
+		if _, ok := tree[name]; ok {
+			return nil, fmt.Errorf("duplicate member %s in %s", name, schema.Path())
+		}
+		cs, ok := children[name]
+		if !ok {
+			tree[name] = v
+			continue
+		}
+
+		switch {
+		case cs.IsList():
+			l, ok := v.([]interface{})
+			if !ok {
+				return nil, fmt.Errorf("CBOR value for list %s is not an array, got %T", cs.Path(), v)
+			}
+			var jl []interface{}
+			for _, e := range l {
+				em, ok := e.(map[interface{}]interface{})
+				if !ok {
+					return nil, fmt.Errorf("CBOR value for list entry %s is not a map, got %T", cs.Path(), e)
+				}
+				je, err := d.toJSONTree(cs, em, csid)
+				if err != nil {
+					return nil, err
+				}
+				jl = append(jl, je)
+			}
+			tree[name] = jl
+		case cs.IsLeafList():
+			l, ok := v.([]interface{})
+			if !ok {
+				return nil, fmt.Errorf("CBOR value for leaf-list %s is not an array, got %T", cs.Path(), v)
+			}
+			var jl []interface{}
+			for _, e := range l {
+				je, err := d.leafToJSON(cs, e)
+				if err != nil {
+					return nil, err
+				}
+				jl = append(jl, je)
+			}
+			tree[name] = jl
+		case cs.IsLeaf():
+			jv, err := d.leafToJSON(cs, v)
+			if err != nil {
+				return nil, err
+			}
+			tree[name] = jv
+		case cs.IsDir():
+			cm, ok := v.(map[interface{}]interface{})
+			if !ok {
+				return nil, fmt.Errorf("CBOR value for container %s is not a map, got %T", cs.Path(), v)
+			}
+			jv, err := d.toJSONTree(cs, cm, csid)
+			if err != nil {
+				return nil, err
+			}
+			tree[name] = jv
+		default:
+			tree[name] = v
+		}
+	}
+	return tree, nil
+}
+
+// leafToJSON converts the CBOR value v of the leaf or leaf-list described by
+// schema to the corresponding RFC7951 JSON value.
+func (d *cborDecoder) leafToJSON(schema *yang.Entry, v interface{}) (interface{}, error) {
+	s, err := util.ResolveIfLeafRef(schema)
+	if err != nil {
+		return nil, err
+	}
+	if s.Type == nil {
+		return nil, fmt.Errorf("nil type for schema %s", schema.Path())
+	}
+
+	if s.Type.Kind != yang.Yunion {
+		jv, err := d.valueToJSON(s.Type, v)
+		if err != nil {
+			return nil, fmt.Errorf("cannot convert CBOR value %v for schema %s: %v", v, schema.Path(), err)
+		}
+		return jv, nil
+	}
+
+	// Within a union, enumerations and identityrefs are tagged such that
+	// they can be distinguished from other member types.
+	if t, ok := v.(cbor.Tag); ok {
+		switch t.Number {
+		case ygot.CBORTagEnumeration:
+			if n, ok := t.Content.(string); ok {
+				return n, nil
+			}
+			return nil, fmt.Errorf("invalid enumeration value %v in union %s", t.Content, schema.Path())
+		case ygot.CBORTagIdentityref:
+			return d.identityToJSON(t.Content)
+		}
+	}
+	for _, t := range util.FlattenedTypes(s.Type.Type) {
+		if jv, err := d.valueToJSON(t, v); err == nil {
+			return jv, nil
+		}
+	}
+	return nil, fmt.Errorf("value %v does not match any type of union %s", v, schema.Path())
+}
+
+// identityToJSON converts the CBOR identityref value v, which is either a
+// SID or a module-qualified name, to the corresponding RFC7951 JSON value.
+func (d *cborDecoder) identityToJSON(v interface{}) (interface{}, error) {
+	if s, ok := v.(string); ok {
+		return s, nil
+	}
+	sid, ok := v.(uint64)
+	if !ok {
+		return nil, fmt.Errorf("invalid identityref value %v of type %T", v, v)
+	}
+	if d.sids == nil {
+		return nil, fmt.Errorf("SID-based identityref %d used without SIDs", sid)
+	}
+	mod, name, ok := d.sids.Identity(sid)
+	if !ok {
+		return nil, fmt.Errorf("unknown identity SID %d", sid)
+	}
+	return fmt.Sprintf("%s:%s", mod, name), nil
+}
+
+// valueToJSON converts the CBOR value v of type t to the corresponding
+// RFC7951 JSON value.
+func (d *cborDecoder) valueToJSON(t *yang.YangType, v interface{}) (interface{}, error) {
+	switch t.Kind {
+	case yang.Ystring:
+		if s, ok := v.(string); ok {
+			return s, nil
+		}
+	case yang.Ybinary:
+		if b, ok := v.([]byte); ok {
+			return base64.StdEncoding.EncodeToString(b), nil
+		}
+	case yang.Ybool:
+		if b, ok := v.(bool); ok {
+			return b, nil
+		}
+	case yang.Yempty:
+		if v == nil {
+			return []interface{}{nil}, nil
+		}
+	case yang.Yint8, yang.Yint16, yang.Yint32, yang.Yuint8, yang.Yuint16, yang.Yuint32:
+		i, ok := cborInt(v)
+		if !ok {
+			break
+		}
+		if !i.IsInt64() {
+			return nil, fmt.Errorf("value %v is out of range for %v", i, t.Kind)
+		}
+		f := float64(i.Int64())
+		if err := checkJSONFloat64Range(t.Kind, f); err != nil {
+			return nil, err
+		}
+		return f, nil
+	case yang.Yint64:
+		if i, ok := cborInt(v); ok && i.IsInt64() {
+			return strconv.FormatInt(i.Int64(), 10), nil
+		}
+	case yang.Yuint64:
+		if i, ok := cborInt(v); ok && i.IsUint64() {
+			return strconv.FormatUint(i.Uint64(), 10), nil
+		}
+	case yang.Ydecimal64:
+		return decimal64ToJSON(v)
+	case yang.Yenum:
+		i, ok := cborInt(v)
+		if !ok || !i.IsInt64() || t.Enum == nil {
+			break
+		}
+		name := t.Enum.Name(i.Int64())
+		if name == "" {
+			return nil, fmt.Errorf("%d is not a value of the enumeration", i)
+		}
+		return name, nil
+	case yang.Yidentityref:
+		return d.identityToJSON(v)
+	default:
+		return nil, fmt.Errorf("unsupported type %v", t.Kind)
+	}
+	return nil, fmt.Errorf("invalid CBOR value of type %T for %v", v, t.Kind)
+}
+
+// decimal64ToJSON converts the CBOR decimal fraction v to the RFC7951 JSON
+// representation of a decimal64 value.
+func decimal64ToJSON(v interface{}) (interface{}, error) {
+	t, ok := v.(cbor.Tag)
+	if !ok || t.Number != ygot.CBORTagDecimal64 {
+		return nil, fmt.Errorf("decimal64 value %v is not a decimal fraction", v)
+	}
+	c, ok := t.Content.([]interface{})
+	if !ok || len(c) != 2 {
+		return nil, fmt.Errorf("invalid decimal fraction %v", t.Content)
+	}
+	exp, ok := cborInt(c[0])
+	if !ok || !exp.IsInt64() || exp.Int64() > 0 || exp.Int64() < -18 {
+		return nil, fmt.Errorf("invalid decimal fraction exponent %v", c[0])
+	}
+	m, ok := cborInt(c[1])
+	if !ok || !m.IsInt64() {
+		return nil, fmt.Errorf("invalid decimal fraction mantissa %v", c[1])
+	}
+
+	digits := int(-exp.Int64())
+	s := strconv.FormatInt(m.Int64(), 10)
+	neg := strings.HasPrefix(s, "-")
+	s = strings.TrimPrefix(s, "-")
+	if digits > 0 {
+		if len(s) <= digits {
+			s = strings.Repeat("0", digits-len(s)+1) + s
+		}
+		s = s[:len(s)-digits] + "." + s[len(s)-digits:]
+	}
+	if neg {
+		s = "-" + s
+	}
+	return s, nil
+}
+
+// cborInt returns the integer value of the decoded CBOR value v, and whether
+// v is an integer.
+func cborInt(v interface{}) (*big.Int, bool) {
+	switch i := v.(type) {
+	case uint64:
+		return new(big.Int).SetUint64(i), true
+	case int64:
+		return big.NewInt(i), true
+	}
+	return nil, false
+}
@@ -0,0 +1,113 @@
+// Copyright 2024 Google Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package ytypes_test
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/openconfig/goyang/pkg/yang"
+	"github.com/openconfig/ygot/integration_tests/schemaops/ctestschema"
+	"github.com/openconfig/ygot/util"
+	"github.com/openconfig/ygot/ygot"
+	"github.com/openconfig/ygot/ytypes"
+)
+
+// ctestschemaSIDs assigns a SID to each data node of the ctestschema
+// Device schema, in the order in which they are found.
+func ctestschemaSIDs(t *testing.T) *ygot.SIDMap {
+	t.Helper()
+	m := ygot.NewSIDMap()
+	sid := uint64(60000)
+	var assign func(e *yang.Entry, path string)
+	assign = func(e *yang.Entry, path string) {
+		for _, ch := range util.FindFirstNonChoiceOrCase(e) {
+			name := ch.Name
+			switch {
+			case path == "" && name == "ordered-multikeyed-lists":
+				name = "ctestschema-rootmod:" + name
+			case path == "" || e.Name == "ordered-multikeyed-lists":
+				// The contents of the container defined by the root
+				// module are augmented by ctestschema.
+				name = "ctestschema:" + name
+			}
+			p := fmt.Sprintf("%s/%s", path, name)
+			if err := m.AddDataSID(p, sid); err != nil {
+				t.Fatalf("cannot add SID for %s: %v", p, err)
+			}
+			sid++
+			if ch.IsDir() {
+				assign(ch, p)
+			}
+		}
+	}
+	assign(ctestschema.SchemaTree["Device"], "")
+	return m
+}
+
+func TestCBORRoundTrip(t *testing.T) {
+	in := &ctestschema.Device{
+		OrderedList:           ctestschema.GetNestedOrderedMap(t),
+		OrderedMultikeyedList: ctestschema.GetOrderedMapMultikeyed(t),
+		OtherData: &ctestschema.OtherData{
+			Motd: ygot.String("hello"),
+		},
+	}
+	opts := cmp.AllowUnexported(ctestschema.OrderedList_OrderedMap{}, ctestschema.OrderedList_OrderedList_OrderedMap{}, ctestschema.OrderedMultikeyedList_OrderedMap{})
+
+	tests := []struct {
+		desc     string
+		inConfig *ygot.CBORConfig
+	}{{
+		desc: "name-based identifiers",
+	}, {
+		desc:     "SID-based identifiers",
+		inConfig: &ygot.CBORConfig{SIDs: ctestschemaSIDs(t)},
+	}}
+
+	for _, tt := range tests {
+		t.Run(tt.desc, func(t *testing.T) {
+			var sids *ygot.SIDMap
+			if tt.inConfig != nil {
+				sids = tt.inConfig.SIDs
+			}
+
+			b, err := ygot.MarshalCBOR(in, tt.inConfig)
+			if err != nil {
+				t.Fatalf("MarshalCBOR: %v", err)
+			}
+			got := &ctestschema.Device{}
+			if err := ytypes.UnmarshalCBOR(ctestschema.SchemaTree["Device"], got, b, sids); err != nil {
+				t.Fatalf("UnmarshalCBOR: %v", err)
+			}
+			if diff := cmp.Diff(in, got, opts); diff != "" {
+				t.Errorf("did not get expected struct after CBOR round trip, diff(-want, +got):\n%s", diff)
+			}
+
+			tv, err := ygot.EncodeCBORTypedValue(in, tt.inConfig)
+			if err != nil {
+				t.Fatalf("EncodeCBORTypedValue: %v", err)
+			}
+			gotTV := &ctestschema.Device{}
+			if err := ytypes.UnmarshalCBORTypedValue(ctestschema.SchemaTree["Device"], gotTV, tv, sids); err != nil {
+				t.Fatalf("UnmarshalCBORTypedValue: %v", err)
+			}
+			if diff := cmp.Diff(in, gotTV, opts); diff != "" {
+				t.Errorf("did not get expected struct after CBOR TypedValue round trip, diff(-want, +got):\n%s", diff)
+			}
+		})
+	}
+}
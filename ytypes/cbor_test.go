@@ -0,0 +1,360 @@
+// Copyright 2024 Google Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package ytypes
+
+import (
+	"testing"
+
+	"github.com/fxamacker/cbor/v2"
+	"github.com/google/go-cmp/cmp"
+	"github.com/openconfig/gnmi/errdiff"
+	"github.com/openconfig/goyang/pkg/yang"
+	"github.com/openconfig/ygot/ygot"
+
+	gpb "github.com/openconfig/gnmi/proto/gnmi"
+)
+
+func TestUnmarshalCBOR(t *testing.T) {
+	enumType := yang.NewEnumType()
+	enumType.Set("E_VALUE_FORTY_ONE", 41)
+	enumType.Set("E_VALUE_FORTY_TWO", 42)
+
+	rootSchema := &yang.Entry{
+		Name: "device",
+		Kind: yang.DirectoryEntry,
+		Annotation: map[string]interface{}{
+			"isFakeRoot": true,
+		},
+		Dir: map[string]*yang.Entry{
+			"container": {
+				Name: "container",
+				Kind: yang.DirectoryEntry,
+				Dir: map[string]*yang.Entry{
+					"int": {
+						Name: "int",
+						Kind: yang.LeafEntry,
+						Type: &yang.YangType{Kind: yang.Yuint8},
+					},
+					"big": {
+						Name: "big",
+						Kind: yang.LeafEntry,
+						Type: &yang.YangType{Kind: yang.Yint64},
+					},
+					"enum": {
+						Name: "enum",
+						Kind: yang.LeafEntry,
+						Type: &yang.YangType{Kind: yang.Yenum, Enum: enumType},
+					},
+					"empty": {
+						Name: "empty",
+						Kind: yang.LeafEntry,
+						Type: &yang.YangType{Kind: yang.Yempty},
+					},
+					"binary": {
+						Name: "binary",
+						Kind: yang.LeafEntry,
+						Type: &yang.YangType{Kind: yang.Ybinary},
+					},
+					"strings": {
+						Name:     "strings",
+						Kind:     yang.LeafEntry,
+						ListAttr: yang.NewDefaultListAttr(),
+						Type:     &yang.YangType{Kind: yang.Ystring},
+					},
+				},
+			},
+			"list": {
+				Name:     "list",
+				Kind:     yang.DirectoryEntry,
+				ListAttr: yang.NewDefaultListAttr(),
+				Key:      "key",
+				Config:   yang.TSTrue,
+				Dir: map[string]*yang.Entry{
+					"key": {
+						Name: "key",
+						Kind: yang.LeafEntry,
+						Type: &yang.YangType{Kind: yang.Ystring},
+					},
+				},
+			},
+		},
+	}
+	populateParentField(nil, rootSchema)
+
+	type ContainerStruct struct {
+		Int     *uint8    `path:"int"`
+		Big     *int64    `path:"big"`
+		Enum    EnumType  `path:"enum"`
+		Empty   YANGEmpty `path:"empty"`
+		Binary  Binary    `path:"binary"`
+		Strings []string  `path:"strings"`
+	}
+
+	type ListStruct struct {
+		Key *string `path:"key"`
+	}
+
+	type RootStruct struct {
+		Container *ContainerStruct       `path:"container"`
+		List      map[string]*ListStruct `path:"list"`
+	}
+
+	sids := ygot.NewSIDMap()
+	for p, sid := range map[string]uint64{
+		"/t:container":      100,
+		"/t:container/int":  101,
+		"/t:container/enum": 102,
+		"/t:list":           110,
+		"/t:list/key":       111,
+	} {
+		if err := sids.AddDataSID(p, sid); err != nil {
+			t.Fatalf("cannot add SID for %s: %v", p, err)
+		}
+	}
+
+	tests := []struct {
+		desc    string
+		in      interface{}
+		inSIDs  *ygot.SIDMap
+		want    *RootStruct
+		wantErr string
+	}{{
+		desc: "name-based identifiers",
+		in: map[interface{}]interface{}{
+			"t:container": map[interface{}]interface{}{
+				"int":     uint64(42),
+				"big":     int64(-9007199254740993),
+				"enum":    uint64(42),
+				"empty":   nil,
+				"binary":  []byte("abc"),
+				"strings": []interface{}{"a", "b"},
+			},
+			"t:list": []interface{}{
+				map[interface{}]interface{}{"key": "k"},
+			},
+		},
+		want: &RootStruct{
+			Container: &ContainerStruct{
+				Int:     ygot.Uint8(42),
+				Big:     ygot.Int64(-9007199254740993),
+				Enum:    42,
+				Empty:   true,
+				Binary:  Binary("abc"),
+				Strings: []string{"a", "b"},
+			},
+			List: map[string]*ListStruct{"k": {Key: ygot.String("k")}},
+		},
+	}, {
+		desc: "SID-based identifiers",
+		in: map[interface{}]interface{}{
+			uint64(100): map[interface{}]interface{}{
+				uint64(1): uint64(42),
+				uint64(2): uint64(41),
+				// Name-based identifiers may be used alongside SIDs.
+				"big": int64(1),
+			},
+			uint64(110): []interface{}{
+				map[interface{}]interface{}{uint64(1): "k"},
+			},
+		},
+		inSIDs: sids,
+		want: &RootStruct{
+			Container: &ContainerStruct{
+				Int:  ygot.Uint8(42),
+				Big:  ygot.Int64(1),
+				Enum: 41,
+			},
+			List: map[string]*ListStruct{"k": {Key: ygot.String("k")}},
+		},
+	}, {
+		desc:    "data is not a map",
+		in:      []interface{}{},
+		wantErr: "CBOR data for device is not a map",
+	}, {
+		desc:    "SID-based identifier without SIDs",
+		in:      map[interface{}]interface{}{uint64(100): map[interface{}]interface{}{}},
+		wantErr: "SID-based member identifier 100 used without SIDs",
+	}, {
+		desc:    "SID of a node that is not a child",
+		in:      map[interface{}]interface{}{uint64(100): map[interface{}]interface{}{uint64(10): nil}},
+		inSIDs:  sids,
+		wantErr: "SID 110 of /t:list is not a child of SID 100",
+	}, {
+		desc:    "unknown SID",
+		in:      map[interface{}]interface{}{uint64(100): map[interface{}]interface{}{uint64(20): nil}},
+		inSIDs:  sids,
+		wantErr: "unknown SID 120",
+	}, {
+		desc:    "out of range integer",
+		in:      map[interface{}]interface{}{"container": map[interface{}]interface{}{"int": uint64(256)}},
+		wantErr: "cannot convert CBOR value 256 for schema /device/container/int",
+	}, {
+		desc:    "invalid enumeration value",
+		in:      map[interface{}]interface{}{"container": map[interface{}]interface{}{"enum": uint64(40)}},
+		wantErr: "40 is not a value of the enumeration",
+	}, {
+		desc:    "list is not an array",
+		in:      map[interface{}]interface{}{"list": map[interface{}]interface{}{}},
+		wantErr: "CBOR value for list /device/list is not an array",
+	}, {
+		desc:    "unknown member",
+		in:      map[interface{}]interface{}{"fish": "chips"},
+		wantErr: "JSON contains unexpected field fish",
+	}}
+
+	for _, tt := range tests {
+		t.Run(tt.desc, func(t *testing.T) {
+			b, err := cbor.Marshal(tt.in)
+			if err != nil {
+				t.Fatalf("cannot marshal CBOR input: %v", err)
+			}
+			got := &RootStruct{}
+			err = UnmarshalCBOR(rootSchema, got, b, tt.inSIDs)
+			if diff := errdiff.Substring(err, tt.wantErr); diff != "" {
+				t.Fatalf("UnmarshalCBOR: did not get expected error, %s", diff)
+			}
+			if err != nil {
+				return
+			}
+			if diff := cmp.Diff(tt.want, got); diff != "" {
+				t.Errorf("UnmarshalCBOR: did not get expected struct, diff(-want, +got):\n%s", diff)
+			}
+		})
+	}
+
+	if err := UnmarshalCBORTypedValue(rootSchema, &RootStruct{}, &gpb.TypedValue{Value: &gpb.TypedValue_StringVal{StringVal: "x"}}, nil); err == nil {
+		t.Errorf("UnmarshalCBORTypedValue: did not get expected error for string TypedValue")
+	}
+}
+
+func TestCBORLeafToJSON(t *testing.T) {
+	sids := ygot.NewSIDMap()
+	if err := sids.AddIdentitySID("m", "ID_ONE", 500); err != nil {
+		t.Fatalf("cannot add identity SID: %v", err)
+	}
+
+	enumType := yang.NewEnumType()
+	enumType.Set("ZERO", 0)
+
+	unionSchema := &yang.Entry{
+		Name: "union",
+		Kind: yang.LeafEntry,
+		Type: &yang.YangType{
+			Kind: yang.Yunion,
+			Type: []*yang.YangType{
+				{Kind: yang.Yint32},
+				{Kind: yang.Yenum, Enum: enumType},
+				{Kind: yang.Yidentityref},
+				{Kind: yang.Ystring},
+			},
+		},
+	}
+
+	tests := []struct {
+		desc    string
+		inSIDs  *ygot.SIDMap
+		in      interface{}
+		want    interface{}
+		wantErr string
+	}{{
+		desc: "integer member",
+		in:   int64(-5),
+		want: float64(-5),
+	}, {
+		desc: "string member",
+		in:   "abc",
+		want: "abc",
+	}, {
+		desc: "tagged enumeration",
+		in:   cbor.Tag{Number: ygot.CBORTagEnumeration, Content: "ZERO"},
+		want: "ZERO",
+	}, {
+		desc:   "tagged identityref SID",
+		inSIDs: sids,
+		in:     cbor.Tag{Number: ygot.CBORTagIdentityref, Content: uint64(500)},
+		want:   "m:ID_ONE",
+	}, {
+		desc:    "tagged identityref SID without SIDs",
+		in:      cbor.Tag{Number: ygot.CBORTagIdentityref, Content: uint64(500)},
+		wantErr: "SID-based identityref 500 used without SIDs",
+	}, {
+		desc:    "unknown identity SID",
+		inSIDs:  sids,
+		in:      cbor.Tag{Number: ygot.CBORTagIdentityref, Content: uint64(501)},
+		wantErr: "unknown identity SID 501",
+	}, {
+		desc:    "no matching member",
+		in:      []byte("abc"),
+		wantErr: "does not match any type of union",
+	}}
+
+	for _, tt := range tests {
+		t.Run(tt.desc, func(t *testing.T) {
+			d := &cborDecoder{sids: tt.inSIDs}
+			got, err := d.leafToJSON(unionSchema, tt.in)
+			if diff := errdiff.Substring(err, tt.wantErr); diff != "" {
+				t.Fatalf("leafToJSON: did not get expected error, %s", diff)
+			}
+			if diff := cmp.Diff(tt.want, got); diff != "" {
+				t.Errorf("leafToJSON: did not get expected value, diff(-want, +got):\n%s", diff)
+			}
+		})
+	}
+}
+
+func TestDecimal64ToJSON(t *testing.T) {
+	tests := []struct {
+		desc    string
+		in      interface{}
+		want    interface{}
+		wantErr string
+	}{{
+		desc: "integer",
+		in:   cbor.Tag{Number: ygot.CBORTagDecimal64, Content: []interface{}{uint64(0), uint64(42)}},
+		want: "42",
+	}, {
+		desc: "fraction",
+		in:   cbor.Tag{Number: ygot.CBORTagDecimal64, Content: []interface{}{int64(-2), uint64(27315)}},
+		want: "273.15",
+	}, {
+		desc: "negative fraction less than one",
+		in:   cbor.Tag{Number: ygot.CBORTagDecimal64, Content: []interface{}{int64(-3), int64(-1)}},
+		want: "-0.001",
+	}, {
+		desc:    "untagged value",
+		in:      uint64(42),
+		wantErr: "is not a decimal fraction",
+	}, {
+		desc:    "invalid exponent",
+		in:      cbor.Tag{Number: ygot.CBORTagDecimal64, Content: []interface{}{uint64(1), uint64(1)}},
+		wantErr: "invalid decimal fraction exponent 1",
+	}, {
+		desc:    "invalid content",
+		in:      cbor.Tag{Number: ygot.CBORTagDecimal64, Content: []interface{}{uint64(1)}},
+		wantErr: "invalid decimal fraction",
+	}}
+
+	for _, tt := range tests {
+		t.Run(tt.desc, func(t *testing.T) {
+			got, err := decimal64ToJSON(tt.in)
+			if diff := errdiff.Substring(err, tt.wantErr); diff != "" {
+				t.Fatalf("decimal64ToJSON: did not get expected error, %s", diff)
+			}
+			if diff := cmp.Diff(tt.want, got); diff != "" {
+				t.Errorf("decimal64ToJSON: did not get expected value, diff(-want, +got):\n%s", diff)
+			}
+		})
+	}
+}
@@ -21,6 +21,8 @@ import (
 	"github.com/openconfig/goyang/pkg/yang"
 	"github.com/openconfig/ygot/util"
 	"github.com/openconfig/ygot/ygot"
+
+	gpb "github.com/openconfig/gnmi/proto/gnmi"
 )
 
 // Refer to: https://tools.ietf.org/html/rfc6020#section-7.9.
@@ -61,6 +63,11 @@ func validateChoice(schema *yang.Entry, structValue ygot.GoStruct) (selected []s
 func IsCaseSelected(schema *yang.Entry, value interface{}) (selected []string, errors []error) {
 	v := reflect.ValueOf(value).Elem()
 	for i := 0; i < v.NumField(); i++ {
+		// Choices that are generated as sum types cannot have more than
+		// one case selected, and are validated with their parent.
+		if util.IsChoiceField(v.Type().Field(i)) {
+			continue
+		}
 		if !util.IsValueNilOrDefault(v.Field(i).Interface()) {
 			fieldType := v.Type().Field(i)
 			cs, err := util.ChildSchema(schema, fieldType)
@@ -86,3 +93,115 @@ func IsCaseSelected(schema *yang.Entry, value interface{}) (selected []string, e
 
 	return
 }
+
+// unmarshalChoice unmarshals the contents of jsonTree that correspond to the
+// YANG choice stored in the field ft of parent, which has value f, and
+// returns the data tree paths of the fields of all of the cases of the
+// choice. The case that is present in jsonTree is determined by unmarshalling
+// into each of the case types returned by the ΛChoiceCaseTypes method of
+// parent, and it is an error for the contents of more than one case to be
+// present. If the selected case is the case that is already stored in f, then
+// the contents of jsonTree are merged into it.
+func unmarshalChoice(schema *yang.Entry, parent interface{}, f reflect.Value, ft reflect.StructField, jsonTree map[string]interface{}, enc Encoding, opts ...UnmarshalOpt) ([][]string, error) {
+	ctm, ok := parent.(ygot.ChoiceCaseTypeMapper)
+	if !ok {
+		return nil, fmt.Errorf("%T does not specify the cases of choice field %s", parent, ft.Name)
+	}
+
+	var allSchemaPaths [][]string
+	var selected []reflect.Value
+	for _, t := range ctm.ΛChoiceCaseTypes()[ft.Name] {
+		if !util.IsTypeStructPtr(t) || !t.Implements(ft.Type) {
+			return nil, fmt.Errorf("invalid type %v for a case of choice field %s in %T", t, ft.Name, parent)
+		}
+		c := reflect.New(t.Elem())
+		sp, err := unmarshalStructFields(schema, c.Interface(), jsonTree, enc, opts...)
+		if err != nil {
+			return nil, err
+		}
+		allSchemaPaths = append(allSchemaPaths, sp...)
+		if !c.Elem().IsZero() {
+			selected = append(selected, c)
+		}
+	}
+
+	switch {
+	case len(selected) == 0:
+		return allSchemaPaths, nil
+	case len(selected) > 1:
+		var names []string
+		for _, c := range selected {
+			names = append(names, c.Elem().Type().Name())
+		}
+		return nil, fmt.Errorf("multiple cases %v selected for choice field %s in %T", names, ft.Name, parent)
+	}
+
+	c := selected[0]
+	if !f.IsNil() && f.Elem().Type() == c.Type() {
+		if _, err := unmarshalStructFields(schema, f.Elem().Interface(), jsonTree, enc, opts...); err != nil {
+			return nil, err
+		}
+		return allSchemaPaths, nil
+	}
+	f.Set(c)
+	return allSchemaPaths, nil
+}
+
+// choiceCaseForPath returns the struct representing the case of the choice
+// stored in the field ft of parent, which has value fv, that has a field
+// whose path is a prefix of path. If the selected case has no such field and
+// create is set, then a struct for the case that does is created and stored
+// in fv, replacing any existing case. nil is returned if no such case is
+// found.
+func choiceCaseForPath(parent interface{}, fv reflect.Value, ft reflect.StructField, path *gpb.Path, create bool) (interface{}, error) {
+	if !fv.IsNil() && caseMatchesPath(fv.Elem().Type(), path) {
+		return fv.Interface(), nil
+	}
+	if !create {
+		return nil, nil
+	}
+
+	ctm, ok := parent.(ygot.ChoiceCaseTypeMapper)
+	if !ok {
+		return nil, fmt.Errorf("%T does not specify the cases of choice field %s", parent, ft.Name)
+	}
+	for _, t := range ctm.ΛChoiceCaseTypes()[ft.Name] {
+		if util.IsTypeStructPtr(t) && t.Implements(ft.Type) && caseMatchesPath(t, path) {
+			c := reflect.New(t.Elem())
+			fv.Set(c)
+			return c.Interface(), nil
+		}
+	}
+	return nil, nil
+}
+
+// caseMatchesPath reports whether the struct pointed to by t, which
+// represents a case of a choice, has a field whose path is a prefix of path.
+func caseMatchesPath(t reflect.Type, path *gpb.Path) bool {
+	for i := 0; i < t.Elem().NumField(); i++ {
+		ft := t.Elem().Field(i)
+		if util.IsChoiceField(ft) {
+			ctm, ok := reflect.New(t.Elem()).Interface().(ygot.ChoiceCaseTypeMapper)
+			if !ok {
+				continue
+			}
+			for _, ct := range ctm.ΛChoiceCaseTypes()[ft.Name] {
+				if util.IsTypeStructPtr(ct) && caseMatchesPath(ct, path) {
+					return true
+				}
+			}
+			continue
+		}
+
+		paths, err := util.SchemaPaths(ft)
+		if err != nil {
+			continue
+		}
+		for _, p := range append(paths, util.ShadowSchemaPaths(ft)...) {
+			if util.PathMatchesPrefix(path, p) {
+				return true
+			}
+		}
+	}
+	return false
+}
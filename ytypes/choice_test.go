@@ -19,9 +19,13 @@ import (
 	"reflect"
 	"testing"
 
+	"github.com/google/go-cmp/cmp"
 	"github.com/kylelemons/godebug/pretty"
+	"github.com/openconfig/gnmi/errdiff"
 	"github.com/openconfig/goyang/pkg/yang"
 	"github.com/openconfig/ygot/ygot"
+
+	gpb "github.com/openconfig/gnmi/proto/gnmi"
 )
 
 type ChoiceStruct struct {
@@ -357,3 +361,274 @@ func TestUnmarshalChoice(t *testing.T) {
 		})
 	}
 }
+
+// sumTypeParent is a GoStruct containing choices that are represented as sum
+// types, such that only one case of each choice can be selected.
+type sumTypeParent struct {
+	Name      *string          `path:"name"`
+	Transport sumTypeTransport `choice:"transport"`
+}
+
+func (*sumTypeParent) IsYANGGoStruct()                          {}
+func (*sumTypeParent) ΛValidate(...ygot.ValidationOption) error { return nil }
+func (*sumTypeParent) ΛEnumTypeMap() map[string][]reflect.Type  { return nil }
+func (*sumTypeParent) ΛBelongingModule() string                 { return "bar" }
+func (*sumTypeParent) ΛChoiceCaseTypes() map[string][]reflect.Type {
+	return map[string][]reflect.Type{
+		"Transport": {reflect.TypeOf((*sumTypeTCP)(nil)), reflect.TypeOf((*sumTypeUDP)(nil))},
+	}
+}
+
+type sumTypeTransport interface {
+	ygot.GoChoiceCase
+	Is_sumTypeTransport()
+}
+
+type sumTypeTCP struct {
+	TCPPort *int32 `path:"tcp-port"`
+}
+
+func (*sumTypeTCP) IsYANGChoiceCase()    {}
+func (*sumTypeTCP) Is_sumTypeTransport() {}
+
+type sumTypeUDP struct {
+	UDPPort *int32      `path:"udp-port"`
+	Mode    sumTypeMode `choice:"mode"`
+}
+
+func (*sumTypeUDP) IsYANGChoiceCase()    {}
+func (*sumTypeUDP) Is_sumTypeTransport() {}
+func (*sumTypeUDP) ΛChoiceCaseTypes() map[string][]reflect.Type {
+	return map[string][]reflect.Type{
+		"Mode": {reflect.TypeOf((*sumTypeLite)(nil))},
+	}
+}
+
+type sumTypeMode interface {
+	ygot.GoChoiceCase
+	Is_sumTypeMode()
+}
+
+type sumTypeLite struct {
+	Lite *bool `path:"lite"`
+}
+
+func (*sumTypeLite) IsYANGChoiceCase() {}
+func (*sumTypeLite) Is_sumTypeMode()   {}
+
+// sumTypeSchema returns the schema of the container represented by
+// sumTypeParent.
+func sumTypeSchema() *yang.Entry {
+	s := &yang.Entry{
+		Name: "parent",
+		Kind: yang.DirectoryEntry,
+		Dir: map[string]*yang.Entry{
+			"name": {
+				Name: "name",
+				Kind: yang.LeafEntry,
+				Type: &yang.YangType{Kind: yang.Ystring},
+			},
+			"transport": {
+				Name: "transport",
+				Kind: yang.ChoiceEntry,
+				Dir: map[string]*yang.Entry{
+					"tcp": {
+						Name: "tcp",
+						Kind: yang.CaseEntry,
+						Dir: map[string]*yang.Entry{
+							"tcp-port": {
+								Name: "tcp-port",
+								Kind: yang.LeafEntry,
+								Type: &yang.YangType{Kind: yang.Yint32},
+							},
+						},
+					},
+					"udp": {
+						Name: "udp",
+						Kind: yang.CaseEntry,
+						Dir: map[string]*yang.Entry{
+							"udp-port": {
+								Name: "udp-port",
+								Kind: yang.LeafEntry,
+								Type: &yang.YangType{Kind: yang.Yint32},
+							},
+							"mode": {
+								Name: "mode",
+								Kind: yang.ChoiceEntry,
+								Dir: map[string]*yang.Entry{
+									"lite": {
+										Name: "lite",
+										Kind: yang.CaseEntry,
+										Dir: map[string]*yang.Entry{
+											"lite": {
+												Name: "lite",
+												Kind: yang.LeafEntry,
+												Type: &yang.YangType{Kind: yang.Ybool},
+											},
+										},
+									},
+								},
+							},
+						},
+					},
+				},
+			},
+		},
+	}
+	populateParentField(nil, s)
+	return s
+}
+
+func TestUnmarshalChoiceSumType(t *testing.T) {
+	tests := []struct {
+		desc             string
+		inParent         *sumTypeParent
+		inJSON           string
+		want             *sumTypeParent
+		wantErrSubstring string
+	}{{
+		desc:   "no case selected",
+		inJSON: `{"name": "foo"}`,
+		want:   &sumTypeParent{Name: ygot.String("foo")},
+	}, {
+		desc:   "case selected",
+		inJSON: `{"name": "foo", "tcp-port": 42}`,
+		want: &sumTypeParent{
+			Name:      ygot.String("foo"),
+			Transport: &sumTypeTCP{TCPPort: ygot.Int32(42)},
+		},
+	}, {
+		desc:   "nested choice",
+		inJSON: `{"udp-port": 42, "lite": true}`,
+		want: &sumTypeParent{
+			Transport: &sumTypeUDP{
+				UDPPort: ygot.Int32(42),
+				Mode:    &sumTypeLite{Lite: ygot.Bool(true)},
+			},
+		},
+	}, {
+		desc:     "merged into existing case",
+		inParent: &sumTypeParent{Transport: &sumTypeUDP{UDPPort: ygot.Int32(42)}},
+		inJSON:   `{"lite": true}`,
+		want: &sumTypeParent{
+			Transport: &sumTypeUDP{
+				UDPPort: ygot.Int32(42),
+				Mode:    &sumTypeLite{Lite: ygot.Bool(true)},
+			},
+		},
+	}, {
+		desc:     "replaces existing case",
+		inParent: &sumTypeParent{Transport: &sumTypeUDP{UDPPort: ygot.Int32(42)}},
+		inJSON:   `{"tcp-port": 43}`,
+		want:     &sumTypeParent{Transport: &sumTypeTCP{TCPPort: ygot.Int32(43)}},
+	}, {
+		desc:             "multiple cases selected",
+		inJSON:           `{"tcp-port": 42, "udp-port": 43}`,
+		wantErrSubstring: "multiple cases",
+	}, {
+		desc:             "unknown field",
+		inJSON:           `{"bad-field": 42}`,
+		wantErrSubstring: "JSON contains unexpected field bad-field",
+	}}
+
+	for _, tt := range tests {
+		t.Run(tt.desc, func(t *testing.T) {
+			var jsonTree interface{}
+			if err := json.Unmarshal([]byte(tt.inJSON), &jsonTree); err != nil {
+				t.Fatalf("cannot unmarshal input JSON: %v", err)
+			}
+
+			got := tt.inParent
+			if got == nil {
+				got = &sumTypeParent{}
+			}
+			err := Unmarshal(sumTypeSchema(), got, jsonTree)
+			if diff := errdiff.Substring(err, tt.wantErrSubstring); diff != "" {
+				t.Fatalf("did not get expected error, %s", diff)
+			}
+			if err != nil {
+				return
+			}
+			if diff := cmp.Diff(tt.want, got); diff != "" {
+				t.Errorf("did not get expected struct, (-want, +got):\n%s", diff)
+			}
+		})
+	}
+}
+
+func TestValidateChoiceSumType(t *testing.T) {
+	tests := []struct {
+		desc    string
+		in      *sumTypeParent
+		wantErr bool
+	}{{
+		desc: "no case selected",
+		in:   &sumTypeParent{Name: ygot.String("foo")},
+	}, {
+		desc: "nested case selected",
+		in: &sumTypeParent{
+			Transport: &sumTypeUDP{
+				UDPPort: ygot.Int32(42),
+				Mode:    &sumTypeLite{Lite: ygot.Bool(true)},
+			},
+		},
+	}, {
+		desc: "case with no schema",
+		in: &sumTypeParent{
+			Transport: &sumTypeBadCase{Bad: ygot.Int32(42)},
+		},
+		wantErr: true,
+	}}
+
+	for _, tt := range tests {
+		t.Run(tt.desc, func(t *testing.T) {
+			errs := Validate(sumTypeSchema(), tt.in)
+			if got, want := errs != nil, tt.wantErr; got != want {
+				t.Errorf("got error %v, want error: %v", errs, want)
+			}
+		})
+	}
+}
+
+type sumTypeBadCase struct {
+	Bad *int32 `path:"bad"`
+}
+
+func (*sumTypeBadCase) IsYANGChoiceCase()    {}
+func (*sumTypeBadCase) Is_sumTypeTransport() {}
+
+func TestChoiceSumTypeNodes(t *testing.T) {
+	schema := sumTypeSchema()
+	root := &sumTypeParent{Transport: &sumTypeTCP{TCPPort: ygot.Int32(42)}}
+	litePath := &gpb.Path{Elem: []*gpb.PathElem{{Name: "lite"}}}
+
+	if err := SetNode(schema, root, litePath, &gpb.TypedValue{Value: &gpb.TypedValue_BoolVal{BoolVal: true}}, &InitMissingElements{}); err != nil {
+		t.Fatalf("SetNode: cannot set %v: %v", litePath, err)
+	}
+	want := &sumTypeParent{
+		Transport: &sumTypeUDP{Mode: &sumTypeLite{Lite: ygot.Bool(true)}},
+	}
+	if diff := cmp.Diff(want, root); diff != "" {
+		t.Fatalf("SetNode: did not get expected struct, (-want, +got):\n%s", diff)
+	}
+
+	nodes, err := GetNode(schema, root, litePath)
+	if err != nil {
+		t.Fatalf("GetNode: cannot get %v: %v", litePath, err)
+	}
+	if len(nodes) != 1 || !reflect.DeepEqual(nodes[0].Data, ygot.Bool(true)) {
+		t.Fatalf("GetNode: got %v, want single node with value true", nodes)
+	}
+
+	tcpPath := &gpb.Path{Elem: []*gpb.PathElem{{Name: "tcp-port"}}}
+	if _, err := GetNode(schema, root, tcpPath); err == nil {
+		t.Fatalf("GetNode: got no error for path %v within unselected case", tcpPath)
+	}
+
+	if err := DeleteNode(schema, root, litePath); err != nil {
+		t.Fatalf("DeleteNode: cannot delete %v: %v", litePath, err)
+	}
+	if diff := cmp.Diff(&sumTypeParent{}, root); diff != "" {
+		t.Errorf("DeleteNode: did not get expected struct, (-want, +got):\n%s", diff)
+	}
+}
@@ -49,35 +49,49 @@ func validateContainer(schema *yang.Entry, value ygot.GoStruct) util.Errors {
 		if reflect.ValueOf(value).IsNil() {
 			return nil
 		}
-		structElems := reflect.ValueOf(value).Elem()
-		structTypes := structElems.Type()
-
-		for i := 0; i < structElems.NumField(); i++ {
-			fieldType := structElems.Type().Field(i)
-			fieldName := fieldType.Name
-			fieldValue := structElems.Field(i).Interface()
+		// validateFields validates the fields of the struct structElems,
+		// which is either the struct being validated, or the struct
+		// representing the selected case of a choice within it.
+		var validateFields func(structElems reflect.Value)
+		validateFields = func(structElems reflect.Value) {
+			for i := 0; i < structElems.NumField(); i++ {
+				fieldType := structElems.Type().Field(i)
+				fieldName := fieldType.Name
+				fieldValue := structElems.Field(i).Interface()
+
+				// Skip annotation fields when validating the schema.
+				if util.IsYgotAnnotation(fieldType) {
+					continue
+				}
 
-			// Skip annotation fields when validating the schema.
-			if util.IsYgotAnnotation(fieldType) {
-				continue
-			}
+				// Only one case of a choice that is generated as a sum
+				// type can be selected, so only the fields of the
+				// selected case need to be validated.
+				if util.IsChoiceField(fieldType) {
+					if cv := structElems.Field(i); !cv.IsNil() && util.IsValueStructPtr(cv.Elem()) {
+						validateFields(cv.Elem().Elem())
+					}
+					continue
+				}
 
-			cschema, err := util.ChildSchema(schema, structTypes.Field(i))
-			switch {
-			case err != nil:
-				errors = util.AppendErr(errors, fmt.Errorf("%s: %v", fieldName, err))
-				continue
-			case cschema != nil:
-				// Regular named child.
-				if errs := Validate(cschema, fieldValue); errs != nil {
-					errors = util.AppendErrs(errors, util.PrefixErrors(errs, cschema.Path()))
+				cschema, err := util.ChildSchema(schema, fieldType)
+				switch {
+				case err != nil:
+					errors = util.AppendErr(errors, fmt.Errorf("%s: %v", fieldName, err))
+					continue
+				case cschema != nil:
+					// Regular named child.
+					if errs := Validate(cschema, fieldValue); errs != nil {
+						errors = util.AppendErrs(errors, util.PrefixErrors(errs, cschema.Path()))
+					}
+				case !util.IsValueNilOrDefault(fieldValue):
+					// Either an element in choice schema subtree, or bad field.
+					// If the former, it will be found in the choice check below.
+					extraFields[fieldName] = nil
 				}
-			case !util.IsValueNilOrDefault(structElems.Field(i).Interface()):
-				// Either an element in choice schema subtree, or bad field.
-				// If the former, it will be found in the choice check below.
-				extraFields[fieldName] = nil
 			}
 		}
+		validateFields(reflect.ValueOf(value).Elem())
 
 		// Field names in the data tree belonging to Choice have the schema of
 		// the elements of that choice. Hence, choice schemas must be checked
@@ -147,113 +161,154 @@ func unmarshalContainer(schema *yang.Entry, parent interface{}, jsonTree interfa
 // - parent is the parent struct, which must be a struct ptr.
 // - jsonTree is a JSON data tree which must be a map[string]interface{}.
 func unmarshalStruct(schema *yang.Entry, parent interface{}, jsonTree map[string]interface{}, enc Encoding, opts ...UnmarshalOpt) error {
+	allSchemaPaths, err := unmarshalStructFields(schema, parent, jsonTree, enc, opts...)
+	if err != nil {
+		return err
+	}
+
+	// Only check for missing fields if the IgnoreExtraFields option isn't specified.
+	if !hasIgnoreExtraFields(opts) {
+		// Go over all JSON fields to make sure that each one is covered
+		// by a data path in the struct.
+		if err := checkDataTreeAgainstPaths(jsonTree, allSchemaPaths); err != nil {
+			return fmt.Errorf("parent container %s (type %T): %s", schema.Name, parent, err)
+		}
+	}
+
+	util.DbgPrint("container after unmarshal:\n%s\n", pretty.Sprint(reflect.ValueOf(parent).Elem().Interface()))
+	return nil
+}
+
+// unmarshalStructFields unmarshals the contents of a JSON tree into the
+// fields of parent, which must be a struct ptr, without checking whether
+// there are elements of the JSON tree that do not correspond to any field.
+// It returns the data tree paths of the fields of parent, such that the
+// caller can perform this check. parent may also be the struct representing
+// a case of a YANG choice, whose fields are children of the node with the
+// supplied schema.
+func unmarshalStructFields(schema *yang.Entry, parent interface{}, jsonTree map[string]interface{}, enc Encoding, opts ...UnmarshalOpt) ([][]string, error) {
+	if u, ok := parent.(RFC7951Unmarshaler); ok && enc == JSONEncoding && !hasPreferShadowPath(opts) {
+		d := &RFC7951Decoder{schema: schema, opts: opts}
+		if err := u.UnmarshalRFC7951(d, jsonTree); err != nil {
+			return nil, err
+		}
+		return d.paths, nil
+	}
+
 	destv := reflect.ValueOf(parent).Elem()
 	var allSchemaPaths [][]string
 
 	// Range over the parent struct fields. For each field, check if the data
 	// is present in the JSON tree and if so unmarshal it into the field.
 	for i := 0; i < destv.NumField(); i++ {
-		f := destv.Field(i)
-		ft := destv.Type().Field(i)
-
-		// Skip annotation fields since they do not have a schema.
-		// TODO(robjs): Implement unmarshalling annotations.
-		if util.IsYgotAnnotation(ft) {
-			// We need to find the paths that we should have unmarshalled here to avoid
-			// throwing errors to users whilst there is a TODO above.
-			paths, err := pathTagFromField(ft)
-			if err != nil {
-				return fmt.Errorf("cannot find JSON field names for annotation field %s, %v", ft.Name, err)
-			}
-
-			for _, s := range strings.Split(paths, "|") {
-				pp := strings.Split(s, "/")
-				allSchemaPaths = append(allSchemaPaths, []string{pp[len(pp)-1]})
-			}
-			continue
-		}
-
-		childSchemaFn := util.ChildSchema
-		if hasPreferShadowPath(opts) {
-			childSchemaFn = util.ChildSchemaPreferShadow
-		}
-		cschema, err := childSchemaFn(schema, ft)
+		sp, err := unmarshalStructField(schema, parent, i, jsonTree, enc, opts...)
 		if err != nil {
-			return err
+			return nil, err
 		}
+		allSchemaPaths = append(allSchemaPaths, sp...)
+	}
 
-		if cschema == nil {
-			return fmt.Errorf("unmarshalContainer could not find schema for type %T, field name %s", parent, ft.Name)
-		}
+	return allSchemaPaths, nil
+}
 
-		// Store the data tree path of the current field. These will be used
-		// at the end to ensure that there are no excess elements in the JSON
-		// tree not covered by any data path.
-		sp, err := dataTreePaths(schema, cschema, ft)
-		if err != nil {
-			return err
-		}
-		allSchemaPaths = append(allSchemaPaths, sp...)
+// unmarshalStructField unmarshals the contents of a JSON tree into the field
+// with index i of parent, which must be a struct ptr. It returns the data tree
+// paths of the field.
+func unmarshalStructField(schema *yang.Entry, parent interface{}, i int, jsonTree map[string]interface{}, enc Encoding, opts ...UnmarshalOpt) ([][]string, error) {
+	destv := reflect.ValueOf(parent).Elem()
+	f := destv.Field(i)
+	ft := destv.Type().Field(i)
 
-		// If there are shadow schema paths, also add them to the allowlist
-		// avoid an unmarshalling error.
-		// NOTE: This is more permissive than ideal in that it doesn't
-		// catch other types of non-compliance errors, i.e. if the JSON
-		// shadow node is a container (should not occur under
-		// OpenConfig YANG rules), or if the JSON cannot be
-		// unmarshalled due to type mismatch.
-		ssp, err := shadowDataTreePaths(schema, cschema, ft)
-		if err != nil {
-			return err
-		}
-		allSchemaPaths = append(allSchemaPaths, ssp...)
+	if util.IsChoiceField(ft) {
+		return unmarshalChoice(schema, parent, f, ft, jsonTree, enc, opts...)
+	}
 
-		jsonValue, err := getJSONTreeValForField(schema, cschema, ft, jsonTree, hasPreferShadowPath(opts))
+	// Skip annotation fields since they do not have a schema.
+	// TODO(robjs): Implement unmarshalling annotations.
+	if util.IsYgotAnnotation(ft) {
+		// We need to find the paths that we should have unmarshalled here to avoid
+		// throwing errors to users whilst there is a TODO above.
+		paths, err := pathTagFromField(ft)
 		if err != nil {
-			return err
+			return nil, fmt.Errorf("cannot find JSON field names for annotation field %s, %v", ft.Name, err)
 		}
 
-		if jsonValue == nil {
-			util.DbgPrint("field %s paths %v not present in tree", ft.Name, sp)
-			continue
+		var allSchemaPaths [][]string
+		for _, s := range strings.Split(paths, "|") {
+			pp := strings.Split(s, "/")
+			allSchemaPaths = append(allSchemaPaths, []string{pp[len(pp)-1]})
 		}
+		return allSchemaPaths, nil
+	}
 
-		util.DbgPrint("populating field %s type %s with paths %v.", ft.Name, ft.Type, sp)
-		// Only create a new field if it is nil, otherwise update just the
-		// fields that are in the data tree being passed to unmarshal, and
-		// preserve all other existing values.
-		if util.IsNilOrInvalidValue(f) {
-			makeField(destv, ft)
-		}
+	childSchemaFn := util.ChildSchema
+	if hasPreferShadowPath(opts) {
+		childSchemaFn = util.ChildSchemaPreferShadow
+	}
+	cschema, err := childSchemaFn(schema, ft)
+	if err != nil {
+		return nil, err
+	}
 
-		p := parent
-		switch {
-		case util.IsUnkeyedList(cschema):
-			// For unkeyed list, we must pass in the addr of the slice to be
-			// able to append to it.
-			p = f.Addr().Interface()
-		case cschema.IsContainer() || cschema.IsList():
-			// For list and container, the new parent is the field we just
-			// created. For leaf and leaf-list, the parent is still the
-			// current container.
-			p = f.Interface()
-		}
-		if err := unmarshalGeneric(cschema, p, jsonValue, enc, opts...); err != nil {
-			return err
-		}
+	if cschema == nil {
+		return nil, fmt.Errorf("unmarshalContainer could not find schema for type %T, field name %s", parent, ft.Name)
 	}
 
-	// Only check for missing fields if the IgnoreExtraFields option isn't specified.
-	if !hasIgnoreExtraFields(opts) {
-		// Go over all JSON fields to make sure that each one is covered
-		// by a data path in the struct.
-		if err := checkDataTreeAgainstPaths(jsonTree, allSchemaPaths); err != nil {
-			return fmt.Errorf("parent container %s (type %T): %s", schema.Name, parent, err)
-		}
+	// Store the data tree path of the current field. These will be used
+	// at the end to ensure that there are no excess elements in the JSON
+	// tree not covered by any data path.
+	allSchemaPaths, err := dataTreePaths(schema, cschema, ft)
+	if err != nil {
+		return nil, err
 	}
 
-	util.DbgPrint("container after unmarshal:\n%s\n", pretty.Sprint(destv.Interface()))
-	return nil
+	// If there are shadow schema paths, also add them to the allowlist
+	// avoid an unmarshalling error.
+	// NOTE: This is more permissive than ideal in that it doesn't
+	// catch other types of non-compliance errors, i.e. if the JSON
+	// shadow node is a container (should not occur under
+	// OpenConfig YANG rules), or if the JSON cannot be
+	// unmarshalled due to type mismatch.
+	ssp, err := shadowDataTreePaths(schema, cschema, ft)
+	if err != nil {
+		return nil, err
+	}
+	allSchemaPaths = append(allSchemaPaths, ssp...)
+
+	jsonValue, err := getJSONTreeValForField(schema, cschema, ft, jsonTree, hasPreferShadowPath(opts))
+	if err != nil {
+		return nil, err
+	}
+
+	if jsonValue == nil {
+		util.DbgPrint("field %s paths %v not present in tree", ft.Name, allSchemaPaths)
+		return allSchemaPaths, nil
+	}
+
+	util.DbgPrint("populating field %s type %s with paths %v.", ft.Name, ft.Type, allSchemaPaths)
+	// Only create a new field if it is nil, otherwise update just the
+	// fields that are in the data tree being passed to unmarshal, and
+	// preserve all other existing values.
+	if util.IsNilOrInvalidValue(f) {
+		makeField(destv, ft)
+	}
+
+	p := parent
+	switch {
+	case util.IsUnkeyedList(cschema):
+		// For unkeyed list, we must pass in the addr of the slice to be
+		// able to append to it.
+		p = f.Addr().Interface()
+	case cschema.IsContainer() || cschema.IsList():
+		// For list and container, the new parent is the field we just
+		// created. For leaf and leaf-list, the parent is still the
+		// current container.
+		p = f.Interface()
+	}
+	if err := unmarshalGeneric(cschema, p, jsonValue, enc, opts...); err != nil {
+		return nil, err
+	}
+	return allSchemaPaths, nil
 }
 
 // validateContainerSchema validates the given container type schema. This is a
@@ -264,7 +319,7 @@ func validateContainerSchema(schema *yang.Entry) error {
 	if schema == nil {
 		return fmt.Errorf("container schema is nil")
 	}
-	if !schema.IsContainer() {
+	if !schema.IsContainer() && !util.IsOperationContainer(schema) {
 		return fmt.Errorf("container schema %s is not a container type", schema.Name)
 	}
 
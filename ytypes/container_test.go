@@ -558,3 +558,60 @@ func TestUnmarshalContainer(t *testing.T) {
 		t.Errorf("nil schema: got error: nil, want nil schema error")
 	}
 }
+
+type OperationInputStruct struct {
+	Destination *string `path:"destination"`
+	Count       *uint8  `path:"count"`
+}
+
+func (*OperationInputStruct) IsYANGGoStruct()                          {}
+func (*OperationInputStruct) ΛValidate(...ygot.ValidationOption) error { return nil }
+func (*OperationInputStruct) ΛEnumTypeMap() map[string][]reflect.Type  { return nil }
+func (*OperationInputStruct) ΛBelongingModule() string                 { return "bar" }
+
+func TestOperationContainer(t *testing.T) {
+	for _, kind := range []yang.EntryKind{yang.InputEntry, yang.OutputEntry, yang.NotificationEntry} {
+		schema := &yang.Entry{
+			Name: "input",
+			Kind: kind,
+			Dir: map[string]*yang.Entry{
+				"destination": {
+					Name: "destination",
+					Kind: yang.LeafEntry,
+					Type: &yang.YangType{Kind: yang.Ystring},
+				},
+				"count": {
+					Name: "count",
+					Kind: yang.LeafEntry,
+					Type: &yang.YangType{Kind: yang.Yuint8},
+				},
+			},
+		}
+		addParents(schema)
+
+		if err := validateContainerSchema(schema); err != nil {
+			t.Errorf("%v: validateContainerSchema: got unexpected error: %v", kind, err)
+		}
+
+		got := &OperationInputStruct{}
+		if err := Unmarshal(schema, got, map[string]interface{}{"destination": "192.0.2.1", "count": float64(5)}); err != nil {
+			t.Fatalf("%v: Unmarshal: got unexpected error: %v", kind, err)
+		}
+		want := &OperationInputStruct{Destination: ygot.String("192.0.2.1"), Count: ygot.Uint8(5)}
+		if !reflect.DeepEqual(got, want) {
+			t.Errorf("%v: Unmarshal: got %s, want %s", kind, pretty.Sprint(got), pretty.Sprint(want))
+		}
+
+		if errs := Validate(schema, got); errs != nil {
+			t.Errorf("%v: Validate: got unexpected errors: %v", kind, errs)
+		}
+
+		nodes, err := GetNode(schema, got, mustPath("/count"))
+		if err != nil {
+			t.Fatalf("%v: GetNode: got unexpected error: %v", kind, err)
+		}
+		if len(nodes) != 1 || !reflect.DeepEqual(nodes[0].Data, ygot.Uint8(5)) {
+			t.Errorf("%v: GetNode: did not get expected node, got %v", kind, nodes)
+		}
+	}
+}
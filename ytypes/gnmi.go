@@ -18,13 +18,40 @@ import (
 	"fmt"
 	"reflect"
 
+	"github.com/openconfig/gnmi/value"
 	"github.com/openconfig/goyang/pkg/yang"
 	"github.com/openconfig/ygot/util"
 	"github.com/openconfig/ygot/ygot"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/types/known/anypb"
 
 	gpb "github.com/openconfig/gnmi/proto/gnmi"
 )
 
+// ProtoUnmapper is an UnmarshalOpt that maps a protobuf message to the values
+// of the leaves that it contains, such that TypedValues that use the gNMI
+// PROTO encoding can be unmarshalled. It is typically implemented using the
+// protobufs generated by ygot's protogen package, for example by the protomap
+// package.
+type ProtoUnmapper interface {
+	UnmarshalOpt
+	// PathsFromProto returns the values of the leaves within m, keyed by
+	// the path of each leaf relative to the data tree node that m
+	// represents.
+	PathsFromProto(m proto.Message) (map[*gpb.Path]any, error)
+}
+
+// protoUnmapper returns the ProtoUnmapper within the supplied slice of
+// UnmarshalOpts, or nil if there is none.
+func protoUnmapper(opts []UnmarshalOpt) ProtoUnmapper {
+	for _, o := range opts {
+		if pu, ok := o.(ProtoUnmapper); ok {
+			return pu
+		}
+	}
+	return nil
+}
+
 // UnmarshalNotifications unmarshals a slice of Notifications on the root
 // GoStruct specified by "schema". It *does not* perform validation after
 // unmarshalling is complete.
@@ -62,10 +89,14 @@ func UnmarshalNotifications(schema *Schema, ns []*gpb.Notification, opts ...Unma
 //
 // If an error occurs during unmarshalling, schema.Root may already be
 // modified. A rollback is not performed.
+//
+// Values that use the gNMI PROTO encoding can only be unmarshalled if a
+// ProtoUnmapper is supplied within opts.
 func UnmarshalSetRequest(schema *Schema, req *gpb.SetRequest, opts ...UnmarshalOpt) error {
 	preferShadowPath := hasPreferShadowPath(opts)
 	ignoreExtraFields := hasIgnoreExtraFields(opts)
 	bestEffortUnmarshal := hasBestEffortUnmarshal(opts)
+	pu := protoUnmapper(opts)
 	if req == nil {
 		return nil
 	}
@@ -82,14 +113,14 @@ func UnmarshalSetRequest(schema *Schema, req *gpb.SetRequest, opts ...UnmarshalO
 			return err
 		}
 	}
-	if err := replacePaths(schema.SchemaTree[rootName], root, req.Prefix, req.Replace, preferShadowPath, ignoreExtraFields, bestEffortUnmarshal); err != nil {
+	if err := replacePaths(schema.SchemaTree[rootName], root, req.Prefix, req.Replace, pu, preferShadowPath, ignoreExtraFields, bestEffortUnmarshal); err != nil {
 		if bestEffortUnmarshal {
 			complianceErrs = complianceErrs.append(err.(*ComplianceErrors).Errors...)
 		} else {
 			return err
 		}
 	}
-	if err := updatePaths(schema.SchemaTree[rootName], root, req.Prefix, req.Update, preferShadowPath, ignoreExtraFields, bestEffortUnmarshal); err != nil {
+	if err := updatePaths(schema.SchemaTree[rootName], root, req.Prefix, req.Update, pu, preferShadowPath, ignoreExtraFields, bestEffortUnmarshal); err != nil {
 		if bestEffortUnmarshal {
 			complianceErrs = complianceErrs.append(err.(*ComplianceErrors).Errors...)
 		} else {
@@ -156,8 +187,9 @@ func joinPrefixToUpdate(prefix *gpb.Path, update *gpb.Update) (*gpb.Update, erro
 
 // replacePaths unmarshals a slice of updates into the given GoStruct. It
 // deletes the values at these paths before unmarshalling them. These updates
-// can either by JSON-encoded or gNMI-encoded values (scalars).
-func replacePaths(schema *yang.Entry, goStruct ygot.GoStruct, prefix *gpb.Path, updates []*gpb.Update, preferShadowPath, ignoreExtraFields, bestEffortUnmarshal bool) error {
+// can either by JSON-encoded or gNMI-encoded values (scalars), or values that
+// use the PROTO encoding if pu is non-nil.
+func replacePaths(schema *yang.Entry, goStruct ygot.GoStruct, prefix *gpb.Path, updates []*gpb.Update, pu ProtoUnmapper, preferShadowPath, ignoreExtraFields, bestEffortUnmarshal bool) error {
 	var dopts []DelNodeOpt
 	var ce *ComplianceErrors
 	if preferShadowPath {
@@ -176,7 +208,7 @@ func replacePaths(schema *yang.Entry, goStruct ygot.GoStruct, prefix *gpb.Path,
 			}
 			return err
 		}
-		if err := setNode(schema, goStruct, update, preferShadowPath, ignoreExtraFields); err != nil {
+		if err := setNode(schema, goStruct, update, pu, preferShadowPath, ignoreExtraFields); err != nil {
 			if bestEffortUnmarshal {
 				ce = ce.append(err)
 				continue
@@ -191,8 +223,9 @@ func replacePaths(schema *yang.Entry, goStruct ygot.GoStruct, prefix *gpb.Path,
 }
 
 // updatePaths unmarshals a slice of updates into the given GoStruct. These
-// updates can either by JSON-encoded or gNMI-encoded values (scalars).
-func updatePaths(schema *yang.Entry, goStruct ygot.GoStruct, prefix *gpb.Path, updates []*gpb.Update, preferShadowPath, ignoreExtraFields, bestEffortUnmarshal bool) error {
+// updates can either by JSON-encoded or gNMI-encoded values (scalars), or
+// values that use the PROTO encoding if pu is non-nil.
+func updatePaths(schema *yang.Entry, goStruct ygot.GoStruct, prefix *gpb.Path, updates []*gpb.Update, pu ProtoUnmapper, preferShadowPath, ignoreExtraFields, bestEffortUnmarshal bool) error {
 	var ce *ComplianceErrors
 
 	for _, update := range updates {
@@ -200,7 +233,7 @@ func updatePaths(schema *yang.Entry, goStruct ygot.GoStruct, prefix *gpb.Path, u
 		if update, err = joinPrefixToUpdate(prefix, update); err != nil {
 			return err
 		}
-		if err := setNode(schema, goStruct, update, preferShadowPath, ignoreExtraFields); err != nil {
+		if err := setNode(schema, goStruct, update, pu, preferShadowPath, ignoreExtraFields); err != nil {
 			if bestEffortUnmarshal {
 				ce = ce.append(err)
 				continue
@@ -215,9 +248,10 @@ func updatePaths(schema *yang.Entry, goStruct ygot.GoStruct, prefix *gpb.Path, u
 	return nil
 }
 
-// setNode unmarshals either a JSON-encoded value or a gNMI-encoded (scalar)
-// value into the given GoStruct.
-func setNode(schema *yang.Entry, goStruct ygot.GoStruct, update *gpb.Update, preferShadowPath, ignoreExtraFields bool) error {
+// setNode unmarshals either a JSON-encoded value, a PROTO-encoded value or a
+// gNMI-encoded (scalar) value into the given GoStruct. PROTO-encoded values
+// are mapped using pu.
+func setNode(schema *yang.Entry, goStruct ygot.GoStruct, update *gpb.Update, pu ProtoUnmapper, preferShadowPath, ignoreExtraFields bool) error {
 	sopts := []SetNodeOpt{&InitMissingElements{}}
 	if preferShadowPath {
 		sopts = append(sopts, &PreferShadowPath{})
@@ -226,8 +260,76 @@ func setNode(schema *yang.Entry, goStruct ygot.GoStruct, update *gpb.Update, pre
 		sopts = append(sopts, &IgnoreExtraFields{})
 	}
 
+	if a := update.GetVal().GetAnyVal(); a != nil {
+		if err := setProtoNode(schema, goStruct, update.Path, a, pu, sopts); err != nil {
+			return fmt.Errorf("setNode: %v", err)
+		}
+		return nil
+	}
+
 	if err := SetNode(schema, goStruct, update.Path, update.Val, sopts...); err != nil {
 		return fmt.Errorf("setNode: %v", err)
 	}
 	return nil
 }
+
+// setProtoNode unmarshals the ygot-generated protobuf message contained in a,
+// which corresponds to the data tree node at path, into the given GoStruct
+// using pu. The protobuf's type must be registered in the global protobuf
+// registry.
+func setProtoNode(schema *yang.Entry, goStruct ygot.GoStruct, path *gpb.Path, a *anypb.Any, pu ProtoUnmapper, sopts []SetNodeOpt) error {
+	if pu == nil {
+		return fmt.Errorf("cannot unmarshal PROTO-encoded value at %v, no ProtoUnmapper supplied", path)
+	}
+	m, err := a.UnmarshalNew()
+	if err != nil {
+		return fmt.Errorf("cannot unmarshal Any value at %v, %v", path, err)
+	}
+	leaves, err := pu.PathsFromProto(m)
+	if err != nil {
+		return fmt.Errorf("cannot map protobuf %s to paths, %v", m.ProtoReflect().Descriptor().FullName(), err)
+	}
+
+	for p, v := range leaves {
+		lp, err := rebaseProtoPath(path, p)
+		if err != nil {
+			return err
+		}
+		tv, ok := v.(*gpb.TypedValue)
+		if !ok {
+			if tv, err = value.FromScalar(v); err != nil {
+				return fmt.Errorf("cannot convert value %v at %v to TypedValue, %v", v, lp, err)
+			}
+		}
+		if err := SetNode(schema, goStruct, lp, tv, sopts...); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// rebaseProtoPath returns the path of the leaf p, which was mapped from a
+// protobuf message corresponding to the data tree node at path, such that the
+// keys of any lists within path are included in it. The schema path of path
+// must be a prefix of p.
+func rebaseProtoPath(path, p *gpb.Path) (*gpb.Path, error) {
+	if len(p.GetElem()) < len(path.GetElem()) {
+		return nil, fmt.Errorf("path %v of protobuf field is not a descendant of %v", p, path)
+	}
+	np := &gpb.Path{Elem: make([]*gpb.PathElem, 0, len(p.GetElem()))}
+	for i, e := range p.GetElem() {
+		if i >= len(path.GetElem()) {
+			np.Elem = append(np.Elem, e)
+			continue
+		}
+		pe := path.GetElem()[i]
+		if pe.GetName() != e.GetName() {
+			return nil, fmt.Errorf("path %v of protobuf field is not a descendant of %v", p, path)
+		}
+		if len(pe.GetKey()) == 0 {
+			pe = e
+		}
+		np.Elem = append(np.Elem, pe)
+	}
+	return np, nil
+}
@@ -20,10 +20,16 @@ import (
 	"testing"
 
 	"github.com/google/go-cmp/cmp"
+	"github.com/openconfig/gnmi/errdiff"
 	"github.com/openconfig/goyang/pkg/yang"
+	"github.com/openconfig/ygot/protomap"
 	"github.com/openconfig/ygot/ygot"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/types/known/anypb"
 
 	gpb "github.com/openconfig/gnmi/proto/gnmi"
+	wpb "github.com/openconfig/ygot/proto/ywrapper"
+	epb "github.com/openconfig/ygot/protomap/testdata/exschemapath"
 )
 
 func TestUnmarshalSetRequest(t *testing.T) {
@@ -313,6 +319,33 @@ func TestUnmarshalSetRequest(t *testing.T) {
 		want: &ListElemStruct1{
 			Key1: ygot.String("hello"),
 		},
+	}, {
+		desc: "deletes of paths beneath another deleted path",
+		inSchema: &Schema{
+			Root: &ListElemStruct1{
+				Key1: ygot.String("hello"),
+				Outer: &OuterContainerType1{
+					Inner: &InnerContainerType1{
+						Int32LeafName:  ygot.Int32(43),
+						StringLeafName: ygot.String("bear"),
+					},
+				},
+			},
+			SchemaTree: map[string]*yang.Entry{
+				"ListElemStruct1": simpleSchema(),
+			},
+		},
+		inReq: &gpb.SetRequest{
+			Prefix: &gpb.Path{},
+			Delete: []*gpb.Path{
+				mustPath("/outer/inner"),
+				mustPath("/outer/inner/config/int32-leaf-field"),
+				mustPath("/outer/inner/config/string-leaf-field"),
+			},
+		},
+		want: &ListElemStruct1{
+			Key1: ygot.String("hello"),
+		},
 	}, {
 		desc: "deletes, replaces and update to a non-empty struct",
 		inSchema: &Schema{
@@ -949,3 +982,191 @@ func TestUnmarshalNotifications(t *testing.T) {
 		})
 	}
 }
+
+// protoRoot, protoSystem and protoInterface are GoStructs corresponding to
+// the messages of the exschemapath protobuf package.
+type protoRoot struct {
+	System    *protoSystem               `path:"system"`
+	Interface map[string]*protoInterface `path:"interfaces/interface"`
+}
+
+func (*protoRoot) IsYANGGoStruct() {}
+
+type protoSystem struct {
+	Hostname *string `path:"config/hostname"`
+}
+
+func (*protoSystem) IsYANGGoStruct() {}
+
+type protoInterface struct {
+	Name        *string `path:"config/name|name"`
+	Description *string `path:"config/description"`
+}
+
+func (*protoInterface) IsYANGGoStruct() {}
+
+func protoRootSchema() *yang.Entry {
+	root := &yang.Entry{
+		Name:       "device",
+		Kind:       yang.DirectoryEntry,
+		Annotation: map[string]interface{}{"isFakeRoot": true},
+		Dir: map[string]*yang.Entry{
+			"system": {
+				Name: "system",
+				Kind: yang.DirectoryEntry,
+				Dir: map[string]*yang.Entry{
+					"config": {
+						Name: "config",
+						Kind: yang.DirectoryEntry,
+						Dir: map[string]*yang.Entry{
+							"hostname": {
+								Name: "hostname",
+								Kind: yang.LeafEntry,
+								Type: &yang.YangType{Kind: yang.Ystring},
+							},
+						},
+					},
+				},
+			},
+			"interfaces": {
+				Name: "interfaces",
+				Kind: yang.DirectoryEntry,
+				Dir: map[string]*yang.Entry{
+					"interface": {
+						Name:     "interface",
+						Kind:     yang.DirectoryEntry,
+						ListAttr: yang.NewDefaultListAttr(),
+						Key:      "name",
+						Config:   yang.TSTrue,
+						Dir: map[string]*yang.Entry{
+							"name": {
+								Name: "name",
+								Kind: yang.LeafEntry,
+								Type: &yang.YangType{Kind: yang.Yleafref, Path: "../config/name"},
+							},
+							"config": {
+								Name: "config",
+								Kind: yang.DirectoryEntry,
+								Dir: map[string]*yang.Entry{
+									"name": {
+										Name: "name",
+										Kind: yang.LeafEntry,
+										Type: &yang.YangType{Kind: yang.Ystring},
+									},
+									"description": {
+										Name: "description",
+										Kind: yang.LeafEntry,
+										Type: &yang.YangType{Kind: yang.Ystring},
+									},
+								},
+							},
+						},
+					},
+				},
+			},
+		},
+	}
+	populateParentField(nil, root)
+	return root
+}
+
+func mustAny(t *testing.T, m proto.Message) *anypb.Any {
+	t.Helper()
+	a, err := anypb.New(m)
+	if err != nil {
+		t.Fatalf("cannot create Any, %v", err)
+	}
+	return a
+}
+
+func TestUnmarshalNotificationsProto(t *testing.T) {
+	tests := []struct {
+		desc    string
+		inRoot  *protoRoot
+		inPath  *gpb.Path
+		inMsg   proto.Message
+		inOpts  []UnmarshalOpt
+		want    *protoRoot
+		wantErr string
+	}{{
+		desc:   "root message",
+		inRoot: &protoRoot{},
+		inPath: &gpb.Path{},
+		inMsg: &epb.Root{
+			System: &epb.System{Hostname: &wpb.StringValue{Value: "box0"}},
+			Interface: []*epb.Root_InterfaceKey{{
+				Name: "eth0",
+				Interface: &epb.Interface{
+					Description: &wpb.StringValue{Value: "uplink"},
+				},
+			}},
+		},
+		want: &protoRoot{
+			System: &protoSystem{Hostname: ygot.String("box0")},
+			Interface: map[string]*protoInterface{
+				"eth0": {Name: ygot.String("eth0"), Description: ygot.String("uplink")},
+			},
+		},
+	}, {
+		desc:   "container message",
+		inRoot: &protoRoot{System: &protoSystem{Hostname: ygot.String("box0")}},
+		inPath: mustPath("/system"),
+		inMsg:  &epb.System{Hostname: &wpb.StringValue{Value: "box1"}},
+		want:   &protoRoot{System: &protoSystem{Hostname: ygot.String("box1")}},
+	}, {
+		desc: "list member message",
+		inRoot: &protoRoot{
+			Interface: map[string]*protoInterface{
+				"eth0": {Name: ygot.String("eth0")},
+			},
+		},
+		inPath: mustPath("/interfaces/interface[name=eth0]"),
+		inMsg:  &epb.Interface{Description: &wpb.StringValue{Value: "uplink"}},
+		want: &protoRoot{
+			Interface: map[string]*protoInterface{
+				"eth0": {Name: ygot.String("eth0"), Description: ygot.String("uplink")},
+			},
+		},
+	}, {
+		desc:    "message for a different path",
+		inRoot:  &protoRoot{},
+		inPath:  mustPath("/interfaces/interface[name=eth0]"),
+		inMsg:   &epb.System{Hostname: &wpb.StringValue{Value: "box0"}},
+		wantErr: "is not a descendant of",
+	}, {
+		desc:    "no ProtoUnmapper",
+		inRoot:  &protoRoot{},
+		inPath:  mustPath("/system"),
+		inMsg:   &epb.System{Hostname: &wpb.StringValue{Value: "box0"}},
+		inOpts:  []UnmarshalOpt{},
+		wantErr: "no ProtoUnmapper supplied",
+	}}
+
+	for _, tt := range tests {
+		t.Run(tt.desc, func(t *testing.T) {
+			schema := &Schema{
+				Root:       tt.inRoot,
+				SchemaTree: map[string]*yang.Entry{"protoRoot": protoRootSchema()},
+			}
+			opts := tt.inOpts
+			if opts == nil {
+				opts = []UnmarshalOpt{&protomap.TypedValueUnmapper{}}
+			}
+			err := UnmarshalNotifications(schema, []*gpb.Notification{{
+				Update: []*gpb.Update{{
+					Path: tt.inPath,
+					Val:  &gpb.TypedValue{Value: &gpb.TypedValue_AnyVal{AnyVal: mustAny(t, tt.inMsg)}},
+				}},
+			}}, opts...)
+			if diff := errdiff.Substring(err, tt.wantErr); diff != "" {
+				t.Fatalf("UnmarshalNotifications: did not get expected error, %s", diff)
+			}
+			if err != nil {
+				return
+			}
+			if diff := cmp.Diff(tt.want, schema.Root); diff != "" {
+				t.Errorf("UnmarshalNotifications: did not get expected struct, diff(-want, +got):\n%s", diff)
+			}
+		})
+	}
+}
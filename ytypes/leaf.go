@@ -511,9 +511,11 @@ func unmarshalUnion(schema *yang.Entry, parent interface{}, fieldName string, va
 	var ok bool
 	switch enc {
 	case GNMIEncoding, gNMIEncodingWithJSONTolerance:
-		var sv *gpb.TypedValue_StringVal
-		if sv, ok = value.(*gpb.TypedValue).GetValue().(*gpb.TypedValue_StringVal); ok {
-			valueStr = sv.StringVal
+		switch v := value.(*gpb.TypedValue).GetValue().(type) {
+		case *gpb.TypedValue_StringVal:
+			valueStr, ok = v.StringVal, true
+		case *gpb.TypedValue_AsciiVal:
+			valueStr, ok = v.AsciiVal, true
 		}
 	case JSONEncoding:
 		valueStr, ok = value.(string)
@@ -791,10 +793,28 @@ func sanitizeJSON(parent interface{}, schema *yang.Entry, fieldName string, valu
 // fieldName is the name of the field being written in GoStruct. tv is the
 // JSON encoded value. jsonTolerance means to allow some otherwise nonmatching
 // types to match due to inconsistencies after json translation; for now, this
-// just involves accepting positive ints as uints.
+// just involves accepting positive ints as uints. Values using the ASCII or
+// BYTES encoding are parsed according to the type of the field.
 func sanitizeGNMI(parent interface{}, schema *yang.Entry, fieldName string, tv *gpb.TypedValue, jsonTolerance bool) (interface{}, error) {
 	ykind := schema.Type.Kind
 
+	switch v := tv.GetValue().(type) {
+	case *gpb.TypedValue_AsciiVal:
+		ntv, err := textToTypedValue(ykind, v.AsciiVal, true)
+		if err != nil {
+			return nil, err
+		}
+		tv = ntv
+	case *gpb.TypedValue_BytesVal:
+		if ykind != yang.Ybinary {
+			ntv, err := textToTypedValue(ykind, string(v.BytesVal), false)
+			if err != nil {
+				return nil, err
+			}
+			tv = ntv
+		}
+	}
+
 	var ok bool
 	if ok = gNMIToYANGTypeMatches(ykind, tv, jsonTolerance); !ok {
 		return nil, fmt.Errorf("failed to unmarshal (%T, %v) into %v", tv.GetValue(), tv.GetValue(), yang.TypeKindToName[ykind])
@@ -851,6 +871,54 @@ func sanitizeGNMI(parent interface{}, schema *yang.Entry, fieldName string, tv *
 	return nil, fmt.Errorf("%v type isn't expected for GNMIEncoding", yang.TypeKindToName[ykind])
 }
 
+// textToTypedValue parses s, the string representation of a value of YANG
+// type ykind received using the ASCII or BYTES encoding, returning the
+// TypedValue of the corresponding type. Binary values are base64 decoded
+// when isASCII is set. The value is returned as an AsciiVal if ykind cannot
+// be represented as text.
+func textToTypedValue(ykind yang.TypeKind, s string, isASCII bool) (*gpb.TypedValue, error) {
+	switch ykind {
+	case yang.Ybool:
+		switch s {
+		case "true":
+			return &gpb.TypedValue{Value: &gpb.TypedValue_BoolVal{BoolVal: true}}, nil
+		case "false":
+			return &gpb.TypedValue{Value: &gpb.TypedValue_BoolVal{BoolVal: false}}, nil
+		}
+		return nil, fmt.Errorf("cannot unmarshal %q into bool", s)
+	case yang.Ystring, yang.Yenum, yang.Yidentityref:
+		return &gpb.TypedValue{Value: &gpb.TypedValue_StringVal{StringVal: s}}, nil
+	case yang.Yint8, yang.Yint16, yang.Yint32, yang.Yint64:
+		i, err := strconv.ParseInt(s, 10, 64)
+		if err != nil {
+			return nil, fmt.Errorf("cannot unmarshal %q into %v, %v", s, yang.TypeKindToName[ykind], err)
+		}
+		return &gpb.TypedValue{Value: &gpb.TypedValue_IntVal{IntVal: i}}, nil
+	case yang.Yuint8, yang.Yuint16, yang.Yuint32, yang.Yuint64:
+		u, err := strconv.ParseUint(s, 10, 64)
+		if err != nil {
+			return nil, fmt.Errorf("cannot unmarshal %q into %v, %v", s, yang.TypeKindToName[ykind], err)
+		}
+		return &gpb.TypedValue{Value: &gpb.TypedValue_UintVal{UintVal: u}}, nil
+	case yang.Ybinary:
+		if !isASCII {
+			return &gpb.TypedValue{Value: &gpb.TypedValue_BytesVal{BytesVal: []byte(s)}}, nil
+		}
+		b, err := base64.StdEncoding.DecodeString(s)
+		if err != nil {
+			return nil, fmt.Errorf("cannot unmarshal %q into binary, %v", s, err)
+		}
+		return &gpb.TypedValue{Value: &gpb.TypedValue_BytesVal{BytesVal: b}}, nil
+	case yang.Ydecimal64:
+		f, err := strconv.ParseFloat(s, 64)
+		if err != nil {
+			return nil, fmt.Errorf("cannot unmarshal %q into decimal64, %v", s, err)
+		}
+		return &gpb.TypedValue{Value: &gpb.TypedValue_DoubleVal{DoubleVal: f}}, nil
+	}
+	return &gpb.TypedValue{Value: &gpb.TypedValue_AsciiVal{AsciiVal: s}}, nil
+}
+
 // gNMIToYANGTypeMatches checks whether the provided yang.TypeKind can be set
 // by using the provided gNMI TypedValue, and returns the TypedValue that
 // should be used to get the underlying value. gNMI TypedValue oneof fields can
@@ -2062,6 +2062,72 @@ func TestUnmarshalLeafGNMIEncoding(t *testing.T) {
 			inVal:    &gpb.TypedValue{},
 			wantErr:  `failed to unmarshal`,
 		},
+		{
+			desc:     "success gNMI AsciiVal to Yint64",
+			inSchema: typeToLeafSchema("int64-leaf", yang.Yint64),
+			inVal:    &gpb.TypedValue{Value: &gpb.TypedValue_AsciiVal{AsciiVal: "-42"}},
+			wantVal:  &LeafContainerStruct{Int64Leaf: ygot.Int64(-42)},
+		},
+		{
+			desc:     "fail gNMI AsciiVal to Yuint8 due to overflow",
+			inSchema: typeToLeafSchema("uint8-leaf", yang.Yuint8),
+			inVal:    &gpb.TypedValue{Value: &gpb.TypedValue_AsciiVal{AsciiVal: "4242"}},
+			wantErr:  `StringToType("4242", uint8) failed`,
+		},
+		{
+			desc:     "fail gNMI AsciiVal to Yuint8 with negative value",
+			inSchema: typeToLeafSchema("uint8-leaf", yang.Yuint8),
+			inVal:    &gpb.TypedValue{Value: &gpb.TypedValue_AsciiVal{AsciiVal: "-1"}},
+			wantErr:  `cannot unmarshal "-1" into uint8`,
+		},
+		{
+			desc:     "success gNMI AsciiVal to Ybool",
+			inSchema: typeToLeafSchema("bool-leaf", yang.Ybool),
+			inVal:    &gpb.TypedValue{Value: &gpb.TypedValue_AsciiVal{AsciiVal: "true"}},
+			wantVal:  &LeafContainerStruct{BoolLeaf: ygot.Bool(true)},
+		},
+		{
+			desc:     "fail gNMI AsciiVal to Ybool",
+			inSchema: typeToLeafSchema("bool-leaf", yang.Ybool),
+			inVal:    &gpb.TypedValue{Value: &gpb.TypedValue_AsciiVal{AsciiVal: "yes"}},
+			wantErr:  `cannot unmarshal "yes" into bool`,
+		},
+		{
+			desc:     "success gNMI AsciiVal to Ystring",
+			inSchema: typeToLeafSchema("string-leaf", yang.Ystring),
+			inVal:    &gpb.TypedValue{Value: &gpb.TypedValue_AsciiVal{AsciiVal: "forty two"}},
+			wantVal:  &LeafContainerStruct{StringLeaf: ygot.String("forty two")},
+		},
+		{
+			desc:     "success gNMI BytesVal to Ystring",
+			inSchema: typeToLeafSchema("string-leaf", yang.Ystring),
+			inVal:    &gpb.TypedValue{Value: &gpb.TypedValue_BytesVal{BytesVal: []byte("forty two")}},
+			wantVal:  &LeafContainerStruct{StringLeaf: ygot.String("forty two")},
+		},
+		{
+			desc:     "success gNMI AsciiVal to Ydecimal64",
+			inSchema: typeToLeafSchema("decimal-leaf", yang.Ydecimal64),
+			inVal:    &gpb.TypedValue{Value: &gpb.TypedValue_AsciiVal{AsciiVal: "42.42"}},
+			wantVal:  &LeafContainerStruct{DecimalLeaf: ygot.Float64(42.42)},
+		},
+		{
+			desc:     "success gNMI AsciiVal to Ybinary",
+			inSchema: typeToLeafSchema("binary-leaf", yang.Ybinary),
+			inVal:    &gpb.TypedValue{Value: &gpb.TypedValue_AsciiVal{AsciiVal: base64.StdEncoding.EncodeToString([]byte("forty two"))}},
+			wantVal:  &LeafContainerStruct{BinaryLeaf: Binary("forty two")},
+		},
+		{
+			desc:     "fail gNMI AsciiVal to Ybinary with invalid base64",
+			inSchema: typeToLeafSchema("binary-leaf", yang.Ybinary),
+			inVal:    &gpb.TypedValue{Value: &gpb.TypedValue_AsciiVal{AsciiVal: "%%"}},
+			wantErr:  `cannot unmarshal "%%" into binary`,
+		},
+		{
+			desc:     "fail gNMI AsciiVal to Yempty",
+			inSchema: typeToLeafSchema("empty-leaf", yang.Yempty),
+			inVal:    &gpb.TypedValue{Value: &gpb.TypedValue_AsciiVal{AsciiVal: "true"}},
+			wantErr:  `failed to unmarshal`,
+		},
 		{
 			desc:     "success gNMI DoubleVal to Ydecimal64",
 			inSchema: typeToLeafSchema("decimal-leaf", yang.Ydecimal64),
@@ -2251,6 +2317,21 @@ func TestUnmarshalLeafGNMIEncoding(t *testing.T) {
 			},
 			wantVal: &LeafContainerStruct{Int8LeafList: []int8{42, 43}},
 		},
+		{
+			desc:     "success unmarshalling int8 leaf list field with AsciiVal elements",
+			inSchema: leafListSchema,
+			inVal: &gpb.TypedValue{
+				Value: &gpb.TypedValue_LeaflistVal{
+					LeaflistVal: &gpb.ScalarArray{
+						Element: []*gpb.TypedValue{
+							{Value: &gpb.TypedValue_AsciiVal{AsciiVal: "42"}},
+							{Value: &gpb.TypedValue_AsciiVal{AsciiVal: "43"}},
+						},
+					},
+				},
+			},
+			wantVal: &LeafContainerStruct{Int8LeafList: []int8{42, 43}},
+		},
 		{
 			desc:     "fail unmarshalling int8 leaf list field with incorrect type",
 			inSchema: leafListSchema,
@@ -47,52 +47,88 @@ func ValidateLeafRefData(schema *yang.Entry, value interface{}, opt *LeafrefOpti
 		return nil
 	}
 
-	// validateLeafRefDataIterFunc is called on every node in the tree through
-	// ForEachField below.
-	validateLeafRefDataIterFunc := func(ni *util.NodeInfo, in, out interface{}) util.Errors {
-		if util.IsValueNil(ni) || util.IsNilOrInvalidValue(ni.FieldValue) {
-			return nil
-		}
-		schema := ni.Schema
-		if schema == nil {
-			return util.NewErrs(fmt.Errorf("schema is nil for value %s, type %T", util.ValueStr(value), value))
-		}
-		if !util.IsLeafRef(schema) || schema.IsLeafList() {
-			return nil
-		}
-
-		pathQueryNode, ok := in.(*util.PathQueryNodeMemo)
-		if !ok {
-			return util.NewErrs(fmt.Errorf("expected input to validateLeafRefDataIterFunc to be type *util.PathQueryNodeMemo, but got %T", in))
-		}
-		gNMIPath, err := leafRefToGNMIPath(ni, schema.Type.Path, pathQueryNode)
-		if err != nil {
-			return util.NewErrs(err)
-		}
-		matchNodes, err := dataNodesAtPath(ni, gNMIPath, pathQueryNode)
-		if err != nil {
-			return util.NewErrs(err)
-		}
+	v := leafrefVisitor{
+		root: value,
+		opt:  opt,
+		errs: &util.DefaultWalkErrors{},
+	}
+	if !util.IsValueNil(value) {
+		util.Walk(v, util.WalkNodeFromGoStruct(value), util.DefaultWalkOptions().WithSchema(schema).WithWalkErrors(v.errs))
+	}
+	return v.errs.Errors
+}
 
-		pathStr := util.StripModulePrefixesStr(schema.Type.Path)
-		util.DbgPrint("Verifying leafref at %s, matching nodes are: %v", pathStr, util.ValueStrDebug(matchNodes))
+// leafrefVisitor is a util.Visitor that validates each leafref within the
+// data tree being walked. Each node is given its own PathQueryNodeMemo, whose
+// parent is the memo of the node's parent, such that the results of path
+// queries can be reused by the node's descendants.
+type leafrefVisitor struct {
+	// parent is the memo of the parent of the nodes being visited.
+	parent *util.PathQueryNodeMemo
+	// root is the root of the data tree.
+	root interface{}
+	// opt is the set of options for leafref validation.
+	opt *LeafrefOptions
+	// errs collects the errors encountered during the walk.
+	errs *util.DefaultWalkErrors
+}
 
-		match, err := matchesNodes(ni, matchNodes)
-		if err != nil {
-			return leafrefErrOrLog(util.NewErrs(err), opt)
-		}
-		if !match {
-			e := fmt.Errorf("field name %s value %s schema path %s has leafref path %s not equal to any target nodes",
-				ni.StructField.Name, util.ValueStr(ni.FieldValue.Interface()), ni.Schema.Path(), pathStr)
-			util.DbgPrint("ERR: %s", e)
-			return leafrefErrOrLog(util.NewErrs(e), opt)
-		}
+// Visit implements the util.Visitor interface. Since v is passed by value,
+// it can be modified to form the visitor of the node's children.
+func (v leafrefVisitor) Visit(node util.WalkNode) util.Visitor {
+	if node == nil {
+		return nil
+	}
+	// Each sibling needs a dedicated (not shared) Memo data structure.
+	memo := &util.PathQueryNodeMemo{
+		Parent: v.parent,
+		Memo:   util.PathQueryMemo{},
+	}
+	for _, err := range v.validateLeafRefData(node.NodeInfo(), memo) {
+		v.errs.Collect(err)
+	}
+	v.parent = memo
+	return v
+}
 
+// validateLeafRefData validates the node described by ni if it is a leafref,
+// using pathQueryNode to memoise the path queries that are made.
+func (v leafrefVisitor) validateLeafRefData(ni *util.NodeInfo, pathQueryNode *util.PathQueryNodeMemo) util.Errors {
+	if util.IsValueNil(ni) || util.IsNilOrInvalidValue(ni.FieldValue) {
+		return nil
+	}
+	schema := ni.Schema
+	if schema == nil {
+		return util.NewErrs(fmt.Errorf("schema is nil for value %s, type %T", util.ValueStr(v.root), v.root))
+	}
+	if !util.IsLeafRef(schema) || schema.IsLeafList() {
 		return nil
 	}
 
-	pathQueryRootNode := &util.PathQueryNodeMemo{Memo: util.PathQueryMemo{}}
-	return util.ForEachField(schema, value, pathQueryRootNode, nil, validateLeafRefDataIterFunc)
+	gNMIPath, err := leafRefToGNMIPath(ni, schema.Type.Path, pathQueryNode)
+	if err != nil {
+		return util.NewErrs(err)
+	}
+	matchNodes, err := dataNodesAtPath(ni, gNMIPath, pathQueryNode)
+	if err != nil {
+		return util.NewErrs(err)
+	}
+
+	pathStr := util.StripModulePrefixesStr(schema.Type.Path)
+	util.DbgPrint("Verifying leafref at %s, matching nodes are: %v", pathStr, util.ValueStrDebug(matchNodes))
+
+	match, err := matchesNodes(ni, matchNodes)
+	if err != nil {
+		return leafrefErrOrLog(util.NewErrs(err), v.opt)
+	}
+	if !match {
+		e := fmt.Errorf("field name %s value %s schema path %s has leafref path %s not equal to any target nodes",
+			ni.StructField.Name, util.ValueStr(ni.FieldValue.Interface()), ni.Schema.Path(), pathStr)
+		util.DbgPrint("ERR: %s", e)
+		return leafrefErrOrLog(util.NewErrs(e), v.opt)
+	}
+
+	return nil
 }
 
 // leafrefErrOrLog returns an error if the global ValidationOptions specifies
@@ -193,7 +229,7 @@ func dataNodesAtPath(ni *util.NodeInfo, path *gpb.Path, pathQueryNode *util.Path
 			_, isOrderedMap := root.Parent.FieldValue.Interface().(ygot.GoOrderedMap)
 			if (root.Parent.Schema.IsList() && (util.IsValueMap(root.Parent.FieldValue) || isOrderedMap)) || (root.Parent.Schema.IsLeafList() && util.IsValueSlice(root.Parent.FieldValue)) {
 				// YANG lists and YANG leaf-lists are represented as Go maps and slices respectively.
-				// Despite these being a single level in the YANG hierarchy, util.ForEachField actually
+				// Despite these being a single level in the YANG hierarchy, util.Walk actually
 				// traverses these elements in two levels: first at the map/slice level, and then at the
 				// element level. Since it does this by creating a "fake", or extra NodeInfo for each
 				// element, we need to skip this level of NodeInfo and instead directly use the NodeInfo
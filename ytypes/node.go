@@ -70,6 +70,10 @@ type retrieveNodeArgs struct {
 	// ignoreExtraFields avoids generating an error when the input path
 	// refers to a field that does not exist in the GoStruct.
 	ignoreExtraFields bool
+	// If leafPredicates is set to true, keys of list elements in the path
+	// that are not keys of the list are treated as predicates on the
+	// leaves of each list entry.
+	leafPredicates bool
 }
 
 // retrieveNode is an internal function that retrieves the node specified by
@@ -83,7 +87,7 @@ func retrieveNode(schema *yang.Entry, root interface{}, path, traversedPath *gpb
 		// When args.val is non-nil and the schema isn't nil, further check whether
 		// the node has a non-leaf schema. Setting a non-leaf schema when the payload
 		// isn't JSON isn't allowed.
-		if !util.IsValueNil(args.val) && schema != nil && !(schema.IsLeaf() || schema.IsLeafList()) {
+		if !util.IsValueNil(args.val) && schema != nil && !(schema.IsLeaf() || schema.IsLeafList() || util.IsAnydataOrAnyxml(schema)) {
 			// When the payload is JSON, however, we are able to unmarshal into the root element.
 			// Note: handling for unmarshalling leaf nodes is done in another location since
 			// we need to know the parent struct of the leaf.
@@ -134,9 +138,19 @@ func retrieveNode(schema *yang.Entry, root interface{}, path, traversedPath *gpb
 
 	orderedMap, isOrderedMap := root.(ygot.GoOrderedMap)
 
+	if args.leafPredicates && schema.IsList() && (isOrderedMap || util.IsValueMap(reflect.ValueOf(root))) {
+		keys, preds, err := splitListPredicates(schema, path.GetElem()[0])
+		if err != nil {
+			return nil, status.Errorf(codes.InvalidArgument, "invalid path %v: %v", path, err)
+		}
+		if len(preds) != 0 {
+			return retrieveNodeListPredicates(schema, root, path, traversedPath, keys, preds, args)
+		}
+	}
+
 	switch {
 	// Check if the schema is a container, or the schema is a list and the parent provided is a member of that list.
-	case schema.IsContainer() || (schema.IsList() && !isOrderedMap && util.IsTypeStructPtr(reflect.TypeOf(root))):
+	case schema.IsContainer() || util.IsOperationContainer(schema) || (schema.IsList() && !isOrderedMap && util.IsTypeStructPtr(reflect.TypeOf(root))):
 		return retrieveNodeContainer(schema, root, path, traversedPath, args)
 	case schema.IsList() && isOrderedMap:
 		return retrieveNodeOrderedList(schema, orderedMap, path, traversedPath, args)
@@ -163,6 +177,29 @@ func retrieveNodeContainer(schema *yang.Entry, root interface{}, path *gpb.Path,
 	for i := 0; i < v.NumField(); i++ {
 		fv, ft := v.Field(i), v.Type().Field(i)
 
+		if util.IsChoiceField(ft) {
+			// The fields of the cases of a choice are children of root,
+			// so the case that contains the path is searched in place
+			// of root.
+			c, err := choiceCaseForPath(root, fv, ft, path, args.modifyRoot && !args.delete)
+			switch {
+			case err != nil:
+				return nil, status.Errorf(codes.Unknown, "failed to find case of choice for %T, field %s: %s", root, ft.Name, err)
+			case c == nil:
+				continue
+			}
+			matches, err := retrieveNodeContainer(schema, c, path, traversedPath, args)
+			if err != nil {
+				return nil, err
+			}
+			// If all of the fields of the case have been deleted, then
+			// the case is no longer selected.
+			if args.delete && reflect.ValueOf(c).Elem().IsZero() {
+				fv.Set(reflect.Zero(ft.Type))
+			}
+			return matches, nil
+		}
+
 		childSchemaFn := util.ChildSchema
 		if args.preferShadowPath {
 			childSchemaFn = util.ChildSchemaPreferShadow
@@ -234,10 +271,10 @@ func retrieveNodeContainer(schema *yang.Entry, root interface{}, path *gpb.Path,
 					if err := util.UpdateField(root, ft.Name, args.val); err != nil {
 						return nil, status.Errorf(codes.Unknown, "failed to update struct field %s in %T with value %v, because of %v", ft.Name, root, args.val, err)
 					}
-				case cschema.IsLeaf() || cschema.IsLeafList():
-					// With GNMIEncoding, unmarshalGeneric can only unmarshal leaf or leaf list
-					// nodes. Schema provided must be the schema of the leaf or leaf list node.
-					// root must be the reference of container leaf/leaf list belongs to.
+				case cschema.IsLeaf() || cschema.IsLeafList() || util.IsAnydataOrAnyxml(cschema):
+					// With GNMIEncoding, unmarshalGeneric can only unmarshal leaf, leaf list,
+					// anydata or anyxml nodes. Schema provided must be the schema of the node.
+					// root must be the reference of container the node belongs to.
 					var val interface{}
 					var encoding Encoding
 
@@ -776,15 +813,217 @@ type TreeNode struct {
 // GetNode retrieves the node specified by the supplied path from the specified root, whose schema must
 // also be supplied. It takes a set of options which can be used to specify get behaviours, such as
 // allowing partial match. If there are no matches for the path, an error is returned.
+//
+// If GetHandleWildcards is specified, the path may contain the multi-level
+// wildcard "...", which matches zero or more path elements. As for other
+// paths, the list elements of such a path must specify all of the keys of the
+// list, either explicitly or as a wildcard, unless GetPartialKeyMatch is
+// specified. If GetLeafPredicates is specified, list elements within the path may select
+// list entries by the values of their leaves, unless the path also contains
+// "...".
 func GetNode(schema *yang.Entry, root interface{}, path *gpb.Path, opts ...GetNodeOpt) ([]*TreeNode, error) {
-	return retrieveNode(schema, root, path, nil, retrieveNodeArgs{
+	args := retrieveNodeArgs{
 		// We never want to modify the input root, so we specify modifyRoot.
 		modifyRoot:       false,
 		partialKeyMatch:  hasPartialKeyMatch(opts),
 		handleWildcards:  hasHandleWildcards(opts),
 		tolerateNil:      hasGetTolerateNil(opts),
 		preferShadowPath: hasGetNodePreferShadowPath(opts),
+		leafPredicates:   hasGetLeafPredicates(opts),
+	}
+	if args.handleWildcards && hasMultiLevelWildcard(path) {
+		if args.leafPredicates {
+			return nil, status.Errorf(codes.InvalidArgument, "leaf predicates cannot be used with the multi-level wildcard, path %v", path)
+		}
+		return retrieveNodeMultiLevelWildcard(schema, root, path, args)
+	}
+	return retrieveNode(schema, root, path, nil, args)
+}
+
+// hasMultiLevelWildcard returns whether the supplied path contains the
+// multi-level wildcard "...".
+func hasMultiLevelWildcard(path *gpb.Path) bool {
+	for _, e := range path.GetElem() {
+		if e.GetName() == util.MultiLevelWildcard {
+			return true
+		}
+	}
+	return false
+}
+
+// retrieveNodeMultiLevelWildcard returns the nodes within root, which has the
+// supplied schema, whose paths match the query path, which contains the
+// multi-level wildcard "...". Since the wildcard may match any number of path
+// elements, the populated data nodes of root are walked, skipping subtrees
+// whose paths cannot match the query.
+func retrieveNodeMultiLevelWildcard(schema *yang.Entry, root interface{}, path *gpb.Path, args retrieveNodeArgs) ([]*TreeNode, error) {
+	// firstWildcard is the index of the first multi-level wildcard in the
+	// query, any path may match the elements that follow it.
+	firstWildcard := 0
+	for i, e := range path.GetElem() {
+		if e.GetName() == util.MultiLevelWildcard {
+			firstWildcard = i
+			break
+		}
+	}
+
+	// The paths of the data nodes are compared to the elements of the
+	// query, since the data nodes' paths do not have an origin.
+	query := &gpb.Path{Elem: path.GetElem()}
+	matchesQuery := util.PathMatchesQueryExactlyAllKeys
+	if args.partialKeyMatch {
+		matchesQuery = util.PathMatchesQueryExactly
+	}
+
+	var matches []*TreeNode
+	err := walkDataNodes(schema, root, &gpb.Path{}, args.preferShadowPath, func(n *TreeNode) bool {
+		// The elements of the node's path that precede the wildcard must
+		// match the query, otherwise none of its descendants can match.
+		k := len(n.Path.GetElem())
+		if k > firstWildcard {
+			k = firstWildcard
+		}
+		if !util.PathMatchesQuery(n.Path, &gpb.Path{Elem: query.Elem[:k]}) {
+			return false
+		}
+		if matchesQuery(n.Path, query) {
+			matches = append(matches, n)
+		}
+		return true
 	})
+	if err != nil {
+		return nil, err
+	}
+	if len(matches) == 0 && !args.tolerateNil {
+		return nil, status.Errorf(codes.NotFound, "no match found in %T, for path %v", root, path)
+	}
+	return matches, nil
+}
+
+// walkDataNodes calls visit for the data tree node root, which has the
+// supplied schema and path, and for each of its populated descendants. The
+// descendants of a node are not visited if visit returns false. If
+// preferShadowPath is set, the paths of the descendants are determined using
+// their shadow paths where they are present.
+func walkDataNodes(schema *yang.Entry, root interface{}, path *gpb.Path, preferShadowPath bool, visit func(*TreeNode) bool) error {
+	if util.IsValueNil(root) {
+		return nil
+	}
+	if !visit(&TreeNode{Schema: schema, Data: root, Path: path}) {
+		return nil
+	}
+
+	rv := reflect.ValueOf(root)
+	if !util.IsValueStructPtr(rv) {
+		// Leaves and leaf-lists have no descendants.
+		return nil
+	}
+	return walkDataNodeFields(schema, root, path, preferShadowPath, visit)
+}
+
+// walkDataNodeFields calls walkDataNodes for each populated field of root,
+// which must be a struct ptr representing the data tree node with the
+// supplied schema and path, or the selected case of a choice within it.
+func walkDataNodeFields(schema *yang.Entry, root interface{}, path *gpb.Path, preferShadowPath bool, visit func(*TreeNode) bool) error {
+	childSchemaFn := util.ChildSchema
+	if preferShadowPath {
+		childSchemaFn = util.ChildSchemaPreferShadow
+	}
+
+	v := reflect.ValueOf(root).Elem()
+	for i := 0; i < v.NumField(); i++ {
+		fv, ft := v.Field(i), v.Type().Field(i)
+		if util.IsYgotAnnotation(ft) || util.IsValueNil(fv.Interface()) {
+			continue
+		}
+
+		if util.IsChoiceField(ft) {
+			// The fields of the selected case of a choice are children
+			// of root.
+			if err := walkDataNodeFields(schema, fv.Interface(), path, preferShadowPath, visit); err != nil {
+				return err
+			}
+			continue
+		}
+
+		cschema, err := childSchemaFn(schema, ft)
+		switch {
+		case err != nil:
+			return status.Errorf(codes.Unknown, "failed to get child schema for %T, field %s: %s", root, ft.Name, err)
+		case cschema == nil:
+			return status.Errorf(codes.InvalidArgument, "could not find schema for type %T, field %s", root, ft.Name)
+		}
+
+		schPaths, err := util.SchemaPaths(ft)
+		if err != nil {
+			return status.Errorf(codes.Unknown, "failed to get schema paths for %T, field %s: %s", root, ft.Name, err)
+		}
+		if shadowPaths := util.ShadowSchemaPaths(ft); preferShadowPath && len(shadowPaths) != 0 {
+			schPaths = shadowPaths
+		}
+
+		for _, p := range schPaths {
+			np := &gpb.Path{Elem: append([]*gpb.PathElem{}, path.GetElem()...)}
+			for _, e := range p {
+				np.Elem = append(np.Elem, &gpb.PathElem{Name: e})
+			}
+
+			// walkEntry visits the list entry v with key k of the list
+			// at path np.
+			walkEntry := func(k, v reflect.Value) error {
+				keys, err := ygot.PathKeyFromStruct(v)
+				if err != nil {
+					// Fall back to the map key if the key fields of the
+					// list entry are not populated.
+					if keys, err = getKeyFields(k, v, cschema.Key); err != nil {
+						return err
+					}
+				}
+				ep := &gpb.Path{Elem: append([]*gpb.PathElem{}, np.Elem...)}
+				ep.Elem[len(ep.Elem)-1] = &gpb.PathElem{Name: ep.Elem[len(ep.Elem)-1].Name, Key: keys}
+				return walkDataNodes(cschema, v.Interface(), ep, preferShadowPath, visit)
+			}
+
+			if om, ok := fv.Interface().(ygot.GoOrderedMap); ok {
+				var walkErr error
+				if err := yreflect.RangeOrderedMap(om, func(k, v reflect.Value) bool {
+					walkErr = walkEntry(k, v)
+					return walkErr == nil
+				}); err != nil {
+					return err
+				}
+				if walkErr != nil {
+					return walkErr
+				}
+				continue
+			}
+
+			if util.IsValueMap(fv) {
+				for _, k := range fv.MapKeys() {
+					if err := walkEntry(k, fv.MapIndex(k)); err != nil {
+						return err
+					}
+				}
+				continue
+			}
+
+			if util.IsValueSlice(fv) && util.IsTypeStructPtr(fv.Type().Elem()) {
+				// The entries of a keyless list have no keys within
+				// their paths.
+				for j := 0; j < fv.Len(); j++ {
+					if err := walkDataNodes(cschema, fv.Index(j).Interface(), np, preferShadowPath, visit); err != nil {
+						return err
+					}
+				}
+				continue
+			}
+
+			if err := walkDataNodes(cschema, fv.Interface(), np, preferShadowPath, visit); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
 }
 
 // GetNodeOpt defines an interface that can be used to supply arguments to functions using GetNode.
@@ -1141,6 +1141,7 @@ type rootStruct struct {
 	List      map[string]*listEntry            `path:"list"`
 	Multilist map[multiListKey]*multiListEntry `path:"multilist"`
 	ChildList map[string]*childList            `path:"state/childlist"`
+	Unkeyed   []*grandchildContainer           `path:"unkeyed"`
 }
 
 func TestGetNode(t *testing.T) {
@@ -1227,6 +1228,23 @@ func TestGetNode(t *testing.T) {
 	}
 	rootSchema.Dir["multilist"] = multiKeyListSchema
 
+	unkeyedListSchema := &yang.Entry{
+		Name:     "unkeyed",
+		Kind:     yang.DirectoryEntry,
+		Parent:   rootSchema,
+		Config:   yang.TSFalse,
+		ListAttr: &yang.ListAttr{},
+		Dir:      map[string]*yang.Entry{},
+	}
+	rootSchema.Dir["unkeyed"] = unkeyedListSchema
+	unkeyedValSchema := &yang.Entry{
+		Name:   "val",
+		Kind:   yang.LeafEntry,
+		Parent: unkeyedListSchema,
+		Type:   &yang.YangType{Kind: yang.Ystring},
+	}
+	unkeyedListSchema.Dir["val"] = unkeyedValSchema
+
 	keyOneListSchema := &yang.Entry{
 		Name:   "keyone",
 		Kind:   yang.LeafEntry,
@@ -1845,6 +1863,191 @@ func TestGetNode(t *testing.T) {
 		inPath:           mustPath("/state/childlist[key=one]/child-container/valeur"),
 		inArgs:           []GetNodeOpt{&PreferShadowPath{}},
 		wantErrSubstring: "no match found in *ytypes.listChildContainer",
+	}, {
+		desc:     "multi-level wildcard matching leaf at any depth",
+		inSchema: rootSchema,
+		inData: &rootStruct{
+			Leaf: ygot.String("leaf"),
+			Container: &childContainer{
+				Container: &grandchildContainer{
+					Val: ygot.String("val"),
+				},
+			},
+		},
+		inPath: mustPath("/.../val"),
+		inArgs: []GetNodeOpt{&GetHandleWildcards{}},
+		wantTreeNodes: []*TreeNode{{
+			Data:   ygot.String("val"),
+			Schema: valSchema,
+			Path:   mustPath("/container/grandchild/val"),
+		}},
+	}, {
+		desc:     "multi-level wildcard matching zero elements",
+		inSchema: rootSchema,
+		inData: &rootStruct{
+			Container: &childContainer{
+				Container: &grandchildContainer{
+					Val: ygot.String("val"),
+				},
+			},
+		},
+		inPath: mustPath("/container/.../grandchild"),
+		inArgs: []GetNodeOpt{&GetHandleWildcards{}},
+		wantTreeNodes: []*TreeNode{{
+			Data:   &grandchildContainer{Val: ygot.String("val")},
+			Schema: grandchildContainerSchema,
+			Path:   mustPath("/container/grandchild"),
+		}},
+	}, {
+		desc:     "trailing multi-level wildcard",
+		inSchema: rootSchema,
+		inData: &rootStruct{
+			Leaf: ygot.String("leaf"),
+			Container: &childContainer{
+				Container: &grandchildContainer{
+					Val: ygot.String("val"),
+				},
+			},
+		},
+		inPath: mustPath("/container/..."),
+		inArgs: []GetNodeOpt{&GetHandleWildcards{}},
+		wantTreeNodes: []*TreeNode{{
+			Data:   &childContainer{Container: &grandchildContainer{Val: ygot.String("val")}},
+			Schema: childContainerSchema,
+			Path:   mustPath("/container"),
+		}, {
+			Data:   &grandchildContainer{Val: ygot.String("val")},
+			Schema: grandchildContainerSchema,
+			Path:   mustPath("/container/grandchild"),
+		}, {
+			Data:   ygot.String("val"),
+			Schema: valSchema,
+			Path:   mustPath("/container/grandchild/val"),
+		}},
+	}, {
+		desc:     "multi-level wildcard followed by list with wildcard key",
+		inSchema: rootSchema,
+		inData: &rootStruct{
+			Multilist: map[multiListKey]*multiListEntry{
+				{Keyone: 1, Keytwo: 2}: {Keyone: ygot.Uint32(1), Keytwo: ygot.Uint32(2)},
+				{Keyone: 3, Keytwo: 4}: {Keyone: ygot.Uint32(3), Keytwo: ygot.Uint32(4)},
+			},
+		},
+		inPath: mustPath("/.../multilist[keyone=*][keytwo=4]/keyone"),
+		inArgs: []GetNodeOpt{&GetHandleWildcards{}},
+		wantTreeNodes: []*TreeNode{{
+			Data:   ygot.Uint32(3),
+			Schema: keyOneListSchema,
+			Path:   mustPath("/multilist[keyone=3][keytwo=4]/keyone"),
+		}},
+	}, {
+		desc:     "multi-level wildcard followed by list with partial keys",
+		inSchema: rootSchema,
+		inData: &rootStruct{
+			Multilist: map[multiListKey]*multiListEntry{
+				{Keyone: 3, Keytwo: 4}: {Keyone: ygot.Uint32(3), Keytwo: ygot.Uint32(4)},
+			},
+		},
+		inPath:           mustPath("/.../multilist[keytwo=4]/keyone"),
+		inArgs:           []GetNodeOpt{&GetHandleWildcards{}},
+		wantErrSubstring: "no match found in *ytypes.rootStruct",
+	}, {
+		desc:     "multi-level wildcard followed by list with partial keys and partial key match",
+		inSchema: rootSchema,
+		inData: &rootStruct{
+			Multilist: map[multiListKey]*multiListEntry{
+				{Keyone: 1, Keytwo: 2}: {Keyone: ygot.Uint32(1), Keytwo: ygot.Uint32(2)},
+				{Keyone: 3, Keytwo: 4}: {Keyone: ygot.Uint32(3), Keytwo: ygot.Uint32(4)},
+			},
+		},
+		inPath: mustPath("/.../multilist[keytwo=4]/keyone"),
+		inArgs: []GetNodeOpt{&GetHandleWildcards{}, &GetPartialKeyMatch{}},
+		wantTreeNodes: []*TreeNode{{
+			Data:   ygot.Uint32(3),
+			Schema: keyOneListSchema,
+			Path:   mustPath("/multilist[keyone=3][keytwo=4]/keyone"),
+		}},
+	}, {
+		desc:     "multi-level wildcard within unkeyed list entries",
+		inSchema: rootSchema,
+		inData: &rootStruct{
+			Unkeyed: []*grandchildContainer{{Val: ygot.String("a")}, {}, {Val: ygot.String("b")}},
+		},
+		inPath: mustPath("/.../val"),
+		inArgs: []GetNodeOpt{&GetHandleWildcards{}},
+		wantTreeNodes: []*TreeNode{{
+			Data:   ygot.String("a"),
+			Schema: unkeyedValSchema,
+			Path:   mustPath("/unkeyed/val"),
+		}, {
+			Data:   ygot.String("b"),
+			Schema: unkeyedValSchema,
+			Path:   mustPath("/unkeyed/val"),
+		}},
+	}, {
+		desc:     "multi-level wildcard within list entries",
+		inSchema: rootSchema,
+		inData: &rootStruct{
+			ChildList: map[string]*childList{
+				"one": {
+					Key:            ygot.String("one"),
+					ChildContainer: &listChildContainer{Value: ygot.String("1")},
+				},
+				"two": {
+					Key: ygot.String("two"),
+				},
+			},
+		},
+		inPath: mustPath("/state/childlist[key=*]/.../config/value"),
+		inArgs: []GetNodeOpt{&GetHandleWildcards{}},
+		wantTreeNodes: []*TreeNode{{
+			Data:   ygot.String("1"),
+			Schema: rootSchema.Dir["state"].Dir["childlist"].Dir["child-container"].Dir["value"],
+			Path:   mustPath("/state/childlist[key=one]/child-container/config/value"),
+		}},
+	}, {
+		desc:     "multi-level wildcard within list entries with preferShadowPath=true",
+		inSchema: rootSchema,
+		inData: &rootStruct{
+			ChildList: map[string]*childList{
+				"one": {
+					Key:            ygot.String("one"),
+					ChildContainer: &listChildContainer{Value: ygot.String("1")},
+				},
+			},
+		},
+		inPath: mustPath("/.../state/value"),
+		inArgs: []GetNodeOpt{&GetHandleWildcards{}, &PreferShadowPath{}},
+		wantTreeNodes: []*TreeNode{{
+			Data:   ygot.String("1"),
+			Schema: rootSchema.Dir["state"].Dir["childlist"].Dir["child-container"].Dir["value"],
+			Path:   mustPath("/state/childlist[key=one]/child-container/state/value"),
+		}},
+	}, {
+		desc:     "multi-level wildcard with no matches",
+		inSchema: rootSchema,
+		inData: &rootStruct{
+			Leaf: ygot.String("leaf"),
+		},
+		inPath:           mustPath("/.../val"),
+		inArgs:           []GetNodeOpt{&GetHandleWildcards{}},
+		wantErrSubstring: "no match found in *ytypes.rootStruct",
+	}, {
+		desc:     "multi-level wildcard with no matches, tolerating nil",
+		inSchema: rootSchema,
+		inData: &rootStruct{
+			Leaf: ygot.String("leaf"),
+		},
+		inPath: mustPath("/.../val"),
+		inArgs: []GetNodeOpt{&GetHandleWildcards{}, &GetTolerateNil{}},
+	}, {
+		desc:     "multi-level wildcard without handling wildcards",
+		inSchema: rootSchema,
+		inData: &rootStruct{
+			Leaf: ygot.String("leaf"),
+		},
+		inPath:           mustPath("/.../leaf"),
+		wantErrSubstring: "no match found in *ytypes.rootStruct",
 	}}
 
 	for _, tt := range tests {
@@ -0,0 +1,353 @@
+// Copyright 2023 Google Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package ytypes
+
+import (
+	"fmt"
+	"reflect"
+	"regexp"
+	"strconv"
+	"strings"
+
+	"github.com/openconfig/goyang/pkg/yang"
+	"github.com/openconfig/ygot/internal/yreflect"
+	"github.com/openconfig/ygot/util"
+	"github.com/openconfig/ygot/ygot"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	gpb "github.com/openconfig/gnmi/proto/gnmi"
+)
+
+// GetLeafPredicates specifies that keys within a list element of the path
+// supplied to GetNode that are not keys of the list are to be treated as
+// predicates on the leaves of each list entry. The name of a predicate is the
+// path of a leaf relative to the list entry, e.g., "oper-status" or
+// "state/oper-status", and its value is an optional operator followed by an
+// operand. The supported operators are:
+//
+//	"==" (or no operator)  equal to
+//	"!="                    not equal to
+//	"<", "<=", ">", ">="    ordered comparison
+//	"=~"                    matches the regular expression
+//	"!~"                    does not match the regular expression
+//
+// For example, the path /interfaces/interface[oper-status=DOWN] matches all
+// interfaces whose oper-status is DOWN, and
+// /bgp/neighbors/neighbor[peer-as=>65000] matches all neighbors whose peer-as
+// is greater than 65000.
+//
+// Predicates are evaluated against the typed value of each leaf: integer and
+// decimal leaves are compared numerically, enumerated leaves are compared by
+// their YANG names, and all other leaves are compared as strings. Regular
+// expressions must match the whole value. A leaf-list satisfies a predicate
+// if any of its values does, and an unset leaf does not satisfy any
+// predicate. List keys that are not specified in a path element containing
+// predicates match all list entries.
+type GetLeafPredicates struct{}
+
+// IsGetNodeOpt implements the GetNodeOpt interface.
+func (*GetLeafPredicates) IsGetNodeOpt() {}
+
+// hasGetLeafPredicates determines whether there is an instance of
+// GetLeafPredicates within the supplied GetNodeOpt slice.
+func hasGetLeafPredicates(opts []GetNodeOpt) bool {
+	for _, o := range opts {
+		if _, ok := o.(*GetLeafPredicates); ok {
+			return true
+		}
+	}
+	return false
+}
+
+// predicateOp is an operator used within a leaf predicate.
+type predicateOp int
+
+const (
+	predEqual predicateOp = iota
+	predNotEqual
+	predLess
+	predLessEqual
+	predGreater
+	predGreaterEqual
+	predMatch
+	predNotMatch
+)
+
+// predicateOps maps the string form of each operator to its predicateOp. Two
+// character operators are listed first such that they are matched in
+// preference to their single character prefixes.
+var predicateOps = []struct {
+	str string
+	op  predicateOp
+}{
+	{"==", predEqual},
+	{"!=", predNotEqual},
+	{"<=", predLessEqual},
+	{">=", predGreaterEqual},
+	{"=~", predMatch},
+	{"!~", predNotMatch},
+	{"<", predLess},
+	{">", predGreater},
+}
+
+// leafPredicate is a parsed predicate on a leaf of a list entry.
+type leafPredicate struct {
+	// path is the path of the leaf relative to the list entry.
+	path *gpb.Path
+	// op is the operator of the predicate.
+	op predicateOp
+	// operand is the value that the leaf is compared to.
+	operand string
+	// re is the compiled regular expression for predMatch and predNotMatch.
+	re *regexp.Regexp
+}
+
+// parseLeafPredicate parses the predicate with the supplied name and value.
+func parseLeafPredicate(name, value string) (*leafPredicate, error) {
+	p := &leafPredicate{path: &gpb.Path{}, op: predEqual, operand: value}
+	for _, n := range strings.Split(name, "/") {
+		if n == "" {
+			return nil, fmt.Errorf("invalid leaf predicate name %q", name)
+		}
+		p.path.Elem = append(p.path.Elem, &gpb.PathElem{Name: n})
+	}
+	for _, o := range predicateOps {
+		if strings.HasPrefix(value, o.str) {
+			p.op, p.operand = o.op, strings.TrimPrefix(value, o.str)
+			break
+		}
+	}
+	if p.op == predMatch || p.op == predNotMatch {
+		re, err := regexp.Compile("^(?:" + p.operand + ")$")
+		if err != nil {
+			return nil, fmt.Errorf("invalid regular expression in leaf predicate %s=%s: %v", name, value, err)
+		}
+		p.re = re
+	}
+	return p, nil
+}
+
+// splitListPredicates splits the keys of the list path element e into those
+// that are keys of the list described by schema and the leaf predicates.
+func splitListPredicates(schema *yang.Entry, e *gpb.PathElem) (map[string]string, []*leafPredicate, error) {
+	isKey := map[string]bool{}
+	for _, k := range strings.Fields(schema.Key) {
+		isKey[k] = true
+	}
+	keys := map[string]string{}
+	var preds []*leafPredicate
+	for k, v := range e.GetKey() {
+		if isKey[k] {
+			keys[k] = v
+			continue
+		}
+		p, err := parseLeafPredicate(k, v)
+		if err != nil {
+			return nil, nil, err
+		}
+		preds = append(preds, p)
+	}
+	return keys, preds, nil
+}
+
+// retrieveNodeListPredicates retrieves the nodes matching path from the list
+// root, whose schema is supplied, where the first element of path contains
+// the leaf predicates preds, and the list keys keys. Each list entry that
+// satisfies all predicates, and whose keys match keys, is traversed further.
+func retrieveNodeListPredicates(schema *yang.Entry, root interface{}, path, traversedPath *gpb.Path, keys map[string]string, preds []*leafPredicate, args retrieveNodeArgs) ([]*TreeNode, error) {
+	var matches []*TreeNode
+	visit := func(v reflect.Value) error {
+		entryKeys, err := ygot.PathKeyFromStruct(v)
+		if err != nil {
+			return status.Errorf(codes.Unknown, "could not get path keys at %v: %v", traversedPath, err)
+		}
+		for k, want := range keys {
+			if !(args.handleWildcards && want == "*") && entryKeys[k] != want {
+				return nil
+			}
+		}
+		for _, p := range preds {
+			ok, err := p.matches(schema, v.Interface(), args)
+			if err != nil {
+				return status.Errorf(codes.InvalidArgument, "cannot evaluate leaf predicate in %v: %v", path.GetElem()[0], err)
+			}
+			if !ok {
+				return nil
+			}
+		}
+		nodes, err := retrieveNode(schema, v.Interface(), util.PopGNMIPath(path), appendElem(traversedPath, &gpb.PathElem{Name: path.GetElem()[0].GetName(), Key: entryKeys}), args)
+		if err != nil {
+			return err
+		}
+		matches = append(matches, nodes...)
+		return nil
+	}
+
+	if om, ok := root.(ygot.GoOrderedMap); ok {
+		var outerErr error
+		if err := yreflect.RangeOrderedMap(om, func(_, v reflect.Value) bool {
+			outerErr = visit(v)
+			return outerErr == nil
+		}); err != nil {
+			return nil, err
+		}
+		return matches, outerErr
+	}
+
+	rv := reflect.ValueOf(root)
+	if !util.IsValueMap(rv) {
+		return nil, status.Errorf(codes.InvalidArgument, "root has type %T, expect map", root)
+	}
+	for _, k := range rv.MapKeys() {
+		if err := visit(rv.MapIndex(k)); err != nil {
+			return nil, err
+		}
+	}
+	return matches, nil
+}
+
+// matches returns true if the list entry entry, whose schema is supplied,
+// satisfies the predicate p.
+func (p *leafPredicate) matches(schema *yang.Entry, entry interface{}, args retrieveNodeArgs) (bool, error) {
+	nodes, err := retrieveNode(schema, entry, p.path, nil, retrieveNodeArgs{
+		tolerateNil:      true,
+		preferShadowPath: args.preferShadowPath,
+	})
+	if err != nil {
+		return false, err
+	}
+	for _, n := range nodes {
+		if n.Schema == nil || !(n.Schema.IsLeaf() || n.Schema.IsLeafList()) {
+			return false, fmt.Errorf("predicate path %v does not refer to a leaf", p.path)
+		}
+		v := reflect.ValueOf(n.Data)
+		if util.IsValueNil(v) {
+			continue
+		}
+		if n.Schema.IsLeafList() && v.Kind() == reflect.Slice {
+			for i := 0; i < v.Len(); i++ {
+				ok, err := p.compare(v.Index(i))
+				if err != nil || ok {
+					return ok, err
+				}
+			}
+			continue
+		}
+		ok, err := p.compare(v)
+		if err != nil || ok {
+			return ok, err
+		}
+	}
+	return false, nil
+}
+
+// compare returns true if the leaf value v satisfies the predicate p.
+func (p *leafPredicate) compare(v reflect.Value) (bool, error) {
+	for v.Kind() == reflect.Ptr || v.Kind() == reflect.Interface {
+		if v.IsNil() {
+			return false, nil
+		}
+		v = v.Elem()
+	}
+
+	var s string
+	var cmp int
+	ordered := p.op != predMatch && p.op != predNotMatch
+	if e, ok := v.Interface().(ygot.GoEnum); ok {
+		name, err := ygot.EnumName(e)
+		if err != nil {
+			return false, err
+		}
+		if ordered && p.op != predEqual && p.op != predNotEqual {
+			return false, fmt.Errorf("ordered comparison is not supported for enumerated value %s", name)
+		}
+		s, cmp = name, strings.Compare(name, p.operand)
+	} else {
+		switch v.Kind() {
+		case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+			s = strconv.FormatInt(v.Int(), 10)
+			if ordered {
+				o, err := strconv.ParseInt(p.operand, 10, 64)
+				if err != nil {
+					return false, fmt.Errorf("cannot compare integer value %s with %q", s, p.operand)
+				}
+				cmp = compareOrdered(v.Int(), o)
+			}
+		case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+			s = strconv.FormatUint(v.Uint(), 10)
+			if ordered {
+				o, err := strconv.ParseUint(p.operand, 10, 64)
+				if err != nil {
+					return false, fmt.Errorf("cannot compare unsigned integer value %s with %q", s, p.operand)
+				}
+				cmp = compareOrdered(v.Uint(), o)
+			}
+		case reflect.Float32, reflect.Float64:
+			s = strconv.FormatFloat(v.Float(), 'g', -1, 64)
+			if ordered {
+				o, err := strconv.ParseFloat(p.operand, 64)
+				if err != nil {
+					return false, fmt.Errorf("cannot compare decimal value %s with %q", s, p.operand)
+				}
+				cmp = compareOrdered(v.Float(), o)
+			}
+		case reflect.Bool:
+			s = strconv.FormatBool(v.Bool())
+			cmp = strings.Compare(s, p.operand)
+		case reflect.String:
+			s = v.String()
+			cmp = strings.Compare(s, p.operand)
+		default:
+			var err error
+			if s, err = ygot.KeyValueAsString(v.Interface()); err != nil {
+				return false, err
+			}
+			cmp = strings.Compare(s, p.operand)
+		}
+	}
+
+	switch p.op {
+	case predEqual:
+		return cmp == 0, nil
+	case predNotEqual:
+		return cmp != 0, nil
+	case predLess:
+		return cmp < 0, nil
+	case predLessEqual:
+		return cmp <= 0, nil
+	case predGreater:
+		return cmp > 0, nil
+	case predGreaterEqual:
+		return cmp >= 0, nil
+	case predMatch:
+		return p.re.MatchString(s), nil
+	case predNotMatch:
+		return !p.re.MatchString(s), nil
+	}
+	return false, fmt.Errorf("unknown predicate operator %d", p.op)
+}
+
+// compareOrdered returns -1, 0 or 1 depending on whether a is less than,
+// equal to, or greater than b.
+func compareOrdered[T int64 | uint64 | float64](a, b T) int {
+	switch {
+	case a < b:
+		return -1
+	case a > b:
+		return 1
+	}
+	return 0
+}
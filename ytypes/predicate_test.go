@@ -0,0 +1,259 @@
+// Copyright 2023 Google Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package ytypes
+
+import (
+	"sort"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/openconfig/gnmi/errdiff"
+	"github.com/openconfig/goyang/pkg/yang"
+	"github.com/openconfig/ygot/ygot"
+)
+
+type predNeighbor struct {
+	Address     *string  `path:"address"`
+	PeerAs      *uint32  `path:"state/peer-as"`
+	Status      EnumType `path:"state/status"`
+	Description *string  `path:"state/description"`
+	Communities []string `path:"state/communities"`
+	Weight      *int8    `path:"state/weight"`
+}
+
+func (*predNeighbor) IsYANGGoStruct() {}
+
+func (n *predNeighbor) ΛListKeyMap() (map[string]interface{}, error) {
+	return map[string]interface{}{"address": *n.Address}, nil
+}
+
+type predRoot struct {
+	Neighbor map[string]*predNeighbor `path:"neighbor"`
+}
+
+func (*predRoot) IsYANGGoStruct() {}
+
+func predSchema() *yang.Entry {
+	sch := &yang.Entry{
+		Name: "root",
+		Kind: yang.DirectoryEntry,
+		Dir: map[string]*yang.Entry{
+			"neighbor": {
+				Name:     "neighbor",
+				Kind:     yang.DirectoryEntry,
+				ListAttr: yang.NewDefaultListAttr(),
+				Key:      "address",
+				Dir: map[string]*yang.Entry{
+					"address": {
+						Name: "address",
+						Kind: yang.LeafEntry,
+						Type: &yang.YangType{Kind: yang.Ystring},
+					},
+					"state": {
+						Name: "state",
+						Kind: yang.DirectoryEntry,
+						Dir: map[string]*yang.Entry{
+							"peer-as": {
+								Name: "peer-as",
+								Kind: yang.LeafEntry,
+								Type: &yang.YangType{Kind: yang.Yuint32},
+							},
+							"status": {
+								Name: "status",
+								Kind: yang.LeafEntry,
+								Type: &yang.YangType{Kind: yang.Yenum},
+							},
+							"description": {
+								Name: "description",
+								Kind: yang.LeafEntry,
+								Type: &yang.YangType{Kind: yang.Ystring},
+							},
+							"communities": {
+								Name:     "communities",
+								Kind:     yang.LeafEntry,
+								ListAttr: yang.NewDefaultListAttr(),
+								Type:     &yang.YangType{Kind: yang.Ystring},
+							},
+							"weight": {
+								Name: "weight",
+								Kind: yang.LeafEntry,
+								Type: &yang.YangType{Kind: yang.Yint8},
+							},
+						},
+					},
+				},
+			},
+		},
+	}
+	addParents(sch)
+	return sch
+}
+
+func TestGetNodeLeafPredicates(t *testing.T) {
+	root := &predRoot{
+		Neighbor: map[string]*predNeighbor{
+			"192.0.2.1": {
+				Address:     ygot.String("192.0.2.1"),
+				PeerAs:      ygot.Uint32(64512),
+				Status:      EnumType(41),
+				Description: ygot.String("transit-a"),
+				Communities: []string{"65000:1", "65000:2"},
+				Weight:      ygot.Int8(-5),
+			},
+			"192.0.2.2": {
+				Address:     ygot.String("192.0.2.2"),
+				PeerAs:      ygot.Uint32(65001),
+				Status:      EnumType(42),
+				Description: ygot.String("peer-b"),
+				Weight:      ygot.Int8(10),
+			},
+			"192.0.2.3": {
+				Address: ygot.String("192.0.2.3"),
+				PeerAs:  ygot.Uint32(4200000000),
+				Status:  EnumType(41),
+			},
+		},
+	}
+
+	tests := []struct {
+		desc             string
+		inPath           string
+		inOpts           []GetNodeOpt
+		want             []string
+		wantErrSubstring string
+	}{{
+		desc:   "enum equality",
+		inPath: "/neighbor[state/status=E_VALUE_FORTY_ONE]/address",
+		want:   []string{"192.0.2.1", "192.0.2.3"},
+	}, {
+		desc:   "explicit equality operator",
+		inPath: "/neighbor[state/status===E_VALUE_FORTY_TWO]/address",
+		want:   []string{"192.0.2.2"},
+	}, {
+		desc:   "not equal",
+		inPath: "/neighbor[state/status=!=E_VALUE_FORTY_ONE]/address",
+		want:   []string{"192.0.2.2"},
+	}, {
+		desc:   "greater than uint32",
+		inPath: "/neighbor[state/peer-as=>65000]/address",
+		want:   []string{"192.0.2.2", "192.0.2.3"},
+	}, {
+		desc:   "less than or equal",
+		inPath: "/neighbor[state/peer-as=<=65001]/address",
+		want:   []string{"192.0.2.1", "192.0.2.2"},
+	}, {
+		desc:   "signed integer comparison",
+		inPath: "/neighbor[state/weight=<0]/address",
+		want:   []string{"192.0.2.1"},
+	}, {
+		desc:   "regular expression",
+		inPath: "/neighbor[state/description==~(transit|peer)-.*]/address",
+		want:   []string{"192.0.2.1", "192.0.2.2"},
+	}, {
+		desc:   "regular expression must match whole value",
+		inPath: "/neighbor[state/description==~transit]/address",
+	}, {
+		desc:   "negated regular expression does not match unset leaf",
+		inPath: "/neighbor[state/description=!~transit-.*]/address",
+		want:   []string{"192.0.2.2"},
+	}, {
+		desc:   "leaf-list value",
+		inPath: "/neighbor[state/communities=65000:2]/address",
+		want:   []string{"192.0.2.1"},
+	}, {
+		desc:   "multiple predicates",
+		inPath: "/neighbor[state/status=E_VALUE_FORTY_ONE][state/peer-as=>65000]/address",
+		want:   []string{"192.0.2.3"},
+	}, {
+		desc:   "predicate and key",
+		inPath: "/neighbor[address=192.0.2.1][state/peer-as=<65000]/address",
+		want:   []string{"192.0.2.1"},
+	}, {
+		desc:   "predicate and non-matching key",
+		inPath: "/neighbor[address=192.0.2.2][state/peer-as=<65000]/address",
+	}, {
+		desc:   "predicate and wildcard key",
+		inPath: "/neighbor[address=*][state/peer-as=<65000]/address",
+		inOpts: []GetNodeOpt{&GetHandleWildcards{}},
+		want:   []string{"192.0.2.1"},
+	}, {
+		desc:             "ordered comparison of enum",
+		inPath:           "/neighbor[state/status=>E_VALUE_FORTY_ONE]/address",
+		wantErrSubstring: "ordered comparison is not supported",
+	}, {
+		desc:             "non-numeric operand",
+		inPath:           "/neighbor[state/peer-as=>big]/address",
+		wantErrSubstring: `cannot compare unsigned integer value`,
+	}, {
+		desc:             "invalid regular expression",
+		inPath:           "/neighbor[state/description==~(]/address",
+		wantErrSubstring: "invalid regular expression",
+	}, {
+		desc:             "predicate on non-existent leaf",
+		inPath:           "/neighbor[peer-as=1]/address",
+		wantErrSubstring: "cannot evaluate leaf predicate",
+	}, {
+		desc:             "multi-level wildcard",
+		inPath:           "/neighbor[state/peer-as=1]/...",
+		inOpts:           []GetNodeOpt{&GetHandleWildcards{}},
+		wantErrSubstring: "cannot be used with the multi-level wildcard",
+	}}
+
+	for _, tt := range tests {
+		t.Run(tt.desc, func(t *testing.T) {
+			nodes, err := GetNode(predSchema(), root, mustPath(tt.inPath), append(tt.inOpts, &GetLeafPredicates{})...)
+			if diff := errdiff.Substring(err, tt.wantErrSubstring); diff != "" {
+				t.Fatalf("GetNode(%s): %s", tt.inPath, diff)
+			}
+			var got []string
+			for _, n := range nodes {
+				got = append(got, *n.Data.(*string))
+			}
+			sort.Strings(got)
+			if diff := cmp.Diff(tt.want, got); diff != "" {
+				t.Errorf("GetNode(%s): did not get expected nodes, diff(-want, +got):\n%s", tt.inPath, diff)
+			}
+		})
+	}
+}
+
+func TestGetNodeLeafPredicatesPath(t *testing.T) {
+	root := &predRoot{
+		Neighbor: map[string]*predNeighbor{
+			"192.0.2.1": {
+				Address: ygot.String("192.0.2.1"),
+				PeerAs:  ygot.Uint32(64512),
+			},
+		},
+	}
+	nodes, err := GetNode(predSchema(), root, mustPath("/neighbor[state/peer-as=64512]/state/peer-as"), &GetLeafPredicates{})
+	if err != nil {
+		t.Fatalf("GetNode: got unexpected error: %v", err)
+	}
+	want := []*TreeNode{{
+		Path:   mustPath("/neighbor[address=192.0.2.1]/state/peer-as"),
+		Schema: predSchema().Dir["neighbor"].Dir["state"].Dir["peer-as"],
+		Data:   ygot.Uint32(64512),
+	}}
+	if err := treeNodesEqual(nodes, want); err != nil {
+		t.Errorf("GetNode: did not get expected nodes: %v", err)
+	}
+
+	// Without GetLeafPredicates, non-key elements of the path are not
+	// interpreted as predicates.
+	if _, err := GetNode(predSchema(), root, mustPath("/neighbor[state/peer-as=64512]/state/peer-as")); err == nil {
+		t.Errorf("GetNode without GetLeafPredicates: did not get expected error")
+	}
+}
@@ -0,0 +1,294 @@
+// Copyright 2023 Google Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package ytypes
+
+import (
+	"encoding/base64"
+	"fmt"
+	"reflect"
+	"strconv"
+
+	"github.com/openconfig/goyang/pkg/yang"
+	"github.com/openconfig/ygot/util"
+	"github.com/openconfig/ygot/ygot"
+)
+
+// RFC7951Unmarshaler is an interface which is implemented by generated
+// structs that have methods to unmarshal RFC7951 JSON into themselves without
+// walking their fields using reflection. When a struct implements
+// RFC7951Unmarshaler, Unmarshal calls UnmarshalRFC7951 to populate it, unless
+// the PreferShadowPath option is used.
+type RFC7951Unmarshaler interface {
+	// UnmarshalRFC7951 unmarshals the supplied JSON object, which
+	// corresponds to the struct, into the struct.
+	UnmarshalRFC7951(d *RFC7951Decoder, jsonTree map[string]any) error
+}
+
+// RFC7951Decoder is used by implementations of RFC7951Unmarshaler to read the
+// contents of a struct from RFC7951 JSON. It records the data tree paths of
+// the fields that are read, such that elements of the JSON that do not
+// correspond to any field can be reported.
+type RFC7951Decoder struct {
+	// schema is the schema of the struct being unmarshalled.
+	schema *yang.Entry
+	// opts are the options used when unmarshalling.
+	opts []UnmarshalOpt
+	// paths are the data tree paths of the fields of the struct that
+	// have been read.
+	paths [][]string
+}
+
+// Value returns the value found in jsonTree at the supplied data tree paths,
+// or nil if no value is present. The data tree paths, along with the supplied
+// shadow paths, are recorded as those that correspond to a field of the
+// struct. An error is returned if different values are found at the paths.
+func (d *RFC7951Decoder) Value(jsonTree map[string]any, paths, shadowPaths [][]string) (any, error) {
+	d.paths = append(d.paths, paths...)
+	d.paths = append(d.paths, shadowPaths...)
+
+	var out any
+	var outPath []string
+	for _, p := range paths {
+		if jr, ok := getJSONTreeValForPath(jsonTree, p); ok {
+			if out != nil && !reflect.DeepEqual(out, jr) {
+				return nil, fmt.Errorf("values at paths %v and %v are different: %v != %v", outPath, p, out, jr)
+			}
+			out = jr
+			outPath = p
+		}
+	}
+	return out, nil
+}
+
+// Field unmarshals the contents of jsonTree into the field with the supplied
+// name of parent, which must be a pointer to the struct being unmarshalled,
+// or to the case of a choice within it, using reflection. It is used for
+// fields whose types are not known when code is generated, along with those
+// that require their schema to be unmarshalled, such as containers, lists
+// and choices.
+func (d *RFC7951Decoder) Field(parent any, name string, jsonTree map[string]any) error {
+	ft, ok := reflect.TypeOf(parent).Elem().FieldByName(name)
+	if !ok {
+		return fmt.Errorf("%T does not have a field named %s", parent, name)
+	}
+	sp, err := unmarshalStructField(d.schema, parent, ft.Index[0], jsonTree, JSONEncoding, d.opts...)
+	if err != nil {
+		return err
+	}
+	d.paths = append(d.paths, sp...)
+	return nil
+}
+
+// jsonTypeError returns the error that is reported when the JSON value v,
+// supplied for the schema node with the supplied name, is not of the type
+// that is used to represent values of kind k.
+func jsonTypeError(name string, v any, k yang.TypeKind) error {
+	return fmt.Errorf("got %T type for field %s, expect %v", v, name, yangToJSONType(k).Kind())
+}
+
+// LeafList returns the elements of the JSON value v supplied for the
+// leaf-list with the supplied name.
+func (*RFC7951Decoder) LeafList(name string, v any) ([]any, error) {
+	l, ok := v.([]any)
+	if !ok {
+		return nil, fmt.Errorf("unmarshalLeafList for schema %s: value %v: got type %T, expect []interface{}", name, util.ValueStr(v), v)
+	}
+	return l, nil
+}
+
+// String returns the JSON value v supplied for the string leaf with the
+// supplied name.
+func (*RFC7951Decoder) String(name string, v any) (string, error) {
+	s, ok := v.(string)
+	if !ok {
+		return "", jsonTypeError(name, v, yang.Ystring)
+	}
+	return s, nil
+}
+
+// Bool returns the JSON value v supplied for the boolean leaf with the
+// supplied name.
+func (*RFC7951Decoder) Bool(name string, v any) (bool, error) {
+	b, ok := v.(bool)
+	if !ok {
+		return false, jsonTypeError(name, v, yang.Ybool)
+	}
+	return b, nil
+}
+
+// Empty returns the JSON value v supplied for the empty leaf with the
+// supplied name, which must be [null].
+func (*RFC7951Decoder) Empty(name string, v any) (bool, error) {
+	l, ok := v.([]any)
+	if !ok {
+		return false, jsonTypeError(name, v, yang.Yempty)
+	}
+	if len(l) != 1 || l[0] != nil {
+		return false, fmt.Errorf("error parsing %v for schema %s: empty leaves must be [null]", v, name)
+	}
+	return true, nil
+}
+
+// Binary returns the JSON value v supplied for the binary leaf with the
+// supplied name.
+func (*RFC7951Decoder) Binary(name string, v any) ([]byte, error) {
+	s, ok := v.(string)
+	if !ok {
+		return nil, jsonTypeError(name, v, yang.Ybinary)
+	}
+	b, err := base64.StdEncoding.DecodeString(s)
+	if err != nil {
+		return nil, fmt.Errorf("error in DecodeString for \n%v\n for schema %s: %v", v, name, err)
+	}
+	return b, nil
+}
+
+// Decimal64 returns the JSON value v supplied for the decimal64 leaf with the
+// supplied name.
+func (*RFC7951Decoder) Decimal64(name string, v any) (float64, error) {
+	s, ok := v.(string)
+	if !ok {
+		return 0, jsonTypeError(name, v, yang.Ydecimal64)
+	}
+	f, err := strconv.ParseFloat(s, 64)
+	if err != nil {
+		return 0, fmt.Errorf("error parsing %v for schema %s: %v", v, name, err)
+	}
+	return f, nil
+}
+
+// Int64 returns the JSON value v supplied for the int64 leaf with the
+// supplied name.
+func (*RFC7951Decoder) Int64(name string, v any) (int64, error) {
+	s, ok := v.(string)
+	if !ok {
+		return 0, jsonTypeError(name, v, yang.Yint64)
+	}
+	i, err := strconv.ParseInt(s, 10, 64)
+	if err != nil {
+		return 0, fmt.Errorf("error parsing %v for schema %s: %v", v, name, err)
+	}
+	return i, nil
+}
+
+// Uint64 returns the JSON value v supplied for the uint64 leaf with the
+// supplied name.
+func (*RFC7951Decoder) Uint64(name string, v any) (uint64, error) {
+	s, ok := v.(string)
+	if !ok {
+		return 0, jsonTypeError(name, v, yang.Yuint64)
+	}
+	u, err := strconv.ParseUint(s, 10, 64)
+	if err != nil {
+		return 0, fmt.Errorf("error parsing %v for schema %s: %v", v, name, err)
+	}
+	return u, nil
+}
+
+// floatInt returns the JSON value v supplied for the leaf with the supplied
+// name, whose type is the integer type k that is represented as a JSON
+// number.
+func floatInt(name string, v any, k yang.TypeKind) (any, error) {
+	f, ok := v.(float64)
+	if !ok {
+		return nil, jsonTypeError(name, v, k)
+	}
+	i, err := yangFloatIntToGoType(k, f)
+	if err != nil {
+		return nil, fmt.Errorf("error parsing %v for schema %s: %v", v, name, err)
+	}
+	return i, nil
+}
+
+// Int8 returns the JSON value v supplied for the int8 leaf with the supplied
+// name.
+func (*RFC7951Decoder) Int8(name string, v any) (int8, error) {
+	i, err := floatInt(name, v, yang.Yint8)
+	if err != nil {
+		return 0, err
+	}
+	return i.(int8), nil
+}
+
+// Int16 returns the JSON value v supplied for the int16 leaf with the
+// supplied name.
+func (*RFC7951Decoder) Int16(name string, v any) (int16, error) {
+	i, err := floatInt(name, v, yang.Yint16)
+	if err != nil {
+		return 0, err
+	}
+	return i.(int16), nil
+}
+
+// Int32 returns the JSON value v supplied for the int32 leaf with the
+// supplied name.
+func (*RFC7951Decoder) Int32(name string, v any) (int32, error) {
+	i, err := floatInt(name, v, yang.Yint32)
+	if err != nil {
+		return 0, err
+	}
+	return i.(int32), nil
+}
+
+// Uint8 returns the JSON value v supplied for the uint8 leaf with the
+// supplied name.
+func (*RFC7951Decoder) Uint8(name string, v any) (uint8, error) {
+	i, err := floatInt(name, v, yang.Yuint8)
+	if err != nil {
+		return 0, err
+	}
+	return i.(uint8), nil
+}
+
+// Uint16 returns the JSON value v supplied for the uint16 leaf with the
+// supplied name.
+func (*RFC7951Decoder) Uint16(name string, v any) (uint16, error) {
+	i, err := floatInt(name, v, yang.Yuint16)
+	if err != nil {
+		return 0, err
+	}
+	return i.(uint16), nil
+}
+
+// Uint32 returns the JSON value v supplied for the uint32 leaf with the
+// supplied name.
+func (*RFC7951Decoder) Uint32(name string, v any) (uint32, error) {
+	i, err := floatInt(name, v, yang.Yuint32)
+	if err != nil {
+		return 0, err
+	}
+	return i.(uint32), nil
+}
+
+// Enum returns the value of the enumerated type e, whose name is typeName,
+// that corresponds to the JSON value v supplied for the leaf with the
+// supplied name, which is stored in the field fieldName.
+func (*RFC7951Decoder) Enum(name, fieldName string, e ygot.GoEnum, typeName string, v any) (int64, error) {
+	s, ok := v.(string)
+	if !ok {
+		return 0, jsonTypeError(name, v, yang.Yenum)
+	}
+	m, ok := e.ΛMap()[typeName]
+	if !ok {
+		return 0, fmt.Errorf("%s is not a valid enum field name", typeName)
+	}
+	s = util.StripModulePrefix(s)
+	for i, def := range m {
+		if util.StripModulePrefix(def.Name) == s {
+			return i, nil
+		}
+	}
+	return 0, fmt.Errorf("%s is not a valid value for enum field %s, type %T", v, fieldName, e)
+}
@@ -0,0 +1,249 @@
+// Copyright 2023 Google Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package ytypes
+
+import (
+	"encoding/json"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/openconfig/goyang/pkg/yang"
+	"github.com/openconfig/ygot/ygot"
+)
+
+// rfc7951Struct is a struct that is unmarshalled from RFC7951 JSON using
+// reflection.
+type rfc7951Struct struct {
+	Str   *string             `path:"str"`
+	Int   *int16              `path:"config/int|int"`
+	Big   *uint64             `path:"big"`
+	On    YANGEmpty           `path:"on"`
+	Enum  EnumType            `path:"enum"`
+	Names []string            `path:"names"`
+	Child *rfc7951ChildStruct `path:"child"`
+}
+
+func (*rfc7951Struct) IsYANGGoStruct()                          {}
+func (*rfc7951Struct) ΛValidate(...ygot.ValidationOption) error { return nil }
+func (*rfc7951Struct) ΛEnumTypeMap() map[string][]reflect.Type  { return nil }
+func (*rfc7951Struct) ΛBelongingModule() string                 { return "m" }
+
+// rfc7951MethodsStruct is a struct with the same fields as rfc7951Struct
+// which implements the RFC7951Unmarshaler interface.
+type rfc7951MethodsStruct rfc7951Struct
+
+func (*rfc7951MethodsStruct) IsYANGGoStruct()                          {}
+func (*rfc7951MethodsStruct) ΛValidate(...ygot.ValidationOption) error { return nil }
+func (*rfc7951MethodsStruct) ΛEnumTypeMap() map[string][]reflect.Type  { return nil }
+func (*rfc7951MethodsStruct) ΛBelongingModule() string                 { return "m" }
+
+func (t *rfc7951MethodsStruct) UnmarshalRFC7951(d *RFC7951Decoder, jsonTree map[string]any) error {
+	if v, err := d.Value(jsonTree, [][]string{{"str"}}, nil); err != nil {
+		return err
+	} else if v != nil {
+		s, err := d.String("str", v)
+		if err != nil {
+			return err
+		}
+		t.Str = &s
+	}
+	if v, err := d.Value(jsonTree, [][]string{{"config", "int"}, {"int"}}, nil); err != nil {
+		return err
+	} else if v != nil {
+		i, err := d.Int16("int", v)
+		if err != nil {
+			return err
+		}
+		t.Int = &i
+	}
+	if v, err := d.Value(jsonTree, [][]string{{"big"}}, nil); err != nil {
+		return err
+	} else if v != nil {
+		u, err := d.Uint64("big", v)
+		if err != nil {
+			return err
+		}
+		t.Big = &u
+	}
+	if v, err := d.Value(jsonTree, [][]string{{"on"}}, nil); err != nil {
+		return err
+	} else if v != nil {
+		b, err := d.Empty("on", v)
+		if err != nil {
+			return err
+		}
+		t.On = YANGEmpty(b)
+	}
+	if v, err := d.Value(jsonTree, [][]string{{"enum"}}, nil); err != nil {
+		return err
+	} else if v != nil {
+		e, err := d.Enum("enum", "Enum", EnumType(0), "EnumType", v)
+		if err != nil {
+			return err
+		}
+		t.Enum = EnumType(e)
+	}
+	if v, err := d.Value(jsonTree, [][]string{{"names"}}, nil); err != nil {
+		return err
+	} else if v != nil {
+		l, err := d.LeafList("names", v)
+		if err != nil {
+			return err
+		}
+		t.Names = nil
+		for _, ev := range l {
+			s, err := d.String("names", ev)
+			if err != nil {
+				return err
+			}
+			t.Names = append(t.Names, s)
+		}
+	}
+	return d.Field(t, "Child", jsonTree)
+}
+
+type rfc7951ChildStruct struct {
+	Val *int32 `path:"val"`
+}
+
+func (*rfc7951ChildStruct) IsYANGGoStruct()                          {}
+func (*rfc7951ChildStruct) ΛValidate(...ygot.ValidationOption) error { return nil }
+func (*rfc7951ChildStruct) ΛEnumTypeMap() map[string][]reflect.Type  { return nil }
+func (*rfc7951ChildStruct) ΛBelongingModule() string                 { return "m" }
+
+func TestRFC7951Unmarshaler(t *testing.T) {
+	leaf := func(name string, k yang.TypeKind) *yang.Entry {
+		return &yang.Entry{Name: name, Kind: yang.LeafEntry, Type: &yang.YangType{Kind: k}}
+	}
+	schema := &yang.Entry{
+		Name: "root",
+		Kind: yang.DirectoryEntry,
+		Dir: map[string]*yang.Entry{
+			"str": leaf("str", yang.Ystring),
+			"config": {
+				Name: "config",
+				Kind: yang.DirectoryEntry,
+				Dir: map[string]*yang.Entry{
+					"int": leaf("int", yang.Yint16),
+				},
+			},
+			"int":  leaf("int", yang.Yint16),
+			"big":  leaf("big", yang.Yuint64),
+			"on":   leaf("on", yang.Yempty),
+			"enum": leaf("enum", yang.Yenum),
+			"names": {
+				Name:     "names",
+				Kind:     yang.LeafEntry,
+				ListAttr: yang.NewDefaultListAttr(),
+				Type:     &yang.YangType{Kind: yang.Ystring},
+			},
+			"child": {
+				Name: "child",
+				Kind: yang.DirectoryEntry,
+				Dir: map[string]*yang.Entry{
+					"val": leaf("val", yang.Yint32),
+				},
+			},
+		},
+	}
+	populateParentField(nil, schema)
+
+	tests := []struct {
+		desc string
+		json string
+		opts []UnmarshalOpt
+	}{{
+		desc: "empty",
+		json: `{}`,
+	}, {
+		desc: "all fields",
+		json: `{
+			"str": "a",
+			"config": {"int": -42},
+			"int": -42,
+			"big": "18446744073709551615",
+			"on": [null],
+			"enum": "E_VALUE_FORTY_TWO",
+			"names": ["a", "b"],
+			"child": {"val": 42}
+		}`,
+	}, {
+		desc: "enum with module prefix",
+		json: `{"enum": "m:E_VALUE_FORTY_ONE"}`,
+	}, {
+		desc: "different values at paths",
+		json: `{"config": {"int": 1}, "int": 2}`,
+	}, {
+		desc: "wrong type for string",
+		json: `{"str": 42}`,
+	}, {
+		desc: "int16 out of range",
+		json: `{"int": 100000}`,
+	}, {
+		desc: "invalid uint64",
+		json: `{"big": "-1"}`,
+	}, {
+		desc: "invalid empty",
+		json: `{"on": [1]}`,
+	}, {
+		desc: "unknown enum value",
+		json: `{"enum": "E_VALUE_FORTY_THREE"}`,
+	}, {
+		desc: "leaf-list not a list",
+		json: `{"names": "a"}`,
+	}, {
+		desc: "leaf-list with wrong type",
+		json: `{"names": ["a", 1]}`,
+	}, {
+		desc: "invalid child",
+		json: `{"child": {"val": "a"}}`,
+	}, {
+		desc: "unknown field",
+		json: `{"str": "a", "unknown": 42}`,
+	}, {
+		desc: "unknown field ignored",
+		json: `{"str": "a", "unknown": 42}`,
+		opts: []UnmarshalOpt{&IgnoreExtraFields{}},
+	}}
+
+	for _, tt := range tests {
+		t.Run(tt.desc, func(t *testing.T) {
+			var jsonTree any
+			if err := json.Unmarshal([]byte(tt.json), &jsonTree); err != nil {
+				t.Fatalf("json.Unmarshal(%s): %v", tt.json, err)
+			}
+
+			want := &rfc7951Struct{}
+			wantErr := Unmarshal(schema, want, jsonTree, tt.opts...)
+			got := &rfc7951MethodsStruct{}
+			gotErr := Unmarshal(schema, got, jsonTree, tt.opts...)
+
+			// Errors that include the type of the struct are expected to
+			// differ only by its name.
+			gotErrStr := strings.ReplaceAll(errToString(gotErr), "rfc7951MethodsStruct", "rfc7951Struct")
+			if diff := cmp.Diff(errToString(wantErr), gotErrStr); diff != "" {
+				t.Fatalf("Unmarshal(%s): did not get expected error, diff(-reflect, +methods):\n%s", tt.json, diff)
+			}
+			if wantErr != nil {
+				return
+			}
+			if diff := cmp.Diff(want, (*rfc7951Struct)(got)); diff != "" {
+				t.Errorf("Unmarshal(%s): did not get expected struct, diff(-reflect, +methods):\n%s", tt.json, diff)
+			}
+		})
+	}
+}
@@ -0,0 +1,158 @@
+// Copyright 2023 Google Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package ytypes
+
+import (
+	"fmt"
+	"reflect"
+
+	"github.com/openconfig/goyang/pkg/yang"
+	"github.com/openconfig/ygot/util"
+	"github.com/openconfig/ygot/ygot"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	gpb "github.com/openconfig/gnmi/proto/gnmi"
+)
+
+// TypeMismatchError is returned by the typed node access functions (Get,
+// GetAll and GetOrCreate) when the data tree node found at a path cannot be
+// returned as the requested type.
+type TypeMismatchError struct {
+	// Path is the path of the data tree node.
+	Path *gpb.Path
+	// Got is the type of the data tree node.
+	Got reflect.Type
+	// Want is the type that was requested by the caller.
+	Want reflect.Type
+}
+
+// Error implements the error interface.
+func (e *TypeMismatchError) Error() string {
+	p, err := ygot.PathToString(e.Path)
+	if err != nil {
+		p = e.Path.String()
+	}
+	return fmt.Sprintf("node at path %s has type %v, cannot be returned as %v", p, e.Got, e.Want)
+}
+
+// TypedTreeNode is the equivalent of TreeNode whose data is of type T.
+type TypedTreeNode[T any] struct {
+	// Schema is the schema entry for the data tree node.
+	Schema *yang.Entry
+	// Data is the data node found at the path.
+	Data T
+	// Path is the path of the data node that is being returned.
+	Path *gpb.Path
+}
+
+// Get retrieves the single node at the supplied path from root, whose schema
+// must also be supplied, and returns it as type T. T may either be the type
+// of the data tree node, or, for leaves that are stored as pointers, the type
+// of the value that the pointer refers to -- for example, a string leaf can be
+// retrieved using either Get[*string] or Get[string].
+//
+// The supplied options are handled as per GetNode. If the path matches no
+// nodes, an error with code NotFound is returned. If it matches more than one
+// node, for example because it contains wildcards, an error is
+// returned and GetAll should be used instead. If the node is not of type T, a
+// *TypeMismatchError is returned.
+func Get[T any](schema *yang.Entry, root any, path *gpb.Path, opts ...GetNodeOpt) (T, error) {
+	var zero T
+	nodes, err := GetNode(schema, root, path, opts...)
+	if err != nil {
+		return zero, err
+	}
+	switch {
+	case len(nodes) == 0:
+		return zero, status.Errorf(codes.NotFound, "no nodes found at path %v", path)
+	case len(nodes) > 1:
+		return zero, status.Errorf(codes.InvalidArgument, "path %v matched %d nodes, want exactly 1", path, len(nodes))
+	}
+	return typedNodeData[T](nodes[0].Data, nodes[0].Path)
+}
+
+// GetAll retrieves all nodes matching the supplied path from root, whose
+// schema must also be supplied, and returns them with their data as type T.
+// The supplied options are handled as per GetNode, such that wildcards and
+// partial key matches are supported when GetHandleWildcards and
+// GetPartialKeyMatch are specified. If any of the matched nodes is not of
+// type T, a *TypeMismatchError is returned.
+func GetAll[T any](schema *yang.Entry, root any, path *gpb.Path, opts ...GetNodeOpt) ([]*TypedTreeNode[T], error) {
+	nodes, err := GetNode(schema, root, path, opts...)
+	if err != nil {
+		return nil, err
+	}
+	tn := make([]*TypedTreeNode[T], 0, len(nodes))
+	for _, n := range nodes {
+		d, err := typedNodeData[T](n.Data, n.Path)
+		if err != nil {
+			return nil, err
+		}
+		tn = append(tn, &TypedTreeNode[T]{Schema: n.Schema, Data: d, Path: n.Path})
+	}
+	return tn, nil
+}
+
+// GetOrCreate retrieves the node at the supplied path from root, whose schema
+// must also be supplied, and returns it as type T. Nodes along the path,
+// including the node itself, are initialised if they do not exist, as per
+// GetOrCreateNode. T is handled as described for Get. If the node is not of
+// type T, a *TypeMismatchError is returned.
+func GetOrCreate[T any](schema *yang.Entry, root any, path *gpb.Path, opts ...GetOrCreateNodeOpt) (T, error) {
+	var zero T
+	n, _, err := GetOrCreateNode(schema, root, path, opts...)
+	if err != nil {
+		return zero, err
+	}
+	return typedNodeData[T](n, path)
+}
+
+// Set sets the node at the supplied path from root, whose schema must also be
+// supplied, to val. val may be a leaf or leaf-list value -- either as its Go
+// type or a pointer to it, as per Get -- or a GoStruct. GoStruct values are
+// merged into any existing data at the path. The supplied options are handled
+// as per SetNode.
+func Set[T any](schema *yang.Entry, root any, path *gpb.Path, val T, opts ...SetNodeOpt) error {
+	if util.IsValueNil(val) {
+		return status.Errorf(codes.InvalidArgument, "cannot set nil value at path %v", path)
+	}
+	tv, err := ygot.EncodeTypedValue(val, gpb.Encoding_JSON_IETF)
+	if err != nil {
+		return status.Errorf(codes.InvalidArgument, "cannot encode value for path %v: %v", path, err)
+	}
+	return SetNode(schema, root, path, tv, opts...)
+}
+
+// typedNodeData returns the data tree node d found at path p as type T. If d
+// is a pointer to a value of type T, the pointer is dereferenced.
+func typedNodeData[T any](d any, p *gpb.Path) (T, error) {
+	var zero T
+	if v, ok := d.(T); ok {
+		return v, nil
+	}
+	want := reflect.TypeOf(&zero).Elem()
+	dv := reflect.ValueOf(d)
+	if !dv.IsValid() {
+		return zero, &TypeMismatchError{Path: p, Want: want}
+	}
+	if dv.Kind() == reflect.Ptr && dv.Type().Elem() == want {
+		if dv.IsNil() {
+			return zero, status.Errorf(codes.NotFound, "node at path %v is not set", p)
+		}
+		return dv.Elem().Interface().(T), nil
+	}
+	return zero, &TypeMismatchError{Path: p, Got: dv.Type(), Want: want}
+}
@@ -0,0 +1,305 @@
+// Copyright 2023 Google Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package ytypes
+
+import (
+	"errors"
+	"sort"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/openconfig/gnmi/errdiff"
+	"github.com/openconfig/goyang/pkg/yang"
+	"github.com/openconfig/ygot/ygot"
+)
+
+type typedTestChild struct {
+	Val *string `path:"val"`
+}
+
+func (*typedTestChild) IsYANGGoStruct() {}
+
+type typedTestItem struct {
+	ID     *string         `path:"id"`
+	Value  *int32          `path:"value"`
+	Values []int32         `path:"values"`
+	Child  *typedTestChild `path:"child"`
+}
+
+func (*typedTestItem) IsYANGGoStruct() {}
+
+func (i *typedTestItem) ΛListKeyMap() (map[string]interface{}, error) {
+	return map[string]interface{}{"id": *i.ID}, nil
+}
+
+type typedTestRootStruct struct {
+	Item map[string]*typedTestItem `path:"item"`
+}
+
+func (*typedTestRootStruct) IsYANGGoStruct() {}
+
+// typedTestSchema returns the schema for typedTestRootStruct.
+func typedTestSchema() *yang.Entry {
+	sch := &yang.Entry{
+		Name: "root",
+		Kind: yang.DirectoryEntry,
+		Dir: map[string]*yang.Entry{
+			"item": {
+				Name:     "item",
+				Kind:     yang.DirectoryEntry,
+				ListAttr: yang.NewDefaultListAttr(),
+				Key:      "id",
+				Dir: map[string]*yang.Entry{
+					"id": {
+						Name: "id",
+						Kind: yang.LeafEntry,
+						Type: &yang.YangType{Kind: yang.Ystring},
+					},
+					"value": {
+						Name: "value",
+						Kind: yang.LeafEntry,
+						Type: &yang.YangType{Kind: yang.Yint32},
+					},
+					"values": {
+						Name:     "values",
+						Kind:     yang.LeafEntry,
+						ListAttr: yang.NewDefaultListAttr(),
+						Type:     &yang.YangType{Kind: yang.Yint32},
+					},
+					"child": {
+						Name: "child",
+						Kind: yang.DirectoryEntry,
+						Dir: map[string]*yang.Entry{
+							"val": {
+								Name: "val",
+								Kind: yang.LeafEntry,
+								Type: &yang.YangType{Kind: yang.Ystring},
+							},
+						},
+					},
+				},
+			},
+		},
+	}
+	addParents(sch)
+	return sch
+}
+
+// typedTestRoot returns a typedTestRootStruct populated with two list entries.
+func typedTestRoot() *typedTestRootStruct {
+	return &typedTestRootStruct{
+		Item: map[string]*typedTestItem{
+			"a": {
+				ID:     ygot.String("a"),
+				Value:  ygot.Int32(1),
+				Values: []int32{1, 2},
+				Child:  &typedTestChild{Val: ygot.String("a-val")},
+			},
+			"b": {
+				ID:    ygot.String("b"),
+				Value: ygot.Int32(2),
+			},
+		},
+	}
+}
+
+func TestGet(t *testing.T) {
+	root := typedTestRoot()
+	sch := typedTestSchema()
+
+	t.Run("leaf as value", func(t *testing.T) {
+		got, err := Get[int32](sch, root, mustPath("/item[id=a]/value"))
+		if err != nil {
+			t.Fatalf("Get: got unexpected error: %v", err)
+		}
+		if got != 1 {
+			t.Errorf("Get: got %d, want 1", got)
+		}
+	})
+
+	t.Run("leaf as pointer", func(t *testing.T) {
+		got, err := Get[*int32](sch, root, mustPath("/item[id=b]/value"))
+		if err != nil {
+			t.Fatalf("Get: got unexpected error: %v", err)
+		}
+		if got != root.Item["b"].Value {
+			t.Errorf("Get: did not get pointer to the leaf field, got %v", got)
+		}
+	})
+
+	t.Run("leaf-list", func(t *testing.T) {
+		got, err := Get[[]int32](sch, root, mustPath("/item[id=a]/values"))
+		if err != nil {
+			t.Fatalf("Get: got unexpected error: %v", err)
+		}
+		if diff := cmp.Diff([]int32{1, 2}, got); diff != "" {
+			t.Errorf("Get: did not get expected leaf-list, diff(-want, +got):\n%s", diff)
+		}
+	})
+
+	t.Run("container", func(t *testing.T) {
+		got, err := Get[*typedTestChild](sch, root, mustPath("/item[id=a]/child"))
+		if err != nil {
+			t.Fatalf("Get: got unexpected error: %v", err)
+		}
+		if got != root.Item["a"].Child {
+			t.Errorf("Get: did not get expected container, got %v", got)
+		}
+	})
+
+	t.Run("type mismatch", func(t *testing.T) {
+		_, err := Get[string](sch, root, mustPath("/item[id=a]/value"))
+		var tme *TypeMismatchError
+		if !errors.As(err, &tme) {
+			t.Fatalf("Get: got error %v, want *TypeMismatchError", err)
+		}
+		if diff := errdiff.Substring(err, "has type *int32, cannot be returned as string"); diff != "" {
+			t.Errorf("Get: %s", diff)
+		}
+	})
+
+	t.Run("multiple matches", func(t *testing.T) {
+		_, err := Get[int32](sch, root, mustPath("/item[id=*]/value"), &GetHandleWildcards{})
+		if diff := errdiff.Substring(err, "matched 2 nodes, want exactly 1"); diff != "" {
+			t.Errorf("Get: %s", diff)
+		}
+	})
+
+	t.Run("not found", func(t *testing.T) {
+		_, err := Get[int32](sch, root, mustPath("/item[id=c]/value"))
+		if diff := errdiff.Substring(err, "no nodes found"); diff != "" {
+			t.Errorf("Get: %s", diff)
+		}
+	})
+}
+
+func TestGetAll(t *testing.T) {
+	root := typedTestRoot()
+	sch := typedTestSchema()
+
+	tests := []struct {
+		desc             string
+		inPath           string
+		inOpts           []GetNodeOpt
+		want             []int32
+		wantErrSubstring string
+	}{{
+		desc:   "wildcard key",
+		inPath: "/item[id=*]/value",
+		inOpts: []GetNodeOpt{&GetHandleWildcards{}},
+		want:   []int32{1, 2},
+	}, {
+		desc:   "partial key match",
+		inPath: "/item/value",
+		inOpts: []GetNodeOpt{&GetPartialKeyMatch{}},
+		want:   []int32{1, 2},
+	}, {
+		desc:   "multi-level wildcard",
+		inPath: "/.../value",
+		inOpts: []GetNodeOpt{&GetHandleWildcards{}},
+		want:   []int32{1, 2},
+	}, {
+		desc:   "no matches",
+		inPath: "/item[id=c]/value",
+	}, {
+		desc:             "invalid path",
+		inPath:           "/item[id=a]/missing",
+		wantErrSubstring: "no match found",
+	}}
+
+	for _, tt := range tests {
+		t.Run(tt.desc, func(t *testing.T) {
+			nodes, err := GetAll[int32](sch, root, mustPath(tt.inPath), tt.inOpts...)
+			if diff := errdiff.Substring(err, tt.wantErrSubstring); diff != "" {
+				t.Fatalf("GetAll: %s", diff)
+			}
+			var got []int32
+			for _, n := range nodes {
+				if n.Schema == nil || n.Path == nil {
+					t.Errorf("GetAll: node %v does not have schema and path set", n)
+				}
+				got = append(got, n.Data)
+			}
+			sort.Slice(got, func(i, j int) bool { return got[i] < got[j] })
+			if diff := cmp.Diff(tt.want, got); diff != "" {
+				t.Errorf("GetAll: did not get expected values, diff(-want, +got):\n%s", diff)
+			}
+		})
+	}
+
+	if _, err := GetAll[*typedTestItem](sch, root, mustPath("/item[id=*]/child"), &GetHandleWildcards{}); !errors.As(err, new(*TypeMismatchError)) {
+		t.Errorf("GetAll: got error %v, want *TypeMismatchError", err)
+	}
+}
+
+func TestGetOrCreate(t *testing.T) {
+	root := &typedTestRootStruct{}
+	sch := typedTestSchema()
+
+	got, err := GetOrCreate[*typedTestChild](sch, root, mustPath("/item[id=a]/child"))
+	if err != nil {
+		t.Fatalf("GetOrCreate: got unexpected error: %v", err)
+	}
+	if got == nil || got != root.Item["a"].Child {
+		t.Errorf("GetOrCreate: did not get created container, got %v", got)
+	}
+
+	v, err := GetOrCreate[int32](sch, root, mustPath("/item[id=a]/value"))
+	if err != nil {
+		t.Fatalf("GetOrCreate: got unexpected error: %v", err)
+	}
+	if v != 0 || root.Item["a"].Value == nil {
+		t.Errorf("GetOrCreate: did not get initialised leaf, got %d, field %v", v, root.Item["a"].Value)
+	}
+
+	if _, err := GetOrCreate[string](sch, root, mustPath("/item[id=a]/value")); !errors.As(err, new(*TypeMismatchError)) {
+		t.Errorf("GetOrCreate: got error %v, want *TypeMismatchError", err)
+	}
+}
+
+func TestSet(t *testing.T) {
+	sch := typedTestSchema()
+
+	t.Run("leaf value", func(t *testing.T) {
+		root := &typedTestRootStruct{}
+		if err := Set(sch, root, mustPath("/item[id=a]/value"), int32(42), &InitMissingElements{}); err != nil {
+			t.Fatalf("Set: got unexpected error: %v", err)
+		}
+		if got, err := Get[int32](sch, root, mustPath("/item[id=a]/value")); err != nil || got != 42 {
+			t.Errorf("Get after Set: got (%d, %v), want (42, nil)", got, err)
+		}
+	})
+
+	t.Run("leaf pointer and leaf-list", func(t *testing.T) {
+		root := typedTestRoot()
+		if err := Set(sch, root, mustPath("/item[id=b]/value"), ygot.Int32(7)); err != nil {
+			t.Fatalf("Set: got unexpected error: %v", err)
+		}
+		if err := Set(sch, root, mustPath("/item[id=b]/values"), []int32{3, 4}); err != nil {
+			t.Fatalf("Set: got unexpected error: %v", err)
+		}
+		want := &typedTestItem{ID: ygot.String("b"), Value: ygot.Int32(7), Values: []int32{3, 4}}
+		if diff := cmp.Diff(want, root.Item["b"]); diff != "" {
+			t.Errorf("Set: did not get expected list entry, diff(-want, +got):\n%s", diff)
+		}
+	})
+
+	t.Run("nil value", func(t *testing.T) {
+		err := Set[*int32](sch, &typedTestRootStruct{}, mustPath("/item[id=a]/value"), nil)
+		if diff := errdiff.Substring(err, "cannot set nil value"); diff != "" {
+			t.Errorf("Set: %s", diff)
+		}
+	})
+}
@@ -116,8 +116,8 @@ const (
 
 // unmarshalGeneric unmarshals the provided value encoded with the given
 // encoding type into the parent with the provided schema. When encoding mode
-// is GNMIEncoding, the schema needs to be pointing to a leaf or leaf list
-// schema.
+// is GNMIEncoding, the schema needs to be pointing to a leaf, leaf list,
+// anydata or anyxml schema.
 func unmarshalGeneric(schema *yang.Entry, parent interface{}, value interface{}, enc Encoding, opts ...UnmarshalOpt) error {
 	util.Indent()
 	defer util.Dedent()
@@ -127,7 +127,7 @@ func unmarshalGeneric(schema *yang.Entry, parent interface{}, value interface{},
 	}
 	util.DbgPrint("Unmarshal value %v, type %T, into parent type %T, schema name %s", util.ValueStrDebug(value), value, parent, schema.Name)
 
-	if enc == GNMIEncoding && !(schema.IsLeaf() || schema.IsLeafList()) {
+	if enc == GNMIEncoding && !(schema.IsLeaf() || schema.IsLeafList() || util.IsAnydataOrAnyxml(schema)) {
 		return errors.New("unmarshalling a non leaf node isn't supported in GNMIEncoding mode")
 	}
 
@@ -144,8 +144,10 @@ func unmarshalGeneric(schema *yang.Entry, parent interface{}, value interface{},
 		return unmarshalList(schema, parent, value, enc, opts...)
 	case schema.IsChoice():
 		return fmt.Errorf("cannot pass choice schema %s to Unmarshal", schema.Name)
-	case schema.IsContainer():
+	case schema.IsContainer(), util.IsOperationContainer(schema):
 		return unmarshalContainer(schema, parent, value, enc, opts...)
+	case util.IsAnydataOrAnyxml(schema):
+		return unmarshalAnyData(schema, parent, value, enc, opts...)
 	}
 	return fmt.Errorf("unknown schema type for type %T, value %v", value, value)
 }
@@ -316,6 +316,11 @@ func schemaToStructFieldName(schema *yang.Entry, parent interface{}, preferShado
 	for i := 0; i < t.NumField(); i++ {
 		f := t.Field(i)
 		fieldName := f.Name
+		// Fields within a choice are unmarshalled directly into the
+		// struct representing their case.
+		if util.IsChoiceField(f) {
+			continue
+		}
 		relativeSchemaPathFn := util.RelativeSchemaPath
 		if preferShadowPath {
 			relativeSchemaPathFn = util.RelativeSchemaPathPreferShadow
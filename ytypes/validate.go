@@ -101,7 +101,7 @@ func Validate(schema *yang.Entry, value interface{}, opts ...ygot.ValidationOpti
 	switch {
 	case schema.IsLeaf():
 		return util.AppendErrs(errs, validateLeaf(schema, value))
-	case schema.IsContainer():
+	case schema.IsContainer(), util.IsOperationContainer(schema):
 		gsv, ok := value.(ygot.GoStruct)
 		if !ok {
 			return util.AppendErr(errs, fmt.Errorf("type %T is not a GoStruct for schema %s", value, schema.Name))
@@ -113,6 +113,8 @@ func Validate(schema *yang.Entry, value interface{}, opts ...ygot.ValidationOpti
 		return util.AppendErrs(errs, validateList(schema, value))
 	case schema.IsChoice():
 		return util.AppendErrs(errs, util.NewErrs(fmt.Errorf("cannot pass choice schema %s to Validate", schema.Name)))
+	case util.IsAnydataOrAnyxml(schema):
+		return util.AppendErrs(errs, validateAnyData(schema, value))
 	}
 	return util.AppendErrs(errs, util.NewErrs(fmt.Errorf("unknown schema type for type %T, value %v", value, value)))
 }
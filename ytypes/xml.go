@@ -0,0 +1,488 @@
+// Copyright 2024 Google Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package ytypes
+
+import (
+	"bytes"
+	"encoding/xml"
+	"fmt"
+	"io"
+	"reflect"
+	"strconv"
+	"strings"
+
+	"github.com/openconfig/goyang/pkg/yang"
+	"github.com/openconfig/ygot/internal/yreflect"
+	"github.com/openconfig/ygot/util"
+	"github.com/openconfig/ygot/ygot"
+)
+
+// XMLNamespaces is an UnmarshalOpt that specifies the XML namespace of each
+// YANG module for UnmarshalXML. Namespaces maps the name of a YANG module to
+// the argument of the module's "namespace" statement, as for the Namespaces
+// field of ygot.XMLConfig.
+type XMLNamespaces struct {
+	Namespaces map[string]string
+}
+
+// IsUnmarshalOpt marks XMLNamespaces as a valid UnmarshalOpt.
+func (*XMLNamespaces) IsUnmarshalOpt() {}
+
+// xmlNode is an element of a parsed XML document.
+type xmlNode struct {
+	// name is the local name of the element.
+	name string
+	// space is the XML namespace of the element.
+	space string
+	// prefixes maps each XML namespace prefix that is in scope at the
+	// element to its namespace. The default namespace is stored with an
+	// empty prefix.
+	prefixes map[string]string
+	// text is the character data contained directly within the element.
+	text string
+	// children are the child elements of the element.
+	children []*xmlNode
+}
+
+// xmlConverter stores the state used to convert a parsed XML document to an
+// RFC7951 JSON tree.
+type xmlConverter struct {
+	// namespaces maps the name of each YANG module to its XML namespace.
+	// It is nil if the namespaces of the modules are not known.
+	namespaces map[string]string
+	// modules maps each XML namespace to the name of its YANG module.
+	modules map[string]string
+	// ignoreExtraFields indicates that elements that are not described by
+	// the schema are to be skipped.
+	ignoreExtraFields bool
+	// structs caches the modules of the schema nodes that are described by
+	// each GoStruct, keyed by the schema of the GoStruct.
+	structs map[*yang.Entry]*xmlStructModules
+}
+
+// xmlStructModules describes the schema nodes that are rendered from the
+// fields of a GoStruct.
+type xmlStructModules struct {
+	// modules maps each schema node to the name of the YANG module that
+	// defines it, as specified by the module tags of the fields.
+	modules map[*yang.Entry]string
+	// types maps each schema node that is described by a child GoStruct
+	// to the type of the child GoStruct.
+	types map[*yang.Entry]reflect.Type
+}
+
+// UnmarshalXML unmarshals the NETCONF-style XML document in data, as
+// defined in RFC7950 Section 7, into the parent GoStruct, which must
+// correspond to the supplied schema. The top-level element of the document
+// is a wrapper, such as the <data> or <config> element of a NETCONF reply,
+// whose children are the children of the schema node; its name is not
+// checked.
+//
+// The XML document is converted to the equivalent RFC7951 JSON tree by using
+// the schema to determine the type of each leaf, such that the same
+// validation is applied as for JSON input. Attributes, such as the NETCONF
+// edit-config "operation" attribute, are ignored.
+//
+// If the XMLNamespaces option is supplied, the namespace of each element must
+// be that of the module that defines it, as specified by the module tags of
+// the parent GoStruct; elements in any other namespace are treated as
+// unknown elements. The namespace prefix of each identityref value is
+// resolved through the in-scope XML namespace declarations to the name of
+// the module that it refers to. Without the option, element namespaces are
+// not checked, and the namespace prefixes of identityref values are removed.
+func UnmarshalXML(schema *yang.Entry, parent interface{}, data []byte, opts ...UnmarshalOpt) error {
+	if schema == nil {
+		return fmt.Errorf("nil schema for parent type %T", parent)
+	}
+	root, err := parseXML(data)
+	if err != nil {
+		return err
+	}
+	c := &xmlConverter{
+		ignoreExtraFields: hasIgnoreExtraFields(opts),
+		structs:           map[*yang.Entry]*xmlStructModules{},
+	}
+	for _, o := range opts {
+		if ns, ok := o.(*XMLNamespaces); ok {
+			c.namespaces = ns.Namespaces
+			c.modules = map[string]string{}
+			for m, n := range ns.Namespaces {
+				c.modules[n] = m
+			}
+		}
+	}
+	var sm *xmlStructModules
+	if t := reflect.TypeOf(parent); t != nil && util.IsTypeStructPtr(t) {
+		sm = c.structModules(schema, t.Elem())
+	}
+	tree, err := c.toJSONTree(schema, sm, root)
+	if err != nil {
+		return err
+	}
+	return Unmarshal(schema, parent, tree, opts...)
+}
+
+// parseXML parses the XML document in data, returning its top-level element.
+func parseXML(data []byte) (*xmlNode, error) {
+	d := xml.NewDecoder(bytes.NewReader(data))
+	var root *xmlNode
+	var stack []*xmlNode
+	for {
+		tok, err := d.Token()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return nil, fmt.Errorf("cannot parse XML: %v", err)
+		}
+		switch t := tok.(type) {
+		case xml.StartElement:
+			n := &xmlNode{name: t.Name.Local, space: t.Name.Space, prefixes: map[string]string{}}
+			if len(stack) != 0 {
+				for pfx, ns := range stack[len(stack)-1].prefixes {
+					n.prefixes[pfx] = ns
+				}
+			}
+			for _, a := range t.Attr {
+				switch {
+				case a.Name.Space == "xmlns":
+					n.prefixes[a.Name.Local] = a.Value
+				case a.Name.Space == "" && a.Name.Local == "xmlns":
+					n.prefixes[""] = a.Value
+				}
+			}
+			switch {
+			case len(stack) != 0:
+				p := stack[len(stack)-1]
+				p.children = append(p.children, n)
+			case root != nil:
+				return nil, fmt.Errorf("cannot parse XML: multiple top-level elements, %s and %s", root.name, n.name)
+			default:
+				root = n
+			}
+			stack = append(stack, n)
+		case xml.EndElement:
+			stack = stack[:len(stack)-1]
+		case xml.CharData:
+			if len(stack) != 0 {
+				stack[len(stack)-1].text += string(t)
+			}
+		}
+	}
+	if root == nil {
+		return nil, fmt.Errorf("cannot parse XML: no top-level element")
+	}
+	return root, nil
+}
+
+// toJSONTree converts the children of the XML element n, which corresponds
+// to the container or list entry described by schema, to an RFC7951 JSON
+// tree. sm describes the GoStruct that contains the fields for the children
+// of schema, and may be nil if it is not known. Elements that are not
+// described by the schema are retained, such that they are reported by
+// Unmarshal unless extra fields are ignored.
+func (c *xmlConverter) toJSONTree(schema *yang.Entry, sm *xmlStructModules, n *xmlNode) (map[string]interface{}, error) {
+	children := map[string]*yang.Entry{}
+	for _, ch := range util.FindFirstNonChoiceOrCase(schema) {
+		children[ch.Name] = ch
+	}
+
+	tree := map[string]interface{}{}
+	for _, cn := range n.children {
+		cs, ok := children[cn.name]
+		if ok && c.namespaces != nil && sm != nil {
+			if mod, known := sm.modules[cs]; known && cn.space != c.namespaces[mod] {
+				if c.ignoreExtraFields {
+					continue
+				}
+				return nil, fmt.Errorf("element %s has namespace %q, which is not the namespace of module %s that defines %s", cn.name, cn.space, mod, cs.Path())
+			}
+		}
+		if !ok {
+			tree[cn.name] = xmlToUntypedJSON(cn)
+			continue
+		}
+
+		// The fields for the children of a container that is compressed
+		// out of the GoStructs are within the same GoStruct.
+		csm := sm
+		if sm != nil {
+			if t, ok := sm.types[cs]; ok {
+				csm = c.structModules(cs, t)
+			}
+		}
+
+		switch {
+		case cs.IsList():
+			v, err := c.toJSONTree(cs, csm, cn)
+			if err != nil {
+				return nil, err
+			}
+			l, _ := tree[cn.name].([]interface{})
+			tree[cn.name] = append(l, v)
+		case cs.IsLeafList():
+			v, err := c.leafToJSON(cs, cn)
+			if err != nil {
+				return nil, err
+			}
+			l, _ := tree[cn.name].([]interface{})
+			tree[cn.name] = append(l, v)
+		case cs.IsLeaf():
+			if _, ok := tree[cn.name]; ok {
+				return nil, fmt.Errorf("duplicate element %s for leaf %s", cn.name, cs.Path())
+			}
+			v, err := c.leafToJSON(cs, cn)
+			if err != nil {
+				return nil, err
+			}
+			tree[cn.name] = v
+		case cs.IsDir():
+			if _, ok := tree[cn.name]; ok {
+				return nil, fmt.Errorf("duplicate element %s for container %s", cn.name, cs.Path())
+			}
+			v, err := c.toJSONTree(cs, csm, cn)
+			if err != nil {
+				return nil, err
+			}
+			tree[cn.name] = v
+		default:
+			tree[cn.name] = xmlToUntypedJSON(cn)
+		}
+	}
+	return tree, nil
+}
+
+// structModules returns the modules of the schema nodes that are described
+// by the fields of the GoStruct type t, whose schema is schema.
+func (c *xmlConverter) structModules(schema *yang.Entry, t reflect.Type) *xmlStructModules {
+	if sm, ok := c.structs[schema]; ok {
+		return sm
+	}
+	sm := &xmlStructModules{
+		modules: map[*yang.Entry]string{},
+		types:   map[*yang.Entry]reflect.Type{},
+	}
+	c.structs[schema] = sm
+
+	for i := 0; i < t.NumField(); i++ {
+		f := t.Field(i)
+		for _, tags := range [][2]string{{"path", "module"}, {"shadow-path", "shadow-module"}} {
+			pathTag, ok := f.Tag.Lookup(tags[0])
+			if !ok {
+				continue
+			}
+			modTag, ok := f.Tag.Lookup(tags[1])
+			if !ok {
+				continue
+			}
+			paths, mods := strings.Split(pathTag, "|"), strings.Split(modTag, "|")
+			if len(paths) != len(mods) {
+				continue
+			}
+			for j := range paths {
+				if strings.HasPrefix(paths[j], "/") {
+					continue
+				}
+				p, m := strings.Split(paths[j], "/"), strings.Split(mods[j], "/")
+				if len(p) != len(m) {
+					continue
+				}
+				e := schema
+				for k := range p {
+					if e = schemaChild(e, util.StripModulePrefix(p[k])); e == nil {
+						break
+					}
+					sm.modules[e] = m[k]
+				}
+				if e != nil && e.IsDir() {
+					if st := xmlFieldStructType(f.Type); st != nil {
+						sm.types[e] = st
+					}
+				}
+			}
+		}
+	}
+	return sm
+}
+
+// schemaChild returns the child of the schema node e with the supplied name,
+// looking through any choice and case statements, or nil if there is no
+// such child.
+func schemaChild(e *yang.Entry, name string) *yang.Entry {
+	if ch, ok := e.Dir[name]; ok && !util.IsChoiceOrCase(ch) {
+		return ch
+	}
+	return util.FindFirstNonChoiceOrCase(e)[name]
+}
+
+// xmlFieldStructType returns the GoStruct type of the container, or of the
+// entries of the list, stored in a field of type t, or nil if t does not
+// store GoStructs.
+func xmlFieldStructType(t reflect.Type) reflect.Type {
+	if t.Implements(reflect.TypeOf((*ygot.GoOrderedMap)(nil)).Elem()) {
+		et, err := yreflect.UnaryMethodArgType(t, "Append")
+		if err != nil {
+			return nil
+		}
+		t = et
+	}
+	switch t.Kind() {
+	case reflect.Map, reflect.Slice:
+		t = t.Elem()
+	}
+	if !util.IsTypeStructPtr(t) {
+		return nil
+	}
+	return t.Elem()
+}
+
+// xmlToUntypedJSON converts the XML element n, for which there is no
+// schema, to a JSON value. Elements without child elements are converted to
+// their text content.
+func xmlToUntypedJSON(n *xmlNode) interface{} {
+	if len(n.children) == 0 {
+		return n.text
+	}
+	m := map[string]interface{}{}
+	for _, c := range n.children {
+		m[c.name] = xmlToUntypedJSON(c)
+	}
+	return m
+}
+
+// leafToJSON converts the text content of the XML element n for the leaf or
+// leaf-list described by schema to the corresponding RFC7951 JSON value. For
+// union types, the first member type that the text is valid for is used.
+func (c *xmlConverter) leafToJSON(schema *yang.Entry, n *xmlNode) (interface{}, error) {
+	text := n.text
+	s, err := util.ResolveIfLeafRef(schema)
+	if err != nil {
+		return nil, err
+	}
+	if s.Type == nil {
+		return nil, fmt.Errorf("nil type for schema %s", schema.Path())
+	}
+
+	if s.Type.Kind != yang.Yunion {
+		v, err := c.textToJSONValue(s.Type, n, false)
+		if err != nil {
+			return nil, fmt.Errorf("cannot convert value %q for schema %s: %v", text, schema.Path(), err)
+		}
+		return v, nil
+	}
+
+	for _, t := range util.FlattenedTypes(s.Type.Type) {
+		if v, err := c.textToJSONValue(t, n, true); err == nil {
+			return v, nil
+		}
+	}
+	return nil, fmt.Errorf("value %q does not match any type of union %s", text, schema.Path())
+}
+
+// textToJSONValue converts the XML text of the element n, which is a value
+// of type t, to the corresponding RFC7951 JSON value. If strict is true,
+// enumerated values are checked against the values defined for the type,
+// such that the correct member of a union can be selected; otherwise such
+// checks are left to unmarshalling.
+func (c *xmlConverter) textToJSONValue(t *yang.YangType, n *xmlNode, strict bool) (interface{}, error) {
+	text := n.text
+	switch t.Kind {
+	case yang.Ystring:
+		return text, nil
+	case yang.Ybinary:
+		return strings.TrimSpace(text), nil
+	case yang.Ybool:
+		switch strings.TrimSpace(text) {
+		case "true":
+			return true, nil
+		case "false":
+			return false, nil
+		}
+		return nil, fmt.Errorf("invalid boolean value")
+	case yang.Yempty:
+		if strings.TrimSpace(text) != "" {
+			return nil, fmt.Errorf("empty leaf has content")
+		}
+		return []interface{}{nil}, nil
+	case yang.Yint8, yang.Yint16, yang.Yint32:
+		v, err := strconv.ParseInt(strings.TrimSpace(text), 10, 32)
+		if err != nil {
+			return nil, err
+		}
+		if err := checkJSONFloat64Range(t.Kind, float64(v)); err != nil {
+			return nil, err
+		}
+		return float64(v), nil
+	case yang.Yuint8, yang.Yuint16, yang.Yuint32:
+		v, err := strconv.ParseUint(strings.TrimSpace(text), 10, 32)
+		if err != nil {
+			return nil, err
+		}
+		if err := checkJSONFloat64Range(t.Kind, float64(v)); err != nil {
+			return nil, err
+		}
+		return float64(v), nil
+	case yang.Yint64:
+		s := strings.TrimSpace(text)
+		if _, err := strconv.ParseInt(s, 10, 64); err != nil {
+			return nil, err
+		}
+		return s, nil
+	case yang.Yuint64:
+		s := strings.TrimSpace(text)
+		if _, err := strconv.ParseUint(s, 10, 64); err != nil {
+			return nil, err
+		}
+		return s, nil
+	case yang.Ydecimal64:
+		s := strings.TrimSpace(text)
+		if _, err := strconv.ParseFloat(s, 64); err != nil {
+			return nil, err
+		}
+		return s, nil
+	case yang.Yenum:
+		s := strings.TrimSpace(text)
+		if strict && (t.Enum == nil || !t.Enum.IsDefined(s)) {
+			return nil, fmt.Errorf("%s is not a value of the enumeration", s)
+		}
+		return s, nil
+	case yang.Yidentityref:
+		s := strings.TrimSpace(text)
+		var pfx string
+		if i := strings.Index(s, ":"); i != -1 {
+			pfx, s = s[:i], s[i+1:]
+		}
+		if strict && (t.IdentityBase == nil || !t.IdentityBase.IsDefined(s)) {
+			return nil, fmt.Errorf("%s is not a value of the identityref", s)
+		}
+		// The prefix of an identityref value in XML is an XML namespace
+		// prefix, which is resolved to the module that it refers to per
+		// RFC7950 Section 9.10.3. An unprefixed value is within the
+		// default namespace.
+		ns, ok := n.prefixes[pfx]
+		if !ok && pfx != "" {
+			return nil, fmt.Errorf("undeclared namespace prefix %q in identityref value %s:%s", pfx, pfx, s)
+		}
+		if c.modules == nil {
+			return s, nil
+		}
+		mod, ok := c.modules[ns]
+		if !ok {
+			return nil, fmt.Errorf("namespace %q of identityref value %s is not that of a known module", ns, s)
+		}
+		return mod + ":" + s, nil
+	}
+	return nil, fmt.Errorf("unsupported type %v", t.Kind)
+}
@@ -0,0 +1,51 @@
+// Copyright 2024 Google Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package ytypes_test
+
+import (
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/openconfig/ygot/integration_tests/schemaops/ctestschema"
+	"github.com/openconfig/ygot/ygot"
+	"github.com/openconfig/ygot/ytypes"
+)
+
+func TestXMLRoundTripOrderedMap(t *testing.T) {
+	in := &ctestschema.Device{
+		OrderedList:           ctestschema.GetNestedOrderedMap(t),
+		OrderedMultikeyedList: ctestschema.GetOrderedMapMultikeyed(t),
+		OtherData: &ctestschema.OtherData{
+			Motd: ygot.String("abc -> <def>"),
+		},
+	}
+	ns := map[string]string{
+		"ctestschema":         "urn:cts",
+		"ctestschema-rootmod": "urn:ctsr",
+	}
+
+	b, err := ygot.MarshalXML(in, &ygot.XMLConfig{Namespaces: ns, Indent: "  "})
+	if err != nil {
+		t.Fatalf("MarshalXML: %v", err)
+	}
+
+	got := &ctestschema.Device{}
+	if err := ytypes.UnmarshalXML(ctestschema.SchemaTree["Device"], got, b, &ytypes.XMLNamespaces{Namespaces: ns}); err != nil {
+		t.Fatalf("UnmarshalXML(%s): %v", b, err)
+	}
+	if diff := cmp.Diff(in, got, cmp.AllowUnexported(ctestschema.OrderedList_OrderedMap{}, ctestschema.OrderedList_OrderedList_OrderedMap{}, ctestschema.OrderedMultikeyedList_OrderedMap{})); diff != "" {
+		t.Errorf("did not get expected struct after XML round trip, diff(-want, +got):\n%s", diff)
+	}
+}
@@ -0,0 +1,384 @@
+// Copyright 2024 Google Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package ytypes
+
+import (
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/openconfig/gnmi/errdiff"
+	"github.com/openconfig/goyang/pkg/yang"
+	"github.com/openconfig/ygot/ygot"
+)
+
+func TestUnmarshalXML(t *testing.T) {
+	enumType := yang.NewEnumType()
+	enumType.Set("E_VALUE_FORTY_ONE", 41)
+	enumType.Set("E_VALUE_FORTY_TWO", 42)
+
+	listSchema := &yang.Entry{
+		Name:     "list",
+		Kind:     yang.DirectoryEntry,
+		ListAttr: yang.NewDefaultListAttr(),
+		Key:      "key",
+		Config:   yang.TSTrue,
+		Dir: map[string]*yang.Entry{
+			"key": {
+				Name: "key",
+				Kind: yang.LeafEntry,
+				Type: &yang.YangType{Kind: yang.Ystring},
+			},
+			"value": {
+				Name: "value",
+				Kind: yang.LeafEntry,
+				Type: &yang.YangType{Kind: yang.Yint64},
+			},
+		},
+	}
+
+	rootSchema := &yang.Entry{
+		Name: "device",
+		Kind: yang.DirectoryEntry,
+		Annotation: map[string]interface{}{
+			"isFakeRoot": true,
+		},
+		Dir: map[string]*yang.Entry{
+			"container": {
+				Name: "container",
+				Kind: yang.DirectoryEntry,
+				Dir: map[string]*yang.Entry{
+					"config": {
+						Name: "config",
+						Kind: yang.DirectoryEntry,
+						Dir: map[string]*yang.Entry{
+							"int": {
+								Name: "int",
+								Kind: yang.LeafEntry,
+								Type: &yang.YangType{Kind: yang.Yuint8},
+							},
+							"bool": {
+								Name: "bool",
+								Kind: yang.LeafEntry,
+								Type: &yang.YangType{Kind: yang.Ybool},
+							},
+							"enum": {
+								Name: "enum",
+								Kind: yang.LeafEntry,
+								Type: &yang.YangType{Kind: yang.Yenum, Enum: enumType},
+							},
+							"empty": {
+								Name: "empty",
+								Kind: yang.LeafEntry,
+								Type: &yang.YangType{Kind: yang.Yempty},
+							},
+							"binary": {
+								Name: "binary",
+								Kind: yang.LeafEntry,
+								Type: &yang.YangType{Kind: yang.Ybinary},
+							},
+							"strings": {
+								Name:     "strings",
+								Kind:     yang.LeafEntry,
+								ListAttr: yang.NewDefaultListAttr(),
+								Type:     &yang.YangType{Kind: yang.Ystring},
+							},
+						},
+					},
+				},
+			},
+			"list": listSchema,
+		},
+	}
+	populateParentField(nil, rootSchema)
+
+	type ContainerStruct struct {
+		Int     *uint8    `path:"config/int" module:"t/t"`
+		Bool    *bool     `path:"config/bool" module:"t/t"`
+		Enum    EnumType  `path:"config/enum" module:"t/t"`
+		Empty   YANGEmpty `path:"config/empty" module:"t/t"`
+		Binary  Binary    `path:"config/binary" module:"t/t"`
+		Strings []string  `path:"config/strings" module:"t/ta"`
+	}
+
+	type ListStruct struct {
+		Key   *string `path:"key" module:"t"`
+		Value *int64  `path:"value" module:"t"`
+	}
+
+	type RootStruct struct {
+		Container *ContainerStruct       `path:"container" module:"t"`
+		List      map[string]*ListStruct `path:"list" module:"t"`
+	}
+
+	namespaces := &XMLNamespaces{Namespaces: map[string]string{"t": "urn:t", "ta": "urn:ta"}}
+
+	tests := []struct {
+		desc    string
+		in      string
+		opts    []UnmarshalOpt
+		want    *RootStruct
+		wantErr string
+	}{{
+		desc: "empty document",
+		in:   `<data xmlns="urn:ietf:params:xml:ns:netconf:base:1.0"/>`,
+		want: &RootStruct{},
+	}, {
+		desc: "leaves of each type",
+		in: `<data xmlns="urn:ietf:params:xml:ns:netconf:base:1.0">
+  <container xmlns="urn:t">
+    <config>
+      <int> 42 </int>
+      <bool>true</bool>
+      <enum>E_VALUE_FORTY_TWO</enum>
+      <empty/>
+      <binary>YWJj</binary>
+      <strings>one</strings>
+      <strings> two</strings>
+    </config>
+  </container>
+</data>`,
+		want: &RootStruct{
+			Container: &ContainerStruct{
+				Int:     ygot.Uint8(42),
+				Bool:    ygot.Bool(true),
+				Enum:    42,
+				Empty:   true,
+				Binary:  Binary("abc"),
+				Strings: []string{"one", " two"},
+			},
+		},
+	}, {
+		desc: "list entries with operation attributes",
+		in: `<config xmlns="urn:ietf:params:xml:ns:netconf:base:1.0" xmlns:nc="urn:ietf:params:xml:ns:netconf:base:1.0">` +
+			`<list xmlns="urn:t"><key>one</key><value>-9223372036854775808</value></list>` +
+			`<list xmlns="urn:t" nc:operation="delete"><key>two</key></list>` +
+			`</config>`,
+		want: &RootStruct{
+			List: map[string]*ListStruct{
+				"one": {Key: ygot.String("one"), Value: ygot.Int64(-9223372036854775808)},
+				"two": {Key: ygot.String("two")},
+			},
+		},
+	}, {
+		desc: "elements in the namespaces of their modules",
+		in: `<data xmlns="urn:ietf:params:xml:ns:netconf:base:1.0">` +
+			`<container xmlns="urn:t"><config><int>1</int><strings xmlns="urn:ta">s</strings></config></container>` +
+			`<list xmlns="urn:t"><key>one</key></list>` +
+			`</data>`,
+		opts: []UnmarshalOpt{namespaces},
+		want: &RootStruct{
+			Container: &ContainerStruct{Int: ygot.Uint8(1), Strings: []string{"s"}},
+			List: map[string]*ListStruct{
+				"one": {Key: ygot.String("one")},
+			},
+		},
+	}, {
+		desc:    "augmented element in the namespace of its parent",
+		in:      `<data><container xmlns="urn:t"><config><strings>s</strings></config></container></data>`,
+		opts:    []UnmarshalOpt{namespaces},
+		wantErr: `element strings has namespace "urn:t", which is not the namespace of module ta`,
+	}, {
+		desc:    "element in an unknown namespace",
+		in:      `<data><list xmlns="urn:other"><key>one</key></list></data>`,
+		opts:    []UnmarshalOpt{namespaces},
+		wantErr: `element list has namespace "urn:other"`,
+	}, {
+		desc: "element in an unknown namespace ignored",
+		in:   `<data><list xmlns="urn:other"><key>one</key></list><list xmlns="urn:t"><key>two</key></list></data>`,
+		opts: []UnmarshalOpt{namespaces, &IgnoreExtraFields{}},
+		want: &RootStruct{
+			List: map[string]*ListStruct{
+				"two": {Key: ygot.String("two")},
+			},
+		},
+	}, {
+		desc:    "unknown element",
+		in:      `<data><container><fish/></container></data>`,
+		wantErr: "JSON contains unexpected field fish",
+	}, {
+		desc: "unknown element ignored",
+		in:   `<data><container><fish/></container></data>`,
+		opts: []UnmarshalOpt{&IgnoreExtraFields{}},
+		want: &RootStruct{Container: &ContainerStruct{}},
+	}, {
+		desc:    "out of range value",
+		in:      `<data><container><config><int>256</int></config></container></data>`,
+		wantErr: `cannot convert value "256" for schema /device/container/config/int`,
+	}, {
+		desc:    "invalid boolean",
+		in:      `<data><container><config><bool>1</bool></config></container></data>`,
+		wantErr: `cannot convert value "1" for schema /device/container/config/bool: invalid boolean value`,
+	}, {
+		desc:    "duplicate leaf",
+		in:      `<data><container><config><bool>true</bool><bool>false</bool></config></container></data>`,
+		wantErr: "duplicate element bool",
+	}, {
+		desc:    "malformed XML",
+		in:      `<data><container></data>`,
+		wantErr: "cannot parse XML",
+	}, {
+		desc:    "multiple top-level elements",
+		in:      `<data/><data/>`,
+		wantErr: "multiple top-level elements",
+	}, {
+		desc:    "no top-level element",
+		in:      ``,
+		wantErr: "no top-level element",
+	}}
+
+	for _, tt := range tests {
+		t.Run(tt.desc, func(t *testing.T) {
+			got := &RootStruct{}
+			err := UnmarshalXML(rootSchema, got, []byte(tt.in), tt.opts...)
+			if diff := errdiff.Substring(err, tt.wantErr); diff != "" {
+				t.Fatalf("UnmarshalXML: did not get expected error, %s", diff)
+			}
+			if err != nil {
+				return
+			}
+			if diff := cmp.Diff(tt.want, got); diff != "" {
+				t.Errorf("UnmarshalXML: did not get expected struct, diff(-want, +got):\n%s", diff)
+			}
+		})
+	}
+}
+
+func TestXMLLeafToJSON(t *testing.T) {
+	identityBase := &yang.Identity{
+		Name: "BASE",
+		Values: []*yang.Identity{
+			{Name: "DERIVED"},
+		},
+	}
+
+	unionSchema := &yang.Entry{
+		Name: "union",
+		Kind: yang.LeafEntry,
+		Type: &yang.YangType{
+			Kind: yang.Yunion,
+			Type: []*yang.YangType{
+				{Kind: yang.Yidentityref, IdentityBase: identityBase},
+				{Kind: yang.Yint16},
+				{Kind: yang.Ydecimal64},
+				{Kind: yang.Ystring},
+			},
+		},
+	}
+
+	identitySchema := &yang.Entry{
+		Name: "identity",
+		Kind: yang.LeafEntry,
+		Type: &yang.YangType{Kind: yang.Yidentityref, IdentityBase: identityBase},
+	}
+
+	tests := []struct {
+		desc         string
+		schema       *yang.Entry
+		in           string
+		inNamespaces map[string]string
+		want         interface{}
+		wantErr      string
+	}{{
+		desc:   "union identityref with prefix",
+		schema: unionSchema,
+		in:     "pfx:DERIVED",
+		want:   "DERIVED",
+	}, {
+		desc:         "identityref with prefix resolved to module",
+		schema:       identitySchema,
+		in:           "pfx:DERIVED",
+		inNamespaces: map[string]string{"idmod": "urn:p"},
+		want:         "idmod:DERIVED",
+	}, {
+		desc:         "identityref in default namespace resolved to module",
+		schema:       identitySchema,
+		in:           "DERIVED",
+		inNamespaces: map[string]string{"defmod": "urn:d"},
+		want:         "defmod:DERIVED",
+	}, {
+		desc:         "identityref with prefix of unknown module",
+		schema:       identitySchema,
+		in:           "pfx:DERIVED",
+		inNamespaces: map[string]string{"other": "urn:o"},
+		wantErr:      `namespace "urn:p" of identityref value DERIVED is not that of a known module`,
+	}, {
+		desc:    "identityref with undeclared prefix",
+		schema:  identitySchema,
+		in:      "nopfx:DERIVED",
+		wantErr: `undeclared namespace prefix "nopfx"`,
+	}, {
+		desc:   "union int16",
+		schema: unionSchema,
+		in:     "-12",
+		want:   float64(-12),
+	}, {
+		desc:   "union decimal64",
+		schema: unionSchema,
+		in:     "1.5",
+		want:   "1.5",
+	}, {
+		desc:   "union string",
+		schema: unionSchema,
+		in:     "pfx:OTHER",
+		want:   "pfx:OTHER",
+	}, {
+		desc: "uint64",
+		schema: &yang.Entry{
+			Name: "uint64",
+			Kind: yang.LeafEntry,
+			Type: &yang.YangType{Kind: yang.Yuint64},
+		},
+		in:   "18446744073709551615",
+		want: "18446744073709551615",
+	}, {
+		desc: "empty with content",
+		schema: &yang.Entry{
+			Name: "empty",
+			Kind: yang.LeafEntry,
+			Type: &yang.YangType{Kind: yang.Yempty},
+		},
+		in:      "x",
+		wantErr: "empty leaf has content",
+	}, {
+		desc: "unsupported type",
+		schema: &yang.Entry{
+			Name: "bits",
+			Kind: yang.LeafEntry,
+			Type: &yang.YangType{Kind: yang.Ybits},
+		},
+		in:      "x",
+		wantErr: "unsupported type bits",
+	}}
+
+	for _, tt := range tests {
+		t.Run(tt.desc, func(t *testing.T) {
+			c := &xmlConverter{namespaces: tt.inNamespaces}
+			if tt.inNamespaces != nil {
+				c.modules = map[string]string{}
+				for m, ns := range tt.inNamespaces {
+					c.modules[ns] = m
+				}
+			}
+			n := &xmlNode{text: tt.in, prefixes: map[string]string{"": "urn:d", "pfx": "urn:p"}}
+			got, err := c.leafToJSON(tt.schema, n)
+			if diff := errdiff.Substring(err, tt.wantErr); diff != "" {
+				t.Fatalf("leafToJSON(%q): did not get expected error, %s", tt.in, diff)
+			}
+			if diff := cmp.Diff(tt.want, got); diff != "" {
+				t.Errorf("leafToJSON(%q): did not get expected value, diff(-want, +got):\n%s", tt.in, diff)
+			}
+		})
+	}
+}
@@ -0,0 +1,352 @@
+// Copyright 2024 Google Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package ytypes
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/openconfig/goyang/pkg/yang"
+	"github.com/openconfig/ygot/util"
+	"gopkg.in/yaml.v3"
+
+	gpb "github.com/openconfig/gnmi/proto/gnmi"
+)
+
+// YAMLError is an error found when unmarshalling a YAML document, which
+// records the position within the document of the node that caused it.
+type YAMLError struct {
+	// Line and Column are the position of the node, starting from 1.
+	Line, Column int
+	// Err is the error found for the node.
+	Err error
+}
+
+// Error implements the error interface.
+func (e *YAMLError) Error() string {
+	return fmt.Sprintf("line %d, column %d: %v", e.Line, e.Column, e.Err)
+}
+
+// Unwrap returns the error found for the node.
+func (e *YAMLError) Unwrap() error {
+	return e.Err
+}
+
+// yamlErrorf returns a YAMLError for the node n with the supplied message.
+func yamlErrorf(n *yaml.Node, format string, args ...interface{}) error {
+	return &YAMLError{Line: n.Line, Column: n.Column, Err: fmt.Errorf(format, args...)}
+}
+
+// UnmarshalYAML unmarshals the YAML document in data, which is expected to
+// follow the RFC7951 mapping of YANG data as generated by ygot.MarshalYAML,
+// into the parent GoStruct, which must correspond to the supplied schema.
+//
+// Scalars are interpreted according to the YANG type of the leaf that they
+// are a value of rather than the type that YAML would infer, such that,
+// for example, a string leaf may be written without quotes regardless of
+// its contents, and int64 values may be written as YAML integers. Once the
+// document is unmarshalled, the value of each leaf is validated against its
+// schema. Errors found for a node within the document are returned as
+// *YAMLError, which specifies the position of the node.
+func UnmarshalYAML(schema *yang.Entry, parent interface{}, data []byte, opts ...UnmarshalOpt) error {
+	if schema == nil {
+		return fmt.Errorf("nil schema for parent type %T", parent)
+	}
+	var doc yaml.Node
+	if err := yaml.Unmarshal(data, &doc); err != nil {
+		return fmt.Errorf("cannot parse YAML: %v", err)
+	}
+	if doc.Kind == 0 {
+		// The document is empty.
+		return nil
+	}
+
+	d := &yamlDecoder{ignoreExtraFields: hasIgnoreExtraFields(opts)}
+	tree, err := d.toJSONTree(schema, doc.Content[0], &gpb.Path{})
+	if err != nil {
+		return err
+	}
+	if err := Unmarshal(schema, parent, tree, opts...); err != nil {
+		return err
+	}
+
+	var getOpts []GetNodeOpt
+	if hasPreferShadowPath(opts) {
+		getOpts = append(getOpts, &PreferShadowPath{})
+	}
+	var errs util.Errors
+	for _, l := range d.leaves {
+		nodes, err := GetNode(schema, parent, l.path, getOpts...)
+		if err != nil || len(nodes) != 1 {
+			// The leaf is not stored in the parent, for example, since
+			// it is a shadowed path.
+			continue
+		}
+		for _, err := range Validate(l.schema, nodes[0].Data) {
+			errs = append(errs, &YAMLError{Line: l.node.Line, Column: l.node.Column, Err: err})
+		}
+	}
+	if len(errs) != 0 {
+		return errs
+	}
+	return nil
+}
+
+// yamlLeaf is a leaf or leaf-list within a YAML document.
+type yamlLeaf struct {
+	// schema is the schema of the leaf or leaf-list.
+	schema *yang.Entry
+	// path is the data path of the leaf or leaf-list.
+	path *gpb.Path
+	// node is the YAML node containing its value.
+	node *yaml.Node
+}
+
+// yamlDecoder converts YAML documents to RFC7951 JSON trees.
+type yamlDecoder struct {
+	// ignoreExtraFields specifies that members that are not described by
+	// the schema should be ignored.
+	ignoreExtraFields bool
+	// leaves are the leaves and leaf-lists that have been converted, in
+	// the order that they are found within the document. Leaves within
+	// unkeyed lists, which cannot be addressed by a data path, are not
+	// included.
+	leaves []*yamlLeaf
+}
+
+// resolveAlias returns the node that n refers to if it is an alias.
+func resolveAlias(n *yaml.Node) *yaml.Node {
+	for n.Kind == yaml.AliasNode {
+		n = n.Alias
+	}
+	return n
+}
+
+// toJSONTree converts the YAML mapping n, which corresponds to the container
+// or list entry described by schema at the supplied data path, to an RFC7951
+// JSON tree. A nil path indicates that the node cannot be addressed.
+func (d *yamlDecoder) toJSONTree(schema *yang.Entry, n *yaml.Node, path *gpb.Path) (map[string]interface{}, error) {
+	n = resolveAlias(n)
+	if n.Kind != yaml.MappingNode {
+		return nil, yamlErrorf(n, "value for %s is not a mapping", schema.Path())
+	}
+	children := map[string]*yang.Entry{}
+	for _, ch := range util.FindFirstNonChoiceOrCase(schema) {
+		children[ch.Name] = ch
+	}
+
+	tree := map[string]interface{}{}
+	for i := 0; i+1 < len(n.Content); i += 2 {
+		k, v := n.Content[i], resolveAlias(n.Content[i+1])
+		if k.Kind != yaml.ScalarNode {
+			return nil, yamlErrorf(k, "invalid key for member of %s", schema.Path())
+		}
+		name := util.StripModulePrefix(k.Value)
+		if _, ok := tree[name]; ok {
+			return nil, yamlErrorf(k, "duplicate member %s in %s", name, schema.Path())
+		}
+		cs, ok := children[name]
+		if !ok {
+			if d.ignoreExtraFields {
+				continue
+			}
+			return nil, yamlErrorf(k, "%s is not a member of %s", name, schema.Path())
+		}
+
+		var cpath *gpb.Path
+		if path != nil {
+			cpath = &gpb.Path{Elem: append(append([]*gpb.PathElem{}, path.Elem...), &gpb.PathElem{Name: name})}
+		}
+
+		switch {
+		case cs.IsList():
+			if v.Kind != yaml.SequenceNode {
+				return nil, yamlErrorf(v, "value for list %s is not a sequence", cs.Path())
+			}
+			var l []interface{}
+			for _, e := range v.Content {
+				je, err := d.listEntryToJSON(cs, resolveAlias(e), cpath)
+				if err != nil {
+					return nil, err
+				}
+				l = append(l, je)
+			}
+			tree[name] = l
+		case cs.IsLeafList():
+			if v.Kind != yaml.SequenceNode {
+				return nil, yamlErrorf(v, "value for leaf-list %s is not a sequence", cs.Path())
+			}
+			var l []interface{}
+			for _, e := range v.Content {
+				je, err := yamlLeafToJSON(cs, resolveAlias(e))
+				if err != nil {
+					return nil, err
+				}
+				l = append(l, je)
+			}
+			tree[name] = l
+			d.addLeaf(cs, cpath, v)
+		case cs.IsLeaf():
+			jv, err := yamlLeafToJSON(cs, v)
+			if err != nil {
+				return nil, err
+			}
+			tree[name] = jv
+			d.addLeaf(cs, cpath, v)
+		default:
+			jv, err := d.toJSONTree(cs, v, cpath)
+			if err != nil {
+				return nil, err
+			}
+			tree[name] = jv
+		}
+	}
+	return tree, nil
+}
+
+// addLeaf records the leaf or leaf-list with the supplied schema, data path
+// and YAML node, if the path is known.
+func (d *yamlDecoder) addLeaf(schema *yang.Entry, path *gpb.Path, n *yaml.Node) {
+	if path != nil {
+		d.leaves = append(d.leaves, &yamlLeaf{schema: schema, path: path, node: n})
+	}
+}
+
+// listEntryToJSON converts the YAML mapping n, which is an entry of the list
+// described by schema at the supplied data path, to an RFC7951 JSON tree.
+func (d *yamlDecoder) listEntryToJSON(schema *yang.Entry, n *yaml.Node, path *gpb.Path) (map[string]interface{}, error) {
+	if schema.Key == "" || path == nil {
+		return d.toJSONTree(schema, n, nil)
+	}
+
+	// The data paths of the descendants of the entry share its path
+	// element, such that the keys of the entry can be set once they are
+	// known.
+	last := len(path.Elem) - 1
+	elem := &gpb.PathElem{Name: path.Elem[last].Name}
+	epath := &gpb.Path{Elem: append(append([]*gpb.PathElem{}, path.Elem[:last]...), elem)}
+	je, err := d.toJSONTree(schema, n, epath)
+	if err != nil {
+		return nil, err
+	}
+	elem.Key = map[string]string{}
+	for _, k := range strings.Fields(schema.Key) {
+		v, ok := je[k]
+		if !ok {
+			return nil, yamlErrorf(n, "missing key %s for entry of list %s", k, schema.Path())
+		}
+		ks := fmt.Sprintf("%v", v)
+		if kt := schema.Dir[k]; kt != nil && kt.Type != nil && kt.Type.Kind == yang.Yidentityref {
+			// Identityref keys are matched by name within data paths.
+			ks = util.StripModulePrefix(ks)
+		}
+		elem.Key[k] = ks
+	}
+	return je, nil
+}
+
+// yamlLeafToJSON converts the YAML value n of the leaf or leaf-list described
+// by schema to the corresponding RFC7951 JSON value.
+func yamlLeafToJSON(schema *yang.Entry, n *yaml.Node) (interface{}, error) {
+	s, err := util.ResolveIfLeafRef(schema)
+	if err != nil {
+		return nil, yamlErrorf(n, "%v", err)
+	}
+	if s.Type == nil {
+		return nil, yamlErrorf(n, "nil type for schema %s", schema.Path())
+	}
+	if s.Type.Kind == yang.Yempty && n.Kind == yaml.SequenceNode && len(n.Content) == 1 {
+		// Empty leaves may be written as [null], as in RFC7951.
+		n = n.Content[0]
+	}
+	if n.Kind != yaml.ScalarNode {
+		return nil, yamlErrorf(n, "value for %s is not a scalar", schema.Path())
+	}
+
+	if s.Type.Kind != yang.Yunion {
+		jv, err := yamlScalarToJSON(s.Type, n)
+		if err != nil {
+			return nil, yamlErrorf(n, "invalid value %q for %s: %v", n.Value, schema.Path(), err)
+		}
+		return jv, nil
+	}
+	for _, t := range util.FlattenedTypes(s.Type.Type) {
+		if jv, err := yamlScalarToJSON(t, n); err == nil {
+			return jv, nil
+		}
+	}
+	return nil, yamlErrorf(n, "value %q does not match any type of union %s", n.Value, schema.Path())
+}
+
+// yamlScalarToJSON converts the YAML scalar n of type t to the corresponding
+// RFC7951 JSON value.
+func yamlScalarToJSON(t *yang.YangType, n *yaml.Node) (interface{}, error) {
+	v := n.Value
+	switch t.Kind {
+	case yang.Ystring, yang.Ybinary, yang.Yidentityref:
+		return v, nil
+	case yang.Ybool:
+		switch v {
+		case "true", "True", "TRUE":
+			return true, nil
+		case "false", "False", "FALSE":
+			return false, nil
+		}
+		return nil, fmt.Errorf("not a boolean")
+	case yang.Yempty:
+		if n.Tag == "!!null" {
+			return []interface{}{nil}, nil
+		}
+		return nil, fmt.Errorf("empty leaf must have a null value")
+	case yang.Yint8, yang.Yint16, yang.Yint32, yang.Yint64:
+		i, err := strconv.ParseInt(v, 0, 64)
+		if err != nil {
+			return nil, err
+		}
+		if t.Kind == yang.Yint64 {
+			return strconv.FormatInt(i, 10), nil
+		}
+		f := float64(i)
+		if err := checkJSONFloat64Range(t.Kind, f); err != nil {
+			return nil, err
+		}
+		return f, nil
+	case yang.Yuint8, yang.Yuint16, yang.Yuint32, yang.Yuint64:
+		i, err := strconv.ParseUint(v, 0, 64)
+		if err != nil {
+			return nil, err
+		}
+		if t.Kind == yang.Yuint64 {
+			return strconv.FormatUint(i, 10), nil
+		}
+		f := float64(i)
+		if err := checkJSONFloat64Range(t.Kind, f); err != nil {
+			return nil, err
+		}
+		return f, nil
+	case yang.Ydecimal64:
+		if _, err := strconv.ParseFloat(v, 64); err != nil {
+			return nil, err
+		}
+		return v, nil
+	case yang.Yenum:
+		if t.Enum == nil || !t.Enum.IsDefined(v) {
+			return nil, fmt.Errorf("not a value of the enumeration")
+		}
+		return v, nil
+	}
+	return nil, fmt.Errorf("unsupported type %v", t.Kind)
+}
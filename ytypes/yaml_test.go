@@ -0,0 +1,368 @@
+// Copyright 2024 Google Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package ytypes
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/openconfig/gnmi/errdiff"
+	"github.com/openconfig/goyang/pkg/yang"
+	"github.com/openconfig/ygot/util"
+	"github.com/openconfig/ygot/ygot"
+	"gopkg.in/yaml.v3"
+)
+
+func TestUnmarshalYAML(t *testing.T) {
+	enumType := yang.NewEnumType()
+	enumType.Set("E_VALUE_FORTY_ONE", 41)
+	enumType.Set("E_VALUE_FORTY_TWO", 42)
+
+	rootSchema := &yang.Entry{
+		Name: "device",
+		Kind: yang.DirectoryEntry,
+		Annotation: map[string]interface{}{
+			"isFakeRoot": true,
+		},
+		Dir: map[string]*yang.Entry{
+			"container": {
+				Name: "container",
+				Kind: yang.DirectoryEntry,
+				Dir: map[string]*yang.Entry{
+					"config": {
+						Name: "config",
+						Kind: yang.DirectoryEntry,
+						Dir: map[string]*yang.Entry{
+							"name": {
+								Name: "name",
+								Kind: yang.LeafEntry,
+								Type: &yang.YangType{Kind: yang.Ystring, Pattern: []string{"^a.*"}},
+							},
+							"int": {
+								Name: "int",
+								Kind: yang.LeafEntry,
+								Type: &yang.YangType{Kind: yang.Yuint8},
+							},
+							"big": {
+								Name: "big",
+								Kind: yang.LeafEntry,
+								Type: &yang.YangType{Kind: yang.Yint64},
+							},
+							"bool": {
+								Name: "bool",
+								Kind: yang.LeafEntry,
+								Type: &yang.YangType{Kind: yang.Ybool},
+							},
+							"enum": {
+								Name: "enum",
+								Kind: yang.LeafEntry,
+								Type: &yang.YangType{Kind: yang.Yenum, Enum: enumType},
+							},
+							"empty": {
+								Name: "empty",
+								Kind: yang.LeafEntry,
+								Type: &yang.YangType{Kind: yang.Yempty},
+							},
+							"strings": {
+								Name:     "strings",
+								Kind:     yang.LeafEntry,
+								ListAttr: yang.NewDefaultListAttr(),
+								Type:     &yang.YangType{Kind: yang.Ystring},
+							},
+						},
+					},
+				},
+			},
+			"list": {
+				Name:     "list",
+				Kind:     yang.DirectoryEntry,
+				ListAttr: yang.NewDefaultListAttr(),
+				Key:      "key",
+				Config:   yang.TSTrue,
+				Dir: map[string]*yang.Entry{
+					"key": {
+						Name: "key",
+						Kind: yang.LeafEntry,
+						Type: &yang.YangType{Kind: yang.Ystring},
+					},
+					"value": {
+						Name: "value",
+						Kind: yang.LeafEntry,
+						Type: &yang.YangType{Kind: yang.Ystring, Pattern: []string{"^v.*"}},
+					},
+				},
+			},
+		},
+	}
+	populateParentField(nil, rootSchema)
+
+	type ContainerStruct struct {
+		Name    *string   `path:"config/name"`
+		Int     *uint8    `path:"config/int"`
+		Big     *int64    `path:"config/big"`
+		Bool    *bool     `path:"config/bool"`
+		Enum    EnumType  `path:"config/enum"`
+		Empty   YANGEmpty `path:"config/empty"`
+		Strings []string  `path:"config/strings"`
+	}
+
+	type ListStruct struct {
+		Key   *string `path:"key"`
+		Value *string `path:"value"`
+	}
+
+	type RootStruct struct {
+		Container *ContainerStruct       `path:"container"`
+		List      map[string]*ListStruct `path:"list"`
+	}
+
+	tests := []struct {
+		desc       string
+		in         string
+		opts       []UnmarshalOpt
+		want       *RootStruct
+		wantErr    string
+		wantLine   int
+		wantColumn int
+	}{{
+		desc: "empty document",
+		in:   "",
+		want: &RootStruct{},
+	}, {
+		desc: "leaves of each type",
+		in: `
+t:container:
+  config:
+    name: a123
+    int: 0x2a
+    big: -9007199254740993
+    bool: true
+    enum: E_VALUE_FORTY_TWO
+    empty: [null]
+    strings: [yes, 42, "x"]
+t:list:
+  - key: 1
+    value: v
+  - key: "on"
+`,
+		want: &RootStruct{
+			Container: &ContainerStruct{
+				Name:    ygot.String("a123"),
+				Int:     ygot.Uint8(42),
+				Big:     ygot.Int64(-9007199254740993),
+				Bool:    ygot.Bool(true),
+				Enum:    42,
+				Empty:   true,
+				Strings: []string{"yes", "42", "x"},
+			},
+			List: map[string]*ListStruct{
+				"1":  {Key: ygot.String("1"), Value: ygot.String("v")},
+				"on": {Key: ygot.String("on")},
+			},
+		},
+	}, {
+		desc: "anchors and aliases",
+		in: `
+container:
+  config: &c
+    name: abc
+    empty: ~
+list:
+  - key: k
+    value: *c
+`,
+		wantErr: "value for /device/list/value is not a scalar",
+		// Errors for aliases are reported at the anchored node.
+		wantLine:   3,
+		wantColumn: 11,
+	}, {
+		desc: "unknown field",
+		in: `
+container:
+  fish: chips
+`,
+		wantErr:    "fish is not a member of /device/container",
+		wantLine:   3,
+		wantColumn: 3,
+	}, {
+		desc: "unknown field ignored",
+		in: `
+container:
+  config:
+    int: 1
+  fish: chips
+`,
+		opts: []UnmarshalOpt{&IgnoreExtraFields{}},
+		want: &RootStruct{Container: &ContainerStruct{Int: ygot.Uint8(1)}},
+	}, {
+		desc: "out of range integer",
+		in: `
+container:
+  config:
+    int: 256
+`,
+		wantErr:    "invalid value \"256\" for /device/container/config/int",
+		wantLine:   4,
+		wantColumn: 10,
+	}, {
+		desc: "invalid boolean",
+		in: `
+container:
+  config:
+    bool: yes
+`,
+		wantErr:    "not a boolean",
+		wantLine:   4,
+		wantColumn: 11,
+	}, {
+		desc: "invalid enumeration value",
+		in: `
+container:
+  config:
+    enum: E_VALUE_FORTY
+`,
+		wantErr:    "not a value of the enumeration",
+		wantLine:   4,
+		wantColumn: 11,
+	}, {
+		desc: "list is not a sequence",
+		in: `
+list:
+  key: k
+`,
+		wantErr:    "value for list /device/list is not a sequence",
+		wantLine:   3,
+		wantColumn: 3,
+	}, {
+		desc: "missing list key",
+		in: `
+list:
+  - value: v
+`,
+		wantErr:    "missing key key for entry of list /device/list",
+		wantLine:   3,
+		wantColumn: 5,
+	}, {
+		desc: "validation error",
+		in: `
+container:
+  config:
+    name: bcd
+`,
+		wantErr:    "does not match regular expression pattern",
+		wantLine:   4,
+		wantColumn: 11,
+	}, {
+		desc: "validation error within list",
+		in: `
+list:
+  - key: a
+    value: v
+  - key: b
+    value: w
+`,
+		wantErr:    "does not match regular expression pattern",
+		wantLine:   6,
+		wantColumn: 12,
+	}}
+
+	for _, tt := range tests {
+		t.Run(tt.desc, func(t *testing.T) {
+			got := &RootStruct{}
+			err := UnmarshalYAML(rootSchema, got, []byte(tt.in), tt.opts...)
+			if diff := errdiff.Substring(err, tt.wantErr); diff != "" {
+				t.Fatalf("UnmarshalYAML: did not get expected error, %s", diff)
+			}
+			if err != nil {
+				var ye *YAMLError
+				if errs, ok := err.(util.Errors); ok {
+					err = errs[0]
+				}
+				if !errors.As(err, &ye) {
+					t.Fatalf("UnmarshalYAML: got error %v of type %T, want *YAMLError", err, err)
+				}
+				if ye.Line != tt.wantLine || ye.Column != tt.wantColumn {
+					t.Errorf("UnmarshalYAML: got error at line %d, column %d, want line %d, column %d", ye.Line, ye.Column, tt.wantLine, tt.wantColumn)
+				}
+				return
+			}
+			if diff := cmp.Diff(tt.want, got); diff != "" {
+				t.Errorf("UnmarshalYAML: did not get expected struct, diff(-want, +got):\n%s", diff)
+			}
+		})
+	}
+}
+
+func TestYAMLScalarToJSON(t *testing.T) {
+	tests := []struct {
+		desc    string
+		inType  *yang.YangType
+		in      string
+		want    interface{}
+		wantErr string
+	}{{
+		desc:   "int32",
+		inType: &yang.YangType{Kind: yang.Yint32},
+		in:     "-42",
+		want:   float64(-42),
+	}, {
+		desc:   "uint64",
+		inType: &yang.YangType{Kind: yang.Yuint64},
+		in:     "18446744073709551615",
+		want:   "18446744073709551615",
+	}, {
+		desc:   "decimal64",
+		inType: &yang.YangType{Kind: yang.Ydecimal64},
+		in:     "273.15",
+		want:   "273.15",
+	}, {
+		desc:    "invalid decimal64",
+		inType:  &yang.YangType{Kind: yang.Ydecimal64},
+		in:      "abc",
+		wantErr: "invalid syntax",
+	}, {
+		desc:   "identityref",
+		inType: &yang.YangType{Kind: yang.Yidentityref},
+		in:     "mod:ID",
+		want:   "mod:ID",
+	}, {
+		desc:    "empty with value",
+		inType:  &yang.YangType{Kind: yang.Yempty},
+		in:      "x",
+		wantErr: "empty leaf must have a null value",
+	}, {
+		desc:    "negative uint8",
+		inType:  &yang.YangType{Kind: yang.Yuint8},
+		in:      "-1",
+		wantErr: "invalid syntax",
+	}}
+
+	for _, tt := range tests {
+		t.Run(tt.desc, func(t *testing.T) {
+			var n yaml.Node
+			if err := yaml.Unmarshal([]byte(tt.in), &n); err != nil {
+				t.Fatalf("cannot parse YAML input: %v", err)
+			}
+			got, err := yamlScalarToJSON(tt.inType, n.Content[0])
+			if diff := errdiff.Substring(err, tt.wantErr); diff != "" {
+				t.Fatalf("yamlScalarToJSON: did not get expected error, %s", diff)
+			}
+			if diff := cmp.Diff(tt.want, got); diff != "" {
+				t.Errorf("yamlScalarToJSON: did not get expected value, diff(-want, +got):\n%s", diff)
+			}
+		})
+	}
+}